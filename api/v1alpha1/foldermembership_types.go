@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MembershipPhase describes whether a FolderMembership has been applied to its FolderTree
+type MembershipPhase string
+
+const (
+	// MembershipPhasePending means the target folder does not (yet) accept memberships
+	MembershipPhasePending MembershipPhase = "Pending"
+
+	// MembershipPhaseApproved means the namespace is part of the target folder
+	MembershipPhaseApproved MembershipPhase = "Approved"
+
+	// MembershipPhaseRejected means the membership conflicts with existing namespace assignments
+	MembershipPhaseRejected MembershipPhase = "Rejected"
+)
+
+// FolderMembershipSpec defines which folder the namespace of the FolderMembership wants to join.
+type FolderMembershipSpec struct {
+	// TreeName is the name of the FolderTree containing the folder
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TreeName string `json:"treeName"`
+
+	// FolderName is the name of the folder the namespace wants to join
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	FolderName string `json:"folderName"`
+}
+
+// FolderMembershipStatus defines the observed state of FolderMembership.
+type FolderMembershipStatus struct {
+	// Phase is the current state of the membership request
+	// +optional
+	Phase MembershipPhase `json:"phase,omitempty"`
+
+	// Message explains the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the FolderMembership that was last evaluated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Tree",type=string,JSONPath=`.spec.treeName`
+// +kubebuilder:printcolumn:name="Folder",type=string,JSONPath=`.spec.folderName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+
+// FolderMembership is the Schema for the foldermemberships API.
+// A FolderMembership lets the owner of a namespace request that the namespace joins a
+// folder of a FolderTree. The FolderTree stays authoritative: the controller only applies
+// memberships for folders that set acceptMemberships, and never for namespaces that are
+// already assigned elsewhere. The membership's own namespace is the namespace that joins.
+type FolderMembership struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the requested folder
+	// +required
+	Spec FolderMembershipSpec `json:"spec"`
+
+	// status defines the observed state of FolderMembership
+	// +optional
+	Status FolderMembershipStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderMembershipList contains a list of FolderMembership
+type FolderMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FolderMembership `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FolderMembership{}, &FolderMembershipList{})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyRule identifies a webhook policy rule that can be excepted by a FolderPolicyException
+// +kubebuilder:validation:Enum=DeniedClusterRole;WildcardSubject;ClusterRoleNotAllowed
+type PolicyRule string
+
+const (
+	// PolicyRuleDeniedClusterRole rejects templates referencing a ClusterRole on the configured denylist
+	PolicyRuleDeniedClusterRole PolicyRule = "DeniedClusterRole"
+
+	// PolicyRuleWildcardSubject rejects templates binding to wildcard subjects such as system:authenticated
+	PolicyRuleWildcardSubject PolicyRule = "WildcardSubject"
+
+	// PolicyRuleClusterRoleNotAllowed rejects templates referencing a ClusterRole missing from the
+	// configured ClusterRole allowlist
+	PolicyRuleClusterRoleNotAllowed PolicyRule = "ClusterRoleNotAllowed"
+)
+
+// FolderPolicyExceptionSpec defines which policy rule is excepted and for which part of a FolderTree.
+type FolderPolicyExceptionSpec struct {
+	// TreeName is the name of the FolderTree the exception applies to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TreeName string `json:"treeName"`
+
+	// FolderName limits the exception to a single folder. If empty, all folders of the tree are covered.
+	// +optional
+	FolderName string `json:"folderName,omitempty"`
+
+	// TemplateName limits the exception to a single role binding template. If empty, all templates are covered.
+	// +optional
+	TemplateName string `json:"templateName,omitempty"`
+
+	// Rule is the policy rule being excepted
+	// +kubebuilder:validation:Required
+	Rule PolicyRule `json:"rule"`
+
+	// Justification explains why the exception is needed (e.g. a ticket reference)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+
+	// ExpiresAt is when the exception stops being honored by the webhook
+	// +kubebuilder:validation:Required
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Tree",type=string,JSONPath=`.spec.treeName`
+// +kubebuilder:printcolumn:name="Rule",type=string,JSONPath=`.spec.rule`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.spec.expiresAt`
+
+// FolderPolicyException is the Schema for the folderpolicyexceptions API.
+// A FolderPolicyException allows a FolderTree (or one of its folders or templates) to
+// violate a specific webhook policy rule until the exception expires. Exceptions are
+// consulted by the validating webhook before rejecting a FolderTree, providing an
+// auditable escape hatch with a recorded justification.
+type FolderPolicyException struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the excepted policy rule and its scope
+	// +required
+	Spec FolderPolicyExceptionSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderPolicyExceptionList contains a list of FolderPolicyException
+type FolderPolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FolderPolicyException `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FolderPolicyException{}, &FolderPolicyExceptionList{})
+}
@@ -17,6 +17,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,6 +32,47 @@ const (
 
 	// ConditionTypeProcessingFailed indicates that processing the FolderTree failed
 	ConditionTypeProcessingFailed = "ProcessingFailed"
+
+	// ConditionTypePartiallyApplied indicates that some RoleBinding operations failed while the rest were applied
+	ConditionTypePartiallyApplied = "PartiallyApplied"
+
+	// ConditionTypeRolloutInProgress indicates that RoleBinding changes are being rolled out in waves
+	ConditionTypeRolloutInProgress = "RolloutInProgress"
+
+	// ConditionTypeProgressing indicates that more RoleBinding operations are being applied than the
+	// write rate limit of the controller allows at once, so applying them takes a while
+	ConditionTypeProgressing = "Progressing"
+
+	// ConditionTypeDrifted indicates that managed RoleBindings were changed out-of-band and,
+	// because of the Warn drift policy, have not been reverted
+	ConditionTypeDrifted = "Drifted"
+
+	// ConditionTypeSuspended indicates that reconciliation is paused by spec.suspend
+	ConditionTypeSuspended = "Suspended"
+
+	// ConditionTypeNamespaceMissing indicates that namespaces listed by folders do not exist,
+	// for example because they were deleted after being added to the FolderTree
+	ConditionTypeNamespaceMissing = "NamespaceMissing"
+
+	// ConditionTypeSuperseded indicates that namespaces of the FolderTree are also assigned by a
+	// FolderTree of higher priority, which manages them instead
+	ConditionTypeSuperseded = "Superseded"
+
+	// ConditionTypeSubjectMappingMissing indicates that role binding templates reference
+	// SubjectMappings that do not exist, so they bind none of the mapped subjects
+	ConditionTypeSubjectMappingMissing = "SubjectMappingMissing"
+
+	// ConditionTypeSubjectUnresolved indicates that RoleBindings bind User or Group subjects that the
+	// subject verifier of the controller could not resolve, so they likely grant no access
+	ConditionTypeSubjectUnresolved = "SubjectUnresolved"
+
+	// ConditionTypeConflict indicates that RoleBindings could not be created because RoleBindings of
+	// the same name that the FolderTree does not manage exist in their namespaces
+	ConditionTypeConflict = "Conflict"
+
+	// ConditionTypeStatusTruncated indicates that status lists were truncated or dropped to keep the
+	// FolderTree within the status size limits
+	ConditionTypeStatusTruncated = "StatusTruncated"
 )
 
 // FolderTree API implementation for hierarchical namespace organization with RBAC.
@@ -52,43 +98,169 @@ type TreeNode struct {
 	// Name is the unique identifier for this tree node
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="name must be a valid DNS-1123 label"
 	Name string `json:"name"`
 
 	// Subfolders is a list of child tree nodes
 	// +optional
 	// +kubebuilder:validation:Schemaless
 	// NOTE: Due to limitations in OpenAPI v3 schema generation for recursive types,
-	// unknown fields in subfolders will be accepted by the API server but ignored
-	// by the controller. This is a known limitation, not a feature.
+	// unknown fields in subfolders are accepted by the API server. The validating
+	// webhook rejects them on the raw object, since the controller would ignore them.
 	Subfolders []TreeNode `json:"subfolders,omitempty"`
+
+	// InheritOnly restricts the propagating role binding templates this node inherits from its
+	// parent to the named ones, for the node and its descendants. Empty inherits all of them.
+	// Global role binding templates are always inherited.
+	// +optional
+	// +listType=set
+	InheritOnly []string `json:"inheritOnly,omitempty"`
+
+	// Exclude lists propagating role binding templates this node does not inherit from its
+	// parent, for the node and its descendants. Global role binding templates cannot be excluded.
+	// +optional
+	// +listType=set
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Inherits reports whether the edge from its parent to this node passes on a propagating
+// role binding template of an ancestor folder, according to InheritOnly and Exclude
+func (n *TreeNode) Inherits(templateName string) bool {
+	if len(n.InheritOnly) > 0 && !slices.Contains(n.InheritOnly, templateName) {
+		return false
+	}
+	return !slices.Contains(n.Exclude, templateName)
 }
 
 // RoleBindingTemplate defines an inline RBAC template for a folder.
 // RoleBindingTemplates contain the subjects and roleRef needed to create RoleBindings.
+// +kubebuilder:validation:XValidation:rule="!has(self.propagateDepth) || !has(self.propagate) || self.propagate",message="propagateDepth cannot be set when propagate is false"
 type RoleBindingTemplate struct {
 	// Name is the unique identifier for this role binding template
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="name must be a valid DNS-1123 label"
 	Name string `json:"name"`
 
 	// Subjects holds references to the objects the role applies to.
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:MinItems=1
-	Subjects []rbacv1.Subject `json:"subjects"`
+	// Subject names and namespaces may use the template variables {{ .tree.name }},
+	// {{ .folder.name }} (the folder of the target namespace) and {{ .namespace }}.
+	// Templates without subjects or subjectRefs use spec.defaults.subjects, which must then be set.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	// SubjectRefs names cluster-scoped SubjectMappings whose subjects are bound in addition to
+	// Subjects, so that templates can refer to logical teams instead of identity provider groups.
+	// A reference to a SubjectMapping that does not exist binds no subjects until it is created.
+	// +optional
+	SubjectRefs []string `json:"subjectRefs,omitempty"`
+
+	// ServiceAccountSelector binds the ServiceAccounts with matching labels in the namespaces of
+	// the FolderTree, in addition to Subjects and SubjectRefs. With subjectNamespaceMode Target,
+	// only the matching ServiceAccounts of the namespace of each RoleBinding are bound.
+	// ServiceAccounts are resolved when the FolderTree is reconciled, which happens whenever
+	// ServiceAccounts are created, deleted or relabeled in its namespaces.
+	// +optional
+	ServiceAccountSelector *metav1.LabelSelector `json:"serviceAccountSelector,omitempty"`
 
 	// RoleRef can only reference a ClusterRole in the global namespace.
 	// If the RoleRef cannot be resolved, the Authorizer must return an error.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self.apiGroup == 'rbac.authorization.k8s.io'",message="roleRef.apiGroup must be 'rbac.authorization.k8s.io'"
 	RoleRef rbacv1.RoleRef `json:"roleRef"`
 
 	// Propagate determines whether this role binding template should be inherited
 	// by child folders in the hierarchy. If true, child folders will inherit this
-	// template. If false or unset (default), this template applies only to the current folder.
+	// template. If false, this template applies only to the current folder.
+	// When unset, spec.defaults.propagate is used, which defaults to false.
+	// +optional
+	Propagate *bool `json:"propagate,omitempty"`
+
+	// PropagateDepth limits how many levels below its folder the template is inherited, e.g. 1 for
+	// direct children only, 2 for children and grandchildren. Setting it implies propagate, which
+	// may then be left unset but not false. When unset, propagating templates are inherited by all
+	// descendants.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PropagateDepth *int32 `json:"propagateDepth,omitempty"`
+
+	// SubjectNamespaceMode determines the namespace of ServiceAccount subjects.
+	// Fixed (default) uses the namespace set on each subject. Target sets it to the namespace
+	// of every generated RoleBinding, binding the ServiceAccount of that name in each target namespace;
+	// ServiceAccount subjects must then leave their namespace empty.
+	// +optional
+	SubjectNamespaceMode SubjectNamespaceMode `json:"subjectNamespaceMode,omitempty"`
+
+	// ExpiresAt is the time the template stops granting access, for temporary access.
+	// Once it has passed, the controller deletes the template's RoleBindings and creates no new ones.
+	// Templates that have already expired cannot be added.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Description is the business justification for the access the template grants. It is
+	// annotated onto every generated RoleBinding, so that auditors find it on the RoleBinding itself.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Reference points to the approval of the access, e.g. a ticket ID or URL. Like Description,
+	// it is annotated onto every generated RoleBinding.
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Reference string `json:"reference,omitempty"`
+}
+
+// NetworkPolicyTemplate defines a NetworkPolicy that is created in every namespace of a folder
+type NetworkPolicyTemplate struct {
+	// Name is the unique identifier for this network policy template within its folder.
+	// A subfolder defining a template of the same name overrides an inherited one.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Spec is the specification of the generated NetworkPolicies
+	// +kubebuilder:validation:Required
+	Spec networkingv1.NetworkPolicySpec `json:"spec"`
+
+	// Propagate determines whether this template is inherited by child folders, like the field
+	// of role binding templates. When unset, spec.defaults.propagate is used.
 	// +optional
-	// +kubebuilder:default=false
 	Propagate *bool `json:"propagate,omitempty"`
 }
 
+// ResourceQuotaTemplate defines a ResourceQuota that is created in every namespace of a folder
+type ResourceQuotaTemplate struct {
+	// Name is the unique identifier for this resource quota template within its folder.
+	// A subfolder defining a template of the same name overrides an inherited one, e.g. to
+	// raise or lower a quota set for a whole branch of the tree.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Spec is the specification of the generated ResourceQuotas
+	// +kubebuilder:validation:Required
+	Spec corev1.ResourceQuotaSpec `json:"spec"`
+
+	// Propagate determines whether this template is inherited by child folders, like the field
+	// of role binding templates. When unset, spec.defaults.propagate is used.
+	// +optional
+	Propagate *bool `json:"propagate,omitempty"`
+}
+
+// SubjectNamespaceMode controls how the namespace of ServiceAccount subjects is determined
+// +kubebuilder:validation:Enum=Fixed;Target
+type SubjectNamespaceMode string
+
+const (
+	// SubjectNamespaceModeFixed uses the namespace given in each ServiceAccount subject
+	SubjectNamespaceModeFixed SubjectNamespaceMode = "Fixed"
+
+	// SubjectNamespaceModeTarget uses the namespace of each generated RoleBinding for ServiceAccount subjects
+	SubjectNamespaceModeTarget SubjectNamespaceMode = "Target"
+)
+
 // Folder represents folder data without hierarchical structure.
 // Folders contain the actual role binding templates and namespace assignments.
 // Folder names are referenced by TreeNode names to establish relationships.
@@ -96,15 +268,200 @@ type Folder struct {
 	// Name is the unique identifier for this folder
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="name must be a valid DNS-1123 label"
 	Name string `json:"name"`
 
 	// RoleBindingTemplates is a list of inline RBAC templates that apply to this folder
 	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=200
 	RoleBindingTemplates []RoleBindingTemplate `json:"roleBindingTemplates,omitempty"`
 
-	// Namespaces is a list of Kubernetes namespaces that belong to this folder
+	// NetworkPolicyTemplates is a list of NetworkPolicies created in the namespaces of this folder
+	// +optional
+	NetworkPolicyTemplates []NetworkPolicyTemplate `json:"networkPolicyTemplates,omitempty"`
+
+	// ResourceQuotaTemplates is a list of ResourceQuotas created in the namespaces of this folder
+	// +optional
+	ResourceQuotaTemplates []ResourceQuotaTemplate `json:"resourceQuotaTemplates,omitempty"`
+
+	// Namespaces is a list of Kubernetes namespaces that belong to this folder. In JSON, an entry is
+	// either the namespace name or an object with the name and the templateOverrides of the namespace,
+	// which are kept in NamespaceOverrides.
 	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=500
 	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceOverrides replaces parts of the role binding templates in effect in single namespaces
+	// of this folder, without detaching them from the folder. Each entry names one of Namespaces,
+	// and is written as the object form of its entry in namespaces.
+	NamespaceOverrides []NamespaceOverride `json:"-"`
+
+	// NamespacePattern adds every namespace whose name matches this regular expression (RE2 syntax,
+	// e.g. "^team-a-.*$") to the folder, in addition to Namespaces. Namespaces listed by a folder or
+	// assigned to another FolderTree are not matched, and a namespace matching the patterns of
+	// several folders joins the first of them.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	NamespacePattern string `json:"namespacePattern,omitempty"`
+
+	// AcceptMemberships allows namespace owners to add their namespaces to this folder
+	// by creating a FolderMembership. Defaults to false.
+	// +optional
+	AcceptMemberships bool `json:"acceptMemberships,omitempty"`
+
+	// PatchNamespaces lists the namespaces whose FolderTreePatches may add role binding templates
+	// and namespaces to this folder, so that e.g. team leads can manage their folder without write
+	// access to the FolderTree. Empty accepts no FolderTreePatches.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=20
+	PatchNamespaces []string `json:"patchNamespaces,omitempty"`
+
+	// BlockInherited lists names of propagating templates from ancestor folders that this folder
+	// opts out of. Blocked templates apply neither to this folder's namespaces nor to its
+	// descendants. Global role binding templates cannot be blocked.
+	// +optional
+	BlockInherited []string `json:"blockInherited,omitempty"`
+
+	// InheritNamespaces makes this folder a namespace group: its namespaces also receive the role
+	// binding templates of all its descendant folders, whether they propagate or not. Templates
+	// only flow up to the folder's own namespaces, never to its ancestors or siblings.
+	// +optional
+	InheritNamespaces bool `json:"inheritNamespaces,omitempty"`
+
+	// LabelsToApply are stamped onto every namespace that belongs directly to this folder,
+	// either listed in Namespaces or joined through an approved FolderMembership. Labels are
+	// removed again when the namespace leaves the folder.
+	// +optional
+	LabelsToApply map[string]string `json:"labelsToApply,omitempty"`
+
+	// AnnotationsToApply are stamped onto every namespace that belongs directly to this folder,
+	// with the same lifecycle as LabelsToApply.
+	// +optional
+	AnnotationsToApply map[string]string `json:"annotationsToApply,omitempty"`
+
+	// Description is a human readable description of the folder. Like Owner and Contact, it is
+	// annotated onto the namespaces of the folder, together with the folder name, with the same
+	// lifecycle as AnnotationsToApply.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Owner is the team or person owning the folder, e.g. team-web
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Contact is where to reach the owner of the folder, e.g. an email address or chat channel
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Contact string `json:"contact,omitempty"`
+}
+
+// NamespaceOverride overrides role binding templates in a single namespace of a folder. It is the
+// object form of an entry of the folder's namespaces.
+type NamespaceOverride struct {
+	// Name is the name of the namespace
+	Name string `json:"name"`
+
+	// TemplateOverrides replaces parts of the role binding templates in effect in this namespace,
+	// keyed by template name. The templates keep applying to the other namespaces of the folder
+	// and its descendants unchanged.
+	// +optional
+	TemplateOverrides map[string]TemplateOverride `json:"templateOverrides,omitempty"`
+}
+
+// TemplateOverride replaces parts of a role binding template for a single namespace
+type TemplateOverride struct {
+	// Subjects replace the subjects of the template
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Subjects []rbacv1.Subject `json:"subjects"`
+}
+
+// folderJSON is the JSON form of Folder without its methods, whose namespaces are written by Folder
+type folderJSON Folder
+
+// UnmarshalJSON accepts namespace entries that are either the namespace name or an object with the
+// name and templateOverrides of the namespace
+func (f *Folder) UnmarshalJSON(data []byte) error {
+	*f = Folder{}
+	object := struct {
+		*folderJSON
+		Namespaces []json.RawMessage `json:"namespaces,omitempty"`
+	}{folderJSON: (*folderJSON)(f)}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	for _, entry := range object.Namespaces {
+		var override NamespaceOverride
+		if len(entry) > 0 && entry[0] == '"' {
+			if err := json.Unmarshal(entry, &override.Name); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(entry, &override); err != nil {
+			return err
+		}
+		f.Namespaces = append(f.Namespaces, override.Name)
+		if len(override.TemplateOverrides) > 0 {
+			f.NamespaceOverrides = append(f.NamespaceOverrides, override)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes namespaces with template overrides as objects and all others as plain names
+func (f Folder) MarshalJSON() ([]byte, error) {
+	var namespaces []any
+	overridden := make(map[string]bool)
+	for _, namespace := range f.Namespaces {
+		if overrides := f.TemplateOverridesFor(namespace); len(overrides) > 0 && !overridden[namespace] {
+			overridden[namespace] = true
+			namespaces = append(namespaces, NamespaceOverride{Name: namespace, TemplateOverrides: overrides})
+			continue
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	// Overrides of namespaces the folder does not list, which validation rejects, are kept too
+	for _, override := range f.NamespaceOverrides {
+		if !slices.Contains(f.Namespaces, override.Name) {
+			namespaces = append(namespaces, override)
+		}
+	}
+	return json.Marshal(struct {
+		folderJSON
+		Namespaces []any `json:"namespaces,omitempty"`
+	}{folderJSON(f), namespaces})
+}
+
+// TemplateOverridesFor returns the template overrides of a namespace of the folder, or nil when it has none
+func (f *Folder) TemplateOverridesFor(namespace string) map[string]TemplateOverride {
+	for _, override := range f.NamespaceOverrides {
+		if override.Name == namespace {
+			return override.TemplateOverrides
+		}
+	}
+	return nil
+}
+
+// NamespaceEntryIndex returns the index of the entry MarshalJSON writes for the namespace override
+// at the given index, so that errors about the override point at its entry in namespaces
+func (f *Folder) NamespaceEntryIndex(override int) int {
+	if i := slices.Index(f.Namespaces, f.NamespaceOverrides[override].Name); i >= 0 {
+		return i
+	}
+	index := len(f.Namespaces)
+	for _, other := range f.NamespaceOverrides[:override] {
+		if !slices.Contains(f.Namespaces, other.Name) {
+			index++
+		}
+	}
+	return index
 }
 
 // FolderTreeSpec defines the desired state of FolderTree using a split structure approach.
@@ -116,11 +473,164 @@ type FolderTreeSpec struct {
 	// +optional
 	Tree *TreeNode `json:"tree,omitempty"`
 
+	// Trees defines additional independent hierarchies, each with its own root.
+	// They behave exactly like Tree, which is kept for compatibility; both may be set.
+	// Node names must be unique across all hierarchies.
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	Trees []TreeNode `json:"trees,omitempty"`
+
 	// Folders is a flat list of folder data containing inline role binding templates and namespace assignments.
-	// Folders can exist independently (standalone) or be referenced by the Tree.
-	// Folder names must be unique within a FolderTree.
+	// Folders can exist independently (standalone) or be referenced by the Tree or Trees.
+	// Folder names must be unique within a FolderTree. Folders are merged by name, so server-side
+	// apply can change one folder without owning the others.
 	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=100
 	Folders []Folder `json:"folders,omitempty"`
+
+	// GlobalRoleBindingTemplates are applied to every namespace of the FolderTree, in tree
+	// and standalone folders alike, as if inherited from above the root folder.
+	// The propagate field has no effect on global templates.
+	// Template names must not be reused by folder templates.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=200
+	GlobalRoleBindingTemplates []RoleBindingTemplate `json:"globalRoleBindingTemplates,omitempty"`
+
+	// RolloutStrategy limits how many namespaces receive RoleBinding changes per reconcile.
+	// When unset, all required operations are applied in a single pass.
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// DriftPolicy controls how out-of-band edits to managed RoleBindings are handled.
+	// Enforce (default) reverts them, Warn leaves them in place and reports them in the
+	// Drifted condition, and Ignore leaves them in place silently.
+	// Deleted RoleBindings are recreated under every policy.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// ReplacementPolicy controls how RoleBindings are replaced when the roleRef of their template
+	// changes, which Kubernetes does not allow to update in place. CreateBeforeDelete (default)
+	// creates the replacement under a name suffixed with a hash of the new roleRef before deleting
+	// the old RoleBinding, so subjects never lose access in between. DeleteBeforeCreate keeps the
+	// name and deletes the old RoleBinding first, leaving a short window without access.
+	// +optional
+	ReplacementPolicy ReplacementPolicy `json:"replacementPolicy,omitempty"`
+
+	// ExcludedNamespaces never receive RoleBindings from this FolderTree, even if a folder lists them.
+	// Use it to protect system namespaces such as kube-system from typos.
+	// +optional
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// Suspend pauses reconciliation: while true, the controller leaves the managed RoleBindings
+	// as they are, including out-of-band edits, and reports the Suspended condition.
+	// Edits are still validated by the webhook and applied once reconciliation resumes.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// PruneMissingNamespaces makes the controller remove namespaces that no longer exist from the
+	// folders of the FolderTree. When false, they are kept and reported in the NamespaceMissing
+	// condition, and RoleBindings are created again if a namespace of the same name is recreated.
+	// +optional
+	PruneMissingNamespaces bool `json:"pruneMissingNamespaces,omitempty"`
+
+	// Priority decides which FolderTree manages a namespace assigned by several FolderTrees, which
+	// the webhook only admits when the controller runs with --allow-namespace-overlap.
+	// The highest priority wins; ties go to the oldest FolderTree, then to the lexically smallest name.
+	// The other FolderTrees leave the namespace alone and report it in the Superseded condition.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Defaults are used by the role binding templates of the FolderTree that leave the
+	// corresponding fields unset.
+	// +optional
+	Defaults *FolderTreeDefaults `json:"defaults,omitempty"`
+
+	// RevisionHistoryLimit is the number of applied specs kept as FolderTreeRevisions for
+	// rollback with the rbac.kubevirt.io/rollback-to annotation. Defaults to 10; 0 keeps none.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Clusters selects remote clusters the RoleBindings are also created in, by the labels of their
+	// kubeconfig Secrets in the controller's cluster Secret namespace. An empty selector selects all
+	// clusters. Unset manages this cluster only.
+	// +optional
+	Clusters *metav1.LabelSelector `json:"clusters,omitempty"`
+}
+
+// FolderTreeDefaults holds FolderTree-wide defaults for role binding templates
+type FolderTreeDefaults struct {
+	// Subjects are used by folder and global role binding templates that list no subjects,
+	// e.g. an org-wide auditors group. Templates with subjects of their own don't get them.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	// Propagate is used by folder role binding templates that don't set propagate.
+	// When unset, such templates don't propagate.
+	// +optional
+	Propagate *bool `json:"propagate,omitempty"`
+}
+
+// Roots returns the roots of all hierarchies of the spec: Tree (if set) followed by Trees
+func (s *FolderTreeSpec) Roots() []TreeNode {
+	if s.Tree == nil {
+		return s.Trees
+	}
+	return append([]TreeNode{*s.Tree}, s.Trees...)
+}
+
+// DriftPolicy controls how the controller handles out-of-band edits to managed RoleBindings
+// +kubebuilder:validation:Enum=Enforce;Warn;Ignore
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce reverts out-of-band edits
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+
+	// DriftPolicyWarn keeps out-of-band edits and reports them in the Drifted condition
+	DriftPolicyWarn DriftPolicy = "Warn"
+
+	// DriftPolicyIgnore keeps out-of-band edits without reporting them
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+)
+
+// ReplacementPolicy controls the order in which RoleBindings are replaced when their roleRef changes
+// +kubebuilder:validation:Enum=CreateBeforeDelete;DeleteBeforeCreate
+type ReplacementPolicy string
+
+const (
+	// ReplacementPolicyCreateBeforeDelete creates the replacement under another name before deleting
+	// the old RoleBinding
+	ReplacementPolicyCreateBeforeDelete ReplacementPolicy = "CreateBeforeDelete"
+
+	// ReplacementPolicyDeleteBeforeCreate deletes the old RoleBinding before recreating it under the
+	// same name
+	ReplacementPolicyDeleteBeforeCreate ReplacementPolicy = "DeleteBeforeCreate"
+)
+
+// RolloutStrategy configures gradual application of RoleBinding changes in waves.
+// Each wave covers a batch of namespaces; the controller waits at least MinWaveInterval
+// between waves so that mass updates (e.g. a tree-wide subject swap) are spread out over time.
+// If both MaxNamespacesPerWave and MaxPercentPerWave are set, the smaller batch wins.
+type RolloutStrategy struct {
+	// MaxNamespacesPerWave is the maximum number of namespaces changed in a single wave
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxNamespacesPerWave *int32 `json:"maxNamespacesPerWave,omitempty"`
+
+	// MaxPercentPerWave is the maximum percentage of the tree's namespaces changed in a single wave
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxPercentPerWave *int32 `json:"maxPercentPerWave,omitempty"`
+
+	// MinWaveInterval is the minimum time between two consecutive waves
+	// +optional
+	MinWaveInterval *metav1.Duration `json:"minWaveInterval,omitempty"`
 }
 
 // FolderTreeStatus defines the observed state of FolderTree.
@@ -132,11 +642,229 @@ type FolderTreeStatus struct {
 	// ProcessedGeneration is the generation of the FolderTree that was last processed
 	// +optional
 	ProcessedGeneration int64 `json:"processedGeneration,omitempty"`
+
+	// Rollout tracks the progress of a wave-based rollout when spec.rolloutStrategy is set
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// AppliedBindings maps "<namespace>/<name>" of every RoleBinding the controller has applied
+	// to a digest of its roleRef and subjects. The webhook uses it as the previous state for
+	// privilege escalation checks on UPDATE and DELETE. It is omitted (and status.truncated set)
+	// when it would exceed the status size limits.
+	// +optional
+	AppliedBindings map[string]string `json:"appliedBindings,omitempty"`
+
+	// Inheritance lists, for every node of spec.tree in depth-first order, the role binding
+	// templates it receives from its ancestors and contributes to its descendants
+	// +optional
+	Inheritance []FolderInheritanceStatus `json:"inheritance,omitempty"`
+
+	// EffectiveBindings maps every managed namespace to the role binding templates in effect there
+	// after inheritance. It is only recorded when the controller runs with --record-effective-bindings,
+	// and omitted (and status.truncated set) when it would exceed the status size limits.
+	// +optional
+	EffectiveBindings map[string][]EffectiveBinding `json:"effectiveBindings,omitempty"`
+
+	// Expirations lists the role binding templates with an expiresAt that has not passed yet,
+	// soonest first
+	// +optional
+	Expirations []TemplateExpiration `json:"expirations,omitempty"`
+
+	// PendingNamespaces lists the namespaces of the folders that do not exist yet, sorted by name.
+	// Their RoleBindings are created by the reconcile that the creation of the namespace triggers.
+	// +optional
+	PendingNamespaces []string `json:"pendingNamespaces,omitempty"`
+
+	// Namespaces reports, for every namespace the FolderTree manages, whether its RoleBindings are
+	// in sync, sorted by name. Namespaces whose RoleBindings could not be deleted after they left the
+	// FolderTree are listed as Failed until the deletion succeeds.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Namespaces []NamespaceStatus `json:"namespaces,omitempty"`
+
+	// Truncated is true when status lists exceeded their size caps and entries were dropped.
+	// The StatusTruncated condition names the lists.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+
+	// CurrentRevision is the FolderTreeRevision of the spec that was last applied successfully
+	// +optional
+	CurrentRevision int64 `json:"currentRevision,omitempty"`
+
+	// Revisions lists the FolderTreeRevisions kept for rollback, oldest first
+	// +optional
+	Revisions []RevisionStatus `json:"revisions,omitempty"`
+
+	// Clusters reports the RoleBindings of each remote cluster selected by spec.clusters
+	// +optional
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+}
+
+// NamespacePhase is the state of the RoleBindings of a FolderTree in a namespace
+// +kubebuilder:validation:Enum=Synced;Failed;Skipped
+type NamespacePhase string
+
+const (
+	// NamespacePhaseSynced means the RoleBindings of the namespace match the spec
+	NamespacePhaseSynced NamespacePhase = "Synced"
+
+	// NamespacePhaseFailed means RoleBinding operations in the namespace failed
+	NamespacePhaseFailed NamespacePhase = "Failed"
+
+	// NamespacePhaseSkipped means the RoleBindings of the namespace were not brought in sync on
+	// purpose, e.g. because the namespace does not exist or waits for its rollout wave
+	NamespacePhaseSkipped NamespacePhase = "Skipped"
+)
+
+// NamespaceStatus describes the RoleBindings of a FolderTree in a namespace
+type NamespaceStatus struct {
+	// Name is the name of the namespace
+	Name string `json:"name"`
+
+	// Phase is Synced, Failed or Skipped
+	Phase NamespacePhase `json:"phase"`
+
+	// Message explains why the namespace failed or was skipped; for failures, it holds the error
+	// of the last failed operation
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterStatus describes the RoleBindings of a FolderTree in a remote cluster
+type ClusterStatus struct {
+	// Name is the name of the kubeconfig Secret of the cluster
+	Name string `json:"name"`
+
+	// Synced is true when the RoleBindings of the cluster were last brought to the desired state
+	Synced bool `json:"synced"`
+
+	// Message explains why the cluster could not be synced
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// RoleBindings is the number of RoleBindings the FolderTree manages in the cluster
+	// +optional
+	RoleBindings int32 `json:"roleBindings,omitempty"`
+
+	// LastSyncTime is when the cluster was last synced successfully
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// RevisionStatus describes a FolderTreeRevision of a FolderTree.
+type RevisionStatus struct {
+	// Revision is the number to pass to the rbac.kubevirt.io/rollback-to annotation
+	Revision int64 `json:"revision"`
+
+	// Name is the name of the FolderTreeRevision
+	Name string `json:"name"`
+
+	// Generation is the generation of the FolderTree whose spec the revision holds
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// CreationTime is when the revision was recorded
+	CreationTime metav1.Time `json:"creationTime"`
+}
+
+// TemplateExpiration is an upcoming expiration of a role binding template.
+type TemplateExpiration struct {
+	// Template is the name of the role binding template
+	Template string `json:"template"`
+
+	// Folder is the folder defining the template; it is empty for global templates
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// ExpiresAt is the time the template stops granting access
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// EffectiveBinding is a role binding template in effect in a namespace.
+type EffectiveBinding struct {
+	// Template is the name of the role binding template
+	Template string `json:"template"`
+
+	// From is the folder defining the template; it is empty for global templates
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// RoleRef is the role the template binds as "<kind>/<name>", e.g. "ClusterRole/view"
+	RoleRef string `json:"roleRef"`
+}
+
+// FolderInheritanceStatus summarizes template inheritance for a single tree node.
+type FolderInheritanceStatus struct {
+	// Path is the "/"-separated path of the node from the tree root, e.g. "org/platform/web"
+	Path string `json:"path"`
+
+	// Summary is a one-line overview such as "receives 2, contributes 1"
+	Summary string `json:"summary"`
+
+	// Received lists the templates inherited from ancestors (and global templates)
+	// as "<template> (from <folder>)" or "<template> (global)"
+	// +optional
+	Received []string `json:"received,omitempty"`
+
+	// Contributed lists the templates of this folder that propagate to its descendants
+	// +optional
+	Contributed []string `json:"contributed,omitempty"`
+
+	// Blocked lists the inherited templates this folder opts out of with blockInherited, or
+	// that the inheritOnly and exclude lists of its tree node filter out, as "<template> (from <folder>)"
+	// +optional
+	Blocked []string `json:"blocked,omitempty"`
+}
+
+// RolloutStatus describes the progress of a wave-based rollout.
+type RolloutStatus struct {
+	// ObservedGeneration is the FolderTree generation this rollout applies
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CurrentWave is the number of the last wave that was applied
+	// +optional
+	CurrentWave int32 `json:"currentWave,omitempty"`
+
+	// TotalNamespaces is the number of namespaces that needed changes when the rollout started
+	// +optional
+	TotalNamespaces int32 `json:"totalNamespaces,omitempty"`
+
+	// RemainingNamespaces is the number of namespaces still waiting for changes
+	// +optional
+	RemainingNamespaces int32 `json:"remainingNamespaces,omitempty"`
+
+	// LastWaveTime is when the last wave was applied
+	// +optional
+	LastWaveTime *metav1.Time `json:"lastWaveTime,omitempty"`
+
+	// Waves lists the most recent waves of this rollout, oldest first
+	// +optional
+	Waves []RolloutWave `json:"waves,omitempty"`
+}
+
+// RolloutWave records a single wave of a rollout.
+type RolloutWave struct {
+	// Number is the sequence number of the wave within the rollout, starting at 1
+	Number int32 `json:"number"`
+
+	// Namespaces are the namespaces changed in this wave
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Operations is the number of RoleBinding operations executed in this wave
+	// +optional
+	Operations int32 `json:"operations,omitempty"`
+
+	// Time is when the wave was applied
+	Time metav1.Time `json:"time"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 
 // FolderTree is the Schema for the foldertrees API.
 // FolderTree allows grouping Kubernetes namespaces into a hierarchical structure
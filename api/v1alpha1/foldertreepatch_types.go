@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PatchPhase describes whether a FolderTreePatch has been merged into its FolderTree
+type PatchPhase string
+
+const (
+	// PatchPhasePending means the target folder does not (yet) accept patches from the namespace of the patch
+	PatchPhasePending PatchPhase = "Pending"
+
+	// PatchPhaseApproved means the templates and namespaces of the patch are part of the target folder
+	PatchPhaseApproved PatchPhase = "Approved"
+
+	// PatchPhaseRejected means the patch conflicts with the FolderTree or other patches
+	PatchPhaseRejected PatchPhase = "Rejected"
+)
+
+// FolderTreePatchSpec defines what a FolderTreePatch adds to a folder.
+type FolderTreePatchSpec struct {
+	// TreeName is the name of the FolderTree containing the folder
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TreeName string `json:"treeName"`
+
+	// FolderName is the name of the folder the patch adds to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	FolderName string `json:"folderName"`
+
+	// RoleBindingTemplates are added to the templates of the folder. Their names may not be used by
+	// the folder, the templates it inherits or other patches.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=50
+	RoleBindingTemplates []RoleBindingTemplate `json:"roleBindingTemplates,omitempty"`
+
+	// Namespaces are added to the namespaces of the folder. They may not belong to any folder yet.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=100
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// FolderTreePatchStatus defines the observed state of FolderTreePatch.
+type FolderTreePatchStatus struct {
+	// Phase is the current state of the patch
+	// +optional
+	Phase PatchPhase `json:"phase,omitempty"`
+
+	// Message explains the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the FolderTreePatch that was last evaluated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Tree",type=string,JSONPath=`.spec.treeName`
+// +kubebuilder:printcolumn:name="Folder",type=string,JSONPath=`.spec.folderName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+
+// FolderTreePatch is the Schema for the foldertreepatches API.
+// A FolderTreePatch adds role binding templates and namespaces to one folder of a FolderTree, so
+// that write access to a namespace holding patches can be delegated instead of write access to the
+// whole cluster-scoped FolderTree. The FolderTree stays authoritative: the controller only merges
+// patches from namespaces the folder lists in patchNamespaces, and the webhook checks that the
+// author holds the permissions a patch grants.
+type FolderTreePatch struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the folder and what is added to it
+	// +required
+	Spec FolderTreePatchSpec `json:"spec"`
+
+	// status defines the observed state of FolderTreePatch
+	// +optional
+	Status FolderTreePatchStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderTreePatchList contains a list of FolderTreePatch
+type FolderTreePatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FolderTreePatch `json:"items"`
+}
+
+// AcceptsPatchesFrom reports whether the folder accepts FolderTreePatches from a namespace
+func (f *Folder) AcceptsPatchesFrom(namespace string) bool {
+	return slices.Contains(f.PatchNamespaces, namespace)
+}
+
+func init() {
+	SchemeBuilder.Register(&FolderTreePatch{}, &FolderTreePatchList{})
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RollbackToAnnotation asks the controller to restore the spec of a FolderTree from one of its
+// FolderTreeRevisions, e.g. "rbac.kubevirt.io/rollback-to: 3". The controller removes the
+// annotation once the spec is restored or the revision turned out not to exist.
+const RollbackToAnnotation = "rbac.kubevirt.io/rollback-to"
+
+// RollbackSpecHashAnnotation records the hash of the spec the webhook validated for the rollback
+// requested with RollbackToAnnotation. It is set by the webhook, and the controller only restores
+// the revision while its spec still has this hash.
+const RollbackSpecHashAnnotation = "rbac.kubevirt.io/rollback-spec-hash"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Tree",type=string,JSONPath=`.metadata.labels.foldertree\.rbac\.kubevirt\.io/tree`
+// +kubebuilder:printcolumn:name="Revision",type=integer,JSONPath=`.revision`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FolderTreeRevision is the Schema for the foldertreerevisions API.
+// A FolderTreeRevision is an immutable snapshot of a FolderTree spec that the controller applied
+// successfully, much like a ControllerRevision of a Deployment. The controller names it
+// "<tree>-<revision>", labels it with the tree, makes the FolderTree its owner and keeps the last
+// spec.revisionHistoryLimit of them. The webhook rejects updates changing the revision, generation
+// or data of a FolderTreeRevision.
+type FolderTreeRevision struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// Revision is the number of the snapshot within its FolderTree, starting at 1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="revision is immutable"
+	Revision int64 `json:"revision"`
+
+	// Generation is the generation of the FolderTree the spec was applied at
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="generation is immutable"
+	Generation int64 `json:"generation,omitempty"`
+
+	// Data is the FolderTree spec of the revision
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Data runtime.RawExtension `json:"data"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderTreeRevisionList contains a list of FolderTreeRevision
+type FolderTreeRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FolderTreeRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FolderTreeRevision{}, &FolderTreeRevisionList{})
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SubjectMappingSpec defines the concrete subjects a logical name stands for.
+type SubjectMappingSpec struct {
+	// Subjects are the users, groups and service accounts bound by role binding templates that
+	// reference the mapping in their subjectRefs. They are bound as they are; template variables
+	// are not expanded.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Subjects []rbacv1.Subject `json:"subjects"`
+
+	// Description explains who the mapping stands for (e.g. the team and its identity provider groups)
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SubjectMapping is the Schema for the subjectmappings API.
+// A SubjectMapping maps a logical name, such as a team, to the concrete users and groups of an
+// identity provider. Role binding templates reference mappings by name in their subjectRefs, so
+// that a change of identity provider groups is made once instead of in every FolderTree.
+// FolderTrees referencing a mapping are reconciled whenever it changes.
+type SubjectMapping struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the subjects of the mapping
+	// +required
+	Spec SubjectMappingSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubjectMappingList contains a list of SubjectMapping
+type SubjectMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SubjectMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SubjectMapping{}, &SubjectMappingList{})
+}
@@ -23,9 +23,43 @@ package v1alpha1
 import (
 	"k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveBinding) DeepCopyInto(out *EffectiveBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveBinding.
+func (in *EffectiveBinding) DeepCopy() *EffectiveBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Folder) DeepCopyInto(out *Folder) {
 	*out = *in
@@ -36,11 +70,56 @@ func (in *Folder) DeepCopyInto(out *Folder) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NetworkPolicyTemplates != nil {
+		in, out := &in.NetworkPolicyTemplates, &out.NetworkPolicyTemplates
+		*out = make([]NetworkPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceQuotaTemplates != nil {
+		in, out := &in.ResourceQuotaTemplates, &out.ResourceQuotaTemplates
+		*out = make([]ResourceQuotaTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make([]NamespaceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PatchNamespaces != nil {
+		in, out := &in.PatchNamespaces, &out.PatchNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockInherited != nil {
+		in, out := &in.BlockInherited, &out.BlockInherited
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelsToApply != nil {
+		in, out := &in.LabelsToApply, &out.LabelsToApply
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnnotationsToApply != nil {
+		in, out := &in.AnnotationsToApply, &out.AnnotationsToApply
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Folder.
@@ -54,26 +133,56 @@ func (in *Folder) DeepCopy() *Folder {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderTree) DeepCopyInto(out *FolderTree) {
+func (in *FolderInheritanceStatus) DeepCopyInto(out *FolderInheritanceStatus) {
+	*out = *in
+	if in.Received != nil {
+		in, out := &in.Received, &out.Received
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Contributed != nil {
+		in, out := &in.Contributed, &out.Contributed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Blocked != nil {
+		in, out := &in.Blocked, &out.Blocked
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderInheritanceStatus.
+func (in *FolderInheritanceStatus) DeepCopy() *FolderInheritanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderInheritanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderMembership) DeepCopyInto(out *FolderMembership) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Spec = in.Spec
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTree.
-func (in *FolderTree) DeepCopy() *FolderTree {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderMembership.
+func (in *FolderMembership) DeepCopy() *FolderMembership {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderTree)
+	out := new(FolderMembership)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FolderTree) DeepCopyObject() runtime.Object {
+func (in *FolderMembership) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -81,31 +190,31 @@ func (in *FolderTree) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderTreeList) DeepCopyInto(out *FolderTreeList) {
+func (in *FolderMembershipList) DeepCopyInto(out *FolderMembershipList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]FolderTree, len(*in))
+		*out = make([]FolderMembership, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeList.
-func (in *FolderTreeList) DeepCopy() *FolderTreeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderMembershipList.
+func (in *FolderMembershipList) DeepCopy() *FolderMembershipList {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderTreeList)
+	out := new(FolderMembershipList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FolderTreeList) DeepCopyObject() runtime.Object {
+func (in *FolderMembershipList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -113,63 +222,144 @@ func (in *FolderTreeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderTreeSpec) DeepCopyInto(out *FolderTreeSpec) {
+func (in *FolderMembershipSpec) DeepCopyInto(out *FolderMembershipSpec) {
 	*out = *in
-	if in.Tree != nil {
-		in, out := &in.Tree, &out.Tree
-		*out = new(TreeNode)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderMembershipSpec.
+func (in *FolderMembershipSpec) DeepCopy() *FolderMembershipSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Folders != nil {
-		in, out := &in.Folders, &out.Folders
-		*out = make([]Folder, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(FolderMembershipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderMembershipStatus) DeepCopyInto(out *FolderMembershipStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderMembershipStatus.
+func (in *FolderMembershipStatus) DeepCopy() *FolderMembershipStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(FolderMembershipStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeSpec.
-func (in *FolderTreeSpec) DeepCopy() *FolderTreeSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderPolicyException) DeepCopyInto(out *FolderPolicyException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderPolicyException.
+func (in *FolderPolicyException) DeepCopy() *FolderPolicyException {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderTreeSpec)
+	out := new(FolderPolicyException)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderPolicyException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderTreeStatus) DeepCopyInto(out *FolderTreeStatus) {
+func (in *FolderPolicyExceptionList) DeepCopyInto(out *FolderPolicyExceptionList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FolderPolicyException, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeStatus.
-func (in *FolderTreeStatus) DeepCopy() *FolderTreeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderPolicyExceptionList.
+func (in *FolderPolicyExceptionList) DeepCopy() *FolderPolicyExceptionList {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderTreeStatus)
+	out := new(FolderPolicyExceptionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderPolicyExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleBindingTemplate) DeepCopyInto(out *RoleBindingTemplate) {
+func (in *FolderPolicyExceptionSpec) DeepCopyInto(out *FolderPolicyExceptionSpec) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderPolicyExceptionSpec.
+func (in *FolderPolicyExceptionSpec) DeepCopy() *FolderPolicyExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderPolicyExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTree) DeepCopyInto(out *FolderTree) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTree.
+func (in *FolderTree) DeepCopy() *FolderTree {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTree) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreeDefaults) DeepCopyInto(out *FolderTreeDefaults) {
 	*out = *in
 	if in.Subjects != nil {
 		in, out := &in.Subjects, &out.Subjects
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
-	out.RoleRef = in.RoleRef
 	if in.Propagate != nil {
 		in, out := &in.Propagate, &out.Propagate
 		*out = new(bool)
@@ -177,28 +367,714 @@ func (in *RoleBindingTemplate) DeepCopyInto(out *RoleBindingTemplate) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplate.
-func (in *RoleBindingTemplate) DeepCopy() *RoleBindingTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeDefaults.
+func (in *FolderTreeDefaults) DeepCopy() *FolderTreeDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleBindingTemplate)
+	out := new(FolderTreeDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TreeNode) DeepCopyInto(out *TreeNode) {
+func (in *FolderTreeList) DeepCopyInto(out *FolderTreeList) {
 	*out = *in
-	if in.Subfolders != nil {
-		in, out := &in.Subfolders, &out.Subfolders
-		*out = make([]TreeNode, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FolderTree, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeList.
+func (in *FolderTreeList) DeepCopy() *FolderTreeList {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTreeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreePatch) DeepCopyInto(out *FolderTreePatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreePatch.
+func (in *FolderTreePatch) DeepCopy() *FolderTreePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTreePatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreePatchList) DeepCopyInto(out *FolderTreePatchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FolderTreePatch, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreePatchList.
+func (in *FolderTreePatchList) DeepCopy() *FolderTreePatchList {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreePatchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTreePatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreePatchSpec) DeepCopyInto(out *FolderTreePatchSpec) {
+	*out = *in
+	if in.RoleBindingTemplates != nil {
+		in, out := &in.RoleBindingTemplates, &out.RoleBindingTemplates
+		*out = make([]RoleBindingTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreePatchSpec.
+func (in *FolderTreePatchSpec) DeepCopy() *FolderTreePatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreePatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreePatchStatus) DeepCopyInto(out *FolderTreePatchStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreePatchStatus.
+func (in *FolderTreePatchStatus) DeepCopy() *FolderTreePatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreePatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreeRevision) DeepCopyInto(out *FolderTreeRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Data.DeepCopyInto(&out.Data)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeRevision.
+func (in *FolderTreeRevision) DeepCopy() *FolderTreeRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreeRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTreeRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreeRevisionList) DeepCopyInto(out *FolderTreeRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FolderTreeRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeRevisionList.
+func (in *FolderTreeRevisionList) DeepCopy() *FolderTreeRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreeRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderTreeRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreeSpec) DeepCopyInto(out *FolderTreeSpec) {
+	*out = *in
+	if in.Tree != nil {
+		in, out := &in.Tree, &out.Tree
+		*out = new(TreeNode)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trees != nil {
+		in, out := &in.Trees, &out.Trees
+		*out = make([]TreeNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Folders != nil {
+		in, out := &in.Folders, &out.Folders
+		*out = make([]Folder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalRoleBindingTemplates != nil {
+		in, out := &in.GlobalRoleBindingTemplates, &out.GlobalRoleBindingTemplates
+		*out = make([]RoleBindingTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(FolderTreeDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeSpec.
+func (in *FolderTreeSpec) DeepCopy() *FolderTreeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderTreeStatus) DeepCopyInto(out *FolderTreeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedBindings != nil {
+		in, out := &in.AppliedBindings, &out.AppliedBindings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Inheritance != nil {
+		in, out := &in.Inheritance, &out.Inheritance
+		*out = make([]FolderInheritanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EffectiveBindings != nil {
+		in, out := &in.EffectiveBindings, &out.EffectiveBindings
+		*out = make(map[string][]EffectiveBinding, len(*in))
+		for key, val := range *in {
+			var outVal []EffectiveBinding
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]EffectiveBinding, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Expirations != nil {
+		in, out := &in.Expirations, &out.Expirations
+		*out = make([]TemplateExpiration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingNamespaces != nil {
+		in, out := &in.PendingNamespaces, &out.PendingNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]RevisionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderTreeStatus.
+func (in *FolderTreeStatus) DeepCopy() *FolderTreeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderTreeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceOverride) DeepCopyInto(out *NamespaceOverride) {
+	*out = *in
+	if in.TemplateOverrides != nil {
+		in, out := &in.TemplateOverrides, &out.TemplateOverrides
+		*out = make(map[string]TemplateOverride, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceOverride.
+func (in *NamespaceOverride) DeepCopy() *NamespaceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceStatus) DeepCopyInto(out *NamespaceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceStatus.
+func (in *NamespaceStatus) DeepCopy() *NamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyTemplate) DeepCopyInto(out *NetworkPolicyTemplate) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Propagate != nil {
+		in, out := &in.Propagate, &out.Propagate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyTemplate.
+func (in *NetworkPolicyTemplate) DeepCopy() *NetworkPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaTemplate) DeepCopyInto(out *ResourceQuotaTemplate) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Propagate != nil {
+		in, out := &in.Propagate, &out.Propagate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaTemplate.
+func (in *ResourceQuotaTemplate) DeepCopy() *ResourceQuotaTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionStatus.
+func (in *RevisionStatus) DeepCopy() *RevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBindingTemplate) DeepCopyInto(out *RoleBindingTemplate) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubjectRefs != nil {
+		in, out := &in.SubjectRefs, &out.SubjectRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountSelector != nil {
+		in, out := &in.ServiceAccountSelector, &out.ServiceAccountSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.RoleRef = in.RoleRef
+	if in.Propagate != nil {
+		in, out := &in.Propagate, &out.Propagate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PropagateDepth != nil {
+		in, out := &in.PropagateDepth, &out.PropagateDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplate.
+func (in *RoleBindingTemplate) DeepCopy() *RoleBindingTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBindingTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.LastWaveTime != nil {
+		in, out := &in.LastWaveTime, &out.LastWaveTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.MaxNamespacesPerWave != nil {
+		in, out := &in.MaxNamespacesPerWave, &out.MaxNamespacesPerWave
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPercentPerWave != nil {
+		in, out := &in.MaxPercentPerWave, &out.MaxPercentPerWave
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinWaveInterval != nil {
+		in, out := &in.MinWaveInterval, &out.MinWaveInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutWave) DeepCopyInto(out *RolloutWave) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutWave.
+func (in *RolloutWave) DeepCopy() *RolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMapping) DeepCopyInto(out *SubjectMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMapping.
+func (in *SubjectMapping) DeepCopy() *SubjectMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SubjectMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMappingList) DeepCopyInto(out *SubjectMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SubjectMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMappingList.
+func (in *SubjectMappingList) DeepCopy() *SubjectMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SubjectMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMappingSpec) DeepCopyInto(out *SubjectMappingSpec) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMappingSpec.
+func (in *SubjectMappingSpec) DeepCopy() *SubjectMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateExpiration) DeepCopyInto(out *TemplateExpiration) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateExpiration.
+func (in *TemplateExpiration) DeepCopy() *TemplateExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateOverride) DeepCopyInto(out *TemplateOverride) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateOverride.
+func (in *TemplateOverride) DeepCopy() *TemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TreeNode) DeepCopyInto(out *TreeNode) {
+	*out = *in
+	if in.Subfolders != nil {
+		in, out := &in.Subfolders, &out.Subfolders
+		*out = make([]TreeNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InheritOnly != nil {
+		in, out := &in.InheritOnly, &out.InheritOnly
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TreeNode.
 func (in *TreeNode) DeepCopy() *TreeNode {
 	if in == nil {
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"kubevirt.io/folders/api/v1alpha1"
+)
+
+// ConvertTo converts this FolderTree to the hub version (v1alpha1).
+// Folders without a parent that are the parent of another folder become tree roots: the first
+// one in spec.tree and the others in spec.trees. Folders without parent or children stay standalone.
+func (src *FolderTree) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.FolderTree)
+
+	children := make(map[string][]string)
+	declared := make(map[string]bool)
+	edges := make(map[string]Folder)
+	for _, folder := range src.Spec.Folders {
+		declared[folder.Name] = true
+		edges[folder.Name] = folder
+	}
+	for _, folder := range src.Spec.Folders {
+		if folder.Parent == "" {
+			continue
+		}
+		if !declared[folder.Parent] {
+			return fmt.Errorf("parent '%s' of folder '%s' is not a declared folder", folder.Parent, folder.Name)
+		}
+		children[folder.Parent] = append(children[folder.Parent], folder.Name)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.FolderTreeSpec{
+		GlobalRoleBindingTemplates: src.Spec.GlobalRoleBindingTemplates,
+		RolloutStrategy:            src.Spec.RolloutStrategy,
+		DriftPolicy:                src.Spec.DriftPolicy,
+		ReplacementPolicy:          src.Spec.ReplacementPolicy,
+		ExcludedNamespaces:         src.Spec.ExcludedNamespaces,
+		Suspend:                    src.Spec.Suspend,
+		PruneMissingNamespaces:     src.Spec.PruneMissingNamespaces,
+		Priority:                   src.Spec.Priority,
+		Defaults:                   src.Spec.Defaults,
+		RevisionHistoryLimit:       src.Spec.RevisionHistoryLimit,
+		Clusters:                   src.Spec.Clusters,
+	}
+	dst.Status = src.Status
+
+	visited := make(map[string]bool)
+	for _, folder := range src.Spec.Folders {
+		dst.Spec.Folders = append(dst.Spec.Folders, folder.Folder)
+
+		if folder.Parent != "" || len(children[folder.Name]) == 0 {
+			continue
+		}
+		root := buildTreeNode(folder.Name, children, edges, visited)
+		if dst.Spec.Tree == nil {
+			dst.Spec.Tree = &root
+		} else {
+			dst.Spec.Trees = append(dst.Spec.Trees, root)
+		}
+	}
+
+	// Folders that were never reached from a root have an ancestor chain that loops
+	for _, folder := range src.Spec.Folders {
+		if folder.Parent != "" && !visited[folder.Name] {
+			return fmt.Errorf("folder '%s' is part of a parent cycle", folder.Name)
+		}
+	}
+
+	return nil
+}
+
+// buildTreeNode builds the tree node of a folder and its descendants, marking them visited.
+// The inheritOnly and exclude lists of a folder move to the tree node of its parent edge.
+// Folders that were already visited are skipped so that duplicate names cannot recurse forever.
+func buildTreeNode(name string, children map[string][]string, edges map[string]Folder, visited map[string]bool) v1alpha1.TreeNode {
+	visited[name] = true
+	node := v1alpha1.TreeNode{Name: name, InheritOnly: edges[name].InheritOnly, Exclude: edges[name].Exclude}
+	for _, child := range children[name] {
+		if visited[child] {
+			continue
+		}
+		node.Subfolders = append(node.Subfolders, buildTreeNode(child, children, edges, visited))
+	}
+	return node
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+// Every tree node sets the parent of its subfolders; tree nodes without folder data become
+// folders of their own so that the hierarchy is preserved.
+func (dst *FolderTree) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.FolderTree)
+
+	parents := make(map[string]string)
+	edges := make(map[string]v1alpha1.TreeNode)
+	var collectParents func(node v1alpha1.TreeNode)
+	collectParents = func(node v1alpha1.TreeNode) {
+		edges[node.Name] = node
+		for _, subfolder := range node.Subfolders {
+			parents[subfolder.Name] = node.Name
+			collectParents(subfolder)
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, folder := range src.Spec.Folders {
+		declared[folder.Name] = true
+	}
+	var treeOnly []Folder
+	var collectTreeOnly func(node v1alpha1.TreeNode)
+	collectTreeOnly = func(node v1alpha1.TreeNode) {
+		if !declared[node.Name] {
+			declared[node.Name] = true
+			treeOnly = append(treeOnly, Folder{Folder: v1alpha1.Folder{Name: node.Name}})
+		}
+		for _, subfolder := range node.Subfolders {
+			collectTreeOnly(subfolder)
+		}
+	}
+
+	for _, root := range src.Spec.Roots() {
+		collectParents(root)
+		collectTreeOnly(root)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = FolderTreeSpec{
+		GlobalRoleBindingTemplates: src.Spec.GlobalRoleBindingTemplates,
+		RolloutStrategy:            src.Spec.RolloutStrategy,
+		DriftPolicy:                src.Spec.DriftPolicy,
+		ReplacementPolicy:          src.Spec.ReplacementPolicy,
+		ExcludedNamespaces:         src.Spec.ExcludedNamespaces,
+		Suspend:                    src.Spec.Suspend,
+		PruneMissingNamespaces:     src.Spec.PruneMissingNamespaces,
+		Priority:                   src.Spec.Priority,
+		Defaults:                   src.Spec.Defaults,
+		RevisionHistoryLimit:       src.Spec.RevisionHistoryLimit,
+		Clusters:                   src.Spec.Clusters,
+	}
+	dst.Status = src.Status
+
+	for _, folder := range src.Spec.Folders {
+		dst.Spec.Folders = append(dst.Spec.Folders, Folder{
+			Folder:      folder,
+			Parent:      parents[folder.Name],
+			InheritOnly: edges[folder.Name].InheritOnly,
+			Exclude:     edges[folder.Name].Exclude,
+		})
+	}
+	for _, folder := range treeOnly {
+		folder.Parent = parents[folder.Name]
+		folder.InheritOnly = edges[folder.Name].InheritOnly
+		folder.Exclude = edges[folder.Name].Exclude
+		dst.Spec.Folders = append(dst.Spec.Folders, folder)
+	}
+
+	return nil
+}
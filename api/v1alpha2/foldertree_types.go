@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/folders/api/v1alpha1"
+)
+
+// Folder represents a folder with its data and an optional reference to its parent folder
+type Folder struct {
+	v1alpha1.Folder `json:",inline"`
+
+	// Parent is the name of the parent folder. Folders without a parent are roots of a hierarchy,
+	// or standalone folders when no other folder names them as parent.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// InheritOnly restricts the propagating role binding templates this folder inherits from its
+	// parent to the named ones, for the folder and its descendants. Empty inherits all of them.
+	// Global role binding templates are always inherited.
+	// +optional
+	// +listType=set
+	InheritOnly []string `json:"inheritOnly,omitempty"`
+
+	// Exclude lists propagating role binding templates this folder does not inherit from its
+	// parent, for the folder and its descendants. Global role binding templates cannot be excluded.
+	// +optional
+	// +listType=set
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// folderFields are the fields Folder adds to the embedded v1alpha1 Folder, whose JSON methods would
+// otherwise leave them out
+type folderFields struct {
+	Parent      string   `json:"parent,omitempty"`
+	InheritOnly []string `json:"inheritOnly,omitempty"`
+	Exclude     []string `json:"exclude,omitempty"`
+}
+
+// UnmarshalJSON reads the embedded v1alpha1 Folder, with its namespace entries, and the fields of Folder
+func (f *Folder) UnmarshalJSON(data []byte) error {
+	var fields folderFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if err := f.Folder.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	f.Parent, f.InheritOnly, f.Exclude = fields.Parent, fields.InheritOnly, fields.Exclude
+	return nil
+}
+
+// MarshalJSON writes the embedded v1alpha1 Folder, with its namespace entries, and the fields of Folder
+func (f Folder) MarshalJSON() ([]byte, error) {
+	data, err := f.Folder.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(folderFields{Parent: f.Parent, InheritOnly: f.InheritOnly, Exclude: f.Exclude})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return json.Marshal(object)
+}
+
+// FolderTreeSpec defines the desired state of FolderTree as a flat list of folders.
+type FolderTreeSpec struct {
+	// Folders is a flat list of folders. The hierarchy is defined by the parent field of each folder.
+	// Folder names must be unique within a FolderTree. Folders are merged by name.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=100
+	Folders []Folder `json:"folders,omitempty"`
+
+	// GlobalRoleBindingTemplates are applied to every namespace of the FolderTree, as if inherited
+	// from above the root folders. The propagate field has no effect on global templates.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=200
+	GlobalRoleBindingTemplates []v1alpha1.RoleBindingTemplate `json:"globalRoleBindingTemplates,omitempty"`
+
+	// RolloutStrategy limits how many namespaces receive RoleBinding changes per reconcile.
+	// +optional
+	RolloutStrategy *v1alpha1.RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// DriftPolicy controls how out-of-band edits to managed RoleBindings are handled.
+	// +optional
+	DriftPolicy v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// ReplacementPolicy controls how RoleBindings are replaced when the roleRef of their template changes.
+	// +optional
+	ReplacementPolicy v1alpha1.ReplacementPolicy `json:"replacementPolicy,omitempty"`
+
+	// ExcludedNamespaces never receive RoleBindings from this FolderTree, even if a folder lists them.
+	// +optional
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// Suspend pauses reconciliation of the FolderTree while true.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// PruneMissingNamespaces makes the controller remove namespaces that no longer exist from the folders.
+	// +optional
+	PruneMissingNamespaces bool `json:"pruneMissingNamespaces,omitempty"`
+
+	// Priority decides which FolderTree manages a namespace assigned by several FolderTrees.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Defaults are used by the role binding templates that leave the corresponding fields unset.
+	// +optional
+	Defaults *v1alpha1.FolderTreeDefaults `json:"defaults,omitempty"`
+
+	// RevisionHistoryLimit is the number of applied specs kept as FolderTreeRevisions for rollback.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Clusters selects remote clusters the RoleBindings are also created in, by the labels of their
+	// kubeconfig Secrets. Unset manages this cluster only.
+	// +optional
+	Clusters *metav1.LabelSelector `json:"clusters,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// FolderTree is the Schema for the foldertrees API.
+// FolderTree allows grouping Kubernetes namespaces into a hierarchical structure
+// with inherited RBAC permissions. Unlike v1alpha1, which nests tree nodes in spec.tree,
+// each folder references its parent folder, so the schema is fully structural.
+// Template, rollout, drift and status types are shared with v1alpha1, the storage version
+// that the controller and webhooks operate on.
+type FolderTree struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of FolderTree
+	// +required
+	Spec FolderTreeSpec `json:"spec"`
+
+	// status defines the observed state of FolderTree
+	// +optional
+	Status v1alpha1.FolderTreeStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// FolderTreeList contains a list of FolderTree
+type FolderTreeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FolderTree `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FolderTree{}, &FolderTreeList{})
+}
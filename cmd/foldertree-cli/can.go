@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// runCan implements "foldertree-cli can <subject> <verb> <resource> [--namespace <namespace>]"
+func runCan(args []string) error {
+	fs := flag.NewFlagSet("can", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	namespace := fs.String("namespace", "", "Only check access in this namespace instead of all namespaces.")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli can <subject> <verb> <resource> [--namespace <namespace>] [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "The subject is given as <kind>:<name>, e.g. group:web-team, user:alice or serviceaccount:<namespace>/<name>.\n")
+		fmt.Fprintf(os.Stderr, "The resource may include an API group and subresource, e.g. deployments.apps or pods/log.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 3 {
+		fs.Usage()
+		return fmt.Errorf("expected <subject>, <verb> and <resource>, got %d arguments", fs.NArg())
+	}
+
+	subject, err := rbac.ParseSubject(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	query, err := rbac.ParseResourceQuery(fs.Arg(1), fs.Arg(2))
+	if err != nil {
+		return err
+	}
+	query.Namespace = *namespace
+
+	where := "any namespace"
+	if query.Namespace != "" {
+		where = "namespace " + query.Namespace
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	grants, err := rbac.SimulateAccess(context.Background(), c, subject, query)
+	if err != nil {
+		return err
+	}
+	if len(grants) == 0 {
+		fmt.Printf("no - no FolderTree allows %s to %s in %s\n", fs.Arg(0), query, where)
+		return nil
+	}
+	fmt.Printf("yes - %s can %s in %s via:\n", fs.Arg(0), query, where)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tTREE\tFOLDER PATH\tTEMPLATE\tFROM\tROLE\tVIA")
+	for _, grant := range grants {
+		from := grant.From
+		if from == "" {
+			from = "(global)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s/%s\t%s:%s\n",
+			grant.Namespace, grant.Tree, strings.Join(grant.Path, "."), grant.Template, from,
+			grant.RoleRef.Kind, grant.RoleRef.Name, grant.Via.Kind, grant.Via.Name)
+	}
+	return w.Flush()
+}
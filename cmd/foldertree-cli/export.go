@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// runExport implements "foldertree-cli export [--tree <foldertree>] [-o <file>]"
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	trees := fs.String("tree", "", "Comma-separated list of FolderTrees to export; all FolderTrees when empty.")
+	output := fs.String("o", "-", "File to write the RoleBindings to, or - for stdout.")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli export [flags]\n\n"+
+			"Writes the RoleBindings FolderTrees want as a single YAML stream, for \"foldertree-cli restore\".\n"+
+			"Owner references and the labels and annotations configured on the controller are not included.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	var treeNames []string
+	if *trees != "" {
+		treeNames = strings.Split(*trees, ",")
+	}
+	roleBindings, err := rbac.ExportRoleBindings(context.Background(), c, treeNames)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		w = file
+	}
+	for i := range roleBindings {
+		data, err := yaml.Marshal(&roleBindings[i])
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	if *output != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d RoleBindings to %s\n", len(roleBindings), *output)
+	}
+	return nil
+}
+
+// runRestore implements "foldertree-cli restore -f <file> [--dry-run]"
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	filename := fs.String("f", "", "File written by \"foldertree-cli export\", or - for stdin.")
+	dryRun := fs.Bool("dry-run", false, "Send the changes as server-side dry runs.")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli restore -f <file> [flags]\n\n"+
+			"Creates the exported RoleBindings, or brings existing ones back to the export, without the controller.\n"+
+			"Only RoleBindings labeled as managed by a FolderTree are restored; other documents are rejected.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *filename == "" {
+		fs.Usage()
+		return fmt.Errorf("expected -f <file>")
+	}
+
+	input := io.Reader(os.Stdin)
+	if *filename != "-" {
+		file, err := os.Open(*filename)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		input = file
+	}
+	roleBindings, err := decodeRoleBindings(input)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, roleBinding := range roleBindings {
+		result, err := rbac.RestoreRoleBinding(context.Background(), c, roleBinding, *dryRun)
+		if err != nil {
+			failed++
+			fmt.Printf("rolebinding/%s/%s: %v\n", roleBinding.Namespace, roleBinding.Name, err)
+			continue
+		}
+		fmt.Printf("rolebinding/%s/%s: %s\n", roleBinding.Namespace, roleBinding.Name, result)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d RoleBindings could not be restored", failed, len(roleBindings))
+	}
+	return nil
+}
+
+// decodeRoleBindings decodes the RoleBindings of a multi-document YAML stream. Any other kind of
+// document is an error, as the stream is expected to be an export.
+func decodeRoleBindings(input io.Reader) ([]*rbacv1.RoleBinding, error) {
+	var roleBindings []*rbacv1.RoleBinding
+	decoder := utilyaml.NewYAMLOrJSONDecoder(input, 4096)
+	for {
+		var document json.RawMessage
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return roleBindings, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse export: %v", err)
+		}
+		if len(document) == 0 || string(document) == "null" {
+			continue
+		}
+		roleBinding := &rbacv1.RoleBinding{}
+		if err := json.Unmarshal(document, roleBinding); err != nil {
+			return nil, fmt.Errorf("failed to decode export: %v", err)
+		}
+		if roleBinding.APIVersion != rbacv1.SchemeGroupVersion.String() || roleBinding.Kind != "RoleBinding" {
+			return nil, fmt.Errorf("unexpected %s %s in export, expected only RoleBindings", roleBinding.APIVersion, roleBinding.Kind)
+		}
+		roleBindings = append(roleBindings, roleBinding)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// foldertree-cli is a command line tool for inspecting FolderTrees in a cluster and validating FolderTree manifests.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(rbacv1alpha1.AddToScheme(scheme))
+}
+
+// commands maps each subcommand name to its implementation
+var commands = map[string]func(args []string) error{
+	"can":        runCan,
+	"export":     runExport,
+	"restore":    runRestore,
+	"who-can":    runWhoCan,
+	"which-tree": runWhichTree,
+	"tree":       runTree,
+	"validate":   runValidate,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: foldertree-cli <command> [flags]
+
+Commands:
+  who-can <verb> <resource> --namespace <namespace>
+        Show which subjects FolderTrees allow to perform an action in a namespace
+  can <subject> <verb> <resource> [--namespace <namespace>]
+        Show in which namespaces FolderTrees allow a subject to perform an action
+  which-tree <namespace>
+        Show which FolderTrees manage a namespace
+  tree <foldertree> [--effective <namespace>]
+        Print the folders of a FolderTree as a tree, or the templates effective in a namespace
+  validate -f <file> [--cluster]
+        Validate FolderTree manifests together, e.g. in CI before applying them
+  export [--tree <foldertree>] [-o <file>]
+        Write the RoleBindings FolderTrees want as a YAML stream, for disaster recovery
+  restore -f <file> [--dry-run]
+        Create or update the RoleBindings of an export without the controller
+`)
+}
+
+// newClient creates a client for the cluster selected by --kubeconfig or the environment
+func newClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// runWhichTree implements "foldertree-cli which-tree <namespace>"
+func runWhichTree(args []string) error {
+	fs := flag.NewFlagSet("which-tree", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli which-tree <namespace> [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <namespace>, got %d arguments", fs.NArg())
+	}
+	namespace := fs.Arg(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := newIndexedCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	trees, err := rbac.WhichTreeOwns(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+	if len(trees) == 0 {
+		fmt.Printf("No FolderTree manages namespace %s\n", namespace)
+		return nil
+	}
+	for _, tree := range trees {
+		fmt.Println(tree)
+	}
+	return nil
+}
+
+// newIndexedCache starts a cache with the FolderTree namespace index registered,
+// the same way the controller manager's shared cache is set up
+func newIndexedCache(ctx context.Context) (cache.Cache, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	c, err := cache.New(restConfig, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	if err := rbac.SetupNamespaceIndex(ctx, c); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "error: cache stopped: %v\n", err)
+		}
+	}()
+	if !c.WaitForCacheSync(ctx) {
+		return nil, fmt.Errorf("failed to sync cache")
+	}
+	return c, nil
+}
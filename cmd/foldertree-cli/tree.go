@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// runTree implements "foldertree-cli tree <foldertree> [--effective <namespace>]"
+func runTree(args []string) error {
+	fs := flag.NewFlagSet("tree", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	effective := fs.String("effective", "", "Show the templates effective in this namespace after inheritance instead of the tree.")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli tree <foldertree> [--effective <namespace>] [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <foldertree>, got %d arguments", fs.NArg())
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	folderTree := &rbacv1alpha1.FolderTree{}
+	if err := c.Get(ctx, types.NamespacedName{Name: fs.Arg(0)}, folderTree); err != nil {
+		return err
+	}
+
+	if *effective == "" {
+		printTree(os.Stdout, folderTree)
+		return nil
+	}
+
+	desired, err := rbac.EffectiveRoleBindings(ctx, c, folderTree, *effective)
+	if err != nil {
+		return err
+	}
+	if len(desired) == 0 {
+		fmt.Printf("FolderTree %s has no templates effective in namespace %s\n", folderTree.Name, *effective)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TEMPLATE\tFROM\tFOLDER PATH\tROLE\tSUBJECTS")
+	for _, desiredRB := range desired {
+		from := desiredRB.RoleBinding.Annotations[rbac.SourceFolderAnnotation]
+		if from == "" {
+			from = "(global)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			desiredRB.RoleBindingTemplate.Name, from, desiredRB.RoleBinding.Annotations[rbac.FolderPathKey],
+			formatRoleRef(desiredRB.RoleBindingTemplate), formatSubjects(desiredRB.RoleBinding.Subjects))
+	}
+	return w.Flush()
+}
+
+// printTree writes the folders of a FolderTree as an ASCII tree, followed by its standalone folders
+func printTree(w io.Writer, folderTree *rbacv1alpha1.FolderTree) {
+	// Show whether templates propagate as resolved against spec.defaults
+	folderTree = rbac.WithDefaults(folderTree)
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range folderTree.Spec.Folders {
+		folderMap[folder.Name] = folder
+	}
+
+	fmt.Fprintf(w, "FolderTree %s\n", folderTree.Name)
+	if defaults := folderTree.Spec.Defaults; defaults != nil && len(defaults.Subjects) > 0 {
+		fmt.Fprintf(w, "  default subjects: %s\n", formatSubjects(defaults.Subjects))
+	}
+	for _, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		fmt.Fprintf(w, "  global: %s\n", formatTemplate(template, false))
+	}
+
+	inTree := make(map[string]bool)
+	roots := folderTree.Spec.Roots()
+	for i, root := range roots {
+		printTreeNode(w, root, "", i == len(roots)-1, folderMap, inTree)
+	}
+
+	var standalone []rbacv1alpha1.TreeNode
+	for _, folder := range folderTree.Spec.Folders {
+		if !inTree[folder.Name] {
+			standalone = append(standalone, rbacv1alpha1.TreeNode{Name: folder.Name})
+		}
+	}
+	if len(standalone) > 0 {
+		fmt.Fprintln(w, "standalone folders:")
+		for i, node := range standalone {
+			printTreeNode(w, node, "", i == len(standalone)-1, folderMap, inTree)
+		}
+	}
+}
+
+// printTreeNode writes a tree node with its namespaces and templates, then recurses into its subfolders
+func printTreeNode(w io.Writer, node rbacv1alpha1.TreeNode, prefix string, last bool,
+	folderMap map[string]rbacv1alpha1.Folder, inTree map[string]bool) {
+	inTree[node.Name] = true
+
+	connector, childPrefix := "├── ", prefix+"│   "
+	if last {
+		connector, childPrefix = "└── ", prefix+"    "
+	}
+	fmt.Fprintf(w, "%s%s%s\n", prefix, connector, node.Name)
+
+	// Details are drawn next to the line connecting the node to its subfolders
+	detailPrefix := childPrefix + "  "
+	if len(node.Subfolders) > 0 {
+		detailPrefix = childPrefix + "│ "
+	}
+	folder := folderMap[node.Name]
+	if len(folder.Namespaces) > 0 {
+		fmt.Fprintf(w, "%snamespaces: %s\n", detailPrefix, strings.Join(folder.Namespaces, ", "))
+	}
+	if folder.NamespacePattern != "" {
+		fmt.Fprintf(w, "%snamespace pattern: %s\n", detailPrefix, folder.NamespacePattern)
+	}
+	for _, template := range folder.RoleBindingTemplates {
+		fmt.Fprintf(w, "%stemplate: %s\n", detailPrefix, formatTemplate(template, true))
+	}
+	if len(folder.BlockInherited) > 0 {
+		fmt.Fprintf(w, "%sblocks: %s\n", detailPrefix, strings.Join(folder.BlockInherited, ", "))
+	}
+	if folder.InheritNamespaces {
+		fmt.Fprintf(w, "%sinherits namespaces: receives the templates of all subfolders\n", detailPrefix)
+	}
+	if len(node.InheritOnly) > 0 {
+		fmt.Fprintf(w, "%sinherits only: %s\n", detailPrefix, strings.Join(node.InheritOnly, ", "))
+	}
+	if len(node.Exclude) > 0 {
+		fmt.Fprintf(w, "%sexcludes: %s\n", detailPrefix, strings.Join(node.Exclude, ", "))
+	}
+	if folder.AcceptMemberships {
+		fmt.Fprintf(w, "%saccepts memberships\n", detailPrefix)
+	}
+	if len(folder.PatchNamespaces) > 0 {
+		fmt.Fprintf(w, "%saccepts patches from: %s\n", detailPrefix, strings.Join(folder.PatchNamespaces, ", "))
+	}
+
+	for i, subfolder := range node.Subfolders {
+		printTreeNode(w, subfolder, childPrefix, i == len(node.Subfolders)-1, folderMap, inTree)
+	}
+}
+
+// formatTemplate describes a template as "<name> -> <kind>/<role>", noting whether it propagates
+// and when it expires
+func formatTemplate(template rbacv1alpha1.RoleBindingTemplate, showPropagate bool) string {
+	description := fmt.Sprintf("%s -> %s", template.Name, formatRoleRef(template))
+	if showPropagate {
+		switch depth := rbac.PropagationDepth(template); {
+		case template.PropagateDepth != nil && depth > 0:
+			description += fmt.Sprintf(" (propagates %d levels)", depth)
+		case depth > 0:
+			description += " (propagates)"
+		}
+	}
+	if template.ExpiresAt != nil {
+		verb := "expires"
+		if rbac.Expired(template, time.Now()) {
+			verb = "expired"
+		}
+		description += fmt.Sprintf(" (%s %s)", verb, template.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	return description
+}
+
+// formatRoleRef returns the role a template binds as "<kind>/<name>"
+func formatRoleRef(template rbacv1alpha1.RoleBindingTemplate) string {
+	return fmt.Sprintf("%s/%s", template.RoleRef.Kind, template.RoleRef.Name)
+}
+
+// formatSubjects returns the subjects of a RoleBinding as a comma-separated list of <kind>:<name>
+func formatSubjects(subjects []rbacv1.Subject) string {
+	var formatted []string
+	for _, subject := range subjects {
+		name := subject.Name
+		if subject.Kind == "ServiceAccount" {
+			name = subject.Namespace + "/" + subject.Name
+		}
+		formatted = append(formatted, subject.Kind+":"+name)
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/manifest"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// runValidate implements "foldertree-cli validate -f <file>"
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	filename := fs.String("f", "", "File with FolderTree manifests to validate, or - for stdin. Other objects are skipped.")
+	excludedNamespaces := fs.String("excluded-namespaces", "", "Comma-separated list of namespaces excluded by the controller configuration.")
+	maxTreeDepth := fs.Int("max-tree-depth", validation.DefaultMaxTreeDepth, "Maximum number of levels of a tree, as configured on the controller.")
+	allowNamespaceOverlap := fs.Bool("allow-namespace-overlap", false, "Accept namespaces assigned in more than one FolderTree, as configured on the controller.")
+	cluster := fs.Bool("cluster", false, "Also check uniqueness against the FolderTrees in the cluster of the current kubeconfig context.")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli validate -f <file> [flags]\n\n"+
+			"Validates FolderTrees without a cluster, including the uniqueness of folder names and namespaces\n"+
+			"across the FolderTrees in the file. With --cluster, the FolderTrees in the cluster are included;\n"+
+			"FolderTrees in the file replace those of the same name. Namespace existence, policy rules and\n"+
+			"privilege escalation are only checked by the webhook.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *filename == "" {
+		fs.Usage()
+		return fmt.Errorf("expected -f <file>")
+	}
+
+	folderTrees, err := manifest.ReadFolderTrees(*filename)
+	if err != nil {
+		return err
+	}
+	if len(folderTrees) == 0 {
+		return fmt.Errorf("no FolderTrees found in %s", *filename)
+	}
+
+	var existing []rbacv1alpha1.FolderTree
+	if *cluster {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		var folderTreeList rbacv1alpha1.FolderTreeList
+		if err := c.List(context.Background(), &folderTreeList); err != nil {
+			return fmt.Errorf("failed to list FolderTrees: %v", err)
+		}
+		existing = folderTreeList.Items
+	}
+
+	opts := validation.Options{MaxTreeDepth: *maxTreeDepth, AllowNamespaceOverlap: *allowNamespaceOverlap}
+	if *excludedNamespaces != "" {
+		opts.ExcludedNamespaces = strings.Split(*excludedNamespaces, ",")
+	}
+	invalid := 0
+	for _, result := range validation.ValidateFolderTrees(folderTrees, existing, opts) {
+		for _, warning := range result.Warnings {
+			fmt.Printf("foldertree/%s: warning: %s\n", result.Name, warning)
+		}
+		if len(result.Errors) > 0 {
+			invalid++
+			for _, err := range result.Errors {
+				fmt.Printf("foldertree/%s: %v\n", result.Name, err)
+			}
+			continue
+		}
+		fmt.Printf("foldertree/%s: valid\n", result.Name)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d FolderTrees are invalid", invalid, len(folderTrees))
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// runWhoCan implements "foldertree-cli who-can <verb> <resource> --namespace <namespace>"
+func runWhoCan(args []string) error {
+	fs := flag.NewFlagSet("who-can", flag.ExitOnError)
+	config.RegisterFlags(fs)
+	namespace := fs.String("namespace", "", "The namespace to check access in.")
+	subject := fs.String("subject", "",
+		"Only report whether this subject is allowed, given as <kind>:<name> "+
+			"(e.g. Group:web-team, User:alice or ServiceAccount:<namespace>/<name>).")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-cli who-can <verb> <resource> --namespace <namespace> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "The resource may include an API group and subresource, e.g. deployments.apps or pods/log.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected <verb> and <resource>, got %d arguments", fs.NArg())
+	}
+
+	query, err := rbac.ParseAccessQuery(fs.Arg(0), fs.Arg(1), *namespace)
+	if err != nil {
+		return err
+	}
+
+	var subjectKind, subjectName string
+	if *subject != "" {
+		var found bool
+		subjectKind, subjectName, found = strings.Cut(*subject, ":")
+		if !found || subjectKind == "" || subjectName == "" {
+			return fmt.Errorf("invalid --subject %q, expected <kind>:<name>", *subject)
+		}
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	grants, err := rbac.WhoCan(context.Background(), c, query)
+	if err != nil {
+		return err
+	}
+
+	if *subject != "" {
+		var matching []rbac.AccessGrant
+		for _, grant := range grants {
+			if strings.EqualFold(grant.Subject.Kind, subjectKind) && displayName(grant) == subjectName {
+				matching = append(matching, grant)
+			}
+		}
+		if len(matching) == 0 {
+			fmt.Printf("no - no FolderTree allows %s %q to %s in namespace %s\n", subjectKind, subjectName, query, query.Namespace)
+			return nil
+		}
+		fmt.Printf("yes - %s %q can %s in namespace %s via:\n", subjectKind, subjectName, query, query.Namespace)
+		grants = matching
+	} else if len(grants) == 0 {
+		fmt.Printf("No FolderTree allows %s in namespace %s\n", query, query.Namespace)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "SUBJECT\tTREE\tFOLDER\tTEMPLATE\tROLE")
+	for _, grant := range grants {
+		fmt.Fprintf(w, "%s:%s\t%s\t%s\t%s\t%s/%s\n",
+			grant.Subject.Kind, displayName(grant), grant.Tree, grant.Folder, grant.Template, grant.RoleRef.Kind, grant.RoleRef.Name)
+	}
+	return w.Flush()
+}
+
+// displayName returns the subject name of a grant, qualified by namespace for service accounts
+func displayName(grant rbac.AccessGrant) string {
+	if grant.Subject.Kind == "ServiceAccount" {
+		return grant.Subject.Namespace + "/" + grant.Subject.Name
+	}
+	return grant.Subject.Name
+}
+
+// reorderFlags moves positional arguments after flags so that flags may be given
+// after the verb and resource, as with kubectl.
+func reorderFlags(args []string) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		// Flags given as "--name value" consume the following argument (who-can has no boolean flags)
+		if !strings.Contains(arg, "=") && i+1 < len(args) {
+			flags = append(flags, args[i+1])
+			i++
+		}
+	}
+	return append(flags, positional...)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// foldertree-diff prints the RoleBinding changes between two versions of FolderTree manifests,
+// e.g. to summarize the access a merge request grants or revokes before it is applied.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/manifest"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+func main() {
+	excludedNamespaces := flag.String("excluded-namespaces", "",
+		"Comma-separated list of namespaces excluded by the controller configuration.")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: foldertree-diff [flags] <old-file> <new-file>\n\n"+
+			"Prints the RoleBinding operations the controller would perform when the FolderTrees of\n"+
+			"<old-file> are replaced by the ones of <new-file>. FolderTrees are matched by name; use\n"+
+			"/dev/null as <old-file> for new FolderTrees. Either file may be - for stdin.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var excluded []string
+	if *excludedNamespaces != "" {
+		excluded = strings.Split(*excludedNamespaces, ",")
+	}
+	if err := run(os.Stdout, flag.Arg(0), flag.Arg(1), excluded); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run prints the operations of every FolderTree that differs between the old and new file
+func run(w io.Writer, oldFile, newFile string, excludedNamespaces []string) error {
+	oldTrees, err := readFolderTrees(oldFile)
+	if err != nil {
+		return err
+	}
+	newTrees, err := readFolderTrees(newFile)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for name := range oldTrees {
+		names[name] = true
+	}
+	for name := range newTrees {
+		names[name] = true
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	changed := false
+	for _, name := range sortedNames {
+		oldTree, newTree := oldTrees[name], newTrees[name]
+		if newTree == nil {
+			// A removed FolderTree deletes everything it created
+			newTree = &rbacv1alpha1.FolderTree{}
+			newTree.Name = name
+		}
+
+		builder := &rbac.RoleBindingBuilder{
+			FolderTree:         newTree,
+			ExcludedNamespaces: excludedNamespaces,
+		}
+		operations, err := rbac.NewWebhookDiffAnalyzer(oldTree, newTree, builder).AnalyzeFolderTreeDiff()
+		if err != nil {
+			return fmt.Errorf("foldertree/%s: %v", name, err)
+		}
+		if len(operations) == 0 {
+			continue
+		}
+
+		changed = true
+		sortOperations(operations)
+		_, _ = fmt.Fprintf(w, "foldertree/%s:\n", name)
+		for _, operation := range operations {
+			_, _ = fmt.Fprintf(w, "  %s\n", describeOperation(operation))
+		}
+	}
+
+	if !changed {
+		_, _ = fmt.Fprintln(w, "No RoleBinding changes")
+	}
+	return nil
+}
+
+// readFolderTrees reads the FolderTrees of a manifest file by name
+func readFolderTrees(filename string) (map[string]*rbacv1alpha1.FolderTree, error) {
+	folderTrees, err := manifest.ReadFolderTrees(filename)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	byName := make(map[string]*rbacv1alpha1.FolderTree, len(folderTrees))
+	for _, folderTree := range folderTrees {
+		if _, duplicate := byName[folderTree.Name]; duplicate {
+			return nil, fmt.Errorf("%s: FolderTree '%s' is defined more than once", filename, folderTree.Name)
+		}
+		byName[folderTree.Name] = folderTree
+	}
+	return byName, nil
+}
+
+// operationOrder lists deletes first, so that a roleRef change reads as revoke then grant
+var operationOrder = map[rbac.OperationType]int{
+	rbac.OperationDelete: 0,
+	rbac.OperationUpdate: 1,
+	rbac.OperationCreate: 2,
+}
+
+// sortOperations orders operations by namespace, RoleBinding name and operation type
+func sortOperations(operations []rbac.RoleBindingOperation) {
+	sort.SliceStable(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if nameA, nameB := roleBindingName(a), roleBindingName(b); nameA != nameB {
+			return nameA < nameB
+		}
+		return operationOrder[a.Type] < operationOrder[b.Type]
+	})
+}
+
+// roleBindingName returns the name of the RoleBinding an operation targets
+func roleBindingName(operation rbac.RoleBindingOperation) string {
+	if operation.DesiredRoleBinding != nil {
+		return operation.DesiredRoleBinding.Name
+	}
+	return operation.ExistingRoleBinding.Name
+}
+
+// describeOperation describes the access an operation grants or revokes, e.g.
+// "+ grant ClusterRole/edit on prod-web to Group:web-team (template web-team-edit)"
+func describeOperation(operation rbac.RoleBindingOperation) string {
+	template := ""
+	if operation.RoleBindingTemplate.Name != "" {
+		template = fmt.Sprintf(" (template %s)", operation.RoleBindingTemplate.Name)
+	}
+
+	switch operation.Type {
+	case rbac.OperationCreate:
+		desired := operation.DesiredRoleBinding
+		return fmt.Sprintf("+ grant %s on %s to %s%s",
+			formatRoleRef(desired.RoleRef), operation.Namespace, formatSubjects(desired.Subjects), template)
+	case rbac.OperationDelete:
+		existing := operation.ExistingRoleBinding
+		return fmt.Sprintf("- revoke %s on %s from %s%s",
+			formatRoleRef(existing.RoleRef), operation.Namespace, formatSubjects(existing.Subjects), template)
+	default:
+		existing, desired := operation.ExistingRoleBinding, operation.DesiredRoleBinding
+		added := subtractSubjects(desired.Subjects, existing.Subjects)
+		removed := subtractSubjects(existing.Subjects, desired.Subjects)
+		var changes []string
+		if len(added) > 0 {
+			changes = append(changes, "grant to "+formatSubjects(added))
+		}
+		if len(removed) > 0 {
+			changes = append(changes, "revoke from "+formatSubjects(removed))
+		}
+		if len(changes) == 0 {
+			changes = append(changes, "labels only")
+		}
+		return fmt.Sprintf("~ update %s on %s: %s%s",
+			formatRoleRef(desired.RoleRef), operation.Namespace, strings.Join(changes, ", "), template)
+	}
+}
+
+// subtractSubjects returns the subjects of a that are not in b
+func subtractSubjects(a, b []rbacv1.Subject) []rbacv1.Subject {
+	var difference []rbacv1.Subject
+	for _, subject := range a {
+		found := false
+		for _, other := range b {
+			if subject == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			difference = append(difference, subject)
+		}
+	}
+	return difference
+}
+
+// formatRoleRef returns a role as "<kind>/<name>"
+func formatRoleRef(roleRef rbacv1.RoleRef) string {
+	return fmt.Sprintf("%s/%s", roleRef.Kind, roleRef.Name)
+}
+
+// formatSubjects returns subjects as a comma-separated list of <kind>:<name>
+func formatSubjects(subjects []rbacv1.Subject) string {
+	var formatted []string
+	for _, subject := range subjects {
+		name := subject.Name
+		if subject.Kind == rbacv1.ServiceAccountKind {
+			name = subject.Namespace + "/" + subject.Name
+		}
+		formatted = append(formatted, subject.Kind+":"+name)
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// hnc-migrate converts the namespace hierarchies of the Hierarchical Namespace Controller (HNC) into
+// a FolderTree. It is meant to run once as a Job in the cluster being migrated, see
+// config/hnc-migrate, and prints the FolderTree manifest or creates the FolderTree.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/hnc"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(rbacv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	name := flag.String("name", "hnc", "The name of the FolderTree.")
+	apply := flag.Bool("apply", false,
+		"If set, the FolderTree is created in the cluster instead of printed. The webhook checks that the "+
+			"user of hnc-migrate holds the permissions it grants.")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: hnc-migrate [flags]\n\n"+
+			"Converts the HNC hierarchies of the cluster into a FolderTree with a folder per namespace. The\n"+
+			"RoleBindings HNC propagates become role binding templates with propagate=true. RoleBindings\n"+
+			"that cannot be converted are reported as warnings in comments.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), os.Stdout, *name, *apply); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run converts the hierarchies of the cluster and prints or creates the FolderTree
+func run(ctx context.Context, w io.Writer, name string, apply bool) error {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	parents, err := hnc.ReadParents(ctx, c)
+	if err != nil {
+		return err
+	}
+	if len(parents) == 0 {
+		return fmt.Errorf("no HNC hierarchies found")
+	}
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := c.List(ctx, roleBindings); err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+
+	folderTree, warnings, err := hnc.Convert(name, parents, roleBindings.Items)
+	if err != nil {
+		return err
+	}
+	// Warnings are YAML comments, so that the output of the Job stays a valid manifest
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(w, "# warning: %s\n", warning)
+	}
+
+	if apply {
+		if err := c.Create(ctx, folderTree); err != nil {
+			return fmt.Errorf("failed to create FolderTree '%s': %w", name, err)
+		}
+		_, _ = fmt.Fprintf(w, "foldertree/%s created with %d folders\n", name, len(folderTree.Spec.Folders))
+		return nil
+	}
+
+	data, err := yaml.Marshal(folderTree)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -17,29 +17,48 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
+	"fmt"
+	"hash/fnv"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	rbacv1alpha2 "kubevirt.io/folders/api/v1alpha2"
+	"kubevirt.io/folders/internal/audit"
 	"kubevirt.io/folders/internal/controller"
+	"kubevirt.io/folders/internal/tracing"
 	webhookv1alpha1 "kubevirt.io/folders/internal/webhook/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -52,6 +71,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(rbacv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(rbacv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -61,9 +81,45 @@ func main() {
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	var releaseOnCancel bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var deniedClusterRoles string
+	var clusterRoleAllowlist string
+	var limitsConfigMap string
+	var allowWildcardSubjects bool
+	var excludedNamespaces string
+	var privilegeCheckMode string
+	var dryRunLabels, subjectAccessReviewNamespaces string
+	var impersonationClientCacheSize int
+	var validationWorkers int
+	var maxTreeDepth int
+	var maxRoleBindings int
+	var roleBindingWarningThreshold int
+	var destructiveChangeThreshold int
+	var specSizeWarningBytes int
+	var maxStatusBytes int
+	var breakGlassGroups string
+	var privilegedUsers, privilegedGroups string
+	var foreignOwnerKeys string
+	var recordEffectiveBindings bool
+	var folderTreeSelector string
+	var disableOwnerReferences bool
+	var roleBindingLabels, roleBindingAnnotations string
+	var adoptRoleBindings bool
+	var allowNamespaceOverlap bool
+	var validateOpenShiftGroups bool
+	var subjectVerifierKind, subjectVerifierURL string
+	var orphanSweepInterval time.Duration
+	var orphanSweepDelete bool
+	var clusterSecretNamespace string
+	var tracingEndpoint string
+	var maxConcurrentOperations int
+	var roleBindingWriteQPS float64
+	var roleBindingWriteBurst int
+	var desiredStateCacheSize int
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -71,6 +127,17 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long a standby replica waits after the last renewal before taking over leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership. "+
+			"Must be less than --leader-elect-lease-duration.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How long replicas wait between attempts to acquire or renew the lease. "+
+			"Must be less than --leader-elect-renew-deadline.")
+	flag.BoolVar(&releaseOnCancel, "leader-elect-release-on-cancel", true,
+		"If set, the leader releases its lease on shutdown so a standby replica takes over without waiting "+
+			"for the lease to expire.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -82,6 +149,116 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&deniedClusterRoles, "denied-cluster-roles", "",
+		"Comma-separated list of ClusterRoles that role binding templates may not reference "+
+			"unless allowed by a FolderPolicyException.")
+	flag.StringVar(&clusterRoleAllowlist, "cluster-role-allowlist", "",
+		"<namespace>/<name> of a ConfigMap whose 'clusterRoles' key lists the only ClusterRoles role binding templates "+
+			"may reference, separated by commas or newlines; '*' matches any characters. All ClusterRoles are allowed if empty.")
+	flag.StringVar(&limitsConfigMap, "limits-configmap", "",
+		"<namespace>/<name> of a ConfigMap setting the maximum numbers of folders, tree nodes, namespaces and role binding "+
+			"templates of a FolderTree, and the groups that may override them per FolderTree. Read on every admission "+
+			"request; the default limits apply if empty or missing.")
+	flag.BoolVar(&allowWildcardSubjects, "allow-wildcard-subjects", false,
+		"If set, role binding templates may bind to wildcard subjects such as system:authenticated "+
+			"without a FolderPolicyException.")
+	flag.StringVar(&privilegeCheckMode, "privilege-check-mode", string(webhookv1alpha1.PrivilegeCheckModeImpersonation),
+		"How the webhook verifies that users hold the permissions they grant: 'impersonation' (dry-run requests "+
+			"as the user) or 'subjectaccessreview' (SubjectAccessReviews, no impersonate permission needed).")
+	flag.StringVar(&dryRunLabels, "dry-run-labels", "",
+		"Comma-separated key=value labels set on the RoleBindings the webhook sends as dry-run requests in "+
+			"impersonation mode, in addition to '"+webhookv1alpha1.DryRunValidationLabel+"=true'.")
+	flag.StringVar(&subjectAccessReviewNamespaces, "subjectaccessreview-namespaces", "",
+		"Comma-separated namespaces whose RoleBinding operations are checked with SubjectAccessReviews "+
+			"in impersonation mode, e.g. where admission policies reject dry-run RoleBindings. '*' matches any characters.")
+	flag.IntVar(&impersonationClientCacheSize, "impersonation-client-cache-size", 128,
+		"The number of impersonation clients the webhook keeps across admission requests.")
+	flag.IntVar(&validationWorkers, "validation-workers", 8,
+		"The number of RoleBinding operations the webhook validates concurrently per admission request.")
+	flag.IntVar(&maxTreeDepth, "max-tree-depth", 10,
+		"The maximum number of levels of a FolderTree tree, counting the root as level 1.")
+	flag.IntVar(&maxRoleBindings, "max-rolebindings", 10000,
+		"The maximum number of RoleBindings a FolderTree may produce across all its namespaces.")
+	flag.IntVar(&roleBindingWarningThreshold, "rolebinding-warning-threshold", 1000,
+		"The number of RoleBindings of a FolderTree above which the webhook returns an admission warning.")
+	flag.IntVar(&destructiveChangeThreshold, "destructive-change-threshold", 50,
+		"The percentage of the RoleBindings of a FolderTree an update may remove without the '"+
+			webhookv1alpha1.ConfirmDestructiveChangeAnnotation+"' annotation. A negative value disables the check.")
+	flag.IntVar(&specSizeWarningBytes, "spec-size-warning-bytes", 768*1024,
+		"The JSON size in bytes of a FolderTree spec above which the webhook warns that the FolderTree approaches "+
+			"the etcd object size limit. A negative value disables the warning.")
+	flag.IntVar(&maxStatusBytes, "max-status-bytes", controller.DefaultMaxStatusBytes,
+		"The maximum JSON size in bytes of a FolderTree status. Larger statuses drop their effective bindings, "+
+			"inheritance and applied bindings, in that order, and report a StatusTruncated condition.")
+	flag.StringVar(&breakGlassGroups, "break-glass-groups", "",
+		"Comma-separated list of groups whose members may skip the webhook privilege escalation check by "+
+			"annotating a FolderTree with rbac.kubevirt.io/break-glass=<ticket-id>. Empty disables break-glass.")
+	flag.StringVar(&foreignOwnerKeys, "foreign-owner-keys", "",
+		"Comma-separated list of label or annotation keys marking namespaces as owned by another tenancy system, "+
+			"e.g. capsule.clastix.io/tenant. The webhook rejects adding such namespaces to folders unless the FolderTree "+
+			"lists them in the "+webhookv1alpha1.AllowForeignOwnedNamespacesAnnotation+" annotation. Empty disables the check.")
+	flag.StringVar(&privilegedUsers, "privileged-users", "",
+		"Comma-separated list of users, e.g. system:serviceaccount:argocd:argocd-application-controller, that skip "+
+			"the webhook privilege escalation check, so GitOps tools can manage FolderTrees without holding every "+
+			"permission they grant.")
+	flag.StringVar(&privilegedGroups, "privileged-groups", "",
+		"Comma-separated list of groups whose members skip the webhook privilege escalation check like --privileged-users. "+
+			"Groups including every user, such as system:authenticated, are rejected.")
+	flag.BoolVar(&recordEffectiveBindings, "record-effective-bindings", false,
+		"If set, FolderTree status lists the role binding templates in effect per namespace in status.effectiveBindings.")
+	flag.StringVar(&folderTreeSelector, "foldertree-selector", "",
+		"Label selector restricting this controller to a shard of the FolderTrees, e.g. shard=a. "+
+			"Each shard uses its own leader election lease. Empty manages all FolderTrees.")
+	flag.BoolVar(&disableOwnerReferences, "disable-owner-references", false,
+		"If set, RoleBindings are managed by label without owner references to their FolderTree, and a finalizer "+
+			"makes the controller delete them with the FolderTree. Existing owner references are removed.")
+	flag.StringVar(&roleBindingLabels, "rolebinding-labels", "",
+		"Comma-separated key=value labels set on every managed RoleBinding, e.g. for chargeback. "+
+			"The controller restores their values like those of its own labels.")
+	flag.StringVar(&roleBindingAnnotations, "rolebinding-annotations", "",
+		"Comma-separated key=value annotations set on every managed RoleBinding, e.g. for policy engines. "+
+			"The controller restores their values like those of its own annotations.")
+	flag.BoolVar(&adoptRoleBindings, "adopt", false,
+		"If set, FolderTrees annotated with foldertree.rbac.kubevirt.io/adopt=true take over unmanaged RoleBindings "+
+			"that exactly match one of their RoleBindings instead of creating duplicates.")
+	flag.BoolVar(&allowNamespaceOverlap, "allow-namespace-overlap", false,
+		"If set, several FolderTrees may list the same namespace. The FolderTree with the highest spec.priority "+
+			"manages it, and the others report it in their Superseded condition.")
+	flag.BoolVar(&validateOpenShiftGroups, "validate-openshift-groups", false,
+		"If set, Group subjects are checked against OpenShift's user.openshift.io Groups. Admission warns about "+
+			"Groups that do not exist and the foldertree_dangling_subjects metric counts them per FolderTree.")
+	flag.StringVar(&subjectVerifierKind, "subject-verifier", "none",
+		"Verifies that User and Group subjects exist: none, openshift to check user.openshift.io Users and Groups, "+
+			"or http to ask --subject-verifier-url. Admission warns about unresolved subjects and the FolderTree "+
+			"lists them in its SubjectUnresolved condition.")
+	flag.StringVar(&subjectVerifierURL, "subject-verifier-url", "",
+		"Endpoint of the http subject verifier. It receives a POST of {\"subjects\": [...]} and answers "+
+			"with the subjects it does not know as {\"unresolved\": [...]}.")
+	flag.DurationVar(&orphanSweepInterval, "orphan-sweep-interval", 0,
+		"Interval of a cluster-wide sweep for RoleBindings labeled with a FolderTree that no longer exists, "+
+			"e.g. 1h. Orphaned RoleBindings are logged and counted in foldertree_orphaned_rolebindings. 0 disables the sweep.")
+	flag.BoolVar(&orphanSweepDelete, "orphan-sweep-delete", false,
+		"If set, the orphan sweep deletes the orphaned RoleBindings it finds instead of only reporting them.")
+	flag.StringVar(&clusterSecretNamespace, "cluster-secret-namespace", "",
+		"Namespace of the kubeconfig Secrets, labeled rbac.kubevirt.io/cluster=true, of the remote clusters "+
+			"FolderTrees may propagate RoleBindings to with spec.clusters. Empty disables multi-cluster propagation.")
+	flag.StringVar(&excludedNamespaces, "excluded-namespaces", "",
+		"Comma-separated list of namespaces that never receive RoleBindings from any FolderTree, "+
+			"e.g. kube-system,kube-public.")
+	flag.IntVar(&maxConcurrentOperations, "max-concurrent-operations", 1,
+		"Number of namespaces whose RoleBinding operations are executed concurrently within a reconcile. "+
+			"The operations of a namespace always run in order.")
+	flag.Float64Var(&roleBindingWriteQPS, "rolebinding-write-qps", 0,
+		"Maximum number of RoleBinding creates, updates and deletes per second across all FolderTrees, "+
+			"so large changes are spread out instead of hitting the API server at once. 0 disables the limit.")
+	flag.IntVar(&roleBindingWriteBurst, "rolebinding-write-burst", 100,
+		"Number of RoleBinding writes allowed at once before --rolebinding-write-qps applies.")
+	flag.IntVar(&desiredStateCacheSize, "desired-state-cache-size", 64,
+		"The number of FolderTree states whose calculated RoleBindings the webhook and the controller share, "+
+			"so the controller reuses the result computed at admission. 0 disables the cache.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
+		"OTLP/gRPC endpoint URL to export reconcile traces to, e.g. http://otel-collector:4317. "+
+			"Tracing is disabled if empty.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -90,6 +267,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := validateLeaderElectionTimings(leaseDuration, renewDeadline, retryPeriod); err != nil {
+		setupLog.Error(err, "invalid leader election flags")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -179,49 +361,188 @@ func main() {
 		})
 	}
 
+	var treeSelector labels.Selector
+	if folderTreeSelector != "" {
+		var err error
+		treeSelector, err = labels.Parse(folderTreeSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --foldertree-selector")
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "3c664029.kubevirt.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaderElectionID:       leaderElectionID(folderTreeSelector),
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// Releasing the lease on shutdown is safe because the program exits as soon as the manager stops.
+		LeaderElectionReleaseOnCancel: releaseOnCancel,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// Serve the effective access query API next to the metrics, behind the same authn/authz filter.
+	// It reveals who has access where, so it is never served without that filter.
+	if secureMetrics {
+		if err := mgr.AddMetricsServerExtraHandler(audit.EffectivePath, audit.NewEffectiveAccessHandler(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to add the effective access endpoint")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("The effective access endpoint is disabled because the metrics endpoint is not secured")
+	}
+
+	extraMetadata, err := parseExtraMetadata(roleBindingLabels, roleBindingAnnotations)
+	if err != nil {
+		setupLog.Error(err, "invalid --rolebinding-labels or --rolebinding-annotations")
+		os.Exit(1)
+	}
+
+	// All FolderTrees share one token bucket for their RoleBinding writes
+	var writeLimiter *rate.Limiter
+	if roleBindingWriteQPS > 0 {
+		if roleBindingWriteBurst < 1 {
+			setupLog.Error(nil, "--rolebinding-write-burst must be at least 1", "burst", roleBindingWriteBurst)
+			os.Exit(1)
+		}
+		writeLimiter = rate.NewLimiter(rate.Limit(roleBindingWriteQPS), roleBindingWriteBurst)
+		setupLog.Info("Rate limiting RoleBinding writes", "qps", roleBindingWriteQPS, "burst", roleBindingWriteBurst)
+	}
+
+	subjectVerifier, err := newSubjectVerifier(subjectVerifierKind, subjectVerifierURL, mgr.GetAPIReader())
+	if err != nil {
+		setupLog.Error(err, "invalid --subject-verifier")
+		os.Exit(1)
+	}
+
+	// The webhook and the controller share the RoleBindings calculated for a FolderTree
+	var desiredStateCache *rbac.DesiredStateCache
+	if desiredStateCacheSize > 0 {
+		desiredStateCache = rbac.NewDesiredStateCache(desiredStateCacheSize)
+	}
+
 	if err := (&controller.FolderTreeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("foldertree-controller"),
+		ExcludedNamespaces: splitList(excludedNamespaces),
+
+		RecordEffectiveBindings: recordEffectiveBindings,
+		TreeSelector:            treeSelector,
+		DisableOwnerReferences:  disableOwnerReferences,
+		AdoptRoleBindings:       adoptRoleBindings,
+		AllowNamespaceOverlap:   allowNamespaceOverlap,
+		MaxConcurrentOperations: maxConcurrentOperations,
+		WriteLimiter:            writeLimiter,
+		ValidateOpenShiftGroups: validateOpenShiftGroups,
+		SubjectVerifier:         subjectVerifier,
+		ClusterSecretNamespace:  clusterSecretNamespace,
+		APIReader:               mgr.GetAPIReader(),
+		StatusLimits:            controller.StatusLimits{MaxStatusBytes: maxStatusBytes},
+		DesiredStateCache:       desiredStateCache,
+		ExtraMetadata:           extraMetadata,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FolderTree")
 		os.Exit(1)
 	}
+	if orphanSweepInterval > 0 {
+		if err := mgr.Add(&controller.OrphanSweeper{
+			Client:   mgr.GetClient(),
+			Interval: orphanSweepInterval,
+			Delete:   orphanSweepDelete,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the orphan sweeper")
+			os.Exit(1)
+		}
+	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhookv1alpha1.SetupFolderTreeWebhookWithManager(mgr); err != nil {
+		mode, err := webhookv1alpha1.ParsePrivilegeCheckMode(privilegeCheckMode)
+		if err != nil {
+			setupLog.Error(err, "invalid --privilege-check-mode")
+			os.Exit(1)
+		}
+		extraDryRunLabels, err := labels.ConvertSelectorToLabelsMap(dryRunLabels)
+		if err != nil {
+			setupLog.Error(err, "invalid --dry-run-labels")
+			os.Exit(1)
+		}
+		allowlist, err := parseNamespacedName(clusterRoleAllowlist)
+		if err != nil {
+			setupLog.Error(err, "invalid --cluster-role-allowlist")
+			os.Exit(1)
+		}
+		limits, err := parseNamespacedName(limitsConfigMap)
+		if err != nil {
+			setupLog.Error(err, "invalid --limits-configmap")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.ValidatePrivilegedRequesters(splitList(privilegedUsers), splitList(privilegedGroups)); err != nil {
+			setupLog.Error(err, "invalid --privileged-users or --privileged-groups")
+			os.Exit(1)
+		}
+		if privilegedUsers != "" || privilegedGroups != "" {
+			setupLog.Info("Privileged users and groups skip the webhook privilege escalation check",
+				"users", splitList(privilegedUsers), "groups", splitList(privilegedGroups))
+		}
+		webhookOptions := webhookv1alpha1.WebhookOptions{
+			DeniedClusterRoles:    splitList(deniedClusterRoles),
+			ClusterRoleAllowlist:  allowlist,
+			AllowWildcardSubjects: allowWildcardSubjects,
+			ExcludedNamespaces:    splitList(excludedNamespaces),
+			PrivilegeCheckMode:    mode,
+
+			DryRunLabels:                  extraDryRunLabels,
+			SubjectAccessReviewNamespaces: splitList(subjectAccessReviewNamespaces),
+
+			ImpersonationClientCacheSize: impersonationClientCacheSize,
+			DesiredStateCache:            desiredStateCache,
+			ValidationWorkers:            validationWorkers,
+			MaxTreeDepth:                 maxTreeDepth,
+			LimitsConfigMap:              limits,
+			MaxRoleBindings:              maxRoleBindings,
+			RoleBindingWarningThreshold:  roleBindingWarningThreshold,
+			SpecSizeWarningBytes:         specSizeWarningBytes,
+			DestructiveChangeThreshold:   destructiveChangeThreshold,
+			ForeignOwnerKeys:             splitList(foreignOwnerKeys),
+			PrivilegedUsers:              splitList(privilegedUsers),
+			PrivilegedGroups:             splitList(privilegedGroups),
+			BreakGlassGroups:             splitList(breakGlassGroups),
+			AllowNamespaceOverlap:        allowNamespaceOverlap,
+			ValidateOpenShiftGroups:      validateOpenShiftGroups,
+			SubjectVerifier:              subjectVerifier,
+			ClusterSecretNamespace:       clusterSecretNamespace,
+		}
+		if err := webhookv1alpha1.SetupFolderTreeWebhookWithManager(mgr, webhookOptions); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "FolderTree")
 			os.Exit(1)
 		}
+		if err := webhookv1alpha1.SetupFolderTreePatchWebhookWithManager(mgr, webhookOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FolderTreePatch")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupFolderTreeRevisionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FolderTreeRevision")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupSubjectMappingWebhookWithManager(mgr, webhookOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SubjectMapping")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
-		if err := mgr.Add(metricsCertWatcher); err != nil {
+		if err := mgr.Add(allReplicas{metricsCertWatcher}); err != nil {
 			setupLog.Error(err, "unable to add metrics certificate watcher to manager")
 			os.Exit(1)
 		}
@@ -229,7 +550,7 @@ func main() {
 
 	if webhookCertWatcher != nil {
 		setupLog.Info("Adding webhook certificate watcher to manager")
-		if err := mgr.Add(webhookCertWatcher); err != nil {
+		if err := mgr.Add(allReplicas{webhookCertWatcher}); err != nil {
 			setupLog.Error(err, "unable to add webhook certificate watcher to manager")
 			os.Exit(1)
 		}
@@ -239,14 +560,226 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+
+	// Only report ready once admission requests can be served, or a failurePolicy=Fail webhook
+	// rejects FolderTree changes while a rollout shifts traffic to this pod
+	readiness := &readinessChecker{cache: mgr.GetCache(), apiReader: mgr.GetAPIReader()}
+	readyzChecks := map[string]healthz.Checker{
+		"informers":   readiness.informersSynced,
+		"foldertrees": readiness.folderTreesListed,
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		readiness.webhookStarted = webhookServer.StartedChecker()
+		readiness.webhookCertWatcher = webhookCertWatcher
+		readyzChecks["webhook"] = readiness.webhookServing
+	}
+	for name, check := range readyzChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", name)
+			os.Exit(1)
+		}
+	}
+
+	// Export spans of the RoleBinding operations per folder, namespace and operation
+	shutdownTracing := func(context.Context) error { return nil }
+	if tracingEndpoint != "" {
+		shutdownTracing, err = tracing.Setup(context.Background(), tracingEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		setupLog.Info("Exporting traces", "endpoint", tracingEndpoint)
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+	startErr := mgr.Start(ctrl.SetupSignalHandler())
+
+	// Flush the spans still buffered
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+	cancel()
+
+	if startErr != nil {
+		setupLog.Error(startErr, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// allReplicas runs a runnable on every replica rather than only on the leader. The webhook and metrics
+// servers serve on all replicas, so their certificate watchers must too or standbys would keep serving
+// a rotated-out certificate.
+type allReplicas struct {
+	manager.Runnable
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (allReplicas) NeedLeaderElection() bool {
+	return false
+}
+
+// readinessChecker holds the readiness checks of the manager. Every replica serves the webhook, so
+// the checks do not depend on leader election.
+type readinessChecker struct {
+	cache     cache.Cache
+	apiReader client.Reader
+
+	// webhookStarted checks that the webhook server accepts TLS connections, and webhookCertWatcher
+	// serves its certificate when --webhook-cert-path is set
+	webhookStarted     healthz.Checker
+	webhookCertWatcher *certwatcher.CertWatcher
+
+	// listed records the first successful List of FolderTrees, after which the API server is not
+	// asked again on every probe
+	listed atomic.Bool
+}
+
+// webhookServing checks that the webhook server is started and serves a certificate that is valid now
+func (r *readinessChecker) webhookServing(req *http.Request) error {
+	if err := r.webhookStarted(req); err != nil {
+		return err
+	}
+	if r.webhookCertWatcher == nil {
+		return nil
+	}
+
+	certificate, err := r.webhookCertWatcher.GetCertificate(nil)
+	if err != nil {
+		return fmt.Errorf("webhook serving certificate: %w", err)
+	}
+	if certificate == nil || len(certificate.Certificate) == 0 {
+		return errors.New("webhook serving certificate is not loaded")
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("webhook serving certificate: %w", err)
+	}
+	if now := time.Now(); now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return fmt.Errorf("webhook serving certificate is only valid from %s to %s",
+			leaf.NotBefore.Format(time.RFC3339), leaf.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// informersSynced checks that the FolderTree informer, which the webhook's uniqueness checks and the
+// controller read from, has synced
+func (r *readinessChecker) informersSynced(req *http.Request) error {
+	informer, err := r.cache.GetInformer(req.Context(), &rbacv1alpha1.FolderTree{}, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("FolderTree informer: %w", err)
+	}
+	if !informer.HasSynced() {
+		return errors.New("FolderTree informer has not synced yet")
+	}
+	return nil
+}
+
+// folderTreesListed checks that FolderTrees have been listed from the API server at least once
+func (r *readinessChecker) folderTreesListed(req *http.Request) error {
+	if r.listed.Load() {
+		return nil
+	}
+	if err := r.apiReader.List(req.Context(), &rbacv1alpha1.FolderTreeList{}, client.Limit(1)); err != nil {
+		return fmt.Errorf("failed to list FolderTrees: %w", err)
+	}
+	r.listed.Store(true)
+	return nil
+}
+
+// validateLeaderElectionTimings rejects lease timings that client-go would refuse once the manager starts,
+// so that a misconfiguration fails at startup with a clear message
+func validateLeaderElectionTimings(leaseDuration, renewDeadline, retryPeriod time.Duration) error {
+	if retryPeriod <= 0 {
+		return fmt.Errorf("--leader-elect-retry-period must be positive, got %s", retryPeriod)
+	}
+	if renewDeadline <= retryPeriod {
+		return fmt.Errorf("--leader-elect-renew-deadline (%s) must be greater than --leader-elect-retry-period (%s)",
+			renewDeadline, retryPeriod)
+	}
+	if leaseDuration <= renewDeadline {
+		return fmt.Errorf("--leader-elect-lease-duration (%s) must be greater than --leader-elect-renew-deadline (%s)",
+			leaseDuration, renewDeadline)
+	}
+	return nil
+}
+
+// newSubjectVerifier returns the subject verifier selected by --subject-verifier, or nil for none
+func newSubjectVerifier(kind, url string, reader client.Reader) (rbac.SubjectVerifier, error) {
+	switch kind {
+	case "", "none":
+		return nil, nil
+	case "openshift":
+		return &rbac.OpenShiftSubjectVerifier{Reader: reader}, nil
+	case "http":
+		if url == "" {
+			return nil, fmt.Errorf("--subject-verifier=http requires --subject-verifier-url")
+		}
+		return &rbac.HTTPSubjectVerifier{URL: url}, nil
+	default:
+		return nil, fmt.Errorf("unknown subject verifier %q, must be none, openshift or http", kind)
+	}
+}
+
+// splitList splits a comma-separated flag value into its non-empty, trimmed elements
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseExtraMetadata parses the comma-separated key=value labels and annotations set on every managed RoleBinding
+func parseExtraMetadata(labels, annotations string) (rbac.ExtraMetadata, error) {
+	parse := func(value string) (map[string]string, error) {
+		var pairs map[string]string
+		for _, item := range splitList(value) {
+			key, value, ok := strings.Cut(item, "=")
+			if !ok || strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("'%s' is not of the form <key>=<value>", item)
+			}
+			if pairs == nil {
+				pairs = make(map[string]string)
+			}
+			pairs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		return pairs, nil
+	}
+
+	var extra rbac.ExtraMetadata
+	var err error
+	if extra.Labels, err = parse(labels); err != nil {
+		return rbac.ExtraMetadata{}, err
+	}
+	if extra.Annotations, err = parse(annotations); err != nil {
+		return rbac.ExtraMetadata{}, err
+	}
+	return extra, extra.Validate()
+}
+
+// parseNamespacedName parses a "<namespace>/<name>" flag value; an empty value is the zero NamespacedName
+func parseNamespacedName(value string) (types.NamespacedName, error) {
+	if value == "" {
+		return types.NamespacedName{}, nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("'%s' is not of the form <namespace>/<name>", value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// leaderElectionID returns the leader election lease name. Every shard of a sharded deployment
+// needs its own lease, so the selector is hashed into the name to keep shards from blocking each other.
+func leaderElectionID(folderTreeSelector string) string {
+	const id = "3c664029.kubevirt.io"
+	if folderTreeSelector == "" {
+		return id
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(folderTreeSelector))
+	return fmt.Sprintf("%08x-%s", hash.Sum32(), id)
+}
@@ -49,6 +49,25 @@ def fix_recursive_crd(crd_file):
         else:
             print("⚠️  tree field not found in spec")
 
+        # Fix the trees field - each item is a TreeNode root
+        if 'trees' in spec_props:
+            try:
+                trees_props = spec_props['trees']['items']['properties']
+                if 'subfolders' in trees_props:
+                    trees_props['subfolders'] = {
+                        'description': 'Subfolders is a list of child tree nodes',
+                        'type': 'array',
+                        'items': {
+                            'type': 'object',
+                            'x-kubernetes-preserve-unknown-fields': True
+                        }
+                    }
+                    print("✅ Fixed trees.items.subfolders schema")
+                else:
+                    print("⚠️  subfolders field not found in trees schema")
+            except KeyError as e:
+                print(f"⚠️  Could not fix trees schema: {e}")
+
         # The folders array doesn't need fixing as it's not recursive
         if 'folders' in spec_props:
             print("✅ Folders schema is already correct (no recursion)")
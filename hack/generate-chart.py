@@ -0,0 +1,137 @@
+#!/usr/bin/env python3
+
+"""
+generate-chart.py
+Renders the generated manifests in config/ (CRDs, manager ClusterRole and webhook
+configurations) as templates of the Helm chart in dist/chart, so the chart stays in
+sync with the controller-gen markers. The remaining chart templates are maintained by hand.
+"""
+
+import sys
+from pathlib import Path
+
+ROOT = Path(__file__).resolve().parent.parent
+CHART_TEMPLATES = ROOT / "dist" / "chart" / "templates"
+
+HEADER = "{{/* Code generated by hack/generate-chart.py from %s. DO NOT EDIT. */}}\n"
+
+# CRDs served through the conversion webhook
+CONVERSION_CRDS = {"foldertrees.rbac.kubevirt.io"}
+
+
+def escape(text):
+    """Escape Go template delimiters that occur in the manifests themselves"""
+    return text.replace("{{", '{{ "{{" }}')
+
+
+def render_crd(source):
+    """Wrap a CRD with the crd.enable toggle, chart labels, cert-manager CA injection and conversion"""
+    text = escape(source.read_text()).removeprefix("---\n")
+    name = next(line.split(":", 1)[1].strip() for line in text.splitlines() if line.startswith("  name: "))
+
+    annotations = [
+        '    {{- if .Values.crd.keep }}\n',
+        '    "helm.sh/resource-policy": keep\n',
+        '    {{- end }}\n',
+    ]
+    if name in CONVERSION_CRDS:
+        annotations += [
+            '    {{- if .Values.certmanager.enable }}\n',
+            '    cert-manager.io/inject-ca-from: "{{ .Release.Namespace }}/{{ include "chart.name" . }}-serving-cert"\n',
+            '    {{- end }}\n',
+        ]
+    text = text.replace("metadata:\n  annotations:\n", "metadata:\n  annotations:\n" + "".join(annotations), 1)
+    text = text.replace("  name: %s\n" % name,
+                        "  name: %s\n  labels:\n    {{- include \"chart.labels\" . | nindent 4 }}\n" % name, 1)
+
+    if name in CONVERSION_CRDS:
+        text = text.replace("spec:\n", "".join([
+            "spec:\n",
+            "  {{- if .Values.webhook.enable }}\n",
+            "  conversion:\n",
+            "    strategy: Webhook\n",
+            "    webhook:\n",
+            "      clientConfig:\n",
+            "        service:\n",
+            "          name: {{ include \"chart.name\" . }}-webhook-service\n",
+            "          namespace: {{ .Release.Namespace }}\n",
+            "          path: /convert\n",
+            "      conversionReviewVersions:\n",
+            "      - v1\n",
+            "  {{- end }}\n",
+        ]), 1)
+
+    return "".join([
+        HEADER % source.relative_to(ROOT),
+        "{{- if .Values.crd.enable }}\n",
+        "---\n",
+        text,
+        "{{- end }}\n",
+    ])
+
+
+def render_role(source):
+    """Wrap the manager ClusterRole with the rbac.enable toggle and chart naming"""
+    text = escape(source.read_text()).removeprefix("---\n")
+    text = text.replace(
+        "metadata:\n  name: manager-role\n",
+        "metadata:\n  name: {{ include \"chart.name\" . }}-manager-role\n"
+        "  labels:\n    {{- include \"chart.labels\" . | nindent 4 }}\n", 1)
+    return "".join([
+        HEADER % source.relative_to(ROOT),
+        "{{- if .Values.rbac.enable }}\n",
+        "---\n",
+        text,
+        "{{- end }}\n",
+    ])
+
+
+def render_webhooks(source):
+    """Wrap the webhook configurations with the webhook.enable toggle, service, failurePolicy and CA injection"""
+    text = escape(source.read_text())
+    for kind in ("mutating", "validating"):
+        text = text.replace(
+            "metadata:\n  name: %s-webhook-configuration\n" % kind,
+            "metadata:\n  name: {{ include \"chart.name\" . }}-%s-webhook-configuration\n"
+            "  labels:\n    {{- include \"chart.labels\" . | nindent 4 }}\n"
+            "  {{- if .Values.certmanager.enable }}\n"
+            "  annotations:\n"
+            "    cert-manager.io/inject-ca-from: \"{{ .Release.Namespace }}/{{ include \"chart.name\" . }}-serving-cert\"\n"
+            "  {{- end }}\n" % kind, 1)
+    text = text.replace("      name: webhook-service\n",
+                        "      name: {{ include \"chart.name\" . }}-webhook-service\n")
+    text = text.replace("      namespace: system\n", "      namespace: {{ .Release.Namespace }}\n")
+    text = text.replace("  failurePolicy: Fail\n", "  failurePolicy: {{ .Values.webhook.failurePolicy }}\n")
+    return "".join([
+        HEADER % source.relative_to(ROOT),
+        "{{- if .Values.webhook.enable }}\n",
+        text,
+        "{{- end }}\n",
+    ])
+
+
+def write(target, content):
+    target.parent.mkdir(parents=True, exist_ok=True)
+    target.write_text(content)
+    print(f"📦 Generated {target.relative_to(ROOT)}")
+
+
+def main():
+    if not CHART_TEMPLATES.is_dir():
+        print(f"ERROR: chart templates not found: {CHART_TEMPLATES}")
+        return 1
+
+    crd_dir = CHART_TEMPLATES / "crd"
+    for stale in crd_dir.glob("*.yaml"):
+        stale.unlink()
+    for source in sorted((ROOT / "config" / "crd" / "bases").glob("*.yaml")):
+        write(crd_dir / source.name, render_crd(source))
+
+    write(CHART_TEMPLATES / "rbac" / "role.yaml", render_role(ROOT / "config" / "rbac" / "role.yaml"))
+    write(CHART_TEMPLATES / "webhook" / "webhooks.yaml",
+          render_webhooks(ROOT / "config" / "webhook" / "manifests.yaml"))
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
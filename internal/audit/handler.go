@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit serves read-only views of the access granted through FolderTrees
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// EffectivePath is the path of the effective access endpoint on the metrics server
+const EffectivePath = "/effective"
+
+var log = logf.Log.WithName("audit")
+
+// EffectiveResponse is the JSON body returned by the effective access endpoint
+type EffectiveResponse struct {
+	Grants []rbac.EffectiveGrant `json:"grants"`
+}
+
+// NewEffectiveAccessHandler returns a handler answering which FolderTree templates grant access where,
+// e.g. GET /effective?namespace=ns1 or GET /effective?subject=group:admins. Both parameters may be
+// combined; at least one is required. The handler relies on the server for authentication and
+// authorization, such as the metrics server filter.
+func NewEffectiveAccessHandler(c client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := rbac.EffectiveAccessQuery{Namespace: req.URL.Query().Get("namespace")}
+		if value := req.URL.Query().Get("subject"); value != "" {
+			subject, err := rbac.ParseSubject(value)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			query.Subject = &subject
+		}
+		if query.Namespace == "" && query.Subject == nil {
+			http.Error(w, "at least one of the namespace and subject query parameters is required", http.StatusBadRequest)
+			return
+		}
+
+		grants, err := rbac.EffectiveAccess(req.Context(), c, query)
+		if err != nil {
+			log.Error(err, "Failed to calculate effective access", "namespace", query.Namespace, "subject", req.URL.Query().Get("subject"))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if grants == nil {
+			grants = []rbac.EffectiveGrant{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(EffectiveResponse{Grants: grants}); err != nil {
+			log.Error(err, "Failed to write effective access response")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+func TestAudit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Suite")
+}
+
+var _ = Describe("Effective access handler", func() {
+	var handler http.Handler
+
+	get := func(url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+		return recorder
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name: "team",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "admins",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "admins", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+					}},
+					Namespaces: []string{"ns1", "ns2"},
+				}},
+			},
+		}
+		handler = NewEffectiveAccessHandler(fake.NewClientBuilder().WithScheme(scheme).WithObjects(folderTree).Build())
+	})
+
+	It("should answer queries by namespace and subject as JSON", func() {
+		response := get(EffectivePath + "?namespace=ns1")
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var body EffectiveResponse
+		Expect(json.Unmarshal(response.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Grants).To(HaveLen(1))
+		Expect(body.Grants[0].Tree).To(Equal("org"))
+		Expect(body.Grants[0].Template).To(Equal("admins"))
+
+		response = get(EffectivePath + "?subject=group:admins")
+		Expect(json.Unmarshal(response.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Grants).To(HaveLen(2))
+
+		response = get(EffectivePath + "?subject=group:nobody")
+		Expect(response.Body.String()).To(Equal("{\"grants\":[]}\n"))
+	})
+
+	It("should reject invalid requests", func() {
+		Expect(get(EffectivePath).Code).To(Equal(http.StatusBadRequest))
+		Expect(get(EffectivePath + "?subject=admins").Code).To(Equal(http.StatusBadRequest))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, EffectivePath+"?namespace=ns1", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
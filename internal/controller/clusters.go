@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// ClusterResyncPeriod is the interval at which FolderTrees with spec.clusters are reconciled, since
+// changes to their RoleBindings in remote clusters are not watched
+const ClusterResyncPeriod = 5 * time.Minute
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+
+// clusterSecrets returns the cluster Secrets selected by spec.clusters. Secrets are read with the
+// APIReader so that the controller does not cache all Secrets of the cluster.
+func (r *FolderTreeReconciler) clusterSecrets(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]corev1.Secret, error) {
+	return rbac.SelectClusters(ctx, r.secretReader(), r.ClusterSecretNamespace, folderTree)
+}
+
+// secretReader returns the reader for cluster Secrets
+func (r *FolderTreeReconciler) secretReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// clusterClient returns a client for the remote cluster of a kubeconfig Secret
+func (r *FolderTreeReconciler) clusterClient(secret *corev1.Secret) (client.Client, error) {
+	if r.NewClusterClient != nil {
+		return r.NewClusterClient(secret)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[rbac.ClusterKubeconfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig in Secret '%s': %v", secret.Name, err)
+	}
+	return client.New(config, client.Options{Scheme: r.Scheme})
+}
+
+// syncClusters brings the RoleBindings of the remote clusters selected by spec.clusters to the
+// desired state of the FolderTree and reports each cluster in status.clusters. RoleBindings are
+// removed from clusters that are no longer selected. A failing cluster does not affect the others
+// or this cluster.
+func (r *FolderTreeReconciler) syncClusters(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, superseded map[string]string,
+	subjectMappings rbac.SubjectMappings) {
+	log := logf.FromContext(ctx)
+
+	if folderTree.Spec.Clusters == nil && len(folderTree.Status.Clusters) == 0 {
+		return
+	}
+	if r.ClusterSecretNamespace == "" {
+		log.Info("Ignoring spec.clusters because multi-cluster propagation is disabled")
+		folderTree.Status.Clusters = nil
+		return
+	}
+
+	secrets, err := r.clusterSecrets(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to select clusters")
+		return
+	}
+
+	desiredTree, err := r.resolveDesiredTree(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to resolve FolderMemberships and FolderTreePatches for remote clusters")
+		return
+	}
+	desiredTree = withoutSupersededNamespaces(desiredTree, superseded)
+
+	selected := make(map[string]bool, len(secrets))
+	statuses := make([]rbacv1alpha1.ClusterStatus, 0, len(secrets))
+	for i := range secrets {
+		secret := &secrets[i]
+		selected[secret.Name] = true
+		status := rbacv1alpha1.ClusterStatus{Name: secret.Name}
+		count, err := r.syncCluster(ctx, secret, desiredTree, subjectMappings)
+		if err != nil {
+			log.Error(err, "Failed to sync RoleBindings of remote cluster", "cluster", secret.Name)
+			status.Message = err.Error()
+		} else {
+			now := metav1.Now()
+			status.Synced = true
+			status.RoleBindings = int32(count)
+			status.LastSyncTime = &now
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Clusters dropped from the selection keep their status until their RoleBindings are removed
+	for _, previous := range folderTree.Status.Clusters {
+		if selected[previous.Name] {
+			continue
+		}
+		if err := r.cleanupCluster(ctx, folderTree.Name, previous.Name); err != nil {
+			log.Error(err, "Failed to remove RoleBindings from deselected cluster", "cluster", previous.Name)
+			previous.Synced = false
+			previous.Message = err.Error()
+			statuses = append(statuses, previous)
+		}
+	}
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	folderTree.Status.Clusters = statuses
+}
+
+// syncCluster applies the desired RoleBindings of a FolderTree to a remote cluster and returns the
+// number of RoleBindings it manages there. Remote RoleBindings have no owner references, since
+// their FolderTree lives in another cluster.
+func (r *FolderTreeReconciler) syncCluster(ctx context.Context, secret *corev1.Secret, desiredTree *rbacv1alpha1.FolderTree,
+	subjectMappings rbac.SubjectMappings) (int, error) {
+	remote, err := r.clusterClient(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	// ServiceAccounts are selected from the remote cluster, where the RoleBindings bind them
+	serviceAccounts, err := rbac.ListServiceAccounts(ctx, remote, desiredTree, rbac.IndexFolderTreeNamespaces(desiredTree))
+	if err != nil {
+		return 0, err
+	}
+
+	builder := &rbac.RoleBindingBuilder{
+		FolderTree:             desiredTree,
+		Scheme:                 r.Scheme,
+		ExcludedNamespaces:     r.ExcludedNamespaces,
+		SubjectMappings:        subjectMappings,
+		ServiceAccounts:        serviceAccounts,
+		DisableOwnerReferences: true,
+		ExtraMetadata:          r.ExtraMetadata,
+		Cache:                  r.DesiredStateCache,
+	}
+	operations, err := rbac.NewDiffAnalyzer(remote, desiredTree, builder).AnalyzeDiff(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to analyze required operations: %v", err)
+	}
+	for _, operation := range operations {
+		if err := executeRemoteOperation(ctx, remote, operation, r.ExtraMetadata); err != nil {
+			return 0, fmt.Errorf("failed to %s: %v", operation.String(), err)
+		}
+	}
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := remote.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": desiredTree.Name,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to count RoleBindings: %v", err)
+	}
+	return len(roleBindingList.Items), nil
+}
+
+// executeRemoteOperation executes a RoleBinding operation in a remote cluster. Creates in
+// namespaces that do not exist in the cluster are skipped.
+func executeRemoteOperation(ctx context.Context, remote client.Client, operation rbac.RoleBindingOperation, extra rbac.ExtraMetadata) error {
+	switch operation.Type {
+	case rbac.OperationCreate:
+		if err := remote.Get(ctx, types.NamespacedName{Name: operation.Namespace}, &corev1.Namespace{}); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		return remote.Create(ctx, operation.DesiredRoleBinding, client.FieldOwner(FieldManager))
+	case rbac.OperationUpdate:
+		if operation.OwnershipOnly {
+			existing := operation.ExistingRoleBinding
+			existing.OwnerReferences = rbac.MergeOwnerReferences(existing, operation.DesiredRoleBinding)
+			return remote.Update(ctx, existing, client.FieldOwner(FieldManager))
+		}
+		return remote.Patch(ctx, rbac.ForServerSideApply(operation.DesiredRoleBinding, extra), client.Apply,
+			client.FieldOwner(FieldManager), client.ForceOwnership)
+	case rbac.OperationDelete:
+		return client.IgnoreNotFound(remote.Delete(ctx, operation.ExistingRoleBinding))
+	default:
+		return fmt.Errorf("unknown operation type: %s", operation.Type)
+	}
+}
+
+// cleanupCluster deletes the RoleBindings of a FolderTree from a remote cluster. A cluster whose
+// Secret was deleted can no longer be reached and is skipped.
+func (r *FolderTreeReconciler) cleanupCluster(ctx context.Context, treeName, cluster string) error {
+	secret := &corev1.Secret{}
+	if err := r.secretReader().Get(ctx, types.NamespacedName{Namespace: r.ClusterSecretNamespace, Name: cluster}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			logf.FromContext(ctx).Info("Cluster Secret not found, leaving its RoleBindings behind", "cluster", cluster)
+			return nil
+		}
+		return err
+	}
+	remote, err := r.clusterClient(secret)
+	if err != nil {
+		return err
+	}
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := remote.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": treeName,
+	}); err != nil {
+		return fmt.Errorf("failed to list RoleBindings for cleanup: %v", err)
+	}
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		if err := client.IgnoreNotFound(remote.Delete(ctx, roleBinding)); err != nil {
+			return fmt.Errorf("failed to delete RoleBinding %s/%s: %v", roleBinding.Namespace, roleBinding.Name, err)
+		}
+	}
+	return nil
+}
+
+// withClusterResync caps the requeue delay of a FolderTree with spec.clusters at ClusterResyncPeriod
+func withClusterResync(folderTree *rbacv1alpha1.FolderTree, requeueAfter time.Duration) time.Duration {
+	if folderTree.Spec.Clusters == nil || (requeueAfter > 0 && requeueAfter < ClusterResyncPeriod) {
+		return requeueAfter
+	}
+	return ClusterResyncPeriod
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+var _ = Describe("FolderTree Controller - Clusters", func() {
+	const (
+		treeName        = "test-clusters"
+		secretNamespace = "clusters-secrets"
+		namespaceName   = "clusters-ns"
+		roleBinding     = "foldertree-" + treeName + "-viewers"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+		remote     client.Client
+	)
+
+	treeKey := types.NamespacedName{Name: treeName}
+	remoteKey := types.NamespacedName{Namespace: namespaceName, Name: roleBinding}
+
+	createClusterSecret := func(name string) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: secretNamespace,
+				Labels:    map[string]string{rbac.ClusterSecretLabel: "true", "region": "east"},
+			},
+			Data: map[string][]byte{rbac.ClusterKubeconfigKey: []byte("unused")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+		})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		for _, name := range []string{secretNamespace, namespaceName} {
+			namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+		}
+
+		remote = fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}},
+		).Build()
+		reconciler = &FolderTreeReconciler{
+			Client:                 k8sClient,
+			Scheme:                 k8sClient.Scheme(),
+			Recorder:               record.NewFakeRecorder(10),
+			ClusterSecretNamespace: secretNamespace,
+			NewClusterClient: func(*corev1.Secret) (client.Client, error) {
+				return remote, nil
+			},
+		}
+		createClusterSecret("east-1")
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: treeName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Clusters: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "web",
+					Namespaces: []string{namespaceName},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			folderTree := &rbacv1alpha1.FolderTree{}
+			if err := k8sClient.Get(ctx, treeKey, folderTree); err != nil {
+				return
+			}
+			folderTree.Finalizers = nil
+			Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	})
+
+	It("should create the RoleBindings in the selected clusters and report them", func() {
+		folderTree := reconcileAndGet(ctx, reconciler, treeName)
+
+		remoteRoleBinding := &rbacv1.RoleBinding{}
+		Expect(remote.Get(ctx, remoteKey, remoteRoleBinding)).To(Succeed())
+		Expect(remoteRoleBinding.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", treeName))
+		Expect(remoteRoleBinding.OwnerReferences).To(BeEmpty())
+
+		Expect(folderTree.Status.Clusters).To(HaveLen(1))
+		Expect(folderTree.Status.Clusters[0].Name).To(Equal("east-1"))
+		Expect(folderTree.Status.Clusters[0].Synced).To(BeTrue())
+		Expect(folderTree.Status.Clusters[0].RoleBindings).To(Equal(int32(1)))
+		Expect(folderTree.Status.Clusters[0].LastSyncTime).NotTo(BeNil())
+
+		// The RoleBinding in this cluster is still created
+		Expect(k8sClient.Get(ctx, remoteKey, &rbacv1.RoleBinding{})).To(Succeed())
+	})
+
+	It("should report clusters that cannot be reached without failing the FolderTree", func() {
+		reconciler.NewClusterClient = nil
+
+		folderTree := reconcileAndGet(ctx, reconciler, treeName)
+		Expect(folderTree.Status.Clusters).To(HaveLen(1))
+		Expect(folderTree.Status.Clusters[0].Synced).To(BeFalse())
+		Expect(folderTree.Status.Clusters[0].Message).To(ContainSubstring("invalid kubeconfig in Secret 'east-1'"))
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+	})
+
+	It("should remove the RoleBindings from clusters that are no longer selected", func() {
+		reconcileAndGet(ctx, reconciler, treeName)
+		Expect(remote.Get(ctx, remoteKey, &rbacv1.RoleBinding{})).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, treeKey, folderTree)).To(Succeed())
+		folderTree.Spec.Clusters = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "west"}}
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+
+		folderTree = reconcileAndGet(ctx, reconciler, treeName)
+		Expect(folderTree.Status.Clusters).To(BeEmpty())
+		Expect(remote.Get(ctx, remoteKey, &rbacv1.RoleBinding{})).NotTo(Succeed())
+	})
+
+	It("should remove the RoleBindings from remote clusters when the FolderTree is deleted", func() {
+		reconcileAndGet(ctx, reconciler, treeName)
+		Expect(remote.Get(ctx, remoteKey, &rbacv1.RoleBinding{})).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, &rbacv1alpha1.FolderTree{ObjectMeta: metav1.ObjectMeta{Name: treeName}})).To(Succeed())
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: treeKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Get(ctx, remoteKey, &rbacv1.RoleBinding{})).NotTo(Succeed())
+	})
+
+	It("should resync FolderTrees with spec.clusters periodically", func() {
+		folderTree := &rbacv1alpha1.FolderTree{Spec: rbacv1alpha1.FolderTreeSpec{Clusters: &metav1.LabelSelector{}}}
+		Expect(withClusterResync(folderTree, 0)).To(Equal(ClusterResyncPeriod))
+		Expect(withClusterResync(folderTree, time.Minute)).To(Equal(time.Minute))
+		Expect(withClusterResync(folderTree, time.Hour)).To(Equal(ClusterResyncPeriod))
+		Expect(withClusterResync(&rbacv1alpha1.FolderTree{}, 0)).To(BeZero())
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// slowClient delays RoleBinding creates and records how many run at the same time
+type slowClient struct {
+	client.Client
+	inFlight, maxInFlight atomic.Int32
+}
+
+func (c *slowClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*rbacv1.RoleBinding); ok {
+		current := c.inFlight.Add(1)
+		defer c.inFlight.Add(-1)
+		for {
+			highest := c.maxInFlight.Load()
+			if current <= highest || c.maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("FolderTree Controller - Concurrent Operations", func() {
+	const resourceName = "test-concurrency"
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		namespaces         []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespaces = nil
+		for i := range 6 {
+			namespace := fmt.Sprintf("concurrency-ns-%d", i)
+			namespaces = append(namespaces, namespace)
+			namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name: "concurrency-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+					Namespaces: namespaces,
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			for _, namespace := range namespaces {
+				roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "foldertree-test-concurrency-viewers", Namespace: namespace}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, roleBinding))).To(Succeed())
+			}
+		})
+	})
+
+	It("should execute the operations of several namespaces at a time, up to the limit", func() {
+		slow := &slowClient{Client: k8sClient}
+		reconciler := &FolderTreeReconciler{Client: slow, Scheme: k8sClient.Scheme(), MaxConcurrentOperations: 3}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(slow.maxInFlight.Load()).To(BeNumerically(">", 1))
+		Expect(slow.maxInFlight.Load()).To(BeNumerically("<=", 3))
+		for _, namespace := range namespaces {
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-concurrency-viewers", Namespace: namespace}, roleBinding)).To(Succeed())
+		}
+	})
+
+	It("should execute the operations of one namespace at a time by default", func() {
+		slow := &slowClient{Client: k8sClient}
+		reconciler := &FolderTreeReconciler{Client: slow, Scheme: k8sClient.Scheme()}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(slow.maxInFlight.Load()).To(BeNumerically("==", 1))
+	})
+
+	It("should aggregate failures without stopping the other namespaces", func() {
+		roleBindingResource := schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}
+		failing := &failingClient{Client: k8sClient, failures: map[string][]error{
+			"create/concurrency-ns-1": {apierrors.NewForbidden(roleBindingResource, "foldertree-test-concurrency-viewers", nil)},
+			"create/concurrency-ns-4": {apierrors.NewForbidden(roleBindingResource, "foldertree-test-concurrency-viewers", nil)},
+		}}
+		reconciler := &FolderTreeReconciler{Client: failing, Scheme: k8sClient.Scheme(), MaxConcurrentOperations: 4}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		var partialErr *partialApplyError
+		Expect(errors.As(err, &partialErr)).To(BeTrue())
+		Expect(partialErr.Total).To(Equal(6))
+		Expect(partialErr.Failures).To(HaveLen(2))
+		Expect([]string{partialErr.Failures[0].Operation.Namespace, partialErr.Failures[1].Operation.Namespace}).To(
+			ConsistOf("concurrency-ns-1", "concurrency-ns-4"))
+
+		for _, namespace := range []string{"concurrency-ns-0", "concurrency-ns-2", "concurrency-ns-3", "concurrency-ns-5"} {
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-concurrency-viewers", Namespace: namespace}, roleBinding)).To(Succeed())
+		}
+	})
+
+	It("should keep the order of the operations within a namespace", func() {
+		operations := []rbac.RoleBindingOperation{
+			{Type: rbac.OperationDelete, Namespace: "ns-a"},
+			{Type: rbac.OperationCreate, Namespace: "ns-b"},
+			{Type: rbac.OperationCreate, Namespace: "ns-a"},
+		}
+
+		groups := groupOperationsByNamespace(operations)
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].key).To(Equal("ns-a"))
+		Expect(groups[0].operations).To(Equal([]rbac.RoleBindingOperation{operations[0], operations[2]}))
+		Expect(groups[1].key).To(Equal("ns-b"))
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// EventReasonConflict is recorded on a FolderTree when a RoleBinding cannot be created because an
+// unmanaged RoleBinding of the same name exists
+const EventReasonConflict = "Conflict"
+
+// nameConflictError is returned for create operations whose RoleBinding name is taken by a
+// RoleBinding the FolderTree does not manage
+type nameConflictError struct {
+	Namespace string
+	Name      string
+	Tree      string
+}
+
+// Error implements the error interface
+func (e *nameConflictError) Error() string {
+	return fmt.Sprintf("RoleBinding '%s' already exists in namespace '%s' and is not managed by FolderTree '%s'",
+		e.Name, e.Namespace, e.Tree)
+}
+
+// checkNameConflict returns a *nameConflictError when a RoleBinding that is not managed by the
+// FolderTree of the desired RoleBinding already has its name, instead of the AlreadyExists error
+// the create would fail with. A RoleBinding the FolderTree does manage, e.g. one it controls whose
+// tree label was tampered with, is returned instead, so that it is applied rather than created.
+func (r *FolderTreeReconciler) checkNameConflict(ctx context.Context, desired *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	existing := &rbacv1.RoleBinding{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tree := desired.Labels["foldertree.rbac.kubevirt.io/tree"]
+	if existing.Labels["foldertree.rbac.kubevirt.io/tree"] == tree || controllingTree(existing) == tree {
+		return existing, nil
+	}
+	return nil, &nameConflictError{Namespace: desired.Namespace, Name: desired.Name, Tree: tree}
+}
+
+// setConflictCondition sets the Conflict condition listing the RoleBindings that could not be
+// created because of an unmanaged RoleBinding of the same name, and removes it when there are none
+func (r *FolderTreeReconciler) setConflictCondition(folderTree *rbacv1alpha1.FolderTree, err error) {
+	var conflicts []string
+	var partialErr *partialApplyError
+	if errors.As(err, &partialErr) {
+		for _, failure := range partialErr.Failures {
+			var conflictErr *nameConflictError
+			if errors.As(failure.Err, &conflictErr) {
+				conflicts = append(conflicts, fmt.Sprintf("%s/%s", conflictErr.Namespace, conflictErr.Name))
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeConflict)
+		return
+	}
+
+	listed := conflicts
+	if len(listed) > maxMissingListed {
+		listed = append(listed[:maxMissingListed:maxMissingListed], fmt.Sprintf("and %d more", len(conflicts)-maxMissingListed))
+	}
+	message := fmt.Sprintf("%d RoleBinding(s) not created because unmanaged RoleBindings of the same name exist: %s",
+		len(conflicts), strings.Join(listed, ", "))
+	setConditionMessage(folderTree, rbacv1alpha1.ConditionTypeConflict, message)
+}
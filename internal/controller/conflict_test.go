@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Name Conflicts", func() {
+	const (
+		treeName      = "test-name-conflicts"
+		namespaceName = "name-conflicts-ns"
+		roleBinding   = "foldertree-" + treeName + "-viewers"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+		recorder   *record.FakeRecorder
+	)
+
+	roleBindingKey := types.NamespacedName{Namespace: namespaceName, Name: roleBinding}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &FolderTreeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		// A hand-made RoleBinding that happens to have the generated name
+		unmanaged := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: roleBinding, Namespace: namespaceName},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "alice", APIGroup: "rbac.authorization.k8s.io"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+		}
+		Expect(k8sClient.Create(ctx, unmanaged)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: roleBinding, Namespace: namespaceName},
+			}))).To(Succeed())
+		})
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: treeName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "web",
+					Namespaces: []string{namespaceName},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	})
+
+	It("should report a Conflict instead of taking over an unmanaged RoleBinding of the same name", func() {
+		folderTree, err := tryReconcileAndGet(ctx, reconciler, treeName)
+		Expect(err).To(HaveOccurred())
+
+		condition := meta.FindStatusCondition(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeConflict)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Message).To(Equal("1 RoleBinding(s) not created because unmanaged RoleBindings of the same name exist: " +
+			namespaceName + "/" + roleBinding))
+		Expect(recorder.Events).To(Receive(Equal("Warning Conflict Cannot create RoleBinding " + namespaceName + "/" + roleBinding +
+			" for template viewers: RoleBinding '" + roleBinding + "' already exists in namespace '" + namespaceName +
+			"' and is not managed by FolderTree '" + treeName + "'")))
+
+		existing := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, roleBindingKey, existing)).To(Succeed())
+		Expect(existing.RoleRef.Name).To(Equal("admin"))
+		Expect(existing.Labels).NotTo(HaveKey("foldertree.rbac.kubevirt.io/tree"))
+	})
+
+	It("should clear the Conflict once the unmanaged RoleBinding is removed", func() {
+		_, err := tryReconcileAndGet(ctx, reconciler, treeName)
+		Expect(err).To(HaveOccurred())
+
+		Expect(k8sClient.Delete(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: roleBinding, Namespace: namespaceName},
+		})).To(Succeed())
+		folderTree, err := tryReconcileAndGet(ctx, reconciler, treeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeConflict)).To(BeFalse())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+		managed := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, roleBindingKey, managed)).To(Succeed())
+		Expect(managed.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", treeName))
+	})
+})
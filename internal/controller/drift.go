@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// maxDriftedListed is the maximum number of drifted RoleBindings named in the Drifted condition message
+const maxDriftedListed = 10
+
+// setDriftedCondition sets the Drifted condition when the drift policy is Warn and RoleBindings
+// have been edited out-of-band, and removes it otherwise. The status is persisted by the
+// following updateStatus call.
+func (r *FolderTreeReconciler) setDriftedCondition(folderTree *rbacv1alpha1.FolderTree, drift []rbac.RoleBindingOperation) {
+	if folderTree.Spec.DriftPolicy != rbacv1alpha1.DriftPolicyWarn || len(drift) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeDrifted)
+		return
+	}
+
+	var names []string
+	for i, operation := range drift {
+		if i == maxDriftedListed {
+			names = append(names, fmt.Sprintf("and %d more", len(drift)-maxDriftedListed))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s/%s", operation.Namespace, operation.ExistingRoleBinding.Name))
+	}
+	message := fmt.Sprintf("%d RoleBinding(s) modified outside of the FolderTree and not reverted (driftPolicy Warn): %s",
+		len(drift), strings.Join(names, ", "))
+
+	for i, condition := range folderTree.Status.Conditions {
+		if condition.Type == rbacv1alpha1.ConditionTypeDrifted {
+			folderTree.Status.Conditions[i].Message = message
+			return
+		}
+	}
+	folderTree.Status.Conditions = append(folderTree.Status.Conditions, metav1.Condition{
+		Type:               rbacv1alpha1.ConditionTypeDrifted,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             rbacv1alpha1.ConditionTypeDrifted,
+		Message:            message,
+	})
+}
+
+// driftPolicyPredicate filters out RoleBinding update events of FolderTrees whose drift policy
+// is Ignore, as such edits are left alone anyway. Deletions are always passed on so that
+// deleted RoleBindings are recreated.
+func driftPolicyPredicate(c client.Client) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			treeName := e.ObjectNew.GetLabels()["foldertree.rbac.kubevirt.io/tree"]
+			if treeName == "" {
+				return true
+			}
+			folderTree := &rbacv1alpha1.FolderTree{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: treeName}, folderTree); err != nil {
+				return true
+			}
+			return folderTree.Spec.DriftPolicy != rbacv1alpha1.DriftPolicyIgnore
+		},
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Drift Policy", func() {
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	// createAndEdit creates a FolderTree with the given drift policy, reconciles it and then
+	// adds a subject to the managed RoleBinding out-of-band
+	createAndEdit := func(resourceName, namespace string, policy rbacv1alpha1.DriftPolicy) types.NamespacedName {
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName,
+			},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "drift-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "viewers",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "viewers",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+				DriftPolicy: policy,
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		rb := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-" + resourceName + "-viewers", Namespace: namespace}, rb)).To(Succeed())
+		rb.Subjects = append(rb.Subjects, rbacv1.Subject{
+			Kind:     "User",
+			Name:     "out-of-band",
+			APIGroup: "rbac.authorization.k8s.io",
+		})
+		Expect(k8sClient.Update(ctx, rb)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		return typeNamespacedName
+	}
+
+	It("should revert out-of-band edits with the default Enforce policy", func() {
+		typeNamespacedName := createAndEdit("test-drift-enforce", "drift-enforce-ns", "")
+
+		rb := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-drift-enforce-viewers", Namespace: "drift-enforce-ns"}, rb)).To(Succeed())
+		Expect(rb.Subjects).To(HaveLen(1))
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeDrifted)).To(BeFalse())
+	})
+
+	It("should keep and report out-of-band edits with the Warn policy", func() {
+		typeNamespacedName := createAndEdit("test-drift-warn", "drift-warn-ns", rbacv1alpha1.DriftPolicyWarn)
+
+		rb := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-drift-warn-viewers", Namespace: "drift-warn-ns"}, rb)).To(Succeed())
+		Expect(rb.Subjects).To(HaveLen(2))
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeDrifted)).To(BeTrue())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+	})
+
+	It("should keep out-of-band edits silently with the Ignore policy", func() {
+		typeNamespacedName := createAndEdit("test-drift-ignore", "drift-ignore-ns", rbacv1alpha1.DriftPolicyIgnore)
+
+		rb := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-drift-ignore-viewers", Namespace: "drift-ignore-ns"}, rb)).To(Succeed())
+		Expect(rb.Subjects).To(HaveLen(2))
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeDrifted)).To(BeFalse())
+	})
+})
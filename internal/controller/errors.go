@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+const (
+	// maxReportedFailures caps the number of failed operations listed in the PartiallyApplied condition
+	maxReportedFailures = 20
+
+	// retryRequeueAfter is the delay before retrying operations that failed with a conflict or a
+	// transient error, unless the API server suggested a longer one
+	retryRequeueAfter = 5 * time.Second
+)
+
+// failureClass classifies why a RoleBinding operation failed, to decide whether retrying can help
+type failureClass string
+
+const (
+	// failureConflict is an optimistic locking conflict; retrying with a fresh copy resolves it
+	failureConflict failureClass = "Conflict"
+	// failureTransient is a timeout, throttling or unavailable API server; retrying later resolves it
+	failureTransient failureClass = "Transient"
+	// failureForbidden means the controller lacks the permissions to apply the RoleBinding
+	failureForbidden failureClass = "Forbidden"
+	// failurePermanent is any other error, e.g. an invalid or unmanaged RoleBinding
+	failurePermanent failureClass = "Permanent"
+)
+
+// classifyError returns the failure class of an error returned by the API server
+func classifyError(err error) failureClass {
+	var netErr net.Error
+	switch {
+	case apierrors.IsConflict(err):
+		return failureConflict
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return failureForbidden
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err), apierrors.IsTooManyRequests(err),
+		apierrors.IsServiceUnavailable(err), apierrors.IsInternalError(err), apierrors.IsUnexpectedServerError(err),
+		errors.As(err, &netErr):
+		return failureTransient
+	default:
+		return failurePermanent
+	}
+}
+
+// retryable reports whether an operation that failed with this class may succeed when retried
+func (c failureClass) retryable() bool {
+	return c == failureConflict || c == failureTransient
+}
+
+// operationFailure records a RoleBinding operation that could not be executed
+type operationFailure struct {
+	Operation rbac.RoleBindingOperation
+	Err       error
+	Class     failureClass
+}
+
+// partialApplyError is returned when some RoleBinding operations failed while the
+// remaining operations were still applied. The message lists every failed
+// template/namespace combination (up to maxReportedFailures) and why it failed.
+type partialApplyError struct {
+	Failures []operationFailure
+	Total    int
+}
+
+// Error implements the error interface
+func (e *partialApplyError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d RoleBinding operations failed: ", len(e.Failures), e.Total)
+
+	for i, failure := range e.Failures {
+		if i == maxReportedFailures {
+			fmt.Fprintf(&sb, "; and %d more", len(e.Failures)-maxReportedFailures)
+			break
+		}
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		fmt.Fprintf(&sb, "%s template '%s' in namespace '%s': %v",
+			failure.Operation.Type, failure.Operation.TemplateName(), failure.Operation.Namespace, failure.Err)
+	}
+
+	return sb.String()
+}
+
+// Retryable reports whether every failure is a conflict or transient error, so the FolderTree
+// can be requeued after RequeueAfter instead of going through exponential backoff
+func (e *partialApplyError) Retryable() bool {
+	for _, failure := range e.Failures {
+		if !failure.Class.retryable() {
+			return false
+		}
+	}
+	return len(e.Failures) > 0
+}
+
+// RequeueAfter returns the delay before the failed operations should be retried: the longest
+// delay suggested by the API server (e.g. Retry-After when throttled), or retryRequeueAfter
+func (e *partialApplyError) RequeueAfter() time.Duration {
+	requeueAfter := retryRequeueAfter
+	for _, failure := range e.Failures {
+		if seconds, ok := apierrors.SuggestsClientDelay(failure.Err); ok {
+			requeueAfter = max(requeueAfter, time.Duration(seconds)*time.Second)
+		}
+	}
+	return requeueAfter
+}
+
+// Unwrap returns the underlying operation errors
+func (e *partialApplyError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
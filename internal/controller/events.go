@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// Event reasons recorded on a FolderTree for operations on its RoleBindings
+const (
+	EventReasonRoleBindingCreated = "RoleBindingCreated"
+	EventReasonRoleBindingUpdated = "RoleBindingUpdated"
+	EventReasonRoleBindingDeleted = "RoleBindingDeleted"
+	EventReasonRoleBindingAdopted = "RoleBindingAdopted"
+	EventReasonOperationFailed    = "RoleBindingOperationFailed"
+)
+
+// errNamespaceNotFound is returned for create operations skipped because the namespace does not exist yet
+var errNamespaceNotFound = errors.New("namespace not found")
+
+// recordOperationEvent emits an Event on the FolderTree for an executed RoleBinding operation,
+// naming the RoleBinding, namespace and template. Failed operations are recorded as warnings.
+// Nothing is recorded when the reconciler has no Recorder.
+func (r *FolderTreeReconciler) recordOperationEvent(folderTree *rbacv1alpha1.FolderTree, operation rbac.RoleBindingOperation, err error) {
+	if r.Recorder == nil {
+		return
+	}
+
+	name := ""
+	if operation.ExistingRoleBinding != nil {
+		name = operation.ExistingRoleBinding.Name
+	} else if operation.DesiredRoleBinding != nil {
+		name = operation.DesiredRoleBinding.Name
+	}
+	target := fmt.Sprintf("RoleBinding %s/%s for template %s", operation.Namespace, name, operation.TemplateName())
+
+	var conflictErr *nameConflictError
+	if errors.As(err, &conflictErr) {
+		r.Recorder.Eventf(folderTree, corev1.EventTypeWarning, EventReasonConflict,
+			"Cannot create %s: %v", target, err)
+		return
+	}
+	if err != nil {
+		r.Recorder.Eventf(folderTree, corev1.EventTypeWarning, EventReasonOperationFailed,
+			"Failed to %s %s: %v", operation.Type, target, err)
+		return
+	}
+
+	switch operation.Type {
+	case rbac.OperationCreate:
+		r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonRoleBindingCreated, "Created %s", target)
+	case rbac.OperationUpdate:
+		if operation.Adopt {
+			r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonRoleBindingAdopted, "Adopted %s", target)
+			return
+		}
+		r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonRoleBindingUpdated, "Updated %s", target)
+	case rbac.OperationDelete:
+		r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonRoleBindingDeleted, "Deleted %s", target)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Events", func() {
+	var (
+		ctx        context.Context
+		recorder   *record.FakeRecorder
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = record.NewFakeRecorder(20)
+		reconciler = &FolderTreeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+	})
+
+	It("should record an Event for every RoleBinding operation", func() {
+		resourceName := "test-events"
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		for _, name := range []string{"events-ns-1", "events-ns-2"} {
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			})).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName,
+			},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "events-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "viewers",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "viewers",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+						Namespaces: []string{"events-ns-1", "events-ns-2", "events-missing-ns"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("recording creates, but not the create skipped for the missing namespace")
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(<-recorder.Events).To(Equal("Normal RoleBindingCreated Created RoleBinding events-ns-1/foldertree-test-events-viewers for template viewers"))
+		Expect(<-recorder.Events).To(Equal("Normal RoleBindingCreated Created RoleBinding events-ns-2/foldertree-test-events-viewers for template viewers"))
+
+		By("recording updates and deletes")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[0].Namespaces = []string{"events-ns-1"}
+		folderTree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "edit"
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).To(ContainElement(ContainSubstring("RoleBindingDeleted Deleted RoleBinding events-ns-2/foldertree-test-events-viewers for template viewers")))
+		Expect(events).To(ContainElement(ContainSubstring("RoleBinding events-ns-1/foldertree-test-events-viewers for template viewers")))
+	})
+})
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// minExpirationRequeue is the shortest delay for reconciling a FolderTree when a template expires,
+// so a template expiring while it is being reconciled doesn't requeue it in a tight loop
+const minExpirationRequeue = time.Second
+
+// untilNextExpiration returns the time until the soonest template expiration in status.expirations,
+// or zero when no template expires
+func untilNextExpiration(folderTree *rbacv1alpha1.FolderTree) time.Duration {
+	if len(folderTree.Status.Expirations) == 0 {
+		return 0
+	}
+	return max(time.Until(folderTree.Status.Expirations[0].ExpiresAt.Time), minExpirationRequeue)
+}
+
+// expirationPassed reports whether a template in status.expirations has expired since the
+// FolderTree was last reconciled
+func expirationPassed(folderTree *rbacv1alpha1.FolderTree) bool {
+	return len(folderTree.Status.Expirations) > 0 && !time.Now().Before(folderTree.Status.Expirations[0].ExpiresAt.Time)
+}
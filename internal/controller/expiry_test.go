@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Template Expiration", func() {
+	const (
+		resourceName = "test-expiration"
+		namespace    = "expiration-ns"
+	)
+	var (
+		ctx                context.Context
+		reconciler         *FolderTreeReconciler
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	listRoleBindings := func() []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		return roleBindings.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	It("should requeue for the next expiration and remove the RoleBindings of expired templates", func() {
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		expiresAt := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "expiration-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+							{
+								Name:      "oncall",
+								Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "oncall", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+								ExpiresAt: &expiresAt,
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(listRoleBindings()).To(HaveLen(2))
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.Expirations).To(HaveLen(1))
+		Expect(folderTree.Status.Expirations[0].Template).To(Equal("oncall"))
+		Expect(folderTree.Status.Expirations[0].Folder).To(Equal("expiration-folder"))
+		Expect(folderTree.Status.Expirations[0].ExpiresAt.Equal(&expiresAt)).To(BeTrue())
+
+		By("letting the template expire")
+		folderTree.Spec.Folders[0].RoleBindingTemplates[1].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+
+		result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(listRoleBindings()).To(ConsistOf(HaveField("RoleRef.Name", "view")))
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.Expirations).To(BeEmpty())
+	})
+
+	It("should not take the fast path once a recorded expiration has passed", func() {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(expirationPassed(folderTree)).To(BeFalse())
+
+		folderTree.Status.Expirations = []rbacv1alpha1.TemplateExpiration{
+			{Template: "oncall", ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour))},
+		}
+		Expect(expirationPassed(folderTree)).To(BeFalse())
+		Expect(untilNextExpiration(folderTree)).To(BeNumerically("~", time.Hour, time.Minute))
+
+		folderTree.Status.Expirations[0].ExpiresAt = metav1.NewTime(time.Now().Add(-time.Second))
+		Expect(expirationPassed(folderTree)).To(BeTrue())
+		Expect(untilNextExpiration(folderTree)).To(Equal(minExpirationRequeue))
+	})
+})
@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// observedStates remembers, per FolderTree, the state the last successful reconcile found nothing
+// left to do in. Periodic resyncs, status-only updates and the namespace events of the FolderTrees
+// a namespace matches all reconcile FolderTrees whose spec did not change, so in big clusters most
+// reconciles see an unchanged FolderTree; comparing against the observed state lets them skip the diff.
+// The states are kept in memory only, so the first reconcile after a restart is always a full one,
+// e.g. to pick up changed controller flags.
+type observedStates struct {
+	mu     sync.Mutex
+	states map[string]observedState
+}
+
+// observedState is the state of a FolderTree and its managed objects after a successful reconcile
+type observedState struct {
+	// resourceVersion of the FolderTree after the status update; any change to the FolderTree,
+	// including status tampering, changes it
+	resourceVersion string
+
+	// managedObjectsHash covers the RoleBindings, NetworkPolicies, ResourceQuotas, namespaces and
+	// FolderMemberships of the FolderTree, which of its namespaces are superseded and the
+	// ServiceAccounts it selects
+	managedObjectsHash string
+
+	// contentHash covers the canonical spec, labels, annotations and status of the FolderTree,
+	// so that a new generation that only reorders the spec is recognized
+	contentHash string
+}
+
+// get returns the observed state of a FolderTree
+func (o *observedStates) get(name string) (observedState, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	state, ok := o.states[name]
+	return state, ok
+}
+
+// set records the observed state of a FolderTree
+func (o *observedStates) set(name string, state observedState) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.states == nil {
+		o.states = make(map[string]observedState)
+	}
+	o.states[name] = state
+}
+
+// forget drops the observed state of a FolderTree, so its next reconcile is a full one
+func (o *observedStates) forget(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.states, name)
+}
+
+// observeReconciled records the state of a FolderTree that was just reconciled successfully. A
+// FolderTree whose content cannot be hashed is still recorded, it just never takes the reordering
+// shortcut.
+func (r *FolderTreeReconciler) observeReconciled(folderTree *rbacv1alpha1.FolderTree, managedObjectsHash string) {
+	contentHash, _ := contentHash(folderTree)
+	r.observed.set(folderTree.Name, observedState{
+		resourceVersion:    folderTree.ResourceVersion,
+		managedObjectsHash: managedObjectsHash,
+		contentHash:        contentHash,
+	})
+}
+
+// upToDate reports whether the FolderTree is Ready for its current generation and neither it nor
+// its managed objects changed since the last successful reconcile, and none of its templates expired since
+func (r *FolderTreeReconciler) upToDate(folderTree *rbacv1alpha1.FolderTree, managedObjectsHash string) bool {
+	if folderTree.Status.ProcessedGeneration != folderTree.Generation || expirationPassed(folderTree) ||
+		!meta.IsStatusConditionTrue(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeReady) {
+		return false
+	}
+	state, ok := r.observed.get(folderTree.Name)
+	return ok && state.resourceVersion == folderTree.ResourceVersion && state.managedObjectsHash == managedObjectsHash
+}
+
+// onlyReordered reports whether the FolderTree is Ready for a previous generation that the current
+// one only reorders, e.g. a GitOps tool sorting folders or subjects differently, and neither its
+// managed objects nor anything else about it changed since the last successful reconcile.
+// Reordering never changes the desired state, so only the processed generation needs an update.
+func (r *FolderTreeReconciler) onlyReordered(folderTree *rbacv1alpha1.FolderTree, managedObjectsHash string) bool {
+	if folderTree.Status.ProcessedGeneration == folderTree.Generation || expirationPassed(folderTree) ||
+		!meta.IsStatusConditionTrue(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeReady) {
+		return false
+	}
+	state, ok := r.observed.get(folderTree.Name)
+	if !ok || state.managedObjectsHash != managedObjectsHash {
+		return false
+	}
+	contentHash, err := contentHash(folderTree)
+	return err == nil && state.contentHash == contentHash
+}
+
+// contentHash hashes the canonical spec, labels, annotations and status of a FolderTree. The
+// processed generation is left out of the status, since it differs after every spec change.
+func contentHash(folderTree *rbacv1alpha1.FolderTree) (string, error) {
+	specHash, err := rbac.SpecHash(folderTree)
+	if err != nil {
+		return "", err
+	}
+	status := folderTree.Status.DeepCopy()
+	status.ProcessedGeneration = 0
+	data, err := json.Marshal([]any{specHash, folderTree.Labels, folderTree.Annotations, status})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// managedObjectsHash hashes the resource versions of everything besides the FolderTree itself
+// that a reconcile depends on: the RoleBindings, NetworkPolicies and ResourceQuotas labeled with
+// the tree, the FolderMemberships and FolderTreePatches targeting it and the namespaces it
+// manages (see managedNamespaces), as well as the FolderTrees superseding its namespaces, the
+// subjects of the SubjectMappings it references and the ServiceAccounts selected by its
+// serviceAccountSelectors.
+// All reads are served from the cache.
+func (r *FolderTreeReconciler) managedObjectsHash(ctx context.Context, folderTree *rbacv1alpha1.FolderTree,
+	memberships []rbacv1alpha1.FolderMembership, patches []rbacv1alpha1.FolderTreePatch, namespaces []string, superseded map[string]string,
+	subjectMappings rbac.SubjectMappings, serviceAccounts rbac.ServiceAccounts) (string, error) {
+	var entries []string
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return "", err
+	}
+	for _, roleBinding := range roleBindingList.Items {
+		entries = append(entries, fmt.Sprintf("rolebinding/%s/%s@%s", roleBinding.Namespace, roleBinding.Name, roleBinding.ResourceVersion))
+	}
+
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	if err := r.List(ctx, networkPolicyList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return "", err
+	}
+	for _, networkPolicy := range networkPolicyList.Items {
+		entries = append(entries, fmt.Sprintf("networkpolicy/%s/%s@%s", networkPolicy.Namespace, networkPolicy.Name, networkPolicy.ResourceVersion))
+	}
+
+	resourceQuotaList := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, resourceQuotaList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return "", err
+	}
+	// The quota controller keeps updating the status, so the content is hashed instead of the resource version
+	for _, resourceQuota := range resourceQuotaList.Items {
+		content, err := json.Marshal([]any{resourceQuota.Spec, resourceQuota.Labels, resourceQuota.Annotations, resourceQuota.OwnerReferences})
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, fmt.Sprintf("resourcequota/%s/%s@%x", resourceQuota.Namespace, resourceQuota.Name, sha256.Sum256(content)))
+	}
+
+	for _, membership := range memberships {
+		entries = append(entries, fmt.Sprintf("membership/%s/%s@%s", membership.Namespace, membership.Name, membership.ResourceVersion))
+	}
+
+	for _, patch := range patches {
+		entries = append(entries, fmt.Sprintf("patch/%s/%s@%s", patch.Namespace, patch.Name, patch.ResourceVersion))
+	}
+
+	for _, namespace := range namespaces {
+		ns := &corev1.Namespace{}
+		err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		// A missing namespace has an empty resource version
+		entries = append(entries, fmt.Sprintf("namespace/%s@%s", namespace, ns.ResourceVersion))
+	}
+
+	for namespace, tree := range superseded {
+		entries = append(entries, fmt.Sprintf("superseded/%s@%s", namespace, tree))
+	}
+
+	// Only the subjects of a mapping matter, and a missing mapping has no content
+	for _, ref := range rbac.SubjectRefs(folderTree) {
+		subjects, ok := subjectMappings[ref]
+		if !ok {
+			entries = append(entries, fmt.Sprintf("subjectmapping/%s@", ref))
+			continue
+		}
+		content, err := json.Marshal(subjects)
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, fmt.Sprintf("subjectmapping/%s@%x", ref, sha256.Sum256(content)))
+	}
+
+	// Only FolderTrees with serviceAccountSelectors have ServiceAccounts to resolve
+	for _, serviceAccount := range serviceAccounts {
+		entries = append(entries, fmt.Sprintf("serviceaccount/%s/%s@%s", serviceAccount.Namespace, serviceAccount.Name, serviceAccount.ResourceVersion))
+	}
+
+	slices.Sort(entries)
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
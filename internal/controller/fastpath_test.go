@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+var _ = Describe("FolderTree Controller - Fast Path", func() {
+	const resourceName = "test-fast-path"
+	var (
+		ctx                context.Context
+		reconciler         *FolderTreeReconciler
+		typeNamespacedName types.NamespacedName
+	)
+
+	// reconcileSkipped reconciles the FolderTree and reports whether the fast path skipped the diff
+	reconcileSkipped := func() bool {
+		before := testutil.ToFloat64(metrics.ReconcilesSkipped)
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		return testutil.ToFloat64(metrics.ReconcilesSkipped) > before
+	}
+
+	listRoleBindings := func(namespace string) []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		return roleBindings.Items
+	}
+
+	createNamespace := func(name string) {
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	It("should skip reconciles only while neither the FolderTree nor its managed objects change", func() {
+		createNamespace("fast-path-ns")
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "fast-path-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{"fast-path-ns", "fast-path-later-ns"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		By("reconciling until nothing is left to do")
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(listRoleBindings("fast-path-ns")).To(HaveLen(1))
+		Expect(reconcileSkipped()).To(BeTrue())
+
+		By("creating an unrelated namespace")
+		createNamespace("fast-path-unrelated-ns")
+		Expect(reconcileSkipped()).To(BeTrue())
+
+		By("creating a namespace of the tree")
+		createNamespace("fast-path-later-ns")
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(listRoleBindings("fast-path-later-ns")).To(HaveLen(1))
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(reconcileSkipped()).To(BeTrue())
+
+		By("deleting a managed RoleBinding")
+		roleBinding := listRoleBindings("fast-path-ns")[0]
+		Expect(k8sClient.Delete(ctx, &roleBinding)).To(Succeed())
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(listRoleBindings("fast-path-ns")).To(HaveLen(1))
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(reconcileSkipped()).To(BeTrue())
+
+		By("tampering with the status")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Status.AppliedBindings = nil
+		Expect(k8sClient.Status().Update(ctx, folderTree)).To(Succeed())
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.AppliedBindings).To(HaveLen(2))
+	})
+
+	It("should skip reconciles of generations that only reorder the spec", func() {
+		typeNamespacedName = types.NamespacedName{Name: resourceName + "-reorder"}
+		createNamespace("fast-path-reorder-a")
+		createNamespace("fast-path-reorder-b")
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: typeNamespacedName.Name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "fast-path-reorder-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "viewers",
+								Subjects: []rbacv1.Subject{
+									{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"},
+									{Kind: "Group", Name: "auditors", APIGroup: "rbac.authorization.k8s.io"},
+								},
+								RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{"fast-path-reorder-a", "fast-path-reorder-b"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		By("reconciling until nothing is left to do")
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(reconcileSkipped()).To(BeTrue())
+		roleBindings := listRoleBindings("fast-path-reorder-a")
+		Expect(roleBindings).To(HaveLen(1))
+		Expect(roleBindings[0].Subjects[0].Name).To(Equal("auditors"))
+
+		By("reordering the namespaces and subjects")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folder := &folderTree.Spec.Folders[0]
+		folder.Namespaces[0], folder.Namespaces[1] = folder.Namespaces[1], folder.Namespaces[0]
+		subjects := folder.RoleBindingTemplates[0].Subjects
+		subjects[0], subjects[1] = subjects[1], subjects[0]
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		Expect(reconcileSkipped()).To(BeTrue())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.ProcessedGeneration).To(Equal(folderTree.Generation))
+		Expect(listRoleBindings("fast-path-reorder-a")[0].ResourceVersion).To(Equal(roleBindings[0].ResourceVersion))
+		Expect(reconcileSkipped()).To(BeTrue())
+
+		By("removing a namespace")
+		folderTree.Spec.Folders[0].Namespaces = folderTree.Spec.Folders[0].Namespaces[:1]
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		Expect(reconcileSkipped()).To(BeFalse())
+		Expect(listRoleBindings("fast-path-reorder-a")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+// CleanupFinalizer is added to FolderTrees when owner references are disabled, so the controller
+// can delete their RoleBindings instead of the garbage collector, to FolderTrees that apply
+// folder metadata to namespaces, so it is removed again, and to FolderTrees with spec.clusters,
+// whose remote RoleBindings no garbage collector can reach
+const CleanupFinalizer = "rbac.kubevirt.io/cleanup-rolebindings"
+
+// finalize removes the folder metadata from the namespaces of a FolderTree being deleted, deletes
+// its RoleBindings, NetworkPolicies and ResourceQuotas, also from remote clusters, and removes the
+// cleanup finalizer.
+// FolderTrees without the finalizer are left to the garbage collector.
+func (r *FolderTreeReconciler) finalize(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(folderTree, CleanupFinalizer) {
+		return nil
+	}
+
+	if err := r.applyNamespaceMetadata(ctx, folderTree.Name, nil); err != nil {
+		return fmt.Errorf("failed to remove folder metadata from namespaces: %v", err)
+	}
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list RoleBindings for cleanup: %v", err)
+	}
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		log.Info("Deleting RoleBinding of deleted FolderTree", "name", roleBinding.Name, "namespace", roleBinding.Namespace)
+		if err := client.IgnoreNotFound(r.Delete(ctx, roleBinding)); err != nil {
+			return fmt.Errorf("failed to delete RoleBinding %s/%s: %v", roleBinding.Namespace, roleBinding.Name, err)
+		}
+	}
+
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	if err := r.List(ctx, networkPolicyList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list NetworkPolicies for cleanup: %v", err)
+	}
+	for i := range networkPolicyList.Items {
+		networkPolicy := &networkPolicyList.Items[i]
+		log.Info("Deleting NetworkPolicy of deleted FolderTree", "name", networkPolicy.Name, "namespace", networkPolicy.Namespace)
+		if err := client.IgnoreNotFound(r.Delete(ctx, networkPolicy)); err != nil {
+			return fmt.Errorf("failed to delete NetworkPolicy %s/%s: %v", networkPolicy.Namespace, networkPolicy.Name, err)
+		}
+	}
+
+	resourceQuotaList := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, resourceQuotaList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list ResourceQuotas for cleanup: %v", err)
+	}
+	for i := range resourceQuotaList.Items {
+		resourceQuota := &resourceQuotaList.Items[i]
+		log.Info("Deleting ResourceQuota of deleted FolderTree", "name", resourceQuota.Name, "namespace", resourceQuota.Namespace)
+		if err := client.IgnoreNotFound(r.Delete(ctx, resourceQuota)); err != nil {
+			return fmt.Errorf("failed to delete ResourceQuota %s/%s: %v", resourceQuota.Namespace, resourceQuota.Name, err)
+		}
+	}
+
+	for _, cluster := range folderTree.Status.Clusters {
+		log.Info("Deleting RoleBindings of deleted FolderTree from remote cluster", "cluster", cluster.Name)
+		if err := r.cleanupCluster(ctx, folderTree.Name, cluster.Name); err != nil {
+			return fmt.Errorf("failed to delete RoleBindings from cluster '%s': %v", cluster.Name, err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(folderTree, CleanupFinalizer)
+	if err := r.Update(ctx, folderTree); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	metrics.ForgetFolderTree(folderTree.Name)
+	return nil
+}
+
+// mapRoleBindingToFolderTree reconciles the FolderTree named by the tree label of a RoleBinding,
+// or of another object the controller manages such as a NetworkPolicy or ResourceQuota
+func mapRoleBindingToFolderTree(_ context.Context, obj client.Object) []reconcile.Request {
+	treeName := obj.GetLabels()["foldertree.rbac.kubevirt.io/tree"]
+	if treeName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: treeName}}}
+}
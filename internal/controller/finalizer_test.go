@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Without Owner References", func() {
+	const (
+		resourceName = "test-no-ownerrefs"
+		namespace    = "no-ownerrefs-ns"
+	)
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	listRoleBindings := func() []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		return roleBindings.Items
+	}
+
+	reconcileWith := func(reconciler *FolderTreeReconciler) {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "no-ownerrefs-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			current := &rbacv1alpha1.FolderTree{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, current); err == nil {
+				controllerutil.RemoveFinalizer(current, CleanupFinalizer)
+				Expect(k8sClient.Update(ctx, current)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, current))).To(Succeed())
+			}
+			for _, roleBinding := range listRoleBindings() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &roleBinding))).To(Succeed())
+			}
+		})
+	})
+
+	It("should migrate owned RoleBindings and clean them up with a finalizer", func() {
+		By("reconciling with owner references")
+		reconcileWith(&FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()})
+		Expect(listRoleBindings()).To(HaveLen(1))
+		Expect(listRoleBindings()[0].OwnerReferences).To(HaveLen(1))
+
+		By("switching owner references off")
+		reconciler := &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), DisableOwnerReferences: true}
+		reconcileWith(reconciler)
+		Expect(listRoleBindings()).To(HaveLen(1))
+		Expect(listRoleBindings()[0].OwnerReferences).To(BeEmpty())
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Finalizers).To(ContainElement(CleanupFinalizer))
+
+		By("deleting the FolderTree")
+		Expect(k8sClient.Delete(ctx, folderTree)).To(Succeed())
+		reconcileWith(reconciler)
+		Expect(listRoleBindings()).To(BeEmpty())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).NotTo(Succeed())
+	})
+
+	It("should create RoleBindings without owner references", func() {
+		reconcileWith(&FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), DisableOwnerReferences: true})
+
+		roleBindings := listRoleBindings()
+		Expect(roleBindings).To(HaveLen(1))
+		Expect(roleBindings[0].OwnerReferences).To(BeEmpty())
+		Expect(roleBindings[0].Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", resourceName))
+		Expect(mapRoleBindingToFolderTree(ctx, &roleBindings[0])).To(Equal([]reconcile.Request{{NamespacedName: typeNamespacedName}}))
+	})
+})
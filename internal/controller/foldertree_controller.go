@@ -18,24 +18,57 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
-	"kubevirt.io/folders/internal/rbac"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/internal/tracing"
+	"kubevirt.io/folders/pkg/rbac"
 )
 
+// FieldManager is the field manager of the RoleBindings the controller creates and applies
+const FieldManager = "foldertree-controller"
+
+// operationRetryBackoff bounds the retries of a single RoleBinding operation within a reconcile;
+// operations still failing after that are retried by requeueing the FolderTree
+var operationRetryBackoff = wait.Backoff{
+	Steps:    3,
+	Duration: 50 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
 // FolderTreeReconciler reconciles a FolderTree object.
 // The controller processes the split structure design where:
 // - spec.tree defines hierarchical relationships between folders
@@ -48,80 +81,598 @@ import (
 type FolderTreeReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Recorder emits Events on the FolderTree for every RoleBinding operation
+	Recorder record.EventRecorder
+
+	// ExcludedNamespaces never receive RoleBindings from any FolderTree
+	ExcludedNamespaces []string
+
+	// StatusLimits caps the lists kept in FolderTree status
+	StatusLimits StatusLimits
+
+	// RecordEffectiveBindings enables status.effectiveBindings, the templates in effect per namespace.
+	// It is off by default because of its size on large trees.
+	RecordEffectiveBindings bool
+
+	// ValidateOpenShiftGroups checks the Group subjects of the RoleBindings against OpenShift's
+	// user.openshift.io Groups and exposes the number of missing ones as the dangling subjects metric
+	ValidateOpenShiftGroups bool
+
+	// SubjectVerifier confirms that the User and Group subjects of the RoleBindings exist; the
+	// unresolved ones are listed in the SubjectUnresolved condition. Nil disables the check.
+	SubjectVerifier rbac.SubjectVerifier
+
+	// DisableOwnerReferences manages RoleBindings by their labels only, without owner references to
+	// the FolderTree, for GitOps tools that prune objects with cross-scope owner references.
+	// A finalizer on the FolderTree then makes the controller delete its RoleBindings.
+	DisableOwnerReferences bool
+
+	// ExtraMetadata is set on every managed RoleBinding on top of the controller's own labels and
+	// annotations, e.g. for chargeback or policy engines
+	ExtraMetadata rbac.ExtraMetadata
+
+	// DesiredStateCache is shared with the webhook, so that reconciles reuse the RoleBindings
+	// calculated at admission for an unchanged FolderTree. Nil calculates them on every reconcile.
+	DesiredStateCache *rbac.DesiredStateCache
+
+	// AdoptRoleBindings lets FolderTrees annotated with rbac.AdoptAnnotation take over unmanaged
+	// RoleBindings that exactly match one of their RoleBindings instead of creating duplicates
+	AdoptRoleBindings bool
+
+	// TreeSelector restricts the controller to the FolderTrees whose labels match it, so several
+	// controller replicas can each manage a shard of the FolderTrees. Nil selects all FolderTrees.
+	TreeSelector labels.Selector
+
+	// AllowNamespaceOverlap lets several FolderTrees list the same namespace, which the FolderTree
+	// of highest spec.priority manages while the others report it in the Superseded condition
+	AllowNamespaceOverlap bool
+
+	// MaxConcurrentOperations is the number of namespaces whose RoleBinding operations are executed
+	// concurrently within a reconcile. Values below 1 execute them one namespace at a time.
+	MaxConcurrentOperations int
+
+	// WriteLimiter is a token bucket shared by all FolderTrees that every RoleBinding create, update
+	// and delete waits for. Nil leaves RoleBinding writes unlimited.
+	WriteLimiter *rate.Limiter
+
+	// ClusterSecretNamespace holds the kubeconfig Secrets of the remote clusters that spec.clusters
+	// selects from. Empty disables multi-cluster propagation.
+	ClusterSecretNamespace string
+
+	// NewClusterClient builds the client of a remote cluster from its kubeconfig Secret.
+	// Defaults to a client for the kubeconfig in rbac.ClusterKubeconfigKey.
+	NewClusterClient func(secret *corev1.Secret) (client.Client, error)
+
+	// APIReader reads cluster Secrets without caching all Secrets; when nil, Client is used
+	APIReader client.Reader
+
+	// observed remembers the state of successfully reconciled FolderTrees for the fast path
+	observed observedStates
+
+	// namespaces maps namespaces to the FolderTrees managing them for the namespace watch
+	namespaces namespaceIndex
+
+	// pending maps namespaces that do not exist yet to the FolderTrees waiting for them, so that
+	// namespace creation only enqueues those FolderTrees
+	pending namespaceIndex
+
+	// serviceAccountNamespaces maps namespaces to the FolderTrees whose serviceAccountSelectors
+	// select ServiceAccounts from them, for the ServiceAccount watch
+	serviceAccountNamespaces namespaceIndex
 }
 
 // +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertrees,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertrees/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertrees/finalizers,verbs=update
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldermemberships,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldermemberships/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertreepatches,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertreepatches/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=subjectmappings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *FolderTreeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *FolderTreeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := logf.FromContext(ctx)
 
+	start := time.Now()
+	defer func() { metrics.ObserveReconcile(start, err) }()
+
 	// Fetch the FolderTree instance
 	folderTree := &rbacv1alpha1.FolderTree{}
-	err := r.Get(ctx, req.NamespacedName, folderTree)
+	err = r.Get(ctx, req.NamespacedName, folderTree)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("FolderTree resource not found. Ignoring since object must be deleted")
+			metrics.ForgetFolderTree(req.Name)
+			r.observed.forget(req.Name)
+			r.namespaces.remove(req.Name)
+			r.pending.remove(req.Name)
+			r.serviceAccountNamespaces.remove(req.Name)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get FolderTree")
 		return ctrl.Result{}, err
 	}
 
-	// No finalizers needed - RoleBindings have owner references and will be garbage collected automatically
+	// Another shard manages FolderTrees outside the tree selector
+	if !r.selects(folderTree) {
+		log.V(1).Info("FolderTree does not match the tree selector of this controller, ignoring", "selector", r.TreeSelector.String())
+		metrics.ForgetFolderTree(req.Name)
+		r.observed.forget(req.Name)
+		r.namespaces.remove(req.Name)
+		r.pending.remove(req.Name)
+		r.serviceAccountNamespaces.remove(req.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// RoleBindings with owner references are garbage collected; without them, the cleanup
+	// finalizer makes the controller delete them. It also releases folder metadata from namespaces.
+	if !folderTree.DeletionTimestamp.IsZero() {
+		r.observed.forget(folderTree.Name)
+		r.namespaces.remove(folderTree.Name)
+		r.pending.remove(folderTree.Name)
+		r.serviceAccountNamespaces.remove(folderTree.Name)
+		return ctrl.Result{}, r.finalize(ctx, folderTree)
+	}
+	if (r.DisableOwnerReferences || appliesNamespaceMetadata(folderTree) || folderTree.Spec.Clusters != nil) && !controllerutil.ContainsFinalizer(folderTree, CleanupFinalizer) {
+		controllerutil.AddFinalizer(folderTree, CleanupFinalizer)
+		if err := r.Update(ctx, folderTree); err != nil {
+			log.Error(err, "Failed to add the cleanup finalizer")
+			return ctrl.Result{}, err
+		}
+	}
 
 	// Note: Validation is now handled by the validating webhook
 
+	// Restore the spec of a previous revision on request; the patch triggers the reconcile of the restored spec
+	if _, ok := folderTree.Annotations[rbacv1alpha1.RollbackToAnnotation]; ok {
+		if err := r.rollback(ctx, folderTree); err != nil {
+			log.Error(err, "Failed to roll back")
+			r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Leave the managed RoleBindings untouched while reconciliation is suspended
+	if folderTree.Spec.Suspend {
+		log.Info("Reconciliation is suspended, skipping RoleBinding operations")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeSuspended,
+			"Reconciliation is suspended by spec.suspend; RoleBindings are not being updated")
+		return ctrl.Result{}, nil
+	}
+
+	// Route events of the namespaces the FolderTree manages to it
+	memberships, err := r.listTargetingMemberships(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to list FolderMemberships")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	patches, err := r.listTargetingPatches(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to list FolderTreePatches")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	patternNamespaces, err := r.listPatternNamespaces(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to list namespaces matching namespace patterns")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	namespaces := managedNamespaces(folderTree, memberships, patches, patternNamespaces)
+	r.namespaces.set(folderTree.Name, namespaces)
+
+	// Namespaces shared with FolderTrees of higher priority are left to them
+	superseded, err := r.findSupersededNamespaces(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to find superseded namespaces")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the subjectRefs of role binding templates
+	subjectMappings, err := rbac.ListSubjectMappings(ctx, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to list SubjectMappings")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the serviceAccountSelectors of role binding templates, and route events of the
+	// ServiceAccounts they select from to the FolderTree
+	serviceAccounts, err := rbac.ListServiceAccounts(ctx, r.Client, folderTree, namespaces)
+	if err != nil {
+		log.Error(err, "Failed to list ServiceAccounts")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	if rbac.UsesServiceAccountSelectors(folderTree) {
+		r.serviceAccountNamespaces.set(folderTree.Name, namespaces)
+	} else {
+		r.serviceAccountNamespaces.remove(folderTree.Name)
+	}
+
+	// Skip the diff when neither the FolderTree nor its managed objects changed since the last
+	// successful reconcile
+	managedObjectsHash, hashErr := r.managedObjectsHash(ctx, folderTree, memberships, patches, namespaces, superseded, subjectMappings, serviceAccounts)
+	if hashErr != nil {
+		log.Error(hashErr, "Failed to hash managed objects, performing a full reconcile")
+	} else if folderTree.Spec.Clusters == nil && r.upToDate(folderTree, managedObjectsHash) {
+		log.V(1).Info("FolderTree and its managed objects are unchanged, skipping reconcile")
+		metrics.ReconcilesSkipped.Inc()
+		return ctrl.Result{RequeueAfter: untilNextExpiration(folderTree)}, nil
+	} else if folderTree.Spec.Clusters == nil && r.onlyReordered(folderTree, managedObjectsHash) {
+		log.V(1).Info("FolderTree spec was only reordered, skipping reconcile", "generation", folderTree.Generation)
+		metrics.ReconcilesSkipped.Inc()
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeReady, "FolderTree processed successfully")
+		r.observeReconciled(folderTree, managedObjectsHash)
+		return ctrl.Result{RequeueAfter: untilNextExpiration(folderTree)}, nil
+	}
+
+	// Report namespaces that were deleted after being added, pruning them from the spec if requested
+	missingNamespaces, err := r.findMissingNamespaces(ctx, folderTree)
+	if err != nil {
+		log.Error(err, "Failed to check for missing namespaces")
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+	if folderTree.Spec.PruneMissingNamespaces && len(missingNamespaces) > 0 {
+		if pruneErr := r.pruneMissingNamespaces(ctx, folderTree, missingNamespaces); pruneErr != nil {
+			log.Error(pruneErr, "Failed to prune missing namespaces", "namespaces", missingNamespaces)
+		} else {
+			log.Info("Pruned missing namespaces from the folders", "namespaces", missingNamespaces)
+			missingNamespaces = nil
+		}
+	}
+	r.setNamespaceMissingCondition(folderTree, missingNamespaces)
+	folderTree.Status.PendingNamespaces = missingNamespaces
+	r.pending.set(folderTree.Name, missingNamespaces)
+	r.setSupersededCondition(folderTree, superseded)
+	r.setSubjectMappingMissingCondition(folderTree, subjectMappings.Missing(folderTree))
+
+	// Summarize template inheritance per tree node for kubectl describe
+	folderTree.Status.Inheritance = rbac.CalculateInheritance(folderTree)
+	folderTree.Status.Expirations = rbac.UpcomingExpirations(folderTree, time.Now())
+
 	// Use diff analyzer to determine and execute only the required operations
-	if err := r.processOperations(ctx, folderTree); err != nil {
+	requeueAfter, err := r.processOperations(ctx, folderTree, superseded, subjectMappings, serviceAccounts)
+	r.setConflictCondition(folderTree, err)
+
+	// Record what is actually applied, even after a partial failure, for the webhook's escalation checks
+	if recordErr := r.recordAppliedBindings(ctx, folderTree); recordErr != nil {
+		log.Error(recordErr, "Failed to record applied RoleBindings")
+	}
+
+	if err != nil {
 		log.Error(err, "Failed to process RoleBinding operations")
-		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProcessingFailed, err.Error())
+		conditionType := rbacv1alpha1.ConditionTypeProcessingFailed
+		var partialErr *partialApplyError
+		if errors.As(err, &partialErr) {
+			conditionType = rbacv1alpha1.ConditionTypePartiallyApplied
+			// Conflicts and transient errors are retried soon, without backing off the whole tree
+			if partialErr.Retryable() {
+				r.updateStatus(ctx, folderTree, conditionType, err.Error())
+				return ctrl.Result{RequeueAfter: partialErr.RequeueAfter()}, nil
+			}
+		}
+		r.updateStatus(ctx, folderTree, conditionType, err.Error())
 		return ctrl.Result{}, err // RequeueAfter is ignored when returning error - controller-runtime uses exponential backoff
 	}
 
+	// A wave-based rollout still has namespaces waiting for their turn
+	if requeueAfter > 0 {
+		r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeRolloutInProgress, rolloutMessage(folderTree.Status.Rollout))
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Propagate the RoleBindings to the remote clusters selected by spec.clusters
+	r.syncClusters(ctx, folderTree, superseded, subjectMappings)
+
+	// Keep the applied spec for rollback
+	if revisionErr := r.recordRevision(ctx, folderTree); revisionErr != nil {
+		log.Error(revisionErr, "Failed to record the FolderTree revision")
+	}
+
 	// Update status
 	r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeReady, "FolderTree processed successfully")
 
-	return ctrl.Result{}, nil // No requeue needed - watches handle all drift detection
+	// The hash was taken before the operations, so when they changed RoleBindings the next
+	// reconcile is a full one that confirms there is nothing left to do
+	if hashErr == nil {
+		r.observeReconciled(folderTree, managedObjectsHash)
+	}
+
+	// Watches handle all drift detection in this cluster; only the next template to expire and
+	// remote clusters need a timed requeue
+	return ctrl.Result{RequeueAfter: withClusterResync(folderTree, untilNextExpiration(folderTree))}, nil
 }
 
 // processOperations uses the diff analyzer to determine what operations are needed
-// and executes only the required changes (create/update/delete)
-func (r *FolderTreeReconciler) processOperations(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+// and executes only the required changes (create/update/delete).
+// Superseded namespaces are left out of the desired state.
+// A non-zero duration is returned when a wave-based rollout has remaining work.
+func (r *FolderTreeReconciler) processOperations(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, superseded map[string]string,
+	subjectMappings rbac.SubjectMappings, serviceAccounts rbac.ServiceAccounts) (_ time.Duration, err error) {
 	log := logf.FromContext(ctx)
+	ctx, span := tracing.Tracer().Start(ctx, "ProcessOperations", trace.WithAttributes(attribute.String("foldertree", folderTree.Name)))
+	defer func() { endSpan(span, err) }()
+
+	// Add namespaces of approved FolderMemberships and approved FolderTreePatches to the desired state
+	desiredTree, err := r.resolveDesiredTree(ctx, folderTree)
+	if err != nil {
+		return 0, err
+	}
+	desiredTree = withoutSupersededNamespaces(desiredTree, superseded)
+
+	// Namespaces opted out by their owners are excluded like those of the controller
+	optedOut, err := r.findOptedOutNamespaces(ctx, desiredTree)
+	if err != nil {
+		return 0, err
+	}
+	if len(optedOut) > 0 {
+		log.Info("Skipping namespaces opted out of folders", "namespaces", optedOut)
+	}
+
+	// Stamp folder labels and annotations onto member namespaces before granting access to them
+	if err := r.applyNamespaceMetadata(ctx, folderTree.Name, desiredNamespaceMetadata(desiredTree, r.ExcludedNamespaces)); err != nil {
+		return 0, err
+	}
+	if err := r.reconcileNetworkPolicies(ctx, desiredTree); err != nil {
+		return 0, err
+	}
+	if err := r.reconcileResourceQuotas(ctx, desiredTree); err != nil {
+		return 0, err
+	}
 
 	// Create diff analyzer to determine what operations are needed
 	builder := &rbac.RoleBindingBuilder{
-		FolderTree: folderTree,
-		Scheme:     r.Scheme, // Include scheme for owner reference
+		FolderTree:         desiredTree,
+		Scheme:             r.Scheme, // Include scheme for owner reference
+		ExcludedNamespaces: append(slices.Clip(r.ExcludedNamespaces), optedOut...),
+		SubjectMappings:    subjectMappings,
+		ServiceAccounts:    serviceAccounts,
+		ExtraMetadata:      r.ExtraMetadata,
+		Cache:              r.DesiredStateCache,
+
+		DisableOwnerReferences: r.DisableOwnerReferences,
 	}
 
-	diffAnalyzer := rbac.NewDiffAnalyzer(r.Client, folderTree, builder)
+	// Roll up the templates in effect per namespace for security reviews
+	// and count Group subjects missing from OpenShift or unknown to the subject verifier
+	folderTree.Status.EffectiveBindings = nil
+	if r.RecordEffectiveBindings || r.ValidateOpenShiftGroups || r.SubjectVerifier != nil {
+		desired, err := rbac.CalculateDesiredRoleBindings(desiredTree, builder)
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate effective bindings: %v", err)
+		}
+		if r.RecordEffectiveBindings {
+			folderTree.Status.EffectiveBindings = rbac.CalculateEffectiveBindings(desired)
+		}
+		if r.ValidateOpenShiftGroups {
+			r.recordDanglingGroups(ctx, folderTree.Name, desired)
+		}
+		if r.SubjectVerifier != nil {
+			r.verifySubjects(ctx, folderTree, desired)
+		}
+	}
+
+	diffAnalyzer := rbac.NewDiffAnalyzer(r.Client, desiredTree, builder)
+	diffAnalyzer.Adopt = r.AdoptRoleBindings && rbac.AdoptionRequested(folderTree)
 
 	// Analyze what operations are needed
-	operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+	analyzeCtx, analyzeSpan := tracing.Tracer().Start(ctx, "AnalyzeDiff")
+	operations, err := diffAnalyzer.AnalyzeDiff(analyzeCtx)
+	analyzeSpan.SetAttributes(attribute.Int("operations", len(operations)))
+	endSpan(analyzeSpan, err)
 	if err != nil {
-		return fmt.Errorf("failed to analyze required operations: %v", err)
+		return 0, fmt.Errorf("failed to analyze required operations: %v", err)
 	}
 
-	// Execute each operation
-	for _, operation := range operations {
-		if err := r.executeOperation(ctx, operation); err != nil {
-			log.Error(err, "Failed to execute operation", "operation", operation.String())
-			return err
+	// Out-of-band edits left in place by the drift policy
+	if len(diffAnalyzer.Drift) > 0 {
+		log.Info("RoleBindings modified out-of-band were not reverted", "driftPolicy", folderTree.Spec.DriftPolicy, "count", len(diffAnalyzer.Drift))
+	}
+	r.setDriftedCondition(folderTree, diffAnalyzer.Drift)
+
+	// Report every namespace, updated by executeOperations as the operations are executed
+	folderTree.Status.Namespaces = namespaceStatuses(desiredTree, operations, folderTree.Status.PendingNamespaces,
+		r.ExcludedNamespaces, optedOut, superseded)
+
+	// Apply changes gradually when a rollout strategy is configured
+	if folderTree.Spec.RolloutStrategy != nil {
+		return r.processRolloutWave(ctx, folderTree, operations)
+	}
+	folderTree.Status.Rollout = nil
+
+	return 0, r.executeOperations(ctx, folderTree, operations)
+}
+
+// executeOperations executes the given operations on up to MaxConcurrentOperations namespaces at a
+// time. The operations of a namespace run in order, so a delete still precedes the create replacing
+// it. Conflicts and transient errors are retried a few times; a failing operation does not stop the
+// remaining ones, and all failures are aggregated into a partialApplyError.
+// Namespaces are traced and timed grouped by their folder.
+func (r *FolderTreeReconciler) executeOperations(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, operations []rbac.RoleBindingOperation) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "ExecuteOperations", trace.WithAttributes(
+		attribute.String("foldertree", folderTree.Name),
+		attribute.Int("operations", len(operations)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	r.markProgressing(ctx, folderTree, len(operations))
+
+	namespaces := groupOperationsByNamespace(operations)
+	namespaceFailures := make([][]operationFailure, len(namespaces))
+
+	var pool errgroup.Group
+	pool.SetLimit(r.maxConcurrentOperations())
+	var folders sync.WaitGroup
+	for _, folder := range groupNamespacesByFolder(namespaces) {
+		folderCtx, folderSpan := tracing.Tracer().Start(ctx, "Folder", trace.WithAttributes(
+			attribute.String("foldertree", folderTree.Name),
+			attribute.String("folder", folder.key),
+			attribute.Int("namespaces", len(folder.namespaces)),
+		))
+		start := time.Now()
+
+		var folderNamespaces sync.WaitGroup
+		for _, i := range folder.namespaces {
+			folderNamespaces.Add(1)
+			pool.Go(func() error {
+				defer folderNamespaces.Done()
+				namespaceFailures[i] = r.executeNamespaceOperations(folderCtx, folderTree, namespaces[i])
+				return nil
+			})
+		}
+
+		// End the folder's span once all of its namespaces are done
+		folders.Add(1)
+		go func() {
+			defer folders.Done()
+			folderNamespaces.Wait()
+			metrics.ObserveFolderOperations(folderTree.Name, folder.key, start)
+			failed := 0
+			for _, i := range folder.namespaces {
+				failed += len(namespaceFailures[i])
+			}
+			if failed > 0 {
+				folderSpan.SetStatus(codes.Error, fmt.Sprintf("%d operations failed", failed))
+			}
+			folderSpan.End()
+		}()
+	}
+	_ = pool.Wait()
+	folders.Wait()
+
+	var failures []operationFailure
+	for i, namespaceFailure := range namespaceFailures {
+		recordNamespaceOutcome(folderTree, namespaces[i], namespaceFailure)
+		failures = append(failures, namespaceFailure...)
+	}
+	if len(failures) > 0 {
+		return &partialApplyError{Failures: failures, Total: len(operations)}
+	}
+
+	return nil
+}
+
+// executeNamespaceOperations executes the operations of a namespace in order
+func (r *FolderTreeReconciler) executeNamespaceOperations(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, namespace operationGroup) []operationFailure {
+	ctx, span := tracing.Tracer().Start(ctx, "Namespace", trace.WithAttributes(
+		attribute.String("namespace", namespace.key),
+		attribute.Int("operations", len(namespace.operations)),
+	))
+	defer span.End()
+
+	var failures []operationFailure
+	for _, operation := range namespace.operations {
+		if err := r.executeTracedOperation(ctx, folderTree, operation); err != nil {
+			failures = append(failures, operationFailure{Operation: operation, Err: err, Class: classifyError(err)})
 		}
-		log.Info("Successfully executed operation", "operation", operation.String())
 	}
+	if len(failures) > 0 {
+		span.SetStatus(codes.Error, fmt.Sprintf("%d of %d operations failed", len(failures), len(namespace.operations)))
+	}
+	return failures
+}
+
+// maxConcurrentOperations returns the number of namespaces whose operations run concurrently
+func (r *FolderTreeReconciler) maxConcurrentOperations() int {
+	if r.MaxConcurrentOperations < 1 {
+		return 1
+	}
+	return r.MaxConcurrentOperations
+}
+
+// executeTracedOperation executes a single operation in its own span, recording the result as a
+// metric and an Event. Operations on namespaces that no longer exist are skipped.
+func (r *FolderTreeReconciler) executeTracedOperation(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, operation rbac.RoleBindingOperation) (err error) {
+	log := logf.FromContext(ctx)
+	ctx, span := tracing.Tracer().Start(ctx, "Operation", trace.WithAttributes(operationAttributes(operation)...))
+	defer func() { endSpan(span, err) }()
+
+	if operation.Type == rbac.OperationCreate {
+		rbac.StampApprovedBy(operation.DesiredRoleBinding, folderTree)
+	}
+	err = r.executeOperationWithRetry(ctx, &operation)
+	skipped := errors.Is(err, errNamespaceNotFound)
+	if skipped {
+		err = nil
+		span.SetAttributes(attribute.Bool("skipped", true))
+	}
+	metrics.RecordOperation(folderTree.Name, string(operation.Type), err)
+	if err != nil {
+		log.Error(err, "Failed to execute operation", "operation", operation.String())
+		r.recordOperationEvent(folderTree, operation, err)
+		return err
+	}
+	if skipped {
+		return nil
+	}
+	log.Info("Successfully executed operation", "operation", operation.String())
+	r.recordOperationEvent(folderTree, operation, nil)
+	return nil
+}
+
+// recordAppliedBindings stores a digest of every RoleBinding currently managed by the FolderTree
+// in status.appliedBindings. The status is persisted by the following updateStatus call.
+func (r *FolderTreeReconciler) recordAppliedBindings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return err
+	}
+
+	applied := make(map[string]string, len(roleBindingList.Items))
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		if !roleBinding.DeletionTimestamp.IsZero() {
+			continue
+		}
+		applied[rbac.AppliedBindingKey(roleBinding)] = rbac.BindingDigest(roleBinding)
+	}
+	folderTree.Status.AppliedBindings = applied
+	metrics.ManagedRoleBindings.WithLabelValues(folderTree.Name).Set(float64(len(applied)))
 
 	return nil
 }
 
-// executeOperation executes a single RoleBinding operation (create/update/delete)
+// executeOperationWithRetry executes a RoleBinding operation, retrying it with operationRetryBackoff
+// while it fails with a conflict or transient error. After a conflict the existing RoleBinding
+// is fetched again so the retry is based on its latest version.
+func (r *FolderTreeReconciler) executeOperationWithRetry(ctx context.Context, operation *rbac.RoleBindingOperation) error {
+	return retry.OnError(operationRetryBackoff, func(err error) bool {
+		class := classifyError(err)
+		if class == failureConflict && operation.ExistingRoleBinding != nil {
+			latest := &rbacv1.RoleBinding{}
+			if getErr := r.Get(ctx, client.ObjectKeyFromObject(operation.ExistingRoleBinding), latest); getErr != nil {
+				return false
+			}
+			operation.ExistingRoleBinding = latest
+		}
+		return class.retryable()
+	}, func() error {
+		return r.executeOperation(ctx, *operation)
+	})
+}
+
+// executeOperation executes a single RoleBinding operation (create/update/delete) once the
+// WriteLimiter allows it
 func (r *FolderTreeReconciler) executeOperation(ctx context.Context, operation rbac.RoleBindingOperation) error {
+	if err := r.waitForWrite(ctx); err != nil {
+		return err
+	}
 	switch operation.Type {
 	case rbac.OperationCreate:
 		return r.executeCreateOperation(ctx, operation)
@@ -134,7 +685,8 @@ func (r *FolderTreeReconciler) executeOperation(ctx context.Context, operation r
 	}
 }
 
-// executeCreateOperation creates a new RoleBinding
+// executeCreateOperation creates a new RoleBinding. Unlike an apply, a create fails when a
+// RoleBinding with the same name exists, so RoleBindings the controller doesn't manage are never taken over.
 func (r *FolderTreeReconciler) executeCreateOperation(ctx context.Context, operation rbac.RoleBindingOperation) error {
 	log := logf.FromContext(ctx)
 
@@ -144,27 +696,44 @@ func (r *FolderTreeReconciler) executeCreateOperation(ctx context.Context, opera
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("Namespace not found, skipping RoleBinding creation", "namespace", operation.Namespace)
-			return nil // Skip if namespace doesn't exist - will be applied when namespace is created
+			return errNamespaceNotFound // Skip if namespace doesn't exist - will be applied when namespace is created
 		}
 		return err
 	}
 
+	existing, err := r.checkNameConflict(ctx, operation.DesiredRoleBinding)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		// The RoleBinding escaped the label-based List of the FolderTree, e.g. because its tree label
+		// was tampered with, and applying it restores the label
+		log.Info("Restoring RoleBinding labels", "name", existing.Name, "namespace", existing.Namespace)
+		return r.Patch(ctx, rbac.ForServerSideApply(operation.DesiredRoleBinding, r.ExtraMetadata), client.Apply,
+			client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+
 	log.Info("Creating RoleBinding", "name", operation.DesiredRoleBinding.Name, "namespace", operation.Namespace)
-	return r.Create(ctx, operation.DesiredRoleBinding)
+	return r.Create(ctx, operation.DesiredRoleBinding, client.FieldOwner(FieldManager))
 }
 
-// executeUpdateOperation updates an existing RoleBinding
+// executeUpdateOperation updates an existing RoleBinding with server-side apply, so only the fields
+// the controller manages are changed and labels or annotations added by other tools are kept
 func (r *FolderTreeReconciler) executeUpdateOperation(ctx context.Context, operation rbac.RoleBindingOperation) error {
 	log := logf.FromContext(ctx)
 
-	// Update the existing RoleBinding with desired values
 	existing := operation.ExistingRoleBinding
-	existing.Subjects = operation.DesiredRoleBinding.Subjects
-	existing.RoleRef = operation.DesiredRoleBinding.RoleRef
-	existing.Labels = operation.DesiredRoleBinding.Labels
+	if operation.OwnershipOnly {
+		// Owner references set by earlier controller versions belong to another field manager
+		// and can only be removed by an update
+		existing.OwnerReferences = rbac.MergeOwnerReferences(existing, operation.DesiredRoleBinding)
+		log.Info("Updating RoleBinding owner references", "name", existing.Name, "namespace", existing.Namespace)
+		return r.Update(ctx, existing, client.FieldOwner(FieldManager))
+	}
 
 	log.Info("Updating RoleBinding", "name", existing.Name, "namespace", existing.Namespace)
-	return r.Update(ctx, existing)
+	return r.Patch(ctx, rbac.ForServerSideApply(operation.DesiredRoleBinding, r.ExtraMetadata), client.Apply,
+		client.FieldOwner(FieldManager), client.ForceOwnership)
 }
 
 // executeDeleteOperation deletes an existing RoleBinding
@@ -175,6 +744,24 @@ func (r *FolderTreeReconciler) executeDeleteOperation(ctx context.Context, opera
 	return r.Delete(ctx, operation.ExistingRoleBinding)
 }
 
+// managedConditionTypes are the condition types set by the controller. Conditions of any other
+// type can only have been written by a third party and are dropped on the next status update.
+var managedConditionTypes = map[string]bool{
+	rbacv1alpha1.ConditionTypeReady:                 true,
+	rbacv1alpha1.ConditionTypeProcessingFailed:      true,
+	rbacv1alpha1.ConditionTypePartiallyApplied:      true,
+	rbacv1alpha1.ConditionTypeRolloutInProgress:     true,
+	rbacv1alpha1.ConditionTypeProgressing:           true,
+	rbacv1alpha1.ConditionTypeDrifted:               true,
+	rbacv1alpha1.ConditionTypeSuspended:             true,
+	rbacv1alpha1.ConditionTypeNamespaceMissing:      true,
+	rbacv1alpha1.ConditionTypeSuperseded:            true,
+	rbacv1alpha1.ConditionTypeSubjectMappingMissing: true,
+	rbacv1alpha1.ConditionTypeSubjectUnresolved:     true,
+	rbacv1alpha1.ConditionTypeConflict:              true,
+	rbacv1alpha1.ConditionTypeStatusTruncated:       true,
+}
+
 // updateStatus updates the status of the FolderTree
 func (r *FolderTreeReconciler) updateStatus(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, conditionType, message string) {
 	condition := metav1.Condition{
@@ -185,15 +772,53 @@ func (r *FolderTreeReconciler) updateStatus(ctx context.Context, folderTree *rba
 		Message:            message,
 	}
 
+	// Any other condition means reconciliation has resumed
+	if conditionType != rbacv1alpha1.ConditionTypeSuspended {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeSuspended)
+	}
+
 	// Clear conflicting conditions to ensure clean status
 	switch conditionType {
 	case rbacv1alpha1.ConditionTypeReady:
-		// Remove ProcessingFailed when setting Ready
+		// Remove failure and progress conditions when setting Ready
 		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProcessingFailed)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypePartiallyApplied)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeRolloutInProgress)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProgressing)
 	case rbacv1alpha1.ConditionTypeProcessingFailed:
-		// Remove Ready when setting ProcessingFailed
+		// Remove Ready and PartiallyApplied when setting ProcessingFailed
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeReady)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypePartiallyApplied)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProgressing)
+	case rbacv1alpha1.ConditionTypePartiallyApplied:
+		// Remove Ready and ProcessingFailed when setting PartiallyApplied
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeReady)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProcessingFailed)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProgressing)
+	case rbacv1alpha1.ConditionTypeRolloutInProgress:
+		// Remove Ready and failure conditions while waves are still pending
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeReady)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProcessingFailed)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypePartiallyApplied)
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeProgressing)
+	case rbacv1alpha1.ConditionTypeProgressing:
+		// Remove Ready while the operations are being applied; failure conditions are kept until
+		// the outcome replaces them
 		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeReady)
+	case rbacv1alpha1.ConditionTypeSuspended:
+		// The spec is not being applied, so the FolderTree is not Ready; failure conditions
+		// are kept to show the state reconciliation was suspended in
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeReady)
+	}
+
+	// Drop conditions the controller doesn't own
+	conditions := folderTree.Status.Conditions[:0]
+	for _, existing := range folderTree.Status.Conditions {
+		if managedConditionTypes[existing.Type] {
+			conditions = append(conditions, existing)
+		}
 	}
+	folderTree.Status.Conditions = conditions
 
 	// Update or add the condition
 	updated := false
@@ -208,7 +833,12 @@ func (r *FolderTreeReconciler) updateStatus(ctx context.Context, folderTree *rba
 		folderTree.Status.Conditions = append(folderTree.Status.Conditions, condition)
 	}
 
-	folderTree.Status.ProcessedGeneration = folderTree.Generation
+	// A suspended generation is not processed
+	if conditionType != rbacv1alpha1.ConditionTypeSuspended {
+		folderTree.Status.ProcessedGeneration = folderTree.Generation
+	}
+	statusBytes := enforceStatusLimits(&folderTree.Status, r.StatusLimits)
+	metrics.StatusBytes.WithLabelValues(folderTree.Name).Set(float64(statusBytes))
 
 	// Update status - ignore error as status updates are best-effort
 	_ = r.Status().Update(ctx, folderTree)
@@ -230,28 +860,135 @@ func (r *FolderTreeReconciler) removeCondition(folderTree *rbacv1alpha1.FolderTr
 // SetupWithManager sets up the controller with the Manager.
 // The controller uses an event-driven approach with comprehensive watches:
 // - For(): Watches FolderTree resources for spec changes
-// - Owns(): Watches RoleBinding resources for drift detection (delete/modify events)
-// - Watches(): Watches Namespace resources for new namespace creation
+// - Owns(): Watches RoleBinding resources for drift detection (delete/modify events, modify only unless driftPolicy is Ignore)
+// - Watches(): Watches Namespace resources for the creation of the pending namespaces of a FolderTree and the deletion (NamespaceMissing) of those it manages
+// - Watches(): Watches FolderMembership and FolderTreePatch resources and reconciles the FolderTree they target
+// - Watches(): Watches the metadata of ServiceAccounts in the namespaces of FolderTrees with serviceAccountSelectors
+// - Watches(): With AllowNamespaceOverlap, watches FolderTrees to reconcile the others sharing their namespaces
+// Without owner references, RoleBindings are watched by their tree label instead of Owns().
+// With owner references, a second controller restores the tree label of RoleBindings it was removed from.
 // This eliminates the need for periodic requeuing since all relevant changes trigger reconciliation.
+// FolderTrees outside the TreeSelector are filtered out of the FolderTree and Namespace watches,
+// and Reconcile ignores them for events from the other watches.
+// It also registers the namespace index used by rbac.WhichTreeOwns on the shared cache.
 func (r *FolderTreeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&rbacv1alpha1.FolderTree{}).
-		Owns(&rbacv1.RoleBinding{}). // Handles drift: RoleBinding delete/modify triggers reconciliation
-		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
-			// When a namespace is created/updated, reconcile all FolderTrees
-			// to check if any need to create RoleBindings in the new namespace
-			var requests []reconcile.Request
-			folderTreeList := &rbacv1alpha1.FolderTreeList{}
-			if err := mgr.GetClient().List(ctx, folderTreeList); err != nil {
-				return requests
+	if err := rbac.SetupNamespaceIndex(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&rbacv1alpha1.FolderTree{}, builder.WithPredicates(predicate.NewPredicateFuncs(r.selects)))
+	if r.DisableOwnerReferences {
+		// Without owner references, RoleBindings are mapped to their FolderTree by label
+		controllerBuilder = controllerBuilder.Watches(&rbacv1.RoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(mapRoleBindingToFolderTree),
+			builder.WithPredicates(driftPolicyPredicate(mgr.GetClient()))).
+			Watches(&networkingv1.NetworkPolicy{}, handler.EnqueueRequestsFromMapFunc(mapRoleBindingToFolderTree)).
+			Watches(&corev1.ResourceQuota{}, handler.EnqueueRequestsFromMapFunc(mapRoleBindingToFolderTree),
+				builder.WithPredicates(resourceQuotaChangedPredicate()))
+	} else {
+		// RoleBindings whose tree label was tampered with escape the label-based List of the
+		// FolderTree, so a dedicated controller restores the label from their owner reference
+		if err := ctrl.NewControllerManagedBy(mgr).
+			For(&rbacv1.RoleBinding{}, builder.WithPredicates(predicate.NewPredicateFuncs(treeLabelDrifted))).
+			Named("foldertree-rolebinding-labels").
+			Complete(reconcile.Func(r.repairRoleBindingLabel)); err != nil {
+			return err
+		}
+		controllerBuilder = controllerBuilder.Owns(&rbacv1.RoleBinding{},
+			builder.WithPredicates(driftPolicyPredicate(mgr.GetClient()))). // Handles drift: RoleBinding delete/modify triggers reconciliation
+			Owns(&networkingv1.NetworkPolicy{}).
+			Owns(&corev1.ResourceQuota{}, builder.WithPredicates(resourceQuotaChangedPredicate()))
+	}
+	if r.AllowNamespaceOverlap {
+		controllerBuilder = controllerBuilder.Watches(&rbacv1alpha1.FolderTree{},
+			handler.EnqueueRequestsFromMapFunc(r.mapFolderTreeToOverlappingTrees))
+	}
+	return controllerBuilder.
+		Watches(&corev1.Namespace{}, r.namespaceEventHandler()).
+		Watches(&rbacv1alpha1.SubjectMapping{}, handler.EnqueueRequestsFromMapFunc(r.mapSubjectMappingToFolderTrees)).
+		Watches(serviceAccountMetadata(), handler.EnqueueRequestsFromMapFunc(r.mapServiceAccountToFolderTrees),
+			builder.OnlyMetadata, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&rbacv1alpha1.FolderMembership{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			membership, ok := a.(*rbacv1alpha1.FolderMembership)
+			if !ok {
+				return nil
 			}
-			for _, ft := range folderTreeList.Items {
-				requests = append(requests, reconcile.Request{
-					NamespacedName: types.NamespacedName{Name: ft.Name},
-				})
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: membership.Spec.TreeName}}}
+		})).
+		Watches(&rbacv1alpha1.FolderTreePatch{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+			patch, ok := a.(*rbacv1alpha1.FolderTreePatch)
+			if !ok {
+				return nil
 			}
-			return requests
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: patch.Spec.TreeName}}}
 		})).
 		Named("foldertree").
 		Complete(r)
 }
+
+// namespaceEventHandler reconciles the FolderTrees waiting for a namespace when it is created, and
+// the FolderTrees managing a namespace when it is updated or deleted
+func (r *FolderTreeReconciler) namespaceEventHandler() handler.EventHandler {
+	enqueue := func(requests []reconcile.Request, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		for _, request := range requests {
+			queue.Add(request)
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(r.mapCreatedNamespaceToFolderTrees(ctx, e.Object), queue)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(r.mapNamespaceToFolderTrees(ctx, e.ObjectNew), queue)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(r.mapNamespaceToFolderTrees(ctx, e.Object), queue)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(r.mapNamespaceToFolderTrees(ctx, e.Object), queue)
+		},
+	}
+}
+
+// mapNamespaceToFolderTrees reconciles the FolderTrees managing a namespace when it is updated or
+// deleted, to report missing namespaces. FolderTrees are looked up in the namespace index
+// maintained by Reconcile, which only holds selected FolderTrees.
+func (r *FolderTreeReconciler) mapNamespaceToFolderTrees(_ context.Context, obj client.Object) []reconcile.Request {
+	return treeRequests(r.namespaces.lookup(obj.GetName()))
+}
+
+// mapCreatedNamespaceToFolderTrees reconciles the FolderTrees listing a namespace in
+// status.pendingNamespaces, or matching it by a namespacePattern, when it is created, so that its
+// RoleBindings are created by that reconcile
+func (r *FolderTreeReconciler) mapCreatedNamespaceToFolderTrees(ctx context.Context, obj client.Object) []reconcile.Request {
+	trees := r.pending.lookup(obj.GetName())
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := r.List(ctx, &folderTreeList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list FolderTrees for namespace patterns", "namespace", obj.GetName())
+		return treeRequests(trees)
+	}
+	for i := range folderTreeList.Items {
+		folderTree := &folderTreeList.Items[i]
+		if r.selects(folderTree) && !slices.Contains(trees, folderTree.Name) && rbac.MatchesNamespacePattern(folderTree, obj.GetName()) {
+			trees = append(trees, folderTree.Name)
+		}
+	}
+	return treeRequests(trees)
+}
+
+// treeRequests returns reconcile requests for the named FolderTrees
+func treeRequests(trees []string) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, tree := range trees {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: tree},
+		})
+	}
+	return requests
+}
+
+// selects reports whether a FolderTree belongs to the shard of this controller
+func (r *FolderTreeReconciler) selects(obj client.Object) bool {
+	return r.TreeSelector == nil || r.TreeSelector.Matches(labels.Set(obj.GetLabels()))
+}
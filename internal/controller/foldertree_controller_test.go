@@ -30,11 +30,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
 )
 
 // Helper function to create bool pointers
 func boolPtr(b bool) *bool { return &b }
 
+// reconcileAndGet reconciles the FolderTree of the given name, expecting success, and returns it as stored afterwards
+func reconcileAndGet(ctx context.Context, reconciler *FolderTreeReconciler, name string) *rbacv1alpha1.FolderTree {
+	folderTree, err := tryReconcileAndGet(ctx, reconciler, name)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return folderTree
+}
+
+// tryReconcileAndGet reconciles the FolderTree of the given name and returns it as stored afterwards,
+// together with the error of the reconcile
+func tryReconcileAndGet(ctx context.Context, reconciler *FolderTreeReconciler, name string) (*rbacv1alpha1.FolderTree, error) {
+	key := types.NamespacedName{Name: name}
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	folderTree := &rbacv1alpha1.FolderTree{}
+	ExpectWithOffset(1, k8sClient.Get(ctx, key, folderTree)).To(Succeed())
+	return folderTree, err
+}
+
 var _ = Describe("FolderTree Controller", func() {
 	var (
 		ctx        context.Context
@@ -762,4 +780,275 @@ var _ = Describe("FolderTree Controller", func() {
 			Expect(err).To(HaveOccurred()) // Should be NotFound
 		})
 	})
+
+	Context("When some operations fail", func() {
+		It("should apply the remaining operations and report PartiallyApplied", func() {
+			resourceName := "test-partial-apply"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+			for _, name := range []string{"partial-apply-ns-a", "partial-apply-ns-b"} {
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+				})).To(Succeed())
+			}
+
+			// An unmanaged RoleBinding with the generated name blocks creation in ns-a
+			blocking := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-partial-apply-viewers",
+					Namespace: "partial-apply-ns-a",
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "someone-else",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+			}
+			Expect(k8sClient.Create(ctx, blocking)).To(Succeed())
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "partial-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "viewers",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "viewers",
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     "view",
+									},
+								},
+							},
+							Namespaces: []string{"partial-apply-ns-a", "partial-apply-ns-b"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			// The operation in ns-b must still have been applied
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      "foldertree-test-partial-apply-viewers",
+				Namespace: "partial-apply-ns-b",
+			}, rb)).To(Succeed())
+
+			updated := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			var partial *metav1.Condition
+			for i := range updated.Status.Conditions {
+				if updated.Status.Conditions[i].Type == rbacv1alpha1.ConditionTypePartiallyApplied {
+					partial = &updated.Status.Conditions[i]
+				}
+			}
+			Expect(partial).NotTo(BeNil())
+			Expect(partial.Message).To(ContainSubstring("1 of 2 RoleBinding operations failed"))
+			Expect(partial.Message).To(ContainSubstring("template 'viewers' in namespace 'partial-apply-ns-a'"))
+			Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeReady)).To(BeFalse())
+
+			By("recording only the RoleBindings that were actually applied")
+			Expect(updated.Status.AppliedBindings).To(HaveLen(1))
+			Expect(updated.Status.AppliedBindings).To(HaveKeyWithValue(
+				"partial-apply-ns-b/foldertree-test-partial-apply-viewers", HavePrefix("ClusterRole/view/")))
+		})
+	})
+
+	Context("When other tools edit managed RoleBindings", func() {
+		It("should keep their labels and annotations while applying its own fields", func() {
+			resourceName := "test-ssa"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+			roleBindingKey := types.NamespacedName{Name: "foldertree-test-ssa-viewers", Namespace: "ssa-ns"}
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ssa-ns"},
+			})).To(Succeed())
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "ssa-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name:     "viewers",
+									Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+									RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+								},
+							},
+							Namespaces: []string{"ssa-ns"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("labeling the RoleBinding from another tool and changing the template")
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, roleBindingKey, rb)).To(Succeed())
+			rb.Labels["team"] = "web"
+			rb.Labels["app.kubernetes.io/managed-by"] = "argocd"
+			rb.Annotations["example.com/owner"] = "web-team"
+			Expect(k8sClient.Update(ctx, rb)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects[0].Name = "new-viewers"
+			Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, roleBindingKey, rb)).To(Succeed())
+			Expect(rb.Subjects).To(ConsistOf(HaveField("Name", "new-viewers")))
+			Expect(rb.Labels).To(HaveKeyWithValue("team", "web"))
+			Expect(rb.Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "argocd"))
+			Expect(rb.Annotations).To(HaveKeyWithValue("example.com/owner", "web-team"))
+			Expect(rb.Annotations).To(HaveKey("foldertree.rbac.kubevirt.io/applied-digest"))
+			Expect(rb.ManagedFields).To(ContainElement(And(
+				HaveField("Manager", FieldManager),
+				HaveField("Operation", metav1.ManagedFieldsOperationApply),
+			)))
+		})
+	})
+
+	Context("When adopting existing RoleBindings", func() {
+		It("should take over a matching hand-managed RoleBinding instead of creating a duplicate", func() {
+			resourceName := "test-adopt"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "adopt-ns"},
+			})).To(Succeed())
+
+			handManaged := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy-viewers", Namespace: "adopt-ns"},
+				Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+			}
+			Expect(k8sClient.Create(ctx, handManaged)).To(Succeed())
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Annotations: map[string]string{"foldertree.rbac.kubevirt.io/adopt": "true"},
+				},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "adopt-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name:     "viewers",
+									Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+									RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+								},
+							},
+							Namespaces: []string{"adopt-ns"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			reconciler.AdoptRoleBindings = true
+			for range 2 {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			roleBindings := &rbacv1.RoleBindingList{}
+			Expect(k8sClient.List(ctx, roleBindings, client.InNamespace("adopt-ns"))).To(Succeed())
+			Expect(roleBindings.Items).To(HaveLen(1))
+			adopted := roleBindings.Items[0]
+			Expect(adopted.Name).To(Equal("legacy-viewers"))
+			Expect(adopted.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", resourceName))
+			Expect(adopted.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/role-binding-template", "viewers"))
+			Expect(adopted.OwnerReferences).To(ConsistOf(HaveField("Name", resourceName)))
+		})
+	})
+
+	Context("When the FolderTree records its last modifier", func() {
+		It("should annotate created RoleBindings with the approving user and keep it on updates", func() {
+			resourceName := "test-approved-by"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "approved-ns"},
+			})).To(Succeed())
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Annotations: map[string]string{rbac.LastModifiedByAnnotation: "jane"},
+				},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "approved-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name:     "viewers",
+									Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+									RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+								},
+							},
+							Namespaces: []string{"approved-ns"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			reconcileTwice := func() {
+				for range 2 {
+					_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+			reconcileTwice()
+
+			roleBindingKey := types.NamespacedName{Name: "foldertree-" + resourceName + "-viewers", Namespace: "approved-ns"}
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, roleBindingKey, roleBinding)).To(Succeed())
+			Expect(roleBinding.Annotations).To(HaveKeyWithValue(rbac.ApprovedByAnnotation, "jane"))
+
+			By("updating the template as another user")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+			folderTree.Annotations[rbac.LastModifiedByAnnotation] = "bob"
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects = append(
+				folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects,
+				rbacv1.Subject{Kind: "User", Name: "bob", APIGroup: "rbac.authorization.k8s.io"})
+			Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+			reconcileTwice()
+
+			Expect(k8sClient.Get(ctx, roleBindingKey, roleBinding)).To(Succeed())
+			Expect(roleBinding.Subjects).To(HaveLen(2))
+			Expect(roleBinding.Annotations).To(HaveKeyWithValue(rbac.ApprovedByAnnotation, "jane"))
+		})
+	})
 })
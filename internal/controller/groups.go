@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups=user.openshift.io,resources=groups,verbs=list
+
+// recordDanglingGroups sets the dangling subjects metric of a FolderTree to the number of its Group
+// subjects that do not exist as OpenShift Groups. Failing to list the Groups, e.g. on a cluster
+// other than OpenShift, is logged and leaves the metric unchanged.
+func (r *FolderTreeReconciler) recordDanglingGroups(ctx context.Context, folderTree string, desired *rbac.DesiredRoleBindingSet) {
+	log := logf.FromContext(ctx)
+
+	groups, err := rbac.ListOpenShiftGroups(ctx, r.Client)
+	if err != nil {
+		log.Info("Could not check Group subjects against OpenShift Groups", "error", err)
+		return
+	}
+	dangling := groups.Dangling(desired)
+	if len(dangling) > 0 {
+		log.Info("RoleBindings bind Groups that do not exist in OpenShift", "groups", dangling)
+	}
+	metrics.DanglingSubjects.WithLabelValues(folderTree).Set(float64(len(dangling)))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// EventReasonLabelRepaired is recorded on a FolderTree when the tree label of one of its
+// RoleBindings was changed or removed out-of-band and has been restored
+const EventReasonLabelRepaired = "LabelRepaired"
+
+// controllingTree returns the name of the FolderTree controlling an object through its owner
+// references, or "" if it has none
+func controllingTree(obj client.Object) string {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "FolderTree" || !strings.HasPrefix(owner.APIVersion, rbacv1alpha1.GroupVersion.Group+"/") {
+		return ""
+	}
+	return owner.Name
+}
+
+// treeLabelDrifted reports whether an object controlled by a FolderTree lost its tree label, which
+// hides it from the label-based List of the objects of the FolderTree
+func treeLabelDrifted(obj client.Object) bool {
+	tree := controllingTree(obj)
+	return tree != "" && obj.GetLabels()["foldertree.rbac.kubevirt.io/tree"] != tree
+}
+
+// repairRoleBindingLabel restores the tree label of a RoleBinding controlled by a FolderTree when it
+// was changed or removed, so that the FolderTree keeps managing the RoleBinding instead of reporting
+// a name conflict for it and leaving it behind when its template goes away. It only runs for
+// RoleBindings passing treeLabelDrifted, and leaves alone RoleBindings whose owner reference does
+// not match the FolderTree or whose FolderTree belongs to another shard.
+func (r *FolderTreeReconciler) repairRoleBindingLabel(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	roleBinding := &rbacv1.RoleBinding{}
+	if err := r.Get(ctx, req.NamespacedName, roleBinding); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !treeLabelDrifted(roleBinding) {
+		return reconcile.Result{}, nil
+	}
+
+	// A RoleBinding whose FolderTree is gone is deleted by the garbage collector
+	owner := metav1.GetControllerOf(roleBinding)
+	folderTree := &rbacv1alpha1.FolderTree{}
+	if err := r.Get(ctx, types.NamespacedName{Name: owner.Name}, folderTree); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if folderTree.UID != owner.UID || !r.selects(folderTree) {
+		return reconcile.Result{}, nil
+	}
+
+	tampered := roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"]
+	patch := client.MergeFromWithOptions(roleBinding.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if roleBinding.Labels == nil {
+		roleBinding.Labels = map[string]string{}
+	}
+	roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"] = folderTree.Name
+	if err := r.Patch(ctx, roleBinding, patch); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	log.Info("Restored the tree label of RoleBinding", "foldertree", folderTree.Name,
+		"namespace", roleBinding.Namespace, "name", roleBinding.Name, "label", tampered)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(folderTree, corev1.EventTypeWarning, EventReasonLabelRepaired,
+			"Restored the tree label of RoleBinding %s/%s, which was changed to %q", roleBinding.Namespace, roleBinding.Name, tampered)
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Label Repair", func() {
+	const (
+		treeName      = "test-label-repair"
+		namespaceName = "label-repair-ns"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+		recorder   *record.FakeRecorder
+	)
+
+	roleBindingKey := types.NamespacedName{Namespace: namespaceName, Name: "foldertree-" + treeName + "-viewers"}
+
+	tamperLabel := func() *rbacv1.RoleBinding {
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, roleBindingKey, roleBinding)).To(Succeed())
+		roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"] = "someone-else"
+		Expect(k8sClient.Update(ctx, roleBinding)).To(Succeed())
+		return roleBinding
+	}
+
+	treeLabel := func() string {
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, roleBindingKey, roleBinding)).To(Succeed())
+		return roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"]
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &FolderTreeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: treeName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name: "viewers",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+					Namespaces: []string{namespaceName},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: roleBindingKey.Namespace, Name: roleBindingKey.Name},
+			}))).To(Succeed())
+		})
+
+		// The second reconcile records the created RoleBinding for the fast path
+		reconcileAndGet(ctx, reconciler, treeName)
+		reconcileAndGet(ctx, reconciler, treeName)
+		Expect(recorder.Events).To(Receive(ContainSubstring("Created")))
+	})
+
+	It("should restore the tree label of RoleBindings controlled by the FolderTree", func() {
+		roleBinding := tamperLabel()
+		Expect(treeLabelDrifted(roleBinding)).To(BeTrue())
+
+		_, err := reconciler.repairRoleBindingLabel(ctx, reconcile.Request{NamespacedName: roleBindingKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(treeLabel()).To(Equal(treeName))
+		Expect(recorder.Events).To(Receive(ContainSubstring(`which was changed to "someone-else"`)))
+	})
+
+	It("should not report RoleBindings with a tampered label as conflicts", func() {
+		tamperLabel()
+
+		folderTree := reconcileAndGet(ctx, reconciler, treeName)
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeConflict)).To(BeFalse())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(treeLabel()).To(Equal(treeName))
+	})
+
+	It("should leave RoleBindings without a FolderTree owner alone", func() {
+		unowned := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespaceName,
+			Name:      "unowned",
+			Labels:    map[string]string{"foldertree.rbac.kubevirt.io/tree": "someone-else"},
+		}}
+		Expect(treeLabelDrifted(unowned)).To(BeFalse())
+	})
+})
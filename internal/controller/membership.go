@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// updateMembershipStatus records the phase of a FolderMembership if it changed
+func (r *FolderTreeReconciler) updateMembershipStatus(ctx context.Context, membership *rbacv1alpha1.FolderMembership,
+	phase rbacv1alpha1.MembershipPhase, message string) {
+
+	if membership.Status.Phase == phase && membership.Status.Message == message &&
+		membership.Status.ObservedGeneration == membership.Generation {
+		return
+	}
+
+	membership.Status.Phase = phase
+	membership.Status.Message = message
+	membership.Status.ObservedGeneration = membership.Generation
+
+	// Status updates are best-effort, the next reconcile retries
+	if err := r.Status().Update(ctx, membership); err != nil {
+		logf.FromContext(ctx).Info("Failed to update FolderMembership status",
+			"namespace", membership.Namespace, "name", membership.Name, "error", err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - FolderMembership", func() {
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	newMembershipTree := func(name string, acceptMemberships bool) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:              name + "-folder",
+						AcceptMemberships: acceptMemberships,
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "viewers",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "viewers",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("When a folder accepts memberships", func() {
+		It("should create RoleBindings in the member namespace and approve the membership", func() {
+			resourceName := "test-membership-approved"
+			namespace := "membership-approved-ns"
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, newMembershipTree(resourceName, true))).To(Succeed())
+
+			membership := &rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join", Namespace: namespace},
+				Spec: rbacv1alpha1.FolderMembershipSpec{
+					TreeName:   resourceName,
+					FolderName: resourceName + "-folder",
+				},
+			}
+			Expect(k8sClient.Create(ctx, membership)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-membership-approved-viewers", Namespace: namespace}, rb)).To(Succeed())
+
+			updated := &rbacv1alpha1.FolderMembership{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "join", Namespace: namespace}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(rbacv1alpha1.MembershipPhaseApproved))
+
+			By("leaving the FolderTree spec untouched")
+			folderTree := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName}, folderTree)).To(Succeed())
+			Expect(folderTree.Spec.Folders[0].Namespaces).To(BeEmpty())
+
+			By("removing the RoleBinding when the membership is deleted")
+			Expect(k8sClient.Delete(ctx, updated)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-membership-approved-viewers", Namespace: namespace}, rb)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When a folder does not accept memberships", func() {
+		It("should leave the membership pending", func() {
+			resourceName := "test-membership-pending"
+			namespace := "membership-pending-ns"
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, newMembershipTree(resourceName, false))).To(Succeed())
+
+			membership := &rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join", Namespace: namespace},
+				Spec: rbacv1alpha1.FolderMembershipSpec{
+					TreeName:   resourceName,
+					FolderName: resourceName + "-folder",
+				},
+			}
+			Expect(k8sClient.Create(ctx, membership)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			rb := &rbacv1.RoleBinding{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-membership-pending-viewers", Namespace: namespace}, rb)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			updated := &rbacv1alpha1.FolderMembership{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "join", Namespace: namespace}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(rbacv1alpha1.MembershipPhasePending))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// namespaceIndex maps namespaces to the FolderTrees managing them, so namespace events only
+// enqueue the affected FolderTrees. It is maintained by Reconcile: every reconcile of a selected
+// FolderTree records the namespaces it manages, and FolderTrees that are deleted or leave the tree
+// selector are removed. Namespace events for a FolderTree not yet reconciled are not needed, since
+// its first reconcile sees the current namespaces anyway.
+type namespaceIndex struct {
+	mu         sync.RWMutex
+	trees      map[string]map[string]bool // namespace -> FolderTree names
+	namespaces map[string][]string        // FolderTree name -> namespaces
+}
+
+// set records the namespaces a FolderTree manages, replacing those recorded before
+func (i *namespaceIndex) set(tree string, namespaces []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.removeLocked(tree)
+	if i.trees == nil {
+		i.trees = make(map[string]map[string]bool)
+		i.namespaces = make(map[string][]string)
+	}
+	for _, namespace := range namespaces {
+		if i.trees[namespace] == nil {
+			i.trees[namespace] = make(map[string]bool)
+		}
+		i.trees[namespace][tree] = true
+	}
+	i.namespaces[tree] = namespaces
+}
+
+// remove drops a FolderTree from the index
+func (i *namespaceIndex) remove(tree string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.removeLocked(tree)
+}
+
+func (i *namespaceIndex) removeLocked(tree string) {
+	for _, namespace := range i.namespaces[tree] {
+		delete(i.trees[namespace], tree)
+		if len(i.trees[namespace]) == 0 {
+			delete(i.trees, namespace)
+		}
+	}
+	delete(i.namespaces, tree)
+}
+
+// lookup returns the sorted names of the FolderTrees managing a namespace
+func (i *namespaceIndex) lookup(namespace string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	var trees []string
+	for tree := range i.trees[namespace] {
+		trees = append(trees, tree)
+	}
+	slices.Sort(trees)
+	return trees
+}
+
+// listTargetingMemberships returns the FolderMemberships targeting a FolderTree, whatever their phase
+func (r *FolderTreeReconciler) listTargetingMemberships(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]rbacv1alpha1.FolderMembership, error) {
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := r.List(ctx, &membershipList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+	var memberships []rbacv1alpha1.FolderMembership
+	for _, membership := range membershipList.Items {
+		if membership.Spec.TreeName == folderTree.Name {
+			memberships = append(memberships, membership)
+		}
+	}
+	return memberships, nil
+}
+
+// managedNamespaces returns the namespaces listed by the folders of a FolderTree and the namespaces
+// of the FolderMemberships and FolderTreePatches targeting it, followed by the existing namespaces
+// matching its namespacePatterns
+func managedNamespaces(folderTree *rbacv1alpha1.FolderTree, memberships []rbacv1alpha1.FolderMembership,
+	patches []rbacv1alpha1.FolderTreePatch, patternNamespaces []string) []string {
+	namespaces := rbac.IndexFolderTreeNamespaces(folderTree)
+	for _, membership := range memberships {
+		if !slices.Contains(namespaces, membership.Namespace) {
+			namespaces = append(namespaces, membership.Namespace)
+		}
+	}
+	for _, patch := range patches {
+		for _, namespace := range patch.Spec.Namespaces {
+			if !slices.Contains(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	for _, namespace := range patternNamespaces {
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
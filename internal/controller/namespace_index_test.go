@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Namespace Index", func() {
+	const resourceName = "test-namespace-index"
+	var (
+		ctx                context.Context
+		reconciler         *FolderTreeReconciler
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	mapNamespace := func(name string) []reconcile.Request {
+		return reconciler.mapNamespaceToFolderTrees(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	It("should map namespace events only to the FolderTrees managing the namespace", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "index-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{"index-first-ns", "index-second-ns"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		By("mapping nothing before the FolderTree is reconciled")
+		Expect(mapNamespace("index-first-ns")).To(BeEmpty())
+
+		reconcileAndGet(ctx, reconciler, resourceName)
+		request := reconcile.Request{NamespacedName: typeNamespacedName}
+		Expect(mapNamespace("index-first-ns")).To(Equal([]reconcile.Request{request}))
+		Expect(mapNamespace("index-second-ns")).To(Equal([]reconcile.Request{request}))
+		Expect(mapNamespace("index-unrelated-ns")).To(BeEmpty())
+
+		By("removing a namespace from the folders")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[0].Namespaces = []string{"index-first-ns"}
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(mapNamespace("index-first-ns")).To(Equal([]reconcile.Request{request}))
+		Expect(mapNamespace("index-second-ns")).To(BeEmpty())
+
+		By("deleting the FolderTree")
+		Expect(k8sClient.Delete(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(mapNamespace("index-first-ns")).To(BeEmpty())
+	})
+
+	It("should map namespace creation only to the FolderTrees waiting for the namespace", func() {
+		existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "index-existing-ns"}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, existing))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name: "index-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+					Namespaces: []string{"index-existing-ns", "index-pending-ns"},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.PendingNamespaces).To(Equal([]string{"index-pending-ns"}))
+
+		mapCreated := func(name string) []reconcile.Request {
+			return reconciler.mapCreatedNamespaceToFolderTrees(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		Expect(mapCreated("index-pending-ns")).To(Equal([]reconcile.Request{{NamespacedName: typeNamespacedName}}))
+		Expect(mapCreated("index-existing-ns")).To(BeEmpty())
+
+		By("creating the pending namespace")
+		pending := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "index-pending-ns"}}
+		Expect(k8sClient.Create(ctx, pending)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pending))).To(Succeed())
+		})
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		roleBinding := types.NamespacedName{Namespace: "index-pending-ns", Name: "foldertree-" + resourceName + "-viewers"}
+		Expect(k8sClient.Get(ctx, roleBinding, &rbacv1.RoleBinding{})).To(Succeed())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.PendingNamespaces).To(BeEmpty())
+		Expect(mapCreated("index-pending-ns")).To(BeEmpty())
+	})
+
+	It("should drop namespaces no FolderTree manages anymore from the index", func() {
+		index := &namespaceIndex{}
+		index.set("first", []string{"shared-ns", "first-ns"})
+		index.set("second", []string{"shared-ns"})
+		Expect(index.lookup("shared-ns")).To(Equal([]string{"first", "second"}))
+
+		index.remove("first")
+		Expect(index.lookup("shared-ns")).To(Equal([]string{"second"}))
+		Expect(index.lookup("first-ns")).To(BeEmpty())
+		Expect(index.trees).NotTo(HaveKey("first-ns"))
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// maxFieldManagerLength is the maximum length of a field manager name accepted by the API server
+const maxFieldManagerLength = 128
+
+// Annotations describing the folder a namespace belongs to, stamped onto namespaces of folders with
+// a description, owner or contact
+const (
+	FolderAnnotation            = "folder.rbac.kubevirt.io/name"
+	FolderDescriptionAnnotation = "folder.rbac.kubevirt.io/description"
+	FolderOwnerAnnotation       = "folder.rbac.kubevirt.io/owner"
+	FolderContactAnnotation     = "folder.rbac.kubevirt.io/contact"
+)
+
+// namespaceMetadata is the set of labels and annotations a FolderTree stamps onto a namespace
+type namespaceMetadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// namespaceFieldManager returns the field manager that owns the labels and annotations a FolderTree
+// applies to namespaces. Every FolderTree has its own, so a tree releasing a namespace never
+// removes metadata another tree applied to it.
+func namespaceFieldManager(treeName string) string {
+	manager := FieldManager + "/" + treeName
+	if len(manager) > maxFieldManagerLength {
+		manager = manager[:maxFieldManagerLength]
+	}
+	return manager
+}
+
+// desiredNamespaceMetadata returns the labels and annotations of folders with labelsToApply,
+// annotationsToApply or folder metadata (see folderAnnotations) per member namespace. Only the
+// folder a namespace belongs to directly contributes; when a namespace is listed by several folders
+// their maps are merged in spec order. The given tree must already include the namespaces of
+// approved FolderMemberships.
+func desiredNamespaceMetadata(folderTree *rbacv1alpha1.FolderTree, excludedNamespaces []string) map[string]*namespaceMetadata {
+	desired := make(map[string]*namespaceMetadata)
+	for _, folder := range folderTree.Spec.Folders {
+		if !appliesFolderMetadata(folder) {
+			continue
+		}
+		for _, namespace := range folder.Namespaces {
+			if slices.Contains(folderTree.Spec.ExcludedNamespaces, namespace) || slices.Contains(excludedNamespaces, namespace) {
+				continue
+			}
+			metadata, ok := desired[namespace]
+			if !ok {
+				metadata = &namespaceMetadata{Labels: map[string]string{}, Annotations: map[string]string{}}
+				desired[namespace] = metadata
+			}
+			maps.Copy(metadata.Labels, folder.LabelsToApply)
+			maps.Copy(metadata.Annotations, folder.AnnotationsToApply)
+			maps.Copy(metadata.Annotations, folderAnnotations(folder))
+		}
+	}
+	return desired
+}
+
+// folderAnnotations returns the annotations naming a folder and its description, owner and contact,
+// or nil when the folder has none of them
+func folderAnnotations(folder rbacv1alpha1.Folder) map[string]string {
+	if folder.Description == "" && folder.Owner == "" && folder.Contact == "" {
+		return nil
+	}
+	annotations := map[string]string{FolderAnnotation: folder.Name}
+	for key, value := range map[string]string{
+		FolderDescriptionAnnotation: folder.Description,
+		FolderOwnerAnnotation:       folder.Owner,
+		FolderContactAnnotation:     folder.Contact,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// appliesFolderMetadata reports whether a folder stamps metadata onto its namespaces
+func appliesFolderMetadata(folder rbacv1alpha1.Folder) bool {
+	return len(folder.LabelsToApply) > 0 || len(folder.AnnotationsToApply) > 0 ||
+		folder.Description != "" || folder.Owner != "" || folder.Contact != ""
+}
+
+// appliesNamespaceMetadata reports whether any folder of the FolderTree stamps metadata onto its namespaces
+func appliesNamespaceMetadata(folderTree *rbacv1alpha1.FolderTree) bool {
+	return slices.ContainsFunc(folderTree.Spec.Folders, appliesFolderMetadata)
+}
+
+// ownedNamespaceMetadata returns the labels and annotations of a namespace owned by the given
+// field manager through server-side apply, and whether the manager owns any field of it
+func ownedNamespaceMetadata(namespace *corev1.Namespace, manager string) (*namespaceMetadata, bool) {
+	for _, entry := range namespace.ManagedFields {
+		if entry.Manager != manager || entry.Operation != metav1.ManagedFieldsOperationApply {
+			continue
+		}
+		owned := &namespaceMetadata{Labels: map[string]string{}, Annotations: map[string]string{}}
+		if entry.FieldsV1 == nil {
+			return owned, true
+		}
+		var fields struct {
+			Metadata struct {
+				Labels      map[string]json.RawMessage `json:"f:labels"`
+				Annotations map[string]json.RawMessage `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return owned, true
+		}
+		for key := range fields.Metadata.Labels {
+			if name, ok := strings.CutPrefix(key, "f:"); ok {
+				owned.Labels[name] = namespace.Labels[name]
+			}
+		}
+		for key := range fields.Metadata.Annotations {
+			if name, ok := strings.CutPrefix(key, "f:"); ok {
+				owned.Annotations[name] = namespace.Annotations[name]
+			}
+		}
+		return owned, true
+	}
+	return nil, false
+}
+
+// applyNamespaceMetadata stamps the desired labels and annotations onto namespaces with server-side
+// apply, and releases them from namespaces that are no longer desired. Keys are removed by applying
+// the remaining set; values another manager also set are left to that manager.
+func (r *FolderTreeReconciler) applyNamespaceMetadata(ctx context.Context, treeName string, desired map[string]*namespaceMetadata) error {
+	log := logf.FromContext(ctx)
+
+	manager := namespaceFieldManager(treeName)
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaceList); err != nil {
+		return fmt.Errorf("failed to list namespaces: %v", err)
+	}
+
+	var errs []error
+	for i := range namespaceList.Items {
+		namespace := &namespaceList.Items[i]
+		owned, owns := ownedNamespaceMetadata(namespace, manager)
+		metadata, wanted := desired[namespace.Name]
+		switch {
+		case !wanted && !owns:
+			continue
+		case !wanted:
+			metadata = &namespaceMetadata{}
+		case owns && maps.Equal(owned.Labels, metadata.Labels) && maps.Equal(owned.Annotations, metadata.Annotations):
+			continue
+		}
+		if !namespace.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err := r.Patch(ctx, namespaceForServerSideApply(namespace.Name, metadata), client.Apply,
+			client.FieldOwner(manager), client.ForceOwnership); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply metadata to namespace '%s': %v", namespace.Name, err))
+			continue
+		}
+		if wanted {
+			log.Info("Applied folder metadata to namespace", "namespace", namespace.Name)
+		} else {
+			log.Info("Removed folder metadata from namespace", "namespace", namespace.Name)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// namespaceForServerSideApply returns the apply configuration of a namespace holding only the given metadata
+func namespaceForServerSideApply(name string, metadata *namespaceMetadata) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      metadata.Labels,
+			Annotations: metadata.Annotations,
+		},
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Namespace Metadata", func() {
+	const (
+		resourceName = "test-ns-metadata"
+		memberNS     = "ns-metadata-member"
+		leavingNS    = "ns-metadata-leaving"
+	)
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		reconciler         *FolderTreeReconciler
+	)
+
+	getNamespace := func(name string) *corev1.Namespace {
+		namespace := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, namespace)).To(Succeed())
+		return namespace
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		for _, name := range []string{memberNS, leavingNS} {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"team": "platform"},
+			}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:               "production",
+						Namespaces:         []string{memberNS, leavingNS},
+						LabelsToApply:      map[string]string{"cost-center": "cc-1234", "environment": "production"},
+						AnnotationsToApply: map[string]string{"example.com/compliance-tier": "pci"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			current := &rbacv1alpha1.FolderTree{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, current); err == nil {
+				controllerutil.RemoveFinalizer(current, CleanupFinalizer)
+				Expect(k8sClient.Update(ctx, current)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, current))).To(Succeed())
+			}
+		})
+	})
+
+	It("should stamp folder metadata onto member namespaces and remove it when they leave", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		for _, name := range []string{memberNS, leavingNS} {
+			namespace := getNamespace(name)
+			Expect(namespace.Labels).To(HaveKeyWithValue("cost-center", "cc-1234"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("environment", "production"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(namespace.Annotations).To(HaveKeyWithValue("example.com/compliance-tier", "pci"))
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Finalizers).To(ContainElement(CleanupFinalizer))
+
+		By("removing a namespace from the folder and dropping a label")
+		folderTree.Spec.Folders[0].Namespaces = []string{memberNS}
+		delete(folderTree.Spec.Folders[0].LabelsToApply, "environment")
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		member := getNamespace(memberNS)
+		Expect(member.Labels).To(HaveKeyWithValue("cost-center", "cc-1234"))
+		Expect(member.Labels).NotTo(HaveKey("environment"))
+
+		leaving := getNamespace(leavingNS)
+		Expect(leaving.Labels).NotTo(HaveKey("cost-center"))
+		Expect(leaving.Labels).To(HaveKeyWithValue("team", "platform"))
+		Expect(leaving.Annotations).NotTo(HaveKey("example.com/compliance-tier"))
+
+		By("deleting the FolderTree")
+		Expect(k8sClient.Delete(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		member = getNamespace(memberNS)
+		Expect(member.Labels).NotTo(HaveKey("cost-center"))
+		Expect(member.Labels).To(HaveKeyWithValue("team", "platform"))
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).NotTo(Succeed())
+	})
+
+	It("should restore folder labels changed out-of-band", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		namespace := getNamespace(memberNS)
+		namespace.Labels["cost-center"] = "unbilled"
+		Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(getNamespace(memberNS).Labels).To(HaveKeyWithValue("cost-center", "cc-1234"))
+	})
+
+	It("should annotate member namespaces with the folder, its description, owner and contact", func() {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[0].Description = "Production workloads of the web shop"
+		folderTree.Spec.Folders[0].Owner = "team-web"
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		annotations := getNamespace(memberNS).Annotations
+		Expect(annotations).To(HaveKeyWithValue(FolderAnnotation, "production"))
+		Expect(annotations).To(HaveKeyWithValue(FolderDescriptionAnnotation, "Production workloads of the web shop"))
+		Expect(annotations).To(HaveKeyWithValue(FolderOwnerAnnotation, "team-web"))
+		Expect(annotations).NotTo(HaveKey(FolderContactAnnotation))
+		Expect(annotations).To(HaveKeyWithValue("example.com/compliance-tier", "pci"))
+
+		By("removing the annotations with the folder metadata")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[0].Description = ""
+		folderTree.Spec.Folders[0].Owner = ""
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		annotations = getNamespace(memberNS).Annotations
+		Expect(annotations).NotTo(HaveKey(FolderAnnotation))
+		Expect(annotations).NotTo(HaveKey(FolderOwnerAnnotation))
+		Expect(annotations).To(HaveKeyWithValue("example.com/compliance-tier", "pci"))
+	})
+})
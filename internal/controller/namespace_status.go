@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// waitingForWaveMessage is the message of namespaces Skipped until their rollout wave executes
+const waitingForWaveMessage = "Waiting for a rollout wave"
+
+// namespaceStatuses returns the status of every namespace of the desired state before the
+// operations are executed: namespaces without operations are Synced, and those with operations
+// are Skipped until executeOperations records their outcome, which it doesn't for namespaces
+// waiting for a later rollout wave. Namespaces that don't exist, are excluded by the controller,
+// are opted out by their owners or are managed by another FolderTree are Skipped.
+func namespaceStatuses(desiredTree *rbacv1alpha1.FolderTree, operations []rbac.RoleBindingOperation,
+	pending, excluded, optedOut []string, superseded map[string]string) []rbacv1alpha1.NamespaceStatus {
+	changed := make(map[string]bool)
+	for _, operation := range operations {
+		changed[operation.Namespace] = true
+	}
+
+	var statuses []rbacv1alpha1.NamespaceStatus
+	for _, namespace := range rbac.IndexFolderTreeNamespaces(desiredTree) {
+		status := rbacv1alpha1.NamespaceStatus{Name: namespace, Phase: rbacv1alpha1.NamespacePhaseSynced}
+		switch {
+		case slices.Contains(pending, namespace):
+			status.Phase = rbacv1alpha1.NamespacePhaseSkipped
+			status.Message = "Namespace does not exist"
+		case slices.Contains(excluded, namespace):
+			status.Phase = rbacv1alpha1.NamespacePhaseSkipped
+			status.Message = "Namespace is excluded by the controller"
+		case slices.Contains(optedOut, namespace):
+			status.Phase = rbacv1alpha1.NamespacePhaseSkipped
+			status.Message = fmt.Sprintf("Namespace is opted out with the '%s: \"true\"' annotation", ExcludeFromFoldersAnnotation)
+		case changed[namespace]:
+			status.Phase = rbacv1alpha1.NamespacePhaseSkipped
+			status.Message = waitingForWaveMessage
+		}
+		statuses = append(statuses, status)
+	}
+	for namespace, tree := range superseded {
+		statuses = append(statuses, rbacv1alpha1.NamespaceStatus{
+			Name:    namespace,
+			Phase:   rbacv1alpha1.NamespacePhaseSkipped,
+			Message: fmt.Sprintf("Namespace is managed by FolderTree '%s'", tree),
+		})
+	}
+
+	slices.SortFunc(statuses, func(a, b rbacv1alpha1.NamespaceStatus) int { return cmp.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// recordNamespaceOutcome records the outcome of the operations executed in a namespace in
+// status.namespaces. Failed namespaces that are no longer part of the desired state, e.g. where
+// RoleBindings could not be deleted, are added; Synced ones are not. Namespaces Skipped for another
+// reason than their rollout wave, e.g. excluded ones whose RoleBindings were removed, stay Skipped.
+func recordNamespaceOutcome(folderTree *rbacv1alpha1.FolderTree, namespace operationGroup, failures []operationFailure) {
+	status := rbacv1alpha1.NamespaceStatus{Name: namespace.key, Phase: rbacv1alpha1.NamespacePhaseSynced}
+	if len(failures) > 0 {
+		last := failures[len(failures)-1]
+		status.Phase = rbacv1alpha1.NamespacePhaseFailed
+		status.Message = fmt.Sprintf("%d of %d RoleBinding operations failed, the last with: %s template '%s': %v",
+			len(failures), len(namespace.operations), last.Operation.Type, last.Operation.TemplateName(), last.Err)
+	}
+
+	statuses := folderTree.Status.Namespaces
+	i, found := slices.BinarySearchFunc(statuses, namespace.key, func(status rbacv1alpha1.NamespaceStatus, name string) int {
+		return cmp.Compare(status.Name, name)
+	})
+	switch {
+	case found && status.Phase == rbacv1alpha1.NamespacePhaseSynced && statuses[i].Phase == rbacv1alpha1.NamespacePhaseSkipped &&
+		statuses[i].Message != waitingForWaveMessage:
+		// Keep the reason the namespace was skipped
+	case found:
+		statuses[i] = status
+	case status.Phase == rbacv1alpha1.NamespacePhaseFailed:
+		folderTree.Status.Namespaces = slices.Insert(statuses, i, status)
+	}
+}
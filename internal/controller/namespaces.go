@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// ExcludeFromFoldersAnnotation set to "true" on a Namespace opts it out of the RoleBindings of every
+// FolderTree, even when a folder lists it. It gives namespace owners an emergency opt-out without
+// editing the cluster-scoped FolderTree; existing RoleBindings are removed.
+const ExcludeFromFoldersAnnotation = "rbac.kubevirt.io/exclude-from-folders"
+
+// EventReasonNamespacesPruned is recorded on a FolderTree when missing namespaces are removed from its folders
+const EventReasonNamespacesPruned = "NamespacesPruned"
+
+// maxMissingListed is the maximum number of missing namespaces named in the NamespaceMissing condition message
+const maxMissingListed = 10
+
+// findMissingNamespaces returns the sorted namespaces listed by the folders of a FolderTree that do
+// not exist. Excluded namespaces never receive RoleBindings and are not reported.
+func (r *FolderTreeReconciler) findMissingNamespaces(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]string, error) {
+	var missing []string
+	checked := make(map[string]bool)
+	for _, folder := range folderTree.Spec.Folders {
+		for _, namespace := range folder.Namespaces {
+			if checked[namespace] || slices.Contains(folderTree.Spec.ExcludedNamespaces, namespace) ||
+				slices.Contains(r.ExcludedNamespaces, namespace) {
+				continue
+			}
+			checked[namespace] = true
+
+			err := r.Get(ctx, types.NamespacedName{Name: namespace}, &corev1.Namespace{})
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, namespace)
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get namespace '%s': %v", namespace, err)
+			}
+		}
+	}
+	slices.Sort(missing)
+	return missing, nil
+}
+
+// findOptedOutNamespaces returns the namespaces of the desired state of a FolderTree that are
+// annotated with ExcludeFromFoldersAnnotation. Missing namespaces are left out.
+func (r *FolderTreeReconciler) findOptedOutNamespaces(ctx context.Context, desiredTree *rbacv1alpha1.FolderTree) ([]string, error) {
+	var optedOut []string
+	for _, namespace := range rbac.IndexFolderTreeNamespaces(desiredTree) {
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get namespace '%s': %v", namespace, err)
+		}
+		if ns.Annotations[ExcludeFromFoldersAnnotation] == "true" {
+			optedOut = append(optedOut, namespace)
+		}
+	}
+	return optedOut, nil
+}
+
+// pruneMissingNamespaces removes the missing namespaces from the folders of the FolderTree with
+// an optimistic-lock patch, so concurrent spec edits are not overwritten. On success the
+// FolderTree is updated in place with the patched spec and a new generation.
+func (r *FolderTreeReconciler) pruneMissingNamespaces(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, missing []string) error {
+	original := folderTree.DeepCopy()
+	for i := range folderTree.Spec.Folders {
+		folder := &folderTree.Spec.Folders[i]
+		folder.Namespaces = slices.DeleteFunc(folder.Namespaces, func(namespace string) bool {
+			return slices.Contains(missing, namespace)
+		})
+		folder.NamespaceOverrides = slices.DeleteFunc(folder.NamespaceOverrides, func(override rbacv1alpha1.NamespaceOverride) bool {
+			return slices.Contains(missing, override.Name)
+		})
+	}
+
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := r.Patch(ctx, folderTree, patch); err != nil {
+		original.DeepCopyInto(folderTree)
+		return fmt.Errorf("failed to prune missing namespaces: %v", err)
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonNamespacesPruned,
+			"Removed namespaces that no longer exist from the folders: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// setNamespaceMissingCondition sets the NamespaceMissing condition listing the missing namespaces,
+// and removes it when there are none. The status is persisted by the following updateStatus call.
+func (r *FolderTreeReconciler) setNamespaceMissingCondition(folderTree *rbacv1alpha1.FolderTree, missing []string) {
+	if len(missing) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeNamespaceMissing)
+		return
+	}
+
+	listed := missing
+	if len(listed) > maxMissingListed {
+		listed = append(slices.Clone(missing[:maxMissingListed]), fmt.Sprintf("and %d more", len(missing)-maxMissingListed))
+	}
+	message := fmt.Sprintf("%d namespace(s) listed in folders do not exist: %s", len(missing), strings.Join(listed, ", "))
+	setConditionMessage(folderTree, rbacv1alpha1.ConditionTypeNamespaceMissing, message)
+}
+
+// setConditionMessage sets a condition to true with the given message, keeping the last transition
+// time of an existing condition of the same type
+func setConditionMessage(folderTree *rbacv1alpha1.FolderTree, conditionType, message string) {
+	for i, condition := range folderTree.Status.Conditions {
+		if condition.Type == conditionType {
+			folderTree.Status.Conditions[i].Message = message
+			return
+		}
+	}
+	folderTree.Status.Conditions = append(folderTree.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             conditionType,
+		Message:            message,
+	})
+}
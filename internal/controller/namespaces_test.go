@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Missing Namespaces", func() {
+	const (
+		resourceName     = "test-missing-namespaces"
+		namespace        = "missing-ns-present"
+		missingNamespace = "missing-ns-gone"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	createFolderTree := func(prune bool) {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				PruneMissingNamespaces: prune,
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "missing-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						// The namespace is never created, as if it had been deleted after being added
+						Namespaces: []string{namespace, missingNamespace},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+	})
+
+	It("should report missing namespaces in the NamespaceMissing condition", func() {
+		createFolderTree(false)
+
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeNamespaceMissing)).To(BeTrue())
+		for _, condition := range folderTree.Status.Conditions {
+			if condition.Type == rbacv1alpha1.ConditionTypeNamespaceMissing {
+				Expect(condition.Message).To(Equal("1 namespace(s) listed in folders do not exist: " + missingNamespace))
+			}
+		}
+		Expect(folderTree.Spec.Folders[0].Namespaces).To(ConsistOf(namespace, missingNamespace))
+
+		By("clearing the condition once the namespace is removed from the folder")
+		folderTree.Spec.Folders[0].Namespaces = []string{namespace}
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		Expect(hasCondition(reconcileAndGet(ctx, reconciler, resourceName), rbacv1alpha1.ConditionTypeNamespaceMissing)).To(BeFalse())
+	})
+
+	It("should prune missing namespaces from the spec when requested", func() {
+		createFolderTree(true)
+
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Spec.Folders[0].Namespaces).To(Equal([]string{namespace}))
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeNamespaceMissing)).To(BeFalse())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(folderTree.Status.ProcessedGeneration).To(Equal(folderTree.Generation))
+
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		Expect(roleBindings.Items).To(HaveLen(1))
+	})
+
+	It("should skip namespaces opted out with the exclude-from-folders annotation", func() {
+		createFolderTree(false)
+		Expect(reconcileAndGet(ctx, reconciler, resourceName).Status.Namespaces).To(ContainElement(
+			rbacv1alpha1.NamespaceStatus{Name: namespace, Phase: rbacv1alpha1.NamespacePhaseSynced}))
+
+		By("removing the RoleBindings once the namespace opts out")
+		namespaceObj := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, namespaceObj)).To(Succeed())
+		namespaceObj.Annotations = map[string]string{ExcludeFromFoldersAnnotation: "true"}
+		Expect(k8sClient.Update(ctx, namespaceObj)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, namespaceObj)).To(Succeed())
+			delete(namespaceObj.Annotations, ExcludeFromFoldersAnnotation)
+			Expect(k8sClient.Update(ctx, namespaceObj)).To(Succeed())
+		})
+
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Status.Namespaces).To(ContainElement(SatisfyAll(
+			HaveField("Name", namespace),
+			HaveField("Phase", rbacv1alpha1.NamespacePhaseSkipped),
+			HaveField("Message", ContainSubstring(ExcludeFromFoldersAnnotation)),
+		)))
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		Expect(roleBindings.Items).To(BeEmpty())
+
+		By("restoring the RoleBindings once the annotation is removed")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, namespaceObj)).To(Succeed())
+		namespaceObj.Annotations[ExcludeFromFoldersAnnotation] = "false"
+		Expect(k8sClient.Update(ctx, namespaceObj)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		Expect(roleBindings.Items).To(HaveLen(1))
+	})
+
+	It("should list at most maxMissingListed namespaces in the condition message", func() {
+		missing := []string{"ns-01", "ns-02", "ns-03", "ns-04", "ns-05", "ns-06", "ns-07", "ns-08", "ns-09", "ns-10", "ns-11", "ns-12"}
+		folderTree := &rbacv1alpha1.FolderTree{}
+		reconciler.setNamespaceMissingCondition(folderTree, missing)
+
+		Expect(folderTree.Status.Conditions).To(HaveLen(1))
+		Expect(folderTree.Status.Conditions[0].Message).To(HaveSuffix("ns-09, ns-10, and 2 more"))
+		Expect(folderTree.Status.Conditions[0].Message).To(HavePrefix("12 namespace(s)"))
+	})
+})
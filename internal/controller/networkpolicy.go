@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// reconcileNetworkPolicies creates, updates and deletes the NetworkPolicies of the network policy
+// templates of a FolderTree. desiredTree is the FolderTree with the namespaces of approved
+// FolderMemberships added. Failed operations do not stop the remaining ones.
+func (r *FolderTreeReconciler) reconcileNetworkPolicies(ctx context.Context, desiredTree *rbacv1alpha1.FolderTree) error {
+	builder := &rbac.NetworkPolicyBuilder{
+		FolderTree:             desiredTree,
+		Scheme:                 r.Scheme,
+		ExcludedNamespaces:     r.ExcludedNamespaces,
+		DisableOwnerReferences: r.DisableOwnerReferences,
+	}
+	operations, err := rbac.AnalyzeNetworkPolicyDiff(ctx, r.Client, desiredTree, builder)
+	if err != nil {
+		return fmt.Errorf("failed to analyze NetworkPolicy operations: %v", err)
+	}
+
+	var errs []error
+	for _, operation := range operations {
+		if err := r.executeNetworkPolicyOperation(ctx, operation); err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s: %v", operation.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// executeNetworkPolicyOperation performs a single NetworkPolicy operation. Like RoleBindings,
+// NetworkPolicies are only created in namespaces that exist and never taken over when one of the
+// same name exists. Updates replace the spec, so rules removed from a template are removed too.
+func (r *FolderTreeReconciler) executeNetworkPolicyOperation(ctx context.Context, operation rbac.NetworkPolicyOperation) error {
+	log := logf.FromContext(ctx)
+
+	switch operation.Type {
+	case rbac.OperationCreate:
+		err := r.Get(ctx, types.NamespacedName{Name: operation.Desired.Namespace}, &corev1.Namespace{})
+		if apierrors.IsNotFound(err) {
+			log.Info("Namespace not found, skipping NetworkPolicy creation", "namespace", operation.Desired.Namespace)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		log.Info("Creating NetworkPolicy", "name", operation.Desired.Name, "namespace", operation.Desired.Namespace)
+		return r.Create(ctx, operation.Desired, client.FieldOwner(FieldManager))
+
+	case rbac.OperationUpdate:
+		updated := operation.Existing.DeepCopy()
+		updated.Spec = operation.Desired.Spec
+		if updated.Labels == nil {
+			updated.Labels = make(map[string]string)
+		}
+		for key, value := range operation.Desired.Labels {
+			if rbac.IsManagedKey(key) {
+				updated.Labels[key] = value
+			}
+		}
+		if r.Scheme != nil {
+			updated.OwnerReferences = rbac.MergeNetworkPolicyOwnerReferences(operation.Existing, operation.Desired)
+		}
+		log.Info("Updating NetworkPolicy", "name", updated.Name, "namespace", updated.Namespace)
+		return r.Update(ctx, updated, client.FieldOwner(FieldManager))
+
+	case rbac.OperationDelete:
+		log.Info("Deleting NetworkPolicy", "name", operation.Existing.Name, "namespace", operation.Existing.Namespace)
+		return client.IgnoreNotFound(r.Delete(ctx, operation.Existing))
+	}
+	return nil
+}
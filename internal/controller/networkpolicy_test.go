@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+var _ = Describe("FolderTree Controller - NetworkPolicy Templates", func() {
+	const (
+		resourceName = "test-network-policies"
+		parentNS     = "network-policies-parent"
+		childNS      = "network-policies-child"
+	)
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		reconciler         *FolderTreeReconciler
+	)
+
+	listNetworkPolicies := func(namespace string) []networkingv1.NetworkPolicy {
+		networkPolicies := &networkingv1.NetworkPolicyList{}
+		Expect(k8sClient.List(ctx, networkPolicies, client.InNamespace(namespace))).To(Succeed())
+		return networkPolicies.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		for _, name := range []string{parentNS, childNS} {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:       "parent",
+						Namespaces: []string{parentNS},
+						NetworkPolicyTemplates: []rbacv1alpha1.NetworkPolicyTemplate{{
+							Name:      "default-deny",
+							Spec:      networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+							Propagate: ptr.To(true),
+						}},
+					},
+					{Name: "child", Namespaces: []string{childNS}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			current := &rbacv1alpha1.FolderTree{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, current); err == nil {
+				controllerutil.RemoveFinalizer(current, CleanupFinalizer)
+				Expect(k8sClient.Update(ctx, current)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, current))).To(Succeed())
+			}
+			for _, namespace := range []string{parentNS, childNS} {
+				for _, networkPolicy := range listNetworkPolicies(namespace) {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &networkPolicy))).To(Succeed())
+				}
+			}
+		})
+	})
+
+	It("should create NetworkPolicies in the folder's and inheriting namespaces", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		for _, namespace := range []string{parentNS, childNS} {
+			networkPolicies := listNetworkPolicies(namespace)
+			Expect(networkPolicies).To(HaveLen(1))
+			Expect(networkPolicies[0].Name).To(Equal("foldertree-test-network-policies-default-deny"))
+			Expect(networkPolicies[0].Labels).To(HaveKeyWithValue(rbac.NetworkPolicyTemplateLabel, "default-deny"))
+			Expect(networkPolicies[0].OwnerReferences).To(HaveLen(1))
+		}
+	})
+
+	It("should update and delete NetworkPolicies when templates change", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[0].NetworkPolicyTemplates[0].Propagate = ptr.To(false)
+		folderTree.Spec.Folders[0].NetworkPolicyTemplates[0].Spec.PolicyTypes = []networkingv1.PolicyType{
+			networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress,
+		}
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		Expect(listNetworkPolicies(childNS)).To(BeEmpty())
+		networkPolicies := listNetworkPolicies(parentNS)
+		Expect(networkPolicies).To(HaveLen(1))
+		Expect(networkPolicies[0].Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+	})
+
+	It("should restore NetworkPolicies changed out-of-band", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		networkPolicy := listNetworkPolicies(parentNS)[0]
+		networkPolicy.Spec.PodSelector = metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}
+		Expect(k8sClient.Update(ctx, &networkPolicy)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		Expect(listNetworkPolicies(parentNS)[0].Spec.PodSelector.MatchLabels).To(BeEmpty())
+	})
+})
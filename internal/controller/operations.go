@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// unknownFolder groups operations on RoleBindings that do not record their folder path
+const unknownFolder = "unknown"
+
+// operationGroup is the operations on a namespace
+type operationGroup struct {
+	key        string
+	operations []rbac.RoleBindingOperation
+}
+
+// groupOperationsByNamespace groups operations by namespace, in the order the namespaces first appear.
+// The order of the operations within a namespace is kept.
+func groupOperationsByNamespace(operations []rbac.RoleBindingOperation) []operationGroup {
+	var groups []operationGroup
+	index := map[string]int{}
+	for _, operation := range operations {
+		i, ok := index[operation.Namespace]
+		if !ok {
+			i = len(groups)
+			index[operation.Namespace] = i
+			groups = append(groups, operationGroup{key: operation.Namespace})
+		}
+		groups[i].operations = append(groups[i].operations, operation)
+	}
+	return groups
+}
+
+// folderNamespaces lists the namespace groups, by index, of a folder
+type folderNamespaces struct {
+	key        string
+	namespaces []int
+}
+
+// groupNamespacesByFolder groups namespace groups by their folder, in the order the folders first appear
+func groupNamespacesByFolder(namespaces []operationGroup) []folderNamespaces {
+	var folders []folderNamespaces
+	index := map[string]int{}
+	for i, namespace := range namespaces {
+		folder := namespaceFolder(namespace)
+		j, ok := index[folder]
+		if !ok {
+			j = len(folders)
+			index[folder] = j
+			folders = append(folders, folderNamespaces{key: folder})
+		}
+		folders[j].namespaces = append(folders[j].namespaces, i)
+	}
+	return folders
+}
+
+// namespaceFolder returns the folder path of a namespace as recorded on the RoleBindings its
+// operations create or update, falling back to the RoleBindings they delete
+func namespaceFolder(namespace operationGroup) string {
+	for _, operation := range namespace.operations {
+		if operation.DesiredRoleBinding != nil && operation.DesiredRoleBinding.Annotations[rbac.FolderPathKey] != "" {
+			return operation.DesiredRoleBinding.Annotations[rbac.FolderPathKey]
+		}
+	}
+	for _, operation := range namespace.operations {
+		if operation.ExistingRoleBinding != nil && operation.ExistingRoleBinding.Annotations[rbac.FolderPathKey] != "" {
+			return operation.ExistingRoleBinding.Annotations[rbac.FolderPathKey]
+		}
+	}
+	return unknownFolder
+}
+
+// operationAttributes describes an operation on its span
+func operationAttributes(operation rbac.RoleBindingOperation) []attribute.KeyValue {
+	name := ""
+	if operation.DesiredRoleBinding != nil {
+		name = operation.DesiredRoleBinding.Name
+	} else if operation.ExistingRoleBinding != nil {
+		name = operation.ExistingRoleBinding.Name
+	}
+	return []attribute.KeyValue{
+		attribute.String("operation", string(operation.Type)),
+		attribute.String("namespace", operation.Namespace),
+		attribute.String("rolebinding", name),
+		attribute.String("template", operation.RoleBindingTemplate.Name),
+	}
+}
+
+// endSpan records err on span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+var orphanlog = logf.Log.WithName("orphan-sweeper")
+
+// OrphanSweeper periodically looks for RoleBindings labeled with a FolderTree that no longer exists,
+// such as those left behind when a FolderTree is deleted without cascading and recreated under
+// another name. Nothing reconciles these RoleBindings anymore. The sweeper reports them, or deletes
+// them when Delete is set. As a manager runnable it only runs on the leader.
+type OrphanSweeper struct {
+	Client client.Client
+
+	// Interval is the time between two sweeps
+	Interval time.Duration
+
+	// Delete deletes the orphaned RoleBindings instead of only reporting them
+	Delete bool
+}
+
+// Start sweeps every Interval until the context is cancelled. It implements manager.Runnable.
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if _, err := s.Sweep(ctx); err != nil {
+			orphanlog.Error(err, "Failed to sweep orphaned RoleBindings")
+		}
+	}, s.Interval)
+	return nil
+}
+
+// Sweep returns the RoleBindings labeled with a FolderTree that does not exist, after deleting
+// them when Delete is set. The foldertree_orphaned_rolebindings metric is set to the number of
+// orphaned RoleBindings left in the cluster.
+func (s *OrphanSweeper) Sweep(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+	folderTreeList := &rbacv1alpha1.FolderTreeList{}
+	if err := s.Client.List(ctx, folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	folderTrees := make(map[string]bool, len(folderTreeList.Items))
+	for _, folderTree := range folderTreeList.Items {
+		folderTrees[folderTree.Name] = true
+	}
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := s.Client.List(ctx, roleBindingList, client.HasLabels{"foldertree.rbac.kubevirt.io/tree"}); err != nil {
+		return nil, fmt.Errorf("failed to list managed RoleBindings: %v", err)
+	}
+
+	var orphans []rbacv1.RoleBinding
+	remaining := 0
+	for _, roleBinding := range roleBindingList.Items {
+		tree := roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"]
+		if folderTrees[tree] || !roleBinding.DeletionTimestamp.IsZero() {
+			continue
+		}
+		orphans = append(orphans, roleBinding)
+
+		if !s.Delete {
+			orphanlog.Info("Found orphaned RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "foldertree", tree)
+			remaining++
+			continue
+		}
+		if err := s.Client.Delete(ctx, &roleBinding); client.IgnoreNotFound(err) != nil {
+			orphanlog.Error(err, "Failed to delete orphaned RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "foldertree", tree)
+			remaining++
+			continue
+		}
+		orphanlog.Info("Deleted orphaned RoleBinding", "namespace", roleBinding.Namespace, "name", roleBinding.Name, "foldertree", tree)
+	}
+
+	metrics.OrphanedRoleBindings.Set(float64(remaining))
+	return orphans, nil
+}
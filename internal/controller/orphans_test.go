@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+var _ = Describe("FolderTree Controller - Orphan Sweep", func() {
+	const namespaceName = "orphan-sweep-ns"
+	var ctx context.Context
+
+	createRoleBinding := func(name string, labels map[string]string) *rbacv1.RoleBinding {
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespaceName, Labels: labels},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+		}
+		Expect(k8sClient.Create(ctx, roleBinding)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, roleBinding))).To(Succeed())
+		})
+		return roleBinding
+	}
+
+	exists := func(roleBinding *rbacv1.RoleBinding) bool {
+		return k8sClient.Get(ctx, client.ObjectKeyFromObject(roleBinding), &rbacv1.RoleBinding{}) == nil
+	}
+
+	var managed, orphaned, unmanaged *rbacv1.RoleBinding
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{ObjectMeta: metav1.ObjectMeta{Name: "orphan-sweep-current"}}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		managed = createRoleBinding("foldertree-orphan-sweep-current-viewers",
+			map[string]string{"foldertree.rbac.kubevirt.io/tree": "orphan-sweep-current"})
+		orphaned = createRoleBinding("foldertree-orphan-sweep-renamed-viewers",
+			map[string]string{"foldertree.rbac.kubevirt.io/tree": "orphan-sweep-renamed"})
+		unmanaged = createRoleBinding("orphan-sweep-hand-made", nil)
+	})
+
+	It("should report RoleBindings of FolderTrees that no longer exist without deleting them", func() {
+		sweeper := &OrphanSweeper{Client: k8sClient}
+
+		orphans, err := sweeper.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		// Other specs may leave RoleBindings of deleted FolderTrees behind, since envtest has no garbage collector
+		Expect(orphans).To(ContainElement(HaveField("Name", orphaned.Name)))
+		Expect(orphans).NotTo(ContainElement(HaveField("Name", managed.Name)))
+		Expect(testutil.ToFloat64(metrics.OrphanedRoleBindings)).To(Equal(float64(len(orphans))))
+		Expect(exists(orphaned)).To(BeTrue())
+	})
+
+	It("should delete only the orphaned RoleBindings when enabled", func() {
+		sweeper := &OrphanSweeper{Client: k8sClient, Delete: true}
+
+		orphans, err := sweeper.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(ContainElement(HaveField("Name", orphaned.Name)))
+		Expect(testutil.ToFloat64(metrics.OrphanedRoleBindings)).To(Equal(0.0))
+		Expect(exists(orphaned)).To(BeFalse())
+		Expect(exists(managed)).To(BeTrue())
+		Expect(exists(unmanaged)).To(BeTrue())
+	})
+})
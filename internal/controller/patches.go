@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// resolveDesiredTree returns the FolderTree the controller should reconcile towards: the given tree
+// with approved FolderMemberships and FolderTreePatches applied and the namespaces matching its
+// namespacePatterns added. The outcome of every membership and patch targeting the tree is recorded
+// in its status. The FolderTree spec itself is never modified.
+func (r *FolderTreeReconciler) resolveDesiredTree(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) (*rbacv1alpha1.FolderTree, error) {
+	resolver, err := rbac.NewTreeResolver(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	opts := validation.Options{ExcludedNamespaces: r.ExcludedNamespaces}
+	resolver.ValidateSpec = func(spec *rbacv1alpha1.FolderTreeSpec) error {
+		return validation.ValidateFolderTreeSpec(spec, opts)
+	}
+	resolver.RecordMembership = func(membership *rbacv1alpha1.FolderMembership, phase rbacv1alpha1.MembershipPhase, message string) {
+		r.updateMembershipStatus(ctx, membership, phase, message)
+	}
+	resolver.RecordPatch = func(patch *rbacv1alpha1.FolderTreePatch, phase rbacv1alpha1.PatchPhase, message string) {
+		r.updatePatchStatus(ctx, patch, phase, message)
+	}
+	return r.resolvePatternNamespaces(ctx, folderTree, resolver.Resolve(folderTree))
+}
+
+// listTargetingPatches returns the FolderTreePatches targeting a FolderTree, whatever their phase
+func (r *FolderTreeReconciler) listTargetingPatches(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]rbacv1alpha1.FolderTreePatch, error) {
+	var patchList rbacv1alpha1.FolderTreePatchList
+	if err := r.List(ctx, &patchList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTreePatches: %v", err)
+	}
+	var patches []rbacv1alpha1.FolderTreePatch
+	for _, patch := range patchList.Items {
+		if patch.Spec.TreeName == folderTree.Name {
+			patches = append(patches, patch)
+		}
+	}
+	return patches, nil
+}
+
+// updatePatchStatus records the phase of a FolderTreePatch if it changed
+func (r *FolderTreeReconciler) updatePatchStatus(ctx context.Context, patch *rbacv1alpha1.FolderTreePatch,
+	phase rbacv1alpha1.PatchPhase, message string) {
+
+	if patch.Status.Phase == phase && patch.Status.Message == message &&
+		patch.Status.ObservedGeneration == patch.Generation {
+		return
+	}
+
+	patch.Status.Phase = phase
+	patch.Status.Message = message
+	patch.Status.ObservedGeneration = patch.Generation
+
+	// Status updates are best-effort, the next reconcile retries
+	if err := r.Status().Update(ctx, patch); err != nil {
+		logf.FromContext(ctx).Info("Failed to update FolderTreePatch status",
+			"namespace", patch.Namespace, "name", patch.Name, "error", err.Error())
+	}
+}
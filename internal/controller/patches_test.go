@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - FolderTreePatch", func() {
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	editorsTemplate := rbacv1alpha1.RoleBindingTemplate{
+		Name: "editors",
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:     "Group",
+				Name:     "editors",
+				APIGroup: "rbac.authorization.k8s.io",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "edit",
+		},
+	}
+
+	newPatchTree := func(name, namespace string, patchNamespaces ...string) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:            name + "-folder",
+						PatchNamespaces: patchNamespaces,
+						Namespaces:      []string{namespace},
+					},
+				},
+			},
+		}
+	}
+
+	Context("When a folder accepts patches from the namespace", func() {
+		It("should create the RoleBindings of the patch and approve it", func() {
+			resourceName := "test-patch-approved"
+			namespace := "patch-approved-ns"
+			addedNamespace := "patch-approved-added-ns"
+			teamNamespace := "patch-approved-team"
+
+			for _, name := range []string{namespace, addedNamespace, teamNamespace} {
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+				})).To(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, newPatchTree(resourceName, namespace, teamNamespace))).To(Succeed())
+
+			patch := &rbacv1alpha1.FolderTreePatch{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: teamNamespace},
+				Spec: rbacv1alpha1.FolderTreePatchSpec{
+					TreeName:             resourceName,
+					FolderName:           resourceName + "-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{editorsTemplate},
+					Namespaces:           []string{addedNamespace},
+				},
+			}
+			Expect(k8sClient.Create(ctx, patch)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			rb := &rbacv1.RoleBinding{}
+			for _, name := range []string{namespace, addedNamespace} {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-patch-approved-editors", Namespace: name}, rb)).To(Succeed())
+			}
+
+			updated := &rbacv1alpha1.FolderTreePatch{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "team", Namespace: teamNamespace}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(rbacv1alpha1.PatchPhaseApproved))
+
+			By("leaving the FolderTree spec untouched")
+			folderTree := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName}, folderTree)).To(Succeed())
+			Expect(folderTree.Spec.Folders[0].RoleBindingTemplates).To(BeEmpty())
+			Expect(folderTree.Spec.Folders[0].Namespaces).To(HaveLen(1))
+
+			By("removing the RoleBindings when the patch is deleted")
+			Expect(k8sClient.Delete(ctx, updated)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range []string{namespace, addedNamespace} {
+				err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-patch-approved-editors", Namespace: name}, rb)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+	})
+
+	Context("When a folder does not accept patches from the namespace", func() {
+		It("should leave the patch pending", func() {
+			resourceName := "test-patch-pending"
+			namespace := "patch-pending-ns"
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, newPatchTree(resourceName, namespace))).To(Succeed())
+
+			patch := &rbacv1alpha1.FolderTreePatch{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace},
+				Spec: rbacv1alpha1.FolderTreePatchSpec{
+					TreeName:             resourceName,
+					FolderName:           resourceName + "-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{editorsTemplate},
+				},
+			}
+			Expect(k8sClient.Create(ctx, patch)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: resourceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			rb := &rbacv1.RoleBinding{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-patch-pending-editors", Namespace: namespace}, rb)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			updated := &rbacv1alpha1.FolderTreePatch{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "team", Namespace: namespace}, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(rbacv1alpha1.PatchPhasePending))
+		})
+	})
+})
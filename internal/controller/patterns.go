@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// listPatternNamespaces returns the existing namespaces matching a namespacePattern of a FolderTree,
+// whether or not they are assigned elsewhere. Terminating namespaces are left out.
+func (r *FolderTreeReconciler) listPatternNamespaces(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]string, error) {
+	if !rbac.UsesNamespacePatterns(folderTree) {
+		return nil, nil
+	}
+	var namespaceList corev1.NamespaceList
+	if err := r.List(ctx, &namespaceList); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	var namespaces []string
+	for _, namespace := range namespaceList.Items {
+		if namespace.DeletionTimestamp.IsZero() && rbac.MatchesNamespacePattern(folderTree, namespace.Name) {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// resolvePatternNamespaces adds the existing namespaces matching the namespacePatterns of a FolderTree
+// to the folders of desiredTree. Namespaces other FolderTrees list, or match by a pattern first, and
+// namespaces that approved FolderMemberships or FolderTreePatches add to other FolderTrees are left to them.
+func (r *FolderTreeReconciler) resolvePatternNamespaces(ctx context.Context, folderTree, desiredTree *rbacv1alpha1.FolderTree) (*rbacv1alpha1.FolderTree, error) {
+	namespaces, err := r.listPatternNamespaces(ctx, folderTree)
+	if err != nil || len(namespaces) == 0 {
+		return desiredTree, err
+	}
+
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := r.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	claimedByTrees := rbac.ClaimedByOtherTrees(folderTree, folderTreeList.Items)
+
+	requested := make(map[string]bool)
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := r.List(ctx, &membershipList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+	for _, membership := range membershipList.Items {
+		if membership.Spec.TreeName != folderTree.Name && membership.Status.Phase == rbacv1alpha1.MembershipPhaseApproved {
+			requested[membership.Namespace] = true
+		}
+	}
+	var patchList rbacv1alpha1.FolderTreePatchList
+	if err := r.List(ctx, &patchList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTreePatches: %v", err)
+	}
+	for _, patch := range patchList.Items {
+		if patch.Spec.TreeName != folderTree.Name && patch.Status.Phase == rbacv1alpha1.PatchPhaseApproved {
+			for _, namespace := range patch.Spec.Namespaces {
+				requested[namespace] = true
+			}
+		}
+	}
+
+	return rbac.WithPatternNamespaces(desiredTree, namespaces, func(namespace string) bool {
+		return requested[namespace] || claimedByTrees(namespace)
+	}), nil
+}
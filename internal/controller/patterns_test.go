@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Namespace Patterns", func() {
+	const resourceName = "test-namespace-patterns"
+	var (
+		ctx                context.Context
+		reconciler         *FolderTreeReconciler
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	createNamespace := func(name string) {
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+	}
+
+	roleBindingsIn := func(namespace string) []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace),
+			client.MatchingLabels{"foldertree.rbac.kubevirt.io/tree": resourceName})).To(Succeed())
+		return roleBindings.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+		createNamespace("pattern-team-a-web")
+		createNamespace("pattern-team-b-web")
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:             "pattern-team-a",
+						NamespacePattern: "^pattern-team-a-.*$",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "team-a", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	})
+
+	It("should create RoleBindings in the namespaces matching the pattern", func() {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindingsIn("pattern-team-a-web")).To(HaveLen(1))
+		Expect(roleBindingsIn("pattern-team-b-web")).To(BeEmpty())
+
+		By("enqueuing the FolderTree when a matching namespace is created")
+		created := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pattern-team-a-db"}}
+		Expect(reconciler.mapCreatedNamespaceToFolderTrees(ctx, created)).To(ConsistOf(
+			reconcile.Request{NamespacedName: typeNamespacedName}))
+		other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pattern-team-b-db"}}
+		Expect(reconciler.mapCreatedNamespaceToFolderTrees(ctx, other)).To(BeEmpty())
+
+		createNamespace(created.Name)
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindingsIn(created.Name)).To(HaveLen(1))
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Spec.Folders[0].Namespaces).To(BeEmpty())
+	})
+
+	It("should leave matching namespaces listed by another FolderTree to it", func() {
+		otherTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-namespace-patterns-other"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "pattern-listed", Namespaces: []string{"pattern-team-a-web"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, otherTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, otherTree))).To(Succeed())
+		})
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindingsIn("pattern-team-a-web")).To(BeEmpty())
+	})
+})
@@ -325,6 +325,16 @@ var _ = Describe("FolderTree Controller - Propagate Field", func() {
 			Expect(childRBNames).NotTo(HaveKey("foldertree-test-mixed-propagation-parent-only-secrets"), "Should NOT have parent-only-secrets (no propagate field)")
 			Expect(childRBNames).NotTo(HaveKey("foldertree-test-mixed-propagation-parent-explicit-no-propagate"), "Should NOT have parent-explicit-no-propagate (propagate: false)")
 
+			By("Verifying the inheritance summary in status")
+			updated := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.Inheritance).To(HaveLen(2))
+			Expect(updated.Status.Inheritance[0].Path).To(Equal("parent"))
+			Expect(updated.Status.Inheritance[0].Contributed).To(Equal([]string{"shared-platform-access"}))
+			Expect(updated.Status.Inheritance[1].Path).To(Equal("parent/child"))
+			Expect(updated.Status.Inheritance[1].Received).To(Equal([]string{"shared-platform-access (from parent)"}))
+			Expect(updated.Status.Inheritance[1].Summary).To(Equal("receives 1, contributes 0"))
+
 			// Clean up
 			Expect(k8sClient.Delete(ctx, folderTree)).To(Succeed())
 			Expect(k8sClient.Delete(ctx, parentNS)).To(Succeed())
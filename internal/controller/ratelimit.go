@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+// waitForWrite blocks until the WriteLimiter allows another RoleBinding write, so that a change
+// touching thousands of RoleBindings is spread out instead of hitting the API server at once.
+// Writes that have to wait are counted as throttled and reported as queued while waiting.
+func (r *FolderTreeReconciler) waitForWrite(ctx context.Context) error {
+	if r.WriteLimiter == nil || r.WriteLimiter.Allow() {
+		return nil
+	}
+	metrics.RoleBindingWritesThrottled.Inc()
+	metrics.RoleBindingWritesQueued.Inc()
+	defer metrics.RoleBindingWritesQueued.Dec()
+	if err := r.WriteLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for the RoleBinding write rate limiter: %w", err)
+	}
+	return nil
+}
+
+// markProgressing persists the Progressing condition before executing more operations than the
+// WriteLimiter allows at once, so that a FolderTree applying a large change shows it is in progress
+// instead of its previous state. The condition is replaced by the outcome of the reconcile.
+func (r *FolderTreeReconciler) markProgressing(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, operations int) {
+	if r.WriteLimiter == nil || float64(operations) <= r.WriteLimiter.Tokens() {
+		return
+	}
+	limit := float64(r.WriteLimiter.Limit())
+	estimate := time.Duration(float64(operations) / limit * float64(time.Second)).Round(time.Second)
+	r.updateStatus(ctx, folderTree, rbacv1alpha1.ConditionTypeProgressing, fmt.Sprintf(
+		"Applying %d RoleBinding operations at up to %g writes per second (about %s)", operations, limit, estimate))
+}
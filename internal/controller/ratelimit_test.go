@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+// progressingClient records whether the FolderTree reported the Progressing condition while its
+// RoleBindings were being created
+type progressingClient struct {
+	client.Client
+	treeName           string
+	createsProgressing atomic.Int32
+}
+
+func (c *progressingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*rbacv1.RoleBinding); ok {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		if err := c.Get(ctx, types.NamespacedName{Name: c.treeName}, folderTree); err == nil &&
+			meta.IsStatusConditionTrue(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeProgressing) {
+			c.createsProgressing.Add(1)
+		}
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("FolderTree Controller - Write Rate Limiting", func() {
+	const resourceName = "test-rate-limit"
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		namespaces         []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespaces = nil
+		for i := range 4 {
+			namespace := fmt.Sprintf("rate-limit-ns-%d", i)
+			namespaces = append(namespaces, namespace)
+			namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name: "rate-limit-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+					Namespaces: namespaces,
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			for _, namespace := range namespaces {
+				roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "foldertree-test-rate-limit-viewers", Namespace: namespace}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, roleBinding))).To(Succeed())
+			}
+		})
+	})
+
+	It("should throttle writes beyond the burst and report the FolderTree as Progressing meanwhile", func() {
+		progressing := &progressingClient{Client: k8sClient, treeName: resourceName}
+		reconciler := &FolderTreeReconciler{
+			Client:       progressing,
+			Scheme:       k8sClient.Scheme(),
+			WriteLimiter: rate.NewLimiter(50, 1),
+		}
+		throttledBefore := testutil.ToFloat64(metrics.RoleBindingWritesThrottled)
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		// Only the first create fits the burst
+		Expect(testutil.ToFloat64(metrics.RoleBindingWritesThrottled) - throttledBefore).To(BeNumerically(">=", 3))
+		Expect(testutil.ToFloat64(metrics.RoleBindingWritesQueued)).To(BeZero())
+		Expect(progressing.createsProgressing.Load()).To(BeNumerically("==", 4))
+		for _, namespace := range namespaces {
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rate-limit-viewers", Namespace: namespace}, roleBinding)).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(meta.FindStatusCondition(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeProgressing)).To(BeNil())
+	})
+
+	It("should not report Progressing when the operations fit the burst", func() {
+		progressing := &progressingClient{Client: k8sClient, treeName: resourceName}
+		reconciler := &FolderTreeReconciler{
+			Client:       progressing,
+			Scheme:       k8sClient.Scheme(),
+			WriteLimiter: rate.NewLimiter(50, 10),
+		}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progressing.createsProgressing.Load()).To(BeZero())
+	})
+})
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// accessRecordingClient records, for every RoleBinding deleted through it, which roleRefs the
+// remaining RoleBindings of the namespace still grant at that moment
+type accessRecordingClient struct {
+	client.Client
+	grantedAtDelete [][]string
+}
+
+func (c *accessRecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if _, ok := obj.(*rbacv1.RoleBinding); ok {
+		roleBindingList := &rbacv1.RoleBindingList{}
+		if err := c.List(ctx, roleBindingList, client.InNamespace(obj.GetNamespace())); err != nil {
+			return err
+		}
+		var granted []string
+		for _, roleBinding := range roleBindingList.Items {
+			granted = append(granted, roleBinding.RoleRef.Name)
+		}
+		c.grantedAtDelete = append(c.grantedAtDelete, granted)
+	}
+	return nil
+}
+
+var _ = Describe("FolderTree Controller - Replacement Policy", func() {
+	const resourceName = "test-replacement"
+	const namespace = "replacement-ns"
+
+	var (
+		ctx                context.Context
+		recordingClient    *accessRecordingClient
+		reconciler         *FolderTreeReconciler
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recordingClient = &accessRecordingClient{Client: k8sClient}
+		reconciler = &FolderTreeReconciler{Client: recordingClient, Scheme: k8sClient.Scheme()}
+
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		}))).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "replacement-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "developers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "developers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+			},
+		})).To(Succeed())
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, folderTree); err == nil {
+			Expect(k8sClient.Delete(ctx, folderTree)).To(Succeed())
+		}
+		Expect(k8sClient.DeleteAllOf(ctx, &rbacv1.RoleBinding{}, client.InNamespace(namespace))).To(Succeed())
+	})
+
+	// changeRoleRef changes the roleRef of the template, optionally with a replacement policy, and reconciles
+	changeRoleRef := func(policy rbacv1alpha1.ReplacementPolicy) {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.ReplacementPolicy = policy
+		folderTree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "edit"
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should grant the new roleRef before revoking the old one by default", func() {
+		changeRoleRef("")
+
+		Expect(recordingClient.grantedAtDelete).To(Equal([][]string{{"edit"}}))
+
+		roleBindingList := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindingList, client.InNamespace(namespace))).To(Succeed())
+		Expect(roleBindingList.Items).To(HaveLen(1))
+		Expect(roleBindingList.Items[0].Name).To(HavePrefix("foldertree-test-replacement-developers-"))
+		Expect(roleBindingList.Items[0].RoleRef.Name).To(Equal("edit"))
+
+		By("leaving the replacement alone on the next reconcile")
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordingClient.grantedAtDelete).To(HaveLen(1))
+	})
+
+	It("should delete first and keep the name with the DeleteBeforeCreate replacement policy", func() {
+		changeRoleRef(rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate)
+
+		Expect(recordingClient.grantedAtDelete).To(Equal([][]string{nil}))
+
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "foldertree-test-replacement-developers"}, roleBinding)).To(Succeed())
+		Expect(roleBinding.RoleRef.Name).To(Equal("edit"))
+	})
+})
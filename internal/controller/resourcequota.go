@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// reconcileResourceQuotas creates, updates and deletes the ResourceQuotas of the resource quota
+// templates of a FolderTree. desiredTree is the FolderTree with the namespaces of approved
+// FolderMemberships added. Out-of-band edits are handled according to the drift policy.
+// Failed operations do not stop the remaining ones.
+func (r *FolderTreeReconciler) reconcileResourceQuotas(ctx context.Context, desiredTree *rbacv1alpha1.FolderTree) error {
+	log := logf.FromContext(ctx)
+
+	builder := &rbac.ResourceQuotaBuilder{
+		FolderTree:             desiredTree,
+		Scheme:                 r.Scheme,
+		ExcludedNamespaces:     r.ExcludedNamespaces,
+		DisableOwnerReferences: r.DisableOwnerReferences,
+	}
+	diff, err := rbac.AnalyzeResourceQuotaDiff(ctx, r.Client, desiredTree, builder)
+	if err != nil {
+		return fmt.Errorf("failed to analyze ResourceQuota operations: %v", err)
+	}
+	for _, drifted := range diff.Drift {
+		log.Info("ResourceQuota modified out-of-band was not reverted", "driftPolicy", desiredTree.Spec.DriftPolicy,
+			"name", drifted.Existing.Name, "namespace", drifted.Existing.Namespace)
+	}
+
+	var errs []error
+	for _, operation := range diff.Operations {
+		if err := r.executeResourceQuotaOperation(ctx, operation); err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s: %v", operation.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// executeResourceQuotaOperation performs a single ResourceQuota operation, see executeNetworkPolicyOperation
+func (r *FolderTreeReconciler) executeResourceQuotaOperation(ctx context.Context, operation rbac.ResourceQuotaOperation) error {
+	log := logf.FromContext(ctx)
+
+	switch operation.Type {
+	case rbac.OperationCreate:
+		err := r.Get(ctx, types.NamespacedName{Name: operation.Desired.Namespace}, &corev1.Namespace{})
+		if apierrors.IsNotFound(err) {
+			log.Info("Namespace not found, skipping ResourceQuota creation", "namespace", operation.Desired.Namespace)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		log.Info("Creating ResourceQuota", "name", operation.Desired.Name, "namespace", operation.Desired.Namespace)
+		return r.Create(ctx, operation.Desired, client.FieldOwner(FieldManager))
+
+	case rbac.OperationUpdate:
+		updated := operation.Existing.DeepCopy()
+		updated.Spec = operation.Desired.Spec
+		if updated.Labels == nil {
+			updated.Labels = make(map[string]string)
+		}
+		for key, value := range operation.Desired.Labels {
+			if rbac.IsManagedKey(key) {
+				updated.Labels[key] = value
+			}
+		}
+		if updated.Annotations == nil {
+			updated.Annotations = make(map[string]string)
+		}
+		updated.Annotations[rbac.AppliedDigestAnnotation] = operation.Desired.Annotations[rbac.AppliedDigestAnnotation]
+		if r.Scheme != nil {
+			updated.OwnerReferences = rbac.MergeResourceQuotaOwnerReferences(operation.Existing, operation.Desired)
+		}
+		log.Info("Updating ResourceQuota", "name", updated.Name, "namespace", updated.Namespace)
+		return r.Update(ctx, updated, client.FieldOwner(FieldManager))
+
+	case rbac.OperationDelete:
+		log.Info("Deleting ResourceQuota", "name", operation.Existing.Name, "namespace", operation.Existing.Namespace)
+		return client.IgnoreNotFound(r.Delete(ctx, operation.Existing))
+	}
+	return nil
+}
+
+// resourceQuotaChangedPredicate drops ResourceQuota updates that only change the status. The quota
+// controller updates the used amounts whenever pods come and go, which must not trigger reconciles.
+func resourceQuotaChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldQuota, oldOK := e.ObjectOld.(*corev1.ResourceQuota)
+			newQuota, newOK := e.ObjectNew.(*corev1.ResourceQuota)
+			if !oldOK || !newOK {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(oldQuota.Spec, newQuota.Spec) ||
+				!equality.Semantic.DeepEqual(oldQuota.Labels, newQuota.Labels) ||
+				!equality.Semantic.DeepEqual(oldQuota.Annotations, newQuota.Annotations) ||
+				!equality.Semantic.DeepEqual(oldQuota.OwnerReferences, newQuota.OwnerReferences)
+		},
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - ResourceQuota Templates", func() {
+	const (
+		resourceName = "test-resource-quotas"
+		teamNS       = "resource-quotas-team"
+		batchNS      = "resource-quotas-batch"
+		quotaName    = "foldertree-test-resource-quotas-compute"
+	)
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		reconciler         *FolderTreeReconciler
+	)
+
+	quotaTemplate := func(cpu string) rbacv1alpha1.ResourceQuotaTemplate {
+		return rbacv1alpha1.ResourceQuotaTemplate{
+			Name:      "compute",
+			Spec:      corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(cpu)}},
+			Propagate: ptr.To(true),
+		}
+	}
+
+	getQuota := func(namespace string) *corev1.ResourceQuota {
+		resourceQuota := &corev1.ResourceQuota{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: quotaName}, resourceQuota)).To(Succeed())
+		return resourceQuota
+	}
+
+	cpuOf := func(resourceQuota *corev1.ResourceQuota) string {
+		hard := resourceQuota.Spec.Hard[corev1.ResourceRequestsCPU]
+		return hard.String()
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		for _, name := range []string{teamNS, batchNS} {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "team", Subfolders: []rbacv1alpha1.TreeNode{{Name: "batch"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "team", Namespaces: []string{teamNS}, ResourceQuotaTemplates: []rbacv1alpha1.ResourceQuotaTemplate{quotaTemplate("4")}},
+					{Name: "batch", Namespaces: []string{batchNS}, ResourceQuotaTemplates: []rbacv1alpha1.ResourceQuotaTemplate{quotaTemplate("16")}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			current := &rbacv1alpha1.FolderTree{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, current); err == nil {
+				controllerutil.RemoveFinalizer(current, CleanupFinalizer)
+				Expect(k8sClient.Update(ctx, current)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, current))).To(Succeed())
+			}
+			for _, namespace := range []string{teamNS, batchNS} {
+				resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: quotaName}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resourceQuota))).To(Succeed())
+			}
+		})
+	})
+
+	It("should create ResourceQuotas with child folders overriding their parents", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		Expect(cpuOf(getQuota(teamNS))).To(Equal("4"))
+		Expect(cpuOf(getQuota(batchNS))).To(Equal("16"))
+		Expect(getQuota(batchNS).Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", resourceName))
+	})
+
+	It("should fall back to the inherited quota when the override is removed", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.Folders[1].ResourceQuotaTemplates = nil
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		Expect(cpuOf(getQuota(batchNS))).To(Equal("4"))
+	})
+
+	It("should handle out-of-band edits according to the drift policy", func() {
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		resourceQuota := getQuota(teamNS)
+		resourceQuota.Spec.Hard[corev1.ResourceRequestsCPU] = resource.MustParse("64")
+		Expect(k8sClient.Update(ctx, resourceQuota)).To(Succeed())
+
+		By("leaving the edit in place under the Warn drift policy")
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(cpuOf(getQuota(teamNS))).To(Equal("64"))
+
+		By("reverting the edit under the Enforce drift policy")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyEnforce
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(cpuOf(getQuota(teamNS))).To(Equal("4"))
+	})
+})
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// failingClient fails RoleBinding creates and updates with queued errors, keyed by "<verb>/<namespace>"
+type failingClient struct {
+	client.Client
+	failures map[string][]error
+	mu       sync.Mutex
+}
+
+func (c *failingClient) nextFailure(verb string, obj client.Object) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := verb + "/" + obj.GetNamespace()
+	if _, ok := obj.(*rbacv1.RoleBinding); !ok || len(c.failures[key]) == 0 {
+		return nil
+	}
+	err := c.failures[key][0]
+	c.failures[key] = c.failures[key][1:]
+	return err
+}
+
+func (c *failingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.nextFailure("create", obj); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *failingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.nextFailure("update", obj); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+var _ = Describe("FolderTree Controller - Operation Retries", func() {
+	const resourceName = "test-retries"
+	var (
+		ctx                context.Context
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+		namespaces         = []string{"retry-ns-a", "retry-ns-b"}
+		roleBindingName    = "foldertree-test-retries-viewers"
+		failing            *failingClient
+		reconciler         *FolderTreeReconciler
+	)
+
+	roleBindingResource := schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}
+
+	getRoleBinding := func(namespace string) (*rbacv1.RoleBinding, error) {
+		roleBinding := &rbacv1.RoleBinding{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: roleBindingName, Namespace: namespace}, roleBinding)
+		return roleBinding, err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		for _, namespace := range namespaces {
+			namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+		}
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "retry-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: namespaces,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		failing = &failingClient{Client: k8sClient, failures: map[string][]error{}}
+		reconciler = &FolderTreeReconciler{Client: failing, Scheme: k8sClient.Scheme()}
+
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			for _, namespace := range namespaces {
+				if roleBinding, err := getRoleBinding(namespace); err == nil {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, roleBinding))).To(Succeed())
+				}
+			}
+		})
+	})
+
+	It("should retry a transient failure within the same reconcile", func() {
+		failing.failures["create/retry-ns-a"] = []error{apierrors.NewServiceUnavailable("etcd leader changed")}
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(failing.failures["create/retry-ns-a"]).To(BeEmpty())
+
+		for _, namespace := range namespaces {
+			_, err := getRoleBinding(namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+	})
+
+	It("should requeue after the suggested delay when transient failures persist", func() {
+		for range operationRetryBackoff.Steps {
+			failing.failures["create/retry-ns-a"] = append(failing.failures["create/retry-ns-a"],
+				apierrors.NewTooManyRequests("slow down", 10))
+		}
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+
+		By("still applying the remaining operations")
+		_, err = getRoleBinding("retry-ns-b")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = getRoleBinding("retry-ns-a")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypePartiallyApplied)).To(BeTrue())
+
+		By("applying the operation on the next attempt")
+		result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		_, err = getRoleBinding("retry-ns-a")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not retry forbidden errors and fall back to exponential backoff", func() {
+		failing.failures["create/retry-ns-a"] = []error{
+			apierrors.NewForbidden(roleBindingResource, roleBindingName, nil),
+			apierrors.NewForbidden(roleBindingResource, roleBindingName, nil),
+		}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).To(HaveOccurred())
+		Expect(failing.failures["create/retry-ns-a"]).To(HaveLen(1))
+
+		_, err = getRoleBinding("retry-ns-b")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report the outcome of every namespace in status.namespaces", func() {
+		failing.failures["create/retry-ns-a"] = []error{apierrors.NewForbidden(roleBindingResource, roleBindingName, nil)}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).To(HaveOccurred())
+
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.Namespaces).To(HaveLen(2))
+		Expect(folderTree.Status.Namespaces[0].Name).To(Equal("retry-ns-a"))
+		Expect(folderTree.Status.Namespaces[0].Phase).To(Equal(rbacv1alpha1.NamespacePhaseFailed))
+		Expect(folderTree.Status.Namespaces[0].Message).To(ContainSubstring("1 of 1 RoleBinding operations failed, the last with: create template 'viewers'"))
+		Expect(folderTree.Status.Namespaces[0].Message).To(ContainSubstring("forbidden"))
+		Expect(folderTree.Status.Namespaces[1]).To(Equal(rbacv1alpha1.NamespaceStatus{Name: "retry-ns-b", Phase: rbacv1alpha1.NamespacePhaseSynced}))
+
+		By("marking the namespace synced once the operation succeeds")
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		Expect(folderTree.Status.Namespaces).To(ConsistOf(
+			rbacv1alpha1.NamespaceStatus{Name: "retry-ns-a", Phase: rbacv1alpha1.NamespacePhaseSynced},
+			rbacv1alpha1.NamespaceStatus{Name: "retry-ns-b", Phase: rbacv1alpha1.NamespacePhaseSynced},
+		))
+	})
+
+	It("should retry an update with the latest version after a conflict", func() {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		roleBinding, err := getRoleBinding("retry-ns-a")
+		Expect(err).NotTo(HaveOccurred())
+		roleBinding.Subjects = []rbacv1.Subject{{Kind: "User", Name: "intruder", APIGroup: "rbac.authorization.k8s.io"}}
+		Expect(k8sClient.Update(ctx, roleBinding)).To(Succeed())
+
+		failing.failures["update/retry-ns-a"] = []error{apierrors.NewConflict(roleBindingResource, roleBindingName, nil)}
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		roleBinding, err = getRoleBinding("retry-ns-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBinding.Subjects).To(ConsistOf(HaveField("Name", "viewers")))
+	})
+})
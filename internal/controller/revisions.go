@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// DefaultRevisionHistoryLimit is the number of FolderTreeRevisions kept when spec.revisionHistoryLimit is unset
+const DefaultRevisionHistoryLimit = 10
+
+// Event reasons recorded on a FolderTree for rollbacks requested with rbacv1alpha1.RollbackToAnnotation
+const (
+	EventReasonRolledBack     = "RolledBack"
+	EventReasonRollbackFailed = "RollbackFailed"
+)
+
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertreerevisions,verbs=get;list;watch;create;delete
+
+// rollback restores the spec of the FolderTreeRevision named by the rbacv1alpha1.RollbackToAnnotation
+// and removes the annotation with an optimistic-lock patch, so concurrent spec edits are not
+// overwritten. The spec is only restored if it still has the hash the webhook recorded in the
+// rbacv1alpha1.RollbackSpecHashAnnotation when it validated the rollback. A target that does not
+// exist or no longer matches is recorded as a Warning Event and the annotations are removed as well,
+// since retrying cannot bring the validated revision back.
+func (r *FolderTreeReconciler) rollback(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	target := folderTree.Annotations[rbacv1alpha1.RollbackToAnnotation]
+	spec, targetErr := rbac.GetRollbackTarget(ctx, r.Client, folderTree.Name, target)
+	if targetErr == nil {
+		targetErr = verifyRollbackSpec(folderTree, target, spec)
+	}
+
+	original := folderTree.DeepCopy()
+	delete(folderTree.Annotations, rbacv1alpha1.RollbackToAnnotation)
+	delete(folderTree.Annotations, rbacv1alpha1.RollbackSpecHashAnnotation)
+	if targetErr == nil {
+		folderTree.Spec = spec
+	}
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := r.Patch(ctx, folderTree, patch); err != nil {
+		original.DeepCopyInto(folderTree)
+		return fmt.Errorf("failed to roll back to revision %s: %v", target, err)
+	}
+
+	if r.Recorder != nil {
+		if targetErr != nil {
+			r.Recorder.Eventf(folderTree, corev1.EventTypeWarning, EventReasonRollbackFailed, "Rollback failed: %v", targetErr)
+		} else {
+			r.Recorder.Eventf(folderTree, corev1.EventTypeNormal, EventReasonRolledBack, "Restored the spec of revision %s", target)
+		}
+	}
+	return nil
+}
+
+// verifyRollbackSpec checks that the spec of the rollback target has the hash the webhook validated
+func verifyRollbackSpec(folderTree *rbacv1alpha1.FolderTree, target string, spec rbacv1alpha1.FolderTreeSpec) error {
+	validated, ok := folderTree.Annotations[rbacv1alpha1.RollbackSpecHashAnnotation]
+	if !ok {
+		return fmt.Errorf("the rollback to revision %s was not admitted by the webhook", target)
+	}
+	hash, err := rbac.RevisionSpecHash(spec)
+	if err != nil {
+		return fmt.Errorf("failed to hash the spec of revision %s: %v", target, err)
+	}
+	if hash != validated {
+		return fmt.Errorf("the spec of revision %s changed after the rollback was validated", target)
+	}
+	return nil
+}
+
+// recordRevision snapshots the successfully applied spec of a FolderTree in a new FolderTreeRevision
+// unless it equals the latest one, deletes the revisions beyond spec.revisionHistoryLimit and lists
+// the remaining ones in status. The status is persisted by the following updateStatus call.
+func (r *FolderTreeReconciler) recordRevision(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	revisions, err := rbac.ListRevisions(ctx, r.Client, folderTree.Name)
+	if err != nil {
+		return err
+	}
+
+	limit := DefaultRevisionHistoryLimit
+	if folderTree.Spec.RevisionHistoryLimit != nil {
+		limit = int(*folderTree.Spec.RevisionHistoryLimit)
+	}
+
+	if limit > 0 {
+		current, err := r.currentRevision(ctx, folderTree, revisions)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			revisions = append(revisions, *current)
+		}
+	}
+
+	// Delete the oldest revisions beyond the limit
+	for len(revisions) > limit {
+		if err := r.Delete(ctx, &revisions[0]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete FolderTreeRevision '%s': %v", revisions[0].Name, err)
+		}
+		revisions = revisions[1:]
+	}
+
+	folderTree.Status.CurrentRevision = 0
+	folderTree.Status.Revisions = nil
+	for _, revision := range revisions {
+		folderTree.Status.Revisions = append(folderTree.Status.Revisions, rbacv1alpha1.RevisionStatus{
+			Revision:     revision.Revision,
+			Name:         revision.Name,
+			Generation:   revision.Generation,
+			CreationTime: revision.CreationTimestamp,
+		})
+		folderTree.Status.CurrentRevision = revision.Revision
+	}
+	return nil
+}
+
+// currentRevision creates the FolderTreeRevision of the spec of a FolderTree, numbered after the
+// latest of its existing revisions. It returns nil when the latest revision holds the same spec.
+func (r *FolderTreeReconciler) currentRevision(ctx context.Context, folderTree *rbacv1alpha1.FolderTree,
+	revisions []rbacv1alpha1.FolderTreeRevision) (*rbacv1alpha1.FolderTreeRevision, error) {
+	number := int64(1)
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		spec, err := rbac.RevisionSpec(&latest)
+		if err == nil && equality.Semantic.DeepEqual(spec, folderTree.Spec) {
+			return nil, nil
+		}
+		number = latest.Revision + 1
+	}
+
+	data, err := json.Marshal(folderTree.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the spec of FolderTree '%s': %v", folderTree.Name, err)
+	}
+	revision := &rbacv1alpha1.FolderTreeRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   rbac.RevisionName(folderTree.Name, number),
+			Labels: map[string]string{"foldertree.rbac.kubevirt.io/tree": folderTree.Name},
+		},
+		Revision:   number,
+		Generation: folderTree.Generation,
+		Data:       runtime.RawExtension{Raw: data},
+	}
+	if r.Scheme != nil {
+		if err := controllerutil.SetControllerReference(folderTree, revision, r.Scheme); err != nil {
+			return nil, err
+		}
+	}
+	// The cache may not have caught up with a revision created by the previous reconcile
+	if err := r.Create(ctx, revision); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create FolderTreeRevision '%s': %v", revision.Name, err)
+	}
+	return revision, nil
+}
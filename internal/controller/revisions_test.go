@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+var _ = Describe("FolderTree Controller - Revisions", func() {
+	const (
+		resourceName = "test-revisions"
+		namespace    = "revisions-ns"
+	)
+	var (
+		ctx                context.Context
+		reconciler         *FolderTreeReconciler
+		recorder           *record.FakeRecorder
+		typeNamespacedName = types.NamespacedName{Name: resourceName}
+	)
+
+	template := func(name, role string) rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{
+			Name:     name,
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: role},
+		}
+	}
+
+	updateSpec := func(mutate func(folderTree *rbacv1alpha1.FolderTree)) {
+		folderTree := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, folderTree)).To(Succeed())
+		mutate(folderTree)
+		Expect(k8sClient.Update(ctx, folderTree)).To(Succeed())
+	}
+
+	drainEvents := func() {
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+	}
+
+	revisionNumbers := func(folderTree *rbacv1alpha1.FolderTree) []int64 {
+		var numbers []int64
+		for _, revision := range folderTree.Status.Revisions {
+			numbers = append(numbers, revision.Revision)
+		}
+		return numbers
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = record.NewFakeRecorder(100)
+		reconciler = &FolderTreeReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: recorder,
+		}
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:                 "revisions-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", "view")},
+					Namespaces:           []string{namespace},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			// Without a garbage collector, the owned revisions and RoleBindings are deleted by hand
+			Expect(k8sClient.DeleteAllOf(ctx, &rbacv1alpha1.FolderTreeRevision{},
+				client.MatchingLabels{"foldertree.rbac.kubevirt.io/tree": resourceName})).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &rbacv1.RoleBinding{}, client.InNamespace(namespace))).To(Succeed())
+		})
+	})
+
+	It("should record a revision for every applied spec", func() {
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Status.CurrentRevision).To(Equal(int64(1)))
+		Expect(revisionNumbers(folderTree)).To(Equal([]int64{1}))
+		Expect(folderTree.Status.Revisions[0].Name).To(Equal(resourceName + "-1"))
+		Expect(folderTree.Status.Revisions[0].Generation).To(Equal(folderTree.Generation))
+
+		revision := &rbacv1alpha1.FolderTreeRevision{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-1"}, revision)).To(Succeed())
+		Expect(revision.OwnerReferences).To(HaveLen(1))
+		Expect(revision.OwnerReferences[0].Name).To(Equal(resourceName))
+		spec, err := rbac.RevisionSpec(revision)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(folderTree.Spec))
+
+		By("not recording a revision when reconciling the same spec again")
+		reconciler.observed.forget(resourceName)
+		Expect(revisionNumbers(reconcileAndGet(ctx, reconciler, resourceName))).To(Equal([]int64{1}))
+
+		By("recording a new revision for a changed spec")
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0] = template("editors", "edit")
+		})
+		folderTree = reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Status.CurrentRevision).To(Equal(int64(2)))
+		Expect(revisionNumbers(folderTree)).To(Equal([]int64{1, 2}))
+	})
+
+	It("should keep at most spec.revisionHistoryLimit revisions", func() {
+		for _, role := range []string{"view", "edit", "admin"} {
+			updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+				folderTree.Spec.RevisionHistoryLimit = ptr.To[int32](2)
+				folderTree.Spec.Folders[0].RoleBindingTemplates[0] = template("team", role)
+			})
+			reconcileAndGet(ctx, reconciler, resourceName)
+		}
+
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(revisionNumbers(folderTree)).To(Equal([]int64{2, 3}))
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-1"}, &rbacv1alpha1.FolderTreeRevision{})).NotTo(Succeed())
+
+		By("deleting all revisions with a limit of 0")
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Spec.RevisionHistoryLimit = ptr.To[int32](0)
+		})
+		folderTree = reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Status.Revisions).To(BeEmpty())
+		Expect(folderTree.Status.CurrentRevision).To(BeZero())
+	})
+
+	It("should restore the spec of the revision named by the rollback annotation", func() {
+		original := reconcileAndGet(ctx, reconciler, resourceName).Spec
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0] = template("editors", "edit")
+		})
+		reconcileAndGet(ctx, reconciler, resourceName)
+
+		hash, err := rbac.RevisionSpecHash(original)
+		Expect(err).NotTo(HaveOccurred())
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Annotations = map[string]string{
+				rbacv1alpha1.RollbackToAnnotation:       "1",
+				rbacv1alpha1.RollbackSpecHashAnnotation: hash,
+			}
+		})
+		drainEvents()
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Spec).To(Equal(original))
+		Expect(folderTree.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackToAnnotation))
+		Expect(folderTree.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackSpecHashAnnotation))
+		Expect(recorder.Events).To(Receive(Equal("Normal RolledBack Restored the spec of revision 1")))
+
+		By("recording the restored spec as a new revision")
+		folderTree = reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(revisionNumbers(folderTree)).To(Equal([]int64{1, 2, 3}))
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		Expect(roleBindings.Items).To(HaveLen(1))
+		Expect(roleBindings.Items[0].RoleRef.Name).To(Equal("view"))
+	})
+
+	It("should drop a rollback whose revision changed after it was validated", func() {
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		spec := folderTree.Spec
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Annotations = map[string]string{
+				rbacv1alpha1.RollbackToAnnotation:       "1",
+				rbacv1alpha1.RollbackSpecHashAnnotation: "validated-elsewhere",
+			}
+		})
+		drainEvents()
+
+		folderTree = reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Spec).To(Equal(spec))
+		Expect(folderTree.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackToAnnotation))
+		Expect(folderTree.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackSpecHashAnnotation))
+		Expect(recorder.Events).To(Receive(Equal(
+			"Warning RollbackFailed Rollback failed: the spec of revision 1 changed after the rollback was validated")))
+	})
+
+	It("should drop a rollback to a revision that does not exist", func() {
+		folderTree := reconcileAndGet(ctx, reconciler, resourceName)
+		spec := folderTree.Spec
+		updateSpec(func(folderTree *rbacv1alpha1.FolderTree) {
+			folderTree.Annotations = map[string]string{rbacv1alpha1.RollbackToAnnotation: "7"}
+		})
+		drainEvents()
+
+		folderTree = reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(folderTree.Spec).To(Equal(spec))
+		Expect(folderTree.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackToAnnotation))
+		Expect(recorder.Events).To(Receive(Equal(
+			"Warning RollbackFailed Rollback failed: revision 7 of FolderTree 'test-revisions' does not exist")))
+	})
+})
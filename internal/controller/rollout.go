@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+const (
+	// defaultWaveRequeue is used between waves when no minWaveInterval is configured
+	defaultWaveRequeue = time.Second
+)
+
+// processRolloutWave applies the operations for the next wave of namespaces and records
+// the wave in status. Operations are grouped by namespace so a namespace is always
+// updated as a whole. Returns the delay before the next wave, or zero when the rollout is complete.
+func (r *FolderTreeReconciler) processRolloutWave(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, operations []rbac.RoleBindingOperation) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	strategy := folderTree.Spec.RolloutStrategy
+
+	// Group operations by namespace, in a stable order
+	operationsByNamespace := make(map[string][]rbac.RoleBindingOperation)
+	for _, operation := range operations {
+		operationsByNamespace[operation.Namespace] = append(operationsByNamespace[operation.Namespace], operation)
+	}
+	pendingNamespaces := make([]string, 0, len(operationsByNamespace))
+	for namespace := range operationsByNamespace {
+		// Namespaces that don't exist yet can't be changed and must not hold up the rollout
+		exists, err := r.namespaceExists(ctx, namespace)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			pendingNamespaces = append(pendingNamespaces, namespace)
+		}
+	}
+	sort.Strings(pendingNamespaces)
+
+	rollout := folderTree.Status.Rollout
+	if len(pendingNamespaces) == 0 {
+		// Nothing left to do - mark any previous rollout as finished
+		if rollout != nil {
+			rollout.RemainingNamespaces = 0
+		}
+		return 0, nil
+	}
+
+	// Start a new rollout for a new generation or after the previous one completed
+	if rollout == nil || rollout.ObservedGeneration != folderTree.Generation || rollout.RemainingNamespaces == 0 {
+		rollout = &rbacv1alpha1.RolloutStatus{
+			ObservedGeneration: folderTree.Generation,
+			TotalNamespaces:    int32(len(pendingNamespaces)),
+		}
+		folderTree.Status.Rollout = rollout
+	}
+
+	// Respect the minimum interval between waves
+	interval := time.Duration(0)
+	if strategy.MinWaveInterval != nil {
+		interval = strategy.MinWaveInterval.Duration
+	}
+	if rollout.LastWaveTime != nil && interval > 0 {
+		// A wave time in the future can't be trusted (e.g. a tampered status) and must not stall the rollout
+		if elapsed := time.Since(rollout.LastWaveTime.Time); elapsed >= 0 && elapsed < interval {
+			rollout.RemainingNamespaces = int32(len(pendingNamespaces))
+			return interval - elapsed, nil
+		}
+	}
+
+	// Apply the next wave
+	batchSize := rolloutBatchSize(strategy, countSpecNamespaces(folderTree))
+	if batchSize == 0 || batchSize > len(pendingNamespaces) {
+		batchSize = len(pendingNamespaces)
+	}
+	waveNamespaces := pendingNamespaces[:batchSize]
+
+	var waveOperations []rbac.RoleBindingOperation
+	for _, namespace := range waveNamespaces {
+		waveOperations = append(waveOperations, operationsByNamespace[namespace]...)
+	}
+
+	log.Info("Applying rollout wave", "wave", rollout.CurrentWave+1, "namespaces", len(waveNamespaces),
+		"operations", len(waveOperations), "remaining", len(pendingNamespaces)-len(waveNamespaces))
+	// Failed operations are recorded but don't prevent the wave from being tracked
+	executeErr := r.executeOperations(ctx, folderTree, waveOperations)
+
+	now := metav1.Now()
+	rollout.CurrentWave++
+	rollout.LastWaveTime = &now
+	rollout.RemainingNamespaces = int32(len(pendingNamespaces) - len(waveNamespaces))
+	rollout.Waves = append(rollout.Waves, rbacv1alpha1.RolloutWave{
+		Number:     rollout.CurrentWave,
+		Namespaces: waveNamespaces,
+		Operations: int32(len(waveOperations)),
+		Time:       now,
+	})
+	if executeErr != nil {
+		return 0, executeErr
+	}
+
+	if rollout.RemainingNamespaces == 0 {
+		return 0, nil
+	}
+	if interval > 0 {
+		return interval, nil
+	}
+	return defaultWaveRequeue, nil
+}
+
+// namespaceExists checks whether the given namespace exists
+func (r *FolderTreeReconciler) namespaceExists(ctx context.Context, namespace string) (bool, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// rolloutBatchSize returns the number of namespaces allowed in a single wave, or 0 if unlimited.
+// When both limits are configured the smaller one wins; a configured limit is always at least 1.
+func rolloutBatchSize(strategy *rbacv1alpha1.RolloutStrategy, totalNamespaces int) int {
+	batchSize := 0
+
+	if strategy.MaxNamespacesPerWave != nil {
+		batchSize = max(int(*strategy.MaxNamespacesPerWave), 1)
+	}
+
+	if strategy.MaxPercentPerWave != nil {
+		// Round up so that small trees still make progress
+		percentBatch := max((totalNamespaces*int(*strategy.MaxPercentPerWave)+99)/100, 1)
+		if batchSize == 0 || percentBatch < batchSize {
+			batchSize = percentBatch
+		}
+	}
+
+	return batchSize
+}
+
+// countSpecNamespaces returns the number of distinct namespaces assigned in the FolderTree spec
+func countSpecNamespaces(folderTree *rbacv1alpha1.FolderTree) int {
+	namespaces := make(map[string]bool)
+	for _, folder := range folderTree.Spec.Folders {
+		for _, namespace := range folder.Namespaces {
+			namespaces[namespace] = true
+		}
+	}
+	return len(namespaces)
+}
+
+// rolloutMessage returns a human-readable summary of the rollout progress
+func rolloutMessage(rollout *rbacv1alpha1.RolloutStatus) string {
+	if rollout == nil {
+		return "Rollout in progress"
+	}
+	return fmt.Sprintf("Rollout in progress: wave %d applied, %d of %d namespaces remaining",
+		rollout.CurrentWave, rollout.RemainingNamespaces, rollout.TotalNamespaces)
+}
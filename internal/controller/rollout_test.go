@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+var _ = Describe("FolderTree Controller - Rollout Strategy", func() {
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	Context("When a rollout strategy is configured", func() {
+		It("should apply RoleBindings in waves and track progress in status", func() {
+			resourceName := "test-rollout-waves"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+			namespaces := []string{"rollout-ns-a", "rollout-ns-b", "rollout-ns-c"}
+
+			for _, name := range namespaces {
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+				})).To(Succeed())
+			}
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "rollout-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "viewers",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "viewers",
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     "view",
+									},
+								},
+							},
+							Namespaces: namespaces,
+						},
+					},
+					RolloutStrategy: &rbacv1alpha1.RolloutStrategy{
+						MaxNamespacesPerWave: int32Ptr(2),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			By("applying the first wave")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			rb := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rollout-waves-viewers", Namespace: "rollout-ns-a"}, rb)).To(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rollout-waves-viewers", Namespace: "rollout-ns-b"}, rb)).To(Succeed())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rollout-waves-viewers", Namespace: "rollout-ns-c"}, rb)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			updated := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.Rollout).NotTo(BeNil())
+			Expect(updated.Status.Rollout.CurrentWave).To(Equal(int32(1)))
+			Expect(updated.Status.Rollout.TotalNamespaces).To(Equal(int32(3)))
+			Expect(updated.Status.Rollout.RemainingNamespaces).To(Equal(int32(1)))
+			Expect(updated.Status.Rollout.Waves).To(HaveLen(1))
+			Expect(updated.Status.Rollout.Waves[0].Namespaces).To(Equal([]string{"rollout-ns-a", "rollout-ns-b"}))
+			Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeRolloutInProgress)).To(BeTrue())
+			Expect(updated.Status.Namespaces).To(Equal([]rbacv1alpha1.NamespaceStatus{
+				{Name: "rollout-ns-a", Phase: rbacv1alpha1.NamespacePhaseSynced},
+				{Name: "rollout-ns-b", Phase: rbacv1alpha1.NamespacePhaseSynced},
+				{Name: "rollout-ns-c", Phase: rbacv1alpha1.NamespacePhaseSkipped, Message: "Waiting for a rollout wave"},
+			}))
+
+			By("applying the final wave")
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rollout-waves-viewers", Namespace: "rollout-ns-c"}, rb)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.Rollout.CurrentWave).To(Equal(int32(2)))
+			Expect(updated.Status.Rollout.RemainingNamespaces).To(BeZero())
+			Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeRolloutInProgress)).To(BeFalse())
+		})
+
+		It("should wait for the minimum interval between waves", func() {
+			resourceName := "test-rollout-interval"
+			typeNamespacedName := types.NamespacedName{Name: resourceName}
+			namespaces := []string{"rollout-interval-a", "rollout-interval-b"}
+
+			for _, name := range namespaces {
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+				})).To(Succeed())
+			}
+
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "rollout-interval-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "editors",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "editors",
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     "edit",
+									},
+								},
+							},
+							Namespaces: namespaces,
+						},
+					},
+					RolloutStrategy: &rbacv1alpha1.RolloutStrategy{
+						MaxPercentPerWave: int32Ptr(50),
+						MinWaveInterval:   &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+			By("reconciling again before the interval has elapsed")
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			rb := &rbacv1.RoleBinding{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: "foldertree-test-rollout-interval-editors", Namespace: "rollout-interval-b"}, rb)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			updated := &rbacv1alpha1.FolderTree{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			Expect(updated.Status.Rollout.CurrentWave).To(Equal(int32(1)))
+		})
+	})
+})
+
+// hasCondition reports whether the FolderTree has a condition of the given type
+func hasCondition(folderTree *rbacv1alpha1.FolderTree, conditionType string) bool {
+	for _, condition := range folderTree.Status.Conditions {
+		if condition.Type == conditionType {
+			return true
+		}
+	}
+	return false
+}
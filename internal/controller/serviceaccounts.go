@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// serviceAccountMetadata returns the object used to watch the metadata of ServiceAccounts;
+// serviceAccountSelectors only need their labels
+func serviceAccountMetadata() *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"}}
+}
+
+// mapServiceAccountToFolderTrees reconciles the FolderTrees whose serviceAccountSelectors select
+// ServiceAccounts from the namespace of a ServiceAccount when it is created, relabeled or deleted,
+// so that RoleBindings follow the selected ServiceAccounts
+func (r *FolderTreeReconciler) mapServiceAccountToFolderTrees(_ context.Context, obj client.Object) []reconcile.Request {
+	return treeRequests(r.serviceAccountNamespaces.lookup(obj.GetNamespace()))
+}
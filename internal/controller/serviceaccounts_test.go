@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - ServiceAccount Selectors", func() {
+	const (
+		treeName      = "test-service-account-selectors"
+		namespaceName = "service-account-selectors-ns"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	boundSubjects := func() []rbacv1.Subject {
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: namespaceName,
+			Name:      "foldertree-" + treeName + "-ci",
+		}, roleBinding)).To(Succeed())
+		return roleBinding.Subjects
+	}
+
+	createServiceAccount := func(name string, labels map[string]string) *corev1.ServiceAccount {
+		serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: name, Labels: labels}}
+		Expect(k8sClient.Create(ctx, serviceAccount)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, serviceAccount))).To(Succeed())
+		})
+		return serviceAccount
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: treeName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "ci",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:                   "ci",
+								ServiceAccountSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ci": "true"}},
+								RoleRef:                rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+							},
+						},
+						Namespaces: []string{namespaceName},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	})
+
+	It("should bind the selected ServiceAccounts and follow their creation, relabeling and deletion", func() {
+		builder := createServiceAccount("builder", map[string]string{"ci": "true"})
+		createServiceAccount("unlabeled", nil)
+
+		reconcileAndGet(ctx, reconciler, treeName)
+		builderSubject := rbacv1.Subject{Kind: "ServiceAccount", Name: "builder", Namespace: namespaceName}
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{builderSubject}))
+
+		By("binding a ServiceAccount created later")
+		createServiceAccount("deployer", map[string]string{"ci": "true"})
+		reconcileAndGet(ctx, reconciler, treeName)
+		deployerSubject := rbacv1.Subject{Kind: "ServiceAccount", Name: "deployer", Namespace: namespaceName}
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{builderSubject, deployerSubject}))
+
+		By("unbinding a relabeled ServiceAccount")
+		builder.Labels = nil
+		Expect(k8sClient.Update(ctx, builder)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, treeName)
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{deployerSubject}))
+	})
+
+	It("should map ServiceAccount events to the FolderTrees selecting from their namespace", func() {
+		reconcileAndGet(ctx, reconciler, treeName)
+
+		serviceAccount := serviceAccountMetadata()
+		serviceAccount.Namespace = namespaceName
+		Expect(reconciler.mapServiceAccountToFolderTrees(ctx, serviceAccount)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: treeName}}))
+
+		serviceAccount.Namespace = "default"
+		Expect(reconciler.mapServiceAccountToFolderTrees(ctx, serviceAccount)).To(BeEmpty())
+	})
+})
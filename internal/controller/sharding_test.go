@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Sharding", func() {
+	const namespace = "sharding-ns"
+	var (
+		ctx         context.Context
+		shardA      *FolderTreeReconciler
+		shardB      *FolderTreeReconciler
+		folderTrees []*rbacv1alpha1.FolderTree
+	)
+
+	newFolderTree := func(name, shard string) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"shard": shard}},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: name + "-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: name + "-viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+			},
+		}
+	}
+
+	roleBindingsOf := func(treeName string) []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		var owned []rbacv1.RoleBinding
+		for _, roleBinding := range roleBindings.Items {
+			for _, ref := range roleBinding.OwnerReferences {
+				if ref.Name == treeName {
+					owned = append(owned, roleBinding)
+				}
+			}
+		}
+		return owned
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		shardA = &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), TreeSelector: labels.SelectorFromSet(labels.Set{"shard": "a"})}
+		shardB = &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), TreeSelector: labels.SelectorFromSet(labels.Set{"shard": "b"})}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTrees = []*rbacv1alpha1.FolderTree{newFolderTree("sharded-a", "a"), newFolderTree("sharded-b", "b")}
+		for _, folderTree := range folderTrees {
+			Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			})
+		}
+	})
+
+	It("should only reconcile FolderTrees matching the tree selector", func() {
+		for _, name := range []string{"sharded-a", "sharded-b"} {
+			_, err := shardA.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(roleBindingsOf("sharded-a")).To(HaveLen(1))
+		Expect(roleBindingsOf("sharded-b")).To(BeEmpty())
+
+		untouched := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "sharded-b"}, untouched)).To(Succeed())
+		Expect(untouched.Status.Conditions).To(BeEmpty())
+
+		_, err := shardB.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "sharded-b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindingsOf("sharded-b")).To(HaveLen(1))
+	})
+
+	It("should only map namespace events to FolderTrees of the shard", func() {
+		unsharded := &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		for _, reconciler := range []*FolderTreeReconciler{shardA, unsharded} {
+			for _, name := range []string{"sharded-a", "sharded-b"} {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(shardA.mapNamespaceToFolderTrees(ctx, namespaceObj)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "sharded-a"}},
+		}))
+		Expect(unsharded.mapNamespaceToFolderTrees(ctx, namespaceObj)).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "sharded-a"}},
+			{NamespacedName: types.NamespacedName{Name: "sharded-b"}},
+		}))
+	})
+
+	It("should select FolderTrees by their labels", func() {
+		Expect(shardA.selects(folderTrees[0])).To(BeTrue())
+		Expect(shardA.selects(folderTrees[1])).To(BeFalse())
+		Expect((&FolderTreeReconciler{}).selects(folderTrees[1])).To(BeTrue())
+	})
+})
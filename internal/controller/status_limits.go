@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// DefaultMaxConditions caps status.conditions
+	DefaultMaxConditions = 16
+
+	// DefaultMaxRolloutWaves caps status.rollout.waves
+	DefaultMaxRolloutWaves = 10
+
+	// DefaultMaxWaveNamespaces caps the namespaces listed per rollout wave
+	DefaultMaxWaveNamespaces = 100
+
+	// DefaultMaxInheritanceEntries caps status.inheritance
+	DefaultMaxInheritanceEntries = 500
+
+	// DefaultMaxInheritanceTemplates caps the templates listed per status.inheritance entry
+	DefaultMaxInheritanceTemplates = 50
+
+	// DefaultMaxAppliedBindings caps status.appliedBindings
+	DefaultMaxAppliedBindings = 10000
+
+	// DefaultMaxEffectiveBindings caps the total number of entries in status.effectiveBindings
+	DefaultMaxEffectiveBindings = 10000
+
+	// DefaultMaxNamespaceStatuses caps status.namespaces
+	DefaultMaxNamespaceStatuses = 1000
+
+	// DefaultMaxStatusBytes caps the JSON size of the status, leaving most of the 1.5MiB etcd
+	// object size limit to the spec
+	DefaultMaxStatusBytes = 512 * 1024
+)
+
+// StatusLimits caps the lists kept in FolderTree status so that FolderTree objects stay well
+// under the etcd object size limit even for huge trees. Zero values use the defaults.
+type StatusLimits struct {
+	MaxConditions           int
+	MaxRolloutWaves         int
+	MaxWaveNamespaces       int
+	MaxInheritanceEntries   int
+	MaxInheritanceTemplates int
+	MaxAppliedBindings      int
+	MaxEffectiveBindings    int
+	MaxNamespaceStatuses    int
+	MaxStatusBytes          int
+}
+
+// withDefaults returns the limits with zero values replaced by the defaults
+func (l StatusLimits) withDefaults() StatusLimits {
+	defaultInt := func(value *int, def int) {
+		if *value <= 0 {
+			*value = def
+		}
+	}
+	defaultInt(&l.MaxConditions, DefaultMaxConditions)
+	defaultInt(&l.MaxRolloutWaves, DefaultMaxRolloutWaves)
+	defaultInt(&l.MaxWaveNamespaces, DefaultMaxWaveNamespaces)
+	defaultInt(&l.MaxInheritanceEntries, DefaultMaxInheritanceEntries)
+	defaultInt(&l.MaxInheritanceTemplates, DefaultMaxInheritanceTemplates)
+	defaultInt(&l.MaxAppliedBindings, DefaultMaxAppliedBindings)
+	defaultInt(&l.MaxEffectiveBindings, DefaultMaxEffectiveBindings)
+	defaultInt(&l.MaxNamespaceStatuses, DefaultMaxNamespaceStatuses)
+	defaultInt(&l.MaxStatusBytes, DefaultMaxStatusBytes)
+	return l
+}
+
+// enforceStatusLimits caps the status lists of the FolderTree, then drops whole lists until the
+// status fits in MaxStatusBytes, and returns the resulting size of the status in bytes.
+// Time-ordered lists drop their oldest entries first; other lists keep their first entries.
+// status.appliedBindings is dropped entirely when over its cap, since a partial map would
+// misreport what is applied; the webhook then falls back to the old spec.
+// status.effectiveBindings is dropped entirely as well, as a partial roll-up would understate access.
+// status.namespaces drops Synced namespaces first, so failures stay visible.
+// The StatusTruncated condition names the lists that lost entries, and status.truncated is set
+// when anything, including conditions, was dropped.
+func enforceStatusLimits(status *rbacv1alpha1.FolderTreeStatus, limits StatusLimits) int {
+	limits = limits.withDefaults()
+	var truncated []string
+	truncate := func(field string) {
+		if !slices.Contains(truncated, field) {
+			truncated = append(truncated, field)
+		}
+	}
+
+	if rollout := status.Rollout; rollout != nil {
+		if len(rollout.Waves) > limits.MaxRolloutWaves {
+			rollout.Waves = rollout.Waves[len(rollout.Waves)-limits.MaxRolloutWaves:]
+			truncate("status.rollout.waves")
+		}
+		for i := range rollout.Waves {
+			if len(rollout.Waves[i].Namespaces) > limits.MaxWaveNamespaces {
+				rollout.Waves[i].Namespaces = rollout.Waves[i].Namespaces[:limits.MaxWaveNamespaces]
+				truncate("status.rollout.waves")
+			}
+		}
+	}
+
+	if len(status.Inheritance) > limits.MaxInheritanceEntries {
+		status.Inheritance = status.Inheritance[:limits.MaxInheritanceEntries]
+		truncate("status.inheritance")
+	}
+	for i := range status.Inheritance {
+		entry := &status.Inheritance[i]
+		if len(entry.Received) > limits.MaxInheritanceTemplates {
+			entry.Received = entry.Received[:limits.MaxInheritanceTemplates]
+			truncate("status.inheritance")
+		}
+		if len(entry.Contributed) > limits.MaxInheritanceTemplates {
+			entry.Contributed = entry.Contributed[:limits.MaxInheritanceTemplates]
+			truncate("status.inheritance")
+		}
+		if len(entry.Blocked) > limits.MaxInheritanceTemplates {
+			entry.Blocked = entry.Blocked[:limits.MaxInheritanceTemplates]
+			truncate("status.inheritance")
+		}
+	}
+
+	if len(status.AppliedBindings) > limits.MaxAppliedBindings {
+		status.AppliedBindings = nil
+		truncate("status.appliedBindings")
+	}
+
+	effectiveBindings := 0
+	for _, bindings := range status.EffectiveBindings {
+		effectiveBindings += len(bindings)
+	}
+	if effectiveBindings > limits.MaxEffectiveBindings {
+		status.EffectiveBindings = nil
+		truncate("status.effectiveBindings")
+	}
+
+	if len(status.Namespaces) > limits.MaxNamespaceStatuses {
+		// Keep failed and skipped namespaces over synced ones, then restore the name order
+		synced := func(namespace rbacv1alpha1.NamespaceStatus) int {
+			if namespace.Phase == rbacv1alpha1.NamespacePhaseSynced {
+				return 1
+			}
+			return 0
+		}
+		slices.SortStableFunc(status.Namespaces, func(a, b rbacv1alpha1.NamespaceStatus) int { return synced(a) - synced(b) })
+		status.Namespaces = status.Namespaces[:limits.MaxNamespaceStatuses]
+		slices.SortFunc(status.Namespaces, func(a, b rbacv1alpha1.NamespaceStatus) int { return strings.Compare(a.Name, b.Name) })
+		truncate("status.namespaces")
+	}
+
+	// Drop the lists that are least needed first until the status fits its byte budget. The
+	// remaining fields are bounded by the spec.
+	for _, drop := range []struct {
+		field string
+		empty func() bool
+		clear func()
+	}{
+		{"status.effectiveBindings", func() bool { return status.EffectiveBindings == nil }, func() { status.EffectiveBindings = nil }},
+		{"status.namespaces", func() bool { return status.Namespaces == nil }, func() { status.Namespaces = nil }},
+		{"status.inheritance", func() bool { return status.Inheritance == nil }, func() { status.Inheritance = nil }},
+		{"status.appliedBindings", func() bool { return status.AppliedBindings == nil }, func() { status.AppliedBindings = nil }},
+	} {
+		if drop.empty() || statusSize(status) <= limits.MaxStatusBytes {
+			continue
+		}
+		drop.clear()
+		truncate(drop.field)
+	}
+
+	if len(truncated) > 0 {
+		setStatusTruncatedCondition(status, truncated)
+	} else {
+		meta.RemoveStatusCondition(&status.Conditions, rbacv1alpha1.ConditionTypeStatusTruncated)
+	}
+	status.Truncated = len(truncated) > 0
+
+	if len(status.Conditions) > limits.MaxConditions {
+		sort.SliceStable(status.Conditions, func(i, j int) bool {
+			return status.Conditions[i].LastTransitionTime.Before(&status.Conditions[j].LastTransitionTime)
+		})
+		status.Conditions = status.Conditions[len(status.Conditions)-limits.MaxConditions:]
+		status.Truncated = true
+	}
+
+	return statusSize(status)
+}
+
+// setStatusTruncatedCondition sets the StatusTruncated condition naming the truncated status fields,
+// keeping its transition time while it stays set
+func setStatusTruncatedCondition(status *rbacv1alpha1.FolderTreeStatus, fields []string) {
+	message := fmt.Sprintf("Entries of %s were dropped to stay within the status size limits", strings.Join(fields, ", "))
+	if condition := meta.FindStatusCondition(status.Conditions, rbacv1alpha1.ConditionTypeStatusTruncated); condition != nil {
+		condition.Message = message
+		return
+	}
+	status.Conditions = append(status.Conditions, metav1.Condition{
+		Type:               rbacv1alpha1.ConditionTypeStatusTruncated,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             rbacv1alpha1.ConditionTypeStatusTruncated,
+		Message:            message,
+	})
+}
+
+// statusSize returns the size of the status serialized as JSON, as it is stored
+func statusSize(status *rbacv1alpha1.FolderTreeStatus) int {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Status Limits", func() {
+	It("should leave status within the limits untouched", func() {
+		status := &rbacv1alpha1.FolderTreeStatus{
+			Conditions:      []metav1.Condition{{Type: rbacv1alpha1.ConditionTypeReady}},
+			Inheritance:     []rbacv1alpha1.FolderInheritanceStatus{{Path: "root", Received: []string{"a (global)"}}},
+			AppliedBindings: map[string]string{"ns/rb": "ClusterRole/view/0123456789abcdef"},
+			Truncated:       true,
+		}
+
+		enforceStatusLimits(status, StatusLimits{})
+
+		Expect(status.Conditions).To(HaveLen(1))
+		Expect(status.Inheritance[0].Received).To(HaveLen(1))
+		Expect(status.AppliedBindings).To(HaveLen(1))
+		Expect(status.Truncated).To(BeFalse())
+	})
+
+	It("should drop the oldest conditions and rollout waves first", func() {
+		now := time.Now()
+		status := &rbacv1alpha1.FolderTreeStatus{
+			Rollout: &rbacv1alpha1.RolloutStatus{},
+		}
+		for i := range 4 {
+			status.Conditions = append(status.Conditions, metav1.Condition{
+				Type:               fmt.Sprintf("Condition%d", i),
+				LastTransitionTime: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+			})
+			status.Rollout.Waves = append(status.Rollout.Waves, rbacv1alpha1.RolloutWave{
+				Number:     int32(i + 1),
+				Namespaces: []string{"a", "b", "c"},
+			})
+		}
+
+		enforceStatusLimits(status, StatusLimits{MaxConditions: 2, MaxRolloutWaves: 2, MaxWaveNamespaces: 1})
+
+		Expect(status.Conditions).To(HaveLen(2))
+		Expect(status.Conditions[0].Type).To(Equal("Condition2"))
+		Expect(status.Conditions[1].Type).To(Equal("Condition3"))
+		Expect(status.Rollout.Waves).To(HaveLen(2))
+		Expect(status.Rollout.Waves[0].Number).To(Equal(int32(3)))
+		Expect(status.Rollout.Waves[1].Namespaces).To(Equal([]string{"a"}))
+		Expect(status.Truncated).To(BeTrue())
+	})
+
+	It("should cap inheritance entries and drop oversized applied bindings", func() {
+		status := &rbacv1alpha1.FolderTreeStatus{
+			Inheritance: []rbacv1alpha1.FolderInheritanceStatus{
+				{Path: "root", Contributed: []string{"a", "b", "c"}},
+				{Path: "root/child", Received: []string{"a (from root)", "b (from root)", "c (from root)"},
+					Blocked: []string{"d (from root)", "e (from root)", "f (from root)"}},
+				{Path: "root/other"},
+			},
+			AppliedBindings: map[string]string{
+				"ns-a/rb": "ClusterRole/view/0123456789abcdef",
+				"ns-b/rb": "ClusterRole/view/0123456789abcdef",
+			},
+		}
+
+		enforceStatusLimits(status, StatusLimits{MaxInheritanceEntries: 2, MaxInheritanceTemplates: 2, MaxAppliedBindings: 1})
+
+		Expect(status.Inheritance).To(HaveLen(2))
+		Expect(status.Inheritance[0].Contributed).To(Equal([]string{"a", "b"}))
+		Expect(status.Inheritance[1].Received).To(Equal([]string{"a (from root)", "b (from root)"}))
+		Expect(status.Inheritance[1].Blocked).To(Equal([]string{"d (from root)", "e (from root)"}))
+		Expect(status.AppliedBindings).To(BeNil())
+		Expect(status.Truncated).To(BeTrue())
+	})
+
+	It("should drop effective bindings over their total cap", func() {
+		status := &rbacv1alpha1.FolderTreeStatus{
+			EffectiveBindings: map[string][]rbacv1alpha1.EffectiveBinding{
+				"ns-a": {{Template: "viewers", RoleRef: "ClusterRole/view"}},
+				"ns-b": {{Template: "viewers", RoleRef: "ClusterRole/view"}, {Template: "admins", RoleRef: "ClusterRole/admin"}},
+			},
+		}
+
+		enforceStatusLimits(status, StatusLimits{MaxEffectiveBindings: 3})
+		Expect(status.EffectiveBindings).To(HaveLen(2))
+		Expect(status.Truncated).To(BeFalse())
+
+		enforceStatusLimits(status, StatusLimits{MaxEffectiveBindings: 2})
+		Expect(status.EffectiveBindings).To(BeNil())
+		Expect(status.Truncated).To(BeTrue())
+	})
+
+	It("should keep failed and skipped namespaces over synced ones", func() {
+		status := &rbacv1alpha1.FolderTreeStatus{
+			Namespaces: []rbacv1alpha1.NamespaceStatus{
+				{Name: "ns-a", Phase: rbacv1alpha1.NamespacePhaseSynced},
+				{Name: "ns-b", Phase: rbacv1alpha1.NamespacePhaseSynced},
+				{Name: "ns-c", Phase: rbacv1alpha1.NamespacePhaseFailed, Message: "forbidden"},
+				{Name: "ns-d", Phase: rbacv1alpha1.NamespacePhaseSkipped, Message: "Namespace does not exist"},
+			},
+		}
+
+		enforceStatusLimits(status, StatusLimits{MaxNamespaceStatuses: 3})
+		Expect(status.Namespaces).To(HaveLen(3))
+		Expect(status.Namespaces[0].Name).To(Equal("ns-a"))
+		Expect(status.Namespaces[1].Name).To(Equal("ns-c"))
+		Expect(status.Namespaces[2].Name).To(Equal("ns-d"))
+		Expect(status.Truncated).To(BeTrue())
+	})
+
+	It("should drop whole lists until the status fits its byte budget and report them", func() {
+		status := &rbacv1alpha1.FolderTreeStatus{
+			EffectiveBindings: map[string][]rbacv1alpha1.EffectiveBinding{
+				"ns-a": {{Template: "viewers", RoleRef: "ClusterRole/view"}},
+			},
+			Inheritance: []rbacv1alpha1.FolderInheritanceStatus{{Path: "root", Contributed: []string{"viewers"}}},
+			AppliedBindings: map[string]string{
+				"ns-a/rb": "ClusterRole/view/0123456789abcdef",
+			},
+		}
+		withoutOptionalLists := &rbacv1alpha1.FolderTreeStatus{AppliedBindings: status.AppliedBindings}
+
+		size := enforceStatusLimits(status, StatusLimits{MaxStatusBytes: statusSize(withoutOptionalLists)})
+		Expect(status.EffectiveBindings).To(BeNil())
+		Expect(status.Inheritance).To(BeNil())
+		Expect(status.AppliedBindings).To(HaveLen(1))
+		Expect(status.Truncated).To(BeTrue())
+		Expect(size).To(Equal(statusSize(status)))
+
+		condition := meta.FindStatusCondition(status.Conditions, rbacv1alpha1.ConditionTypeStatusTruncated)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Message).To(Equal(
+			"Entries of status.effectiveBindings, status.inheritance were dropped to stay within the status size limits"))
+
+		By("removing the condition once the status fits again")
+		enforceStatusLimits(status, StatusLimits{})
+		Expect(meta.FindStatusCondition(status.Conditions, rbacv1alpha1.ConditionTypeStatusTruncated)).To(BeNil())
+		Expect(status.Truncated).To(BeFalse())
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Status Tampering", func() {
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	It("should overwrite tampered status fields on the next reconcile", func() {
+		resourceName := "test-status-tamper"
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "status-tamper-ns"},
+		})).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName,
+			},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "status-tamper-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "viewers",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "viewers",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+						Namespaces: []string{"status-tamper-ns"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("tampering with the status subresource")
+		tampered := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, tampered)).To(Succeed())
+		tampered.Status.Conditions = append(tampered.Status.Conditions, metav1.Condition{
+			Type:               "Hacked",
+			Status:             metav1.ConditionTrue,
+			Reason:             "Tampered",
+			Message:            "injected by a status writer",
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		})
+		tampered.Status.AppliedBindings = map[string]string{
+			"kube-system/foldertree-test-status-tamper-admins": "ClusterRole/cluster-admin/0123456789abcdef",
+		}
+		tampered.Status.ProcessedGeneration = 999
+		Expect(k8sClient.Status().Update(ctx, tampered)).To(Succeed())
+
+		By("reconciling again")
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(hasCondition(updated, "Hacked")).To(BeFalse())
+		Expect(hasCondition(updated, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(updated.Status.ProcessedGeneration).To(Equal(updated.Generation))
+		Expect(updated.Status.AppliedBindings).To(HaveLen(1))
+		Expect(updated.Status.AppliedBindings).To(HaveKey("status-tamper-ns/foldertree-test-status-tamper-viewers"))
+	})
+
+	It("should record effective bindings only when enabled", func() {
+		resourceName := "test-effective-bindings"
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "effective-bindings-ns"},
+		})).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "effective-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{"effective-bindings-ns"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		reconciler.RecordEffectiveBindings = true
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.EffectiveBindings).To(Equal(map[string][]rbacv1alpha1.EffectiveBinding{
+			"effective-bindings-ns": {{Template: "viewers", From: "effective-folder", RoleRef: "ClusterRole/view"}},
+		}))
+
+		By("disabling the roll-up")
+		reconciler.RecordEffectiveBindings = false
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+		Expect(updated.Status.EffectiveBindings).To(BeNil())
+	})
+})
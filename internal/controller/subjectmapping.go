@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// setSubjectMappingMissingCondition sets the SubjectMappingMissing condition listing the
+// SubjectMappings referenced by templates that do not exist, and removes it when there are none
+func (r *FolderTreeReconciler) setSubjectMappingMissingCondition(folderTree *rbacv1alpha1.FolderTree, missing []string) {
+	if len(missing) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeSubjectMappingMissing)
+		return
+	}
+
+	listed := missing
+	if len(listed) > maxMissingListed {
+		listed = append(slices.Clone(missing[:maxMissingListed]), fmt.Sprintf("and %d more", len(missing)-maxMissingListed))
+	}
+	message := fmt.Sprintf("%d SubjectMapping(s) referenced by templates do not exist and bind no subjects: %s",
+		len(missing), strings.Join(listed, ", "))
+	setConditionMessage(folderTree, rbacv1alpha1.ConditionTypeSubjectMappingMissing, message)
+}
+
+// mapSubjectMappingToFolderTrees reconciles the FolderTrees whose templates reference a
+// SubjectMapping when it is created, updated or deleted, so that RoleBindings follow its subjects
+func (r *FolderTreeReconciler) mapSubjectMappingToFolderTrees(ctx context.Context, obj client.Object) []reconcile.Request {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := r.List(ctx, &folderTreeList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list FolderTrees for SubjectMapping", "subjectMapping", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range folderTreeList.Items {
+		folderTree := &folderTreeList.Items[i]
+		if r.selects(folderTree) && slices.Contains(rbac.SubjectRefs(folderTree), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: folderTree.Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Subject Mappings", func() {
+	const (
+		treeName      = "test-subject-mappings"
+		mappingName   = "team-frontend"
+		namespaceName = "subject-mappings-ns"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	frontendDevs := rbacv1.Subject{Kind: "Group", Name: "idp:frontend-devs", APIGroup: "rbac.authorization.k8s.io"}
+	viewers := rbacv1.Subject{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}
+
+	boundSubjects := func() []rbacv1.Subject {
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{
+			Namespace: namespaceName,
+			Name:      "foldertree-" + treeName + "-frontend",
+		}, roleBinding)).To(Succeed())
+		return roleBinding.Subjects
+	}
+
+	createSubjectMapping := func(subjects ...rbacv1.Subject) *rbacv1alpha1.SubjectMapping {
+		mapping := &rbacv1alpha1.SubjectMapping{
+			ObjectMeta: metav1.ObjectMeta{Name: mappingName},
+			Spec:       rbacv1alpha1.SubjectMappingSpec{Subjects: subjects},
+		}
+		Expect(k8sClient.Create(ctx, mapping)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, mapping))).To(Succeed())
+		})
+		return mapping
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+
+		namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceName}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: treeName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "frontend",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:        "frontend",
+								Subjects:    []rbacv1.Subject{viewers},
+								SubjectRefs: []string{mappingName},
+								RoleRef:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+							},
+						},
+						Namespaces: []string{namespaceName},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	})
+
+	It("should bind the subjects of referenced SubjectMappings and follow their changes", func() {
+		mapping := createSubjectMapping(frontendDevs, viewers)
+
+		folderTree := reconcileAndGet(ctx, reconciler, treeName)
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeSubjectMappingMissing)).To(BeFalse())
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{viewers, frontendDevs}))
+
+		By("updating the RoleBinding when the mapping changes")
+		frontendOncall := rbacv1.Subject{Kind: "User", Name: "oncall@example.com", APIGroup: "rbac.authorization.k8s.io"}
+		mapping.Spec.Subjects = []rbacv1.Subject{frontendOncall}
+		Expect(k8sClient.Update(ctx, mapping)).To(Succeed())
+		reconcileAndGet(ctx, reconciler, treeName)
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{viewers, frontendOncall}))
+	})
+
+	It("should report missing SubjectMappings and bind the remaining subjects", func() {
+		folderTree := reconcileAndGet(ctx, reconciler, treeName)
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		missing := meta.FindStatusCondition(folderTree.Status.Conditions, rbacv1alpha1.ConditionTypeSubjectMappingMissing)
+		Expect(missing).NotTo(BeNil())
+		Expect(missing.Message).To(Equal("1 SubjectMapping(s) referenced by templates do not exist and bind no subjects: " + mappingName))
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{viewers}))
+
+		By("clearing the condition once the mapping is created")
+		createSubjectMapping(frontendDevs)
+		folderTree = reconcileAndGet(ctx, reconciler, treeName)
+		Expect(hasCondition(folderTree, rbacv1alpha1.ConditionTypeSubjectMappingMissing)).To(BeFalse())
+		Expect(boundSubjects()).To(Equal([]rbacv1.Subject{viewers, frontendDevs}))
+	})
+
+	It("should map SubjectMapping changes to the FolderTrees referencing them", func() {
+		mapping := &rbacv1alpha1.SubjectMapping{ObjectMeta: metav1.ObjectMeta{Name: mappingName}}
+		Expect(reconciler.mapSubjectMappingToFolderTrees(ctx, mapping)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: treeName}}))
+
+		unreferenced := &rbacv1alpha1.SubjectMapping{ObjectMeta: metav1.ObjectMeta{Name: "team-backend"}}
+		Expect(reconciler.mapSubjectMappingToFolderTrees(ctx, unreferenced)).To(BeEmpty())
+	})
+})
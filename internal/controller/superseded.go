@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// findSupersededNamespaces returns the namespaces of a FolderTree that FolderTrees of higher
+// priority also list, mapped to the FolderTree managing them. Without AllowNamespaceOverlap the
+// webhook rejects shared namespaces, and nothing is superseded.
+func (r *FolderTreeReconciler) findSupersededNamespaces(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) (map[string]string, error) {
+	if !r.AllowNamespaceOverlap {
+		return nil, nil
+	}
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := r.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	return rbac.SupersededNamespaces(folderTree, folderTreeList.Items), nil
+}
+
+// withoutSupersededNamespaces returns the desired state of a FolderTree with the superseded
+// namespaces excluded, so that RoleBindings and other objects it created there are removed
+func withoutSupersededNamespaces(desiredTree *rbacv1alpha1.FolderTree, superseded map[string]string) *rbacv1alpha1.FolderTree {
+	if len(superseded) == 0 {
+		return desiredTree
+	}
+	desiredTree = desiredTree.DeepCopy()
+	for _, namespace := range slices.Sorted(maps.Keys(superseded)) {
+		desiredTree.Spec.ExcludedNamespaces = append(desiredTree.Spec.ExcludedNamespaces, namespace)
+	}
+	return desiredTree
+}
+
+// setSupersededCondition sets the Superseded condition listing the superseded namespaces and the
+// FolderTrees managing them, and removes it when there are none
+func (r *FolderTreeReconciler) setSupersededCondition(folderTree *rbacv1alpha1.FolderTree, superseded map[string]string) {
+	if len(superseded) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeSuperseded)
+		return
+	}
+
+	var listed []string
+	for _, namespace := range slices.Sorted(maps.Keys(superseded)) {
+		if len(listed) == maxMissingListed {
+			listed = append(listed, fmt.Sprintf("and %d more", len(superseded)-maxMissingListed))
+			break
+		}
+		listed = append(listed, fmt.Sprintf("%s (FolderTree '%s')", namespace, superseded[namespace]))
+	}
+	message := fmt.Sprintf("%d namespace(s) are managed by FolderTrees of higher priority: %s", len(superseded), strings.Join(listed, ", "))
+	setConditionMessage(folderTree, rbacv1alpha1.ConditionTypeSuperseded, message)
+}
+
+// mapFolderTreeToOverlappingTrees reconciles the other FolderTrees sharing namespaces with a
+// FolderTree when it changes, since its priority and namespaces decide which of them manages the
+// shared namespaces. Updates map both the old and the new object, so released namespaces are covered.
+func (r *FolderTreeReconciler) mapFolderTreeToOverlappingTrees(_ context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, namespace := range rbac.IndexFolderTreeNamespaces(obj) {
+		for _, tree := range r.namespaces.lookup(namespace) {
+			request := reconcile.Request{NamespacedName: client.ObjectKey{Name: tree}}
+			if tree != obj.GetName() && !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+	return requests
+}
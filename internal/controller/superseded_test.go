@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Namespace Overlap", func() {
+	const (
+		lowTree         = "test-overlap-low"
+		highTree        = "test-overlap-high"
+		sharedNamespace = "overlap-shared"
+		ownNamespace    = "overlap-own"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	createFolderTree := func(name string, priority int32, namespaces ...string) {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Priority: priority,
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: name + "-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: namespaces,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+	}
+
+	treesWithRoleBindingsIn := func(namespace string) []string {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		var trees []string
+		for _, roleBinding := range roleBindings.Items {
+			trees = append(trees, roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"])
+		}
+		return trees
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client:                k8sClient,
+			Scheme:                k8sClient.Scheme(),
+			AllowNamespaceOverlap: true,
+		}
+		for _, name := range []string{sharedNamespace, ownNamespace} {
+			namespaceObj := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespaceObj))).To(Succeed())
+		}
+	})
+
+	It("should leave shared namespaces to the FolderTree of highest priority", func() {
+		createFolderTree(lowTree, 0, sharedNamespace, ownNamespace)
+		createFolderTree(highTree, 10, sharedNamespace)
+
+		low := reconcileAndGet(ctx, reconciler, lowTree)
+		high := reconcileAndGet(ctx, reconciler, highTree)
+		Expect(hasCondition(low, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(hasCondition(high, rbacv1alpha1.ConditionTypeSuperseded)).To(BeFalse())
+		superseded := meta.FindStatusCondition(low.Status.Conditions, rbacv1alpha1.ConditionTypeSuperseded)
+		Expect(superseded).NotTo(BeNil())
+		Expect(superseded.Message).To(Equal("1 namespace(s) are managed by FolderTrees of higher priority: " +
+			sharedNamespace + " (FolderTree '" + highTree + "')"))
+
+		Expect(treesWithRoleBindingsIn(sharedNamespace)).To(ConsistOf(highTree))
+		Expect(treesWithRoleBindingsIn(ownNamespace)).To(ConsistOf(lowTree))
+
+		By("handing the namespace over when the priorities change")
+		low.Spec.Priority = 20
+		Expect(k8sClient.Update(ctx, low)).To(Succeed())
+		high = reconcileAndGet(ctx, reconciler, highTree)
+		low = reconcileAndGet(ctx, reconciler, lowTree)
+		Expect(hasCondition(low, rbacv1alpha1.ConditionTypeSuperseded)).To(BeFalse())
+		Expect(hasCondition(high, rbacv1alpha1.ConditionTypeSuperseded)).To(BeTrue())
+		Expect(treesWithRoleBindingsIn(sharedNamespace)).To(ConsistOf(lowTree))
+	})
+
+	It("should not supersede namespaces unless overlap is allowed", func() {
+		createFolderTree(lowTree, 0, sharedNamespace)
+		createFolderTree(highTree, 10, sharedNamespace)
+		reconciler.AllowNamespaceOverlap = false
+
+		Expect(hasCondition(reconcileAndGet(ctx, reconciler, lowTree), rbacv1alpha1.ConditionTypeSuperseded)).To(BeFalse())
+	})
+
+	It("should map FolderTree changes to the other FolderTrees sharing their namespaces", func() {
+		reconciler.namespaces.set(lowTree, []string{sharedNamespace, ownNamespace})
+		reconciler.namespaces.set(highTree, []string{sharedNamespace})
+		changed := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: highTree},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "changed", Namespaces: []string{sharedNamespace}}},
+			},
+		}
+
+		Expect(reconciler.mapFolderTreeToOverlappingTrees(ctx, changed)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: lowTree}}))
+	})
+})
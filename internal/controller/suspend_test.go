@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Suspend", func() {
+	const (
+		resourceName = "test-suspend"
+		namespace    = "suspend-ns"
+	)
+	var (
+		ctx        context.Context
+		reconciler *FolderTreeReconciler
+	)
+
+	listRoleBindings := func() []rbacv1.RoleBinding {
+		roleBindings := &rbacv1.RoleBindingList{}
+		Expect(k8sClient.List(ctx, roleBindings, client.InNamespace(namespace))).To(Succeed())
+		return roleBindings.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &FolderTreeReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	It("should skip RoleBinding operations while suspended and resume afterwards", func() {
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Suspend: true,
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "suspend-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{namespace},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+		})
+
+		By("reconciling the suspended FolderTree")
+		suspended := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(listRoleBindings()).To(BeEmpty())
+		Expect(hasCondition(suspended, rbacv1alpha1.ConditionTypeSuspended)).To(BeTrue())
+		Expect(hasCondition(suspended, rbacv1alpha1.ConditionTypeReady)).To(BeFalse())
+		Expect(suspended.Status.ProcessedGeneration).To(BeZero())
+
+		By("resuming reconciliation")
+		suspended.Spec.Suspend = false
+		Expect(k8sClient.Update(ctx, suspended)).To(Succeed())
+		resumed := reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(listRoleBindings()).To(HaveLen(1))
+		Expect(hasCondition(resumed, rbacv1alpha1.ConditionTypeSuspended)).To(BeFalse())
+		Expect(hasCondition(resumed, rbacv1alpha1.ConditionTypeReady)).To(BeTrue())
+		Expect(resumed.Status.ProcessedGeneration).To(Equal(resumed.Generation))
+
+		By("leaving out-of-band edits alone while suspended")
+		resumed.Spec.Suspend = true
+		Expect(k8sClient.Update(ctx, resumed)).To(Succeed())
+		roleBinding := listRoleBindings()[0]
+		roleBinding.Subjects = append(roleBinding.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder", APIGroup: "rbac.authorization.k8s.io"})
+		Expect(k8sClient.Update(ctx, &roleBinding)).To(Succeed())
+
+		reconcileAndGet(ctx, reconciler, resourceName)
+		Expect(listRoleBindings()[0].Subjects).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("FolderTree Controller - Tracing", func() {
+	var (
+		ctx      context.Context
+		recorder *tracetest.SpanRecorder
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	// spansNamed returns the ended spans with the given name
+	spansNamed := func(name string) []sdktrace.ReadOnlySpan {
+		var spans []sdktrace.ReadOnlySpan
+		for _, span := range recorder.Ended() {
+			if span.Name() == name {
+				spans = append(spans, span)
+			}
+		}
+		return spans
+	}
+	// attributeOf returns the string value of an attribute of a span
+	attributeOf := func(span sdktrace.ReadOnlySpan, key string) string {
+		for _, kv := range span.Attributes() {
+			if kv.Key == attribute.Key(key) {
+				return kv.Value.Emit()
+			}
+		}
+		return ""
+	}
+
+	It("should trace RoleBinding operations per folder, namespace and operation", func() {
+		for _, name := range []string{"tracing-parent-ns", "tracing-child-ns"} {
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+		}
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "tracing-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "tracing-parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "tracing-child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:       "tracing-parent",
+						Namespaces: []string{"tracing-parent-ns"},
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:      "viewers",
+							Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+							RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							Propagate: boolPtr(true),
+						}},
+					},
+					{Name: "tracing-child", Namespaces: []string{"tracing-child-ns"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, folderTree)).To(Succeed())
+
+		reconciler := &FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: folderTree.Name}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(spansNamed("ProcessOperations")).To(HaveLen(1))
+		Expect(spansNamed("AnalyzeDiff")).To(HaveLen(1))
+		executeSpans := spansNamed("ExecuteOperations")
+		Expect(executeSpans).To(HaveLen(1))
+
+		folderSpans := spansNamed("Folder")
+		Expect(folderSpans).To(HaveLen(2))
+		var folders []string
+		for _, span := range folderSpans {
+			Expect(span.Parent().SpanID()).To(Equal(executeSpans[0].SpanContext().SpanID()))
+			folders = append(folders, attributeOf(span, "folder"))
+		}
+		Expect(folders).To(ConsistOf("tracing-parent", "tracing-parent.tracing-child"))
+
+		namespaceSpans := spansNamed("Namespace")
+		Expect(namespaceSpans).To(HaveLen(2))
+		operationSpans := spansNamed("Operation")
+		Expect(operationSpans).To(HaveLen(2))
+		for _, span := range operationSpans {
+			Expect(attributeOf(span, "operation")).To(Equal("create"))
+			Expect(attributeOf(span, "template")).To(Equal("viewers"))
+			Expect([]string{namespaceSpans[0].SpanContext().SpanID().String(), namespaceSpans[1].SpanContext().SpanID().String()}).
+				To(ContainElement(span.Parent().SpanID().String()))
+		}
+	})
+})
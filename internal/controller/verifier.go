@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups=user.openshift.io,resources=users,verbs=list
+
+// verifySubjects sets the SubjectUnresolved condition listing the User and Group subjects of the
+// desired RoleBindings that the SubjectVerifier cannot resolve, and removes it when there are none.
+// Failing to verify, e.g. because the endpoint is down, is logged and leaves the condition unchanged.
+func (r *FolderTreeReconciler) verifySubjects(ctx context.Context, folderTree *rbacv1alpha1.FolderTree, desired *rbac.DesiredRoleBindingSet) {
+	unresolved, err := rbac.UnresolvedSubjects(ctx, r.SubjectVerifier, desired)
+	if err != nil {
+		logf.FromContext(ctx).Info("Could not verify the subjects of the RoleBindings", "error", err)
+		return
+	}
+	if len(unresolved) == 0 {
+		r.removeCondition(folderTree, rbacv1alpha1.ConditionTypeSubjectUnresolved)
+		return
+	}
+
+	listed := unresolved
+	if len(listed) > maxMissingListed {
+		listed = append(slices.Clone(unresolved[:maxMissingListed]), fmt.Sprintf("and %d more", len(unresolved)-maxMissingListed))
+	}
+	message := fmt.Sprintf("%d subject(s) could not be resolved and likely grant no access: %s",
+		len(unresolved), strings.Join(listed, ", "))
+	setConditionMessage(folderTree, rbacv1alpha1.ConditionTypeSubjectUnresolved, message)
+}
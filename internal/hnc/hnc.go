@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hnc converts the hierarchies of the Hierarchical Namespace Controller (HNC) into
+// FolderTrees, so that clusters can migrate from HNC to folders.
+package hnc
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// InheritedFromLabel marks the copies HNC propagates to descendants, naming the source namespace
+	InheritedFromLabel = "hnc.x-k8s.io/inherited-from"
+
+	// PropagateNoneAnnotation stops HNC from propagating an object
+	PropagateNoneAnnotation = "propagate.hnc.x-k8s.io/none"
+
+	// propagateAnnotationPrefix prefixes the HNC annotations limiting propagation to some descendants
+	propagateAnnotationPrefix = "propagate.hnc.x-k8s.io/"
+)
+
+// HierarchyConfigurationListGVK is the list kind of the HNC HierarchyConfigurations, one per
+// namespace, whose spec.parent names the parent namespace
+var HierarchyConfigurationListGVK = schema.GroupVersionKind{
+	Group:   "hnc.x-k8s.io",
+	Version: "v1alpha2",
+	Kind:    "HierarchyConfigurationList",
+}
+
+// templateNameInvalidChars matches the characters of RoleBinding names that template names may not contain
+var templateNameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ReadParents returns the parent of every namespace with an HNC parent, keyed by namespace
+func ReadParents(ctx context.Context, c client.Reader) (map[string]string, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(HierarchyConfigurationListGVK)
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list HNC HierarchyConfigurations: %w", err)
+	}
+
+	parents := make(map[string]string)
+	for _, item := range list.Items {
+		parent, _, err := unstructured.NestedString(item.Object, "spec", "parent")
+		if err != nil {
+			return nil, fmt.Errorf("HierarchyConfiguration %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		if parent != "" {
+			parents[item.GetNamespace()] = parent
+		}
+	}
+	return parents, nil
+}
+
+// Convert returns a FolderTree equivalent to the HNC hierarchies given by parents, the parent of
+// every namespace that has one. Every namespace of a hierarchy becomes a folder of the same name
+// holding the namespace, and the root namespaces become the roots of spec.trees. The RoleBindings HNC
+// propagates, those in namespaces with children that are no propagated copies themselves, become
+// propagating role binding templates of their folder.
+//
+// RoleBindings that cannot be expressed as templates, e.g. because they bind namespaced Roles or
+// propagate to selected descendants only, are left out and reported as warnings.
+func Convert(name string, parents map[string]string, roleBindings []rbacv1.RoleBinding) (*rbacv1alpha1.FolderTree, []string, error) {
+	children := make(map[string][]string)
+	for namespace, parent := range parents {
+		children[parent] = append(children[parent], namespace)
+	}
+	for _, namespaces := range children {
+		slices.Sort(namespaces)
+	}
+
+	var roots []string
+	for parent := range children {
+		if _, ok := parents[parent]; !ok {
+			roots = append(roots, parent)
+		}
+	}
+	slices.Sort(roots)
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1alpha1.GroupVersion.String(), Kind: "FolderTree"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	visited := make(map[string]bool)
+	var buildNode func(namespace string) rbacv1alpha1.TreeNode
+	buildNode = func(namespace string) rbacv1alpha1.TreeNode {
+		visited[namespace] = true
+		node := rbacv1alpha1.TreeNode{Name: namespace}
+		for _, child := range children[namespace] {
+			node.Subfolders = append(node.Subfolders, buildNode(child))
+		}
+		return node
+	}
+	for _, root := range roots {
+		folderTree.Spec.Trees = append(folderTree.Spec.Trees, buildNode(root))
+	}
+	if len(visited) != len(parents)+len(roots) {
+		var cyclic []string
+		for namespace := range parents {
+			if !visited[namespace] {
+				cyclic = append(cyclic, namespace)
+			}
+		}
+		slices.Sort(cyclic)
+		return nil, nil, fmt.Errorf("the parents of namespaces %s form a cycle", strings.Join(cyclic, ", "))
+	}
+
+	templates, warnings := convertRoleBindings(children, roleBindings)
+	for _, namespace := range slices.Sorted(maps.Keys(visited)) {
+		folderTree.Spec.Folders = append(folderTree.Spec.Folders, rbacv1alpha1.Folder{
+			Name:                 namespace,
+			Namespaces:           []string{namespace},
+			RoleBindingTemplates: templates[namespace],
+		})
+	}
+	return folderTree, warnings, nil
+}
+
+// convertRoleBindings returns the templates of the RoleBindings HNC propagates by namespace.
+// Template names are unique across the FolderTree, since names inherited from ancestors may not be reused.
+func convertRoleBindings(children map[string][]string, roleBindings []rbacv1.RoleBinding) (map[string][]rbacv1alpha1.RoleBindingTemplate, []string) {
+	sorted := slices.Clone(roleBindings)
+	slices.SortFunc(sorted, func(a, b rbacv1.RoleBinding) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	templates := make(map[string][]rbacv1alpha1.RoleBindingTemplate)
+	names := make(map[string]bool)
+	var warnings []string
+	for _, roleBinding := range sorted {
+		if len(children[roleBinding.Namespace]) == 0 || roleBinding.Labels[InheritedFromLabel] != "" {
+			continue
+		}
+		if skip, reason := unsupported(roleBinding); skip {
+			if reason != "" {
+				warnings = append(warnings, fmt.Sprintf("skipped RoleBinding %s/%s: %s", roleBinding.Namespace, roleBinding.Name, reason))
+			}
+			continue
+		}
+
+		base := templateName(roleBinding.Name)
+		name := base
+		for i := 2; names[name]; i++ {
+			suffix := fmt.Sprintf("-%d", i)
+			name = strings.TrimSuffix(base[:min(len(base), 63-len(suffix))], "-") + suffix
+		}
+		names[name] = true
+
+		templates[roleBinding.Namespace] = append(templates[roleBinding.Namespace], rbacv1alpha1.RoleBindingTemplate{
+			Name:      name,
+			Subjects:  roleBinding.Subjects,
+			RoleRef:   roleBinding.RoleRef,
+			Propagate: ptr.To(true),
+		})
+	}
+	return templates, warnings
+}
+
+// unsupported reports whether a RoleBinding HNC propagates cannot be converted and why. RoleBindings
+// HNC does not propagate are skipped without a reason.
+func unsupported(roleBinding rbacv1.RoleBinding) (bool, string) {
+	for key, value := range roleBinding.Annotations {
+		if !strings.HasPrefix(key, propagateAnnotationPrefix) {
+			continue
+		}
+		if key == PropagateNoneAnnotation && value == "true" {
+			return true, ""
+		}
+		return true, fmt.Sprintf("the %s annotation propagates it to selected descendants only", key)
+	}
+	if roleBinding.RoleRef.Kind != "ClusterRole" {
+		return true, fmt.Sprintf("role binding templates can only bind ClusterRoles, not %s %s",
+			roleBinding.RoleRef.Kind, roleBinding.RoleRef.Name)
+	}
+	if len(roleBinding.Subjects) == 0 {
+		return true, "it has no subjects"
+	}
+	return false, ""
+}
+
+// templateName returns a template name for a RoleBinding name, which may contain dots and be longer
+func templateName(roleBindingName string) string {
+	name := templateNameInvalidChars.ReplaceAllString(strings.ToLower(roleBindingName), "-")
+	name = strings.Trim(name[:min(len(name), 63)], "-")
+	if name == "" {
+		return "rolebinding"
+	}
+	return name
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hnc
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+func TestHNC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HNC Suite")
+}
+
+var _ = Describe("HNC migration", func() {
+	roleBinding := func(namespace, name, role string) rbacv1.RoleBinding {
+		return rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role},
+		}
+	}
+
+	// acme has the children team-a and team-b, and team-a has the child team-a-dev
+	parents := map[string]string{"team-a": "acme", "team-b": "acme", "team-a-dev": "team-a"}
+
+	It("should read the parents of the HierarchyConfigurations", func() {
+		hierarchy := func(namespace, parent string) *unstructured.Unstructured {
+			object := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{}}}
+			object.SetAPIVersion("hnc.x-k8s.io/v1alpha2")
+			object.SetKind("HierarchyConfiguration")
+			object.SetNamespace(namespace)
+			object.SetName("hierarchy")
+			if parent != "" {
+				Expect(unstructured.SetNestedField(object.Object, parent, "spec", "parent")).To(Succeed())
+			}
+			return object
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(runtime.NewScheme()).
+			WithObjects(hierarchy("acme", ""), hierarchy("team-a", "acme"), hierarchy("team-a-dev", "team-a")).
+			Build()
+
+		Expect(ReadParents(context.Background(), c)).To(Equal(map[string]string{"team-a": "acme", "team-a-dev": "team-a"}))
+	})
+
+	It("should convert hierarchies into a folder per namespace", func() {
+		folderTree, warnings, err := Convert("hnc", parents, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		Expect(folderTree.Name).To(Equal("hnc"))
+		Expect(folderTree.Kind).To(Equal("FolderTree"))
+		Expect(folderTree.Spec.Trees).To(Equal([]rbacv1alpha1.TreeNode{{
+			Name: "acme",
+			Subfolders: []rbacv1alpha1.TreeNode{
+				{Name: "team-a", Subfolders: []rbacv1alpha1.TreeNode{{Name: "team-a-dev"}}},
+				{Name: "team-b"},
+			},
+		}}))
+		Expect(folderTree.Spec.Folders).To(HaveLen(4))
+		for _, folder := range folderTree.Spec.Folders {
+			Expect(folder.Namespaces).To(Equal([]string{folder.Name}))
+		}
+		Expect(validation.ValidateFolderTreeSpec(&folderTree.Spec, validation.Options{})).To(Succeed())
+	})
+
+	It("should convert the RoleBindings HNC propagates into propagating templates", func() {
+		inherited := roleBinding("team-a", "admins", "admin")
+		inherited.Labels = map[string]string{InheritedFromLabel: "acme"}
+		local := roleBinding("team-a", "local", "view")
+		local.Annotations = map[string]string{PropagateNoneAnnotation: "true"}
+
+		folderTree, warnings, err := Convert("hnc", parents, []rbacv1.RoleBinding{
+			roleBinding("acme", "admins", "admin"),
+			inherited,
+			local,
+			roleBinding("team-a", "Team.Admins", "edit"),
+			roleBinding("team-a-dev", "devs", "edit"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		templates := map[string][]rbacv1alpha1.RoleBindingTemplate{}
+		for _, folder := range folderTree.Spec.Folders {
+			templates[folder.Name] = folder.RoleBindingTemplates
+		}
+		Expect(templates["acme"]).To(Equal([]rbacv1alpha1.RoleBindingTemplate{{
+			Name:      "admins",
+			Subjects:  []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "admins"}},
+			RoleRef:   rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+			Propagate: ptr.To(true),
+		}}))
+		// Only the source is converted, and leaf namespaces have nothing to propagate
+		Expect(templates["team-a"]).To(HaveLen(1))
+		Expect(templates["team-a"][0].Name).To(Equal("team-admins"))
+		Expect(templates["team-a-dev"]).To(BeEmpty())
+		Expect(validation.ValidateFolderTreeSpec(&folderTree.Spec, validation.Options{})).To(Succeed())
+	})
+
+	It("should make template names unique across the FolderTree", func() {
+		folderTree, _, err := Convert("hnc", parents, []rbacv1.RoleBinding{
+			roleBinding("acme", "viewers", "view"),
+			roleBinding("team-a", "viewers", "view"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validation.ValidateFolderTreeSpec(&folderTree.Spec, validation.Options{})).To(Succeed())
+
+		var names []string
+		for _, folder := range folderTree.Spec.Folders {
+			for _, template := range folder.RoleBindingTemplates {
+				names = append(names, template.Name)
+			}
+		}
+		Expect(names).To(ConsistOf("viewers", "viewers-2"))
+	})
+
+	It("should warn about RoleBindings that cannot be converted", func() {
+		roleRefBinding := roleBinding("acme", "role-binding", "reader")
+		roleRefBinding.RoleRef.Kind = "Role"
+		selected := roleBinding("acme", "selected", "view")
+		selected.Annotations = map[string]string{"propagate.hnc.x-k8s.io/treeSelect": "team-a"}
+
+		folderTree, warnings, err := Convert("hnc", parents, []rbacv1.RoleBinding{roleRefBinding, selected})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(
+			"skipped RoleBinding acme/role-binding: role binding templates can only bind ClusterRoles, not Role reader",
+			"skipped RoleBinding acme/selected: the propagate.hnc.x-k8s.io/treeSelect annotation propagates it to selected descendants only",
+		))
+		for _, folder := range folderTree.Spec.Folders {
+			Expect(folder.RoleBindingTemplates).To(BeEmpty())
+		}
+	})
+
+	It("should reject cyclic hierarchies", func() {
+		_, _, err := Convert("hnc", map[string]string{"a": "b", "b": "a", "team-a": "acme"}, nil)
+		Expect(err).To(MatchError("the parents of namespaces a, b form a cycle"))
+	})
+})
@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the custom Prometheus metrics of the FolderTree controller and webhook.
+// All metrics are registered with the controller-runtime metrics registry and are served
+// on the manager's metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ResultSuccess labels a successful reconcile or operation
+	ResultSuccess = "success"
+	// ResultError labels a failed reconcile or operation
+	ResultError = "error"
+)
+
+// Webhook rejection reasons
+const (
+	RejectionReasonStructure           = "structure"
+	RejectionReasonBusinessLogic       = "business_logic"
+	RejectionReasonPolicy              = "policy"
+	RejectionReasonConflict            = "conflict"
+	RejectionReasonNamespaceMissing    = "namespace_missing"
+	RejectionReasonNamespaceOwned      = "namespace_owned"
+	RejectionReasonPrivilegeEscalation = "privilege_escalation"
+	RejectionReasonFanOut              = "fan_out"
+	RejectionReasonDestructiveChange   = "destructive_change"
+)
+
+var (
+	// ManagedRoleBindings is the number of RoleBindings currently managed per FolderTree
+	ManagedRoleBindings = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "foldertree_managed_rolebindings",
+			Help: "Number of RoleBindings currently managed by a FolderTree",
+		},
+		[]string{"foldertree"},
+	)
+
+	// DanglingSubjects is the number of Group subjects bound by a FolderTree that do not exist as
+	// OpenShift Groups. It is only set when the controller validates OpenShift Groups.
+	DanglingSubjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "foldertree_dangling_subjects",
+			Help: "Number of Group subjects bound by a FolderTree that do not exist as OpenShift Groups",
+		},
+		[]string{"foldertree"},
+	)
+
+	// StatusBytes is the size of the status of a FolderTree serialized as JSON, after the status
+	// size limits were enforced
+	StatusBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "foldertree_status_bytes",
+			Help: "Size in bytes of the status of a FolderTree",
+		},
+		[]string{"foldertree"},
+	)
+
+	// OrphanedRoleBindings is the number of RoleBindings labeled with a FolderTree that does not
+	// exist, as of the last sweep
+	OrphanedRoleBindings = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "foldertree_orphaned_rolebindings",
+			Help: "Number of RoleBindings labeled with a FolderTree that does not exist, as of the last orphan sweep",
+		},
+	)
+
+	// RoleBindingOperations counts executed RoleBinding operations per FolderTree, type and result
+	RoleBindingOperations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foldertree_rolebinding_operations_total",
+			Help: "Total number of RoleBinding create/update/delete operations executed by the controller",
+		},
+		[]string{"foldertree", "operation", "result"},
+	)
+
+	// RoleBindingWritesQueued is the number of RoleBinding operations waiting for the write rate limiter
+	RoleBindingWritesQueued = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "foldertree_rolebinding_writes_queued",
+			Help: "Number of RoleBinding operations currently waiting for the write rate limiter",
+		},
+	)
+
+	// RoleBindingWritesThrottled counts RoleBinding operations delayed by the write rate limiter
+	RoleBindingWritesThrottled = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "foldertree_rolebinding_writes_throttled_total",
+			Help: "Total number of RoleBinding operations delayed by the write rate limiter",
+		},
+	)
+
+	// ReconcileDuration observes the duration of FolderTree reconciles by result
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "foldertree_reconcile_duration_seconds",
+			Help:    "Duration of FolderTree reconciles in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"result"},
+	)
+
+	// FolderOperationsDuration observes how long the RoleBinding operations of a folder take per reconcile
+	FolderOperationsDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "foldertree_folder_operations_duration_seconds",
+			Help:    "Duration of executing the RoleBinding operations of a folder during a reconcile in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"foldertree", "folder"},
+	)
+
+	// ReconcilesSkipped counts reconciles that found the FolderTree and its managed objects
+	// unchanged since the last successful reconcile and skipped the diff
+	ReconcilesSkipped = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "foldertree_reconciles_skipped_total",
+			Help: "Total number of FolderTree reconciles skipped because nothing changed since the last successful reconcile",
+		},
+	)
+
+	// WebhookRejections counts FolderTree admission requests rejected by the webhook
+	WebhookRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foldertree_webhook_rejections_total",
+			Help: "Total number of FolderTree admission requests rejected by the validating webhook",
+		},
+		[]string{"operation", "reason"},
+	)
+
+	// WebhookPrivilegeCheckDuration observes the duration of the privilege escalation check by mode
+	WebhookPrivilegeCheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "foldertree_webhook_privilege_check_duration_seconds",
+			Help:    "Duration of the privilege escalation check of FolderTree admission requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"mode"},
+	)
+
+	// WebhookBreakGlass counts FolderTree admission requests that skipped the privilege escalation
+	// check under a break-glass annotation
+	WebhookBreakGlass = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foldertree_webhook_break_glass_total",
+			Help: "Total number of FolderTree admission requests that skipped the privilege escalation check under break-glass",
+		},
+		[]string{"operation"},
+	)
+
+	// WebhookPrivilegedBypass counts admission requests of privileged users that skipped the
+	// privilege escalation check
+	WebhookPrivilegedBypass = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foldertree_webhook_privileged_bypass_total",
+			Help: "Total number of admission requests of privileged users that skipped the privilege escalation check",
+		},
+		[]string{"operation"},
+	)
+
+	// WebhookWarnings counts the structured warnings returned with FolderTree admission responses
+	WebhookWarnings = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foldertree_webhook_warnings_total",
+			Help: "Total number of deprecation and limit warnings returned by the validating webhook, by category",
+		},
+		[]string{"operation", "category"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ManagedRoleBindings,
+		DanglingSubjects,
+		StatusBytes,
+		OrphanedRoleBindings,
+		RoleBindingOperations,
+		RoleBindingWritesQueued,
+		RoleBindingWritesThrottled,
+		ReconcileDuration,
+		FolderOperationsDuration,
+		ReconcilesSkipped,
+		WebhookRejections,
+		WebhookPrivilegeCheckDuration,
+		WebhookBreakGlass,
+		WebhookPrivilegedBypass,
+		WebhookWarnings,
+	)
+}
+
+// ObserveReconcile records the duration of a reconcile
+func ObserveReconcile(start time.Time, err error) {
+	ReconcileDuration.WithLabelValues(resultLabel(err)).Observe(time.Since(start).Seconds())
+}
+
+// RecordOperation counts an executed RoleBinding operation
+func RecordOperation(folderTree, operation string, err error) {
+	RoleBindingOperations.WithLabelValues(folderTree, operation, resultLabel(err)).Inc()
+}
+
+// ObserveFolderOperations records how long the operations of a folder took
+func ObserveFolderOperations(folderTree, folder string, start time.Time) {
+	FolderOperationsDuration.WithLabelValues(folderTree, folder).Observe(time.Since(start).Seconds())
+}
+
+// RecordRejection counts a webhook rejection and returns the error unchanged
+func RecordRejection(operation, reason string, err error) error {
+	WebhookRejections.WithLabelValues(operation, reason).Inc()
+	return err
+}
+
+// ObservePrivilegeCheck records the duration of a webhook privilege escalation check
+func ObservePrivilegeCheck(mode string, start time.Time) {
+	WebhookPrivilegeCheckDuration.WithLabelValues(mode).Observe(time.Since(start).Seconds())
+}
+
+// RecordBreakGlass counts an admission request skipping the privilege escalation check under break-glass
+func RecordBreakGlass(operation string) {
+	WebhookBreakGlass.WithLabelValues(operation).Inc()
+}
+
+// RecordPrivilegedBypass counts an admission request of a privileged user skipping the privilege escalation check
+func RecordPrivilegedBypass(operation string) {
+	WebhookPrivilegedBypass.WithLabelValues(operation).Inc()
+}
+
+// RecordWarning counts a structured admission warning
+func RecordWarning(operation, category string) {
+	WebhookWarnings.WithLabelValues(operation, category).Inc()
+}
+
+// ForgetFolderTree removes all per-FolderTree series of a deleted FolderTree
+func ForgetFolderTree(folderTree string) {
+	ManagedRoleBindings.DeleteLabelValues(folderTree)
+	DanglingSubjects.DeleteLabelValues(folderTree)
+	StatusBytes.DeleteLabelValues(folderTree)
+	RoleBindingOperations.DeletePartialMatch(prometheus.Labels{"foldertree": folderTree})
+	FolderOperationsDuration.DeletePartialMatch(prometheus.Labels{"foldertree": folderTree})
+}
+
+// resultLabel maps an error to a result label value
+func resultLabel(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
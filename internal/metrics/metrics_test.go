@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metrics Suite")
+}
+
+var _ = Describe("Metrics", func() {
+	It("should count operations by FolderTree, type and result", func() {
+		RecordOperation("metrics-tree", "create", nil)
+		RecordOperation("metrics-tree", "create", nil)
+		RecordOperation("metrics-tree", "delete", errors.New("forbidden"))
+
+		Expect(testutil.ToFloat64(RoleBindingOperations.WithLabelValues("metrics-tree", "create", ResultSuccess))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(RoleBindingOperations.WithLabelValues("metrics-tree", "delete", ResultError))).To(Equal(1.0))
+	})
+
+	It("should count webhook rejections and pass the error through", func() {
+		err := errors.New("privilege escalation prevented")
+		Expect(RecordRejection("update", RejectionReasonPrivilegeEscalation, err)).To(BeIdenticalTo(err))
+		Expect(testutil.ToFloat64(WebhookRejections.WithLabelValues("update", RejectionReasonPrivilegeEscalation))).To(Equal(1.0))
+	})
+
+	It("should observe privilege check durations by mode", func() {
+		ObservePrivilegeCheck("subjectaccessreview", time.Now())
+
+		Expect(testutil.CollectAndCount(WebhookPrivilegeCheckDuration, "foldertree_webhook_privilege_check_duration_seconds")).To(Equal(1))
+	})
+
+	It("should drop the series of a deleted FolderTree", func() {
+		ManagedRoleBindings.WithLabelValues("deleted-tree").Set(3)
+		StatusBytes.WithLabelValues("deleted-tree").Set(2048)
+		RecordOperation("deleted-tree", "create", nil)
+		RecordOperation("kept-tree", "create", nil)
+		ObserveFolderOperations("deleted-tree", "root.prod", time.Now())
+		ObserveFolderOperations("kept-tree", "root", time.Now())
+
+		ForgetFolderTree("deleted-tree")
+
+		Expect(testutil.CollectAndCount(ManagedRoleBindings, "foldertree_managed_rolebindings")).To(BeZero())
+		Expect(testutil.CollectAndCount(StatusBytes, "foldertree_status_bytes")).To(BeZero())
+		Expect(testutil.CollectAndCount(FolderOperationsDuration, "foldertree_folder_operations_duration_seconds")).To(Equal(1))
+		Expect(testutil.ToFloat64(RoleBindingOperations.WithLabelValues("kept-tree", "create", ResultSuccess))).To(Equal(1.0))
+	})
+})
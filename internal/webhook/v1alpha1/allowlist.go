@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ClusterRoleAllowlistKey is the key of the ClusterRole allowlist ConfigMap listing the allowed
+// ClusterRoles, separated by commas or newlines. A "*" in an entry matches any characters, so
+// "folders.example.com/*" allows every ClusterRole with that prefix.
+const ClusterRoleAllowlistKey = "clusterRoles"
+
+// clusterRoleAllowlist restricts the ClusterRoles role binding templates may reference
+type clusterRoleAllowlist struct {
+	// source names where the allowlist was read from, for rejection messages
+	source   string
+	patterns []string
+}
+
+// allows reports whether the ClusterRole matches an entry of the allowlist
+func (a *clusterRoleAllowlist) allows(clusterRole string) bool {
+	for _, pattern := range a.patterns {
+		if matchesWildcard(pattern, clusterRole) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadClusterRoleAllowlist reads the configured ClusterRole allowlist ConfigMap. It returns nil
+// when no allowlist is configured. A configured but missing ConfigMap is an error, so that a
+// misconfiguration does not silently allow every ClusterRole.
+func (v *FolderTreeCustomValidator) loadClusterRoleAllowlist(ctx context.Context) (*clusterRoleAllowlist, error) {
+	key := v.Options.ClusterRoleAllowlist
+	if key.Name == "" {
+		return nil, nil
+	}
+	source := fmt.Sprintf("ConfigMap %s", key)
+
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to read the ClusterRole allowlist from %s: %v", source, err)
+	}
+
+	patterns := strings.FieldsFunc(configMap.Data[ClusterRoleAllowlistKey], func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	allowlist := &clusterRoleAllowlist{source: source}
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			allowlist.patterns = append(allowlist.patterns, pattern)
+		}
+	}
+	return allowlist, nil
+}
+
+// matchesWildcard reports whether name matches pattern, where each "*" matches any characters
+func matchesWildcard(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
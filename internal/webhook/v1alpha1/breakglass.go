@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+)
+
+const (
+	// BreakGlassAnnotation carries the incident ticket under which a member of a break-glass group
+	// changes or deletes a FolderTree without the privilege escalation check
+	BreakGlassAnnotation = "rbac.kubevirt.io/break-glass"
+
+	// EventReasonBreakGlass is the reason of the Event recorded for break-glass admission requests
+	EventReasonBreakGlass = "BreakGlass"
+)
+
+// breakGlass reports whether the privilege escalation check of an admission request is skipped.
+// This is the case when the FolderTree carries the BreakGlassAnnotation and the requesting user is
+// a member of one of the configured break-glass groups. Every skipped check is logged, counted and,
+// unless the request is a dry run, recorded as a Warning Event on the FolderTree.
+func (v *FolderTreeCustomValidator) breakGlass(ctx context.Context, operation string, folderTree *rbacv1alpha1.FolderTree) bool {
+	ticket := folderTree.Annotations[BreakGlassAnnotation]
+	if ticket == "" || len(v.Options.BreakGlassGroups) == 0 {
+		return false
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	if !slices.ContainsFunc(req.UserInfo.Groups, func(group string) bool {
+		return slices.Contains(v.Options.BreakGlassGroups, group)
+	}) {
+		foldertreelog.Info("Ignoring break-glass annotation of a user outside the break-glass groups",
+			"name", folderTree.Name, "user", req.UserInfo.Username, "ticket", ticket)
+		return false
+	}
+
+	foldertreelog.Info("Skipping privilege escalation check for break-glass request",
+		"name", folderTree.Name, "operation", operation, "user", req.UserInfo.Username, "ticket", ticket)
+	metrics.RecordBreakGlass(operation)
+	if v.Recorder != nil && (req.DryRun == nil || !*req.DryRun) {
+		v.Recorder.Eventf(folderTree, corev1.EventTypeWarning, EventReasonBreakGlass,
+			"Privilege escalation check of %s skipped for user %s under break-glass ticket %s",
+			operation, req.UserInfo.Username, ticket)
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+
+// secretReader returns the reader for cluster Secrets, which are not cached
+func (v *FolderTreeCustomValidator) secretReader() client.Reader {
+	if v.APIReader != nil {
+		return v.APIReader
+	}
+	return v.Client
+}
+
+// validateClusterAccess requires the user to be allowed to get the kubeconfig Secret of every
+// remote cluster a FolderTree with spec.clusters propagates to, when creating it or changing its
+// spec. The webhook only runs in this cluster, so the privilege escalation check does not cover
+// remote clusters; access to their credentials stands in for it. oldFolderTree is nil on create.
+func (v *FolderTreeCustomValidator) validateClusterAccess(ctx context.Context, userInfo authenticationv1.UserInfo,
+	oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) error {
+	if newFolderTree.Spec.Clusters == nil || v.Options.ClusterSecretNamespace == "" {
+		return nil
+	}
+	if oldFolderTree != nil && equality.Semantic.DeepEqual(oldFolderTree.Spec, newFolderTree.Spec) {
+		return nil
+	}
+
+	secrets, err := rbac.SelectClusters(ctx, v.secretReader(), v.Options.ClusterSecretNamespace, newFolderTree)
+	if err != nil {
+		return err
+	}
+	authorizer := newSubjectAccessReviewAuthorizer(v.Client, userInfo)
+	for _, secret := range secrets {
+		allowed, err := authorizer.allowed(ctx, accessKey{
+			namespace: secret.Namespace, verb: "get", resource: "secrets", name: secret.Name,
+		})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("user lacks required permissions: cannot get the Secret of cluster '%s' in namespace '%s'",
+				secret.Name, secret.Namespace)
+		}
+	}
+	return nil
+}
+
+// clusterWarnings warns about spec.clusters selecting no cluster, including when multi-cluster
+// propagation is disabled
+func (v *FolderTreeCustomValidator) clusterWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	if folderTree.Spec.Clusters == nil {
+		return nil
+	}
+	if v.Options.ClusterSecretNamespace == "" {
+		return admission.Warnings{"spec.clusters: multi-cluster propagation is disabled on this controller; " +
+			"RoleBindings are only created in this cluster"}
+	}
+
+	secrets, err := rbac.SelectClusters(ctx, v.secretReader(), v.Options.ClusterSecretNamespace, folderTree)
+	if err != nil {
+		foldertreelog.Info("Could not check the clusters selected by spec.clusters", "error", err)
+		return nil
+	}
+	if len(secrets) == 0 {
+		return admission.Warnings{fmt.Sprintf(
+			"spec.clusters: no cluster Secret in namespace '%s' matches; RoleBindings are only created in this cluster",
+			v.Options.ClusterSecretNamespace)}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// maxConflictWarnings caps the number of name conflicts warned about individually
+const maxConflictWarnings = 10
+
+// nameConflictWarnings warns about RoleBindings of the FolderTree whose names are taken by
+// RoleBindings it does not manage. The controller does not replace them and reports a Conflict
+// condition instead. They are not rejected, since the existing RoleBinding may be removed or
+// adopted later.
+func (v *FolderTreeCustomValidator) nameConflictWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	builder := &rbac.RoleBindingBuilder{
+		FolderTree:         folderTree,
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		Cache:              v.Options.DesiredStateCache,
+	}
+	desired, err := rbac.CalculateDesiredRoleBindings(folderTree, builder)
+	if err != nil {
+		foldertreelog.Info("Could not calculate the RoleBindings to check for name conflicts", "error", err)
+		return nil
+	}
+
+	var conflicts []string
+	for _, desiredRB := range desired.RoleBindings {
+		existing := &rbacv1.RoleBinding{}
+		key := types.NamespacedName{Namespace: desiredRB.Namespace, Name: desiredRB.RoleBinding.Name}
+		if err := v.Client.Get(ctx, key, existing); err != nil {
+			if !apierrors.IsNotFound(err) {
+				foldertreelog.Info("Could not check RoleBinding for a name conflict", "roleBinding", key, "error", err)
+			}
+			continue
+		}
+		if existing.Labels["foldertree.rbac.kubevirt.io/tree"] != folderTree.Name {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"RoleBinding '%s' in namespace '%s' already exists and is not managed by FolderTree '%s'; "+
+					"it is not replaced until removed or adopted", key.Name, key.Namespace, folderTree.Name))
+		}
+	}
+	slices.Sort(conflicts)
+
+	if len(conflicts) > maxConflictWarnings {
+		return append(conflicts[:maxConflictWarnings:maxConflictWarnings], fmt.Sprintf(
+			"%d more RoleBindings of FolderTree '%s' conflict with existing RoleBindings", len(conflicts)-maxConflictWarnings, folderTree.Name))
+	}
+	return conflicts
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// Defaulting admission webhook for FolderTree resources.
+// Records the user changing the spec of a FolderTree in its annotations.
+// +kubebuilder:webhook:path=/mutate-rbac-kubevirt-io-v1alpha1-foldertree,mutating=true,failurePolicy=fail,sideEffects=None,groups=rbac.kubevirt.io,resources=foldertrees,verbs=create;update,versions=v1alpha1,name=mfoldertree.rbac.kubevirt.io,admissionReviewVersions=v1
+
+// FolderTreeCustomDefaulter records the requesting user of the admission request in the
+// rbac.LastModifiedByAnnotation and rbac.LastModifiedByUIDAnnotation annotations whenever the spec
+// of a FolderTree is created or changed. Updates leaving the spec unchanged, such as the controller
+// adding its finalizer, keep the previous values, so users cannot set the annotations themselves.
+// Likewise it records the hash of the spec a newly requested rollback restores in the
+// rbacv1alpha1.RollbackSpecHashAnnotation.
+//
+// +kubebuilder:object:generate=false
+type FolderTreeCustomDefaulter struct {
+	// Reader reads the FolderTreeRevisions that rollbacks restore
+	Reader client.Reader
+}
+
+var _ webhook.CustomDefaulter = &FolderTreeCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type FolderTree.
+func (d *FolderTreeCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	folderTree, ok := obj.(*rbacv1alpha1.FolderTree)
+	if !ok {
+		return fmt.Errorf("expected a FolderTree object but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get admission request: %v", err)
+	}
+
+	// Rollbacks are only validated on update, so a new FolderTree cannot carry a validated hash
+	delete(folderTree.Annotations, rbacv1alpha1.RollbackSpecHashAnnotation)
+
+	if req.Operation == admissionv1.Update {
+		oldFolderTree := &rbacv1alpha1.FolderTree{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldFolderTree); err != nil {
+			return fmt.Errorf("could not decode the old FolderTree: %v", err)
+		}
+		if err := d.recordRollbackSpecHash(ctx, oldFolderTree, folderTree); err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(oldFolderTree.Spec, folderTree.Spec) {
+			restoreAnnotation(folderTree, oldFolderTree, rbac.LastModifiedByAnnotation)
+			restoreAnnotation(folderTree, oldFolderTree, rbac.LastModifiedByUIDAnnotation)
+			return nil
+		}
+	}
+
+	if folderTree.Annotations == nil {
+		folderTree.Annotations = make(map[string]string)
+	}
+	folderTree.Annotations[rbac.LastModifiedByAnnotation] = req.UserInfo.Username
+	if req.UserInfo.UID != "" {
+		folderTree.Annotations[rbac.LastModifiedByUIDAnnotation] = req.UserInfo.UID
+	} else {
+		delete(folderTree.Annotations, rbac.LastModifiedByUIDAnnotation)
+	}
+	foldertreelog.Info("Recorded FolderTree modifier", "name", folderTree.Name, "user", req.UserInfo.Username)
+	return nil
+}
+
+// recordRollbackSpecHash sets the rbacv1alpha1.RollbackSpecHashAnnotation to the hash of the revision
+// an update newly rolls back to, and keeps its previous value while that rollback is pending. It stays
+// unset when the revision cannot be read, in which case the validating webhook rejects the rollback.
+func (d *FolderTreeCustomDefaulter) recordRollbackSpecHash(ctx context.Context, oldFolderTree, folderTree *rbacv1alpha1.FolderTree) error {
+	if _, ok := folderTree.Annotations[rbacv1alpha1.RollbackToAnnotation]; !ok {
+		return nil
+	}
+	target, ok := rollbackRequest(oldFolderTree, folderTree)
+	if !ok {
+		restoreAnnotation(folderTree, oldFolderTree, rbacv1alpha1.RollbackSpecHashAnnotation)
+		return nil
+	}
+	if d.Reader == nil {
+		return nil
+	}
+
+	spec, err := rbac.GetRollbackTarget(ctx, d.Reader, folderTree.Name, target)
+	if err != nil {
+		return nil
+	}
+	hash, err := rbac.RevisionSpecHash(spec)
+	if err != nil {
+		return err
+	}
+	folderTree.Annotations[rbacv1alpha1.RollbackSpecHashAnnotation] = hash
+	return nil
+}
+
+// restoreAnnotation resets an annotation of a FolderTree to its value on the old object, removing it
+// when the old object did not have it
+func restoreAnnotation(folderTree, oldFolderTree *rbacv1alpha1.FolderTree, key string) {
+	value, ok := oldFolderTree.Annotations[key]
+	if !ok {
+		delete(folderTree.Annotations, key)
+		return
+	}
+	if folderTree.Annotations == nil {
+		folderTree.Annotations = make(map[string]string)
+	}
+	folderTree.Annotations[key] = value
+}
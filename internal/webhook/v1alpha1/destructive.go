@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// ConfirmDestructiveChangeAnnotation confirms an update removing more of the RoleBindings of a
+	// FolderTree than WebhookOptions.DestructiveChangeThreshold allows. Its value must be the hash of
+	// the updated spec, which the rejection of the unconfirmed update names, so that a confirmation
+	// left on the FolderTree does not carry over to later changes.
+	ConfirmDestructiveChangeAnnotation = "rbac.kubevirt.io/confirm-destructive-change"
+
+	// defaultDestructiveChangeThreshold is the percentage of RoleBindings an update may remove without
+	// confirmation when WebhookOptions.DestructiveChangeThreshold is not set
+	defaultDestructiveChangeThreshold = 50
+
+	// specHashLength is the number of hex characters of the spec hash confirming a destructive change
+	specHashLength = 12
+)
+
+// validateDestructiveChange rejects updates removing more than the threshold percentage of the
+// RoleBindings a FolderTree currently produces, e.g. because spec.tree was dropped while the folders
+// were kept, unless the FolderTree carries ConfirmDestructiveChangeAnnotation with the hash of the
+// new spec. Confirmed changes are admitted with a warning.
+func (v *FolderTreeCustomValidator) validateDestructiveChange(oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) (admission.Warnings, error) {
+	threshold := v.destructiveChangeThreshold()
+	if threshold < 0 {
+		return nil, nil
+	}
+
+	previous, err := v.desiredRoleBindings(oldFolderTree)
+	if err != nil || len(previous) == 0 {
+		return nil, err
+	}
+	desired, err := v.desiredRoleBindings(newFolderTree)
+	if err != nil {
+		return nil, err
+	}
+	removed := 0
+	for key := range previous {
+		if _, ok := desired[key]; !ok {
+			removed++
+		}
+	}
+	if removed*100 <= threshold*len(previous) {
+		return nil, nil
+	}
+
+	hash, err := specHash(newFolderTree.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if newFolderTree.Annotations[ConfirmDestructiveChangeAnnotation] == hash {
+		return admission.Warnings{fmt.Sprintf(
+			"spec: confirmed update of FolderTree '%s' removes %d of its %d RoleBindings",
+			newFolderTree.Name, removed, len(previous))}, nil
+	}
+	return nil, field.ErrorList{field.Forbidden(field.NewPath("spec"), fmt.Sprintf(
+		"update of FolderTree '%s' would remove %d of its %d RoleBindings, more than %d%%; "+
+			"annotate it with %s=%s to confirm the change",
+		newFolderTree.Name, removed, len(previous), threshold, ConfirmDestructiveChangeAnnotation, hash))}.ToAggregate()
+}
+
+// specHash returns the hash of a spec confirming a destructive change to it
+func specHash(spec rbacv1alpha1.FolderTreeSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the spec: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:specHashLength], nil
+}
+
+// destructiveChangeThreshold returns the percentage of RoleBindings an update may remove without
+// confirmation, negative if destructive changes are not checked
+func (v *FolderTreeCustomValidator) destructiveChangeThreshold() int {
+	if v.Options.DestructiveChangeThreshold == 0 {
+		return defaultDestructiveChangeThreshold
+	}
+	return v.Options.DestructiveChangeThreshold
+}
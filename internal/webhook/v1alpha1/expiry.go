@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// pathedTemplate is a role binding template with its folder (empty for global templates) and field path
+type pathedTemplate struct {
+	Folder   string
+	Template rbacv1alpha1.RoleBindingTemplate
+	Path     *field.Path
+}
+
+// expiringTemplates returns the role binding templates of a FolderTree that set expiresAt
+func expiringTemplates(folderTree *rbacv1alpha1.FolderTree) []pathedTemplate {
+	var templates []pathedTemplate
+	for j, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		if template.ExpiresAt != nil {
+			templates = append(templates, pathedTemplate{Template: template,
+				Path: field.NewPath("spec", "globalRoleBindingTemplates").Index(j)})
+		}
+	}
+	for i, folder := range folderTree.Spec.Folders {
+		for j, template := range folder.RoleBindingTemplates {
+			if template.ExpiresAt != nil {
+				templates = append(templates, pathedTemplate{Folder: folder.Name, Template: template,
+					Path: field.NewPath("spec", "folders").Index(i).Child("roleBindingTemplates").Index(j)})
+			}
+		}
+	}
+	return templates
+}
+
+// validateExpirations rejects role binding templates that have already expired, unless an update
+// leaves the template's expiresAt unchanged: expired templates grant nothing, and requiring their
+// removal would block unrelated changes. oldFolderTree is nil on create.
+func validateExpirations(oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree, now time.Time) error {
+	unchanged := make(map[string]time.Time)
+	if oldFolderTree != nil {
+		for _, old := range expiringTemplates(oldFolderTree) {
+			unchanged[old.Folder+"/"+old.Template.Name] = old.Template.ExpiresAt.Time
+		}
+	}
+
+	var allErrors field.ErrorList
+	for _, expiring := range expiringTemplates(newFolderTree) {
+		if !rbac.Expired(expiring.Template, now) {
+			continue
+		}
+		if expiresAt, ok := unchanged[expiring.Folder+"/"+expiring.Template.Name]; ok && expiresAt.Equal(expiring.Template.ExpiresAt.Time) {
+			continue
+		}
+		allErrors = append(allErrors, field.Invalid(expiring.Path.Child("expiresAt"),
+			expiring.Template.ExpiresAt.UTC().Format(time.RFC3339),
+			fmt.Sprintf("role binding template '%s' has already expired", expiring.Template.Name)))
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors.ToAggregate()
+	}
+	return nil
+}
+
+// expiredTemplateWarnings warns about role binding templates that have expired and no longer grant access
+func expiredTemplateWarnings(folderTree *rbacv1alpha1.FolderTree, now time.Time) admission.Warnings {
+	var warnings admission.Warnings
+	for _, expiring := range expiringTemplates(folderTree) {
+		if rbac.Expired(expiring.Template, now) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: role binding template '%s' expired at %s and no longer grants access; it can be removed",
+				expiring.Path, expiring.Template.Name, expiring.Template.ExpiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+	return warnings
+}
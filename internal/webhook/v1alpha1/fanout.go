@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+const (
+	// defaultMaxRoleBindings is the maximum RoleBinding fan-out when WebhookOptions.MaxRoleBindings is not set
+	defaultMaxRoleBindings = 10000
+
+	// defaultRoleBindingWarningThreshold is the RoleBinding fan-out above which admission warns when
+	// WebhookOptions.RoleBindingWarningThreshold is not set
+	defaultRoleBindingWarningThreshold = 1000
+
+	// defaultSpecSizeWarningBytes is the JSON size of the spec above which admission warns when
+	// WebhookOptions.SpecSizeWarningBytes is not set. The status and metadata share the rest of the
+	// 1.5MiB etcd object size limit.
+	defaultSpecSizeWarningBytes = 768 * 1024
+)
+
+// desiredRoleBindings returns the RoleBindings the controller creates for a FolderTree from the
+// namespaces listed in its spec, keyed by namespace/name
+func (v *FolderTreeCustomValidator) desiredRoleBindings(folderTree *rbacv1alpha1.FolderTree) (map[string]*rbac.DesiredRoleBinding, error) {
+	builder := &rbac.RoleBindingBuilder{
+		FolderTree:         folderTree,
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		Cache:              v.Options.DesiredStateCache,
+	}
+	desired, err := rbac.CalculateDesiredRoleBindings(folderTree, builder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate the RoleBindings of FolderTree '%s': %w", folderTree.Name, err)
+	}
+	return desired.RoleBindings, nil
+}
+
+// countRoleBindings returns the number of RoleBindings the controller creates for a FolderTree from
+// the namespaces listed in its spec
+func (v *FolderTreeCustomValidator) countRoleBindings(folderTree *rbacv1alpha1.FolderTree) (int, error) {
+	desired, err := v.desiredRoleBindings(folderTree)
+	if err != nil {
+		return 0, err
+	}
+	return len(desired), nil
+}
+
+// validateFanOut limits the total number of RoleBindings a FolderTree produces, the cross product of
+// its templates and the namespaces they apply to, which the per-field limits do not bound. Updates that
+// do not increase the count of a FolderTree already above the limit are allowed, so lowering the limit
+// does not block unrelated changes. oldFolderTree is nil on create.
+func (v *FolderTreeCustomValidator) validateFanOut(oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) (admission.Warnings, error) {
+	count, err := v.countRoleBindings(newFolderTree)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > v.maxRoleBindings() {
+		previous := 0
+		if oldFolderTree != nil {
+			if previous, err = v.countRoleBindings(oldFolderTree); err != nil {
+				return nil, err
+			}
+		}
+		if count > previous {
+			return nil, field.ErrorList{field.Forbidden(field.NewPath("spec"), fmt.Sprintf(
+				"FolderTree '%s' would produce %d RoleBindings, which exceeds the maximum of %d; "+
+					"split it into several FolderTrees or reduce the propagating templates",
+				newFolderTree.Name, count, v.maxRoleBindings()))}.ToAggregate()
+		}
+	}
+
+	if count > v.roleBindingWarningThreshold() {
+		return admission.Warnings{fmt.Sprintf(
+			"spec: FolderTree '%s' produces %d RoleBindings, more than the warning threshold of %d",
+			newFolderTree.Name, count, v.roleBindingWarningThreshold())}, nil
+	}
+	return nil, nil
+}
+
+// specSizeWarnings warns when the spec of a FolderTree alone approaches the etcd object size limit,
+// before the API server starts rejecting writes of the FolderTree, including status updates
+func (v *FolderTreeCustomValidator) specSizeWarnings(folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	threshold := v.Options.SpecSizeWarningBytes
+	if threshold == 0 {
+		threshold = defaultSpecSizeWarningBytes
+	}
+	if threshold < 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(folderTree.Spec)
+	if err != nil {
+		foldertreelog.Error(err, "Failed to measure the spec size", "foldertree", folderTree.Name)
+		return nil
+	}
+	if len(data) > threshold {
+		return admission.Warnings{fmt.Sprintf(
+			"spec: FolderTree '%s' has a spec of %d bytes, more than the warning threshold of %d bytes; "+
+				"FolderTrees are limited to about 1.5MiB including status, consider splitting it into several FolderTrees",
+			folderTree.Name, len(data), threshold)}
+	}
+	return nil
+}
+
+// maxRoleBindings returns the maximum number of RoleBindings a FolderTree may produce
+func (v *FolderTreeCustomValidator) maxRoleBindings() int {
+	if v.Options.MaxRoleBindings <= 0 {
+		return defaultMaxRoleBindings
+	}
+	return v.Options.MaxRoleBindings
+}
+
+// roleBindingWarningThreshold returns the number of RoleBindings above which admission warns
+func (v *FolderTreeCustomValidator) roleBindingWarningThreshold() int {
+	if v.Options.RoleBindingWarningThreshold <= 0 {
+		return defaultRoleBindingWarningThreshold
+	}
+	return v.Options.RoleBindingWarningThreshold
+}
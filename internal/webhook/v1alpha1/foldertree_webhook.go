@@ -19,15 +19,20 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"maps"
+	"slices"
+	"time"
 
+	"golang.org/x/sync/errgroup"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
-	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,17 +40,139 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
-	"kubevirt.io/folders/internal/rbac"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
 )
 
 // nolint:unused
 // log is for logging in this package.
 var foldertreelog = logf.Log.WithName("foldertree-resource")
 
-// SetupFolderTreeWebhookWithManager registers the webhook for FolderTree in the manager.
-func SetupFolderTreeWebhookWithManager(mgr ctrl.Manager) error {
+// WebhookOptions holds the configurable policy settings of the FolderTree webhook.
+// The zero value is a valid configuration.
+type WebhookOptions struct {
+	// DeniedClusterRoles lists ClusterRoles that role binding templates may not reference
+	// unless a FolderPolicyException allows it
+	DeniedClusterRoles []string
+
+	// ClusterRoleAllowlist names a ConfigMap whose ClusterRoleAllowlistKey lists the only ClusterRoles
+	// role binding templates may reference, unless a FolderPolicyException allows others.
+	// An empty name allows all ClusterRoles.
+	ClusterRoleAllowlist types.NamespacedName
+
+	// AllowWildcardSubjects disables the policy rule rejecting wildcard subjects
+	// such as system:authenticated
+	AllowWildcardSubjects bool
+
+	// ExcludedNamespaces may not be assigned to folders of any FolderTree
+	ExcludedNamespaces []string
+
+	// PrivilegeCheckMode selects how the permissions of the requesting user are verified.
+	// Defaults to PrivilegeCheckModeImpersonation.
+	PrivilegeCheckMode PrivilegeCheckMode
+
+	// DryRunLabels are set, in addition to DryRunValidationLabel, on the RoleBindings sent as
+	// dry-run requests in impersonation mode, for admission policies to exempt them by
+	DryRunLabels map[string]string
+
+	// SubjectAccessReviewNamespaces lists namespaces whose RoleBinding operations are checked with
+	// SubjectAccessReviews even in impersonation mode, e.g. because admission policies there reject
+	// the dry-run RoleBindings. '*' matches any characters.
+	SubjectAccessReviewNamespaces []string
+
+	// ImpersonationClientCacheSize is the number of impersonation clients kept across admission
+	// requests, least recently used first out. Defaults to 128.
+	ImpersonationClientCacheSize int
+
+	// DesiredStateCache is shared with the controller, which then reuses the RoleBindings
+	// calculated at admission. Nil calculates them on every request.
+	DesiredStateCache *rbac.DesiredStateCache
+
+	// ValidationWorkers is the number of RoleBinding operations validated concurrently per
+	// admission request. Defaults to 8.
+	ValidationWorkers int
+
+	// MaxTreeDepth is the maximum number of levels of a tree, counting the root as level 1.
+	// Defaults to 10.
+	MaxTreeDepth int
+
+	// LimitsConfigMap names a ConfigMap raising or lowering the maximum numbers of folders, tree
+	// nodes, namespaces and role binding templates of a FolderTree, and listing the groups that may
+	// override them per FolderTree. An empty name, or a missing ConfigMap, applies the default limits.
+	LimitsConfigMap types.NamespacedName
+
+	// MaxRoleBindings is the maximum number of RoleBindings a FolderTree may produce across all its
+	// namespaces. Defaults to 10000.
+	MaxRoleBindings int
+
+	// RoleBindingWarningThreshold is the number of RoleBindings above which admission warns about
+	// the size of a FolderTree. Defaults to 1000.
+	RoleBindingWarningThreshold int
+
+	// SpecSizeWarningBytes is the JSON size of the spec above which admission warns that the
+	// FolderTree approaches the etcd object size limit. Defaults to 768KiB; negative disables the warning.
+	SpecSizeWarningBytes int
+
+	// DestructiveChangeThreshold is the percentage of the RoleBindings of a FolderTree an update may
+	// remove unless it is confirmed with ConfirmDestructiveChangeAnnotation. Defaults to 50; negative
+	// disables the check.
+	DestructiveChangeThreshold int
+
+	// ForeignOwnerKeys lists label or annotation keys marking namespaces as owned by another
+	// tenancy system, e.g. capsule.clastix.io/tenant. Newly added namespaces carrying one are
+	// rejected unless the FolderTree lists them in AllowForeignOwnedNamespacesAnnotation.
+	// Empty disables the check.
+	ForeignOwnerKeys []string
+
+	// PrivilegedUsers lists users, e.g. the ServiceAccounts of GitOps tools, that skip the privilege
+	// escalation check, so they manage FolderTrees without holding the permissions they grant
+	PrivilegedUsers []string
+
+	// PrivilegedGroups lists groups whose members skip the privilege escalation check like PrivilegedUsers
+	PrivilegedGroups []string
+
+	// BreakGlassGroups lists the groups whose members may skip the privilege escalation check by
+	// annotating a FolderTree with BreakGlassAnnotation. Empty disables break-glass.
+	BreakGlassGroups []string
+
+	// AllowNamespaceOverlap admits FolderTrees listing namespaces of other FolderTrees, with a
+	// warning naming the FolderTree that manages them by spec.priority. Folder and tree node names
+	// must still be unique.
+	AllowNamespaceOverlap bool
+
+	// ValidateOpenShiftGroups warns about Group subjects that do not exist as OpenShift
+	// user.openshift.io Groups. Clusters without the OpenShift user API are not checked.
+	ValidateOpenShiftGroups bool
+
+	// SubjectVerifier confirms that User and Group subjects exist in the identity provider, warning
+	// about the unresolved ones. Nil disables the check.
+	SubjectVerifier rbac.SubjectVerifier
+
+	// ClusterSecretNamespace holds the kubeconfig Secrets of the remote clusters FolderTrees may
+	// select with spec.clusters. Users must be allowed to get the Secrets of the clusters a
+	// FolderTree propagates to. Empty disables multi-cluster propagation.
+	ClusterSecretNamespace string
+}
+
+// SetupFolderTreeWebhookWithManager registers the validating and defaulting webhooks for FolderTree in the manager.
+// When other FolderTree versions are in the manager's scheme, the builder also serves the
+// conversion webhook, converting them through the v1alpha1 hub.
+func SetupFolderTreeWebhookWithManager(mgr ctrl.Manager, opts WebhookOptions) error {
+	if err := setupUniquenessIndex(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return fmt.Errorf("failed to index FolderTrees by folder names and namespaces: %v", err)
+	}
+
 	return ctrl.NewWebhookManagedBy(mgr).For(&rbacv1alpha1.FolderTree{}).
-		WithValidator(&FolderTreeCustomValidator{Client: mgr.GetClient()}).
+		WithValidator(&FolderTreeCustomValidator{
+			Client:               mgr.GetClient(),
+			APIReader:            mgr.GetAPIReader(),
+			Options:              opts,
+			Recorder:             mgr.GetEventRecorderFor("foldertree-webhook"),
+			impersonationClients: newImpersonationClientCache(mgr.GetConfig(), mgr.GetScheme(), opts.ImpersonationClientCacheSize),
+			uniquenessIndex:      true,
+		}).
+		WithDefaulter(&FolderTreeCustomDefaulter{Reader: mgr.GetAPIReader()}).
 		Complete()
 }
 
@@ -54,7 +181,14 @@ func SetupFolderTreeWebhookWithManager(mgr ctrl.Manager) error {
 // and cross-resource validation that cannot be enforced by OpenAPI schema alone.
 // NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
 // Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
-// +kubebuilder:webhook:path=/validate-rbac-kubevirt-io-v1alpha1-foldertree,mutating=false,failurePolicy=fail,sideEffects=None,groups=rbac.kubevirt.io,resources=foldertrees,verbs=create;update;delete,versions=v1alpha1,name=foldertree.rbac.kubevirt.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=folderpolicyexceptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=subjectmappings,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:webhook:path=/validate-rbac-kubevirt-io-v1alpha1-foldertree,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=rbac.kubevirt.io,resources=foldertrees,verbs=create;update;delete,versions=v1alpha1,name=foldertree.rbac.kubevirt.io,admissionReviewVersions=v1
 
 // FolderTreeCustomValidator struct is responsible for validating the FolderTree resource
 // when it is created, updated, or deleted. It validates the split structure design where:
@@ -71,7 +205,23 @@ func SetupFolderTreeWebhookWithManager(mgr ctrl.Manager) error {
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 // +kubebuilder:object:generate=false
 type FolderTreeCustomValidator struct {
-	Client client.Client
+	Client  client.Client
+	Options WebhookOptions
+
+	// APIReader reads the ClusterRole allowlist ConfigMap without caching all ConfigMaps; when nil,
+	// Client is used
+	APIReader client.Reader
+
+	// Recorder records break-glass admission requests as Events; when nil, no Events are recorded
+	Recorder record.EventRecorder
+
+	// impersonationClients caches impersonation clients across admission requests; when nil,
+	// a new client is created for every request
+	impersonationClients *impersonationClientCache
+
+	// uniquenessIndex is set when Client is a cache with uniquenessIndexField registered, so
+	// global uniqueness is checked against the FolderTrees sharing a name only
+	uniquenessIndex bool
 }
 
 var _ webhook.CustomValidator = &FolderTreeCustomValidator{}
@@ -84,38 +234,38 @@ func (v *FolderTreeCustomValidator) ValidateCreate(ctx context.Context, obj runt
 	}
 	foldertreelog.Info("Validation for FolderTree upon creation", "name", foldertree.GetName())
 
-	var allWarnings admission.Warnings
+	// Validate the templates as the controller applies them, with spec.defaults filled in
+	foldertree = rbac.WithDefaults(foldertree)
 
-	// Note: We cannot validate unknown fields here because controller-runtime
-	// already unmarshaled the object, dropping unknown fields. This is a
-	// limitation of the CustomValidator interface.
-	// Unknown fields in TreeNode.subfolders are handled by x-kubernetes-preserve-unknown-fields
-	// in the CRD schema, which allows them but they're ignored by the Go struct.
-
-	// Validate the split structure: both TreeNodes (hierarchy) and Folders (data)
-	if err := v.validateNewStructure(ctx, foldertree); err != nil {
-		return nil, err
-	}
+	var allWarnings admission.Warnings
 
-	// Validate business logic
-	if err := v.validateBusinessLogic(ctx, foldertree); err != nil {
-		return nil, err
+	// Unknown fields in schemaless subfolders are dropped when decoding, so they are checked on the raw object
+	unknownFieldWarnings, err := v.validateUnknownFields(ctx)
+	if err != nil {
+		return nil, metrics.RecordRejection("create", metrics.RejectionReasonStructure, validation.Reject(validation.ErrInvalidStructure, err))
 	}
+	allWarnings = append(allWarnings, unknownFieldWarnings...)
 
-	// Check for conflicts with other FolderTrees
-	if err := v.validateGlobalUniqueness(ctx, foldertree); err != nil {
+	// Validate the FolderTree like any change of a FolderTree, unless break-glass skips the privilege escalation check
+	changeWarnings, err := v.validateTreeChange(ctx, treeChange{
+		Operation:          "create",
+		New:                foldertree,
+		ListsValidated:     true,
+		SkipPrivilegeCheck: func() bool { return v.breakGlass(ctx, "create", foldertree) },
+	})
+	if err != nil {
 		return nil, err
 	}
+	allWarnings = append(allWarnings, changeWarnings...)
 
-	// Validate that all namespaces exist (for CREATE, all namespaces are "new")
-	if err := v.validateNamespacesExist(ctx, foldertree, nil); err != nil {
-		return nil, err
-	}
-
-	// Validate RBAC authorization (privilege escalation check)
-	if err := v.validateRBACAuthorization(ctx, foldertree); err != nil {
-		return nil, err
-	}
+	// Report valid but likely mistaken configurations without rejecting them
+	allWarnings = append(allWarnings, v.collectWarnings(foldertree)...)
+	allWarnings = append(allWarnings, v.subjectMappingWarnings(ctx, foldertree)...)
+	allWarnings = append(allWarnings, v.openShiftGroupWarnings(ctx, foldertree)...)
+	allWarnings = append(allWarnings, v.subjectVerifierWarnings(ctx, foldertree)...)
+	allWarnings = append(allWarnings, v.nameConflictWarnings(ctx, foldertree)...)
+	allWarnings = append(allWarnings, v.clusterWarnings(ctx, foldertree)...)
+	allWarnings = append(allWarnings, v.lifecycleWarnings(ctx, "create", foldertree)...)
 
 	return allWarnings, nil
 }
@@ -134,525 +284,432 @@ func (v *FolderTreeCustomValidator) ValidateUpdate(ctx context.Context, oldObj,
 
 	foldertreelog.Info("Validation for FolderTree upon update", "name", newFolderTree.GetName())
 
-	var allWarnings admission.Warnings
+	// Validate the templates as the controller applies them, with spec.defaults filled in
+	oldFolderTree = rbac.WithDefaults(oldFolderTree)
+	newFolderTree = rbac.WithDefaults(newFolderTree)
 
-	// Validate the tree structures and folders
-	if err := v.validateNewStructure(ctx, newFolderTree); err != nil {
-		return nil, err
+	// A rollback is validated against the spec the controller will restore
+	newFolderTree, err := v.rollbackTarget(ctx, oldFolderTree, newFolderTree)
+	if err != nil {
+		return nil, metrics.RecordRejection("update", metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec, err))
 	}
 
-	// Validate business logic
-	if err := v.validateBusinessLogic(ctx, newFolderTree); err != nil {
-		return nil, err
-	}
+	var allWarnings admission.Warnings
 
-	// Check for conflicts with other FolderTrees (excluding this one)
-	if err := v.validateGlobalUniqueness(ctx, newFolderTree); err != nil {
+	// Unknown fields in schemaless subfolders are dropped when decoding, so they are checked on the raw object
+	unknownFieldWarnings, err := v.validateUnknownFields(ctx)
+	if err != nil {
+		return nil, metrics.RecordRejection("update", metrics.RejectionReasonStructure, validation.Reject(validation.ErrInvalidStructure, err))
+	}
+	allWarnings = append(allWarnings, unknownFieldWarnings...)
+
+	// Break-glass only applies to spec changes, so that metadata updates are not recorded as such
+	specChanged := !equality.Semantic.DeepEqual(oldFolderTree.Spec, newFolderTree.Spec)
+	changeWarnings, err := v.validateTreeChange(ctx, treeChange{
+		Operation:          "update",
+		Old:                oldFolderTree,
+		New:                newFolderTree,
+		ListsValidated:     true,
+		SkipPrivilegeCheck: func() bool { return specChanged && v.breakGlass(ctx, "update", newFolderTree) },
+	})
+	if err != nil {
 		return nil, err
 	}
+	allWarnings = append(allWarnings, changeWarnings...)
 
-	// Validate that new namespaces exist (only NEW namespaces must exist)
-	if err := v.validateNamespacesExist(ctx, newFolderTree, oldFolderTree); err != nil {
-		return nil, err
+	// Guard against accidental mass-revocation, e.g. by dropping spec.tree, once the user may make the change
+	destructiveWarnings, err := v.validateDestructiveChange(oldFolderTree, newFolderTree)
+	if err != nil {
+		return nil, metrics.RecordRejection("update", metrics.RejectionReasonDestructiveChange, validation.Reject(validation.ErrDestructiveChange, err))
 	}
+	allWarnings = append(allWarnings, destructiveWarnings...)
 
-	// No need to validate permission references since role binding templates are now inline
-
-	// Validate RBAC authorization (privilege escalation check) - compare FolderTree states
-	if err := v.validateRBACAuthorizationUpdate(ctx, oldFolderTree, newFolderTree); err != nil {
-		return nil, err
-	}
+	// Report valid but likely mistaken configurations without rejecting them
+	allWarnings = append(allWarnings, v.collectWarnings(newFolderTree)...)
+	allWarnings = append(allWarnings, v.subjectMappingWarnings(ctx, newFolderTree)...)
+	allWarnings = append(allWarnings, v.openShiftGroupWarnings(ctx, newFolderTree)...)
+	allWarnings = append(allWarnings, v.subjectVerifierWarnings(ctx, newFolderTree)...)
+	allWarnings = append(allWarnings, v.nameConflictWarnings(ctx, newFolderTree)...)
+	allWarnings = append(allWarnings, v.clusterWarnings(ctx, newFolderTree)...)
+	allWarnings = append(allWarnings, v.lifecycleWarnings(ctx, "update", newFolderTree)...)
 
 	return allWarnings, nil
 }
 
-// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FolderTree.
-func (v *FolderTreeCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	foldertree, ok := obj.(*rbacv1alpha1.FolderTree)
-	if !ok {
-		return nil, fmt.Errorf("expected a FolderTree object but got %T", obj)
-	}
-	foldertreelog.Info("Validation for FolderTree upon deletion", "name", foldertree.GetName())
+// treeChange is a change of a FolderTree: its creation or update, or the change a FolderTreePatch makes to it
+type treeChange struct {
+	// Operation is the admission operation recorded with rejections
+	Operation string
 
-	// Validate RBAC authorization - user must have permission to delete all RoleBindings
-	// that will be removed when this FolderTree is deleted
-	if err := v.validateRBACAuthorizationDelete(ctx, foldertree); err != nil {
-		return nil, err
-	}
+	// Old is the FolderTree before the change, nil on creation, and New the FolderTree after it.
+	// Both have spec.defaults filled in.
+	Old, New *rbacv1alpha1.FolderTree
 
-	return nil, nil
+	// Patch, when set, is the FolderTreePatch making the change. Old and New already have it applied,
+	// so it is not resolved again.
+	Patch *rbacv1alpha1.FolderTreePatch
+
+	// ListsValidated reports whether the API server already rejected duplicate list keys of New,
+	// which it cannot do across a FolderTree and a FolderTreePatch
+	ListsValidated bool
+
+	// SkipPrivilegeCheck, when set, reports whether the privilege escalation check is skipped. It
+	// is only called once all other checks passed.
+	SkipPrivilegeCheck func() bool
 }
 
-// validateNewStructure validates the split structure design by:
-// 1. Validating the TreeNode structure (hierarchy validation)
-// 2. Validating each Folder in the folders array (data validation with inline role binding templates)
-// 3. Ensuring proper structure and field constraints for all types
-func (v *FolderTreeCustomValidator) validateNewStructure(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
-	var allErrors field.ErrorList
+// validateTreeChange runs the checks every change of a FolderTree must pass, whether the FolderTree
+// itself or a FolderTreePatch makes it. The fan-out, policy and privilege checks apply to the trees the
+// controller reconciles towards, with FolderMemberships and FolderTreePatches resolved.
+func (v *FolderTreeCustomValidator) validateTreeChange(ctx context.Context, change treeChange) (admission.Warnings, error) {
+	operation, oldFolderTree, newFolderTree := change.Operation, change.Old, change.New
+	var allWarnings admission.Warnings
 
-	// Validate the tree structure (if it exists)
-	if folderTree.Spec.Tree != nil {
-		treePath := field.NewPath("spec", "tree")
-		if err := v.validateTreeNode(ctx, *folderTree.Spec.Tree, treePath); err != nil {
-			allErrors = append(allErrors, field.InternalError(treePath, err))
-		}
+	// Validate the tree structures and folders
+	if err := v.validateNewStructure(ctx, newFolderTree); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonStructure, validation.Reject(validation.ErrInvalidStructure, err))
 	}
 
-	// Validate each folder
-	for i, folder := range folderTree.Spec.Folders {
-		folderPath := field.NewPath("spec", "folders").Index(i)
-		if err := v.validateFolder(ctx, folder, folderPath); err != nil {
-			allErrors = append(allErrors, field.InternalError(folderPath, err))
-		}
+	// Only privileged users may override the size limits of a FolderTree
+	if err := v.validateLimitOverrides(ctx, oldFolderTree, newFolderTree); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonPolicy, validation.Reject(validation.ErrPolicyViolation, err))
 	}
 
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
+	// Validate business logic
+	opts, err := v.businessLogicOptions(ctx, newFolderTree)
+	if err == nil {
+		opts.APIServerListValidation = change.ListsValidated
+		err = validation.ValidateBusinessLogic(&newFolderTree.Spec, opts)
 	}
-
-	return nil
-}
-
-// validateTreeNode validates a single tree node structure
-//
-//nolint:unparam
-func (v *FolderTreeCustomValidator) validateTreeNode(ctx context.Context, treeNode rbacv1alpha1.TreeNode, fldPath *field.Path) error {
-	var allErrors field.ErrorList
-
-	// Validate name
-	if len(treeNode.Name) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
-	} else if !isValidKubernetesName(treeNode.Name) {
-		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), treeNode.Name, "name must be a valid DNS-1123 label"))
+	if err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec, err))
 	}
-
-	// Recursively validate subfolders
-	for i, subfolder := range treeNode.Subfolders {
-		subPath := fldPath.Child("subfolders").Index(i)
-		if err := v.validateTreeNode(ctx, subfolder, subPath); err != nil {
-			allErrors = append(allErrors, field.InternalError(subPath, err))
-		}
+	if err := validateExpirations(oldFolderTree, newFolderTree, time.Now()); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec, err))
 	}
 
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
+	// The fan-out, policy and privilege checks compare the RoleBindings of the trees the controller reconciles
+	// towards before and after the change, as it may approve or drop FolderMemberships and FolderTreePatches
+	oldDesiredTree, err := v.resolveDesiredTree(ctx, oldFolderTree, change.Patch)
+	if err != nil {
+		return nil, err
+	}
+	newDesiredTree, err := v.resolveDesiredTree(ctx, newFolderTree, change.Patch)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
+	// Limit the total number of RoleBindings the FolderTree produces
+	fanOutWarnings, err := v.validateFanOut(oldDesiredTree, newDesiredTree)
+	if err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonFanOut, validation.Reject(validation.ErrFanOutExceeded, err))
+	}
+	allWarnings = append(allWarnings, fanOutWarnings...)
+	allWarnings = append(allWarnings, v.specSizeWarnings(newFolderTree)...)
 
-// validateFolder validates a single folder data structure
-func (v *FolderTreeCustomValidator) validateFolder(ctx context.Context, folder rbacv1alpha1.Folder, fldPath *field.Path) error {
-	var allErrors field.ErrorList
+	// Validate policy rules, honoring FolderPolicyExceptions
+	if err := v.validatePolicies(ctx, newDesiredTree); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonPolicy, validation.Reject(validation.ErrPolicyViolation, err))
+	}
 
-	// Validate name
-	if len(folder.Name) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
-	} else if !isValidKubernetesName(folder.Name) {
-		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), folder.Name, "name must be a valid DNS-1123 label"))
+	// Check for conflicts with other FolderTrees (excluding this one)
+	overlapWarnings, err := v.validateGlobalUniqueness(ctx, newFolderTree)
+	if err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonConflict, validation.Reject(validation.ErrDuplicateFolder, err))
 	}
+	allWarnings = append(allWarnings, overlapWarnings...)
+	patternWarnings, err := v.validateNamespacePatterns(ctx, newFolderTree)
+	if err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonConflict, validation.Reject(validation.ErrDuplicateNamespace, err))
+	}
+	allWarnings = append(allWarnings, patternWarnings...)
 
-	// Validate role binding templates
-	for i, roleBindingTemplate := range folder.RoleBindingTemplates {
-		roleBindingTemplatePath := fldPath.Child("roleBindingTemplates").Index(i)
-		if err := v.validateRoleBindingTemplate(ctx, roleBindingTemplate, roleBindingTemplatePath); err != nil {
-			allErrors = append(allErrors, field.InternalError(roleBindingTemplatePath, err))
-		}
+	// Validate that new namespaces exist (only NEW namespaces must exist)
+	if err := v.validateNamespacesExist(ctx, newFolderTree, oldFolderTree); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonNamespaceMissing, validation.Reject(validation.ErrNamespaceMissing, err))
 	}
 
-	// Validate namespaces
-	for i, namespace := range folder.Namespaces {
-		if len(namespace) == 0 {
-			allErrors = append(allErrors, field.Invalid(
-				fldPath.Child("namespaces").Index(i), namespace,
-				"namespace name cannot be empty string"))
-		} else if !isValidKubernetesName(namespace) {
-			allErrors = append(allErrors, field.Invalid(
-				fldPath.Child("namespaces").Index(i), namespace,
-				"namespace must be a valid DNS-1123 label"))
-		}
+	// Validate that no new namespace is owned by another tenancy system
+	if err := v.validateForeignOwners(ctx, newFolderTree, oldFolderTree); err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonNamespaceOwned, validation.Reject(validation.ErrNamespaceOwned, err))
 	}
 
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
+	// Validate RBAC authorization (privilege escalation check) - compare FolderTree states
+	if change.SkipPrivilegeCheck == nil || !change.SkipPrivilegeCheck() {
+		if err := v.validateRBACAuthorizationUpdate(ctx, oldDesiredTree, newDesiredTree); err != nil {
+			return nil, metrics.RecordRejection(operation, metrics.RejectionReasonPrivilegeEscalation, validation.Reject(validation.ErrPrivilegeEscalation, err))
+		}
 	}
 
-	return nil
+	return allWarnings, nil
 }
 
-// validateRoleBindingTemplate validates a single role binding template structure
-func (v *FolderTreeCustomValidator) validateRoleBindingTemplate(_ context.Context, roleBindingTemplate rbacv1alpha1.RoleBindingTemplate, fldPath *field.Path) error {
-	var allErrors field.ErrorList
-
-	// Validate name
-	if len(roleBindingTemplate.Name) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
-	} else if !isValidKubernetesName(roleBindingTemplate.Name) {
-		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), roleBindingTemplate.Name, "name must be a valid DNS-1123 label"))
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FolderTree.
+func (v *FolderTreeCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	foldertree, ok := obj.(*rbacv1alpha1.FolderTree)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTree object but got %T", obj)
 	}
+	foldertreelog.Info("Validation for FolderTree upon deletion", "name", foldertree.GetName())
 
-	// Validate subjects (required and must have at least one)
-	if len(roleBindingTemplate.Subjects) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("subjects"), "subjects cannot be empty"))
-	} else {
-		for i, subject := range roleBindingTemplate.Subjects {
-			subjectPath := fldPath.Child("subjects").Index(i)
-
-			// Validate subject kind
-			if len(subject.Kind) == 0 {
-				allErrors = append(allErrors, field.Required(subjectPath.Child("kind"), "kind cannot be empty"))
-			}
-
-			// Validate subject name
-			if len(subject.Name) == 0 {
-				allErrors = append(allErrors, field.Required(subjectPath.Child("name"), "name cannot be empty"))
-			}
-
-			// Validate apiGroup for Group and User kinds
-			if (subject.Kind == "Group" || subject.Kind == "User") && subject.APIGroup != "rbac.authorization.k8s.io" {
-				allErrors = append(allErrors, field.Invalid(subjectPath.Child("apiGroup"), subject.APIGroup, "apiGroup must be 'rbac.authorization.k8s.io' for Group and User kinds"))
-			}
+	// Validate RBAC authorization - user must have permission to delete all RoleBindings
+	// that will be removed when this FolderTree is deleted, unless skipped under break-glass
+	if !v.breakGlass(ctx, "delete", foldertree) {
+		if err := v.validateRBACAuthorizationDelete(ctx, foldertree); err != nil {
+			return nil, metrics.RecordRejection("delete", metrics.RejectionReasonPrivilegeEscalation, validation.Reject(validation.ErrPrivilegeEscalation, err))
 		}
 	}
 
-	// Validate roleRef (required)
-	if len(roleBindingTemplate.RoleRef.Kind) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("roleRef").Child("kind"), "roleRef.kind cannot be empty"))
-	}
-	if len(roleBindingTemplate.RoleRef.Name) == 0 {
-		allErrors = append(allErrors, field.Required(fldPath.Child("roleRef").Child("name"), "roleRef.name cannot be empty"))
-	}
-	if roleBindingTemplate.RoleRef.APIGroup != "rbac.authorization.k8s.io" {
-		allErrors = append(allErrors, field.Invalid(fldPath.Child("roleRef").Child("apiGroup"), roleBindingTemplate.RoleRef.APIGroup, "roleRef.apiGroup must be 'rbac.authorization.k8s.io'"))
+	return nil, nil
+}
+
+// validationOptions returns the options of the offline validation checks
+func (v *FolderTreeCustomValidator) validationOptions() validation.Options {
+	return validation.Options{
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		MaxTreeDepth:       v.Options.MaxTreeDepth,
+		// Duplicate list keys are rejected by the API server before admission
+		APIServerListValidation: true,
+		AllowNamespaceOverlap:   v.Options.AllowNamespaceOverlap,
 	}
+}
 
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
+// validateNewStructure validates the structure of the trees, folders and templates of a FolderTree
+// and the length of the RoleBinding names generated from them
+func (v *FolderTreeCustomValidator) validateNewStructure(_ context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	if err := validation.ValidateStructure(&folderTree.Spec); err != nil {
+		return err
 	}
+	return validation.ValidateRoleBindingNames(folderTree)
+}
 
-	return nil
+// validateRoleBindingTemplate validates a single role binding template structure
+func (v *FolderTreeCustomValidator) validateRoleBindingTemplate(_ context.Context, roleBindingTemplate rbacv1alpha1.RoleBindingTemplate, fldPath *field.Path) error {
+	return validation.ValidateRoleBindingTemplate(roleBindingTemplate, fldPath)
 }
 
-// isValidKubernetesName validates that a name follows DNS-1123 label format
-func isValidKubernetesName(name string) bool {
-	// DNS-1123 label: lowercase alphanumeric characters or '-',
-	// must start and end with alphanumeric character
-	if len(name) == 0 || len(name) > 63 {
-		return false
+// validateBusinessLogic performs additional business logic validation
+func (v *FolderTreeCustomValidator) validateBusinessLogic(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	opts, err := v.businessLogicOptions(ctx, folderTree)
+	if err != nil {
+		return err
 	}
+	return validation.ValidateBusinessLogic(&folderTree.Spec, opts)
+}
 
-	// Regex for DNS-1123 label
-	dnsLabelRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
-	return dnsLabelRegex.MatchString(name)
+// businessLogicOptions returns the validation options with the size limits that apply to a FolderTree
+func (v *FolderTreeCustomValidator) businessLogicOptions(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) (validation.Options, error) {
+	opts := v.validationOptions()
+	config, err := v.loadLimitsConfig(ctx)
+	if err != nil {
+		return opts, err
+	}
+	opts.Limits, err = limitsFor(config, folderTree)
+	return opts, err
 }
 
-// validateBusinessLogic performs additional business logic validation
-func (v *FolderTreeCustomValidator) validateBusinessLogic(_ context.Context, folderTree *rbacv1alpha1.FolderTree) error {
-	var allErrors field.ErrorList
+// collectWarnings returns admission warnings for configurations that are valid but likely
+// mistakes: empty standalone folders and role binding templates that can never produce a
+// RoleBinding because no namespace is in their reach. Hard conflicts are reported by
+// validateBusinessLogic instead.
+func (v *FolderTreeCustomValidator) collectWarnings(folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	var warnings admission.Warnings
 
-	// Validate that at least one namespace is assigned somewhere
-	hasNamespaces := false
-	for _, folder := range folderTree.Spec.Folders {
-		if len(folder.Namespaces) > 0 {
-			hasNamespaces = true
-			break
-		}
+	folderIndexMap := make(map[string]int)
+	for i, folder := range folderTree.Spec.Folders {
+		folderIndexMap[folder.Name] = i
 	}
 
-	if !hasNamespaces {
-		allErrors = append(allErrors, field.Invalid(
-			field.NewPath("spec", "folders"),
-			folderTree.Spec.Folders,
-			"folder tree must contain at least one namespace assignment"))
+	// reachesNamespaces reports whether a folder can receive namespaces, either directly
+	// or, when accepting memberships or patches, through approved FolderMemberships or FolderTreePatches
+	reachesNamespaces := func(folder rbacv1alpha1.Folder) bool {
+		return len(folder.Namespaces) > 0 || folder.AcceptMemberships || len(folder.PatchNamespaces) > 0
 	}
 
-	// Validate unique folder names
-	folderNames := make(map[string]*field.Path)
-	for i, folder := range folderTree.Spec.Folders {
-		folderPath := field.NewPath("spec", "folders").Index(i)
-		if existingPath, exists := folderNames[folder.Name]; exists {
-			allErrors = append(allErrors, field.Duplicate(
-				folderPath.Child("name"),
-				fmt.Sprintf("folder name '%s' already used at %s", folder.Name, existingPath)))
-		} else {
-			folderNames[folder.Name] = folderPath.Child("name")
+	// Folders below a namespace group with namespaces always reach the group's namespaces
+	groupReached := make(map[string]bool)
+	var walkGroups func(node rbacv1alpha1.TreeNode, reached bool)
+	walkGroups = func(node rbacv1alpha1.TreeNode, reached bool) {
+		groupReached[node.Name] = reached
+		if folderIndex, exists := folderIndexMap[node.Name]; exists {
+			folder := folderTree.Spec.Folders[folderIndex]
+			reached = reached || (folder.InheritNamespaces && reachesNamespaces(folder))
+		}
+		for _, subfolder := range node.Subfolders {
+			walkGroups(subfolder, reached)
 		}
 	}
-
-	// Validate unique role binding template names within each folder
-	for i, folder := range folderTree.Spec.Folders {
-		folderPath := field.NewPath("spec", "folders").Index(i)
-		roleBindingTemplateNames := make(map[string]*field.Path)
-		for j, roleBindingTemplate := range folder.RoleBindingTemplates {
-			roleBindingTemplatePath := folderPath.Child("roleBindingTemplates").Index(j)
-			if existingPath, exists := roleBindingTemplateNames[roleBindingTemplate.Name]; exists {
-				allErrors = append(allErrors, field.Duplicate(
-					roleBindingTemplatePath.Child("name"),
-					fmt.Sprintf("role binding template name '%s' already used in folder '%s' at %s", roleBindingTemplate.Name, folder.Name, existingPath)))
-			} else {
-				roleBindingTemplateNames[roleBindingTemplate.Name] = roleBindingTemplatePath.Child("name")
+	roots := folderTree.Spec.Roots()
+	for _, root := range roots {
+		walkGroups(root, false)
+	}
+
+	// warnUnreachableTemplates warns about the templates of a folder that can never apply.
+	// Non-propagating templates only apply to the folder's own namespaces, propagating
+	// templates also apply to the namespaces of the descendants within their propagation depth.
+	// nearest is the distance to the closest descendant with namespaces, or 0 if there is none.
+	warnUnreachableTemplates := func(folder rbacv1alpha1.Folder, nearest int) {
+		folderPath := field.NewPath("spec", "folders").Index(folderIndexMap[folder.Name])
+		for j, template := range folder.RoleBindingTemplates {
+			depth := rbac.PropagationDepth(template)
+			if reachesNamespaces(folder) || (nearest > 0 && nearest <= depth) || groupReached[folder.Name] {
+				continue
 			}
+			reason := "the folder has no namespaces"
+			switch {
+			case depth > 0 && nearest > 0:
+				reason = fmt.Sprintf("no subfolder within its propagateDepth of %d has namespaces", depth)
+			case depth > 0:
+				reason = "neither the folder nor any of its subfolders has namespaces"
+			}
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: role binding template '%s' of folder '%s' will not apply to any namespace because %s",
+				folderPath.Child("roleBindingTemplates").Index(j), template.Name, folder.Name, reason))
 		}
 	}
 
-	// Validate unique namespace assignments
-	namespaceAssignments := make(map[string]*field.Path)
-	for i, folder := range folderTree.Spec.Folders {
-		folderPath := field.NewPath("spec", "folders").Index(i)
-		for j, namespace := range folder.Namespaces {
-			namespacePath := folderPath.Child("namespaces").Index(j)
-			if existingPath, exists := namespaceAssignments[namespace]; exists {
-				allErrors = append(allErrors, field.Duplicate(
-					namespacePath,
-					fmt.Sprintf("namespace '%s' already assigned at %s", namespace, existingPath)))
-			} else {
-				namespaceAssignments[namespace] = namespacePath
+	// Walk the trees bottom-up so each node knows its closest descendant with namespaces.
+	// walk returns the number of levels from the node's parent down to the closest node of
+	// its subtree with namespaces, or 0 if the subtree has none.
+	var walk func(node rbacv1alpha1.TreeNode) int
+	walk = func(node rbacv1alpha1.TreeNode) int {
+		nearest := 0
+		for _, subfolder := range node.Subfolders {
+			if distance := walk(subfolder); distance > 0 && (nearest == 0 || distance < nearest) {
+				nearest = distance
 			}
 		}
-	}
-
-	// Validate unique tree node names within the tree
-	treeNodeNames := make(map[string]*field.Path)
-	if folderTree.Spec.Tree != nil {
-		treePath := field.NewPath("spec", "tree")
-		v.validateUniqueTreeNodeNames(*folderTree.Spec.Tree, treePath, treeNodeNames, &allErrors)
-	}
-
-	// Validate role binding template names don't conflict in inheritance chains
-	v.validateInheritanceConflicts(folderTree, &allErrors)
 
-	// Validate that all tree nodes reference declared folders and all folders are used
-	v.validateFolderReferences(folderTree, &allErrors)
-
-	// Validate reasonable limits
-	totalFolders := len(folderTree.Spec.Folders)
-	totalTreeNodes := 0
-	totalNamespaces := 0
-	totalRoleBindingTemplates := 0
-
-	// Count tree nodes
-	var countTreeNodes func(rbacv1alpha1.TreeNode)
-	countTreeNodes = func(treeNode rbacv1alpha1.TreeNode) {
-		totalTreeNodes++
-		for _, subfolder := range treeNode.Subfolders {
-			countTreeNodes(subfolder)
+		folderIndex, exists := folderIndexMap[node.Name]
+		if exists {
+			folder := folderTree.Spec.Folders[folderIndex]
+			warnUnreachableTemplates(folder, nearest)
+			if reachesNamespaces(folder) {
+				return 1
+			}
 		}
+		// Undeclared folders are rejected by validateFolderReferences
+		if nearest == 0 {
+			return 0
+		}
+		return nearest + 1
 	}
-
-	if folderTree.Spec.Tree != nil {
-		countTreeNodes(*folderTree.Spec.Tree)
-	}
-
-	// Count namespaces and role binding templates
-	for _, folder := range folderTree.Spec.Folders {
-		totalNamespaces += len(folder.Namespaces)
-		totalRoleBindingTemplates += len(folder.RoleBindingTemplates)
-	}
-
-	// Apply reasonable limits
-	if totalFolders > 100 {
-		allErrors = append(allErrors, field.TooMany(
-			field.NewPath("spec", "folders"),
-			totalFolders,
-			100))
-	}
-
-	if totalTreeNodes > 100 {
-		allErrors = append(allErrors, field.TooMany(
-			field.NewPath("spec", "trees"),
-			totalTreeNodes,
-			100))
-	}
-
-	if totalNamespaces > 500 {
-		allErrors = append(allErrors, field.TooMany(
-			field.NewPath("spec", "folders"),
-			totalNamespaces,
-			500))
-	}
-
-	if totalRoleBindingTemplates > 200 {
-		allErrors = append(allErrors, field.TooMany(
-			field.NewPath("spec", "folders"),
-			totalRoleBindingTemplates,
-			200))
-	}
-
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
-	}
-
-	return nil
-}
-
-// validateUniqueTreeNodeNames validates that tree node names are unique within the tree structure
-func (v *FolderTreeCustomValidator) validateUniqueTreeNodeNames(treeNode rbacv1alpha1.TreeNode, fldPath *field.Path,
-	treeNodeNames map[string]*field.Path, allErrors *field.ErrorList) {
-
-	// Check if this tree node name is already used
-	if existingPath, exists := treeNodeNames[treeNode.Name]; exists {
-		*allErrors = append(*allErrors, field.Duplicate(
-			fldPath.Child("name"),
-			fmt.Sprintf("tree node name '%s' already used at %s", treeNode.Name, existingPath)))
-	} else {
-		treeNodeNames[treeNode.Name] = fldPath.Child("name")
-	}
-
-	// Recursively check subfolders
-	for i, subfolder := range treeNode.Subfolders {
-		subPath := fldPath.Child("subfolders").Index(i)
-		v.validateUniqueTreeNodeNames(subfolder, subPath, treeNodeNames, allErrors)
-	}
-}
-
-// validateInheritanceConflicts validates that role binding template names don't conflict
-// in inheritance chains. This prevents the issue where a child folder's template
-// overwrites a parent folder's template with the same name.
-func (v *FolderTreeCustomValidator) validateInheritanceConflicts(folderTree *rbacv1alpha1.FolderTree, allErrors *field.ErrorList) {
-	// Create a map of folder name to folder data for quick lookup
-	folderMap := make(map[string]rbacv1alpha1.Folder)
-	folderIndexMap := make(map[string]int) // Track folder indices for error reporting
-	for i, folder := range folderTree.Spec.Folders {
-		folderMap[folder.Name] = folder
-		folderIndexMap[folder.Name] = i
+	for _, root := range roots {
+		walk(root)
 	}
 
-	// Check the tree for inheritance conflicts (if it exists)
-	if folderTree.Spec.Tree != nil {
-		treePath := field.NewPath("spec", "tree")
-		v.validateTreeInheritanceConflicts(*folderTree.Spec.Tree, treePath, folderMap, folderIndexMap, []string{}, allErrors)
-	}
-}
+	// Blocks and edge filters only have an effect on templates propagated from ancestors
+	var walkBlocks func(node rbacv1alpha1.TreeNode, nodePath *field.Path, inherited []inheritedTemplate)
+	walkBlocks = func(node rbacv1alpha1.TreeNode, nodePath *field.Path, inherited []inheritedTemplate) {
+		warnings = append(warnings, unmatchedEdgeFilterWarnings(node, nodePath, inherited)...)
+		inherited = slices.DeleteFunc(slices.Clone(inherited), func(template inheritedTemplate) bool {
+			return !node.Inherits(template.name)
+		})
 
-// validateTreeInheritanceConflicts recursively validates inheritance conflicts in a tree structure
-//
-//nolint:unparam
-func (v *FolderTreeCustomValidator) validateTreeInheritanceConflicts(
-	treeNode rbacv1alpha1.TreeNode,
-	treePath *field.Path,
-	folderMap map[string]rbacv1alpha1.Folder,
-	folderIndexMap map[string]int,
-	inheritedTemplateNames []string,
-	allErrors *field.ErrorList) {
-
-	// Get folder data for this tree node
-	folder, exists := folderMap[treeNode.Name]
-	var currentTemplateNames []string
-
-	if exists {
-		// Check for conflicts between inherited templates and this folder's templates
-		folderIndex := folderIndexMap[treeNode.Name]
-		folderPath := field.NewPath("spec", "folders").Index(folderIndex)
-
-		for j, roleBindingTemplate := range folder.RoleBindingTemplates {
-			templatePath := folderPath.Child("roleBindingTemplates").Index(j)
-
-			// Check if this template name conflicts with any inherited template
-			for _, inheritedName := range inheritedTemplateNames {
-				if roleBindingTemplate.Name == inheritedName {
-					*allErrors = append(*allErrors, field.Invalid(
-						templatePath.Child("name"),
-						roleBindingTemplate.Name,
-						fmt.Sprintf("role binding template name '%s' conflicts with inherited template from parent folder in tree hierarchy", roleBindingTemplate.Name)))
+		folderIndex, exists := folderIndexMap[node.Name]
+		if exists {
+			folder := folderTree.Spec.Folders[folderIndex]
+			var inheritedNames []string
+			for _, template := range inherited {
+				inheritedNames = append(inheritedNames, template.name)
+			}
+			warnings = append(warnings, unmatchedBlockWarnings(folder, folderIndex, inheritedNames)...)
+			inherited = slices.DeleteFunc(slices.Clone(inherited), func(template inheritedTemplate) bool {
+				return slices.Contains(folder.BlockInherited, template.name)
+			})
+			for _, template := range folder.RoleBindingTemplates {
+				if depth := rbac.PropagationDepth(template); depth > 0 {
+					inherited = append(inherited, inheritedTemplate{name: template.Name, remaining: depth})
 				}
 			}
-
-			currentTemplateNames = append(currentTemplateNames, roleBindingTemplate.Name)
 		}
-
-		// Combine inherited and current template names for child validation
-		allTemplateNames := append(inheritedTemplateNames, currentTemplateNames...)
-
-		// Recursively validate subfolders with accumulated template names
-		for _, subfolder := range treeNode.Subfolders {
-			v.validateTreeInheritanceConflicts(subfolder, treePath, folderMap, folderIndexMap, allTemplateNames, allErrors)
+		// Each level down consumes one level of the remaining propagation depth
+		var descending []inheritedTemplate
+		for _, template := range inherited {
+			if template.remaining > 0 {
+				descending = append(descending, inheritedTemplate{name: template.name, remaining: template.remaining - 1})
+			}
 		}
-	} else {
-		// Tree node exists but no folder data - pass inherited templates to children
-		for _, subfolder := range treeNode.Subfolders {
-			v.validateTreeInheritanceConflicts(subfolder, treePath, folderMap, folderIndexMap, inheritedTemplateNames, allErrors)
+		for i, subfolder := range node.Subfolders {
+			walkBlocks(subfolder, nodePath.Child("subfolders").Index(i), descending)
 		}
 	}
-}
-
-// validateFolderReferences validates that all tree nodes reference declared folders
-// and that all declared folders are used somewhere (either in trees or as standalone)
-func (v *FolderTreeCustomValidator) validateFolderReferences(folderTree *rbacv1alpha1.FolderTree, allErrors *field.ErrorList) {
-	// Create sets for tracking
-	declaredFolders := make(map[string]int)    // folder name -> index in folders array
-	referencedFolders := make(map[string]bool) // folder names referenced in trees
+	if folderTree.Spec.Tree != nil {
+		walkBlocks(*folderTree.Spec.Tree, field.NewPath("spec", "tree"), nil)
+	}
+	for i, root := range folderTree.Spec.Trees {
+		walkBlocks(root, field.NewPath("spec", "trees").Index(i), nil)
+	}
 
-	// Collect all declared folders
+	// Standalone folders only apply their templates to their own namespaces
 	for i, folder := range folderTree.Spec.Folders {
-		declaredFolders[folder.Name] = i
-	}
-
-	// Recursively collect all folder names referenced in trees
-	var collectReferencedFolders func(rbacv1alpha1.TreeNode, *field.Path)
-	collectReferencedFolders = func(treeNode rbacv1alpha1.TreeNode, treePath *field.Path) {
-		// Check if this tree node references a declared folder
-		if _, exists := declaredFolders[treeNode.Name]; !exists {
-			*allErrors = append(*allErrors, field.Invalid(
-				treePath.Child("name"),
-				treeNode.Name,
-				fmt.Sprintf("tree node '%s' references undeclared folder (must be declared in spec.folders)", treeNode.Name)))
-		} else {
-			referencedFolders[treeNode.Name] = true
+		if v.isInAnyTreeHelper(folder.Name, roots) {
+			continue
 		}
-
-		// Recursively check subfolders
-		for i, subfolder := range treeNode.Subfolders {
-			subPath := treePath.Child("subfolders").Index(i)
-			collectReferencedFolders(subfolder, subPath)
+		warnings = append(warnings, unmatchedBlockWarnings(folder, i, nil)...)
+		if len(folder.Namespaces) == 0 && len(folder.RoleBindingTemplates) == 0 && !folder.AcceptMemberships && len(folder.PatchNamespaces) == 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: folder '%s' is declared but not used in any tree and has no namespaces or role binding templates (possible configuration error)",
+				field.NewPath("spec", "folders").Index(i), folder.Name))
+			continue
 		}
+		warnUnreachableTemplates(folder, 0)
 	}
 
-	// Check the tree (if it exists)
-	if folderTree.Spec.Tree != nil {
-		treePath := field.NewPath("spec", "tree")
-		collectReferencedFolders(*folderTree.Spec.Tree, treePath)
-	}
+	warnings = append(warnings, expiredTemplateWarnings(folderTree, time.Now())...)
+
+	return warnings
+}
 
-	// Check that all declared folders are used (either in trees or as standalone)
-	for folderName, folderIndex := range declaredFolders {
-		isUsedInTree := referencedFolders[folderName]
-		isStandalone := !v.isInAnyTreeHelper(folderName, folderTree.Spec.Tree)
+// inheritedTemplate is the name of a template a folder inherits and the number of levels
+// below the folder that inherit it too
+type inheritedTemplate struct {
+	name      string
+	remaining int
+}
 
-		// A folder is valid if it's either used in a tree OR it's standalone (not in any tree)
-		// If it's not in any tree, it's considered a standalone folder which is valid
-		if !isUsedInTree && !isStandalone {
-			// This case shouldn't happen due to the logic above, but kept for completeness
-			continue
+// unmatchedBlockWarnings warns about blockInherited entries of a folder that do not name
+// any of the templates it inherits from its ancestors, which are likely typos
+func unmatchedBlockWarnings(folder rbacv1alpha1.Folder, folderIndex int, inherited []string) admission.Warnings {
+	var warnings admission.Warnings
+	for j, name := range folder.BlockInherited {
+		if !slices.Contains(inherited, name) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: folder '%s' blocks template '%s', which it does not inherit from any ancestor folder",
+				field.NewPath("spec", "folders").Index(folderIndex).Child("blockInherited").Index(j), folder.Name, name))
 		}
+	}
+	return warnings
+}
 
-		// If a folder is declared but never referenced in trees and has no namespaces,
-		// it might be a configuration error (though technically valid as an empty standalone folder)
-		if !isUsedInTree && isStandalone {
-			folder := folderTree.Spec.Folders[folderIndex]
-			if len(folder.Namespaces) == 0 && len(folder.RoleBindingTemplates) == 0 {
-				// This is just a warning-level issue - empty standalone folders are technically valid
-				// but might indicate a configuration mistake
-				*allErrors = append(*allErrors, field.Invalid(
-					field.NewPath("spec", "folders").Index(folderIndex).Child("name"),
-					folderName,
-					"folder is declared but not used in any tree and has no namespaces or role binding templates (possible configuration error)"))
+// unmatchedEdgeFilterWarnings warns about inheritOnly and exclude entries of a tree node that do
+// not name any of the templates it inherits from its ancestors, which are likely typos
+func unmatchedEdgeFilterWarnings(node rbacv1alpha1.TreeNode, nodePath *field.Path, inherited []inheritedTemplate) admission.Warnings {
+	var warnings admission.Warnings
+	for _, list := range []struct {
+		name  string
+		names []string
+	}{{"inheritOnly", node.InheritOnly}, {"exclude", node.Exclude}} {
+		for j, name := range list.names {
+			if !slices.ContainsFunc(inherited, func(template inheritedTemplate) bool { return template.name == name }) {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s: tree node '%s' filters template '%s', which it does not inherit from any ancestor folder",
+					nodePath.Child(list.name).Index(j), node.Name, name))
 			}
 		}
 	}
+	return warnings
 }
 
 // isInAnyTreeHelper is a helper function for validateFolderReferences
 // (separate from the main isInTree to avoid confusion with the diff analyzer)
-func (v *FolderTreeCustomValidator) isInAnyTreeHelper(folderName string, tree *rbacv1alpha1.TreeNode) bool {
-	return v.isInTreeHelper(folderName, tree)
-}
-
-// isInTreeHelper is a helper function for validateFolderReferences
-func (v *FolderTreeCustomValidator) isInTreeHelper(folderName string, tree *rbacv1alpha1.TreeNode) bool {
-	if tree == nil {
-		return false
+func (v *FolderTreeCustomValidator) isInAnyTreeHelper(folderName string, roots []rbacv1alpha1.TreeNode) bool {
+	for _, root := range roots {
+		if v.isInTreeNodeHelper(folderName, root) {
+			return true
+		}
 	}
-	return v.isInTreeNodeHelper(folderName, *tree)
+	return false
 }
 
 // isInTreeNodeHelper recursively checks if a folder name appears in a tree node
@@ -668,90 +725,15 @@ func (v *FolderTreeCustomValidator) isInTreeNodeHelper(folderName string, node r
 	return false
 }
 
-// validateGlobalUniqueness checks that folder names and namespaces don't conflict with other FolderTrees
-func (v *FolderTreeCustomValidator) validateGlobalUniqueness(ctx context.Context, newTree *rbacv1alpha1.FolderTree) error {
-	// Get all existing FolderTrees
-	var folderTreeList rbacv1alpha1.FolderTreeList
-	if err := v.Client.List(ctx, &folderTreeList); err != nil {
-		return fmt.Errorf("failed to list existing FolderTrees: %v", err)
-	}
-
-	// Collect folder names and namespaces from the new tree
-	newFolderNames := make(map[string]bool)
-	newNamespaces := make(map[string]bool)
-	newTreeNodeNames := make(map[string]bool)
-
-	// Collect from folders
-	for _, folder := range newTree.Spec.Folders {
-		newFolderNames[folder.Name] = true
-		for _, ns := range folder.Namespaces {
-			newNamespaces[ns] = true
-		}
-	}
-
-	// Collect from tree nodes
-	var collectFromTreeNode func(rbacv1alpha1.TreeNode)
-	collectFromTreeNode = func(treeNode rbacv1alpha1.TreeNode) {
-		newTreeNodeNames[treeNode.Name] = true
-		for _, subfolder := range treeNode.Subfolders {
-			collectFromTreeNode(subfolder)
-		}
-	}
-
-	if newTree.Spec.Tree != nil {
-		collectFromTreeNode(*newTree.Spec.Tree)
-	}
-
-	// Check against existing trees
-	var allErrors field.ErrorList
-	for _, existingTree := range folderTreeList.Items {
-		// Skip self when updating
-		if existingTree.Name == newTree.Name {
-			continue
-		}
-
-		// Check existing folders for conflicts
-		for _, folder := range existingTree.Spec.Folders {
-			// Check for folder name conflicts
-			if newFolderNames[folder.Name] {
-				allErrors = append(allErrors, field.Duplicate(
-					field.NewPath("spec", "folders"),
-					fmt.Sprintf("folder name '%s' already exists in FolderTree '%s'", folder.Name, existingTree.Name)))
-			}
-
-			// Check for namespace conflicts
-			for _, ns := range folder.Namespaces {
-				if newNamespaces[ns] {
-					allErrors = append(allErrors, field.Duplicate(
-						field.NewPath("spec", "folders"),
-						fmt.Sprintf("namespace '%s' is already assigned in FolderTree '%s'", ns, existingTree.Name)))
-				}
-			}
-		}
-
-		// Check existing tree nodes for conflicts
-		var checkExistingTreeNode func(rbacv1alpha1.TreeNode)
-		checkExistingTreeNode = func(treeNode rbacv1alpha1.TreeNode) {
-			if newTreeNodeNames[treeNode.Name] {
-				allErrors = append(allErrors, field.Duplicate(
-					field.NewPath("spec", "trees"),
-					fmt.Sprintf("tree node name '%s' already exists in FolderTree '%s'", treeNode.Name, existingTree.Name)))
-			}
-			for _, subfolder := range treeNode.Subfolders {
-				checkExistingTreeNode(subfolder)
-			}
-		}
-
-		if existingTree.Spec.Tree != nil {
-			checkExistingTreeNode(*existingTree.Spec.Tree)
-		}
-	}
-
-	if len(allErrors) > 0 {
-		return allErrors.ToAggregate()
+// validateGlobalUniqueness checks that folder names and namespaces don't conflict with other FolderTrees.
+// With AllowNamespaceOverlap, shared namespaces are reported as warnings naming the FolderTree managing them.
+func (v *FolderTreeCustomValidator) validateGlobalUniqueness(ctx context.Context, newTree *rbacv1alpha1.FolderTree) (admission.Warnings, error) {
+	// Get the existing FolderTrees that may conflict
+	existingTrees, err := v.uniquenessCandidates(ctx, newTree)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	return validation.ValidateUniqueness(newTree, existingTrees, v.validationOptions())
 }
 
 // validateNamespacesExist validates that new namespaces being added to the FolderTree exist.
@@ -798,14 +780,6 @@ func (v *FolderTreeCustomValidator) validateNamespacesExist(ctx context.Context,
 	return nil
 }
 
-// validateRBACAuthorization checks that the user has permissions to perform the specific operations
-// that would be required to synchronize the FolderTree. This prevents privilege escalation and
-// validates deletion permissions when namespaces or rolebindingtemplates are removed.
-func (v *FolderTreeCustomValidator) validateRBACAuthorization(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
-	// For CREATE operations, validate against empty old state
-	return v.validateRBACAuthorizationUpdate(ctx, nil, folderTree)
-}
-
 // validateRBACAuthorizationUpdate performs privilege escalation validation for UPDATE operations
 // by comparing old and new FolderTree states to determine actual changes being made.
 // This is the correct approach - webhook should compare FolderTree states, not cluster state.
@@ -819,19 +793,50 @@ func (v *FolderTreeCustomValidator) validateRBACAuthorizationUpdate(ctx context.
 	}
 
 	// Skip RBAC authorization check for status-only updates
-	if req.SubResource == "status" {
+	if isStatusOnlyUpdate(req, oldFolderTree, newFolderTree) {
 		foldertreelog.Info("Skipping RBAC authorization check for status subresource update")
 		return nil
 	}
 
+	// Privileged users, e.g. GitOps tools, are trusted with any change
+	if v.privilegedRequester(req) {
+		return nil
+	}
+
+	// Mapped subjects are part of the RoleBindings the user is authorized for
+	subjectMappings, err := rbac.ListSubjectMappings(ctx, v.Client)
+	if err != nil {
+		return err
+	}
+
+	// So are the ServiceAccounts selected by serviceAccountSelectors
+	serviceAccounts, err := rbac.ListServiceAccounts(ctx, v.Client, newFolderTree, rbac.IndexFolderTreeNamespaces(newFolderTree))
+	if err != nil {
+		return err
+	}
+
 	// Use webhook diff analyzer to compare FolderTree states (not cluster state)
 	builder := &rbac.RoleBindingBuilder{
-		FolderTree: newFolderTree,
-		Scheme:     nil, // Don't set owner reference for webhook validation
+		FolderTree:         newFolderTree,
+		Scheme:             nil, // Don't set owner reference for webhook validation
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		SubjectMappings:    subjectMappings,
+		ServiceAccounts:    serviceAccounts,
+		Cache:              v.Options.DesiredStateCache,
+	}
+
+	// Namespaces created later join through namespacePatterns without the webhook seeing them
+	if err := v.validatePatternRoleBindings(oldFolderTree, newFolderTree, builder); err != nil {
+		return fmt.Errorf("privilege escalation prevented: %v", err)
 	}
 
 	webhookDiffAnalyzer := rbac.NewWebhookDiffAnalyzer(oldFolderTree, newFolderTree, builder)
 
+	// Also check the changes from what the controller actually applied when it is known
+	if oldFolderTree != nil && len(oldFolderTree.Status.AppliedBindings) > 0 {
+		webhookDiffAnalyzer.AppliedBindings = oldFolderTree.Status.AppliedBindings
+	}
+
 	// Analyze what operations would be performed between FolderTree states
 	operations, err := webhookDiffAnalyzer.AnalyzeFolderTreeDiff()
 	if err != nil {
@@ -839,23 +844,47 @@ func (v *FolderTreeCustomValidator) validateRBACAuthorizationUpdate(ctx context.
 	}
 
 	// Validate user has permission for these specific operations
-	if err := v.validateOperationsWithImpersonation(ctx, operations, req.UserInfo, oldFolderTree); err != nil {
+	defer metrics.ObservePrivilegeCheck(string(v.privilegeCheckMode()), time.Now())
+	if err := v.validateOperationsAsUser(ctx, operations, req.UserInfo, oldFolderTree); err != nil {
+		return fmt.Errorf("privilege escalation prevented: %v", err)
+	}
+	if err := v.validateTemplatedObjectAuthorization(ctx, req.UserInfo, oldFolderTree, newFolderTree); err != nil {
+		return fmt.Errorf("privilege escalation prevented: %v", err)
+	}
+	if err := v.validateClusterAccess(ctx, req.UserInfo, oldFolderTree, newFolderTree); err != nil {
 		return fmt.Errorf("privilege escalation prevented: %v", err)
 	}
 
 	return nil
 }
 
-// validateOperationsWithImpersonation performs privilege escalation validation
-// by impersonating the user and attempting to perform the required operations with dry-run.
+// isStatusOnlyUpdate reports whether the request is an update of the status subresource that leaves the spec untouched.
+// The API server already drops spec changes sent to the status subresource, but the spec is compared as well so that
+// a misrouted or malformed request can never use the status path to skip the privilege escalation check.
+func isStatusOnlyUpdate(req admission.Request, oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) bool {
+	if req.SubResource != "status" || oldFolderTree == nil || newFolderTree == nil {
+		return false
+	}
+
+	if !equality.Semantic.DeepEqual(oldFolderTree.Spec, newFolderTree.Spec) {
+		foldertreelog.Info("Status subresource request changes the spec, performing full RBAC authorization check",
+			"name", newFolderTree.Name, "user", req.UserInfo.Username)
+		return false
+	}
+
+	return true
+}
+
+// validateOperationsAsUser performs privilege escalation validation by checking each required
+// operation on behalf of the user, see PrivilegeCheckMode.
 // Handles DELETE+CREATE pairs specially to avoid dry-run conflicts with immutable roleRef.
 // Handles deleted namespaces by skipping validation for namespaces that already existed in the tree
 // but requiring new namespaces to exist and have proper permissions.
-func (v *FolderTreeCustomValidator) validateOperationsWithImpersonation(ctx context.Context, operations []rbac.RoleBindingOperation, userInfo authenticationv1.UserInfo, oldFolderTree *rbacv1alpha1.FolderTree) error {
-	// Create an impersonation client for the requesting user
-	impersonationClient, err := v.createImpersonationClient(userInfo)
+func (v *FolderTreeCustomValidator) validateOperationsAsUser(ctx context.Context, operations []rbac.RoleBindingOperation, userInfo authenticationv1.UserInfo, oldFolderTree *rbacv1alpha1.FolderTree) error {
+	// Create an authorizer checking operations on behalf of the requesting user
+	authorizer, err := v.newRoleBindingAuthorizer(userInfo)
 	if err != nil {
-		return fmt.Errorf("failed to create impersonation client: %v", err)
+		return err
 	}
 
 	// Collect namespaces from old state to determine which are newly added
@@ -864,14 +893,28 @@ func (v *FolderTreeCustomValidator) validateOperationsWithImpersonation(ctx cont
 	// Group operations by namespace/name to detect DELETE+CREATE pairs
 	operationGroups := v.groupOperationsByTarget(operations)
 
-	// Validate each group of operations
-	for target, ops := range operationGroups {
-		if err := v.validateOperationGroup(ctx, impersonationClient, ops, oldNamespaces); err != nil {
-			return fmt.Errorf("failed to validate operations for %s: %v", target, err)
-		}
+	// Validate the groups of operations concurrently, stopping at the first failure
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(v.validationWorkers())
+	for _, target := range slices.Sorted(maps.Keys(operationGroups)) {
+		ops := operationGroups[target]
+		group.Go(func() error {
+			if err := v.validateOperationGroup(groupCtx, authorizer, ops, oldNamespaces); err != nil {
+				return fmt.Errorf("failed to validate operations for %s: %v", target, err)
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return group.Wait()
+}
+
+// validationWorkers returns the number of operations validated concurrently
+func (v *FolderTreeCustomValidator) validationWorkers() int {
+	if v.Options.ValidationWorkers <= 0 {
+		return defaultValidationWorkers
+	}
+	return v.Options.ValidationWorkers
 }
 
 // collectNamespaces gathers all namespaces from a FolderTree spec.
@@ -891,27 +934,13 @@ func (v *FolderTreeCustomValidator) collectNamespaces(folderTree *rbacv1alpha1.F
 	return namespaces
 }
 
-// createImpersonationClient creates a Kubernetes client that impersonates the specified user
+// createImpersonationClient returns a Kubernetes client that impersonates the specified user,
+// reusing a cached client when the webhook was set up with a cache
 func (v *FolderTreeCustomValidator) createImpersonationClient(userInfo authenticationv1.UserInfo) (client.Client, error) {
-	// Get the current REST config
-	config := ctrl.GetConfigOrDie()
-
-	// Set impersonation
-	config.Impersonate = rest.ImpersonationConfig{
-		UserName: userInfo.Username,
-		Groups:   userInfo.Groups,
-		UID:      userInfo.UID,
-	}
-
-	// Create a new client with impersonation
-	impersonationClient, err := client.New(config, client.Options{
-		Scheme: v.Client.Scheme(),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create impersonation client: %v", err)
+	if v.impersonationClients != nil {
+		return v.impersonationClients.get(userInfo)
 	}
-
-	return impersonationClient, nil
+	return newImpersonationClient(ctrl.GetConfigOrDie(), v.Client.Scheme(), userInfo)
 }
 
 // groupOperationsByTarget groups operations by their target RoleBinding (namespace/name)
@@ -939,7 +968,7 @@ func (v *FolderTreeCustomValidator) groupOperationsByTarget(operations []rbac.Ro
 // validateOperationGroup validates a group of operations for the same target RoleBinding
 // Handles DELETE+CREATE pairs specially to avoid dry-run conflicts with immutable roleRef.
 // Uses oldNamespaces to determine if a namespace is newly added or already existed in the tree.
-func (v *FolderTreeCustomValidator) validateOperationGroup(ctx context.Context, impersonationClient client.Client, operations []rbac.RoleBindingOperation, oldNamespaces map[string]bool) error {
+func (v *FolderTreeCustomValidator) validateOperationGroup(ctx context.Context, authorizer roleBindingAuthorizer, operations []rbac.RoleBindingOperation, oldNamespaces map[string]bool) error {
 	// Check if this is a DELETE+CREATE pair (roleRef change scenario)
 	if len(operations) == 2 {
 		var deleteOp, createOp *rbac.RoleBindingOperation
@@ -955,14 +984,14 @@ func (v *FolderTreeCustomValidator) validateOperationGroup(ctx context.Context,
 		if deleteOp != nil && createOp != nil {
 			// This is a DELETE+CREATE pair - handle specially
 			wasInOldTree := oldNamespaces[deleteOp.Namespace]
-			return v.validateDeleteCreatePair(ctx, impersonationClient, *deleteOp, *createOp, wasInOldTree)
+			return v.validateDeleteCreatePair(ctx, authorizer, *deleteOp, *createOp, wasInOldTree)
 		}
 	}
 
 	// Not a DELETE+CREATE pair - validate operations individually
 	for _, operation := range operations {
 		wasInOldTree := oldNamespaces[operation.Namespace]
-		if err := v.validateSingleOperation(ctx, impersonationClient, operation, wasInOldTree); err != nil {
+		if err := v.validateSingleOperation(ctx, authorizer, operation, wasInOldTree); err != nil {
 			return fmt.Errorf("failed to validate %s: %v", operation.String(), err)
 		}
 	}
@@ -973,9 +1002,9 @@ func (v *FolderTreeCustomValidator) validateOperationGroup(ctx context.Context,
 // validateDeleteCreatePair validates DELETE+CREATE operations for the same RoleBinding
 // Uses temporary unique name for CREATE validation to avoid dry-run conflicts.
 // Handles deleted namespaces by checking existence before validation.
-func (v *FolderTreeCustomValidator) validateDeleteCreatePair(ctx context.Context, impersonationClient client.Client, deleteOp, createOp rbac.RoleBindingOperation, wasInOldTree bool) error {
+func (v *FolderTreeCustomValidator) validateDeleteCreatePair(ctx context.Context, authorizer roleBindingAuthorizer, deleteOp, createOp rbac.RoleBindingOperation, wasInOldTree bool) error {
 	// Validate DELETE of existing RoleBinding
-	if err := v.validateDeleteOperation(ctx, impersonationClient, deleteOp); err != nil {
+	if err := v.validateDeleteOperation(ctx, authorizer, deleteOp); err != nil {
 		return fmt.Errorf("failed to validate DELETE operation: %v", err)
 	}
 
@@ -987,24 +1016,24 @@ func (v *FolderTreeCustomValidator) validateDeleteCreatePair(ctx context.Context
 	originalName := createOp.DesiredRoleBinding.Name
 	tempCreateOp.DesiredRoleBinding.Name = rbac.GenerateRandomRoleBindingName(originalName, "validation")
 
-	if err := v.validateCreateOperation(ctx, impersonationClient, tempCreateOp, wasInOldTree); err != nil {
+	if err := v.validateCreateOperation(ctx, authorizer, tempCreateOp, wasInOldTree); err != nil {
 		return fmt.Errorf("failed to validate CREATE operation: %v", err)
 	}
 
 	return nil
 }
 
-// validateSingleOperation validates a single RoleBinding operation with impersonation + dry-run.
+// validateSingleOperation validates a single RoleBinding operation on behalf of the user.
 // Checks namespace existence and handles deleted namespaces appropriately based on whether
 // the namespace was in the old tree or is newly added.
-func (v *FolderTreeCustomValidator) validateSingleOperation(ctx context.Context, impersonationClient client.Client, operation rbac.RoleBindingOperation, wasInOldTree bool) error {
+func (v *FolderTreeCustomValidator) validateSingleOperation(ctx context.Context, authorizer roleBindingAuthorizer, operation rbac.RoleBindingOperation, wasInOldTree bool) error {
 	switch operation.Type {
 	case rbac.OperationCreate:
-		return v.validateCreateOperation(ctx, impersonationClient, operation, wasInOldTree)
+		return v.validateCreateOperation(ctx, authorizer, operation, wasInOldTree)
 	case rbac.OperationUpdate:
-		return v.validateUpdateOperation(ctx, impersonationClient, operation)
+		return v.validateUpdateOperation(ctx, authorizer, operation)
 	case rbac.OperationDelete:
-		return v.validateDeleteOperation(ctx, impersonationClient, operation)
+		return v.validateDeleteOperation(ctx, authorizer, operation)
 	default:
 		return fmt.Errorf("unknown operation type: %s", operation.Type)
 	}
@@ -1013,7 +1042,7 @@ func (v *FolderTreeCustomValidator) validateSingleOperation(ctx context.Context,
 // validateCreateOperation validates that the user can create the RoleBinding.
 // For NEW namespaces (not in old tree), validates that the namespace exists and user has permissions.
 // For EXISTING namespaces (in old tree), skips validation if namespace was deleted externally.
-func (v *FolderTreeCustomValidator) validateCreateOperation(ctx context.Context, impersonationClient client.Client, operation rbac.RoleBindingOperation, wasInOldTree bool) error {
+func (v *FolderTreeCustomValidator) validateCreateOperation(ctx context.Context, authorizer roleBindingAuthorizer, operation rbac.RoleBindingOperation, wasInOldTree bool) error {
 	// Check if namespace exists
 	ns := &corev1.Namespace{}
 	err := v.Client.Get(ctx, types.NamespacedName{Name: operation.Namespace}, ns)
@@ -1040,17 +1069,12 @@ func (v *FolderTreeCustomValidator) validateCreateOperation(ctx context.Context,
 	testRoleBinding := operation.DesiredRoleBinding.DeepCopy()
 	testRoleBinding.Name = rbac.GenerateRandomRoleBindingName(testRoleBinding.Name, operation.RoleBindingTemplate.Name)
 
-	// Attempt to create with dry-run using impersonation
-	if err := impersonationClient.Create(ctx, testRoleBinding, client.DryRunAll); err != nil {
-		return fmt.Errorf("dry-run creation failed (user lacks required permissions): %v", err)
-	}
-
-	return nil
+	return authorizer.authorizeCreate(ctx, testRoleBinding)
 }
 
 // validateUpdateOperation validates that the user can update the RoleBinding.
 // Skips validation if the namespace was deleted externally.
-func (v *FolderTreeCustomValidator) validateUpdateOperation(ctx context.Context, impersonationClient client.Client, operation rbac.RoleBindingOperation) error {
+func (v *FolderTreeCustomValidator) validateUpdateOperation(ctx context.Context, authorizer roleBindingAuthorizer, operation rbac.RoleBindingOperation) error {
 	// Check if namespace still exists
 	ns := &corev1.Namespace{}
 	err := v.Client.Get(ctx, types.NamespacedName{Name: operation.Namespace}, ns)
@@ -1073,16 +1097,11 @@ func (v *FolderTreeCustomValidator) validateUpdateOperation(ctx context.Context,
 	testRoleBinding.RoleRef = operation.DesiredRoleBinding.RoleRef
 	testRoleBinding.Labels = operation.DesiredRoleBinding.Labels
 
-	// Attempt to update with dry-run using impersonation
-	if err := impersonationClient.Update(ctx, testRoleBinding, client.DryRunAll); err != nil {
-		return fmt.Errorf("dry-run update failed (user lacks required permissions): %v", err)
-	}
-
-	return nil
+	return authorizer.authorizeUpdate(ctx, testRoleBinding)
 }
 
 // validateRBACAuthorizationDelete performs privilege escalation validation for DELETE operations
-// by calculating all RoleBindings that would be deleted and validating user permissions for each.
+// by listing all RoleBindings that would be deleted and validating user permissions for each.
 func (v *FolderTreeCustomValidator) validateRBACAuthorizationDelete(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
 	// Get the user info from the admission request
 	req, err := admission.RequestFromContext(ctx)
@@ -1092,48 +1111,87 @@ func (v *FolderTreeCustomValidator) validateRBACAuthorizationDelete(ctx context.
 		return nil
 	}
 
-	// Calculate all RoleBindings that would be deleted when this FolderTree is removed
-	builder := &rbac.RoleBindingBuilder{
-		FolderTree: folderTree,
-		Scheme:     v.Client.Scheme(),
+	// Privileged users, e.g. GitOps tools, are trusted with any change
+	if v.privilegedRequester(req) {
+		return nil
 	}
 
-	desiredState, err := rbac.CalculateDesiredRoleBindings(folderTree, builder)
+	// Collect the RoleBindings that would be deleted when this FolderTree is removed
+	operations, err := v.collectDeleteOperations(ctx, folderTree)
 	if err != nil {
-		return fmt.Errorf("failed to calculate RoleBindings for deletion validation: %v", err)
+		return err
 	}
 
-	// Create impersonation client
-	impersonationClient, err := v.createImpersonationClient(req.UserInfo)
+	// Create an authorizer checking operations on behalf of the requesting user
+	defer metrics.ObservePrivilegeCheck(string(v.privilegeCheckMode()), time.Now())
+	authorizer, err := v.newRoleBindingAuthorizer(req.UserInfo)
 	if err != nil {
-		return fmt.Errorf("failed to create impersonation client: %v", err)
+		return err
 	}
 
 	// Validate that the user can delete each RoleBinding that would be removed
-	for _, desiredRoleBinding := range desiredState.RoleBindings {
-		operation := rbac.RoleBindingOperation{
-			Type:                rbac.OperationDelete,
-			Namespace:           desiredRoleBinding.Namespace,
-			RoleBindingTemplate: desiredRoleBinding.RoleBindingTemplate,
-			ExistingRoleBinding: desiredRoleBinding.RoleBinding, // The RoleBinding that would be deleted
-		}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(v.validationWorkers())
+	for _, operation := range operations {
+		group.Go(func() error {
+			if err := authorizer.authorizeDelete(groupCtx, operation.ExistingRoleBinding); err != nil {
+				return fmt.Errorf("privilege escalation prevented: failed to validate DELETE RoleBinding '%s' in namespace '%s' for template '%s': %v",
+					operation.ExistingRoleBinding.Name,
+					operation.Namespace,
+					operation.TemplateName(),
+					err)
+			}
+			return nil
+		})
+	}
 
-		if err := v.validateDeleteOperation(ctx, impersonationClient, operation); err != nil {
-			return fmt.Errorf("privilege escalation prevented: failed to validate DELETE RoleBinding '%s' in namespace '%s' for template '%s': %v",
-				desiredRoleBinding.RoleBinding.Name,
-				desiredRoleBinding.Namespace,
-				desiredRoleBinding.RoleBindingTemplate.Name,
-				err)
-		}
+	if err := group.Wait(); err != nil {
+		return err
 	}
 
+	// NetworkPolicies and ResourceQuotas are deleted together with the FolderTree as well
+	if err := v.validateTemplatedObjectAuthorization(ctx, req.UserInfo, folderTree, nil); err != nil {
+		return fmt.Errorf("privilege escalation prevented: %v", err)
+	}
 	return nil
 }
 
+// collectDeleteOperations returns a DELETE operation for every RoleBinding removed together with the FolderTree.
+// The RoleBindings are listed by the FolderTree's label rather than calculated from the spec, which diverges
+// from the cluster when namespaces are missing, propagation changed or a reconcile is pending. Listing bypasses
+// the cache when possible, so that RoleBindings deleted a moment ago are not checked.
+func (v *FolderTreeCustomValidator) collectDeleteOperations(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]rbac.RoleBindingOperation, error) {
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := reader.List(ctx, roleBindingList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list RoleBindings for deletion validation: %v", err)
+	}
+
+	operations := make([]rbac.RoleBindingOperation, 0, len(roleBindingList.Items))
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		// Already being deleted, e.g. together with its namespace
+		if !roleBinding.DeletionTimestamp.IsZero() {
+			continue
+		}
+		operations = append(operations, rbac.RoleBindingOperation{
+			Type:                rbac.OperationDelete,
+			Namespace:           roleBinding.Namespace,
+			ExistingRoleBinding: roleBinding,
+		})
+	}
+	return operations, nil
+}
+
 // validateDeleteOperation validates that the user can delete the RoleBinding.
 // Skips validation if the namespace or RoleBinding was already deleted.
 // This is critical for allowing FolderTree deletion when namespaces have been removed.
-func (v *FolderTreeCustomValidator) validateDeleteOperation(ctx context.Context, impersonationClient client.Client, operation rbac.RoleBindingOperation) error {
+func (v *FolderTreeCustomValidator) validateDeleteOperation(ctx context.Context, authorizer roleBindingAuthorizer, operation rbac.RoleBindingOperation) error {
 	// Check if namespace still exists
 	ns := &corev1.Namespace{}
 	err := v.Client.Get(ctx, types.NamespacedName{Name: operation.Namespace}, ns)
@@ -1170,9 +1228,8 @@ func (v *FolderTreeCustomValidator) validateDeleteOperation(ctx context.Context,
 	}
 
 	// Both namespace and RoleBinding exist - validate delete permission
-	// Attempt to delete with dry-run using impersonation
-	if err := impersonationClient.Delete(ctx, existingRB, client.DryRunAll); err != nil {
-		return fmt.Errorf("dry-run deletion failed (user lacks required permissions): %v", err)
+	if err := authorizer.authorizeDelete(ctx, existingRB); err != nil {
+		return err
 	}
 
 	return nil
@@ -18,15 +18,43 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
-	"kubevirt.io/folders/internal/rbac"
+	rbacv1alpha2 "kubevirt.io/folders/api/v1alpha2"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
 )
 
 // createTestNamespace creates a simple Namespace object for testing
@@ -38,6 +66,22 @@ func createTestNamespace(name string) *corev1.Namespace {
 	}
 }
 
+// int32Ptr returns a pointer to the given int32 value
+func int32Ptr(i int32) *int32 { return &i }
+
+// unresolvedVerifier is a subject verifier that cannot resolve the subjects of the given names
+type unresolvedVerifier map[string]bool
+
+func (v unresolvedVerifier) Unresolved(_ context.Context, subjects []rbacv1.Subject) ([]rbacv1.Subject, error) {
+	var unresolved []rbacv1.Subject
+	for _, subject := range subjects {
+		if v[subject.Name] {
+			unresolved = append(unresolved, subject)
+		}
+	}
+	return unresolved, nil
+}
+
 var _ = Describe("FolderTree Webhook", func() {
 	var (
 		ctx       context.Context
@@ -199,7 +243,7 @@ var _ = Describe("FolderTree Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
-		It("should reject duplicate role binding template names within a folder", func() {
+		It("should leave duplicate role binding template names within a folder to the API server", func() {
 			obj.Spec = rbacv1alpha1.FolderTreeSpec{
 				Folders: []rbacv1alpha1.Folder{
 					{
@@ -241,9 +285,10 @@ var _ = Describe("FolderTree Webhook", func() {
 				},
 			}
 
-			warnings, err := validator.ValidateCreate(ctx, obj)
-			Expect(err).To(HaveOccurred())
-			Expect(warnings).To(BeEmpty())
+			// The CRD declares roleBindingTemplates a list-map keyed by name; only the CLI checks it
+			Expect(validator.validateBusinessLogic(ctx, obj)).To(Succeed())
+			Expect(validation.ValidateFolderTreeSpec(&obj.Spec, validation.Options{})).To(
+				MatchError(ContainSubstring("role binding template name 'duplicate-name' already used")))
 		})
 
 		It("should validate tree structure with inheritance", func() {
@@ -274,6 +319,7 @@ var _ = Describe("FolderTree Webhook", func() {
 									Kind:     "ClusterRole",
 									Name:     "admin",
 								},
+								Propagate: &[]bool{true}[0],
 							},
 						},
 					},
@@ -340,7 +386,7 @@ var _ = Describe("FolderTree Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
-		It("should reject duplicate folder names", func() {
+		It("should leave duplicate folder names to the API server", func() {
 			obj.Spec = rbacv1alpha1.FolderTreeSpec{
 				Folders: []rbacv1alpha1.Folder{
 					{
@@ -388,9 +434,10 @@ var _ = Describe("FolderTree Webhook", func() {
 				},
 			}
 
-			warnings, err := validator.ValidateCreate(ctx, obj)
-			Expect(err).To(HaveOccurred())
-			Expect(warnings).To(BeEmpty())
+			// The CRD declares folders a list-map keyed by name; only the CLI checks it
+			Expect(validator.validateBusinessLogic(ctx, obj)).To(Succeed())
+			Expect(validation.ValidateFolderTreeSpec(&obj.Spec, validation.Options{})).To(
+				MatchError(ContainSubstring("folder name 'duplicate-folder' already used")))
 		})
 
 		It("should reject duplicate namespace assignments", func() {
@@ -969,6 +1016,7 @@ var _ = Describe("FolderTree Webhook", func() {
 									Kind:     "ClusterRole",
 									Name:     "view",
 								},
+								Propagate: &[]bool{true}[0],
 							},
 						},
 					},
@@ -1082,10 +1130,11 @@ var _ = Describe("FolderTree Webhook", func() {
 			}
 
 			warnings, err := validator.ValidateCreate(ctx, obj)
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("not used in any tree and has no namespaces or role binding templates"))
-			Expect(err.Error()).To(ContainSubstring("empty-standalone"))
-			Expect(warnings).To(BeEmpty())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("spec.folders[1]"))
+			Expect(warnings[0]).To(ContainSubstring("not used in any tree and has no namespaces or role binding templates"))
+			Expect(warnings[0]).To(ContainSubstring("empty-standalone"))
 		})
 
 		It("should accept valid standalone folders", func() {
@@ -1172,6 +1221,7 @@ var _ = Describe("FolderTree Webhook", func() {
 									Kind:     "ClusterRole",
 									Name:     "view",
 								},
+								Propagate: &[]bool{true}[0],
 							},
 						},
 						// No namespaces - this is valid for inheritance-only folders
@@ -1754,10 +1804,2970 @@ var _ = Describe("FolderTree Webhook", func() {
 			}
 
 			// ValidateDelete should succeed even though namespace doesn't exist
-			// The validateDeleteOperation() method checks namespace existence and skips if not found
+			// Only RoleBindings that exist are checked, and none exist in a deleted namespace
 			warnings, err := validator.ValidateDelete(ctx, obj)
 			Expect(err).NotTo(HaveOccurred(), "Should allow DELETE even when namespace was deleted")
 			Expect(warnings).To(BeEmpty())
 		})
 	})
+
+	Context("Foreign Owner Validation", func() {
+		var ownerValidator FolderTreeCustomValidator
+
+		newTree := func(namespaces ...string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "owner-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{{Name: "owner-folder", Namespaces: namespaces}},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			ownerValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(
+						createTestNamespace("plain-ns"),
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+							Name:   "tenant-ns",
+							Labels: map[string]string{"capsule.clastix.io/tenant": "oil"},
+						}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+							Name:        "project-ns",
+							Annotations: map[string]string{"projects.example.com/owner": "team-gas"},
+						}},
+					).
+					Build(),
+				Options: WebhookOptions{ForeignOwnerKeys: []string{"capsule.clastix.io/tenant", "projects.example.com/owner"}},
+			}
+		})
+
+		It("should reject new namespaces owned by another tenancy system", func() {
+			Expect(ownerValidator.validateForeignOwners(ctx, newTree("plain-ns", "missing-ns"), nil)).To(Succeed())
+
+			err := ownerValidator.validateForeignOwners(ctx, newTree("plain-ns", "tenant-ns", "project-ns"), nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].namespaces[1]: Forbidden: namespace 'tenant-ns' is owned by another tenancy system (capsule.clastix.io/tenant=oil)"))
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].namespaces[2]: Forbidden: namespace 'project-ns' is owned by another tenancy system (projects.example.com/owner=team-gas)"))
+		})
+
+		It("should allow namespaces listed in the override annotation or already in the FolderTree", func() {
+			folderTree := newTree("tenant-ns", "project-ns")
+			folderTree.Annotations = map[string]string{AllowForeignOwnedNamespacesAnnotation: "tenant-ns, project-ns"}
+			Expect(ownerValidator.validateForeignOwners(ctx, folderTree, nil)).To(Succeed())
+
+			Expect(ownerValidator.validateForeignOwners(ctx, newTree("tenant-ns"), newTree("tenant-ns"))).To(Succeed())
+		})
+
+		It("should not check namespaces without configured keys", func() {
+			ownerValidator.Options.ForeignOwnerKeys = nil
+			Expect(ownerValidator.validateForeignOwners(ctx, newTree("tenant-ns"), nil)).To(Succeed())
+		})
+	})
+
+	Context("Rollout Strategy Validation", func() {
+		It("should accept a rollout strategy with a wave size limit", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:       "test-folder",
+						Namespaces: []string{"test-ns"},
+					},
+				},
+				RolloutStrategy: &rbacv1alpha1.RolloutStrategy{
+					MaxPercentPerWave: int32Ptr(25),
+					MinWaveInterval:   &metav1.Duration{Duration: 30 * time.Second},
+				},
+			}
+
+			err := validator.validateNewStructure(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a rollout strategy without any wave size limit", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:       "test-folder",
+						Namespaces: []string{"test-ns"},
+					},
+				},
+				RolloutStrategy: &rbacv1alpha1.RolloutStrategy{
+					MinWaveInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			}
+
+			err := validator.validateNewStructure(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("maxNamespacesPerWave or maxPercentPerWave"))
+		})
+
+		It("should reject out-of-range rollout strategy values", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:       "test-folder",
+						Namespaces: []string{"test-ns"},
+					},
+				},
+				RolloutStrategy: &rbacv1alpha1.RolloutStrategy{
+					MaxNamespacesPerWave: int32Ptr(0),
+					MaxPercentPerWave:    int32Ptr(150),
+				},
+			}
+
+			err := validator.validateNewStructure(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("maxNamespacesPerWave"))
+			Expect(err.Error()).To(ContainSubstring("maxPercentPerWave"))
+		})
+	})
+
+	Context("Policy Rules and FolderPolicyExceptions", func() {
+		newPolicyTree := func(name string, subject rbacv1.Subject, roleName string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "policy-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name:     "policy-template",
+									Subjects: []rbacv1.Subject{subject},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     roleName,
+									},
+								},
+							},
+							Namespaces: []string{"test-ns"},
+						},
+					},
+				},
+			}
+		}
+
+		wildcardSubject := rbacv1.Subject{
+			Kind:     "Group",
+			Name:     "system:authenticated",
+			APIGroup: "rbac.authorization.k8s.io",
+		}
+		regularSubject := rbacv1.Subject{
+			Kind:     "Group",
+			Name:     "developers",
+			APIGroup: "rbac.authorization.k8s.io",
+		}
+
+		It("should reject wildcard subjects by default", func() {
+			tree := newPolicyTree("policy-wildcard-tree", wildcardSubject, "view")
+
+			err := validator.validatePolicies(ctx, tree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("WildcardSubject"))
+			Expect(err.Error()).To(ContainSubstring("system:authenticated"))
+		})
+
+		It("should reject wildcard subjects in template overrides", func() {
+			tree := newPolicyTree("policy-wildcard-override-tree", regularSubject, "view")
+			tree.Spec.Folders[0].NamespaceOverrides = []rbacv1alpha1.NamespaceOverride{{
+				Name: tree.Spec.Folders[0].Namespaces[0],
+				TemplateOverrides: map[string]rbacv1alpha1.TemplateOverride{
+					"policy-template": {Subjects: []rbacv1.Subject{wildcardSubject}},
+				},
+			}}
+
+			err := validator.validatePolicies(ctx, tree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].namespaces[0].templateOverrides[policy-template].subjects[0].name"))
+			Expect(err.Error()).To(ContainSubstring("WildcardSubject"))
+		})
+
+		It("should reject wildcard subjects bound through subjectRefs", func() {
+			mapping := &rbacv1alpha1.SubjectMapping{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy-everyone"},
+				Spec:       rbacv1alpha1.SubjectMappingSpec{Subjects: []rbacv1.Subject{regularSubject, wildcardSubject}},
+			}
+			Expect(k8sClient.Create(ctx, mapping)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, mapping) })
+			tree := newPolicyTree("policy-wildcard-mapping-tree", regularSubject, "view")
+			tree.Spec.Folders[0].RoleBindingTemplates[0].SubjectRefs = []string{"policy-everyone"}
+
+			err := validator.validatePolicies(ctx, tree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].roleBindingTemplates[0].subjectRefs[0]"))
+			Expect(err.Error()).To(ContainSubstring("subject 'system:authenticated' of SubjectMapping 'policy-everyone'"))
+		})
+
+		It("should allow wildcard subjects when the rule is disabled", func() {
+			validator.Options.AllowWildcardSubjects = true
+			tree := newPolicyTree("policy-wildcard-allowed-tree", wildcardSubject, "view")
+
+			Expect(validator.validatePolicies(ctx, tree)).To(Succeed())
+		})
+
+		It("should reject denied ClusterRoles", func() {
+			validator.Options.DeniedClusterRoles = []string{"cluster-admin"}
+			tree := newPolicyTree("policy-denied-role-tree", regularSubject, "cluster-admin")
+
+			err := validator.validatePolicies(ctx, tree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("DeniedClusterRole"))
+			Expect(err.Error()).To(ContainSubstring("cluster-admin"))
+		})
+
+		Context("ClusterRole allowlist", func() {
+			allowlistKey := types.NamespacedName{Namespace: "test-ns", Name: "cluster-role-allowlist"}
+
+			BeforeEach(func() {
+				allowlist := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: allowlistKey.Name, Namespace: allowlistKey.Namespace},
+					Data:       map[string]string{ClusterRoleAllowlistKey: "view, edit\nfolders.example.com/*\n"},
+				}
+				Expect(k8sClient.Create(ctx, allowlist)).To(Succeed())
+				DeferCleanup(func() { _ = k8sClient.Delete(ctx, allowlist) })
+				validator.Options.ClusterRoleAllowlist = allowlistKey
+			})
+
+			It("should allow listed and wildcard-matched ClusterRoles", func() {
+				Expect(validator.validatePolicies(ctx, newPolicyTree("allowlist-view-tree", regularSubject, "view"))).To(Succeed())
+				Expect(validator.validatePolicies(ctx, newPolicyTree("allowlist-prefix-tree", regularSubject, "folders.example.com/operator"))).To(Succeed())
+			})
+
+			It("should reject other ClusterRoles naming the template and the allowlist", func() {
+				err := validator.validatePolicies(ctx, newPolicyTree("allowlist-admin-tree", regularSubject, "cluster-admin"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("ClusterRoleNotAllowed"))
+				Expect(err.Error()).To(ContainSubstring("template 'policy-template' references ClusterRole 'cluster-admin'"))
+				Expect(err.Error()).To(ContainSubstring("ConfigMap test-ns/cluster-role-allowlist"))
+				Expect(err.Error()).To(ContainSubstring("spec.folders[0].roleBindingTemplates[0].roleRef.name"))
+			})
+
+			It("should not restrict Roles", func() {
+				tree := newPolicyTree("allowlist-role-tree", regularSubject, "local-role")
+				tree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Kind = "Role"
+				Expect(validator.validatePolicies(ctx, tree)).To(Succeed())
+			})
+
+			It("should reject when the allowlist ConfigMap is missing", func() {
+				validator.Options.ClusterRoleAllowlist = types.NamespacedName{Namespace: "test-ns", Name: "missing-allowlist"}
+				err := validator.validatePolicies(ctx, newPolicyTree("allowlist-missing-tree", regularSubject, "view"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("ConfigMap test-ns/missing-allowlist"))
+			})
+
+			It("should match wildcards anywhere in an entry", func() {
+				Expect(matchesWildcard("view", "view")).To(BeTrue())
+				Expect(matchesWildcard("view", "viewer")).To(BeFalse())
+				Expect(matchesWildcard("*", "anything")).To(BeTrue())
+				Expect(matchesWildcard("folders.example.com/*", "folders.example.com/a/b")).To(BeTrue())
+				Expect(matchesWildcard("folders.example.com/*", "other.example.com/a")).To(BeFalse())
+				Expect(matchesWildcard("team-*-view", "team-a-view")).To(BeTrue())
+				Expect(matchesWildcard("team-*-view", "team-a-edit")).To(BeFalse())
+				Expect(matchesWildcard("a*b*c", "abc")).To(BeTrue())
+				Expect(matchesWildcard("a*a", "a")).To(BeFalse())
+			})
+		})
+
+		It("should honor an unexpired FolderPolicyException", func() {
+			tree := newPolicyTree("policy-excepted-tree", wildcardSubject, "view")
+			exception := &rbacv1alpha1.FolderPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy-excepted-tree-wildcard"},
+				Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+					TreeName:      "policy-excepted-tree",
+					FolderName:    "policy-folder",
+					Rule:          rbacv1alpha1.PolicyRuleWildcardSubject,
+					Justification: "OPS-1: public read access",
+					ExpiresAt:     metav1.NewTime(time.Now().Add(time.Hour)),
+				},
+			}
+			Expect(k8sClient.Create(ctx, exception)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, exception) })
+
+			Eventually(func() error {
+				return validator.validatePolicies(ctx, tree)
+			}).Should(Succeed())
+		})
+
+		It("should ignore expired or non-matching exceptions", func() {
+			violation := policyViolation{
+				Rule:     rbacv1alpha1.PolicyRuleWildcardSubject,
+				Folder:   "policy-folder",
+				Template: "policy-template",
+			}
+			now := time.Now()
+			exceptions := []rbacv1alpha1.FolderPolicyException{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "expired"},
+					Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+						TreeName:  "tree",
+						Rule:      rbacv1alpha1.PolicyRuleWildcardSubject,
+						ExpiresAt: metav1.NewTime(now.Add(-time.Minute)),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-template"},
+					Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+						TreeName:     "tree",
+						TemplateName: "other",
+						Rule:         rbacv1alpha1.PolicyRuleWildcardSubject,
+						ExpiresAt:    metav1.NewTime(now.Add(time.Hour)),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-rule"},
+					Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+						TreeName:  "tree",
+						Rule:      rbacv1alpha1.PolicyRuleDeniedClusterRole,
+						ExpiresAt: metav1.NewTime(now.Add(time.Hour)),
+					},
+				},
+			}
+			Expect(findPolicyException(exceptions, "tree", violation, now)).To(BeNil())
+
+			exceptions = append(exceptions, rbacv1alpha1.FolderPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "whole-tree"},
+				Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+					TreeName:  "tree",
+					Rule:      rbacv1alpha1.PolicyRuleWildcardSubject,
+					ExpiresAt: metav1.NewTime(now.Add(time.Hour)),
+				},
+			})
+			exception := findPolicyException(exceptions, "tree", violation, now)
+			Expect(exception).NotTo(BeNil())
+			Expect(exception.Name).To(Equal("whole-tree"))
+		})
+	})
+
+	Context("Subject Template Validation", func() {
+		newTemplate := func(subjectName, subjectNamespace string) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name: "templated",
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:      "ServiceAccount",
+						Name:      subjectName,
+						Namespace: subjectNamespace,
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+			}
+		}
+
+		It("should accept supported template variables", func() {
+			template := newTemplate("{{ .folder.name }}-deployer", "{{ .namespace }}")
+			Expect(validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))).To(Succeed())
+		})
+
+		It("should reject unknown variables", func() {
+			template := newTemplate("{{ .folder.owner }}-deployer", "default")
+			err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid subject template"))
+		})
+
+		It("should reject malformed templates", func() {
+			template := newTemplate("deployer", "{{ .namespace")
+			err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("template.subjects[0].namespace"))
+		})
+	})
+
+	Context("Global Role Binding Templates", func() {
+		newTemplate := func(name, role string) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name: name,
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "Group",
+						Name:     name,
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     role,
+				},
+			}
+		}
+
+		It("should reject folder templates that reuse a global template name", func() {
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "global-conflict-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{newTemplate("auditors", "view")},
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name:                 "global-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{newTemplate("auditors", "edit")},
+							Namespaces:           []string{"test-ns"},
+						},
+					},
+				},
+			}
+
+			err := validator.validateBusinessLogic(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("conflicts with global role binding template"))
+		})
+
+		It("should leave duplicate global template names to the API server", func() {
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "global-duplicate-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+						newTemplate("auditors", "view"),
+						newTemplate("auditors", "edit"),
+					},
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "global-folder", Namespaces: []string{"test-ns"}},
+					},
+				},
+			}
+
+			Expect(validator.validateBusinessLogic(ctx, folderTree)).To(Succeed())
+			Expect(validation.ValidateFolderTreeSpec(&folderTree.Spec, validation.Options{})).To(
+				MatchError(ContainSubstring("global role binding template name 'auditors' already used")))
+		})
+
+		It("should validate the structure of global templates", func() {
+			invalid := newTemplate("auditors", "view")
+			invalid.RoleRef.APIGroup = "example.com"
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "global-invalid-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{invalid},
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "global-folder", Namespaces: []string{"test-ns"}},
+					},
+				},
+			}
+
+			err := validator.validateNewStructure(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.globalRoleBindingTemplates[0]"))
+		})
+
+		It("should apply policy rules to global templates", func() {
+			validator.Options.DeniedClusterRoles = []string{"cluster-admin"}
+			folderTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "global-policy-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{newTemplate("admins", "cluster-admin")},
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "global-folder", Namespaces: []string{"test-ns"}},
+					},
+				},
+			}
+
+			err := validator.validatePolicies(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.globalRoleBindingTemplates[0].roleRef.name"))
+		})
+	})
+
+	Context("Status Subresource Tampering", func() {
+		newStatusTree := func() *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "status-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name:       "status-folder",
+							Namespaces: []string{"test-ns"},
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "viewers",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "viewers",
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     "view",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+		statusRequest := func(subResource string) admission.Request {
+			return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation:   admissionv1.Update,
+				SubResource: subResource,
+			}}
+		}
+
+		It("should only skip the authorization check for status updates that leave the spec untouched", func() {
+			oldTree := newStatusTree()
+			newTree := oldTree.DeepCopy()
+			newTree.Status.ProcessedGeneration = 42
+
+			Expect(isStatusOnlyUpdate(statusRequest("status"), oldTree, newTree)).To(BeTrue())
+
+			By("rejecting the skip when the spec changes")
+			newTree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "cluster-admin"
+			Expect(isStatusOnlyUpdate(statusRequest("status"), oldTree, newTree)).To(BeFalse())
+		})
+
+		It("should not skip the authorization check for other or malformed subresources", func() {
+			oldTree := newStatusTree()
+			newTree := oldTree.DeepCopy()
+
+			for _, subResource := range []string{"", "Status", "status/", "status ", "scale"} {
+				Expect(isStatusOnlyUpdate(statusRequest(subResource), oldTree, newTree)).To(BeFalse(), "subresource %q", subResource)
+			}
+			Expect(isStatusOnlyUpdate(statusRequest("status"), nil, newTree)).To(BeFalse())
+		})
+
+		It("should only check DELETE of the managed RoleBindings that exist", func() {
+			folderTree := newStatusTree()
+			folderTree.Status.AppliedBindings = map[string]string{
+				"other-ns/foldertree-status-tree-viewers": "ClusterRole/view/0123456789abcdef",
+			}
+			managedBinding := func(namespace, tree string) *rbacv1.RoleBinding {
+				return &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foldertree-status-tree-viewers",
+						Namespace: namespace,
+						Labels:    map[string]string{"foldertree.rbac.kubevirt.io/tree": tree},
+					},
+					RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}
+			}
+			listValidator := FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(managedBinding("removed-ns", "status-tree"), managedBinding("test-ns", "other-tree")).
+					Build(),
+			}
+
+			// Only the existing RoleBinding of the tree is checked, not those derived from its spec or status
+			operations, err := listValidator.collectDeleteOperations(ctx, folderTree)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(rbac.OperationDelete))
+			Expect(operations[0].Namespace).To(Equal("removed-ns"))
+			Expect(operations[0].TemplateName()).To(BeEmpty())
+		})
+	})
+
+	Context("Subject Namespace Mode Validation", func() {
+		newTemplate := func(mode rbacv1alpha1.SubjectNamespaceMode, subjects ...rbacv1.Subject) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:     "deployers",
+				Subjects: subjects,
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "edit",
+				},
+				SubjectNamespaceMode: mode,
+			}
+		}
+		serviceAccount := func(namespace string) rbacv1.Subject {
+			return rbacv1.Subject{Kind: "ServiceAccount", Name: "deployer", Namespace: namespace}
+		}
+		group := rbacv1.Subject{Kind: "Group", Name: "deployers", APIGroup: "rbac.authorization.k8s.io"}
+
+		It("should accept ServiceAccounts without namespace in Target mode", func() {
+			template := newTemplate(rbacv1alpha1.SubjectNamespaceModeTarget, serviceAccount(""), group)
+			Expect(validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))).To(Succeed())
+		})
+
+		It("should reject ServiceAccounts with a namespace in Target mode", func() {
+			template := newTemplate(rbacv1alpha1.SubjectNamespaceModeTarget, serviceAccount("ci"))
+			err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("template.subjects[0].namespace"))
+		})
+
+		It("should require a ServiceAccount subject in Target mode", func() {
+			template := newTemplate(rbacv1alpha1.SubjectNamespaceModeTarget, group)
+			err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires at least one ServiceAccount subject"))
+		})
+
+		It("should require a ServiceAccount namespace in Fixed mode", func() {
+			for _, mode := range []rbacv1alpha1.SubjectNamespaceMode{"", rbacv1alpha1.SubjectNamespaceModeFixed} {
+				template := newTemplate(mode, serviceAccount(""))
+				err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("namespace is required for ServiceAccount subjects"))
+
+				template = newTemplate(mode, serviceAccount("ci"))
+				Expect(validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))).To(Succeed())
+			}
+		})
+
+		It("should reject unknown modes", func() {
+			template := newTemplate("Source", serviceAccount("ci"))
+			err := validator.validateRoleBindingTemplate(ctx, template, field.NewPath("template"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("template.subjectNamespaceMode"))
+		})
+	})
+
+	Context("Excluded Namespaces", func() {
+		newTree := func(namespaces ...string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "excluded-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "excluded-folder", Namespaces: namespaces},
+					},
+				},
+			}
+		}
+
+		It("should reject namespaces listed in spec.excludedNamespaces", func() {
+			folderTree := newTree("test-ns", "kube-system")
+			folderTree.Spec.ExcludedNamespaces = []string{"kube-system"}
+
+			err := validator.validateBusinessLogic(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].namespaces[1]"))
+			Expect(err.Error()).To(ContainSubstring("listed in spec.excludedNamespaces"))
+		})
+
+		It("should reject namespaces excluded by the controller configuration", func() {
+			validator.Options.ExcludedNamespaces = []string{"kube-public"}
+
+			err := validator.validateBusinessLogic(ctx, newTree("kube-public"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("excluded from FolderTrees by the controller configuration"))
+
+			Expect(validator.validateBusinessLogic(ctx, newTree("test-ns"))).To(Succeed())
+		})
+	})
+
+	Context("Size Limits", func() {
+		limitsKey := types.NamespacedName{Namespace: "test-ns", Name: "foldertree-limits"}
+		var limitsConfigMap *corev1.ConfigMap
+
+		newTree := func(namespaces ...string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "limits-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{{Name: "limits-folder", Namespaces: namespaces}},
+				},
+			}
+		}
+		requestBy := func(groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: "jane", Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			limitsConfigMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: limitsKey.Name, Namespace: limitsKey.Namespace},
+				Data:       map[string]string{"maxNamespaces": "1", LimitOverrideGroupsKey: "platform-admins"},
+			}
+			Expect(k8sClient.Create(ctx, limitsConfigMap)).To(Succeed())
+			DeferCleanup(func() { _ = k8sClient.Delete(ctx, limitsConfigMap) })
+			validator.Options.LimitsConfigMap = limitsKey
+		})
+
+		It("should apply the limits of the ConfigMap and the override annotations", func() {
+			Expect(validator.validateBusinessLogic(ctx, newTree("ns-a"))).To(Succeed())
+			err := validator.validateBusinessLogic(ctx, newTree("ns-a", "ns-b"))
+			Expect(err).To(MatchError(ContainSubstring("must have at most 1 items")))
+
+			overridden := newTree("ns-a", "ns-b")
+			overridden.Annotations = map[string]string{LimitOverrideAnnotationPrefix + "maxNamespaces": "2"}
+			Expect(validator.validateBusinessLogic(ctx, overridden)).To(Succeed())
+
+			overridden.Annotations[LimitOverrideAnnotationPrefix+"maxColors"] = "2"
+			Expect(validator.validateBusinessLogic(ctx, overridden)).To(MatchError(ContainSubstring(`unknown limit "maxColors"`)))
+
+			By("rejecting invalid limits in the ConfigMap")
+			limitsConfigMap.Data["maxFolders"] = "many"
+			Expect(k8sClient.Update(ctx, limitsConfigMap)).To(Succeed())
+			Expect(validator.validateBusinessLogic(ctx, newTree("ns-a"))).To(MatchError(ContainSubstring("maxFolders must be a positive integer")))
+
+			By("applying the default limits without a ConfigMap")
+			validator.Options.LimitsConfigMap = types.NamespacedName{Namespace: "test-ns", Name: "missing-limits"}
+			Expect(validator.validateBusinessLogic(ctx, newTree("ns-a", "ns-b"))).To(Succeed())
+		})
+
+		It("should only let members of the override groups change override annotations", func() {
+			oldTree := newTree("ns-a")
+			newTree := oldTree.DeepCopy()
+			newTree.Annotations = map[string]string{LimitOverrideAnnotationPrefix + "maxNamespaces": "10"}
+
+			err := validator.validateLimitOverrides(requestBy("developers"), oldTree, newTree)
+			Expect(err).To(MatchError(ContainSubstring("only members of the limit override groups")))
+			Expect(validator.validateLimitOverrides(requestBy("platform-admins"), oldTree, newTree)).To(Succeed())
+
+			By("allowing updates that keep the override annotations")
+			Expect(validator.validateLimitOverrides(requestBy("developers"), newTree, newTree.DeepCopy())).To(Succeed())
+		})
+	})
+
+	Context("Multiple Trees", func() {
+		newTree := func() *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "multi-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Tree: &rbacv1alpha1.TreeNode{
+						Name:       "engineering",
+						Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}},
+					},
+					Trees: []rbacv1alpha1.TreeNode{
+						{Name: "finance", Subfolders: []rbacv1alpha1.TreeNode{{Name: "billing"}}},
+					},
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "engineering"},
+						{Name: "web", Namespaces: []string{"web-ns"}},
+						{Name: "finance"},
+						{Name: "billing", Namespaces: []string{"billing-ns"}},
+					},
+				},
+			}
+		}
+
+		It("should accept independent hierarchies in spec.tree and spec.trees", func() {
+			Expect(validator.validateBusinessLogic(ctx, newTree())).To(Succeed())
+		})
+
+		It("should reject node names reused across hierarchies", func() {
+			folderTree := newTree()
+			folderTree.Spec.Trees = append(folderTree.Spec.Trees, rbacv1alpha1.TreeNode{Name: "web"})
+
+			err := validator.validateBusinessLogic(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.trees[1].name"))
+		})
+
+		It("should reject nodes in spec.trees that reference undeclared folders", func() {
+			folderTree := newTree()
+			folderTree.Spec.Trees[0].Subfolders = append(folderTree.Spec.Trees[0].Subfolders, rbacv1alpha1.TreeNode{Name: "payroll"})
+
+			err := validator.validateBusinessLogic(ctx, folderTree)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.trees[0].subfolders[1].name"))
+		})
+	})
+
+	Context("v1alpha2 Conversion", func() {
+		It("should convert the tree to parent references and back", func() {
+			hub := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "conversion-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Tree: &rbacv1alpha1.TreeNode{
+						Name:       "root",
+						Subfolders: []rbacv1alpha1.TreeNode{{Name: "prod", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web", Exclude: []string{"ops"}}}}},
+					},
+					Trees: []rbacv1alpha1.TreeNode{
+						{Name: "finance", Subfolders: []rbacv1alpha1.TreeNode{{Name: "billing"}}},
+					},
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "root"},
+						{Name: "prod"},
+						{Name: "web", Namespaces: []string{"web-ns"}},
+						{Name: "finance"},
+						{Name: "billing", Namespaces: []string{"billing-ns"}},
+						{Name: "sandbox", Namespaces: []string{"sandbox-ns"}},
+					},
+					DriftPolicy: rbacv1alpha1.DriftPolicyWarn,
+				},
+			}
+
+			spoke := &rbacv1alpha2.FolderTree{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			Expect(spoke.Name).To(Equal("conversion-tree"))
+			Expect(spoke.Spec.DriftPolicy).To(Equal(rbacv1alpha1.DriftPolicyWarn))
+			parents := make(map[string]string)
+			for _, folder := range spoke.Spec.Folders {
+				parents[folder.Name] = folder.Parent
+			}
+			Expect(parents).To(Equal(map[string]string{
+				"root": "", "prod": "root", "web": "prod", "finance": "", "billing": "finance", "sandbox": "",
+			}))
+			Expect(spoke.Spec.Folders[2].Exclude).To(Equal([]string{"ops"}))
+
+			converted := &rbacv1alpha1.FolderTree{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			Expect(converted.Spec).To(Equal(hub.Spec))
+		})
+
+		It("should reject unknown parents and parent cycles", func() {
+			spoke := &rbacv1alpha2.FolderTree{
+				Spec: rbacv1alpha2.FolderTreeSpec{
+					Folders: []rbacv1alpha2.Folder{
+						{Folder: rbacv1alpha1.Folder{Name: "a"}, Parent: "b"},
+						{Folder: rbacv1alpha1.Folder{Name: "b"}, Parent: "a"},
+					},
+				},
+			}
+			err := spoke.ConvertTo(&rbacv1alpha1.FolderTree{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("parent cycle"))
+
+			spoke.Spec.Folders[1].Parent = "missing"
+			err = spoke.ConvertTo(&rbacv1alpha1.FolderTree{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("parent 'missing' of folder 'b' is not a declared folder"))
+		})
+	})
+
+	Context("Admission Warnings", func() {
+		template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:      name,
+				Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name + "-group", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				Propagate: &propagate,
+			}
+		}
+
+		It("should warn about non-propagating templates on folders without namespaces", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("local-only", false)}},
+					{Name: "child", Namespaces: []string{"test-ns"}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"spec.folders[0].roleBindingTemplates[0]: role binding template 'local-only' of folder 'parent' " +
+					"will not apply to any namespace because the folder has no namespaces"))
+		})
+
+		It("should warn about propagating templates when no descendant has namespaces", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "root",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "empty-branch"}, {Name: "used-branch"}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("reaches-used-branch", true)}},
+					{Name: "empty-branch", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("dead-end", true)}},
+					{Name: "used-branch", Namespaces: []string{"test-ns"}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("'dead-end' of folder 'empty-branch'"))
+			Expect(warnings[0]).To(ContainSubstring("neither the folder nor any of its subfolders has namespaces"))
+		})
+
+		It("should warn about propagating templates whose propagateDepth ends above the namespaces", func() {
+			shallow := template("shallow", true)
+			shallow.PropagateDepth = ptr.To[int32](1)
+			deep := template("deep", true)
+			deep.PropagateDepth = ptr.To[int32](2)
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "root",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "middle", Subfolders: []rbacv1alpha1.TreeNode{{Name: "leaf"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{shallow, deep}},
+					{Name: "middle"},
+					{Name: "leaf", Namespaces: []string{"test-ns"}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"spec.folders[0].roleBindingTemplates[0]: role binding template 'shallow' of folder 'root' " +
+					"will not apply to any namespace because no subfolder within its propagateDepth of 1 has namespaces"))
+		})
+
+		It("should not warn about folders that accept memberships", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "team", Subfolders: []rbacv1alpha1.TreeNode{{Name: "joinable"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "team", Namespaces: []string{"test-ns"}},
+					{
+						Name:                 "joinable",
+						AcceptMemberships:    true,
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("members", false)},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should warn about templates on standalone folders without namespaces on update", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "standalone", Namespaces: []string{"test-ns"}}},
+			}
+			newObj := obj.DeepCopy()
+			newObj.Spec.Folders = append(newObj.Spec.Folders, rbacv1alpha1.Folder{
+				Name:                 "templates-only",
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("orphaned", true)},
+			})
+
+			warnings, err := validator.ValidateUpdate(ctx, obj, newObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("spec.folders[1].roleBindingTemplates[0]"))
+			Expect(warnings[0]).To(ContainSubstring("'orphaned' of folder 'templates-only'"))
+		})
+	})
+
+	Context("SubjectAccessReview Privilege Check", func() {
+		var (
+			grants  map[accessKey]bool
+			reviews int
+		)
+
+		// sarClient answers SubjectAccessReviews from grants, so that no impersonation is needed
+		sarClient := func(objects ...client.Object) client.Client {
+			return fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(objects...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						review, ok := obj.(*authorizationv1.SubjectAccessReview)
+						if !ok {
+							return c.Create(ctx, obj, opts...)
+						}
+						reviews++
+						Expect(review.Spec.User).To(Equal("jane"))
+						attributes := review.Spec.ResourceAttributes
+						review.Status.Allowed = grants[accessKey{
+							namespace: attributes.Namespace, verb: attributes.Verb, group: attributes.Group,
+							resource: attributes.Resource, subresource: attributes.Subresource, name: attributes.Name,
+						}]
+						return nil
+					},
+				}).
+				Build()
+		}
+
+		viewRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "view"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get"}},
+			},
+		}
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewers", Namespace: "sar-ns"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		}
+		createRoleBindings := accessKey{namespace: "sar-ns", verb: "create", group: rbacv1.GroupName, resource: "rolebindings"}
+
+		BeforeEach(func() {
+			grants = map[accessKey]bool{}
+			reviews = 0
+		})
+
+		It("should parse the privilege check mode flag", func() {
+			mode, err := ParsePrivilegeCheckMode("subjectaccessreview")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(Equal(PrivilegeCheckModeSubjectAccessReview))
+
+			_, err = ParsePrivilegeCheckMode("dry-run")
+			Expect(err).To(MatchError(ContainSubstring("unknown privilege check mode 'dry-run'")))
+		})
+
+		It("should allow creating a RoleBinding when the user may bind the role", func() {
+			grants[createRoleBindings] = true
+			grants[accessKey{namespace: "sar-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}] = true
+
+			authorizer := newSubjectAccessReviewAuthorizer(sarClient(), authenticationv1.UserInfo{Username: "jane"})
+			Expect(authorizer.authorizeCreate(ctx, roleBinding)).To(Succeed())
+		})
+
+		It("should require every permission of the role when the user may not bind it", func() {
+			grants[createRoleBindings] = true
+			grants[accessKey{namespace: "sar-ns", verb: "get", resource: "pods"}] = true
+			authorizer := newSubjectAccessReviewAuthorizer(sarClient(viewRole), authenticationv1.UserInfo{Username: "jane"})
+
+			err := authorizer.authorizeCreate(ctx, roleBinding)
+			Expect(err).To(MatchError(ContainSubstring("ClusterRole sar-ns/view grants get on pods/log")))
+
+			grants[accessKey{namespace: "sar-ns", verb: "get", resource: "pods", subresource: "log"}] = true
+			authorizer = newSubjectAccessReviewAuthorizer(sarClient(viewRole), authenticationv1.UserInfo{Username: "jane"})
+			Expect(authorizer.authorizeCreate(ctx, roleBinding)).To(Succeed())
+		})
+
+		It("should only require the delete permission for deletions and cache decisions", func() {
+			grants[accessKey{namespace: "sar-ns", verb: "delete", group: rbacv1.GroupName, resource: "rolebindings", name: "viewers"}] = true
+			authorizer := newSubjectAccessReviewAuthorizer(sarClient(), authenticationv1.UserInfo{Username: "jane"})
+
+			Expect(authorizer.authorizeDelete(ctx, roleBinding)).To(Succeed())
+			Expect(authorizer.authorizeDelete(ctx, roleBinding)).To(Succeed())
+			Expect(reviews).To(Equal(1))
+
+			Expect(authorizer.authorizeCreate(ctx, roleBinding)).To(MatchError(ContainSubstring("cannot create rolebindings in namespace 'sar-ns'")))
+		})
+
+		It("should validate a FolderTree in subjectaccessreview mode without impersonation", func() {
+			grants[createRoleBindings] = true
+			grants[accessKey{namespace: "sar-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}] = true
+			sarValidator := FolderTreeCustomValidator{
+				Client:  sarClient(createTestNamespace("sar-ns")),
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview},
+			}
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "sar-folder",
+					Namespaces: []string{"sar-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+						RoleRef:  roleBinding.RoleRef,
+					}},
+				}},
+			}
+			requestCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "jane"},
+			}})
+
+			_, err := sarValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			delete(grants, createRoleBindings)
+			_, err = sarValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+		})
+
+		It("should check an update against the FolderTreePatches it approves", func() {
+			patch := &rbacv1alpha1.FolderTreePatch{
+				ObjectMeta: metav1.ObjectMeta{Name: "viewers", Namespace: "team-ns"},
+				Spec: rbacv1alpha1.FolderTreePatchSpec{
+					TreeName:   "sar-tree",
+					FolderName: "sar-folder",
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+						RoleRef:  roleBinding.RoleRef,
+					}},
+				},
+				Status: rbacv1alpha1.FolderTreePatchStatus{Phase: rbacv1alpha1.PatchPhasePending},
+			}
+			sarValidator := FolderTreeCustomValidator{
+				Client:  sarClient(createTestNamespace("sar-ns"), patch),
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview},
+			}
+			oldTree := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "sar-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{{Name: "sar-folder", Namespaces: []string{"sar-ns"}}},
+				},
+			}
+			newTree := oldTree.DeepCopy()
+			newTree.Spec.Folders[0].PatchNamespaces = []string{"team-ns"}
+			requestCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: "jane"},
+			}})
+
+			// Accepting the patch approves it, and the controller creates its RoleBindings
+			_, err := sarValidator.ValidateUpdate(requestCtx, oldTree, newTree)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+
+			grants[createRoleBindings] = true
+			grants[accessKey{namespace: "sar-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}] = true
+			_, err = sarValidator.ValidateUpdate(requestCtx, oldTree, newTree)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// dryRunClient records the RoleBindings sent as dry-run creations
+		dryRunClient := func(created *[]*rbacv1.RoleBinding) client.Client {
+			return fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						*created = append(*created, obj.(*rbacv1.RoleBinding))
+						return nil
+					},
+				}).
+				Build()
+		}
+
+		It("should label dry-run RoleBindings without changing the desired ones", func() {
+			var created []*rbacv1.RoleBinding
+			labeledValidator := FolderTreeCustomValidator{
+				Options: WebhookOptions{DryRunLabels: map[string]string{"policy.example.com/exempt": "true"}},
+			}
+			authorizer := &impersonationAuthorizer{client: dryRunClient(&created), labels: labeledValidator.dryRunLabels()}
+			desired := roleBinding.DeepCopy()
+			desired.Labels = map[string]string{"rbac.kubevirt.io/managed-by": "foldertree-controller"}
+
+			Expect(authorizer.authorizeCreate(ctx, desired)).To(Succeed())
+			Expect(created).To(HaveLen(1))
+			Expect(created[0].Labels).To(Equal(map[string]string{
+				"rbac.kubevirt.io/managed-by": "foldertree-controller",
+				DryRunValidationLabel:         "true",
+				"policy.example.com/exempt":   "true",
+			}))
+			Expect(desired.Labels).To(HaveLen(1))
+		})
+
+		It("should check the configured namespaces with SubjectAccessReviews in impersonation mode", func() {
+			var created []*rbacv1.RoleBinding
+			grants[accessKey{namespace: "regulated-a", verb: "create", group: rbacv1.GroupName, resource: "rolebindings"}] = true
+			grants[accessKey{namespace: "regulated-a", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}] = true
+			authorizer := &namespaceRoutingAuthorizer{
+				subjectAccessReviewNamespaces: []string{"regulated-*"},
+				subjectAccessReview:           newSubjectAccessReviewAuthorizer(sarClient(), authenticationv1.UserInfo{Username: "jane"}),
+				impersonation:                 &impersonationAuthorizer{client: dryRunClient(&created)},
+			}
+
+			regulated := roleBinding.DeepCopy()
+			regulated.Namespace = "regulated-a"
+			Expect(authorizer.authorizeCreate(ctx, regulated)).To(Succeed())
+			Expect(reviews).To(Equal(2))
+			Expect(created).To(BeEmpty())
+
+			Expect(authorizer.authorizeCreate(ctx, roleBinding)).To(Succeed())
+			Expect(reviews).To(Equal(2))
+			Expect(created).To(HaveLen(1))
+		})
+	})
+
+	Context("Impersonation Client Cache and Worker Pool", func() {
+		restConfig := &rest.Config{Host: "https://foldertree.invalid"}
+
+		It("should reuse clients of the same user regardless of group order", func() {
+			cache := newImpersonationClientCache(restConfig, clientgoscheme.Scheme, 0)
+
+			first, err := cache.get(authenticationv1.UserInfo{Username: "jane", Groups: []string{"devs", "ops"}})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := cache.get(authenticationv1.UserInfo{Username: "jane", Groups: []string{"ops", "devs"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(BeIdenticalTo(first))
+
+			other, err := cache.get(authenticationv1.UserInfo{Username: "jane", Groups: []string{"devs"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other).NotTo(BeIdenticalTo(first))
+		})
+
+		It("should evict the least recently used client", func() {
+			cache := newImpersonationClientCache(restConfig, clientgoscheme.Scheme, 1)
+
+			jane, err := cache.get(authenticationv1.UserInfo{Username: "jane"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.get(authenticationv1.UserInfo{Username: "joe"})
+			Expect(err).NotTo(HaveOccurred())
+
+			again, err := cache.get(authenticationv1.UserInfo{Username: "jane"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).NotTo(BeIdenticalTo(jane))
+			Expect(restConfig.Impersonate.UserName).To(BeEmpty(), "the base config must not be modified")
+		})
+
+		It("should validate operations concurrently and report the first failure", func() {
+			var objects []client.Object
+			var operations []rbac.RoleBindingOperation
+			grants := map[string]bool{}
+			for i := range 20 {
+				namespace := fmt.Sprintf("worker-ns-%02d", i)
+				roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "viewers", Namespace: namespace}}
+				objects = append(objects, createTestNamespace(namespace), roleBinding.DeepCopy())
+				operations = append(operations, rbac.RoleBindingOperation{
+					Type:                rbac.OperationDelete,
+					Namespace:           namespace,
+					ExistingRoleBinding: roleBinding,
+				})
+				grants[namespace] = i != 13
+			}
+			var mu sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			workerClient := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(objects...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						review := obj.(*authorizationv1.SubjectAccessReview)
+						mu.Lock()
+						inFlight++
+						maxInFlight = max(maxInFlight, inFlight)
+						mu.Unlock()
+						time.Sleep(5 * time.Millisecond)
+						mu.Lock()
+						inFlight--
+						mu.Unlock()
+						review.Status.Allowed = grants[review.Spec.ResourceAttributes.Namespace]
+						return nil
+					},
+				}).
+				Build()
+			workerValidator := FolderTreeCustomValidator{
+				Client:  workerClient,
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview, ValidationWorkers: 4},
+			}
+
+			err := workerValidator.validateOperationsAsUser(ctx, operations, authenticationv1.UserInfo{Username: "jane"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("worker-ns-13/viewers")))
+			Expect(maxInFlight).To(BeNumerically(">", 1))
+			Expect(maxInFlight).To(BeNumerically("<=", 4))
+		})
+	})
+
+	Context("Blocked Inheritance", func() {
+		template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:      name,
+				Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name + "-group", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				Propagate: &propagate,
+			}
+		}
+
+		It("should allow a folder to block an inherited template and redefine its name", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", true)}},
+					{
+						Name:                 "child",
+						Namespaces:           []string{"child-ns"},
+						BlockInherited:       []string{"viewers"},
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", false)},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should still reject redefining an inherited template that is not blocked", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", true)}},
+					{Name: "child", Namespaces: []string{"child-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", false)}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("conflicts with inherited template"))
+		})
+
+		It("should reject blocking global templates and duplicate entries", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", true)}},
+					{Name: "child", Namespaces: []string{"child-ns"}, BlockInherited: []string{"security", "viewers", "viewers"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("security", false)},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[1].blockInherited[0]"))
+			Expect(err.Error()).To(ContainSubstring("global role binding template 'security' cannot be blocked"))
+			Expect(err.Error()).To(ContainSubstring("spec.folders[1].blockInherited[2]: Duplicate value"))
+		})
+
+		It("should warn about blocks that match no inherited template", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("local-only", false)}},
+					{Name: "child", Namespaces: []string{"child-ns"}, BlockInherited: []string{"local-only"}},
+					{Name: "standalone", Namespaces: []string{"standalone-ns"}, BlockInherited: []string{"viewers"}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"spec.folders[1].blockInherited[0]: folder 'child' blocks template 'local-only', which it does not inherit from any ancestor folder",
+				"spec.folders[2].blockInherited[0]: folder 'standalone' blocks template 'viewers', which it does not inherit from any ancestor folder",
+			))
+		})
+	})
+
+	Context("Edge Filters", func() {
+		template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:      name,
+				Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name + "-group", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				Propagate: &propagate,
+			}
+		}
+
+		It("should allow a subfolder to redefine a template its edge excludes and warn about unmatched entries", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{
+					{Name: "child", Exclude: []string{"viewers"}},
+					{Name: "tree", InheritOnly: []string{"editors", "typo"}},
+				}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+						template("viewers", true), template("editors", true),
+					}},
+					{Name: "child", Namespaces: []string{"child-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", false)}},
+					{Name: "tree", Namespaces: []string{"tree-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", false)}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"spec.tree.subfolders[1].inheritOnly[1]: tree node 'tree' filters template 'typo', which it does not inherit from any ancestor folder",
+			))
+		})
+
+		It("should reject edge filters on tree roots, of global templates and duplicate entries", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Exclude: []string{"viewers"}, Subfolders: []rbacv1alpha1.TreeNode{
+					{Name: "child", InheritOnly: []string{"security", "viewers", "viewers"}},
+				}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("viewers", true)}},
+					{Name: "child", Namespaces: []string{"child-ns"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("security", false)},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tree.exclude: Forbidden: tree roots do not inherit templates from a parent folder"))
+			Expect(err.Error()).To(ContainSubstring("global role binding template 'security' is always inherited"))
+			Expect(err.Error()).To(ContainSubstring("spec.tree.subfolders[0].inheritOnly[2]: Duplicate value"))
+		})
+	})
+
+	Context("Tree Depth and Cycles", func() {
+		// chain returns a tree of the given depth with folders node-1 ... node-<depth>,
+		// the last of which has a namespace
+		chain := func(depth int) rbacv1alpha1.FolderTreeSpec {
+			spec := rbacv1alpha1.FolderTreeSpec{}
+			var node *rbacv1alpha1.TreeNode
+			for level := depth; level >= 1; level-- {
+				name := fmt.Sprintf("node-%d", level)
+				parent := rbacv1alpha1.TreeNode{Name: name}
+				if node != nil {
+					parent.Subfolders = []rbacv1alpha1.TreeNode{*node}
+				}
+				node = &parent
+				folder := rbacv1alpha1.Folder{Name: name}
+				if level == depth {
+					folder.Namespaces = []string{"test-ns"}
+				}
+				spec.Folders = append(spec.Folders, folder)
+			}
+			spec.Tree = node
+			return spec
+		}
+
+		It("should accept trees up to the maximum depth", func() {
+			obj.Spec = chain(10)
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject trees deeper than the maximum depth", func() {
+			obj.Spec = chain(11)
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tree" + strings.Repeat(".subfolders[0]", 10) + ": Invalid value: \"node-11\""))
+			Expect(err.Error()).To(ContainSubstring("exceeds the maximum tree depth of 10"))
+		})
+
+		It("should honor a configured maximum depth", func() {
+			validator.Options.MaxTreeDepth = 3
+			obj.Spec = chain(4)
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tree node is at depth 4, which exceeds the maximum tree depth of 3"))
+		})
+
+		It("should report nodes repeating an ancestor as a cycle", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "root",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "child", Subfolders: []rbacv1alpha1.TreeNode{{Name: "root"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", Namespaces: []string{"test-ns"}},
+					{Name: "child"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tree.subfolders[0].subfolders[0].name: Invalid value: \"root\": " +
+				"tree node 'root' repeats its ancestor at spec.tree.name, forming a cycle"))
+		})
+	})
+
+	Context("FolderTree Defaults", func() {
+		auditors := rbacv1.Subject{Kind: "Group", Name: "auditors", APIGroup: "rbac.authorization.k8s.io"}
+		viewers := func() rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:    "viewers",
+				RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+			}
+		}
+
+		It("should accept templates without subjects when default subjects are set", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Defaults: &rbacv1alpha1.FolderTreeDefaults{Subjects: []rbacv1.Subject{auditors}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "folder", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers()}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:    "global-viewers",
+					RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject templates without subjects when no default subjects are set", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Defaults: &rbacv1alpha1.FolderTreeDefaults{Propagate: &[]bool{true}[0]},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "folder", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers()}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("subjects cannot be empty unless subjectRefs, serviceAccountSelector or spec.defaults.subjects is set"))
+		})
+
+		It("should report invalid default subjects once, at spec.defaults", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Defaults: &rbacv1alpha1.FolderTreeDefaults{Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Name: "bot"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "folder", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers()}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.defaults.subjects[0].namespace"))
+			Expect(err.Error()).NotTo(ContainSubstring("roleBindingTemplates"))
+		})
+
+		It("should apply the default propagate behavior to templates that don't set it", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Defaults: &rbacv1alpha1.FolderTreeDefaults{Subjects: []rbacv1.Subject{auditors}, Propagate: &[]bool{true}[0]},
+				Tree:     &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers()}},
+					{Name: "child", Namespaces: []string{"child-ns"}},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			By("letting a template override the default")
+			obj.Spec.Folders[0].RoleBindingTemplates[0].Propagate = &[]bool{false}[0]
+			warnings, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("will not apply to any namespace because the folder has no namespaces")))
+		})
+	})
+
+	Context("Modifier Annotations", func() {
+		var defaulter FolderTreeCustomDefaulter
+
+		requestAs := func(operation admissionv1.Operation, username string, oldFolderTree *rbacv1alpha1.FolderTree) context.Context {
+			req := admissionv1.AdmissionRequest{
+				Operation: operation,
+				UserInfo:  authenticationv1.UserInfo{Username: username, UID: username + "-uid"},
+			}
+			if oldFolderTree != nil {
+				raw, err := json.Marshal(oldFolderTree)
+				Expect(err).NotTo(HaveOccurred())
+				req.OldObject = runtime.RawExtension{Raw: raw}
+			}
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: req})
+		}
+
+		BeforeEach(func() {
+			obj.Name = "audited-tree"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "folder", Namespaces: []string{"test-ns"}}},
+			}
+		})
+
+		It("should record the requesting user on create", func() {
+			Expect(defaulter.Default(requestAs(admissionv1.Create, "jane", nil), obj)).To(Succeed())
+
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByAnnotation, "jane"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByUIDAnnotation, "jane-uid"))
+		})
+
+		It("should record the requesting user when the spec changes", func() {
+			oldObj := obj.DeepCopy()
+			oldObj.Annotations = map[string]string{rbac.LastModifiedByAnnotation: "jane"}
+			obj.Annotations = map[string]string{rbac.LastModifiedByAnnotation: "jane"}
+			obj.Spec.Folders[0].Namespaces = append(obj.Spec.Folders[0].Namespaces, "child-ns")
+
+			Expect(defaulter.Default(requestAs(admissionv1.Update, "bob", oldObj), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByAnnotation, "bob"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByUIDAnnotation, "bob-uid"))
+		})
+
+		It("should keep the recorded user when the spec is unchanged", func() {
+			oldObj := obj.DeepCopy()
+			oldObj.Annotations = map[string]string{rbac.LastModifiedByAnnotation: "jane"}
+			obj.Finalizers = []string{"rbac.kubevirt.io/cleanup-rolebindings"}
+
+			Expect(defaulter.Default(requestAs(admissionv1.Update, "system:serviceaccount:foldertree-system:controller", oldObj), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByAnnotation, "jane"))
+			Expect(obj.Annotations).NotTo(HaveKey(rbac.LastModifiedByUIDAnnotation))
+		})
+
+		It("should revert edits of the annotations that leave the spec unchanged", func() {
+			oldObj := obj.DeepCopy()
+			oldObj.Annotations = map[string]string{rbac.LastModifiedByAnnotation: "jane"}
+			obj.Annotations = map[string]string{rbac.LastModifiedByAnnotation: "someone-else"}
+
+			Expect(defaulter.Default(requestAs(admissionv1.Update, "mallory", oldObj), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(rbac.LastModifiedByAnnotation, "jane"))
+		})
+	})
+
+	Context("Template Expiration", func() {
+		expiringTemplate := func(expiresAt time.Time) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:      "oncall",
+				Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "oncall", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				ExpiresAt: &metav1.Time{Time: expiresAt},
+			}
+		}
+
+		BeforeEach(func() {
+			obj.Name = "expiring-tree"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:                 "folder",
+					Namespaces:           []string{"test-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{expiringTemplate(time.Now().Add(time.Hour))},
+				}},
+			}
+		})
+
+		It("should accept templates expiring in the future", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject templates that have already expired on create", func() {
+			obj.Spec.Folders[0].RoleBindingTemplates[0].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].roleBindingTemplates[0].expiresAt"))
+			Expect(err.Error()).To(ContainSubstring("role binding template 'oncall' has already expired"))
+		})
+
+		It("should keep expired templates on unrelated updates with a warning", func() {
+			obj.Spec.Folders[0].RoleBindingTemplates[0].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			newObj := obj.DeepCopy()
+			newObj.Spec.Folders[0].Namespaces = append(newObj.Spec.Folders[0].Namespaces, "child-ns")
+
+			warnings, err := validator.ValidateUpdate(ctx, obj, newObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("role binding template 'oncall' expired at")))
+		})
+
+		It("should reject updates setting an expiresAt in the past", func() {
+			newObj := obj.DeepCopy()
+			newObj.Spec.Folders[0].RoleBindingTemplates[0].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+			_, err := validator.ValidateUpdate(ctx, obj, newObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has already expired"))
+		})
+	})
+
+	Context("Break-Glass", func() {
+		var (
+			recorder        *record.FakeRecorder
+			breakGlassValid FolderTreeCustomValidator
+		)
+
+		// requestAs returns a context carrying an admission request of a user in the given groups
+		requestAs := func(operation admissionv1.Operation, groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: operation,
+				UserInfo:  authenticationv1.UserInfo{Username: "oncall-jane", Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			// Deny every SubjectAccessReview, so that only break-glass lets requests through
+			denyingClient := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(createTestNamespace("incident-ns")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if _, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+							return nil
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			breakGlassValid = FolderTreeCustomValidator{
+				Client: denyingClient,
+				Options: WebhookOptions{
+					PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview,
+					BreakGlassGroups:   []string{"sre-oncall"},
+				},
+				Recorder: recorder,
+			}
+
+			obj.Name = "incident-tree"
+			obj.Annotations = map[string]string{BreakGlassAnnotation: "INC-1234"}
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "incident",
+					Namespaces: []string{"incident-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "responders",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "responders", APIGroup: rbacv1.GroupName}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+					}},
+				}},
+			}
+		})
+
+		It("should skip the privilege escalation check for members of a break-glass group", func() {
+			before := testutil.ToFloat64(metrics.WebhookBreakGlass.WithLabelValues("create"))
+
+			_, err := breakGlassValid.ValidateCreate(requestAs(admissionv1.Create, "sre-oncall"), obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testutil.ToFloat64(metrics.WebhookBreakGlass.WithLabelValues("create"))).To(Equal(before + 1))
+			Expect(recorder.Events).To(Receive(And(
+				ContainSubstring("Warning BreakGlass"),
+				ContainSubstring("oncall-jane"),
+				ContainSubstring("INC-1234"),
+			)))
+		})
+
+		It("should keep checking users outside the break-glass groups", func() {
+			_, err := breakGlassValid.ValidateCreate(requestAs(admissionv1.Create, "developers"), obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+			Expect(recorder.Events).NotTo(Receive())
+		})
+
+		It("should keep checking FolderTrees without the annotation or when no groups are configured", func() {
+			delete(obj.Annotations, BreakGlassAnnotation)
+			_, err := breakGlassValid.ValidateCreate(requestAs(admissionv1.Create, "sre-oncall"), obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+
+			obj.Annotations[BreakGlassAnnotation] = "INC-1234"
+			breakGlassValid.Options.BreakGlassGroups = nil
+			_, err = breakGlassValid.ValidateCreate(requestAs(admissionv1.Create, "sre-oncall"), obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+			Expect(recorder.Events).NotTo(Receive())
+		})
+
+		It("should apply to spec changes and deletions but not record metadata-only updates", func() {
+			oldObj := obj.DeepCopy()
+			oldObj.Spec.Folders[0].RoleBindingTemplates = nil
+
+			_, err := breakGlassValid.ValidateUpdate(requestAs(admissionv1.Update, "sre-oncall"), oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("check of update skipped")))
+
+			_, err = breakGlassValid.ValidateUpdate(requestAs(admissionv1.Update, "sre-oncall"), obj, obj.DeepCopy())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive())
+
+			_, err = breakGlassValid.ValidateDelete(requestAs(admissionv1.Delete, "sre-oncall"), obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("check of delete skipped")))
+		})
+
+		It("should not record Events for dry-run requests", func() {
+			dryRunCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "oncall-jane", Groups: []string{"sre-oncall"}},
+				DryRun:    &[]bool{true}[0],
+			}})
+
+			_, err := breakGlassValid.ValidateCreate(dryRunCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive())
+		})
+	})
+
+	Context("Privileged Users", func() {
+		var privilegedValidator FolderTreeCustomValidator
+
+		// requestAs returns a context carrying an admission request of the given user and groups
+		requestAs := func(operation admissionv1.Operation, username string, groups ...string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: operation,
+				UserInfo:  authenticationv1.UserInfo{Username: username, Groups: groups},
+			}})
+		}
+
+		BeforeEach(func() {
+			// Deny every SubjectAccessReview, so that only the privileged users get through
+			privilegedValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(createTestNamespace("gitops-ns"), &rbacv1.RoleBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "foldertree-gitops-tree-admins",
+							Namespace: "gitops-ns",
+							Labels:    map[string]string{"foldertree.rbac.kubevirt.io/tree": "gitops-tree"},
+						},
+						RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+					}).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							if _, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+								return nil
+							}
+							return c.Create(ctx, obj, opts...)
+						},
+					}).
+					Build(),
+				Options: WebhookOptions{
+					PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview,
+					PrivilegedUsers:    []string{"system:serviceaccount:argocd:argocd-application-controller"},
+					PrivilegedGroups:   []string{"system:serviceaccounts:flux-system"},
+				},
+			}
+
+			obj.Name = "gitops-tree"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "gitops",
+					Namespaces: []string{"gitops-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "admins",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "admins", APIGroup: rbacv1.GroupName}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+					}},
+				}},
+			}
+		})
+
+		It("should skip the privilege escalation check for privileged users and groups", func() {
+			before := testutil.ToFloat64(metrics.WebhookPrivilegedBypass.WithLabelValues("create"))
+
+			_, err := privilegedValidator.ValidateCreate(requestAs(admissionv1.Create, "system:serviceaccount:argocd:argocd-application-controller"), obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testutil.ToFloat64(metrics.WebhookPrivilegedBypass.WithLabelValues("create"))).To(Equal(before + 1))
+
+			oldObj := obj.DeepCopy()
+			oldObj.Spec.Folders[0].RoleBindingTemplates = nil
+			fluxCtx := requestAs(admissionv1.Update, "system:serviceaccount:flux-system:kustomize-controller", "system:serviceaccounts:flux-system")
+			_, err = privilegedValidator.ValidateUpdate(fluxCtx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = privilegedValidator.ValidateDelete(requestAs(admissionv1.Delete, "system:serviceaccount:argocd:argocd-application-controller"), obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should keep checking other users", func() {
+			_, err := privilegedValidator.ValidateCreate(requestAs(admissionv1.Create, "jane", "system:serviceaccounts"), obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+
+			_, err = privilegedValidator.ValidateDelete(requestAs(admissionv1.Delete, "jane"), obj)
+			Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+		})
+
+		It("should reject wildcard groups and patterns", func() {
+			Expect(ValidatePrivilegedRequesters([]string{"system:serviceaccount:argocd:argocd-application-controller"},
+				[]string{"system:serviceaccounts:flux-system"})).To(Succeed())
+			Expect(ValidatePrivilegedRequesters(nil, []string{"system:authenticated"})).To(MatchError(ContainSubstring("includes every user")))
+			Expect(ValidatePrivilegedRequesters([]string{"system:serviceaccount:argocd:*"}, nil)).To(MatchError(ContainSubstring("patterns are not supported")))
+		})
+	})
+
+	Context("RoleBinding Fan-Out", func() {
+		template := func(name string) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:     name,
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+			}
+		}
+
+		BeforeEach(func() {
+			// 3 templates in 2 namespaces produce 6 RoleBindings
+			obj.Name = "fan-out-tree"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:                 "folder",
+					Namespaces:           []string{"test-ns", "child-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("readers"), template("auditors"), template("oncall")},
+				}},
+			}
+		})
+
+		It("should accept FolderTrees within the limits without warnings", func() {
+			validator.Options.MaxRoleBindings = 6
+			validator.Options.RoleBindingWarningThreshold = 6
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject FolderTrees producing more RoleBindings than the maximum", func() {
+			validator.Options.MaxRoleBindings = 5
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("would produce 6 RoleBindings, which exceeds the maximum of 5")))
+		})
+
+		It("should warn above the warning threshold", func() {
+			validator.Options.RoleBindingWarningThreshold = 4
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("produces 6 RoleBindings, more than the warning threshold of 4")))
+		})
+
+		It("should only reject updates increasing the count of a FolderTree above the maximum", func() {
+			validator.Options.MaxRoleBindings = 4
+
+			unrelated := obj.DeepCopy()
+			unrelated.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "edit"
+			_, err := validator.ValidateUpdate(ctx, obj, unrelated)
+			Expect(err).NotTo(HaveOccurred())
+
+			growing := obj.DeepCopy()
+			growing.Spec.Folders[0].RoleBindingTemplates = append(growing.Spec.Folders[0].RoleBindingTemplates, template("admins"))
+			_, err = validator.ValidateUpdate(ctx, obj, growing)
+			Expect(err).To(MatchError(ContainSubstring("would produce 8 RoleBindings")))
+		})
+
+		It("should warn when the spec approaches the object size limit", func() {
+			validator.Options.SpecSizeWarningBytes = 100
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(MatchRegexp(`has a spec of \d+ bytes, more than the warning threshold of 100 bytes`)))
+
+			validator.Options.SpecSizeWarningBytes = -1
+			warnings, err = validator.ValidateUpdate(ctx, obj, obj.DeepCopy())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).NotTo(ContainElement(ContainSubstring("has a spec of")))
+		})
+
+		It("should require confirming updates removing most of the RoleBindings", func() {
+			shrinking := obj.DeepCopy()
+			shrinking.Spec.Folders[0].RoleBindingTemplates = shrinking.Spec.Folders[0].RoleBindingTemplates[:2]
+			_, err := validator.ValidateUpdate(ctx, obj, shrinking)
+			Expect(err).NotTo(HaveOccurred())
+
+			destructive := obj.DeepCopy()
+			destructive.Spec.Folders[0].RoleBindingTemplates = destructive.Spec.Folders[0].RoleBindingTemplates[:1]
+			_, err = validator.ValidateUpdate(ctx, obj, destructive)
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrDestructiveChange))
+			Expect(err).To(MatchError(ContainSubstring("would remove 4 of its 6 RoleBindings, more than 50%")))
+			hash := regexp.MustCompile(ConfirmDestructiveChangeAnnotation + `=(\w+)`).FindStringSubmatch(err.Error())
+			Expect(hash).To(HaveLen(2))
+
+			// A confirmation of another change does not apply
+			destructive.Annotations = map[string]string{ConfirmDestructiveChangeAnnotation: "0123456789ab"}
+			_, err = validator.ValidateUpdate(ctx, obj, destructive)
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrDestructiveChange))
+
+			destructive.Annotations[ConfirmDestructiveChangeAnnotation] = hash[1]
+			warnings, err := validator.ValidateUpdate(ctx, obj, destructive)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("confirmed update of FolderTree 'fan-out-tree' removes 4 of its 6 RoleBindings")))
+
+			delete(destructive.Annotations, ConfirmDestructiveChangeAnnotation)
+			validator.Options.DestructiveChangeThreshold = -1
+			_, err = validator.ValidateUpdate(ctx, obj, destructive)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Namespace Metadata", func() {
+		newTree := func(labels, annotations map[string]string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "metadata-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "metadata-folder", LabelsToApply: labels, AnnotationsToApply: annotations},
+					},
+				},
+			}
+		}
+
+		It("should accept valid labels and annotations", func() {
+			folderTree := newTree(
+				map[string]string{"cost-center": "cc-1234", "example.com/environment": "production"},
+				map[string]string{"example.com/compliance-tier": "PCI DSS level 1"})
+			Expect(validator.validateNewStructure(ctx, folderTree)).To(Succeed())
+		})
+
+		It("should reject invalid label keys and values", func() {
+			err := validator.validateNewStructure(ctx, newTree(map[string]string{"bad key": "ok", "env": "not a value"}, nil))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].labelsToApply"))
+			Expect(err.Error()).To(ContainSubstring("bad key"))
+			Expect(err.Error()).To(ContainSubstring("not a value"))
+		})
+
+		It("should reject reserved Kubernetes keys", func() {
+			err := validator.validateNewStructure(ctx, newTree(
+				map[string]string{"pod-security.kubernetes.io/enforce": "privileged"},
+				map[string]string{"scheduler.alpha.kubernetes.io/node-selector": "pool=gpu", "k8s.io/owner": "me"}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].labelsToApply[pod-security.kubernetes.io/enforce]: Forbidden"))
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].annotationsToApply[scheduler.alpha.kubernetes.io/node-selector]: Forbidden"))
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].annotationsToApply[k8s.io/owner]: Forbidden"))
+		})
+	})
+
+	Context("NetworkPolicy Templates", func() {
+		var npValidator FolderTreeCustomValidator
+
+		// requestAs returns a context carrying an admission request of the given user
+		requestAs := func(username string) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: username},
+			}})
+		}
+
+		newTree := func(templates ...rbacv1alpha1.NetworkPolicyTemplate) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "np-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{{Name: "np-folder", Namespaces: []string{"np-ns"}, NetworkPolicyTemplates: templates}},
+				},
+			}
+		}
+		denyAll := rbacv1alpha1.NetworkPolicyTemplate{Name: "deny-all"}
+
+		BeforeEach(func() {
+			// Only the network admin may manage NetworkPolicies
+			sarClient := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(createTestNamespace("np-ns")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+							review.Status.Allowed = review.Spec.User == "network-admin" ||
+								review.Spec.ResourceAttributes.Resource != "networkpolicies"
+							return nil
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			npValidator = FolderTreeCustomValidator{
+				Client:  sarClient,
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview},
+			}
+		})
+
+		It("should reject invalid and duplicate template names", func() {
+			err := validator.validateNewStructure(ctx, newTree(denyAll, denyAll, rbacv1alpha1.NetworkPolicyTemplate{Name: "Not_Valid"}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].networkPolicyTemplates[1].name: Duplicate value"))
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].networkPolicyTemplates[2].name"))
+		})
+
+		It("should require permission to manage NetworkPolicies in the affected namespaces", func() {
+			oldTree := newTree()
+			newTree := newTree(denyAll)
+
+			_, err := npValidator.ValidateUpdate(requestAs("developer"), oldTree, newTree)
+			Expect(err).To(MatchError(ContainSubstring("cannot create networkpolicies in namespace 'np-ns'")))
+
+			_, err = npValidator.ValidateUpdate(requestAs("network-admin"), oldTree, newTree)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = npValidator.ValidateDelete(requestAs("developer"), newTree)
+			Expect(err).To(MatchError(ContainSubstring("cannot delete networkpolicies in namespace 'np-ns'")))
+		})
+
+		It("should not check NetworkPolicy permissions when templates are unchanged", func() {
+			_, err := npValidator.ValidateUpdate(requestAs("developer"), newTree(denyAll), newTree(denyAll))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("ResourceQuota Templates", func() {
+		It("should require permission to manage ResourceQuotas in the affected namespaces", func() {
+			// Only the platform admin may manage ResourceQuotas
+			sarClient := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(createTestNamespace("quota-ns")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+							review.Status.Allowed = review.Spec.User == "platform-admin" ||
+								review.Spec.ResourceAttributes.Resource != "resourcequotas"
+							return nil
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			quotaValidator := FolderTreeCustomValidator{
+				Client:  sarClient,
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview},
+			}
+			requestAs := func(username string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					UserInfo:  authenticationv1.UserInfo{Username: username},
+				}})
+			}
+			newTree := func(cpu string) *rbacv1alpha1.FolderTree {
+				return &rbacv1alpha1.FolderTree{
+					ObjectMeta: metav1.ObjectMeta{Name: "quota-tree"},
+					Spec: rbacv1alpha1.FolderTreeSpec{
+						Folders: []rbacv1alpha1.Folder{{
+							Name:       "quota-folder",
+							Namespaces: []string{"quota-ns"},
+							ResourceQuotaTemplates: []rbacv1alpha1.ResourceQuotaTemplate{{
+								Name: "compute",
+								Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(cpu)}},
+							}},
+						}},
+					},
+				}
+			}
+
+			By("rejecting a namespace owner raising their own quota")
+			_, err := quotaValidator.ValidateUpdate(requestAs("developer"), newTree("4"), newTree("400"))
+			Expect(err).To(MatchError(ContainSubstring("cannot update resourcequotas in namespace 'quota-ns'")))
+
+			By("admitting the change from the platform admin")
+			_, err = quotaValidator.ValidateUpdate(requestAs("platform-admin"), newTree("4"), newTree("400"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("admitting equivalent quantities without a check")
+			_, err = quotaValidator.ValidateUpdate(requestAs("developer"), newTree("4"), newTree("4000m"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Namespace Overlap", func() {
+		var existing *rbacv1alpha1.FolderTree
+
+		BeforeEach(func() {
+			existing = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "overlap-existing"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Priority: 5,
+					Folders:  []rbacv1alpha1.Folder{{Name: "overlap-existing-folder", Namespaces: []string{"test-ns"}}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, existing))).To(Succeed())
+			})
+
+			obj.Name = "overlap-new"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "overlap-new-folder", Namespaces: []string{"test-ns"}}},
+			}
+		})
+
+		It("should reject namespaces of other FolderTrees by default", func() {
+			_, err := validator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("namespace 'test-ns' is already assigned in FolderTree 'overlap-existing'"))
+		})
+
+		It("should warn about shared namespaces, naming the FolderTree of highest priority", func() {
+			validator.Options.AllowNamespaceOverlap = true
+
+			warnings, err := validator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf("namespace 'test-ns' is also assigned in FolderTree 'overlap-existing'; " +
+				"FolderTree 'overlap-existing' manages it by priority"))
+
+			obj.Spec.Priority = 10
+			warnings, err = validator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("FolderTree 'overlap-new' manages it by priority")))
+		})
+
+		It("should still reject folder names of other FolderTrees", func() {
+			validator.Options.AllowNamespaceOverlap = true
+			obj.Spec.Folders[0].Name = "overlap-existing-folder"
+
+			_, err := validator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("folder name 'overlap-existing-folder' already exists"))
+		})
+	})
+
+	Context("Namespace Patterns", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"pattern-team-a-web", "pattern-team-b-web", "pattern-listed"} {
+				Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, createTestNamespace(name)))).To(Succeed())
+			}
+			existing := &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "pattern-existing"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{Name: "pattern-existing-folder", NamespacePattern: "^pattern-team-a-.*$"},
+						{Name: "pattern-existing-listed", Namespaces: []string{"pattern-listed"}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, existing))).To(Succeed())
+			})
+			obj.Name = "pattern-new"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "pattern-new-folder"}},
+			}
+		})
+
+		It("should reject identical patterns and patterns matching the same namespace", func() {
+			obj.Spec.Folders[0].NamespacePattern = "^pattern-team-a-.*$"
+			_, err := validator.validateNamespacePatterns(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("is already used by folder 'pattern-existing-folder' in FolderTree 'pattern-existing'")))
+
+			obj.Spec.Folders[0].NamespacePattern = "^pattern-.*-web$"
+			_, err = validator.validateNamespacePatterns(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("both match namespace 'pattern-team-a-web'")))
+		})
+
+		It("should admit disjoint patterns and warn about listed namespaces", func() {
+			obj.Spec.Folders[0].NamespacePattern = "^pattern-(team-b-.*|listed)$"
+			warnings, err := validator.validateNamespacePatterns(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("matches namespace 'pattern-listed', which FolderTree 'pattern-existing' lists")))
+
+			By("leaving the listed namespace out of the RoleBindings checked for privilege escalation")
+			expanded, err := validator.withPatternNamespaces(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expanded.Spec.Folders[0].Namespaces).To(Equal([]string{"pattern-team-b-web"}))
+		})
+
+		It("should only let privileged requesters grant access to namespaces created later", func() {
+			requestAs := func(operation admissionv1.Operation, username string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: operation,
+					UserInfo:  authenticationv1.UserInfo{Username: username},
+				}})
+			}
+			validator.Options.PrivilegedUsers = []string{"gitops"}
+			obj.Spec.Folders[0].NamespacePattern = "^pattern-later-.*$"
+			obj.Spec.Folders[0].RoleBindingTemplates = []rbacv1alpha1.RoleBindingTemplate{{
+				Name:     "developers",
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: "developers", APIGroup: rbacv1.GroupName}},
+				RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			}}
+
+			_, err := validator.ValidateCreate(requestAs(admissionv1.Create, "jane"), obj)
+			Expect(err).To(MatchError(ContainSubstring(
+				"namespaces matching '^pattern-later-.*$' would receive RoleBinding 'foldertree-pattern-new-developers'")))
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPrivilegeEscalation))
+
+			_, err = validator.ValidateCreate(requestAs(admissionv1.Create, "gitops"), obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("adding a matching namespace created after admission")
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, createTestNamespace("pattern-later-web")))).To(Succeed())
+			expanded, err := validator.withPatternNamespaces(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expanded.Spec.Folders[0].Namespaces).To(Equal([]string{"pattern-later-web"}))
+
+			By("rejecting changes to the access the pattern grants by other users")
+			updated := obj.DeepCopy()
+			updated.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "edit"
+			_, err = validator.ValidateUpdate(requestAs(admissionv1.Update, "jane"), obj, updated)
+			Expect(err).To(MatchError(ContainSubstring("only privileged requesters may change the access namespacePatterns grant")))
+		})
+	})
+
+	Context("Uniqueness Index", func() {
+		var indexedValidator FolderTreeCustomValidator
+
+		BeforeEach(func() {
+			indexedClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithIndex(&rbacv1alpha1.FolderTree{}, uniquenessIndexField, indexUniquenessKeys).
+				WithObjects(
+					&rbacv1alpha1.FolderTree{
+						ObjectMeta: metav1.ObjectMeta{Name: "indexed-folders"},
+						Spec: rbacv1alpha1.FolderTreeSpec{
+							Tree:    &rbacv1alpha1.TreeNode{Name: "indexed-root", Subfolders: []rbacv1alpha1.TreeNode{{Name: "indexed-child"}}},
+							Folders: []rbacv1alpha1.Folder{{Name: "indexed-root"}, {Name: "indexed-child", Namespaces: []string{"indexed-ns"}}},
+						},
+					},
+					&rbacv1alpha1.FolderTree{
+						ObjectMeta: metav1.ObjectMeta{Name: "indexed-unrelated"},
+						Spec: rbacv1alpha1.FolderTreeSpec{
+							Folders: []rbacv1alpha1.Folder{{Name: "indexed-unrelated", Namespaces: []string{"indexed-unrelated-ns"}}},
+						},
+					},
+				).
+				Build()
+			indexedValidator = FolderTreeCustomValidator{Client: indexedClient, uniquenessIndex: true}
+			obj.Name = "indexed-new"
+		})
+
+		It("should only consider FolderTrees sharing a folder name or namespace", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "indexed-new", Namespaces: []string{"indexed-ns"}}},
+			}
+			candidates, err := indexedValidator.uniquenessCandidates(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(HaveLen(1))
+			Expect(candidates[0].Name).To(Equal("indexed-folders"))
+
+			_, err = indexedValidator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("namespace 'indexed-ns' is already assigned in FolderTree 'indexed-folders'")))
+		})
+
+		It("should find tree node names and leave the FolderTree itself out", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree:    &rbacv1alpha1.TreeNode{Name: "indexed-new", Subfolders: []rbacv1alpha1.TreeNode{{Name: "indexed-child"}}},
+				Folders: []rbacv1alpha1.Folder{{Name: "indexed-new"}},
+			}
+			_, err := indexedValidator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("tree node name 'indexed-child' already exists in FolderTree 'indexed-folders'")))
+
+			obj.Name = "indexed-folders"
+			candidates, err := indexedValidator.uniquenessCandidates(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(BeEmpty())
+		})
+
+		It("should accept FolderTrees sharing nothing", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "indexed-new", Namespaces: []string{"indexed-new-ns"}}},
+			}
+			warnings, err := indexedValidator.validateGlobalUniqueness(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("Rejection Codes", func() {
+		viewers := func(propagate bool) rbacv1alpha1.RoleBindingTemplate {
+			return rbacv1alpha1.RoleBindingTemplate{
+				Name:      "viewers",
+				Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				Propagate: &propagate,
+			}
+		}
+
+		BeforeEach(func() {
+			obj.Name = "codes-tree"
+		})
+
+		It("should classify inheritance conflicts", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "codes-parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "codes-child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "codes-parent", Namespaces: []string{"test-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers(true)}},
+					{Name: "codes-child", Namespaces: []string{"child-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers(false)}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrInheritConflict))
+		})
+
+		It("should classify other validation stages by stage", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "codes", Namespaces: []string{"codes-missing-ns"}}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrNamespaceMissing))
+
+			Expect(validation.RejectionCodeOf(fmt.Errorf("plain"))).To(BeEmpty())
+			Expect(validation.RejectionCodeOf(validation.Reject(validation.ErrPolicyViolation, validation.Reject(validation.ErrFanOutExceeded, fmt.Errorf("limit"))))).To(Equal(validation.ErrFanOutExceeded))
+		})
+
+		It("should return the code as the reason of the admission response, with field causes", func() {
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "codes", Namespaces: []string{"test-ns"}},
+					{Name: "codes-other", Namespaces: []string{"test-ns"}},
+				},
+			}
+			obj.SetGroupVersionKind(rbacv1alpha1.GroupVersion.WithKind("FolderTree"))
+			raw, err := json.Marshal(obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler := admission.WithCustomValidator(k8sClient.Scheme(), obj, &validator)
+			response := handler.Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Reason).To(Equal(metav1.StatusReason(validation.ErrInvalidSpec)))
+			Expect(response.Result.Code).To(Equal(int32(http.StatusForbidden)))
+			Expect(response.Result.Message).To(HavePrefix("[InvalidSpec] "))
+			Expect(response.Result.Details.Causes).To(ContainElement(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: `Duplicate value: "namespace 'test-ns' already assigned at spec.folders[0].namespaces[0]"`,
+				Field:   "spec.folders[1].namespaces[0]",
+			}))
+		})
+	})
+
+	Context("Subject Mappings", func() {
+		BeforeEach(func() {
+			obj.Name = "subject-mappings"
+			obj.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "frontend",
+					Namespaces: []string{"test-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:        "frontend",
+						SubjectRefs: []string{"team-frontend"},
+						RoleRef:     rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+					}},
+				}},
+			}
+		})
+
+		It("should accept templates binding only subjectRefs", func() {
+			Expect(validator.validateNewStructure(ctx, obj)).To(Succeed())
+		})
+
+		It("should reject invalid and duplicate subjectRefs", func() {
+			obj.Spec.Folders[0].RoleBindingTemplates[0].SubjectRefs = []string{"Team_Frontend", "team-frontend", "team-frontend"}
+
+			err := validator.validateNewStructure(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("subjectRefs[0]: Invalid value: \"Team_Frontend\""))
+			Expect(err.Error()).To(ContainSubstring("subjectRefs[2]: Duplicate value: \"team-frontend\""))
+		})
+
+		It("should warn about SubjectMappings that do not exist", func() {
+			Expect(validator.subjectMappingWarnings(ctx, obj)).To(ConsistOf(
+				"SubjectMapping 'team-frontend' referenced in subjectRefs does not exist; " +
+					"templates bind none of its subjects until it is created"))
+
+			mapping := &rbacv1alpha1.SubjectMapping{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-frontend"},
+				Spec: rbacv1alpha1.SubjectMappingSpec{
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "idp:frontend", APIGroup: "rbac.authorization.k8s.io"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, mapping)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, mapping))).To(Succeed())
+			})
+			Expect(validator.subjectMappingWarnings(ctx, obj)).To(BeEmpty())
+		})
+	})
+
+	Context("Unknown Subfolder Fields", func() {
+		rawTree := func(subfolders string) []byte {
+			return []byte(`{"apiVersion":"rbac.kubevirt.io/v1alpha1","kind":"FolderTree","metadata":{"name":"unknown-tree"},` +
+				`"spec":{"tree":{"name":"unknown-root","subfolders":[` + subfolders + `]},` +
+				`"folders":[{"name":"unknown-root","namespaces":["test-ns"]},{"name":"unknown-child","namespaces":["child-ns"]}]}}`)
+		}
+		requestContext := func(operation admissionv1.Operation, raw, oldRaw []byte) context.Context {
+			return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: operation,
+				Object:    runtime.RawExtension{Raw: raw},
+				OldObject: runtime.RawExtension{Raw: oldRaw},
+			}})
+		}
+		decode := func(raw []byte) *rbacv1alpha1.FolderTree {
+			tree := &rbacv1alpha1.FolderTree{}
+			Expect(json.Unmarshal(raw, tree)).To(Succeed())
+			return tree
+		}
+
+		BeforeEach(func() {
+			// The trees bind nothing, so no reviews are made, and no impersonation client is needed
+			validator.Options.PrivilegeCheckMode = PrivilegeCheckModeSubjectAccessReview
+		})
+
+		It("should reject a misspelled subfolders field", func() {
+			raw := rawTree(`{"name":"unknown-child","subfolder":[{"name":"unknown-grandchild"}]}`)
+			_, err := validator.ValidateCreate(requestContext(admissionv1.Create, raw, nil), decode(raw))
+			Expect(err).To(HaveOccurred())
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrInvalidStructure))
+			Expect(err.Error()).To(ContainSubstring("spec.tree.subfolders[0].subfolder"))
+		})
+
+		It("should report unknown fields in nested subfolders and additional trees", func() {
+			unknown, err := unknownFields([]byte(`{"spec":{` +
+				`"tree":{"name":"a","subfolders":[{"name":"b","subfolders":[{"name":"c","color":"red"}]}]},` +
+				`"trees":[{"name":"d","subfolders":[{"name":"e","children":[]}]}]}}`))
+			Expect(err).NotTo(HaveOccurred())
+			var paths []string
+			for _, unknownField := range unknown {
+				paths = append(paths, unknownField.Field)
+			}
+			Expect(paths).To(Equal([]string{
+				"spec.tree.subfolders[0].subfolders[0].color",
+				"spec.trees[0].subfolders[0].children",
+			}))
+		})
+
+		It("should report unknown fields in namespace entries", func() {
+			unknown, err := unknownFields([]byte(`{"spec":{"folders":[{"name":"a","namespaces":[` +
+				`"plain-ns",{"name":"object-ns","templateOverride":{}}]}]}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unknown).To(HaveLen(1))
+			Expect(unknown[0].Field).To(Equal("spec.folders[0].namespaces[1].templateOverride"))
+			Expect(unknown[0].Detail).To(ContainSubstring("namespace entries only support name and templateOverrides"))
+		})
+
+		It("should only warn about unknown fields already stored in the old object", func() {
+			oldRaw := rawTree(`{"name":"unknown-child","legacy":true}`)
+			raw := rawTree(`{"name":"unknown-child","legacy":true}`)
+			warnings, err := validator.ValidateUpdate(requestContext(admissionv1.Update, raw, oldRaw), decode(oldRaw), decode(raw))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement("spec.tree.subfolders[0].legacy: unknown field is ignored"))
+
+			raw = rawTree(`{"name":"unknown-child","legacy":true,"subfolder":[]}`)
+			_, err = validator.ValidateUpdate(requestContext(admissionv1.Update, raw, oldRaw), decode(oldRaw), decode(raw))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.tree.subfolders[0].subfolder"))
+		})
+
+		It("should accept subfolders with only known fields", func() {
+			raw := rawTree(`{"name":"unknown-child","subfolders":[]}`)
+			_, err := validator.ValidateCreate(requestContext(admissionv1.Create, raw, nil), decode(raw))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Rollback", func() {
+		var grants map[accessKey]bool
+
+		viewers := rbacv1alpha1.RoleBindingTemplate{
+			Name:     "viewers",
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		}
+		admins := rbacv1alpha1.RoleBindingTemplate{
+			Name:     "admins",
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "admins", APIGroup: rbacv1.GroupName}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+		}
+		spec := func(template rbacv1alpha1.RoleBindingTemplate) rbacv1alpha1.FolderTreeSpec {
+			return rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:                 "rollback-folder",
+					Namespaces:           []string{"rollback-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template},
+				}},
+			}
+		}
+
+		var rollbackValidator FolderTreeCustomValidator
+		var requestCtx context.Context
+
+		BeforeEach(func() {
+			grants = map[accessKey]bool{
+				{namespace: "rollback-ns", verb: "create", group: rbacv1.GroupName, resource: "rolebindings"}:                                           true,
+				{namespace: "rollback-ns", verb: "delete", group: rbacv1.GroupName, resource: "rolebindings", name: "foldertree-rollback-tree-viewers"}: true,
+				{namespace: "rollback-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}:                               true,
+			}
+
+			data, err := json.Marshal(spec(admins))
+			Expect(err).NotTo(HaveOccurred())
+			revision := &rbacv1alpha1.FolderTreeRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "rollback-tree-1",
+					Labels: map[string]string{"foldertree.rbac.kubevirt.io/tree": "rollback-tree"},
+				},
+				Revision: 1,
+				Data:     runtime.RawExtension{Raw: data},
+			}
+			// SubjectAccessReviews are answered from grants, so that no impersonation is needed
+			rollbackValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(createTestNamespace("rollback-ns"), revision).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							review, ok := obj.(*authorizationv1.SubjectAccessReview)
+							if !ok {
+								return c.Create(ctx, obj, opts...)
+							}
+							attributes := review.Spec.ResourceAttributes
+							review.Status.Allowed = grants[accessKey{
+								namespace: attributes.Namespace, verb: attributes.Verb, group: attributes.Group,
+								resource: attributes.Resource, subresource: attributes.Subresource, name: attributes.Name,
+							}]
+							return nil
+						},
+					}).
+					Build(),
+				// The rollback replaces the only RoleBinding, which needs no confirmation here
+				Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview, DestructiveChangeThreshold: -1},
+			}
+			requestCtx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: "jane"},
+			}})
+
+			obj = &rbacv1alpha1.FolderTree{ObjectMeta: metav1.ObjectMeta{Name: "rollback-tree"}, Spec: spec(viewers)}
+		})
+
+		// rollbackTo requests a rollback, with the spec hash recorded by the defaulter
+		rollbackTo := func(revision string) *rbacv1alpha1.FolderTree {
+			newObj := obj.DeepCopy()
+			newObj.Annotations = map[string]string{rbacv1alpha1.RollbackToAnnotation: revision}
+			defaulter := &FolderTreeCustomDefaulter{Reader: rollbackValidator.Client}
+			Expect(defaulter.recordRollbackSpecHash(ctx, obj, newObj)).To(Succeed())
+			return newObj
+		}
+
+		It("should reject a rollback to a revision that does not exist", func() {
+			_, err := rollbackValidator.ValidateUpdate(requestCtx, obj, rollbackTo("2"))
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrInvalidSpec))
+			Expect(err).To(MatchError(ContainSubstring("revision 2 of FolderTree 'rollback-tree' does not exist")))
+
+			_, err = rollbackValidator.ValidateUpdate(requestCtx, obj, rollbackTo("latest"))
+			Expect(err).To(MatchError(ContainSubstring("rollback target 'latest' is not a revision number")))
+		})
+
+		It("should reject spec changes together with a rollback", func() {
+			newObj := rollbackTo("1")
+			newObj.Spec.Priority = 1
+			_, err := rollbackValidator.ValidateUpdate(requestCtx, obj, newObj)
+			Expect(err).To(MatchError(ContainSubstring("the spec cannot be changed together with a rollback")))
+		})
+
+		It("should check the privileges for the spec the rollback restores", func() {
+			_, err := rollbackValidator.ValidateUpdate(requestCtx, obj, rollbackTo("1"))
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPrivilegeEscalation))
+			Expect(err).To(MatchError(ContainSubstring("admin")))
+
+			grants[accessKey{namespace: "rollback-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "admin"}] = true
+			_, err = rollbackValidator.ValidateUpdate(requestCtx, obj, rollbackTo("1"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a rollback whose revision changed after the defaulter recorded its hash", func() {
+			newObj := rollbackTo("1")
+			Expect(newObj.Annotations).To(HaveKey(rbacv1alpha1.RollbackSpecHashAnnotation))
+			grants[accessKey{namespace: "rollback-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "admin"}] = true
+
+			newObj.Annotations[rbacv1alpha1.RollbackSpecHashAnnotation] = "other-spec"
+			_, err := rollbackValidator.ValidateUpdate(requestCtx, obj, newObj)
+			Expect(err).To(MatchError(ContainSubstring("revision 1 changed while the rollback was admitted")))
+
+			delete(newObj.Annotations, rbacv1alpha1.RollbackSpecHashAnnotation)
+			_, err = rollbackValidator.ValidateUpdate(requestCtx, obj, newObj)
+			Expect(err).To(MatchError(ContainSubstring("revision 1 changed while the rollback was admitted")))
+		})
+
+		It("should keep the recorded hash only while the rollback is pending", func() {
+			defaulter := &FolderTreeCustomDefaulter{Reader: rollbackValidator.Client}
+			obj.Annotations = map[string]string{
+				rbacv1alpha1.RollbackToAnnotation:       "1",
+				rbacv1alpha1.RollbackSpecHashAnnotation: "recorded",
+			}
+			newObj := obj.DeepCopy()
+			newObj.Annotations[rbacv1alpha1.RollbackSpecHashAnnotation] = "forged"
+			Expect(defaulter.recordRollbackSpecHash(ctx, obj, newObj)).To(Succeed())
+			Expect(newObj.Annotations).To(HaveKeyWithValue(rbacv1alpha1.RollbackSpecHashAnnotation, "recorded"))
+
+			// A FolderTree cannot be created with a hash, since only updates validate rollbacks
+			createCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+			}})
+			Expect(defaulter.Default(createCtx, newObj)).To(Succeed())
+			Expect(newObj.Annotations).NotTo(HaveKey(rbacv1alpha1.RollbackSpecHashAnnotation))
+		})
+
+		It("should not look the revision up again for updates keeping the annotation", func() {
+			obj.Annotations = map[string]string{rbacv1alpha1.RollbackToAnnotation: "2"}
+			newObj := obj.DeepCopy()
+			newObj.Labels = map[string]string{"team": "platform"}
+			_, err := rollbackValidator.ValidateUpdate(requestCtx, obj, newObj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("OpenShift Groups", func() {
+		var groupsValidator FolderTreeCustomValidator
+
+		BeforeEach(func() {
+			groupKind := rbac.OpenShiftGroupListGVK.GroupVersion().WithKind("Group")
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(groupKind, meta.RESTScopeRoot)
+			group := &unstructured.Unstructured{}
+			group.SetGroupVersionKind(groupKind)
+			group.SetName("web-viewers")
+
+			groupsValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithRESTMapper(mapper).
+					WithObjects(group).
+					Build(),
+				Options: WebhookOptions{ValidateOpenShiftGroups: true},
+			}
+			obj = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "groups-tree"},
+			}
+			obj.Spec.Folders = []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"web-prod"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name: "viewers",
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: "{{ .folder.name }}-viewers", APIGroup: "rbac.authorization.k8s.io"},
+						{Kind: "Group", Name: "web-editors", APIGroup: "rbac.authorization.k8s.io"},
+						{Kind: "Group", Name: "system:authenticated", APIGroup: "rbac.authorization.k8s.io"},
+					},
+					RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}},
+			}}
+		})
+
+		It("should warn about Group subjects that do not exist in OpenShift", func() {
+			Expect(groupsValidator.openShiftGroupWarnings(ctx, obj)).To(ConsistOf(
+				"Group 'web-editors' does not exist in OpenShift; RoleBindings binding it grant no access until the Group is created"))
+		})
+
+		It("should not check Groups unless enabled", func() {
+			groupsValidator.Options.ValidateOpenShiftGroups = false
+			Expect(groupsValidator.openShiftGroupWarnings(ctx, obj)).To(BeEmpty())
+		})
+	})
+
+	Context("Subject Verifier", func() {
+		var verifierValidator FolderTreeCustomValidator
+
+		BeforeEach(func() {
+			verifierValidator = FolderTreeCustomValidator{
+				Client:  fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build(),
+				Options: WebhookOptions{SubjectVerifier: unresolvedVerifier{"web-viewers": true, "bob": true}},
+			}
+			obj = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "verifier-tree"},
+			}
+			obj.Spec.Folders = []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"web-prod"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name: "viewers",
+					Subjects: []rbacv1.Subject{
+						{Kind: "Group", Name: "{{ .folder.name }}-viewers", APIGroup: "rbac.authorization.k8s.io"},
+						{Kind: "User", Name: "alice", APIGroup: "rbac.authorization.k8s.io"},
+						{Kind: "User", Name: "bob", APIGroup: "rbac.authorization.k8s.io"},
+					},
+					RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}},
+			}}
+		})
+
+		It("should warn about subjects the verifier cannot resolve", func() {
+			Expect(verifierValidator.subjectVerifierWarnings(ctx, obj)).To(ConsistOf(
+				"Group web-viewers could not be resolved; RoleBindings binding it likely grant no access",
+				"User bob could not be resolved; RoleBindings binding it likely grant no access"))
+		})
+
+		It("should not verify subjects without a verifier", func() {
+			verifierValidator.Options.SubjectVerifier = nil
+			Expect(verifierValidator.subjectVerifierWarnings(ctx, obj)).To(BeEmpty())
+		})
+	})
+
+	Context("RoleBinding Names", func() {
+		BeforeEach(func() {
+			obj = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "names-tree"},
+			}
+			obj.Spec.Folders = []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"names-ns"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "viewers",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}},
+			}}
+		})
+
+		It("should reject templates whose RoleBinding names would be too long", func() {
+			obj.Name = strings.Repeat("a", 240)
+
+			err := validator.validateNewStructure(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.folders[0].roleBindingTemplates[0].name: Too long: may not be more than 1 "))
+
+			obj.Spec.Folders[0].RoleBindingTemplates[0].Name = "v"
+			Expect(validator.validateNewStructure(ctx, obj)).To(Succeed())
+		})
+
+		It("should warn about RoleBinding names taken by unmanaged RoleBindings", func() {
+			Expect(validator.nameConflictWarnings(ctx, obj)).To(BeEmpty())
+
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, createTestNamespace("names-ns")))).To(Succeed())
+			unmanaged := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "foldertree-names-tree-viewers", Namespace: "names-ns"},
+				Subjects:   []rbacv1.Subject{{Kind: "User", Name: "alice", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+			}
+			Expect(k8sClient.Create(ctx, unmanaged)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, unmanaged))).To(Succeed())
+			})
+
+			Expect(validator.nameConflictWarnings(ctx, obj)).To(ConsistOf(
+				"RoleBinding 'foldertree-names-tree-viewers' in namespace 'names-ns' already exists and is not managed by " +
+					"FolderTree 'names-tree'; it is not replaced until removed or adopted"))
+
+			unmanaged.Labels = map[string]string{"foldertree.rbac.kubevirt.io/tree": "names-tree"}
+			Expect(k8sClient.Update(ctx, unmanaged)).To(Succeed())
+			Expect(validator.nameConflictWarnings(ctx, obj)).To(BeEmpty())
+		})
+	})
+
+	Context("Clusters", func() {
+		var (
+			grants           map[accessKey]bool
+			clusterValidator FolderTreeCustomValidator
+			requestCtx       context.Context
+		)
+
+		clusterSecret := func(name string, labels map[string]string) *corev1.Secret {
+			return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster-secrets", Labels: labels}}
+		}
+		getSecret := func(name string) accessKey {
+			return accessKey{namespace: "cluster-secrets", verb: "get", resource: "secrets", name: name}
+		}
+
+		BeforeEach(func() {
+			grants = map[accessKey]bool{
+				{namespace: "clusters-ns", verb: "create", group: rbacv1.GroupName, resource: "rolebindings"}:             true,
+				{namespace: "clusters-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}: true,
+			}
+			// SubjectAccessReviews are answered from grants, so that no impersonation is needed
+			clusterValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(
+						createTestNamespace("clusters-ns"),
+						clusterSecret("east-1", map[string]string{rbac.ClusterSecretLabel: "true", "region": "east"}),
+						clusterSecret("east-2", map[string]string{rbac.ClusterSecretLabel: "true", "region": "east"}),
+						clusterSecret("east-unlabeled", map[string]string{"region": "east"}),
+					).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							review, ok := obj.(*authorizationv1.SubjectAccessReview)
+							if !ok {
+								return c.Create(ctx, obj, opts...)
+							}
+							attributes := review.Spec.ResourceAttributes
+							review.Status.Allowed = grants[accessKey{
+								namespace: attributes.Namespace, verb: attributes.Verb, group: attributes.Group,
+								resource: attributes.Resource, subresource: attributes.Subresource, name: attributes.Name,
+							}]
+							return nil
+						},
+					}).
+					Build(),
+				Options: WebhookOptions{
+					PrivilegeCheckMode:     PrivilegeCheckModeSubjectAccessReview,
+					ClusterSecretNamespace: "cluster-secrets",
+				},
+			}
+			requestCtx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "jane"},
+			}})
+
+			obj = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "clusters-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Clusters: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+					Folders: []rbacv1alpha1.Folder{{
+						Name:       "clusters-folder",
+						Namespaces: []string{"clusters-ns"},
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:     "viewers",
+							Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+							RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+						}},
+					}},
+				},
+			}
+		})
+
+		It("should reject an invalid cluster selector", func() {
+			obj.Spec.Clusters = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "region", Operator: metav1.LabelSelectorOpIn},
+			}}
+			_, err := clusterValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.clusters.matchExpressions[0].values"))
+		})
+
+		It("should require access to the Secret of every selected cluster", func() {
+			grants[getSecret("east-1")] = true
+			_, err := clusterValidator.ValidateCreate(requestCtx, obj)
+			Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPrivilegeEscalation))
+			Expect(err).To(MatchError(ContainSubstring("cannot get the Secret of cluster 'east-2' in namespace 'cluster-secrets'")))
+
+			grants[getSecret("east-2")] = true
+			warnings, err := clusterValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should not check cluster access again for updates that keep the spec", func() {
+			newObj := obj.DeepCopy()
+			newObj.Labels = map[string]string{"team": "platform"}
+			_, err := clusterValidator.ValidateUpdate(requestCtx, obj, newObj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should warn when no cluster matches", func() {
+			obj.Spec.Clusters = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "west"}}
+			warnings, err := clusterValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("no cluster Secret in namespace 'cluster-secrets' matches")))
+		})
+
+		It("should warn when multi-cluster propagation is disabled", func() {
+			clusterValidator.Options.ClusterSecretNamespace = ""
+			warnings, err := clusterValidator.ValidateCreate(requestCtx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("multi-cluster propagation is disabled")))
+		})
+	})
+
+	Context("Lifecycle Warnings", func() {
+		var lifecycleValidator FolderTreeCustomValidator
+
+		newTree := func(roleRef string, namespaces ...string) *rbacv1alpha1.FolderTree {
+			return &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "lifecycle-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{{
+						Name:       "lifecycle-folder",
+						Namespaces: namespaces,
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:     "viewers",
+							Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+							RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: roleRef},
+						}},
+					}},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			lifecycleValidator = FolderTreeCustomValidator{
+				Client: fake.NewClientBuilder().
+					WithScheme(clientgoscheme.Scheme).
+					WithObjects(
+						&corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{Name: "foldertree-limits", Namespace: "test-ns"},
+							Data:       map[string]string{"maxNamespaces": "10"},
+						},
+						&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}},
+						&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+							Name:        "legacy-view",
+							Annotations: map[string]string{DeprecatedClusterRoleAnnotation: "replaced by view, removed after 2026-12-31"},
+						}},
+					).
+					Build(),
+				Options: WebhookOptions{LimitsConfigMap: types.NamespacedName{Namespace: "test-ns", Name: "foldertree-limits"}},
+			}
+		})
+
+		It("should not warn about trees well within their limits", func() {
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "create", newTree("view", "ns-1"))).To(BeEmpty())
+		})
+
+		It("should warn when a tree uses more than 90% of a limit", func() {
+			namespaces := make([]string, 10)
+			for i := range namespaces {
+				namespaces[i] = fmt.Sprintf("ns-%d", i)
+			}
+			before := testutil.ToFloat64(metrics.WebhookWarnings.WithLabelValues("create", "ApproachingLimit"))
+
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "create", newTree("view", namespaces...))).To(ConsistOf(
+				"[ApproachingLimit] spec.folders: FolderTree 'lifecycle-tree' has 10 namespaces, 100% of the limit of 10"))
+			Expect(testutil.ToFloat64(metrics.WebhookWarnings.WithLabelValues("create", "ApproachingLimit"))).To(Equal(before + 1))
+
+			By("not warning at 90% of the limit")
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "create", newTree("view", namespaces[:9]...))).To(BeEmpty())
+		})
+
+		It("should warn about templates referencing ClusterRoles slated for removal", func() {
+			folderTree := newTree("legacy-view", "ns-1")
+			folderTree.Spec.GlobalRoleBindingTemplates = []rbacv1alpha1.RoleBindingTemplate{folderTree.Spec.Folders[0].RoleBindingTemplates[0]}
+			folderTree.Spec.GlobalRoleBindingTemplates[0].Name = "global-viewers"
+
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "update", folderTree)).To(ConsistOf(
+				"[DeprecatedClusterRole] spec.globalRoleBindingTemplates[0].roleRef.name: template 'global-viewers' references "+
+					"ClusterRole 'legacy-view', which is slated for removal: replaced by view, removed after 2026-12-31",
+				"[DeprecatedClusterRole] spec.folders[0].roleBindingTemplates[0].roleRef.name: template 'viewers' references "+
+					"ClusterRole 'legacy-view', which is slated for removal: replaced by view, removed after 2026-12-31"))
+		})
+
+		It("should warn about deprecated fields", func() {
+			original := deprecatedFields
+			DeferCleanup(func() { deprecatedFields = original })
+			deprecatedFields = []deprecatedField{{
+				path:    field.NewPath("spec", "tree"),
+				used:    func(folderTree *rbacv1alpha1.FolderTree) bool { return folderTree.Spec.Tree != nil },
+				message: "use spec.trees instead, spec.tree is removed in v1alpha2",
+			}}
+
+			folderTree := newTree("view", "ns-1")
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "create", folderTree)).To(BeEmpty())
+
+			folderTree.Spec.Tree = &rbacv1alpha1.TreeNode{Name: "lifecycle-folder"}
+			Expect(lifecycleValidator.lifecycleWarnings(ctx, "create", folderTree)).To(ConsistOf(
+				"[DeprecatedField] spec.tree: use spec.trees instead, spec.tree is removed in v1alpha2"))
+		})
+	})
 })
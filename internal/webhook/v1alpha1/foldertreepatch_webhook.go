@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// log is for logging in this package.
+var foldertreepatchlog = logf.Log.WithName("foldertreepatch-resource")
+
+// SetupFolderTreePatchWebhookWithManager registers the validating webhook for FolderTreePatch in the manager.
+func SetupFolderTreePatchWebhookWithManager(mgr ctrl.Manager, opts WebhookOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rbacv1alpha1.FolderTreePatch{}).
+		WithValidator(&FolderTreePatchCustomValidator{
+			FolderTree: &FolderTreeCustomValidator{
+				Client:               mgr.GetClient(),
+				APIReader:            mgr.GetAPIReader(),
+				Options:              opts,
+				Recorder:             mgr.GetEventRecorderFor("foldertreepatch-webhook"),
+				impersonationClients: newImpersonationClientCache(mgr.GetConfig(), mgr.GetScheme(), opts.ImpersonationClientCacheSize),
+			},
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-rbac-kubevirt-io-v1alpha1-foldertreepatch,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=rbac.kubevirt.io,resources=foldertreepatches,verbs=create;update;delete,versions=v1alpha1,name=foldertreepatch.rbac.kubevirt.io,admissionReviewVersions=v1
+
+// FolderTreePatchCustomValidator validates FolderTreePatches when they are created, updated, or deleted.
+// A patch is validated as the change it makes to its FolderTree: the folder must accept patches
+// from the namespace of the patch, the patched tree must be valid, and the user must hold the
+// permissions of the RoleBindings the patch adds or removes, exactly as for an update of the
+// FolderTree itself. Write access to the FolderTree is not required.
+//
+// +kubebuilder:object:generate=false
+type FolderTreePatchCustomValidator struct {
+	// FolderTree validates the FolderTree as patched
+	FolderTree *FolderTreeCustomValidator
+}
+
+var _ webhook.CustomValidator = &FolderTreePatchCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type FolderTreePatch.
+func (v *FolderTreePatchCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	patch, ok := obj.(*rbacv1alpha1.FolderTreePatch)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreePatch object but got %T", obj)
+	}
+	foldertreepatchlog.Info("Validation for FolderTreePatch upon creation", "namespace", patch.Namespace, "name", patch.Name)
+
+	return v.validatePatch(ctx, "create", nil, patch)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type FolderTreePatch.
+func (v *FolderTreePatchCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPatch, ok := oldObj.(*rbacv1alpha1.FolderTreePatch)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreePatch object for the oldObj but got %T", oldObj)
+	}
+	newPatch, ok := newObj.(*rbacv1alpha1.FolderTreePatch)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreePatch object for the newObj but got %T", newObj)
+	}
+	foldertreepatchlog.Info("Validation for FolderTreePatch upon update", "namespace", newPatch.Namespace, "name", newPatch.Name)
+
+	return v.validatePatch(ctx, "update", oldPatch, newPatch)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FolderTreePatch.
+// Deleting an approved patch removes its RoleBindings, which requires the permission to delete them.
+func (v *FolderTreePatchCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	patch, ok := obj.(*rbacv1alpha1.FolderTreePatch)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreePatch object but got %T", obj)
+	}
+	foldertreepatchlog.Info("Validation for FolderTreePatch upon deletion", "namespace", patch.Namespace, "name", patch.Name)
+
+	if patch.Status.Phase != rbacv1alpha1.PatchPhaseApproved {
+		return nil, nil
+	}
+	folderTree, err := v.getFolderTree(ctx, patch.Spec.TreeName)
+	if err != nil || folderTree == nil {
+		return nil, err
+	}
+	patched, err := rbac.ApplyFolderTreePatch(folderTree, patch)
+	if err != nil {
+		// The folder is gone, and so are the RoleBindings of the patch
+		return nil, nil
+	}
+	if err := v.FolderTree.validateRBACAuthorizationUpdate(ctx, patched, folderTree); err != nil {
+		return nil, metrics.RecordRejection("delete", metrics.RejectionReasonPrivilegeEscalation, validation.Reject(validation.ErrPrivilegeEscalation, err))
+	}
+	return nil, nil
+}
+
+// validatePatch validates the change a created or updated FolderTreePatch makes to its FolderTree
+func (v *FolderTreePatchCustomValidator) validatePatch(ctx context.Context, operation string, oldPatch, newPatch *rbacv1alpha1.FolderTreePatch) (admission.Warnings, error) {
+	folderTree, err := v.getFolderTree(ctx, newPatch.Spec.TreeName)
+	if err != nil {
+		return nil, err
+	}
+	if folderTree == nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec,
+			fmt.Errorf("FolderTree '%s' not found", newPatch.Spec.TreeName)))
+	}
+
+	var folder *rbacv1alpha1.Folder
+	for i := range folderTree.Spec.Folders {
+		if folderTree.Spec.Folders[i].Name == newPatch.Spec.FolderName {
+			folder = &folderTree.Spec.Folders[i]
+		}
+	}
+	if folder == nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec,
+			fmt.Errorf("folder '%s' not found in FolderTree '%s'", newPatch.Spec.FolderName, folderTree.Name)))
+	}
+	if !folder.AcceptsPatchesFrom(newPatch.Namespace) {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec,
+			fmt.Errorf("folder '%s' does not accept patches from namespace '%s'", folder.Name, newPatch.Namespace)))
+	}
+	// Namespace groups receive the templates of their descendants, in namespaces the folder cannot accept patches for
+	if group, ok := rbac.NamespaceGroupAbove(&folderTree.Spec, folder.Name); ok && len(newPatch.Spec.RoleBindingTemplates) > 0 {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec,
+			fmt.Errorf("folder '%s' is below namespace group '%s', whose namespaces would receive the role binding templates of the patch",
+				folder.Name, group)))
+	}
+
+	// Validate the templates as the controller applies them, with spec.defaults filled in
+	newTree, err := rbac.ApplyFolderTreePatch(folderTree, newPatch)
+	if err != nil {
+		return nil, metrics.RecordRejection(operation, metrics.RejectionReasonBusinessLogic, validation.Reject(validation.ErrInvalidSpec, err))
+	}
+	newTree = rbac.WithDefaults(newTree)
+	oldTree := rbac.WithDefaults(folderTree)
+	if oldPatch != nil {
+		if patched, err := rbac.ApplyFolderTreePatch(folderTree, oldPatch); err == nil {
+			oldTree = rbac.WithDefaults(patched)
+		}
+	}
+
+	// The change is validated like an update of the FolderTree, except that the user needs the
+	// permissions of the RoleBindings the patch changes, but not access to the FolderTree. Unlike
+	// for FolderTrees, the API server cannot reject duplicate names across the tree and the patch.
+	return v.FolderTree.validateTreeChange(ctx, treeChange{
+		Operation:      operation,
+		Old:            oldTree,
+		New:            newTree,
+		Patch:          newPatch,
+		ListsValidated: false,
+	})
+}
+
+// getFolderTree returns the FolderTree a patch targets without the RoleBindings the controller
+// applied, so that only the changes of the patch are validated, or nil if it does not exist
+func (v *FolderTreePatchCustomValidator) getFolderTree(ctx context.Context, name string) (*rbacv1alpha1.FolderTree, error) {
+	folderTree := &rbacv1alpha1.FolderTree{}
+	if err := v.FolderTree.Client.Get(ctx, types.NamespacedName{Name: name}, folderTree); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get FolderTree '%s': %v", name, err)
+	}
+	folderTree.Status = rbacv1alpha1.FolderTreeStatus{}
+	return folderTree, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+var _ = Describe("FolderTreePatch Webhook", func() {
+	var (
+		ctx       context.Context
+		grants    map[accessKey]bool
+		validator *FolderTreePatchCustomValidator
+		patch     *rbacv1alpha1.FolderTreePatch
+	)
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "patched-tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			Folders: []rbacv1alpha1.Folder{{
+				Name:            "team-folder",
+				PatchNamespaces: []string{"team-ns"},
+				Namespaces:      []string{"folder-ns"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "viewers",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+				}},
+			}},
+		},
+	}
+
+	// grant allows the user the given verb on the RoleBindings of the patched folder in a namespace
+	grant := func(namespace, verb string) {
+		if verb == "delete" {
+			grants[accessKey{namespace: namespace, verb: verb, group: rbacv1.GroupName, resource: "rolebindings", name: "foldertree-patched-tree-editors"}] = true
+			return
+		}
+		grants[accessKey{namespace: namespace, verb: verb, group: rbacv1.GroupName, resource: "rolebindings"}] = true
+		grants[accessKey{namespace: namespace, verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "edit"}] = true
+		grants[accessKey{namespace: namespace, verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"}] = true
+	}
+
+	// requestContext returns the context of an admission request of the user jane
+	requestContext := func(operation admissionv1.Operation) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: "jane"},
+		}})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		grants = map[accessKey]bool{}
+
+		// SubjectAccessReviews are answered from grants, so that no impersonation is needed
+		c := fake.NewClientBuilder().
+			WithScheme(clientgoscheme.Scheme).
+			WithObjects(folderTree.DeepCopy(), createTestNamespace("folder-ns"), createTestNamespace("added-ns"), createTestNamespace("team-ns"),
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "foldertree-patched-tree-editors", Namespace: "folder-ns"}}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					review, ok := obj.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return c.Create(ctx, obj, opts...)
+					}
+					attributes := review.Spec.ResourceAttributes
+					review.Status.Allowed = grants[accessKey{
+						namespace: attributes.Namespace, verb: attributes.Verb, group: attributes.Group,
+						resource: attributes.Resource, subresource: attributes.Subresource, name: attributes.Name,
+					}]
+					return nil
+				},
+			}).
+			Build()
+		validator = &FolderTreePatchCustomValidator{FolderTree: &FolderTreeCustomValidator{
+			Client:  c,
+			Options: WebhookOptions{PrivilegeCheckMode: PrivilegeCheckModeSubjectAccessReview},
+		}}
+
+		patch = &rbacv1alpha1.FolderTreePatch{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-ns"},
+			Spec: rbacv1alpha1.FolderTreePatchSpec{
+				TreeName:   "patched-tree",
+				FolderName: "team-folder",
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "editors",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "editors", APIGroup: rbacv1.GroupName}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+				}},
+			},
+		}
+	})
+
+	It("should only require the permissions of the RoleBindings the patch adds", func() {
+		grant("folder-ns", "create")
+		delete(grants, accessKey{namespace: "folder-ns", verb: "bind", group: rbacv1.GroupName, resource: "clusterroles", name: "view"})
+
+		_, err := validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rejecting namespaces the user may not grant access to, including for the templates of the FolderTree")
+		updated := patch.DeepCopy()
+		updated.Spec.Namespaces = []string{"added-ns"}
+		_, err = validator.ValidateUpdate(requestContext(admissionv1.Update), patch, updated)
+		Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPrivilegeEscalation))
+
+		grant("added-ns", "create")
+		_, err = validator.ValidateUpdate(requestContext(admissionv1.Update), patch, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject patches the folder does not accept", func() {
+		patch.Namespace = "other-ns"
+		_, err := validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).To(MatchError(ContainSubstring("folder 'team-folder' does not accept patches from namespace 'other-ns'")))
+
+		patch.Namespace = "team-ns"
+		patch.Spec.TreeName = "missing-tree"
+		_, err = validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).To(MatchError(ContainSubstring("FolderTree 'missing-tree' not found")))
+	})
+
+	It("should reject templates for folders below a namespace group", func() {
+		groupedTree := folderTree.DeepCopy()
+		groupedTree.Name = "grouped-tree"
+		groupedTree.Spec.Tree = &rbacv1alpha1.TreeNode{Name: "group", Subfolders: []rbacv1alpha1.TreeNode{{Name: "team-folder"}}}
+		groupedTree.Spec.Folders = append(groupedTree.Spec.Folders, rbacv1alpha1.Folder{
+			Name: "group", InheritNamespaces: true, Namespaces: []string{"added-ns"},
+		})
+		Expect(validator.FolderTree.Client.Create(ctx, groupedTree)).To(Succeed())
+		grant("folder-ns", "create")
+		grant("added-ns", "create")
+
+		patch.Spec.TreeName = "grouped-tree"
+		_, err := validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).To(MatchError(ContainSubstring("folder 'team-folder' is below namespace group 'group'")))
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrInvalidSpec))
+	})
+
+	It("should reject patches making the FolderTree invalid", func() {
+		grant("folder-ns", "create")
+
+		patch.Spec.RoleBindingTemplates[0].Name = "viewers"
+		_, err := validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).To(MatchError(ContainSubstring("viewers")))
+
+		patch.Spec.RoleBindingTemplates[0].Name = "editors"
+		patch.Spec.Namespaces = []string{"missing-ns"}
+		_, err = validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(err).To(MatchError(ContainSubstring("namespace 'missing-ns' does not exist")))
+	})
+
+	It("should validate the patched FolderTree like an update of the FolderTree", func() {
+		grant("folder-ns", "create")
+
+		By("rejecting templates that break the policy rules")
+		wildcard := patch.DeepCopy()
+		wildcard.Spec.RoleBindingTemplates[0].Subjects = []rbacv1.Subject{{Kind: "Group", Name: "system:authenticated", APIGroup: rbacv1.GroupName}}
+		_, err := validator.ValidateCreate(requestContext(admissionv1.Create), wildcard)
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPolicyViolation))
+		Expect(err.Error()).To(ContainSubstring("WildcardSubject"))
+
+		By("rejecting patches that exceed the RoleBinding fan-out")
+		validator.FolderTree.Options.MaxRoleBindings = 1
+		_, err = validator.ValidateCreate(requestContext(admissionv1.Create), patch)
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrFanOutExceeded))
+		Expect(err.Error()).To(ContainSubstring("would produce 2 RoleBindings"))
+	})
+
+	It("should require deleting the RoleBindings of an approved patch to delete it", func() {
+		patch.Status.Phase = rbacv1alpha1.PatchPhaseApproved
+		_, err := validator.ValidateDelete(requestContext(admissionv1.Delete), patch)
+		Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+
+		grant("folder-ns", "delete")
+		_, err = validator.ValidateDelete(requestContext(admissionv1.Delete), patch)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("allowing the deletion of patches that were never merged")
+		delete(grants, accessKey{namespace: "folder-ns", verb: "delete", group: rbacv1.GroupName, resource: "rolebindings", name: "foldertree-patched-tree-editors"})
+		patch.Status.Phase = rbacv1alpha1.PatchPhasePending
+		_, err = validator.ValidateDelete(requestContext(admissionv1.Delete), patch)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// SetupFolderTreeRevisionWebhookWithManager registers the validating webhook for FolderTreeRevision in the manager.
+func SetupFolderTreeRevisionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rbacv1alpha1.FolderTreeRevision{}).
+		WithValidator(&FolderTreeRevisionCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-rbac-kubevirt-io-v1alpha1-foldertreerevision,mutating=false,failurePolicy=fail,sideEffects=None,groups=rbac.kubevirt.io,resources=foldertreerevisions,verbs=update,versions=v1alpha1,name=foldertreerevision.rbac.kubevirt.io,admissionReviewVersions=v1
+
+// FolderTreeRevisionCustomValidator keeps FolderTreeRevisions immutable. Rollbacks restore the data of
+// a revision, so changing it would restore a spec the FolderTree webhook never validated. The data is
+// schemaless in the CRD, which CEL validation rules cannot compare, so it is checked here.
+//
+// +kubebuilder:object:generate=false
+type FolderTreeRevisionCustomValidator struct{}
+
+var _ webhook.CustomValidator = &FolderTreeRevisionCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type FolderTreeRevision.
+func (v *FolderTreeRevisionCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type FolderTreeRevision.
+func (v *FolderTreeRevisionCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRevision, ok := oldObj.(*rbacv1alpha1.FolderTreeRevision)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreeRevision object for the oldObj but got %T", oldObj)
+	}
+	newRevision, ok := newObj.(*rbacv1alpha1.FolderTreeRevision)
+	if !ok {
+		return nil, fmt.Errorf("expected a FolderTreeRevision object for the newObj but got %T", newObj)
+	}
+
+	var allErrors field.ErrorList
+	if newRevision.Revision != oldRevision.Revision {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("revision"), "a FolderTreeRevision is immutable"))
+	}
+	if newRevision.Generation != oldRevision.Generation {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("generation"), "a FolderTreeRevision is immutable"))
+	}
+	if !bytes.Equal(newRevision.Data.Raw, oldRevision.Data.Raw) {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("data"), "a FolderTreeRevision is immutable"))
+	}
+	treeLabel := "foldertree.rbac.kubevirt.io/tree"
+	if newRevision.Labels[treeLabel] != oldRevision.Labels[treeLabel] {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("metadata", "labels").Key(treeLabel),
+			"the FolderTree of a FolderTreeRevision cannot be changed"))
+	}
+	if len(allErrors) > 0 {
+		return nil, metrics.RecordRejection("update", metrics.RejectionReasonStructure,
+			validation.Reject(validation.ErrInvalidStructure, allErrors.ToAggregate()))
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type FolderTreeRevision.
+func (v *FolderTreeRevisionCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+var _ = Describe("FolderTreeRevision Webhook", func() {
+	var (
+		validator *FolderTreeRevisionCustomValidator
+		revision  *rbacv1alpha1.FolderTreeRevision
+	)
+
+	BeforeEach(func() {
+		validator = &FolderTreeRevisionCustomValidator{}
+		revision = &rbacv1alpha1.FolderTreeRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "revised-tree-1",
+				Labels: map[string]string{"foldertree.rbac.kubevirt.io/tree": "revised-tree"},
+			},
+			Revision:   1,
+			Generation: 3,
+			Data:       runtime.RawExtension{Raw: []byte(`{"folders":[{"name":"folder","namespaces":["team-ns"]}]}`)},
+		}
+	})
+
+	It("should allow metadata changes that keep the snapshot", func() {
+		updated := revision.DeepCopy()
+		updated.Annotations = map[string]string{"note": "before the migration"}
+		_, err := validator.ValidateUpdate(context.Background(), revision, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject changes of the snapshot", func() {
+		updated := revision.DeepCopy()
+		updated.Data.Raw = []byte(`{"folders":[{"name":"folder","namespaces":["kube-system"]}]}`)
+		updated.Revision = 2
+		_, err := validator.ValidateUpdate(context.Background(), revision, updated)
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring("data: Forbidden: a FolderTreeRevision is immutable"))
+		Expect(err.Error()).To(ContainSubstring("revision: Forbidden: a FolderTreeRevision is immutable"))
+
+		updated = revision.DeepCopy()
+		updated.Labels["foldertree.rbac.kubevirt.io/tree"] = "other-tree"
+		_, err = validator.ValidateUpdate(context.Background(), revision, updated)
+		Expect(err).To(MatchError(ContainSubstring("the FolderTree of a FolderTreeRevision cannot be changed")))
+	})
+})
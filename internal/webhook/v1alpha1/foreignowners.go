@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// AllowForeignOwnedNamespacesAnnotation lists, comma-separated, the namespaces owned by another
+// tenancy system that a FolderTree manages RBAC in anyway
+const AllowForeignOwnedNamespacesAnnotation = "rbac.kubevirt.io/allow-foreign-owned-namespaces"
+
+// validateForeignOwners rejects namespaces newly added to the folders of a FolderTree that carry
+// one of the configured foreign owner keys as a label or annotation, e.g. the tenant label of
+// Capsule, unless the FolderTree lists them in AllowForeignOwnedNamespacesAnnotation. Two tenancy
+// systems managing RBAC in the same namespace would keep overwriting each other's RoleBindings.
+// Namespaces already in the old FolderTree are not checked again, and missing namespaces are left
+// to validateNamespacesExist.
+func (v *FolderTreeCustomValidator) validateForeignOwners(ctx context.Context, newFolderTree, oldFolderTree *rbacv1alpha1.FolderTree) error {
+	if len(v.Options.ForeignOwnerKeys) == 0 {
+		return nil
+	}
+	oldNamespaces := v.collectNamespaces(oldFolderTree)
+	allowed := strings.Split(newFolderTree.Annotations[AllowForeignOwnedNamespacesAnnotation], ",")
+	for i := range allowed {
+		allowed[i] = strings.TrimSpace(allowed[i])
+	}
+
+	var allErrors field.ErrorList
+	for i, folder := range newFolderTree.Spec.Folders {
+		for j, ns := range folder.Namespaces {
+			if oldNamespaces[ns] || slices.Contains(allowed, ns) {
+				continue
+			}
+			fldPath := field.NewPath("spec", "folders").Index(i).Child("namespaces").Index(j)
+			namespace := &corev1.Namespace{}
+			if err := v.Client.Get(ctx, types.NamespacedName{Name: ns}, namespace); apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				allErrors = append(allErrors, field.InternalError(fldPath, fmt.Errorf("failed to check the owner of namespace '%s': %v", ns, err)))
+				continue
+			}
+			if key, owner, ok := v.foreignOwner(namespace); ok {
+				allErrors = append(allErrors, field.Forbidden(fldPath, fmt.Sprintf(
+					"namespace '%s' is owned by another tenancy system (%s=%s); list it in the '%s' annotation to manage its RBAC anyway",
+					ns, key, owner, AllowForeignOwnedNamespacesAnnotation)))
+			}
+		}
+	}
+	return allErrors.ToAggregate()
+}
+
+// foreignOwner returns the first configured foreign owner key a namespace carries as a label or
+// annotation, together with its value
+func (v *FolderTreeCustomValidator) foreignOwner(namespace *corev1.Namespace) (string, string, bool) {
+	for _, key := range v.Options.ForeignOwnerKeys {
+		if owner, ok := namespace.Labels[key]; ok {
+			return key, owner, true
+		}
+		if owner, ok := namespace.Annotations[key]; ok {
+			return key, owner, true
+		}
+	}
+	return "", "", false
+}
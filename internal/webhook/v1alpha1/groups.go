@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups=user.openshift.io,resources=groups,verbs=list
+
+// openShiftGroupWarnings warns about Group subjects that do not exist as OpenShift Groups when
+// WebhookOptions.ValidateOpenShiftGroups is set. They are not rejected, since groups synced from an
+// identity provider may be created after the FolderTree binding them.
+func (v *FolderTreeCustomValidator) openShiftGroupWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	if !v.Options.ValidateOpenShiftGroups {
+		return nil
+	}
+	groups, err := rbac.ListOpenShiftGroups(ctx, v.Client)
+	if err != nil {
+		foldertreelog.Info("Could not check Group subjects against OpenShift Groups", "error", err)
+		return nil
+	}
+	subjectMappings, err := rbac.ListSubjectMappings(ctx, v.Client)
+	if err != nil {
+		foldertreelog.Info("Could not check the Group subjects of SubjectMappings", "error", err)
+	}
+
+	builder := &rbac.RoleBindingBuilder{
+		FolderTree:         folderTree,
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		SubjectMappings:    subjectMappings,
+		Cache:              v.Options.DesiredStateCache,
+	}
+	desired, err := rbac.CalculateDesiredRoleBindings(folderTree, builder)
+	if err != nil {
+		foldertreelog.Info("Could not calculate the RoleBindings to check Group subjects", "error", err)
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, group := range groups.Dangling(desired) {
+		warnings = append(warnings, fmt.Sprintf(
+			"Group '%s' does not exist in OpenShift; RoleBindings binding it grant no access until the Group is created", group))
+	}
+	return warnings
+}
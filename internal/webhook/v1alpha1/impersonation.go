@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultImpersonationClientCacheSize is the number of impersonation clients kept when
+	// WebhookOptions.ImpersonationClientCacheSize is not set
+	defaultImpersonationClientCacheSize = 128
+
+	// defaultValidationWorkers is the number of operations validated concurrently when
+	// WebhookOptions.ValidationWorkers is not set
+	defaultValidationWorkers = 8
+)
+
+// impersonationClientCache keeps the most recently used impersonation clients, so that
+// repeated admission requests of the same user do not build a new REST client each time
+type impersonationClientCache struct {
+	config  *rest.Config
+	scheme  *runtime.Scheme
+	clients *lru.Cache
+}
+
+// newImpersonationClientCache returns a cache of up to size clients impersonating users on top of config
+func newImpersonationClientCache(config *rest.Config, scheme *runtime.Scheme, size int) *impersonationClientCache {
+	if size <= 0 {
+		size = defaultImpersonationClientCacheSize
+	}
+	return &impersonationClientCache{config: config, scheme: scheme, clients: lru.New(size)}
+}
+
+// get returns the client impersonating the user, creating it on a cache miss
+func (c *impersonationClientCache) get(userInfo authenticationv1.UserInfo) (client.Client, error) {
+	key := impersonationKey(userInfo)
+	if cached, ok := c.clients.Get(key); ok {
+		return cached.(client.Client), nil
+	}
+
+	impersonationClient, err := newImpersonationClient(c.config, c.scheme, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	c.clients.Add(key, impersonationClient)
+	return impersonationClient, nil
+}
+
+// impersonationKey identifies the identity an impersonation client acts as. Groups are
+// sorted since their order does not change what the user is allowed to do.
+func impersonationKey(userInfo authenticationv1.UserInfo) string {
+	groups := slices.Clone(userInfo.Groups)
+	slices.Sort(groups)
+	return strings.Join(append([]string{userInfo.Username, userInfo.UID}, groups...), "\x00")
+}
+
+// newImpersonationClient creates a Kubernetes client that impersonates the specified user
+func newImpersonationClient(config *rest.Config, scheme *runtime.Scheme, userInfo authenticationv1.UserInfo) (client.Client, error) {
+	config = rest.CopyConfig(config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: userInfo.Username,
+		Groups:   userInfo.Groups,
+		UID:      userInfo.UID,
+	}
+
+	impersonationClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonation client: %v", err)
+	}
+	return impersonationClient, nil
+}
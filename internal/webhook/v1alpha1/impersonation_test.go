@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// newTestUser provisions a user authenticating with a client certificate against the envtest API
+// server and returns a client acting as that user. Requests of the client reach the webhook as real
+// admission requests, so the impersonation-based privilege escalation check runs end to end.
+func newTestUser(name string, groups ...string) client.Client {
+	user, err := testEnv.AddUser(envtest.User{Name: name, Groups: groups}, cfg)
+	Expect(err).NotTo(HaveOccurred())
+	userClient, err := client.New(user.Config(), client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	return userClient
+}
+
+var _ = Describe("FolderTree Webhook - Impersonation", Ordered, func() {
+	const (
+		teamNamespace  = "impersonation-team"
+		otherNamespace = "impersonation-other"
+		editorRole     = "impersonation-foldertree-editor"
+	)
+	var (
+		// alice administers teamNamespace only
+		alice client.Client
+		// bob administers both namespaces through the platform group
+		bob client.Client
+	)
+
+	// grant binds a ClusterRole in a namespace, or cluster-wide when namespace is empty
+	grant := func(namespace, clusterRole string, subject rbacv1.Subject) {
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole}
+		name := "impersonation-" + clusterRole + "-" + subject.Name
+		var binding client.Object
+		if namespace == "" {
+			binding = &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}, Subjects: []rbacv1.Subject{subject}, RoleRef: roleRef}
+		} else {
+			binding = &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Subjects: []rbacv1.Subject{subject}, RoleRef: roleRef}
+		}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, binding))).To(Succeed())
+	}
+
+	// newTree returns a FolderTree binding clusterRole to the viewers group in namespaces
+	newTree := func(name, clusterRole string, namespaces ...string) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       name,
+					Namespaces: namespaces,
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+						RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole},
+					}},
+				}},
+			},
+		}
+	}
+
+	BeforeAll(func() {
+		for _, namespace := range []string{teamNamespace, otherNamespace} {
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, createTestNamespace(namespace)))).To(Succeed())
+		}
+		editor := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: editorRole},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{rbacv1alpha1.GroupVersion.Group},
+				Resources: []string{"foldertrees"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			}},
+		}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, editor))).To(Succeed())
+
+		aliceSubject := rbacv1.Subject{Kind: "User", Name: "alice", APIGroup: rbacv1.GroupName}
+		platformSubject := rbacv1.Subject{Kind: "Group", Name: "platform", APIGroup: rbacv1.GroupName}
+		grant("", editorRole, aliceSubject)
+		grant("", editorRole, platformSubject)
+		grant(teamNamespace, "admin", aliceSubject)
+		grant(teamNamespace, "admin", platformSubject)
+		grant(otherNamespace, "admin", platformSubject)
+
+		alice = newTestUser("alice")
+		bob = newTestUser("bob", "platform")
+	})
+
+	AfterEach(func() {
+		var folderTreeList rbacv1alpha1.FolderTreeList
+		Expect(k8sClient.List(ctx, &folderTreeList)).To(Succeed())
+		for i := range folderTreeList.Items {
+			if folderTree := &folderTreeList.Items[i]; folderTree.Labels["test"] == "impersonation" {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, folderTree))).To(Succeed())
+			}
+		}
+	})
+
+	// create creates the FolderTree as the given user, retrying while the webhook's cache has not
+	// seen the namespaces yet
+	create := func(user client.Client, folderTree *rbacv1alpha1.FolderTree) error {
+		folderTree.Labels = map[string]string{"test": "impersonation"}
+		var err error
+		Eventually(func() string {
+			err = user.Create(ctx, folderTree.DeepCopy())
+			if err == nil {
+				return ""
+			}
+			return err.Error()
+		}).ShouldNot(ContainSubstring("does not exist"))
+		return err
+	}
+
+	It("should admit FolderTrees granting roles the user holds in its namespaces", func() {
+		Expect(create(alice, newTree("impersonation-view", "view", teamNamespace))).To(Succeed())
+	})
+
+	It("should reject FolderTrees granting roles beyond the user's permissions", func() {
+		err := create(alice, newTree("impersonation-cluster-admin", "cluster-admin", teamNamespace))
+		Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+	})
+
+	It("should reject FolderTrees reaching namespaces the user does not administer", func() {
+		err := create(alice, newTree("impersonation-other", "view", teamNamespace, otherNamespace))
+		Expect(err).To(MatchError(ContainSubstring("privilege escalation prevented")))
+		Expect(err).To(MatchError(ContainSubstring(otherNamespace)))
+	})
+
+	It("should impersonate the groups of the user", func() {
+		Expect(create(bob, newTree("impersonation-group", "view", teamNamespace, otherNamespace))).To(Succeed())
+	})
+
+	It("should check updates against the new namespaces", func() {
+		folderTree := newTree("impersonation-update", "view", teamNamespace)
+		Expect(create(alice, folderTree)).To(Succeed())
+
+		updated := &rbacv1alpha1.FolderTree{}
+		Expect(alice.Get(ctx, client.ObjectKeyFromObject(folderTree), updated)).To(Succeed())
+		updated.Spec.Folders[0].Namespaces = []string{teamNamespace, otherNamespace}
+		Expect(alice.Update(ctx, updated)).To(MatchError(ContainSubstring("privilege escalation prevented")))
+
+		Expect(bob.Get(ctx, client.ObjectKeyFromObject(folderTree), updated)).To(Succeed())
+		updated.Spec.Folders[0].Namespaces = []string{teamNamespace, otherNamespace}
+		Expect(bob.Update(ctx, updated)).To(Succeed())
+	})
+
+	It("should only allow deleting FolderTrees whose RoleBindings the user may delete", func() {
+		folderTree := newTree("impersonation-delete", "view", teamNamespace, otherNamespace)
+		Expect(create(bob, folderTree)).To(Succeed())
+
+		// No controller runs in this suite, so the RoleBinding it would create is created here
+		managed := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foldertree-impersonation-delete-viewers",
+				Namespace: otherNamespace,
+				Labels:    map[string]string{"foldertree.rbac.kubevirt.io/tree": folderTree.Name},
+			},
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		}
+		Expect(k8sClient.Create(ctx, managed)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, managed))).To(Succeed())
+		})
+
+		Expect(alice.Delete(ctx, folderTree)).To(MatchError(ContainSubstring("privilege escalation prevented")))
+		Expect(bob.Delete(ctx, folderTree)).To(Succeed())
+	})
+
+	It("should allow deleting FolderTrees whose RoleBindings were never created", func() {
+		folderTree := newTree("impersonation-never-applied", "view", teamNamespace, otherNamespace)
+		Expect(create(bob, folderTree)).To(Succeed())
+
+		Expect(alice.Delete(ctx, folderTree)).To(Succeed())
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+const (
+	// LimitOverrideAnnotationPrefix prefixes the annotations overriding a limit of the limits
+	// ConfigMap for a single FolderTree, e.g. limits.rbac.kubevirt.io/maxNamespaces: "2000". Only
+	// members of the groups in LimitOverrideGroupsKey may set or change them.
+	LimitOverrideAnnotationPrefix = "limits.rbac.kubevirt.io/"
+
+	// LimitOverrideGroupsKey is the key of the limits ConfigMap listing the groups, separated by
+	// commas or newlines, whose members may set limit override annotations
+	LimitOverrideGroupsKey = "overrideGroups"
+)
+
+// limitKeys are the limits that the limits ConfigMap and the override annotations may set
+var limitKeys = []string{"maxFolders", "maxTreeNodes", "maxNamespaces", "maxRoleBindingTemplates", "maxNamespaceGroupRoleBindings"}
+
+// limitsConfig is the content of the limits ConfigMap
+type limitsConfig struct {
+	limits         validation.Limits
+	overrideGroups []string
+}
+
+// setLimit sets the limit of a key of limitKeys from its string value
+func setLimit(limits *validation.Limits, key, value string) error {
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 1 {
+		return fmt.Errorf("%s must be a positive integer, got %q", key, value)
+	}
+	switch key {
+	case "maxFolders":
+		limits.MaxFolders = limit
+	case "maxTreeNodes":
+		limits.MaxTreeNodes = limit
+	case "maxNamespaces":
+		limits.MaxNamespaces = limit
+	case "maxRoleBindingTemplates":
+		limits.MaxRoleBindingTemplates = limit
+	case "maxNamespaceGroupRoleBindings":
+		limits.MaxNamespaceGroupRoleBindings = limit
+	default:
+		return fmt.Errorf("unknown limit %q, expected one of %s", key, strings.Join(limitKeys, ", "))
+	}
+	return nil
+}
+
+// loadLimitsConfig reads the configured limits ConfigMap on every admission request, so changes
+// apply without a restart. No ConfigMap configured, or a missing one, applies the default limits;
+// an invalid value is an error, so that a typo does not silently lift a limit.
+func (v *FolderTreeCustomValidator) loadLimitsConfig(ctx context.Context) (limitsConfig, error) {
+	key := v.Options.LimitsConfigMap
+	if key.Name == "" {
+		return limitsConfig{}, nil
+	}
+
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, configMap); apierrors.IsNotFound(err) {
+		foldertreelog.Info("Limits ConfigMap not found, applying the default limits", "configMap", key.String())
+		return limitsConfig{}, nil
+	} else if err != nil {
+		return limitsConfig{}, fmt.Errorf("failed to read the limits from ConfigMap %s: %v", key, err)
+	}
+
+	var config limitsConfig
+	for _, limitKey := range limitKeys {
+		if value, ok := configMap.Data[limitKey]; ok {
+			if err := setLimit(&config.limits, limitKey, value); err != nil {
+				return limitsConfig{}, fmt.Errorf("invalid limits in ConfigMap %s: %v", key, err)
+			}
+		}
+	}
+	for _, group := range strings.FieldsFunc(configMap.Data[LimitOverrideGroupsKey], func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		if group = strings.TrimSpace(group); group != "" {
+			config.overrideGroups = append(config.overrideGroups, group)
+		}
+	}
+	return config, nil
+}
+
+// limitOverrides returns the limit override annotations of a FolderTree
+func limitOverrides(folderTree *rbacv1alpha1.FolderTree) map[string]string {
+	overrides := make(map[string]string)
+	if folderTree == nil {
+		return overrides
+	}
+	for key, value := range folderTree.Annotations {
+		if limit, ok := strings.CutPrefix(key, LimitOverrideAnnotationPrefix); ok {
+			overrides[limit] = value
+		}
+	}
+	return overrides
+}
+
+// limitsFor returns the limits a FolderTree is validated against: those of the limits ConfigMap
+// with the override annotations of the FolderTree applied
+func limitsFor(config limitsConfig, folderTree *rbacv1alpha1.FolderTree) (validation.Limits, error) {
+	limits := config.limits
+	var allErrors field.ErrorList
+	overrides := limitOverrides(folderTree)
+	for _, limit := range slices.Sorted(maps.Keys(overrides)) {
+		if err := setLimit(&limits, limit, overrides[limit]); err != nil {
+			allErrors = append(allErrors, field.Invalid(
+				field.NewPath("metadata", "annotations").Key(LimitOverrideAnnotationPrefix+limit), overrides[limit], err.Error()))
+		}
+	}
+	return limits, allErrors.ToAggregate()
+}
+
+// validateLimitOverrides rejects setting, changing or removing limit override annotations unless
+// the requesting user is a member of one of the override groups of the limits ConfigMap. Updates
+// keeping the annotations of the old FolderTree are always allowed.
+func (v *FolderTreeCustomValidator) validateLimitOverrides(ctx context.Context, oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) error {
+	if maps.Equal(limitOverrides(oldFolderTree), limitOverrides(newFolderTree)) {
+		return nil
+	}
+	config, err := v.loadLimitsConfig(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err == nil && slices.ContainsFunc(req.UserInfo.Groups, func(group string) bool {
+		return slices.Contains(config.overrideGroups, group)
+	}) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("metadata", "annotations"), fmt.Sprintf(
+		"only members of the limit override groups may change %s* annotations", LimitOverrideAnnotationPrefix))}.ToAggregate()
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// existingNamespaces lists the names of the namespaces that are not being deleted
+func (v *FolderTreeCustomValidator) existingNamespaces(ctx context.Context) ([]string, error) {
+	var namespaceList corev1.NamespaceList
+	if err := v.Client.List(ctx, &namespaceList); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	var namespaces []string
+	for _, namespace := range namespaceList.Items {
+		if namespace.DeletionTimestamp.IsZero() {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// resolveDesiredTree returns the FolderTree the controller reconciles towards: the tree with the
+// FolderMemberships and FolderTreePatches it approves applied, as the controller resolves them, and
+// the namespaces matching its namespacePatterns added. The applied FolderTreePatch, if any, is
+// already part of the tree and skipped.
+func (v *FolderTreeCustomValidator) resolveDesiredTree(ctx context.Context, folderTree *rbacv1alpha1.FolderTree,
+	applied *rbacv1alpha1.FolderTreePatch) (*rbacv1alpha1.FolderTree, error) {
+	if folderTree == nil {
+		return nil, nil
+	}
+	resolver, err := rbac.NewTreeResolver(ctx, v.Client)
+	if err != nil {
+		return nil, err
+	}
+	if applied != nil {
+		resolver.Patches = slices.DeleteFunc(resolver.Patches, func(patch rbacv1alpha1.FolderTreePatch) bool {
+			return patch.Namespace == applied.Namespace && patch.Name == applied.Name
+		})
+	}
+	opts := validation.Options{ExcludedNamespaces: v.Options.ExcludedNamespaces}
+	resolver.ValidateSpec = func(spec *rbacv1alpha1.FolderTreeSpec) error {
+		return validation.ValidateFolderTreeSpec(spec, opts)
+	}
+	return v.withPatternNamespaces(ctx, resolver.Resolve(folderTree))
+}
+
+// withPatternNamespaces adds the existing namespaces matching the namespacePatterns of a FolderTree
+// to its folders, as the controller does, so that the RoleBindings created there are validated too
+func (v *FolderTreeCustomValidator) withPatternNamespaces(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) (*rbacv1alpha1.FolderTree, error) {
+	if folderTree == nil || !rbac.UsesNamespacePatterns(folderTree) {
+		return folderTree, nil
+	}
+	namespaces, err := v.existingNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := v.Client.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	return rbac.WithPatternNamespaces(folderTree, namespaces, rbac.ClaimedByOtherTrees(folderTree, folderTreeList.Items)), nil
+}
+
+// validateNamespacePatterns rejects namespacePatterns that overlap the namespacePatterns of other
+// FolderTrees: identical patterns, and patterns that both match an existing namespace. Which tree
+// would own the namespaces of such patterns depends on tree names rather than on intent. A pattern
+// matching namespaces that another FolderTree lists is only warned about, as the list takes precedence.
+func (v *FolderTreeCustomValidator) validateNamespacePatterns(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) (admission.Warnings, error) {
+	if !rbac.UsesNamespacePatterns(folderTree) {
+		return nil, nil
+	}
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := v.Client.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	namespaces, err := v.existingNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors field.ErrorList
+	var warnings admission.Warnings
+	for i, folder := range folderTree.Spec.Folders {
+		if folder.NamespacePattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(folder.NamespacePattern)
+		if err != nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "folders").Index(i).Child("namespacePattern")
+
+		for _, existingTree := range folderTreeList.Items {
+			if existingTree.Name == folderTree.Name {
+				continue
+			}
+			listed := rbac.IndexFolderTreeNamespaces(&existingTree)
+			for _, existingFolder := range existingTree.Spec.Folders {
+				if existingFolder.NamespacePattern == "" {
+					continue
+				}
+				if existingFolder.NamespacePattern == folder.NamespacePattern {
+					allErrors = append(allErrors, field.Duplicate(fldPath, fmt.Sprintf(
+						"namespacePattern '%s' is already used by folder '%s' in FolderTree '%s'",
+						folder.NamespacePattern, existingFolder.Name, existingTree.Name)))
+					continue
+				}
+				existingPattern, err := regexp.Compile(existingFolder.NamespacePattern)
+				if err != nil {
+					continue
+				}
+				for _, namespace := range namespaces {
+					if pattern.MatchString(namespace) && existingPattern.MatchString(namespace) {
+						allErrors = append(allErrors, field.Invalid(fldPath, folder.NamespacePattern, fmt.Sprintf(
+							"overlaps namespacePattern '%s' of folder '%s' in FolderTree '%s': both match namespace '%s'",
+							existingFolder.NamespacePattern, existingFolder.Name, existingTree.Name, namespace)))
+						break
+					}
+				}
+			}
+			for _, namespace := range listed {
+				if pattern.MatchString(namespace) {
+					warnings = append(warnings, fmt.Sprintf(
+						"%s: namespacePattern '%s' matches namespace '%s', which FolderTree '%s' lists and keeps",
+						fldPath, folder.NamespacePattern, namespace, existingTree.Name))
+				}
+			}
+		}
+	}
+	return warnings, allErrors.ToAggregate()
+}
+
+// validatePatternRoleBindings rejects changes to the RoleBindings that namespaces receive when they start
+// matching a namespacePattern. Such namespaces join the FolderTree after admission, without anybody's
+// permissions being checked for them, so only privileged requesters may change what patterns grant.
+func (v *FolderTreeCustomValidator) validatePatternRoleBindings(oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree, builder *rbac.RoleBindingBuilder) error {
+	if !rbac.UsesNamespacePatterns(newFolderTree) {
+		return nil
+	}
+	desired, err := rbac.PatternRoleBindings(newFolderTree, builder)
+	if err != nil {
+		return err
+	}
+	var previous map[string]*rbacv1.RoleBinding
+	if oldFolderTree != nil {
+		oldBuilder := *builder
+		oldBuilder.FolderTree = oldFolderTree
+		if previous, err = rbac.PatternRoleBindings(oldFolderTree, &oldBuilder); err != nil {
+			return err
+		}
+	}
+
+	var allErrors field.ErrorList
+	for i, folder := range newFolderTree.Spec.Folders {
+		if folder.NamespacePattern == "" {
+			continue
+		}
+		fldPath := field.NewPath("spec", "folders").Index(i).Child("namespacePattern")
+		for _, key := range slices.Sorted(maps.Keys(desired)) {
+			name, ok := strings.CutPrefix(key, folder.Name+"/")
+			if !ok {
+				continue
+			}
+			roleBinding, old := desired[key], previous[key]
+			if old != nil && equality.Semantic.DeepEqual(old.RoleRef, roleBinding.RoleRef) &&
+				equality.Semantic.DeepEqual(old.Subjects, roleBinding.Subjects) {
+				continue
+			}
+			allErrors = append(allErrors, field.Forbidden(fldPath, fmt.Sprintf(
+				"namespaces matching '%s' would receive RoleBinding '%s' when they are created; "+
+					"only privileged requesters may change the access namespacePatterns grant",
+				folder.NamespacePattern, name)))
+		}
+	}
+	return allErrors.ToAggregate()
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// wildcardGroups are groups that include every (or every unauthenticated) requester
+var wildcardGroups = []string{
+	"system:authenticated",
+	"system:unauthenticated",
+	"system:serviceaccounts",
+}
+
+// policyViolation describes a template that breaks a policy rule
+type policyViolation struct {
+	Rule     rbacv1alpha1.PolicyRule
+	Folder   string
+	Template string
+	Path     *field.Path
+	Detail   string
+
+	// SubjectMapping names the SubjectMapping a template binds the violating subject through, if any
+	SubjectMapping string
+}
+
+// validatePolicies checks every role binding template against the configured policy rules.
+// Violations covered by an unexpired FolderPolicyException are allowed and logged;
+// all other violations reject the FolderTree.
+func (v *FolderTreeCustomValidator) validatePolicies(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) error {
+	allowlist, err := v.loadClusterRoleAllowlist(ctx)
+	if err != nil {
+		return err
+	}
+	// Subjects bound through subjectRefs are held to the same subject rules
+	var subjectMappings rbac.SubjectMappings
+	if len(rbac.SubjectRefs(folderTree)) > 0 {
+		if subjectMappings, err = rbac.ListSubjectMappings(ctx, v.Client); err != nil {
+			return err
+		}
+	}
+	return v.rejectPolicyViolations(ctx, folderTree.Name, v.collectPolicyViolations(folderTree, allowlist, subjectMappings))
+}
+
+// rejectPolicyViolations returns an error listing the violations of a FolderTree that no unexpired
+// FolderPolicyException covers. Covered violations are logged.
+func (v *FolderTreeCustomValidator) rejectPolicyViolations(ctx context.Context, treeName string, violations []policyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	// Only look up exceptions when there is something to except
+	var exceptionList rbacv1alpha1.FolderPolicyExceptionList
+	if err := v.Client.List(ctx, &exceptionList); err != nil {
+		return fmt.Errorf("failed to list FolderPolicyExceptions: %v", err)
+	}
+
+	var allErrors field.ErrorList
+	for _, violation := range violations {
+		if exception := findPolicyException(exceptionList.Items, treeName, violation, time.Now()); exception != nil {
+			foldertreelog.Info("Policy violation allowed by FolderPolicyException",
+				"foldertree", treeName,
+				"rule", violation.Rule,
+				"folder", violation.Folder,
+				"template", violation.Template,
+				"exception", exception.Name,
+				"justification", exception.Spec.Justification)
+			continue
+		}
+
+		allErrors = append(allErrors, field.Forbidden(violation.Path,
+			fmt.Sprintf("%s (policy rule %s; create a FolderPolicyException to allow it)", violation.Detail, violation.Rule)))
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors.ToAggregate()
+	}
+
+	return nil
+}
+
+// collectPolicyViolations returns all policy rule violations in the FolderTree spec, checking ClusterRoles
+// against allowlist unless it is nil and the subjects of subjectRefs against subjectMappings.
+// Global templates are reported with an empty folder name.
+func (v *FolderTreeCustomValidator) collectPolicyViolations(folderTree *rbacv1alpha1.FolderTree, allowlist *clusterRoleAllowlist,
+	subjectMappings rbac.SubjectMappings) []policyViolation {
+	var violations []policyViolation
+
+	for j, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		templatePath := field.NewPath("spec", "globalRoleBindingTemplates").Index(j)
+		violations = append(violations, v.collectTemplateViolations("", template, templatePath, allowlist, subjectMappings)...)
+	}
+
+	for i, folder := range folderTree.Spec.Folders {
+		for j, template := range folder.RoleBindingTemplates {
+			templatePath := field.NewPath("spec", "folders").Index(i).Child("roleBindingTemplates").Index(j)
+			violations = append(violations, v.collectTemplateViolations(folder.Name, template, templatePath, allowlist, subjectMappings)...)
+		}
+
+		// Overrides bind their own subjects, so they are held to the same subject rules
+		for j, override := range folder.NamespaceOverrides {
+			overridesPath := field.NewPath("spec", "folders").Index(i).Child("namespaces").Index(folder.NamespaceEntryIndex(j)).Child("templateOverrides")
+			for _, name := range slices.Sorted(maps.Keys(override.TemplateOverrides)) {
+				violations = append(violations, v.collectSubjectViolations(folder.Name, name,
+					override.TemplateOverrides[name].Subjects, overridesPath.Key(name).Child("subjects"))...)
+			}
+		}
+	}
+
+	return violations
+}
+
+// collectTemplateViolations returns the policy rule violations of a single role binding template
+func (v *FolderTreeCustomValidator) collectTemplateViolations(folderName string, template rbacv1alpha1.RoleBindingTemplate, templatePath *field.Path,
+	allowlist *clusterRoleAllowlist, subjectMappings rbac.SubjectMappings) []policyViolation {
+	var violations []policyViolation
+
+	if template.RoleRef.Kind == "ClusterRole" && allowlist != nil && !allowlist.allows(template.RoleRef.Name) {
+		violations = append(violations, policyViolation{
+			Rule:     rbacv1alpha1.PolicyRuleClusterRoleNotAllowed,
+			Folder:   folderName,
+			Template: template.Name,
+			Path:     templatePath.Child("roleRef", "name"),
+			Detail: fmt.Sprintf("template '%s' references ClusterRole '%s', which is not in the ClusterRole allowlist of %s",
+				template.Name, template.RoleRef.Name, allowlist.source),
+		})
+	}
+
+	if template.RoleRef.Kind == "ClusterRole" && slices.Contains(v.Options.DeniedClusterRoles, template.RoleRef.Name) {
+		violations = append(violations, policyViolation{
+			Rule:     rbacv1alpha1.PolicyRuleDeniedClusterRole,
+			Folder:   folderName,
+			Template: template.Name,
+			Path:     templatePath.Child("roleRef", "name"),
+			Detail:   fmt.Sprintf("ClusterRole '%s' is not allowed in role binding templates", template.RoleRef.Name),
+		})
+	}
+
+	violations = append(violations, v.collectSubjectViolations(folderName, template.Name, template.Subjects, templatePath.Child("subjects"))...)
+	return append(violations, v.collectMappedSubjectViolations(folderName, template, templatePath.Child("subjectRefs"), subjectMappings)...)
+}
+
+// collectSubjectViolations returns the policy rule violations of the subjects bound by a template
+func (v *FolderTreeCustomValidator) collectSubjectViolations(folderName, templateName string, subjects []rbacv1.Subject,
+	subjectsPath *field.Path) []policyViolation {
+	if v.Options.AllowWildcardSubjects {
+		return nil
+	}
+
+	var violations []policyViolation
+	for k, subject := range subjects {
+		if isWildcardSubject(subject.Kind, subject.Name) {
+			violations = append(violations, policyViolation{
+				Rule:     rbacv1alpha1.PolicyRuleWildcardSubject,
+				Folder:   folderName,
+				Template: templateName,
+				Path:     subjectsPath.Index(k).Child("name"),
+				Detail:   fmt.Sprintf("subject '%s' grants access to every requester", subject.Name),
+			})
+		}
+	}
+	return violations
+}
+
+// collectMappedSubjectViolations returns the policy rule violations of the subjects a template binds
+// through the SubjectMappings of its subjectRefs. Missing SubjectMappings bind no subjects.
+func (v *FolderTreeCustomValidator) collectMappedSubjectViolations(folderName string, template rbacv1alpha1.RoleBindingTemplate,
+	subjectRefsPath *field.Path, subjectMappings rbac.SubjectMappings) []policyViolation {
+	if v.Options.AllowWildcardSubjects {
+		return nil
+	}
+
+	var violations []policyViolation
+	for k, ref := range template.SubjectRefs {
+		for _, subject := range subjectMappings[ref] {
+			if isWildcardSubject(subject.Kind, subject.Name) {
+				violations = append(violations, policyViolation{
+					Rule:           rbacv1alpha1.PolicyRuleWildcardSubject,
+					Folder:         folderName,
+					Template:       template.Name,
+					Path:           subjectRefsPath.Index(k),
+					Detail:         fmt.Sprintf("subject '%s' of SubjectMapping '%s' grants access to every requester", subject.Name, ref),
+					SubjectMapping: ref,
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// isWildcardSubject reports whether a subject matches all (or all anonymous) requesters
+func isWildcardSubject(kind, name string) bool {
+	if name == "*" {
+		return true
+	}
+	switch kind {
+	case "Group":
+		return slices.Contains(wildcardGroups, name)
+	case "User":
+		return name == "system:anonymous"
+	}
+	return false
+}
+
+// findPolicyException returns the first unexpired exception covering the violation, or nil.
+// An exception covers a violation when the tree and rule match and its folder and template
+// are either empty (all) or equal to the violating folder and template.
+func findPolicyException(exceptions []rbacv1alpha1.FolderPolicyException, treeName string, violation policyViolation, now time.Time) *rbacv1alpha1.FolderPolicyException {
+	for i := range exceptions {
+		exception := &exceptions[i]
+		if exception.Spec.TreeName != treeName || exception.Spec.Rule != violation.Rule {
+			continue
+		}
+		if exception.Spec.FolderName != "" && exception.Spec.FolderName != violation.Folder {
+			continue
+		}
+		if exception.Spec.TemplateName != "" && exception.Spec.TemplateName != violation.Template {
+			continue
+		}
+		if !now.Before(exception.Spec.ExpiresAt.Time) {
+			continue
+		}
+		return exception
+	}
+	return nil
+}
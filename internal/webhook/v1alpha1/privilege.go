@@ -0,0 +1,375 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DryRunValidationLabel is set to "true" on the RoleBindings sent as dry-run requests in
+// impersonation mode. Admission policies such as Gatekeeper constraints can exclude them by this
+// label; they are never persisted, and their names are random.
+const DryRunValidationLabel = "rbac.kubevirt.io/dry-run-validation"
+
+// PrivilegeCheckMode selects how the webhook verifies that the requesting user may perform
+// the RoleBinding operations a FolderTree change results in
+type PrivilegeCheckMode string
+
+const (
+	// PrivilegeCheckModeImpersonation performs every operation as a dry-run while impersonating
+	// the user. It requires the impersonate permission and a client per admission request.
+	PrivilegeCheckModeImpersonation PrivilegeCheckMode = "impersonation"
+
+	// PrivilegeCheckModeSubjectAccessReview asks the API server through SubjectAccessReviews whether
+	// the user may manage the RoleBindings and either bind the referenced role or holds all of its
+	// permissions, mirroring the RBAC escalation check of the API server.
+	PrivilegeCheckModeSubjectAccessReview PrivilegeCheckMode = "subjectaccessreview"
+)
+
+// ParsePrivilegeCheckMode returns the PrivilegeCheckMode named by s
+func ParsePrivilegeCheckMode(s string) (PrivilegeCheckMode, error) {
+	switch mode := PrivilegeCheckMode(s); mode {
+	case PrivilegeCheckModeImpersonation, PrivilegeCheckModeSubjectAccessReview:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown privilege check mode '%s' (must be '%s' or '%s')",
+			s, PrivilegeCheckModeImpersonation, PrivilegeCheckModeSubjectAccessReview)
+	}
+}
+
+// roleBindingAuthorizer checks whether the requesting user may perform an operation on a RoleBinding.
+// It returns an error describing the missing permission when the user may not.
+type roleBindingAuthorizer interface {
+	authorizeCreate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error
+	authorizeUpdate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error
+	authorizeDelete(ctx context.Context, roleBinding *rbacv1.RoleBinding) error
+}
+
+// newRoleBindingAuthorizer returns the roleBindingAuthorizer for the configured PrivilegeCheckMode
+func (v *FolderTreeCustomValidator) newRoleBindingAuthorizer(userInfo authenticationv1.UserInfo) (roleBindingAuthorizer, error) {
+	if v.Options.PrivilegeCheckMode == PrivilegeCheckModeSubjectAccessReview {
+		return newSubjectAccessReviewAuthorizer(v.Client, userInfo), nil
+	}
+
+	impersonationClient, err := v.createImpersonationClient(userInfo)
+	if err != nil {
+		return nil, err
+	}
+	impersonation := &impersonationAuthorizer{client: impersonationClient, labels: v.dryRunLabels()}
+	if len(v.Options.SubjectAccessReviewNamespaces) == 0 {
+		return impersonation, nil
+	}
+	return &namespaceRoutingAuthorizer{
+		subjectAccessReviewNamespaces: v.Options.SubjectAccessReviewNamespaces,
+		subjectAccessReview:           newSubjectAccessReviewAuthorizer(v.Client, userInfo),
+		impersonation:                 impersonation,
+	}, nil
+}
+
+// dryRunLabels returns the labels set on dry-run RoleBindings: the configured DryRunLabels
+// and DryRunValidationLabel
+func (v *FolderTreeCustomValidator) dryRunLabels() map[string]string {
+	labels := maps.Clone(v.Options.DryRunLabels)
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[DryRunValidationLabel] = "true"
+	return labels
+}
+
+// privilegeCheckMode returns the configured PrivilegeCheckMode, defaulting to impersonation
+func (v *FolderTreeCustomValidator) privilegeCheckMode() PrivilegeCheckMode {
+	if v.Options.PrivilegeCheckMode == "" {
+		return PrivilegeCheckModeImpersonation
+	}
+	return v.Options.PrivilegeCheckMode
+}
+
+// impersonationAuthorizer performs the operations as dry-runs with a client impersonating the user.
+// Created and updated RoleBindings carry labels, so that admission policies can recognize them.
+type impersonationAuthorizer struct {
+	client client.Client
+	labels map[string]string
+}
+
+// labeled returns a copy of roleBinding with the dry-run labels added
+func (a *impersonationAuthorizer) labeled(roleBinding *rbacv1.RoleBinding) *rbacv1.RoleBinding {
+	if len(a.labels) == 0 {
+		return roleBinding
+	}
+	labeled := roleBinding.DeepCopy()
+	if labeled.Labels == nil {
+		labeled.Labels = make(map[string]string, len(a.labels))
+	}
+	maps.Copy(labeled.Labels, a.labels)
+	return labeled
+}
+
+func (a *impersonationAuthorizer) authorizeCreate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	if err := a.client.Create(ctx, a.labeled(roleBinding), client.DryRunAll); err != nil {
+		return fmt.Errorf("dry-run creation failed (user lacks required permissions): %v", err)
+	}
+	return nil
+}
+
+func (a *impersonationAuthorizer) authorizeUpdate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	if err := a.client.Update(ctx, a.labeled(roleBinding), client.DryRunAll); err != nil {
+		return fmt.Errorf("dry-run update failed (user lacks required permissions): %v", err)
+	}
+	return nil
+}
+
+func (a *impersonationAuthorizer) authorizeDelete(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	if err := a.client.Delete(ctx, roleBinding, client.DryRunAll); err != nil {
+		return fmt.Errorf("dry-run deletion failed (user lacks required permissions): %v", err)
+	}
+	return nil
+}
+
+// namespaceRoutingAuthorizer checks operations in the configured namespaces with SubjectAccessReviews
+// and all others with dry-runs, for namespaces whose admission chain rejects the dry-run RoleBindings
+type namespaceRoutingAuthorizer struct {
+	subjectAccessReviewNamespaces []string
+	subjectAccessReview           roleBindingAuthorizer
+	impersonation                 roleBindingAuthorizer
+}
+
+// authorizerFor returns the authorizer checking operations in namespace
+func (a *namespaceRoutingAuthorizer) authorizerFor(namespace string) roleBindingAuthorizer {
+	for _, pattern := range a.subjectAccessReviewNamespaces {
+		if matchesWildcard(pattern, namespace) {
+			return a.subjectAccessReview
+		}
+	}
+	return a.impersonation
+}
+
+func (a *namespaceRoutingAuthorizer) authorizeCreate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	return a.authorizerFor(roleBinding.Namespace).authorizeCreate(ctx, roleBinding)
+}
+
+func (a *namespaceRoutingAuthorizer) authorizeUpdate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	return a.authorizerFor(roleBinding.Namespace).authorizeUpdate(ctx, roleBinding)
+}
+
+func (a *namespaceRoutingAuthorizer) authorizeDelete(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	return a.authorizerFor(roleBinding.Namespace).authorizeDelete(ctx, roleBinding)
+}
+
+// accessKey identifies a SubjectAccessReview of the requesting user
+type accessKey struct {
+	namespace, verb, group, resource, subresource, name, path string
+}
+
+// subjectAccessReviewAuthorizer checks the operations with SubjectAccessReviews. Decisions are
+// cached for the lifetime of the authorizer, i.e. one admission request, since the same role
+// is usually bound in many namespaces. It is safe for concurrent use.
+type subjectAccessReviewAuthorizer struct {
+	client   client.Client
+	userInfo authenticationv1.UserInfo
+
+	mu        sync.Mutex
+	decisions map[accessKey]bool
+}
+
+func newSubjectAccessReviewAuthorizer(c client.Client, userInfo authenticationv1.UserInfo) *subjectAccessReviewAuthorizer {
+	return &subjectAccessReviewAuthorizer{client: c, userInfo: userInfo, decisions: make(map[accessKey]bool)}
+}
+
+func (a *subjectAccessReviewAuthorizer) authorizeCreate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	if err := a.authorizeRoleBindingVerb(ctx, "create", roleBinding.Namespace, ""); err != nil {
+		return err
+	}
+	return a.authorizeRoleRef(ctx, roleBinding)
+}
+
+func (a *subjectAccessReviewAuthorizer) authorizeUpdate(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	if err := a.authorizeRoleBindingVerb(ctx, "update", roleBinding.Namespace, roleBinding.Name); err != nil {
+		return err
+	}
+	return a.authorizeRoleRef(ctx, roleBinding)
+}
+
+func (a *subjectAccessReviewAuthorizer) authorizeDelete(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	return a.authorizeRoleBindingVerb(ctx, "delete", roleBinding.Namespace, roleBinding.Name)
+}
+
+// authorizeRoleBindingVerb checks that the user may perform verb on RoleBindings in the namespace
+func (a *subjectAccessReviewAuthorizer) authorizeRoleBindingVerb(ctx context.Context, verb, namespace, name string) error {
+	key := accessKey{namespace: namespace, verb: verb, group: rbacv1.GroupName, resource: "rolebindings", name: name}
+	allowed, err := a.allowed(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("user lacks required permissions: cannot %s rolebindings in namespace '%s'", verb, namespace)
+	}
+	return nil
+}
+
+// authorizeRoleRef performs the escalation check of the API server: the user must either be
+// allowed to bind the referenced role or hold every permission it grants in the namespace
+func (a *subjectAccessReviewAuthorizer) authorizeRoleRef(ctx context.Context, roleBinding *rbacv1.RoleBinding) error {
+	roleRef := roleBinding.RoleRef
+	namespace := roleBinding.Namespace
+
+	resource := "clusterroles"
+	if roleRef.Kind == "Role" {
+		resource = "roles"
+	}
+	canBind, err := a.allowed(ctx, accessKey{
+		namespace: namespace, verb: "bind", group: rbacv1.GroupName, resource: resource, name: roleRef.Name,
+	})
+	if err != nil {
+		return err
+	}
+	if canBind {
+		return nil
+	}
+
+	rules, err := a.roleRules(ctx, roleRef, namespace)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		for _, key := range ruleAccessKeys(rule, namespace) {
+			allowed, err := a.allowed(ctx, key)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return fmt.Errorf("user lacks required permissions: %s %s/%s grants %s, which the user does not have",
+					roleRef.Kind, namespace, roleRef.Name, describeAccessKey(key))
+			}
+		}
+	}
+	return nil
+}
+
+// roleRules returns the rules of the Role or ClusterRole a RoleBinding references
+func (a *subjectAccessReviewAuthorizer) roleRules(ctx context.Context, roleRef rbacv1.RoleRef, namespace string) ([]rbacv1.PolicyRule, error) {
+	if roleRef.Kind == "Role" {
+		role := &rbacv1.Role{}
+		if err := a.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: roleRef.Name}, role); err != nil {
+			return nil, fmt.Errorf("failed to get Role '%s' in namespace '%s': %v", roleRef.Name, namespace, err)
+		}
+		return role.Rules, nil
+	}
+
+	clusterRole := &rbacv1.ClusterRole{}
+	if err := a.client.Get(ctx, types.NamespacedName{Name: roleRef.Name}, clusterRole); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterRole '%s': %v", roleRef.Name, err)
+	}
+	return clusterRole.Rules, nil
+}
+
+// ruleAccessKeys expands a policy rule into the individual accesses it grants in a namespace
+func ruleAccessKeys(rule rbacv1.PolicyRule, namespace string) []accessKey {
+	var keys []accessKey
+	for _, verb := range rule.Verbs {
+		for _, path := range rule.NonResourceURLs {
+			keys = append(keys, accessKey{verb: verb, path: path})
+		}
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				resource, subresource, _ := strings.Cut(resource, "/")
+				names := rule.ResourceNames
+				if len(names) == 0 {
+					names = []string{""}
+				}
+				for _, name := range names {
+					keys = append(keys, accessKey{
+						namespace: namespace, verb: verb, group: group,
+						resource: resource, subresource: subresource, name: name,
+					})
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// describeAccessKey formats an access for error messages, e.g. "delete on apps/deployments"
+func describeAccessKey(key accessKey) string {
+	if key.path != "" {
+		return fmt.Sprintf("%s on %s", key.verb, key.path)
+	}
+	resource := key.resource
+	if key.group != "" {
+		resource = key.group + "/" + resource
+	}
+	if key.subresource != "" {
+		resource += "/" + key.subresource
+	}
+	if key.name != "" {
+		resource += " '" + key.name + "'"
+	}
+	return fmt.Sprintf("%s on %s", key.verb, resource)
+}
+
+// allowed returns whether the user is allowed the access, asking the API server on a cache miss
+func (a *subjectAccessReviewAuthorizer) allowed(ctx context.Context, key accessKey) (bool, error) {
+	a.mu.Lock()
+	allowed, cached := a.decisions[key]
+	a.mu.Unlock()
+	if cached {
+		return allowed, nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(a.userInfo.Extra))
+	for k, v := range a.userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   a.userInfo.Username,
+			Groups: a.userInfo.Groups,
+			UID:    a.userInfo.UID,
+			Extra:  extra,
+		},
+	}
+	if key.path != "" {
+		review.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{Path: key.path, Verb: key.verb}
+	} else {
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   key.namespace,
+			Verb:        key.verb,
+			Group:       key.group,
+			Resource:    key.resource,
+			Subresource: key.subresource,
+			Name:        key.name,
+		}
+	}
+
+	if err := a.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to create SubjectAccessReview: %v", err)
+	}
+	a.mu.Lock()
+	a.decisions[key] = review.Status.Allowed
+	a.mu.Unlock()
+	return review.Status.Allowed, nil
+}
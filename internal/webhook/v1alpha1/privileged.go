@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"kubevirt.io/folders/internal/metrics"
+)
+
+// ValidatePrivilegedRequesters checks the configured privileged users and groups. Wildcard groups
+// such as system:authenticated would exempt every user from the privilege escalation check, and
+// patterns are not supported, so that every entry names exactly the requesters it exempts.
+func ValidatePrivilegedRequesters(users, groups []string) error {
+	for _, user := range users {
+		if strings.Contains(user, "*") {
+			return fmt.Errorf("privileged user '%s' must be a user name, patterns are not supported", user)
+		}
+	}
+	for _, group := range groups {
+		if strings.Contains(group, "*") {
+			return fmt.Errorf("privileged group '%s' must be a group name, patterns are not supported", group)
+		}
+		if slices.Contains(wildcardGroups, group) {
+			return fmt.Errorf("privileged group '%s' includes every user and would disable the privilege escalation check", group)
+		}
+	}
+	return nil
+}
+
+// privilegedRequester reports whether the requesting user is one of the configured privileged
+// users or a member of one of the privileged groups, e.g. the ServiceAccount of a GitOps tool,
+// and skips the privilege escalation check. Such requesters manage FolderTrees without holding
+// every permission the RoleBindings grant. Every skipped check is logged and counted.
+func (v *FolderTreeCustomValidator) privilegedRequester(req admission.Request) bool {
+	if !slices.Contains(v.Options.PrivilegedUsers, req.UserInfo.Username) &&
+		!slices.ContainsFunc(req.UserInfo.Groups, func(group string) bool {
+			return slices.Contains(v.Options.PrivilegedGroups, group)
+		}) {
+		return false
+	}
+
+	operation := strings.ToLower(string(req.Operation))
+	foldertreelog.Info("Skipping privilege escalation check for privileged user",
+		"kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace, "operation", operation, "user", req.UserInfo.Username)
+	metrics.RecordPrivilegedBypass(operation)
+	return true
+}
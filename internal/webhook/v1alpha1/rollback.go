@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups=rbac.kubevirt.io,resources=foldertreerevisions,verbs=get
+
+// rollbackRequest returns the revision an update newly asks the controller to roll back to
+func rollbackRequest(oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) (string, bool) {
+	target, ok := newFolderTree.Annotations[rbacv1alpha1.RollbackToAnnotation]
+	if previous, requested := oldFolderTree.Annotations[rbacv1alpha1.RollbackToAnnotation]; !ok || (requested && previous == target) {
+		return "", false
+	}
+	return target, true
+}
+
+// rollbackTarget returns the FolderTree an update leads to. An update setting the
+// rbacv1alpha1.RollbackToAnnotation makes the controller restore the spec of the named
+// FolderTreeRevision, so the revision must exist and its spec is validated in place of the
+// unchanged one, including the privilege escalation check against the applied state. The spec
+// must have the hash the defaulter recorded in the rbacv1alpha1.RollbackSpecHashAnnotation,
+// which the controller checks again before restoring it.
+func (v *FolderTreeCustomValidator) rollbackTarget(ctx context.Context, oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) (*rbacv1alpha1.FolderTree, error) {
+	target, ok := rollbackRequest(oldFolderTree, newFolderTree)
+	if !ok {
+		return newFolderTree, nil
+	}
+
+	annotationPath := field.NewPath("metadata", "annotations").Key(rbacv1alpha1.RollbackToAnnotation)
+	if !equality.Semantic.DeepEqual(oldFolderTree.Spec, newFolderTree.Spec) {
+		return nil, field.Forbidden(annotationPath, "the spec cannot be changed together with a rollback")
+	}
+
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	spec, err := rbac.GetRollbackTarget(ctx, reader, newFolderTree.Name, target)
+	if err != nil {
+		return nil, field.Invalid(annotationPath, target, err.Error())
+	}
+	hash, err := rbac.RevisionSpecHash(spec)
+	if err != nil {
+		return nil, err
+	}
+	if newFolderTree.Annotations[rbacv1alpha1.RollbackSpecHashAnnotation] != hash {
+		return nil, field.Forbidden(annotationPath, fmt.Sprintf("revision %s changed while the rollback was admitted", target))
+	}
+
+	foldertreelog.Info("Validating the spec restored by a rollback", "name", newFolderTree.Name, "revision", target)
+	rolledBack := newFolderTree.DeepCopy()
+	rolledBack.Spec = spec
+	return rbac.WithDefaults(rolledBack), nil
+}
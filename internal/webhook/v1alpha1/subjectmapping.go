@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// subjectMappingWarnings warns about subjectRefs naming SubjectMappings that do not exist. They are
+// not rejected, so that a FolderTree can be applied before the SubjectMappings it references.
+func (v *FolderTreeCustomValidator) subjectMappingWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	if len(rbac.SubjectRefs(folderTree)) == 0 {
+		return nil
+	}
+	subjectMappings, err := rbac.ListSubjectMappings(ctx, v.Client)
+	if err != nil {
+		foldertreelog.Info("Could not check the SubjectMappings referenced by templates", "error", err)
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, ref := range subjectMappings.Missing(folderTree) {
+		warnings = append(warnings, fmt.Sprintf(
+			"SubjectMapping '%s' referenced in subjectRefs does not exist; templates bind none of its subjects until it is created", ref))
+	}
+	return warnings
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/rbac"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// log is for logging in this package.
+var subjectmappinglog = logf.Log.WithName("subjectmapping-resource")
+
+// SetupSubjectMappingWebhookWithManager registers the validating webhook for SubjectMapping in the manager.
+func SetupSubjectMappingWebhookWithManager(mgr ctrl.Manager, opts WebhookOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rbacv1alpha1.SubjectMapping{}).
+		WithValidator(&SubjectMappingCustomValidator{
+			FolderTree: &FolderTreeCustomValidator{
+				Client:  mgr.GetClient(),
+				Options: opts,
+			},
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-rbac-kubevirt-io-v1alpha1-subjectmapping,mutating=false,failurePolicy=fail,sideEffects=None,groups=rbac.kubevirt.io,resources=subjectmappings,verbs=create;update,versions=v1alpha1,name=subjectmapping.rbac.kubevirt.io,admissionReviewVersions=v1
+
+// SubjectMappingCustomValidator validates SubjectMappings when they are created or updated. The FolderTrees
+// referencing a SubjectMapping bind its subjects without being updated themselves, so the subject policy
+// rules of those FolderTrees are checked here, honoring their FolderPolicyExceptions.
+//
+// +kubebuilder:object:generate=false
+type SubjectMappingCustomValidator struct {
+	// FolderTree checks the policy rules of the FolderTrees referencing the SubjectMapping
+	FolderTree *FolderTreeCustomValidator
+}
+
+var _ webhook.CustomValidator = &SubjectMappingCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SubjectMapping.
+func (v *SubjectMappingCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mapping, ok := obj.(*rbacv1alpha1.SubjectMapping)
+	if !ok {
+		return nil, fmt.Errorf("expected a SubjectMapping object but got %T", obj)
+	}
+	subjectmappinglog.Info("Validation for SubjectMapping upon creation", "name", mapping.Name)
+
+	return nil, v.validateMapping(ctx, "create", mapping)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SubjectMapping.
+func (v *SubjectMappingCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	mapping, ok := newObj.(*rbacv1alpha1.SubjectMapping)
+	if !ok {
+		return nil, fmt.Errorf("expected a SubjectMapping object for the newObj but got %T", newObj)
+	}
+	subjectmappinglog.Info("Validation for SubjectMapping upon update", "name", mapping.Name)
+
+	return nil, v.validateMapping(ctx, "update", mapping)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SubjectMapping.
+func (v *SubjectMappingCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateMapping rejects a SubjectMapping whose subjects break the subject policy rules of a FolderTree
+// referencing it. Only the violations of the subjects bound through the mapping are considered.
+func (v *SubjectMappingCustomValidator) validateMapping(ctx context.Context, operation string, mapping *rbacv1alpha1.SubjectMapping) error {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := v.FolderTree.Client.List(ctx, &folderTreeList); err != nil {
+		return fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+
+	subjectMappings := rbac.SubjectMappings{mapping.Name: mapping.Spec.Subjects}
+	for i := range folderTreeList.Items {
+		folderTree := rbac.WithDefaults(&folderTreeList.Items[i])
+		if !slices.Contains(rbac.SubjectRefs(folderTree), mapping.Name) {
+			continue
+		}
+
+		var violations []policyViolation
+		for _, violation := range v.FolderTree.collectPolicyViolations(folderTree, nil, subjectMappings) {
+			if violation.SubjectMapping == mapping.Name {
+				violations = append(violations, violation)
+			}
+		}
+		if err := v.FolderTree.rejectPolicyViolations(ctx, folderTree.Name, violations); err != nil {
+			return metrics.RecordRejection(operation, metrics.RejectionReasonPolicy, validation.Reject(validation.ErrPolicyViolation,
+				fmt.Errorf("FolderTree '%s' references SubjectMapping '%s': %v", folderTree.Name, mapping.Name, err)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+var _ = Describe("SubjectMapping Webhook", func() {
+	var (
+		ctx     context.Context
+		mapping *rbacv1alpha1.SubjectMapping
+	)
+
+	wildcardSubject := rbacv1.Subject{Kind: "Group", Name: "system:authenticated", APIGroup: rbacv1.GroupName}
+	regularSubject := rbacv1.Subject{Kind: "Group", Name: "developers", APIGroup: rbacv1.GroupName}
+
+	// newValidator returns a validator whose FolderTrees and FolderPolicyExceptions are the given objects
+	newValidator := func(objects ...client.Object) *SubjectMappingCustomValidator {
+		referencing := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "mapped-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{
+					Name:       "mapped-folder",
+					Namespaces: []string{"mapped-ns"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:        "developers",
+						SubjectRefs: []string{"developers"},
+						RoleRef:     rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+					}},
+				}},
+			},
+		}
+		return &SubjectMappingCustomValidator{FolderTree: &FolderTreeCustomValidator{
+			Client: fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithObjects(append(objects, referencing)...).
+				Build(),
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mapping = &rbacv1alpha1.SubjectMapping{
+			ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+			Spec:       rbacv1alpha1.SubjectMappingSpec{Subjects: []rbacv1.Subject{regularSubject}},
+		}
+	})
+
+	It("should reject wildcard subjects for FolderTrees referencing the mapping", func() {
+		validator := newValidator()
+		_, err := validator.ValidateCreate(ctx, mapping)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := mapping.DeepCopy()
+		updated.Spec.Subjects = append(updated.Spec.Subjects, wildcardSubject)
+		_, err = validator.ValidateUpdate(ctx, mapping, updated)
+		Expect(validation.RejectionCodeOf(err)).To(Equal(validation.ErrPolicyViolation))
+		Expect(err.Error()).To(ContainSubstring("FolderTree 'mapped-tree' references SubjectMapping 'developers'"))
+		Expect(err.Error()).To(ContainSubstring("WildcardSubject"))
+
+		By("allowing wildcard subjects in mappings no FolderTree references")
+		updated.Name = "unreferenced"
+		_, err = validator.ValidateCreate(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should honor the FolderPolicyExceptions of the referencing FolderTrees", func() {
+		validator := newValidator(&rbacv1alpha1.FolderPolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: "mapped-tree-wildcard"},
+			Spec: rbacv1alpha1.FolderPolicyExceptionSpec{
+				TreeName:      "mapped-tree",
+				Rule:          rbacv1alpha1.PolicyRuleWildcardSubject,
+				Justification: "OPS-2: company-wide read access",
+				ExpiresAt:     metav1.NewTime(time.Now().Add(time.Hour)),
+			},
+		})
+
+		mapping.Spec.Subjects = append(mapping.Spec.Subjects, wildcardSubject)
+		_, err := validator.ValidateCreate(ctx, mapping)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// validateTemplatedObjectAuthorization checks that the user may create, update and delete the
+// NetworkPolicies and ResourceQuotas the controller would change when moving from the old to the
+// new FolderTree. Either tree may be nil. The checks use SubjectAccessReviews in every privilege
+// check mode, since these objects grant no permissions that could be escalated.
+func (v *FolderTreeCustomValidator) validateTemplatedObjectAuthorization(ctx context.Context, userInfo authenticationv1.UserInfo,
+	oldFolderTree, newFolderTree *rbacv1alpha1.FolderTree) error {
+	var checks []accessKey
+
+	oldPolicies, err := v.desiredNetworkPolicies(oldFolderTree)
+	if err != nil {
+		return err
+	}
+	newPolicies, err := v.desiredNetworkPolicies(newFolderTree)
+	if err != nil {
+		return err
+	}
+	checks = appendObjectChecks(checks, networkingv1.GroupName, "networkpolicies", oldPolicies, newPolicies,
+		func(a, b *networkingv1.NetworkPolicy) bool { return equality.Semantic.DeepEqual(a.Spec, b.Spec) })
+
+	oldQuotas, err := v.desiredResourceQuotas(oldFolderTree)
+	if err != nil {
+		return err
+	}
+	newQuotas, err := v.desiredResourceQuotas(newFolderTree)
+	if err != nil {
+		return err
+	}
+	checks = appendObjectChecks(checks, corev1.GroupName, "resourcequotas", oldQuotas, newQuotas,
+		func(a, b *corev1.ResourceQuota) bool { return equality.Semantic.DeepEqual(a.Spec, b.Spec) })
+
+	if len(checks) == 0 {
+		return nil
+	}
+	authorizer := newSubjectAccessReviewAuthorizer(v.Client, userInfo)
+	for _, key := range checks {
+		allowed, err := authorizer.allowed(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("user lacks required permissions: cannot %s %s in namespace '%s'", key.verb, key.resource, key.namespace)
+		}
+	}
+	return nil
+}
+
+// appendObjectChecks adds the access checks for creating, updating and deleting objects of a
+// resource to get from the old to the new desired objects, sorted by namespace and verb
+func appendObjectChecks[T client.Object](checks []accessKey, group, resource string, oldObjects, newObjects map[string]T, equal func(a, b T) bool) []accessKey {
+	var added []accessKey
+	addCheck := func(verb string, obj T) {
+		key := accessKey{namespace: obj.GetNamespace(), verb: verb, group: group, resource: resource}
+		if !slices.Contains(added, key) {
+			added = append(added, key)
+		}
+	}
+	for key, desired := range newObjects {
+		if existing, ok := oldObjects[key]; !ok {
+			addCheck("create", desired)
+		} else if !equal(existing, desired) {
+			addCheck("update", desired)
+		}
+	}
+	for key, existing := range oldObjects {
+		if _, ok := newObjects[key]; !ok {
+			addCheck("delete", existing)
+		}
+	}
+
+	slices.SortFunc(added, func(a, b accessKey) int {
+		if a.namespace != b.namespace {
+			return strings.Compare(a.namespace, b.namespace)
+		}
+		return strings.Compare(a.verb, b.verb)
+	})
+	return append(checks, added...)
+}
+
+// desiredNetworkPolicies returns the NetworkPolicies the controller creates for a FolderTree, or none for nil
+func (v *FolderTreeCustomValidator) desiredNetworkPolicies(folderTree *rbacv1alpha1.FolderTree) (map[string]*networkingv1.NetworkPolicy, error) {
+	if folderTree == nil {
+		return nil, nil
+	}
+	builder := &rbac.NetworkPolicyBuilder{FolderTree: folderTree, ExcludedNamespaces: v.Options.ExcludedNamespaces}
+	desired, err := rbac.CalculateDesiredNetworkPolicies(folderTree, builder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate NetworkPolicies: %v", err)
+	}
+	return desired, nil
+}
+
+// desiredResourceQuotas returns the ResourceQuotas the controller creates for a FolderTree, or none for nil
+func (v *FolderTreeCustomValidator) desiredResourceQuotas(folderTree *rbacv1alpha1.FolderTree) (map[string]*corev1.ResourceQuota, error) {
+	if folderTree == nil {
+		return nil, nil
+	}
+	builder := &rbac.ResourceQuotaBuilder{FolderTree: folderTree, ExcludedNamespaces: v.Options.ExcludedNamespaces}
+	desired, err := rbac.CalculateDesiredResourceQuotas(folderTree, builder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate ResourceQuotas: %v", err)
+	}
+	return desired, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// uniquenessIndexField is the cache field index mapping FolderTrees to the names that must be
+// unique across FolderTrees: "folder/<name>" for folder and tree node names and
+// "namespace/<name>" for the namespaces their folders list
+const uniquenessIndexField = "spec.uniquenessKeys"
+
+// setupUniquenessIndex registers uniquenessIndexField with a cache, e.g. the field indexer of a
+// manager. It must be called before the cache is started.
+func setupUniquenessIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &rbacv1alpha1.FolderTree{}, uniquenessIndexField, indexUniquenessKeys)
+}
+
+// indexUniquenessKeys returns the uniqueness keys of a FolderTree for uniquenessIndexField
+func indexUniquenessKeys(obj client.Object) []string {
+	folderTree, ok := obj.(*rbacv1alpha1.FolderTree)
+	if !ok {
+		return nil
+	}
+	return uniquenessKeys(folderTree)
+}
+
+// uniquenessKeys returns the folder names, tree node names and namespaces of a FolderTree,
+// without duplicates
+func uniquenessKeys(folderTree *rbacv1alpha1.FolderTree) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, folder := range folderTree.Spec.Folders {
+		add("folder/" + folder.Name)
+		for _, namespace := range folder.Namespaces {
+			add("namespace/" + namespace)
+		}
+	}
+
+	var addTreeNode func(rbacv1alpha1.TreeNode)
+	addTreeNode = func(treeNode rbacv1alpha1.TreeNode) {
+		add("folder/" + treeNode.Name)
+		for _, subfolder := range treeNode.Subfolders {
+			addTreeNode(subfolder)
+		}
+	}
+	for _, root := range folderTree.Spec.Roots() {
+		addTreeNode(root)
+	}
+
+	return keys
+}
+
+// uniquenessCandidates returns the other FolderTrees that may conflict with a FolderTree. With
+// uniquenessIndexField registered in the cache, only FolderTrees sharing one of its uniqueness keys
+// are returned, so admission does not scan the spec of every FolderTree; otherwise all are returned.
+func (v *FolderTreeCustomValidator) uniquenessCandidates(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) ([]rbacv1alpha1.FolderTree, error) {
+	if !v.uniquenessIndex {
+		var folderTreeList rbacv1alpha1.FolderTreeList
+		if err := v.Client.List(ctx, &folderTreeList); err != nil {
+			return nil, fmt.Errorf("failed to list existing FolderTrees: %v", err)
+		}
+		return folderTreeList.Items, nil
+	}
+
+	var candidates []rbacv1alpha1.FolderTree
+	seen := map[string]bool{folderTree.Name: true}
+	for _, key := range uniquenessKeys(folderTree) {
+		// The candidates are only read, so they need not be copied out of the cache
+		var folderTreeList rbacv1alpha1.FolderTreeList
+		if err := v.Client.List(ctx, &folderTreeList, client.MatchingFields{uniquenessIndexField: key}, client.UnsafeDisableDeepCopy); err != nil {
+			return nil, fmt.Errorf("failed to list FolderTrees sharing '%s': %v", key, err)
+		}
+		for _, candidate := range folderTreeList.Items {
+			if !seen[candidate.Name] {
+				seen[candidate.Name] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates, nil
+}
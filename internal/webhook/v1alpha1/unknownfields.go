@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// treeNodeFields are the fields a tree node supports. Subfolders are schemaless in the CRD, so the API
+// server keeps any other field inside them, and decoding into TreeNode silently drops it.
+var treeNodeFields = map[string]bool{"name": true, "subfolders": true, "inheritOnly": true, "exclude": true}
+
+// folderNamespaceFields are the fields of a folder namespace in object form. Namespace entries may be
+// plain names, so the CRD cannot prune them either.
+var folderNamespaceFields = map[string]bool{"name": true, "templateOverrides": true}
+
+// validateUnknownFields rejects unknown fields inside tree subfolders and folder namespace entries,
+// which the typed object no longer has, by re-parsing the raw admission object. A typo like
+// `subfolder:` would otherwise flatten the hierarchy without notice. Unknown fields already stored in
+// the old object are only warned about, so FolderTrees written before this check keep accepting updates.
+func (v *FolderTreeCustomValidator) validateUnknownFields(ctx context.Context) (admission.Warnings, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	unknown, err := unknownFields(req.Object.Raw)
+	if err != nil || len(unknown) == 0 {
+		return nil, nil
+	}
+	stored := map[string]bool{}
+	if previous, err := unknownFields(req.OldObject.Raw); err == nil {
+		for _, unknownField := range previous {
+			stored[unknownField.Field] = true
+		}
+	}
+
+	var warnings admission.Warnings
+	var allErrors field.ErrorList
+	for _, unknownField := range unknown {
+		if stored[unknownField.Field] {
+			warnings = append(warnings, fmt.Sprintf("%s: unknown field is ignored", unknownField.Field))
+			continue
+		}
+		allErrors = append(allErrors, unknownField)
+	}
+	return warnings, allErrors.ToAggregate()
+}
+
+// unknownFields returns errors for the unknown fields inside the subfolders of spec.tree and
+// spec.trees and inside the namespace entries of spec.folders in a raw FolderTree. The root nodes
+// themselves are pruned by the CRD schema.
+func unknownFields(raw []byte) (field.ErrorList, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var object struct {
+		Spec struct {
+			Tree    map[string]interface{}   `json:"tree"`
+			Trees   []map[string]interface{} `json:"trees"`
+			Folders []struct {
+				Namespaces []interface{} `json:"namespaces"`
+			} `json:"folders"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	var paths []*field.Path
+	specPath := field.NewPath("spec")
+	if object.Spec.Tree != nil {
+		collectUnknownSubfolderFields(object.Spec.Tree, specPath.Child("tree"), &paths)
+	}
+	for i, root := range object.Spec.Trees {
+		collectUnknownSubfolderFields(root, specPath.Child("trees").Index(i), &paths)
+	}
+	var allErrors field.ErrorList
+	for _, path := range paths {
+		allErrors = append(allErrors, field.Forbidden(path, "unknown field; tree nodes only support name, subfolders, inheritOnly and exclude"))
+	}
+
+	for i, folder := range object.Spec.Folders {
+		for j, item := range folder.Namespaces {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, key := range slices.Sorted(maps.Keys(entry)) {
+				if !folderNamespaceFields[key] {
+					allErrors = append(allErrors, field.Forbidden(
+						specPath.Child("folders").Index(i).Child("namespaces").Index(j).Child(key),
+						"unknown field; namespace entries only support name and templateOverrides"))
+				}
+			}
+		}
+	}
+	return allErrors, nil
+}
+
+// collectUnknownSubfolderFields walks the subfolders of a raw tree node
+func collectUnknownSubfolderFields(node map[string]interface{}, fldPath *field.Path, paths *[]*field.Path) {
+	subfolders, _ := node["subfolders"].([]interface{})
+	for i, item := range subfolders {
+		subfolder, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		subPath := fldPath.Child("subfolders").Index(i)
+		for _, key := range slices.Sorted(maps.Keys(subfolder)) {
+			if !treeNodeFields[key] {
+				*paths = append(*paths, subPath.Child(key))
+			}
+		}
+		collectUnknownSubfolderFields(subfolder, subPath, paths)
+	}
+}
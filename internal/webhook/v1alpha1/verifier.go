@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// +kubebuilder:rbac:groups=user.openshift.io,resources=users,verbs=list
+
+// subjectVerifierWarnings warns about User and Group subjects that WebhookOptions.SubjectVerifier
+// cannot resolve. Like openShiftGroupWarnings, they are not rejected, since identities may be
+// provisioned after the FolderTree binding them.
+func (v *FolderTreeCustomValidator) subjectVerifierWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	if v.Options.SubjectVerifier == nil {
+		return nil
+	}
+	subjectMappings, err := rbac.ListSubjectMappings(ctx, v.Client)
+	if err != nil {
+		foldertreelog.Info("Could not verify the subjects of SubjectMappings", "error", err)
+	}
+
+	builder := &rbac.RoleBindingBuilder{
+		FolderTree:         folderTree,
+		ExcludedNamespaces: v.Options.ExcludedNamespaces,
+		SubjectMappings:    subjectMappings,
+		Cache:              v.Options.DesiredStateCache,
+	}
+	desired, err := rbac.CalculateDesiredRoleBindings(folderTree, builder)
+	if err != nil {
+		foldertreelog.Info("Could not calculate the RoleBindings to verify subjects", "error", err)
+		return nil
+	}
+	unresolved, err := rbac.UnresolvedSubjects(ctx, v.Options.SubjectVerifier, desired)
+	if err != nil {
+		foldertreelog.Info("Could not verify the subjects of the RoleBindings", "error", err)
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, subject := range unresolved {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s could not be resolved; RoleBindings binding it likely grant no access", subject))
+	}
+	return warnings
+}
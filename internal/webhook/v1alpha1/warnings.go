@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/metrics"
+	"kubevirt.io/folders/pkg/validation"
+)
+
+// DeprecatedClusterRoleAnnotation marks a ClusterRole slated for removal. Its value, e.g.
+// "replaced by team-view, removed after 2026-12-31", is included in the admission warning of every
+// FolderTree whose role binding templates reference the ClusterRole.
+const DeprecatedClusterRoleAnnotation = "rbac.kubevirt.io/deprecated"
+
+// limitWarningPercent is the share of a size limit above which admission warns that a FolderTree
+// approaches it
+const limitWarningPercent = 90
+
+// warningCategory classifies structured admission warnings, for users and the warnings metric
+type warningCategory string
+
+const (
+	// warningDeprecatedField reports the use of a field that a future API version removes
+	warningDeprecatedField warningCategory = "DeprecatedField"
+	// warningApproachingLimit reports a FolderTree close to one of its size limits
+	warningApproachingLimit warningCategory = "ApproachingLimit"
+	// warningDeprecatedClusterRole reports a template referencing a ClusterRole slated for removal
+	warningDeprecatedClusterRole warningCategory = "DeprecatedClusterRole"
+)
+
+// structuredWarning is an admission warning about a field of a FolderTree
+type structuredWarning struct {
+	category warningCategory
+	path     *field.Path
+	message  string
+}
+
+// String formats the warning as kubectl prints it after "Warning: "
+func (w structuredWarning) String() string {
+	return fmt.Sprintf("[%s] %s: %s", w.category, w.path, w.message)
+}
+
+// deprecatedField is a FolderTree field that is still served but will be removed
+type deprecatedField struct {
+	path *field.Path
+	// used reports whether a FolderTree sets the field
+	used func(folderTree *rbacv1alpha1.FolderTree) bool
+	// message explains what to use instead and when the field goes away
+	message string
+}
+
+// deprecatedFields lists the deprecated FolderTree fields. A field is added here for at least one
+// release before the API version removing it, so that users see the warning on every write.
+var deprecatedFields []deprecatedField
+
+// lifecycleWarnings returns the structured warnings of a FolderTree: deprecated fields, size limits
+// it approaches and ClusterRoles slated for removal that its templates reference. Every warning
+// is counted in the warnings metric.
+func (v *FolderTreeCustomValidator) lifecycleWarnings(ctx context.Context, operation string, folderTree *rbacv1alpha1.FolderTree) admission.Warnings {
+	var structured []structuredWarning
+	structured = append(structured, deprecatedFieldWarnings(folderTree)...)
+	structured = append(structured, v.limitWarnings(ctx, folderTree)...)
+	structured = append(structured, v.deprecatedClusterRoleWarnings(ctx, folderTree)...)
+
+	var warnings admission.Warnings
+	for _, warning := range structured {
+		metrics.RecordWarning(operation, string(warning.category))
+		warnings = append(warnings, warning.String())
+	}
+	return warnings
+}
+
+// deprecatedFieldWarnings warns about the deprecated fields a FolderTree sets
+func deprecatedFieldWarnings(folderTree *rbacv1alpha1.FolderTree) []structuredWarning {
+	var warnings []structuredWarning
+	for _, deprecated := range deprecatedFields {
+		if deprecated.used(folderTree) {
+			warnings = append(warnings, structuredWarning{warningDeprecatedField, deprecated.path, deprecated.message})
+		}
+	}
+	return warnings
+}
+
+// limitWarnings warns when a FolderTree uses more than limitWarningPercent of one of its size
+// limits, before it is rejected for exceeding it. An unreadable limits ConfigMap already rejected
+// the request, so it is not reported again.
+func (v *FolderTreeCustomValidator) limitWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) []structuredWarning {
+	config, err := v.loadLimitsConfig(ctx)
+	if err != nil {
+		return nil
+	}
+	limits, err := limitsFor(config, folderTree)
+	if err != nil {
+		return nil
+	}
+	limits = limits.Resolved()
+	size := validation.MeasureSpec(&folderTree.Spec)
+
+	var warnings []structuredWarning
+	for _, usage := range []struct {
+		path  *field.Path
+		what  string
+		count int
+		limit int
+	}{
+		{field.NewPath("spec", "folders"), "folders", size.Folders, limits.MaxFolders},
+		{field.NewPath("spec", "trees"), "tree nodes", size.TreeNodes, limits.MaxTreeNodes},
+		{field.NewPath("spec", "folders"), "namespaces", size.Namespaces, limits.MaxNamespaces},
+		{field.NewPath("spec", "folders"), "role binding templates", size.RoleBindingTemplates, limits.MaxRoleBindingTemplates},
+		{field.NewPath("spec", "folders"), "RoleBindings inherited by namespace groups", size.NamespaceGroupRoleBindings, limits.MaxNamespaceGroupRoleBindings},
+	} {
+		if usage.count*100 > usage.limit*limitWarningPercent && usage.count <= usage.limit {
+			warnings = append(warnings, structuredWarning{warningApproachingLimit, usage.path, fmt.Sprintf(
+				"FolderTree '%s' has %d %s, %d%% of the limit of %d",
+				folderTree.Name, usage.count, usage.what, usage.count*100/usage.limit, usage.limit)})
+		}
+	}
+	return warnings
+}
+
+// deprecatedClusterRoleWarnings warns about role binding templates referencing ClusterRoles
+// annotated with DeprecatedClusterRoleAnnotation. ClusterRoles that cannot be read are skipped.
+func (v *FolderTreeCustomValidator) deprecatedClusterRoleWarnings(ctx context.Context, folderTree *rbacv1alpha1.FolderTree) []structuredWarning {
+	deprecation := make(map[string]string)
+	lookup := func(name string) string {
+		message, ok := deprecation[name]
+		if !ok {
+			clusterRole := &rbacv1.ClusterRole{}
+			if err := v.Client.Get(ctx, types.NamespacedName{Name: name}, clusterRole); err == nil {
+				message = clusterRole.Annotations[DeprecatedClusterRoleAnnotation]
+			}
+			deprecation[name] = message
+		}
+		return message
+	}
+
+	var warnings []structuredWarning
+	check := func(template rbacv1alpha1.RoleBindingTemplate, fldPath *field.Path) {
+		if template.RoleRef.Kind != "ClusterRole" {
+			return
+		}
+		if message := lookup(template.RoleRef.Name); message != "" {
+			warnings = append(warnings, structuredWarning{warningDeprecatedClusterRole, fldPath.Child("roleRef", "name"), fmt.Sprintf(
+				"template '%s' references ClusterRole '%s', which is slated for removal: %s", template.Name, template.RoleRef.Name, message)})
+		}
+	}
+	for i, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		check(template, field.NewPath("spec", "globalRoleBindingTemplates").Index(i))
+	}
+	for i, folder := range folderTree.Spec.Folders {
+		for j, template := range folder.RoleBindingTemplates {
+			check(template, field.NewPath("spec", "folders").Index(i).Child("roleBindingTemplates").Index(j))
+		}
+	}
+	return warnings
+}
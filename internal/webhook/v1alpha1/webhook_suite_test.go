@@ -109,7 +109,16 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupFolderTreeWebhookWithManager(mgr)
+	err = SetupFolderTreeWebhookWithManager(mgr, WebhookOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupFolderTreePatchWebhookWithManager(mgr, WebhookOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupFolderTreeRevisionWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupSubjectMappingWebhookWithManager(mgr, WebhookOptions{})
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:webhook
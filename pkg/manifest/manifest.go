@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest reads FolderTrees from YAML or JSON manifests, for tools that work on
+// FolderTrees before they are applied to a cluster.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	rbacv1alpha2 "kubevirt.io/folders/api/v1alpha2"
+)
+
+// DecodeFolderTrees decodes the FolderTrees of a multi-document YAML or JSON stream, converting
+// v1alpha2 FolderTrees to v1alpha1. Documents of other kinds are skipped.
+func DecodeFolderTrees(input io.Reader) ([]*rbacv1alpha1.FolderTree, error) {
+	scheme := runtime.NewScheme()
+	if err := rbacv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := rbacv1alpha2.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var folderTrees []*rbacv1alpha1.FolderTree
+	decoder := utilyaml.NewYAMLOrJSONDecoder(input, 4096)
+	for {
+		var document json.RawMessage
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return folderTrees, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %v", err)
+		}
+		if len(document) == 0 || string(document) == "null" {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(document, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %v", err)
+		}
+		switch folderTree := obj.(type) {
+		case *rbacv1alpha1.FolderTree:
+			folderTrees = append(folderTrees, folderTree)
+		case *rbacv1alpha2.FolderTree:
+			hub := &rbacv1alpha1.FolderTree{}
+			if err := folderTree.ConvertTo(hub); err != nil {
+				return nil, fmt.Errorf("failed to convert FolderTree '%s': %v", folderTree.Name, err)
+			}
+			folderTrees = append(folderTrees, hub)
+		}
+	}
+}
+
+// ReadFolderTrees decodes the FolderTrees of a manifest file, or of stdin when filename is "-"
+func ReadFolderTrees(filename string) ([]*rbacv1alpha1.FolderTree, error) {
+	if filename == "-" {
+		return DecodeFolderTrees(os.Stdin)
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return DecodeFolderTrees(file)
+}
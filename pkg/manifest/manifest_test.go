@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rbacv1alpha2 "kubevirt.io/folders/api/v1alpha2"
+)
+
+func TestManifest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Manifest Package Suite")
+}
+
+var _ = Describe("DecodeFolderTrees", func() {
+	It("should decode FolderTrees of both versions and skip other objects", func() {
+		folderTrees, err := DecodeFolderTrees(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web-prod
+---
+apiVersion: rbac.kubevirt.io/v1alpha1
+kind: FolderTree
+metadata:
+  name: hub
+spec:
+  tree:
+    name: platform
+    subfolders:
+    - name: web
+  folders:
+  - name: web
+    namespaces: [web-prod]
+---
+---
+apiVersion: rbac.kubevirt.io/v1alpha2
+kind: FolderTree
+metadata:
+  name: spoke
+spec:
+  folders:
+  - name: platform
+  - name: web
+    parent: platform
+    namespaces: [web-prod]
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(folderTrees).To(HaveLen(2))
+
+		Expect(folderTrees[0].Name).To(Equal("hub"))
+		Expect(folderTrees[0].Spec.Tree.Subfolders[0].Name).To(Equal("web"))
+
+		Expect(folderTrees[1].Name).To(Equal("spoke"))
+		Expect(folderTrees[1].Spec.Tree).NotTo(BeNil())
+		Expect(folderTrees[1].Spec.Tree.Name).To(Equal("platform"))
+		Expect(folderTrees[1].Spec.Tree.Subfolders[0].Name).To(Equal("web"))
+	})
+
+	It("should decode JSON manifests", func() {
+		folderTrees, err := DecodeFolderTrees(strings.NewReader(
+			`{"apiVersion": "rbac.kubevirt.io/v1alpha1", "kind": "FolderTree", "metadata": {"name": "json"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(folderTrees).To(HaveLen(1))
+		Expect(folderTrees[0].Name).To(Equal("json"))
+	})
+
+	It("should decode namespace entries given as names or objects with templateOverrides", func() {
+		folderTrees, err := DecodeFolderTrees(strings.NewReader(`
+apiVersion: rbac.kubevirt.io/v1alpha1
+kind: FolderTree
+metadata:
+  name: hub
+spec:
+  folders:
+  - name: web
+    namespaces:
+    - web-prod
+    - name: web-staging
+      templateOverrides:
+        viewers:
+          subjects:
+          - {kind: Group, name: web-oncall, apiGroup: rbac.authorization.k8s.io}
+    - name: web-dev
+---
+apiVersion: rbac.kubevirt.io/v1alpha2
+kind: FolderTree
+metadata:
+  name: spoke
+spec:
+  folders:
+  - name: platform
+  - name: web
+    parent: platform
+    namespaces:
+    - name: web-prod
+      templateOverrides:
+        viewers:
+          subjects:
+          - {kind: Group, name: web-oncall, apiGroup: rbac.authorization.k8s.io}
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(folderTrees).To(HaveLen(2))
+
+		web := folderTrees[0].Spec.Folders[0]
+		Expect(web.Namespaces).To(Equal([]string{"web-prod", "web-staging", "web-dev"}))
+		Expect(web.NamespaceOverrides).To(HaveLen(1))
+		Expect(web.TemplateOverridesFor("web-staging")).To(HaveKey("viewers"))
+
+		// Only overridden namespaces are written back as objects
+		data, err := json.Marshal(web)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"namespaces":["web-prod",{"name":"web-staging","templateOverrides":{"viewers":`))
+		Expect(string(data)).To(ContainSubstring(`"web-dev"]`))
+
+		Expect(folderTrees[1].Spec.Tree.Subfolders[0].Name).To(Equal("web"))
+		Expect(folderTrees[1].Spec.Folders[1].Namespaces).To(Equal([]string{"web-prod"}))
+		Expect(folderTrees[1].Spec.Folders[1].TemplateOverridesFor("web-prod")).To(HaveKey("viewers"))
+	})
+
+	It("should keep the v1alpha2 folder fields next to object namespace entries", func() {
+		var folder rbacv1alpha2.Folder
+		Expect(json.Unmarshal([]byte(`{"name":"web","parent":"platform","exclude":["viewers"],`+
+			`"namespaces":["web-prod",{"name":"web-staging","templateOverrides":{"editors":{"subjects":[{"kind":"Group","name":"web-oncall"}]}}}]}`), &folder)).To(Succeed())
+		Expect(folder.Parent).To(Equal("platform"))
+		Expect(folder.Exclude).To(Equal([]string{"viewers"}))
+		Expect(folder.Namespaces).To(Equal([]string{"web-prod", "web-staging"}))
+		Expect(folder.TemplateOverridesFor("web-staging")).To(HaveKey("editors"))
+
+		data, err := json.Marshal(folder)
+		Expect(err).NotTo(HaveOccurred())
+		var roundTripped rbacv1alpha2.Folder
+		Expect(json.Unmarshal(data, &roundTripped)).To(Succeed())
+		Expect(roundTripped).To(Equal(folder))
+	})
+
+	It("should not recurse forever on duplicate v1alpha2 folder names", func() {
+		folderTrees, err := DecodeFolderTrees(strings.NewReader(`
+apiVersion: rbac.kubevirt.io/v1alpha2
+kind: FolderTree
+metadata:
+  name: duplicate
+spec:
+  folders:
+  - name: web
+  - name: web
+    parent: web
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(folderTrees[0].Spec.Folders).To(HaveLen(2))
+	})
+
+	It("should reject malformed manifests", func() {
+		_, err := DecodeFolderTrees(strings.NewReader("apiVersion: rbac.kubevirt.io/v1alpha1\nkind: FolderTree\nspec: [\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// AdoptAnnotation opts a FolderTree into adopting existing RoleBindings when set to "true".
+// It only has an effect when the controller runs with --adopt.
+const AdoptAnnotation = "foldertree.rbac.kubevirt.io/adopt"
+
+// AdoptionRequested reports whether a FolderTree is annotated to adopt existing RoleBindings
+func AdoptionRequested(folderTree *rbacv1alpha1.FolderTree) bool {
+	return folderTree.Annotations[AdoptAnnotation] == "true"
+}
+
+// matchAdoptedRoleBindings pairs desired RoleBindings with managed RoleBindings of the same template
+// in the same namespace that were adopted under another name. The adopted RoleBinding is moved to the
+// key of the desired one and the desired RoleBinding takes its name, so it is updated in place
+// instead of being deleted and created again under the generated name.
+func matchAdoptedRoleBindings(existing map[string]*rbacv1.RoleBinding, desired map[string]*DesiredRoleBinding) {
+	adopted := make(map[string]string)
+	for key, existingRB := range existing {
+		if _, exists := desired[key]; !exists {
+			template := existingRB.Labels["foldertree.rbac.kubevirt.io/role-binding-template"]
+			adopted[fmt.Sprintf("%s/%s", existingRB.Namespace, template)] = key
+		}
+	}
+	if len(adopted) == 0 {
+		return
+	}
+
+	for key, desiredRB := range desired {
+		if _, exists := existing[key]; exists {
+			continue
+		}
+		existingKey, ok := adopted[fmt.Sprintf("%s/%s", desiredRB.Namespace, desiredRB.RoleBindingTemplate.Name)]
+		if !ok {
+			continue
+		}
+		existing[key] = existing[existingKey]
+		delete(existing, existingKey)
+		desired[key] = renamedDesiredRoleBinding(desiredRB, existing[key].Name)
+	}
+}
+
+// renamedDesiredRoleBinding returns a copy of a desired RoleBinding with another name
+func renamedDesiredRoleBinding(desiredRB *DesiredRoleBinding, name string) *DesiredRoleBinding {
+	renamed := *desiredRB
+	renamed.RoleBinding = desiredRB.RoleBinding.DeepCopy()
+	renamed.RoleBinding.Name = name
+	return &renamed
+}
+
+// adoptRoleBindings replaces create operations by updates of unmanaged RoleBindings (without the
+// tree label) in the same namespace that already grant exactly the same role to the same subjects,
+// so migrating hand-managed RBAC into a FolderTree doesn't leave duplicates behind. The update adds
+// the labels, annotations and owner reference of a managed RoleBinding and keeps the name.
+// Every unmanaged RoleBinding is adopted at most once; candidates are tried in name order.
+func (da *DiffAnalyzer) adoptRoleBindings(ctx context.Context, operations []RoleBindingOperation) ([]RoleBindingOperation, error) {
+	candidates := make(map[string][]*rbacv1.RoleBinding)
+	for i, operation := range operations {
+		if operation.Type != OperationCreate {
+			continue
+		}
+
+		namespaceCandidates, listed := candidates[operation.Namespace]
+		if !listed {
+			var err error
+			if namespaceCandidates, err = da.listUnmanagedRoleBindings(ctx, operation.Namespace); err != nil {
+				return nil, err
+			}
+		}
+
+		for j, candidate := range namespaceCandidates {
+			if candidate.RoleRef != operation.DesiredRoleBinding.RoleRef ||
+				!subjectsEqual(candidate.Subjects, operation.DesiredRoleBinding.Subjects) {
+				continue
+			}
+			desired := operation.DesiredRoleBinding.DeepCopy()
+			desired.Name = candidate.Name
+			operations[i] = RoleBindingOperation{
+				Type:                OperationUpdate,
+				Namespace:           operation.Namespace,
+				RoleBindingTemplate: operation.RoleBindingTemplate,
+				ExistingRoleBinding: candidate,
+				DesiredRoleBinding:  desired,
+				Adopt:               true,
+			}
+			namespaceCandidates = append(namespaceCandidates[:j:j], namespaceCandidates[j+1:]...)
+			break
+		}
+		candidates[operation.Namespace] = namespaceCandidates
+	}
+	return operations, nil
+}
+
+// listUnmanagedRoleBindings returns the RoleBindings of a namespace that no FolderTree manages, sorted by name
+func (da *DiffAnalyzer) listUnmanagedRoleBindings(ctx context.Context, namespace string) ([]*rbacv1.RoleBinding, error) {
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := da.Client.List(ctx, roleBindingList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list RoleBindings in namespace '%s': %v", namespace, err)
+	}
+
+	var unmanaged []*rbacv1.RoleBinding
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		if _, managed := roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"]; managed || !roleBinding.DeletionTimestamp.IsZero() {
+			continue
+		}
+		unmanaged = append(unmanaged, roleBinding)
+	}
+	sort.Slice(unmanaged, func(i, j int) bool {
+		return unmanaged[i].Name < unmanaged[j].Name
+	})
+	return unmanaged, nil
+}
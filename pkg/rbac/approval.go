@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// LastModifiedByAnnotation records the user whose admission request last changed the spec of a
+	// FolderTree. It is maintained by the FolderTree defaulting webhook; edits by users are reverted.
+	LastModifiedByAnnotation = "foldertree.rbac.kubevirt.io/last-modified-by"
+
+	// LastModifiedByUIDAnnotation records the UID of the user in LastModifiedByAnnotation, when the
+	// authenticator provides one
+	LastModifiedByUIDAnnotation = "foldertree.rbac.kubevirt.io/last-modified-by-uid"
+
+	// ApprovedByAnnotation records on a RoleBinding the user who last modified the FolderTree when
+	// the RoleBinding was created, tracing generated RBAC back to the request that caused it
+	ApprovedByAnnotation = "foldertree.rbac.kubevirt.io/approved-by"
+)
+
+// StampApprovedBy annotates a RoleBinding about to be created with the last modifier of its
+// FolderTree. FolderTrees created before the defaulting webhook was installed have no
+// modifier recorded and leave the RoleBinding unchanged.
+func StampApprovedBy(roleBinding *rbacv1.RoleBinding, folderTree *rbacv1alpha1.FolderTree) {
+	approver := folderTree.Annotations[LastModifiedByAnnotation]
+	if approver == "" {
+		return
+	}
+	if roleBinding.Annotations == nil {
+		roleBinding.Annotations = make(map[string]string)
+	}
+	roleBinding.Annotations[ApprovedByAnnotation] = approver
+}
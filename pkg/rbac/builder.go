@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Builder builds the RoleBinding of a role binding template for one namespace of a FolderTree.
+// RoleBindingBuilder is the default implementation. Controllers embedding folder semantics can
+// customize naming, labels and subject resolution by setting RoleBindingBuilder.Override to a type
+// that embeds the RoleBindingBuilder and replaces some of its methods, while CalculateDesiredRoleBindings,
+// DiffAnalyzer and WebhookDiffAnalyzer keep working on the result.
+type Builder interface {
+	// NameFor returns the name of the RoleBindings of a template. Names must be unique per
+	// template within a namespace.
+	NameFor(templateName string) string
+
+	// Labels returns the labels of a RoleBinding of a template in a namespace of a folder. They
+	// must include the foldertree.rbac.kubevirt.io/tree label, by which DiffAnalyzer finds the
+	// RoleBindings of a FolderTree.
+	Labels(folderName, namespace string, template rbacv1alpha1.RoleBindingTemplate) map[string]string
+
+	// BuildRoleBinding builds the RoleBinding of a template in a namespace of a folder
+	BuildRoleBinding(folderName, namespace string, template rbacv1alpha1.RoleBindingTemplate) (*rbacv1.RoleBinding, error)
+}
+
+var _ Builder = &RoleBindingBuilder{}
+
+// builder returns the Override when set, and the RoleBindingBuilder itself otherwise
+func (rb *RoleBindingBuilder) builder() Builder {
+	if rb.Override != nil {
+		return rb.Override
+	}
+	return rb
+}
+
+// build builds a RoleBinding with the Override when set. The applied digest is recalculated,
+// since an Override may change the subjects or roleRef of the default RoleBinding.
+func (rb *RoleBindingBuilder) build(folderName, namespace string, template rbacv1alpha1.RoleBindingTemplate) (*rbacv1.RoleBinding, error) {
+	if rb.Override == nil {
+		return rb.BuildRoleBinding(folderName, namespace, template)
+	}
+	roleBinding, err := rb.Override.BuildRoleBinding(folderName, namespace, template)
+	if err != nil {
+		return nil, err
+	}
+	if roleBinding.Labels == nil {
+		roleBinding.Labels = make(map[string]string)
+	}
+	if roleBinding.Annotations == nil {
+		roleBinding.Annotations = make(map[string]string)
+	}
+	roleBinding.Subjects = uniqueSubjects(roleBinding.Subjects)
+	roleBinding.Annotations[AppliedDigestAnnotation] = BindingDigest(roleBinding)
+	return roleBinding, nil
+}
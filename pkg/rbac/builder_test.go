@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// tenantBuilder names RoleBindings after the tenant, labels them with it and binds the tenant's
+// group in addition to the template's subjects
+type tenantBuilder struct {
+	*RoleBindingBuilder
+	tenant string
+}
+
+func (b *tenantBuilder) NameFor(templateName string) string {
+	return b.tenant + "-" + templateName
+}
+
+func (b *tenantBuilder) Labels(folderName, namespace string, template rbacv1alpha1.RoleBindingTemplate) map[string]string {
+	labels := b.RoleBindingBuilder.Labels(folderName, namespace, template)
+	labels["example.com/tenant"] = b.tenant
+	return labels
+}
+
+func (b *tenantBuilder) BuildRoleBinding(folderName, namespace string, template rbacv1alpha1.RoleBindingTemplate) (*rbacv1.RoleBinding, error) {
+	roleBinding, err := b.RoleBindingBuilder.BuildRoleBinding(folderName, namespace, template)
+	if err != nil {
+		return nil, err
+	}
+	roleBinding.Subjects = append(roleBinding.Subjects, rbacv1.Subject{Kind: "Group", Name: b.tenant + "-admins", APIGroup: rbacv1.GroupName})
+	return roleBinding, nil
+}
+
+var _ = Describe("Builder", func() {
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			Folders: []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"web-prod"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "viewers",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+				}},
+			}},
+		},
+	}
+
+	newBuilder := func() *RoleBindingBuilder {
+		builder := &RoleBindingBuilder{FolderTree: folderTree, Cache: NewDesiredStateCache(1)}
+		builder.Override = &tenantBuilder{RoleBindingBuilder: builder, tenant: "acme"}
+		return builder
+	}
+
+	It("should calculate RoleBindings with the Override", func() {
+		desired, err := CalculateDesiredRoleBindings(folderTree, newBuilder())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.RoleBindings).To(HaveKey("web-prod/acme-viewers"))
+
+		roleBinding := desired.RoleBindings["web-prod/acme-viewers"].RoleBinding
+		Expect(roleBinding.Labels).To(HaveKeyWithValue("example.com/tenant", "acme"))
+		Expect(roleBinding.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", "tree"))
+		Expect(roleBinding.Labels).To(HaveKeyWithValue(FolderPathKey, "web"))
+		Expect(roleBinding.Subjects).To(ContainElement(HaveField("Name", "acme-admins")))
+		Expect(roleBinding.Annotations).To(HaveKeyWithValue(AppliedDigestAnnotation, BindingDigest(roleBinding)))
+	})
+
+	It("should find RoleBindings built with the Override in sync", func() {
+		builder := newBuilder()
+		desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+		existing := desired.RoleBindings["web-prod/acme-viewers"].RoleBinding.DeepCopy()
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+		operations, err := NewDiffAnalyzer(fakeClient, folderTree, builder).AnalyzeDiff(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(operations).To(BeEmpty())
+	})
+
+	It("should keep building the default RoleBindings without an Override", func() {
+		desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.RoleBindings).To(HaveKey("web-prod/foldertree-tree-viewers"))
+	})
+})
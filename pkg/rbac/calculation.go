@@ -0,0 +1,324 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// DesiredRoleBindingSet represents the complete set of RoleBindings that should exist
+// for a given FolderTree. This is shared logic used by both controller and webhook.
+type DesiredRoleBindingSet struct {
+	RoleBindings map[string]*DesiredRoleBinding // key: namespace/name
+}
+
+// CalculateDesiredRoleBindings calculates what RoleBindings should exist for a given FolderTree.
+// This is the shared logic used by both controller (for cluster state comparison) and
+// webhook (for FolderTree state comparison). Templates are resolved against spec.defaults first,
+// and templates whose expiresAt has passed are left out. The spec is canonicalized and the subjects
+// of every RoleBinding are sorted, so reordering the spec never changes the result. Results are
+// reused from the builder's DesiredStateCache when the FolderTree and the builder inputs have not changed.
+func CalculateDesiredRoleBindings(folderTree *rbacv1alpha1.FolderTree, builder *RoleBindingBuilder) (*DesiredRoleBindingSet, error) {
+	folderTree = Canonical(WithoutExpired(WithDefaults(folderTree), time.Now()))
+	if builder.Cache == nil || builder.Override != nil {
+		return calculateDesiredRoleBindings(folderTree, builder)
+	}
+
+	// Cached RoleBindings are calculated without owner references and extra metadata, so that the
+	// webhook and the controller share them, and are copied before both are set
+	key, err := desiredStateKey(folderTree, builder)
+	if err != nil {
+		return calculateDesiredRoleBindings(folderTree, builder)
+	}
+	cached, ok := builder.Cache.get(key)
+	if !ok {
+		unowned := *builder
+		unowned.Scheme = nil
+		unowned.ExtraMetadata = ExtraMetadata{}
+		if cached, err = calculateDesiredRoleBindings(folderTree, &unowned); err != nil {
+			return nil, err
+		}
+		builder.Cache.add(key, cached)
+	}
+
+	desired := cached.deepCopy()
+	for _, desiredRoleBinding := range desired.RoleBindings {
+		builder.ExtraMetadata.stamp(desiredRoleBinding.RoleBinding)
+		if err := builder.setOwnerReference(desiredRoleBinding.RoleBinding); err != nil {
+			return nil, err
+		}
+	}
+	return desired, nil
+}
+
+// calculateDesiredRoleBindings calculates the RoleBindings of a FolderTree whose templates are resolved
+func calculateDesiredRoleBindings(folderTree *rbacv1alpha1.FolderTree, builder *RoleBindingBuilder) (*DesiredRoleBindingSet, error) {
+	desired := make(map[string]*DesiredRoleBinding)
+
+	// Create a map of folder name to folder data for quick lookup
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range folderTree.Spec.Folders {
+		folderMap[folder.Name] = folder
+	}
+
+	// Excluded namespaces never receive RoleBindings, whatever the folders say
+	excluded := excludedNamespaceSet(folderTree, builder.ExcludedNamespaces)
+
+	// Global templates apply to every namespace, as if inherited from above the root
+	var globalTemplates []sourcedTemplate
+	for _, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		globalTemplates = append(globalTemplates, sourcedTemplate{RoleBindingTemplate: template, Remaining: math.MaxInt})
+	}
+
+	// Process the tree structures (if they exist)
+	roots := folderTree.Spec.Roots()
+	for _, root := range roots {
+		if err := calculateFromTreeNode(root, nil, folderMap, globalTemplates, excluded, desired, builder); err != nil {
+			return nil, err
+		}
+	}
+
+	// Process standalone folders (not in any tree)
+	for _, folder := range folderTree.Spec.Folders {
+		if !isInTree(folder.Name, roots) {
+			roleBindingTemplates := slices.Clip(globalTemplates)
+			for _, template := range folder.RoleBindingTemplates {
+				roleBindingTemplates = append(roleBindingTemplates, sourcedTemplate{RoleBindingTemplate: template, Source: folder.Name})
+			}
+			for _, namespace := range folder.Namespaces {
+				if excluded[namespace] {
+					continue
+				}
+				for _, roleBindingTemplate := range roleBindingTemplates {
+					template := withOverride(roleBindingTemplate.RoleBindingTemplate, folder.TemplateOverridesFor(namespace))
+					roleBinding, err := builder.build(folder.Name, namespace, template)
+					if err != nil {
+						return nil, fmt.Errorf("failed to build RoleBinding for standalone folder '%s': %v", folder.Name, err)
+					}
+					builder.StampFolderPath(roleBinding, []string{folder.Name}, roleBindingTemplate.Source)
+
+					key := fmt.Sprintf("%s/%s", namespace, roleBinding.Name)
+					desired[key] = &DesiredRoleBinding{
+						Namespace:           namespace,
+						Folder:              folder.Name,
+						RoleBindingTemplate: template,
+						RoleBinding:         roleBinding,
+					}
+				}
+			}
+		}
+	}
+
+	// Subject mappings and ServiceAccount selectors append subjects after the listed ones
+	for _, desiredRoleBinding := range desired {
+		SortSubjects(desiredRoleBinding.RoleBinding.Subjects)
+	}
+
+	return &DesiredRoleBindingSet{RoleBindings: desired}, nil
+}
+
+// sourcedTemplate is a role binding template together with the folder that defines it.
+// The source is empty for global templates.
+type sourcedTemplate struct {
+	rbacv1alpha1.RoleBindingTemplate
+	Source string
+
+	// Remaining is the number of levels below the folder receiving the template that inherit it too
+	Remaining int
+}
+
+// descend returns the inherited templates that reach one level further down
+func descend(inherited []sourcedTemplate) []sourcedTemplate {
+	var descending []sourcedTemplate
+	for _, template := range inherited {
+		if template.Remaining > 0 {
+			template.Remaining--
+			descending = append(descending, template)
+		}
+	}
+	return descending
+}
+
+// calculateFromTreeNode recursively calculates desired RoleBindings from tree structure.
+// parentPath holds the names of the node's ancestors, starting at the tree root.
+func calculateFromTreeNode(node rbacv1alpha1.TreeNode, parentPath []string, folderMap map[string]rbacv1alpha1.Folder, inheritedRoleBindingTemplates []sourcedTemplate,
+	excluded map[string]bool, desired map[string]*DesiredRoleBinding, builder *RoleBindingBuilder) error {
+	folderPath := append(slices.Clip(parentPath), node.Name)
+
+	// Drop the inherited templates the edge from the parent does not pass on
+	inheritedRoleBindingTemplates = withoutEdgeFiltered(inheritedRoleBindingTemplates, node)
+
+	// Get folder data for this node
+	folder, exists := folderMap[node.Name]
+	var templatesToInherit []sourcedTemplate
+
+	if exists {
+		// Drop the inherited templates this folder opts out of, for it and its descendants
+		inheritedRoleBindingTemplates = withoutBlocked(inheritedRoleBindingTemplates, folder.BlockInherited)
+
+		// Combine inherited role binding templates with this folder's role binding templates
+		allRoleBindingTemplates := slices.Clip(inheritedRoleBindingTemplates)
+		for _, template := range folder.RoleBindingTemplates {
+			allRoleBindingTemplates = append(allRoleBindingTemplates, sourcedTemplate{RoleBindingTemplate: template, Source: folder.Name})
+		}
+
+		// A namespace group also receives the templates of its descendants. They only flow up to
+		// this folder's namespaces and are not passed down again below.
+		if folder.InheritNamespaces {
+			for _, template := range DescendantTemplates(node, folderMap) {
+				allRoleBindingTemplates = append(allRoleBindingTemplates, sourcedTemplate{RoleBindingTemplate: template.RoleBindingTemplate, Source: template.Folder})
+			}
+		}
+
+		// Create desired RoleBindings for this folder's namespaces
+		for _, namespace := range folder.Namespaces {
+			if excluded[namespace] {
+				continue
+			}
+			for _, roleBindingTemplate := range allRoleBindingTemplates {
+				template := withOverride(roleBindingTemplate.RoleBindingTemplate, folder.TemplateOverridesFor(namespace))
+				roleBinding, err := builder.build(folder.Name, namespace, template)
+				if err != nil {
+					return fmt.Errorf("failed to build RoleBinding for folder '%s': %v", folder.Name, err)
+				}
+				builder.StampFolderPath(roleBinding, folderPath, roleBindingTemplate.Source)
+
+				key := fmt.Sprintf("%s/%s", namespace, roleBinding.Name)
+				desired[key] = &DesiredRoleBinding{
+					Namespace:           namespace,
+					Folder:              folder.Name,
+					RoleBindingTemplate: template,
+					RoleBinding:         roleBinding,
+				}
+			}
+		}
+
+		// Determine which templates should be inherited by child folders: the inherited templates
+		// that may go further down, and this folder's templates that propagate
+		templatesToInherit = descend(inheritedRoleBindingTemplates)
+		for _, template := range folder.RoleBindingTemplates {
+			if depth := PropagationDepth(template); depth > 0 {
+				templatesToInherit = append(templatesToInherit, sourcedTemplate{RoleBindingTemplate: template, Source: folder.Name, Remaining: depth - 1})
+			}
+		}
+	} else {
+		// Tree node exists but no folder data - only pass inherited role binding templates
+		templatesToInherit = descend(inheritedRoleBindingTemplates)
+	}
+
+	// Recurse into subfolders with templates that should be inherited
+	for _, subfolder := range node.Subfolders {
+		if err := calculateFromTreeNode(subfolder, folderPath, folderMap, templatesToInherit, excluded, desired, builder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DescendantTemplate is a role binding template of a folder below a tree node
+type DescendantTemplate struct {
+	rbacv1alpha1.RoleBindingTemplate
+
+	// Folder is the folder defining the template
+	Folder string
+
+	// Index is the index of the template in the role binding templates of Folder
+	Index int
+}
+
+// DescendantTemplates returns the role binding templates defined by the folders below a tree node,
+// in depth-first order, which the namespaces of a folder with inheritNamespaces receive too. Edge
+// filters and blocked templates only restrict inheritance downward, so they do not apply.
+func DescendantTemplates(node rbacv1alpha1.TreeNode, folderMap map[string]rbacv1alpha1.Folder) []DescendantTemplate {
+	var templates []DescendantTemplate
+	for _, subfolder := range node.Subfolders {
+		for j, template := range folderMap[subfolder.Name].RoleBindingTemplates {
+			templates = append(templates, DescendantTemplate{RoleBindingTemplate: template, Folder: subfolder.Name, Index: j})
+		}
+		templates = append(templates, DescendantTemplates(subfolder, folderMap)...)
+	}
+	return templates
+}
+
+// withOverride returns the template with the override of a namespace applied, if it has one
+func withOverride(template rbacv1alpha1.RoleBindingTemplate, overrides map[string]rbacv1alpha1.TemplateOverride) rbacv1alpha1.RoleBindingTemplate {
+	if override, ok := overrides[template.Name]; ok {
+		template.Subjects = override.Subjects
+	}
+	return template
+}
+
+// withoutBlocked returns the inherited templates except those named in blocked.
+// Global templates, which have no source folder, cannot be blocked.
+func withoutBlocked(inherited []sourcedTemplate, blocked []string) []sourcedTemplate {
+	if len(blocked) == 0 {
+		return inherited
+	}
+	var remaining []sourcedTemplate
+	for _, template := range inherited {
+		if template.Source != "" && slices.Contains(blocked, template.Name) {
+			continue
+		}
+		remaining = append(remaining, template)
+	}
+	return remaining
+}
+
+// withoutEdgeFiltered returns the inherited templates the inheritOnly and exclude lists of a tree
+// node pass on. Global templates, which have no source folder, are always passed on.
+func withoutEdgeFiltered(inherited []sourcedTemplate, node rbacv1alpha1.TreeNode) []sourcedTemplate {
+	if len(node.InheritOnly) == 0 && len(node.Exclude) == 0 {
+		return inherited
+	}
+	var remaining []sourcedTemplate
+	for _, template := range inherited {
+		if template.Source != "" && !node.Inherits(template.Name) {
+			continue
+		}
+		remaining = append(remaining, template)
+	}
+	return remaining
+}
+
+// isInTree checks if a folder name appears in any of the tree structures
+func isInTree(folderName string, roots []rbacv1alpha1.TreeNode) bool {
+	for _, root := range roots {
+		if isInTreeNode(folderName, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInTreeNode recursively checks if a folder name appears in a tree node
+func isInTreeNode(folderName string, node rbacv1alpha1.TreeNode) bool {
+	if node.Name == folderName {
+		return true
+	}
+	for _, subfolder := range node.Subfolders {
+		if isInTreeNode(folderName, subfolder) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Canonical returns the FolderTree with every list of its spec whose order carries no meaning
+// sorted: trees, subfolders, folders, templates, namespaces, subjects and the name lists of
+// inheritance filters. GitOps tools that reorder these lists therefore produce the same
+// canonical FolderTree. The original is never modified.
+func Canonical(folderTree *rbacv1alpha1.FolderTree) *rbacv1alpha1.FolderTree {
+	canonical := folderTree.DeepCopy()
+	spec := &canonical.Spec
+
+	if spec.Tree != nil {
+		canonicalTreeNode(spec.Tree)
+	}
+	for i := range spec.Trees {
+		canonicalTreeNode(&spec.Trees[i])
+	}
+	slices.SortFunc(spec.Trees, func(a, b rbacv1alpha1.TreeNode) int { return cmp.Compare(a.Name, b.Name) })
+
+	for i := range spec.Folders {
+		folder := &spec.Folders[i]
+		canonicalTemplates(folder.RoleBindingTemplates)
+		slices.SortFunc(folder.NetworkPolicyTemplates, func(a, b rbacv1alpha1.NetworkPolicyTemplate) int { return cmp.Compare(a.Name, b.Name) })
+		slices.SortFunc(folder.ResourceQuotaTemplates, func(a, b rbacv1alpha1.ResourceQuotaTemplate) int { return cmp.Compare(a.Name, b.Name) })
+		slices.Sort(folder.Namespaces)
+		for _, namespace := range folder.NamespaceOverrides {
+			for _, override := range namespace.TemplateOverrides {
+				SortSubjects(override.Subjects)
+			}
+		}
+		slices.SortFunc(folder.NamespaceOverrides, func(a, b rbacv1alpha1.NamespaceOverride) int { return cmp.Compare(a.Name, b.Name) })
+		slices.Sort(folder.PatchNamespaces)
+		slices.Sort(folder.BlockInherited)
+	}
+	slices.SortFunc(spec.Folders, func(a, b rbacv1alpha1.Folder) int { return cmp.Compare(a.Name, b.Name) })
+
+	canonicalTemplates(spec.GlobalRoleBindingTemplates)
+	slices.Sort(spec.ExcludedNamespaces)
+	if spec.Defaults != nil {
+		SortSubjects(spec.Defaults.Subjects)
+	}
+	return canonical
+}
+
+// canonicalTreeNode sorts the subfolders and inheritance filters of a tree node and its descendants
+func canonicalTreeNode(treeNode *rbacv1alpha1.TreeNode) {
+	for i := range treeNode.Subfolders {
+		canonicalTreeNode(&treeNode.Subfolders[i])
+	}
+	slices.SortFunc(treeNode.Subfolders, func(a, b rbacv1alpha1.TreeNode) int { return cmp.Compare(a.Name, b.Name) })
+	slices.Sort(treeNode.InheritOnly)
+	slices.Sort(treeNode.Exclude)
+}
+
+// canonicalTemplates sorts role binding templates by name and the subjects and subjectRefs of each
+func canonicalTemplates(templates []rbacv1alpha1.RoleBindingTemplate) {
+	for i := range templates {
+		SortSubjects(templates[i].Subjects)
+		slices.Sort(templates[i].SubjectRefs)
+	}
+	slices.SortFunc(templates, func(a, b rbacv1alpha1.RoleBindingTemplate) int { return cmp.Compare(a.Name, b.Name) })
+}
+
+// SortSubjects sorts RBAC subjects by kind, namespace, name and API group
+func SortSubjects(subjects []rbacv1.Subject) {
+	slices.SortFunc(subjects, func(a, b rbacv1.Subject) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.APIGroup, b.APIGroup),
+		)
+	})
+}
+
+// SpecHash returns the hash of the canonical spec of a FolderTree, which is the same for all
+// FolderTrees whose specs only differ in the order of their lists
+func SpecHash(folderTree *rbacv1alpha1.FolderTree) (string, error) {
+	data, err := json.Marshal(Canonical(folderTree).Spec)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// uniqueSubjects returns the subjects without repeats, keeping the first of each in place
+func uniqueSubjects(subjects []rbacv1.Subject) []rbacv1.Subject {
+	seen := make(map[rbacv1.Subject]bool, len(subjects))
+	return slices.DeleteFunc(subjects, func(subject rbacv1.Subject) bool {
+		if seen[subject] {
+			return true
+		}
+		seen[subject] = true
+		return false
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Canonical", func() {
+	var (
+		auditors   = rbacv1.Subject{Kind: "Group", Name: "auditors", APIGroup: "rbac.authorization.k8s.io"}
+		developers = rbacv1.Subject{Kind: "Group", Name: "developers", APIGroup: "rbac.authorization.k8s.io"}
+		viewRole   = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+		folderTree *rbacv1alpha1.FolderTree
+	)
+
+	// permuted returns the FolderTree with its folders, subfolders, templates, namespaces and subjects reversed
+	permuted := func(folderTree *rbacv1alpha1.FolderTree) *rbacv1alpha1.FolderTree {
+		reversed := folderTree.DeepCopy()
+		slices.Reverse(reversed.Spec.Tree.Subfolders)
+		slices.Reverse(reversed.Spec.Folders)
+		for i := range reversed.Spec.Folders {
+			folder := &reversed.Spec.Folders[i]
+			slices.Reverse(folder.Namespaces)
+			slices.Reverse(folder.RoleBindingTemplates)
+			for j := range folder.RoleBindingTemplates {
+				slices.Reverse(folder.RoleBindingTemplates[j].Subjects)
+			}
+		}
+		return reversed
+	}
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "api"}, {Name: "web"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{Name: "audit", Subjects: []rbacv1.Subject{auditors}, RoleRef: viewRole},
+							{Name: "developers", Subjects: []rbacv1.Subject{auditors, developers}, RoleRef: viewRole, Propagate: boolPtr(true)},
+						},
+					},
+					{Name: "api", Namespaces: []string{"api-ns", "api-staging-ns"}},
+					{Name: "web", Namespaces: []string{"web-ns"}},
+				},
+			},
+		}
+	})
+
+	It("should return the same spec and hash for permutations without changing the original", func() {
+		reordered := permuted(folderTree)
+		original := reordered.DeepCopy()
+
+		Expect(Canonical(reordered).Spec).To(Equal(Canonical(folderTree).Spec))
+		Expect(reordered).To(Equal(original))
+
+		hash, err := SpecHash(folderTree)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(SpecHash(reordered)).To(Equal(hash))
+
+		By("changing the hash when the content changes")
+		reordered.Spec.Folders[0].Namespaces = reordered.Spec.Folders[0].Namespaces[1:]
+		Expect(SpecHash(reordered)).NotTo(Equal(hash))
+	})
+
+	It("should calculate the same RoleBindings with sorted subjects for permutations", func() {
+		desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		reordered := permuted(folderTree)
+		desiredReordered, err := CalculateDesiredRoleBindings(reordered, &RoleBindingBuilder{FolderTree: reordered})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(desiredReordered.RoleBindings).To(Equal(desired.RoleBindings))
+		developersBinding := desired.RoleBindings["web-ns/foldertree-org-developers"]
+		Expect(developersBinding).NotTo(BeNil())
+		Expect(developersBinding.RoleBinding.Subjects).To(Equal([]rbacv1.Subject{auditors, developers}))
+	})
+})
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// ClusterSecretLabel marks the Secrets of the cluster Secret namespace that hold the kubeconfig of
+	// a remote cluster when set to "true". The name of the Secret is the name of the cluster.
+	ClusterSecretLabel = "rbac.kubevirt.io/cluster"
+
+	// ClusterKubeconfigKey is the key of the kubeconfig in a cluster Secret
+	ClusterKubeconfigKey = "kubeconfig"
+)
+
+// SelectClusters returns the cluster Secrets of the namespace that spec.clusters of a FolderTree
+// selects, sorted by name. A FolderTree without spec.clusters selects none.
+func SelectClusters(ctx context.Context, c client.Reader, namespace string, folderTree *rbacv1alpha1.FolderTree) ([]corev1.Secret, error) {
+	if folderTree.Spec.Clusters == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(folderTree.Spec.Clusters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec.clusters: %v", err)
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{ClusterSecretLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list cluster Secrets: %v", err)
+	}
+
+	var clusters []corev1.Secret
+	for _, secret := range secretList.Items {
+		if selector.Matches(labels.Set(secret.Labels)) {
+			clusters = append(clusters, secret)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
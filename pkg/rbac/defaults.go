@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// WithDefaults returns the FolderTree with spec.defaults filled into the role binding templates
+// that leave the corresponding fields unset. Fields a template sets are never changed and the
+// defaults are copied in order, so the result only depends on the spec; resolving a FolderTree
+// that was already resolved returns it unchanged. The original is never modified.
+func WithDefaults(folderTree *rbacv1alpha1.FolderTree) *rbacv1alpha1.FolderTree {
+	defaults := folderTree.Spec.Defaults
+	if defaults == nil || (len(defaults.Subjects) == 0 && defaults.Propagate == nil) {
+		return folderTree
+	}
+
+	resolved := folderTree.DeepCopy()
+	for i := range resolved.Spec.Folders {
+		for j := range resolved.Spec.Folders[i].RoleBindingTemplates {
+			template := &resolved.Spec.Folders[i].RoleBindingTemplates[j]
+			applyDefaultSubjects(template, defaults)
+			// A depth implies propagation, so the default must not turn it off
+			if template.Propagate == nil && template.PropagateDepth == nil && defaults.Propagate != nil {
+				template.Propagate = ptr.To(*defaults.Propagate)
+			}
+		}
+	}
+	// Propagate has no effect on global templates, so only their subjects are defaulted
+	for i := range resolved.Spec.GlobalRoleBindingTemplates {
+		applyDefaultSubjects(&resolved.Spec.GlobalRoleBindingTemplates[i], defaults)
+	}
+	return resolved
+}
+
+// applyDefaultSubjects sets the default subjects on a template that lists neither subjects nor
+// subjectRefs and selects no ServiceAccounts
+func applyDefaultSubjects(template *rbacv1alpha1.RoleBindingTemplate, defaults *rbacv1alpha1.FolderTreeDefaults) {
+	if len(template.Subjects) == 0 && len(template.SubjectRefs) == 0 && template.ServiceAccountSelector == nil &&
+		len(defaults.Subjects) > 0 {
+		template.Subjects = slices.Clone(defaults.Subjects)
+	}
+}
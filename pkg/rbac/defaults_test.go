@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("WithDefaults", func() {
+	var (
+		auditors   = rbacv1.Subject{Kind: "Group", Name: "auditors", APIGroup: "rbac.authorization.k8s.io"}
+		developers = rbacv1.Subject{Kind: "Group", Name: "developers", APIGroup: "rbac.authorization.k8s.io"}
+		viewRole   = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+		folderTree *rbacv1alpha1.FolderTree
+	)
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{Name: "audit", RoleRef: viewRole},
+							{Name: "developers", Subjects: []rbacv1.Subject{developers}, RoleRef: viewRole, Propagate: boolPtr(false)},
+						},
+						Namespaces: []string{"platform-ns"},
+					},
+					{Name: "web", Namespaces: []string{"web-ns"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{Name: "global-audit", RoleRef: viewRole}},
+			},
+		}
+	})
+
+	It("should return the FolderTree unchanged without defaults", func() {
+		Expect(WithDefaults(folderTree)).To(BeIdenticalTo(folderTree))
+	})
+
+	It("should fill in unset fields without changing the original", func() {
+		folderTree.Spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{
+			Subjects:  []rbacv1.Subject{auditors},
+			Propagate: boolPtr(true),
+		}
+		original := folderTree.DeepCopy()
+
+		resolved := WithDefaults(folderTree)
+
+		Expect(folderTree).To(Equal(original))
+		templates := resolved.Spec.Folders[0].RoleBindingTemplates
+		Expect(templates[0].Subjects).To(Equal([]rbacv1.Subject{auditors}))
+		Expect(templates[0].Propagate).To(Equal(boolPtr(true)))
+		Expect(templates[1].Subjects).To(Equal([]rbacv1.Subject{developers}))
+		Expect(templates[1].Propagate).To(Equal(boolPtr(false)))
+		Expect(resolved.Spec.GlobalRoleBindingTemplates[0].Subjects).To(Equal([]rbacv1.Subject{auditors}))
+		Expect(resolved.Spec.GlobalRoleBindingTemplates[0].Propagate).To(BeNil())
+
+		By("resolving an already resolved FolderTree to the same result")
+		Expect(WithDefaults(resolved)).To(Equal(resolved))
+	})
+
+	It("should not turn off propagation of templates with a propagateDepth", func() {
+		folderTree.Spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{Propagate: boolPtr(false)}
+		folderTree.Spec.Folders[0].RoleBindingTemplates[0].PropagateDepth = ptr.To[int32](1)
+
+		templates := WithDefaults(folderTree).Spec.Folders[0].RoleBindingTemplates
+		Expect(templates[0].Propagate).To(BeNil())
+		Expect(PropagationDepth(templates[0])).To(Equal(1))
+		Expect(PropagationDepth(templates[1])).To(BeZero())
+	})
+
+	It("should calculate the same RoleBindings as spelling the defaults out", func() {
+		folderTree.Spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{
+			Subjects:  []rbacv1.Subject{auditors},
+			Propagate: boolPtr(true),
+		}
+		explicit := folderTree.DeepCopy()
+		explicit.Spec.Defaults = nil
+		explicit.Spec.Folders[0].RoleBindingTemplates[0].Subjects = []rbacv1.Subject{auditors}
+		explicit.Spec.Folders[0].RoleBindingTemplates[0].Propagate = boolPtr(true)
+		explicit.Spec.GlobalRoleBindingTemplates[0].Subjects = []rbacv1.Subject{auditors}
+
+		withDefaults, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		spelledOut, err := CalculateDesiredRoleBindings(explicit, &RoleBindingBuilder{FolderTree: explicit})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(withDefaults.RoleBindings).To(HaveLen(len(spelledOut.RoleBindings)))
+		Expect(withDefaults.RoleBindings).To(HaveKey("web-ns/foldertree-org-audit"))
+		for key, desiredRB := range spelledOut.RoleBindings {
+			Expect(withDefaults.RoleBindings).To(HaveKey(key))
+			Expect(withDefaults.RoleBindings[key].RoleBinding).To(Equal(desiredRB.RoleBinding))
+		}
+
+		Expect(CalculateInheritance(folderTree)).To(Equal(CalculateInheritance(explicit)))
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"k8s.io/utils/lru"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// DesiredStateCache keeps the RoleBindings calculated for FolderTrees, keyed by a hash of
+// everything the calculation depends on. The webhook and the controller run in the same manager
+// and share one cache, so the controller reuses the result computed at admission instead of
+// recalculating it for large trees. Templates are resolved against spec.defaults and their
+// expiresAt before hashing, so an expiring template changes the key. A nil cache caches nothing.
+type DesiredStateCache struct {
+	entries *lru.Cache
+}
+
+// NewDesiredStateCache returns a cache of the desired RoleBindings of up to size FolderTree states
+func NewDesiredStateCache(size int) *DesiredStateCache {
+	return &DesiredStateCache{entries: lru.New(size)}
+}
+
+// desiredStateKey hashes the resolved FolderTree and the builder inputs of a calculation. Owner
+// references and extra metadata are left out, since they are set on the returned copies.
+func desiredStateKey(folderTree *rbacv1alpha1.FolderTree, builder *RoleBindingBuilder) (string, error) {
+	data, err := json.Marshal(struct {
+		Tree               string
+		Spec               rbacv1alpha1.FolderTreeSpec
+		ExcludedNamespaces []string
+		SubjectMappings    SubjectMappings
+		ServiceAccounts    ServiceAccounts
+	}{
+		Tree:               builder.FolderTree.Name,
+		Spec:               folderTree.Spec,
+		ExcludedNamespaces: builder.ExcludedNamespaces,
+		SubjectMappings:    builder.SubjectMappings,
+		ServiceAccounts:    builder.ServiceAccounts,
+	})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// get returns the cached RoleBindings for key. Callers must not modify them.
+func (c *DesiredStateCache) get(key string) (*DesiredRoleBindingSet, bool) {
+	if c == nil || key == "" {
+		return nil, false
+	}
+	cached, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return cached.(*DesiredRoleBindingSet), true
+}
+
+// add caches the RoleBindings calculated for key
+func (c *DesiredStateCache) add(key string, desired *DesiredRoleBindingSet) {
+	if c == nil || key == "" {
+		return
+	}
+	c.entries.Add(key, desired)
+}
+
+// Len returns the number of cached FolderTree states
+func (c *DesiredStateCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.entries.Len()
+}
+
+// deepCopy returns a copy of the set whose RoleBindings callers may modify
+func (s *DesiredRoleBindingSet) deepCopy() *DesiredRoleBindingSet {
+	roleBindings := make(map[string]*DesiredRoleBinding, len(s.RoleBindings))
+	for key, desired := range s.RoleBindings {
+		roleBindings[key] = &DesiredRoleBinding{
+			Namespace:           desired.Namespace,
+			Folder:              desired.Folder,
+			RoleBindingTemplate: *desired.RoleBindingTemplate.DeepCopy(),
+			RoleBinding:         desired.RoleBinding.DeepCopy(),
+		}
+	}
+	return &DesiredRoleBindingSet{RoleBindings: roleBindings}
+}
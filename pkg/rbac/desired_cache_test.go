@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Desired State Cache", func() {
+	var (
+		viewers    = []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}}
+		viewRole   = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+		scheme     *runtime.Scheme
+		cache      *DesiredStateCache
+		folderTree *rbacv1alpha1.FolderTree
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		cache = NewDesiredStateCache(8)
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid")},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{Name: "viewers", Subjects: viewers, RoleRef: viewRole}},
+						Namespaces:           []string{"platform-ns"},
+					},
+				},
+			},
+		}
+	})
+
+	It("should reuse the webhook's calculation in the controller with owner references", func() {
+		admission := &RoleBindingBuilder{FolderTree: folderTree, Cache: cache}
+		fromWebhook, err := CalculateDesiredRoleBindings(folderTree, admission)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.Len()).To(Equal(1))
+		Expect(fromWebhook.RoleBindings["platform-ns/foldertree-org-viewers"].RoleBinding.OwnerReferences).To(BeEmpty())
+
+		reconcile := &RoleBindingBuilder{FolderTree: folderTree, Scheme: scheme, Cache: cache}
+		fromController, err := CalculateDesiredRoleBindings(folderTree, reconcile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.Len()).To(Equal(1))
+		roleBinding := fromController.RoleBindings["platform-ns/foldertree-org-viewers"].RoleBinding
+		Expect(roleBinding.OwnerReferences).To(ConsistOf(HaveField("UID", types.UID("org-uid"))))
+
+		uncached, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree, Scheme: scheme})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fromController).To(Equal(uncached))
+	})
+
+	It("should return copies callers may modify", func() {
+		builder := &RoleBindingBuilder{FolderTree: folderTree, Cache: cache}
+		first, err := CalculateDesiredRoleBindings(folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+		first.RoleBindings["platform-ns/foldertree-org-viewers"].RoleBinding.Subjects = nil
+
+		second, err := CalculateDesiredRoleBindings(folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.RoleBindings["platform-ns/foldertree-org-viewers"].RoleBinding.Subjects).To(Equal(viewers))
+	})
+
+	It("should recalculate when the spec or the builder inputs change", func() {
+		_, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree, Cache: cache})
+		Expect(err).NotTo(HaveOccurred())
+
+		excluded := &RoleBindingBuilder{FolderTree: folderTree, ExcludedNamespaces: []string{"platform-ns"}, Cache: cache}
+		desired, err := CalculateDesiredRoleBindings(folderTree, excluded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.RoleBindings).To(BeEmpty())
+		Expect(cache.Len()).To(Equal(2))
+
+		folderTree.Spec.Folders[0].Namespaces = append(folderTree.Spec.Folders[0].Namespaces, "platform-dev")
+		desired, err = CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree, Cache: cache})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.RoleBindings).To(HaveKey("platform-dev/foldertree-org-viewers"))
+		Expect(cache.Len()).To(Equal(3))
+	})
+})
@@ -19,6 +19,7 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,14 @@ type RoleBindingOperation struct {
 	RoleBindingTemplate rbacv1alpha1.RoleBindingTemplate
 	ExistingRoleBinding *rbacv1.RoleBinding // nil for create operations
 	DesiredRoleBinding  *rbacv1.RoleBinding // nil for delete operations
+
+	// OwnershipOnly marks updates that only replace the FolderTree owner references of
+	// ExistingRoleBinding, leaving the rest of it as it is
+	OwnershipOnly bool
+
+	// Adopt marks updates that take over an unmanaged ExistingRoleBinding granting exactly
+	// what the template grants, instead of creating a duplicate
+	Adopt bool
 }
 
 // String returns a human-readable description of the operation
@@ -54,6 +63,10 @@ func (op *RoleBindingOperation) String() string {
 		return fmt.Sprintf("CREATE RoleBinding '%s' in namespace '%s' for template '%s'",
 			op.DesiredRoleBinding.Name, op.Namespace, op.RoleBindingTemplate.Name)
 	case OperationUpdate:
+		if op.Adopt {
+			return fmt.Sprintf("ADOPT RoleBinding '%s' in namespace '%s' for template '%s'",
+				op.ExistingRoleBinding.Name, op.Namespace, op.RoleBindingTemplate.Name)
+		}
 		return fmt.Sprintf("UPDATE RoleBinding '%s' in namespace '%s' for template '%s'",
 			op.ExistingRoleBinding.Name, op.Namespace, op.RoleBindingTemplate.Name)
 	case OperationDelete:
@@ -64,12 +77,40 @@ func (op *RoleBindingOperation) String() string {
 	}
 }
 
+// TemplateName returns the name of the role binding template the operation belongs to.
+// Delete operations carry an empty template, so the template label of the existing RoleBinding is used instead.
+func (op *RoleBindingOperation) TemplateName() string {
+	if op.RoleBindingTemplate.Name != "" {
+		return op.RoleBindingTemplate.Name
+	}
+	if op.ExistingRoleBinding != nil {
+		return op.ExistingRoleBinding.Labels["foldertree.rbac.kubevirt.io/role-binding-template"]
+	}
+	return ""
+}
+
+// targetKey returns the "<namespace>/<name>" key of the RoleBinding the operation acts on
+func (op *RoleBindingOperation) targetKey() string {
+	if op.ExistingRoleBinding != nil {
+		return fmt.Sprintf("%s/%s", op.Namespace, op.ExistingRoleBinding.Name)
+	}
+	return fmt.Sprintf("%s/%s", op.Namespace, op.DesiredRoleBinding.Name)
+}
+
 // DiffAnalyzer compares the desired state (from FolderTree) with the current state (existing RoleBindings)
 // and returns a list of operations needed to synchronize them
 type DiffAnalyzer struct {
 	Client     client.Client
 	FolderTree *rbacv1alpha1.FolderTree
 	Builder    *RoleBindingBuilder
+
+	// Drift holds the operations that would revert out-of-band edits but were left out by
+	// AnalyzeDiff because the FolderTree's drift policy is Warn or Ignore
+	Drift []RoleBindingOperation
+
+	// Adopt makes AnalyzeDiff adopt unmanaged RoleBindings that exactly match a desired
+	// RoleBinding instead of creating duplicates (see adoptRoleBindings)
+	Adopt bool
 }
 
 // NewDiffAnalyzer creates a new DiffAnalyzer instance
@@ -95,9 +136,20 @@ func (da *DiffAnalyzer) AnalyzeDiff(ctx context.Context) ([]RoleBindingOperation
 		return nil, fmt.Errorf("failed to collect desired RoleBindings: %v", err)
 	}
 
+	// Keep RoleBindings adopted under another name instead of recreating them under the generated name
+	matchAdoptedRoleBindings(existingRoleBindings, desiredRoleBindings)
+
 	// Compare and generate operations
 	operations := da.compareAndGenerateOperations(existingRoleBindings, desiredRoleBindings)
 
+	if da.Adopt {
+		if operations, err = da.adoptRoleBindings(ctx, operations); err != nil {
+			return nil, err
+		}
+	}
+
+	// Execute operations in an order that doesn't revoke access being replaced
+	SortOperations(da.FolderTree, operations)
 	return operations, nil
 }
 
@@ -124,6 +176,7 @@ func (da *DiffAnalyzer) getExistingRoleBindings(ctx context.Context) (map[string
 // DesiredRoleBinding represents a RoleBinding that should exist according to the FolderTree spec
 type DesiredRoleBinding struct {
 	Namespace           string
+	Folder              string
 	RoleBindingTemplate rbacv1alpha1.RoleBindingTemplate
 	RoleBinding         *rbacv1.RoleBinding
 }
@@ -143,14 +196,39 @@ func (da *DiffAnalyzer) collectDesiredRoleBindings() (map[string]*DesiredRoleBin
 func (da *DiffAnalyzer) compareAndGenerateOperations(existing map[string]*rbacv1.RoleBinding, desired map[string]*DesiredRoleBinding) []RoleBindingOperation {
 	var operations []RoleBindingOperation
 
+	da.Drift = nil
+	enforce := da.FolderTree.Spec.DriftPolicy == "" || da.FolderTree.Spec.DriftPolicy == rbacv1alpha1.DriftPolicyEnforce
+
 	// Check for creates and updates
 	for key, desiredRB := range desired {
 		if existingRB, exists := existing[key]; exists {
 			// RoleBinding exists, check if it needs updating
 			if da.needsUpdate(existingRB, desiredRB.RoleBinding) {
+				// An unchanged desired digest means the difference was not caused by the spec
+				if !enforce && da.isDrift(existingRB, desiredRB.RoleBinding) {
+					da.Drift = append(da.Drift, RoleBindingOperation{
+						Type:                OperationUpdate,
+						Namespace:           desiredRB.Namespace,
+						RoleBindingTemplate: desiredRB.RoleBindingTemplate,
+						ExistingRoleBinding: existingRB,
+						DesiredRoleBinding:  desiredRB.RoleBinding,
+					})
+					if da.needsAdoption(existingRB, desiredRB.RoleBinding) {
+						operations = append(operations, da.adoptionOperation(existingRB, desiredRB))
+					}
+					continue
+				}
+
 				// Check if roleRef changed - if so, we need DELETE+CREATE because roleRef is immutable
 				if existingRB.RoleRef != desiredRB.RoleBinding.RoleRef {
-					// RoleRef changed - need to delete and recreate
+					// RoleRef changed - need to delete and recreate, under another name unless the
+					// replacement policy deletes first (see SortOperations)
+					replacement := desiredRB.RoleBinding
+					if createsBeforeDeletes(da.FolderTree) {
+						replacement = replacement.DeepCopy()
+						replacement.Name = ReplacementRoleBindingName(
+							da.Builder.builder().NameFor(desiredRB.RoleBindingTemplate.Name), replacement.RoleRef)
+					}
 					operations = append(operations, RoleBindingOperation{
 						Type:                OperationDelete,
 						Namespace:           desiredRB.Namespace,
@@ -163,7 +241,7 @@ func (da *DiffAnalyzer) compareAndGenerateOperations(existing map[string]*rbacv1
 						Namespace:           desiredRB.Namespace,
 						RoleBindingTemplate: desiredRB.RoleBindingTemplate,
 						ExistingRoleBinding: nil,
-						DesiredRoleBinding:  desiredRB.RoleBinding,
+						DesiredRoleBinding:  replacement,
 					})
 				} else {
 					// Only subjects or labels changed - safe to update
@@ -175,6 +253,9 @@ func (da *DiffAnalyzer) compareAndGenerateOperations(existing map[string]*rbacv1
 						DesiredRoleBinding:  desiredRB.RoleBinding,
 					})
 				}
+			} else if da.needsAdoption(existingRB, desiredRB.RoleBinding) {
+				// Only the owner references differ, e.g. after switching owner reference mode
+				operations = append(operations, da.adoptionOperation(existingRB, desiredRB))
 			}
 		} else {
 			// RoleBinding doesn't exist, needs to be created
@@ -208,7 +289,7 @@ func (da *DiffAnalyzer) compareAndGenerateOperations(existing map[string]*rbacv1
 // needsUpdate checks if an existing RoleBinding needs to be updated to match the desired state
 func (da *DiffAnalyzer) needsUpdate(existing, desired *rbacv1.RoleBinding) bool {
 	// Compare subjects
-	if !da.subjectsEqual(existing.Subjects, desired.Subjects) {
+	if !subjectsEqual(existing.Subjects, desired.Subjects) {
 		return true
 	}
 
@@ -217,42 +298,87 @@ func (da *DiffAnalyzer) needsUpdate(existing, desired *rbacv1.RoleBinding) bool
 		return true
 	}
 
-	// Compare labels (only the ones we manage)
+	// Compare labels (only the ones we manage), so labels set by other tools are never fought over
 	for key, desiredValue := range desired.Labels {
+		if !IsManagedKey(key) {
+			continue
+		}
 		if existingValue, exists := existing.Labels[key]; !exists || existingValue != desiredValue {
 			return true
 		}
 	}
 
+	// Compare the folder path annotations, which are kept even when the path is too long for a label,
+	// and the description and reference of the template
+	for _, key := range []string{FolderPathKey, SourceFolderAnnotation, DescriptionAnnotation, ReferenceAnnotation} {
+		if existing.Annotations[key] != desired.Annotations[key] {
+			return true
+		}
+	}
+
+	// The extra labels and annotations configured for all RoleBindings are managed too
+	if da.Builder != nil && da.Builder.ExtraMetadata.differs(existing) {
+		return true
+	}
+
 	return false
 }
 
-// subjectsEqual compares two slices of RBAC subjects for equality
-func (da *DiffAnalyzer) subjectsEqual(a, b []rbacv1.Subject) bool {
-	if len(a) != len(b) {
+// needsAdoption reports whether the FolderTree owner references of an existing RoleBinding must be
+// replaced by the desired ones. RoleBindings are matched by their labels regardless of ownership, so
+// this strips owner references when they are disabled and adopts RoleBindings owned by an earlier
+// FolderTree of the same name. RoleBindings without any FolderTree owner reference are left unowned,
+// e.g. after turning owner references back on; the cleanup finalizer still covers them.
+// Ownership is only compared when the builder manages it.
+func (da *DiffAnalyzer) needsAdoption(existing, desired *rbacv1.RoleBinding) bool {
+	if da.Builder == nil || !da.Builder.managesOwnership() {
 		return false
 	}
+	return slices.ContainsFunc(existing.OwnerReferences, isFolderTreeOwnerReference) && !ownershipEqual(existing, desired)
+}
+
+// adoptionOperation returns an update operation that only changes the owner references of an
+// existing RoleBinding, leaving its content, including out-of-band edits, as it is
+func (da *DiffAnalyzer) adoptionOperation(existing *rbacv1.RoleBinding, desired *DesiredRoleBinding) RoleBindingOperation {
+	adopted := existing.DeepCopy()
+	adopted.OwnerReferences = MergeOwnerReferences(existing, desired.RoleBinding)
+	return RoleBindingOperation{
+		Type:                OperationUpdate,
+		Namespace:           desired.Namespace,
+		RoleBindingTemplate: desired.RoleBindingTemplate,
+		ExistingRoleBinding: existing,
+		DesiredRoleBinding:  adopted,
+		OwnershipOnly:       true,
+	}
+}
 
-	// Create maps for comparison (order shouldn't matter)
-	aMap := make(map[string]rbacv1.Subject)
-	bMap := make(map[string]rbacv1.Subject)
+// isDrift reports whether an existing RoleBinding that differs from the desired one was edited
+// out-of-band, i.e. it was last written for the same desired content it should have now.
+// RoleBindings without a recorded digest (written by older controller versions) are never drift;
+// the digest is recorded by the update that reverts them. A changed folder path means the
+// namespace moved within the tree, and a changed description or reference means the template was
+// edited, neither of which is drift.
+func (da *DiffAnalyzer) isDrift(existing, desired *rbacv1.RoleBinding) bool {
+	applied, ok := existing.Annotations[AppliedDigestAnnotation]
+	return ok && applied == desired.Annotations[AppliedDigestAnnotation] &&
+		existing.Annotations[FolderPathKey] == desired.Annotations[FolderPathKey] &&
+		existing.Annotations[DescriptionAnnotation] == desired.Annotations[DescriptionAnnotation] &&
+		existing.Annotations[ReferenceAnnotation] == desired.Annotations[ReferenceAnnotation]
+}
 
+// subjectsEqual reports whether two slices of RBAC subjects bind the same subjects. Order and
+// repeated subjects carry no meaning in a RoleBinding, so both are ignored.
+func subjectsEqual(a, b []rbacv1.Subject) bool {
+	aSet := make(map[rbacv1.Subject]bool, len(a))
 	for _, subject := range a {
-		key := fmt.Sprintf("%s:%s:%s:%s", subject.Kind, subject.Name, subject.Namespace, subject.APIGroup)
-		aMap[key] = subject
+		aSet[subject] = true
 	}
-
+	bSet := make(map[rbacv1.Subject]bool, len(b))
 	for _, subject := range b {
-		key := fmt.Sprintf("%s:%s:%s:%s", subject.Kind, subject.Name, subject.Namespace, subject.APIGroup)
-		bMap[key] = subject
-	}
-
-	// Compare maps
-	for key, subjectA := range aMap {
-		if subjectB, exists := bMap[key]; !exists || subjectA != subjectB {
+		if !aSet[subject] {
 			return false
 		}
+		bSet[subject] = true
 	}
-
-	return true
+	return len(aSet) == len(bSet)
 }
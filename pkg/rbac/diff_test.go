@@ -0,0 +1,1884 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Helper function to create bool pointers
+func boolPtr(b bool) *bool { return &b }
+
+var _ = Describe("DiffAnalyzer", func() {
+	var (
+		ctx          context.Context
+		fakeClient   client.Client
+		folderTree   *rbacv1alpha1.FolderTree
+		builder      *RoleBindingBuilder
+		diffAnalyzer *DiffAnalyzer
+		scheme       *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-tree",
+			},
+		}
+
+		builder = &RoleBindingBuilder{
+			FolderTree: folderTree,
+			Scheme:     scheme,
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		diffAnalyzer = NewDiffAnalyzer(fakeClient, folderTree, builder)
+	})
+
+	Context("when no existing RoleBindings exist", func() {
+		It("should generate create operations for all desired RoleBindings", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns1", "test-ns2"},
+					},
+				},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2)) // 2 namespaces × 1 template = 2 operations
+
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationCreate))
+				Expect(op.DesiredRoleBinding).NotTo(BeNil())
+				Expect(op.ExistingRoleBinding).To(BeNil())
+			}
+		})
+	})
+
+	Context("when existing RoleBindings match desired state", func() {
+		It("should generate no operations", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			// Create existing RoleBinding that matches desired state
+			existingRB := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-admin-template",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by":                      "foldertree-controller",
+						"foldertree.rbac.kubevirt.io/tree":                  "test-tree",
+						"foldertree.rbac.kubevirt.io/role-binding-template": "admin-template",
+						"foldertree.rbac.kubevirt.io/path":                  "test-folder",
+						"foldertree.rbac.kubevirt.io/inherited":             "false",
+					},
+					Annotations: map[string]string{
+						"foldertree.rbac.kubevirt.io/path":          "test-folder",
+						"foldertree.rbac.kubevirt.io/source-folder": "test-folder",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "test-user",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty()) // No operations needed
+		})
+	})
+
+	Context("when existing RoleBindings need updates", func() {
+		It("should generate update operations", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "updated-user", // Different from existing
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			// Create existing RoleBinding with different subjects
+			existingRB := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-admin-template",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by":                      "foldertree-controller",
+						"foldertree.rbac.kubevirt.io/tree":                  "test-tree",
+						"foldertree.rbac.kubevirt.io/role-binding-template": "admin-template",
+						"foldertree.rbac.kubevirt.io/path":                  "test-folder",
+						"foldertree.rbac.kubevirt.io/inherited":             "false",
+					},
+					Annotations: map[string]string{
+						"foldertree.rbac.kubevirt.io/path":          "test-folder",
+						"foldertree.rbac.kubevirt.io/source-folder": "test-folder",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "old-user", // Different from desired
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+
+			op := operations[0]
+			Expect(op.Type).To(Equal(OperationUpdate))
+			Expect(op.ExistingRoleBinding).NotTo(BeNil())
+			Expect(op.DesiredRoleBinding).NotTo(BeNil())
+			Expect(op.DesiredRoleBinding.Subjects[0].Name).To(Equal("updated-user"))
+		})
+	})
+
+	Context("when existing RoleBindings are no longer needed", func() {
+		It("should generate delete operations", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns"}, // Only one namespace now
+					},
+				},
+			}
+
+			// Create existing RoleBindings in two namespaces
+			existingRB1 := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-admin-template",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by":                      "foldertree-controller",
+						"foldertree.rbac.kubevirt.io/tree":                  "test-tree",
+						"foldertree.rbac.kubevirt.io/role-binding-template": "admin-template",
+						"foldertree.rbac.kubevirt.io/path":                  "test-folder",
+						"foldertree.rbac.kubevirt.io/inherited":             "false",
+					},
+					Annotations: map[string]string{
+						"foldertree.rbac.kubevirt.io/path":          "test-folder",
+						"foldertree.rbac.kubevirt.io/source-folder": "test-folder",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "test-user",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+			existingRB2 := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-admin-template",
+					Namespace: "old-ns", // This namespace is no longer in the spec
+					Labels: map[string]string{
+						"foldertree.rbac.kubevirt.io/tree": "test-tree",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "test-user",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingRB1)).To(Succeed())
+			Expect(fakeClient.Create(ctx, existingRB2)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1)) // Only delete operation for old-ns
+
+			op := operations[0]
+			Expect(op.Type).To(Equal(OperationDelete))
+			Expect(op.ExistingRoleBinding).NotTo(BeNil())
+			Expect(op.ExistingRoleBinding.Namespace).To(Equal("old-ns"))
+			Expect(op.DesiredRoleBinding).To(BeNil())
+		})
+	})
+
+	Context("with propagate field", func() {
+		It("should respect propagate=false and not inherit templates", func() {
+			// Helper function to create bool pointer
+			boolPtr := func(b bool) *bool { return &b }
+
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{
+							Name: "child",
+						},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "parent",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:      "propagating-template",
+								Propagate: boolPtr(true), // Explicitly set to true
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "parent-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+							{
+								Name:      "non-propagating-template",
+								Propagate: boolPtr(false), // Set to false - should not inherit
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "parent-secrets-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+					},
+					{
+						Name: "child",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "child-template",
+								// No propagate field - defaults to true
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "child-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "edit",
+								},
+							},
+						},
+						Namespaces: []string{"child-ns"},
+					},
+				},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Should have 2 operations: propagating-template + child-template
+			// non-propagating-template should NOT be inherited
+			Expect(operations).To(HaveLen(2))
+
+			templateNames := make(map[string]bool)
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationCreate))
+				Expect(op.Namespace).To(Equal("child-ns"))
+				templateNames[op.RoleBindingTemplate.Name] = true
+			}
+
+			// Should have the propagating template and child's own template
+			Expect(templateNames).To(HaveKey("propagating-template"))
+			Expect(templateNames).To(HaveKey("child-template"))
+
+			// Should NOT have the non-propagating template
+			Expect(templateNames).NotTo(HaveKey("non-propagating-template"))
+		})
+
+		It("should handle default propagate behavior (nil means false)", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{
+							Name: "child",
+						},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "parent",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "default-propagate-template",
+								// No Propagate field specified - should default to true
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "parent-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+					},
+					{
+						Name:       "child",
+						Namespaces: []string{"child-ns"},
+						// No role binding templates - should inherit from parent
+					},
+				},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty()) // Should NOT inherit the parent template (default is false)
+
+			// No operations should be created since propagate defaults to false
+		})
+	})
+
+	Context("with tree inheritance", func() {
+		It("should generate operations for inherited role binding templates", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{
+							Name: "child",
+						},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "parent",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:      "parent-template",
+								Propagate: boolPtr(true), // Explicitly enable propagation
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "parent-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "view",
+								},
+							},
+						},
+					},
+					{
+						Name: "child",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "child-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "child-group",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "edit",
+								},
+							},
+						},
+						Namespaces: []string{"child-ns"},
+					},
+				},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2)) // 2 templates × 1 namespace = 2 operations (parent propagates, child is local)
+
+			// Should have operations for both parent and child templates in child namespace
+			templateNames := make(map[string]bool)
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationCreate))
+				Expect(op.Namespace).To(Equal("child-ns"))
+				templateNames[op.RoleBindingTemplate.Name] = true
+			}
+			Expect(templateNames).To(HaveKey("parent-template"))
+			Expect(templateNames).To(HaveKey("child-template"))
+		})
+
+		It("should delete inherited RoleBindings below a folder once it blocks the template", func() {
+			viewTemplate := func(name string) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name:      name,
+					Propagate: boolPtr(true),
+					Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name + "-group", APIGroup: "rbac.authorization.k8s.io"}},
+					RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}
+			}
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "child", Subfolders: []rbacv1alpha1.TreeNode{{Name: "grandchild"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewTemplate("auditors")}, Namespaces: []string{"parent-ns"}},
+					{Name: "child", Namespaces: []string{"child-ns"}},
+					{Name: "grandchild", Namespaces: []string{"grandchild-ns"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewTemplate("security")},
+			}
+
+			// Apply the unblocked state first, so the block has existing RoleBindings to remove
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(6))
+			for _, op := range operations {
+				Expect(fakeClient.Create(ctx, op.DesiredRoleBinding)).To(Succeed())
+			}
+
+			folderTree.Spec.Folders[1].BlockInherited = []string{"auditors"}
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2))
+			deleted := make(map[string]bool)
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationDelete))
+				Expect(op.ExistingRoleBinding.Name).To(Equal("foldertree-test-tree-auditors"))
+				deleted[op.Namespace] = true
+			}
+			Expect(deleted).To(Equal(map[string]bool{"child-ns": true, "grandchild-ns": true}))
+
+			// Removing the block recreates them
+			for _, op := range operations {
+				Expect(fakeClient.Delete(ctx, op.ExistingRoleBinding)).To(Succeed())
+			}
+			folderTree.Spec.Folders[1].BlockInherited = nil
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2))
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationCreate))
+				Expect(op.RoleBindingTemplate.Name).To(Equal("auditors"))
+			}
+		})
+		It("should only pass the templates an edge of the tree lets through to the subfolder", func() {
+			viewTemplate := func(name string) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name:      name,
+					Propagate: boolPtr(true),
+					Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name + "-group", APIGroup: "rbac.authorization.k8s.io"}},
+					RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}
+			}
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "only", InheritOnly: []string{"auditors"}, Subfolders: []rbacv1alpha1.TreeNode{{Name: "grandchild"}}},
+						{Name: "excluding", Exclude: []string{"auditors"}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "parent",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewTemplate("auditors"), viewTemplate("viewers")},
+						Namespaces:           []string{"parent-ns"},
+					},
+					{Name: "only", Namespaces: []string{"only-ns"}},
+					{Name: "grandchild", Namespaces: []string{"grandchild-ns"}},
+					{Name: "excluding", Namespaces: []string{"excluding-ns"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewTemplate("security")},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			templates := make(map[string][]string)
+			for _, op := range operations {
+				templates[op.Namespace] = append(templates[op.Namespace], op.RoleBindingTemplate.Name)
+			}
+			Expect(templates["parent-ns"]).To(ConsistOf("auditors", "viewers", "security"))
+			Expect(templates["only-ns"]).To(ConsistOf("auditors", "security"))
+			Expect(templates["grandchild-ns"]).To(ConsistOf("auditors", "security"))
+			Expect(templates["excluding-ns"]).To(ConsistOf("viewers", "security"))
+		})
+
+		It("should only apply a template to descendants within its propagateDepth", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "parent",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "child", Subfolders: []rbacv1alpha1.TreeNode{
+							{Name: "grandchild", Subfolders: []rbacv1alpha1.TreeNode{{Name: "great-grandchild"}}},
+						}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "parent",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:           "team",
+							PropagateDepth: ptr.To[int32](1),
+							Subjects:       []rbacv1.Subject{{Kind: "Group", Name: "team", APIGroup: "rbac.authorization.k8s.io"}},
+							RoleRef:        rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+						}},
+						Namespaces: []string{"parent-ns"},
+					},
+					{
+						Name: "child",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:           "leads",
+							Propagate:      boolPtr(true),
+							PropagateDepth: ptr.To[int32](2),
+							Subjects:       []rbacv1.Subject{{Kind: "Group", Name: "leads", APIGroup: "rbac.authorization.k8s.io"}},
+							RoleRef:        rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+						}},
+						Namespaces: []string{"child-ns"},
+					},
+					{Name: "grandchild", Namespaces: []string{"grandchild-ns"}},
+					{Name: "great-grandchild", Namespaces: []string{"great-grandchild-ns"}},
+				},
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			applied := make(map[string][]string)
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationCreate))
+				applied[op.RoleBindingTemplate.Name] = append(applied[op.RoleBindingTemplate.Name], op.Namespace)
+			}
+			Expect(applied["team"]).To(ConsistOf("parent-ns", "child-ns"))
+			Expect(applied["leads"]).To(ConsistOf("child-ns", "grandchild-ns", "great-grandchild-ns"))
+		})
+
+		It("should apply the templates of descendants to the namespaces of a namespace group", func() {
+			editTemplate := func(name string) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name:     name,
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"},
+				}
+			}
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "org",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+						{Name: "data"},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "org", Namespaces: []string{"org-ns"}},
+					{
+						Name:                 "platform",
+						InheritNamespaces:    true,
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{editTemplate("sre")},
+						Namespaces:           []string{"platform-shared"},
+					},
+					{
+						Name:                 "web",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{editTemplate("web-devs")},
+						Namespaces:           []string{"web-ns"},
+					},
+					{
+						Name:                 "data",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{editTemplate("data-devs")},
+						Namespaces:           []string{"data-ns"},
+					},
+				},
+			}
+
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			templates := make(map[string][]string)
+			for _, desiredRB := range desired.RoleBindings {
+				templates[desiredRB.Namespace] = append(templates[desiredRB.Namespace], desiredRB.RoleBindingTemplate.Name)
+			}
+			Expect(templates["platform-shared"]).To(ConsistOf("sre", "web-devs"))
+			Expect(templates["web-ns"]).To(ConsistOf("web-devs"))
+			Expect(templates["data-ns"]).To(ConsistOf("data-devs"))
+			Expect(templates).NotTo(HaveKey("org-ns"))
+
+			pulledUp := desired.RoleBindings["platform-shared/foldertree-test-tree-web-devs"]
+			Expect(pulledUp).NotTo(BeNil())
+			Expect(pulledUp.Folder).To(Equal("platform"))
+			Expect(pulledUp.RoleBinding.Labels).To(HaveKeyWithValue(InheritedLabel, "true"))
+			Expect(pulledUp.RoleBinding.Annotations).To(HaveKeyWithValue(SourceFolderAnnotation, "web"))
+		})
+	})
+
+	Context("with mixed operations", func() {
+		It("should generate create, update, and delete operations as needed", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "updated-user", // Will need update
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+							{
+								Name: "new-template", // Will need create
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "new-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "edit",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			// Create existing RoleBindings
+			existingRB1 := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-admin-template",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"foldertree.rbac.kubevirt.io/tree": "test-tree",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "old-user", // Different from desired
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+			existingRB2 := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-tree-old-template",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"foldertree.rbac.kubevirt.io/tree": "test-tree",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "old-user",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingRB1)).To(Succeed())
+			Expect(fakeClient.Create(ctx, existingRB2)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(3)) // 1 update + 1 create + 1 delete
+
+			operationTypes := make(map[OperationType]int)
+			for _, op := range operations {
+				operationTypes[op.Type]++
+			}
+			Expect(operationTypes[OperationCreate]).To(Equal(1)) // new-template
+			Expect(operationTypes[OperationUpdate]).To(Equal(1)) // admin-template
+			Expect(operationTypes[OperationDelete]).To(Equal(1)) // old-template
+		})
+	})
+
+	Context("RoleBindingOperation String method", func() {
+		It("should resolve template names for all operation types", func() {
+			createOp := RoleBindingOperation{
+				Type:                OperationCreate,
+				Namespace:           "test-ns",
+				RoleBindingTemplate: rbacv1alpha1.RoleBindingTemplate{Name: "admin-template"},
+			}
+			Expect(createOp.TemplateName()).To(Equal("admin-template"))
+
+			// Delete operations carry an empty template; the label on the existing RoleBinding is used
+			deleteOp := RoleBindingOperation{
+				Type:      OperationDelete,
+				Namespace: "test-ns",
+				ExistingRoleBinding: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foldertree-test-tree-old-template",
+						Namespace: "test-ns",
+						Labels: map[string]string{
+							"foldertree.rbac.kubevirt.io/role-binding-template": "old-template",
+						},
+					},
+				},
+			}
+			Expect(deleteOp.TemplateName()).To(Equal("old-template"))
+		})
+
+		It("should return correct string representations", func() {
+			// Test CREATE operation
+			createOp := RoleBindingOperation{
+				Type:      OperationCreate,
+				Namespace: "test-ns",
+				RoleBindingTemplate: rbacv1alpha1.RoleBindingTemplate{
+					Name: "test-template",
+				},
+				DesiredRoleBinding: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-rb",
+					},
+				},
+			}
+			Expect(createOp.String()).To(ContainSubstring("CREATE RoleBinding 'test-rb' in namespace 'test-ns' for template 'test-template'"))
+
+			// Test UPDATE operation
+			updateOp := RoleBindingOperation{
+				Type:      OperationUpdate,
+				Namespace: "test-ns",
+				RoleBindingTemplate: rbacv1alpha1.RoleBindingTemplate{
+					Name: "test-template",
+				},
+				ExistingRoleBinding: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-rb",
+					},
+				},
+			}
+			Expect(updateOp.String()).To(ContainSubstring("UPDATE RoleBinding 'test-rb' in namespace 'test-ns' for template 'test-template'"))
+
+			// Test DELETE operation
+			deleteOp := RoleBindingOperation{
+				Type:      OperationDelete,
+				Namespace: "test-ns",
+				ExistingRoleBinding: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-rb",
+					},
+				},
+			}
+			Expect(deleteOp.String()).To(ContainSubstring("DELETE RoleBinding 'test-rb' in namespace 'test-ns'"))
+		})
+	})
+
+	Context("RoleRef Change Scenarios", func() {
+		It("should generate DELETE+CREATE operations when roleRef changes", func() {
+			// Create existing RoleBinding with 'view' roleRef
+			existingRB := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-admin",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"foldertree.rbac.kubevirt.io/tree": "test",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "Group",
+						Name:     "test-group",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					Kind:     "ClusterRole",
+					Name:     "view", // Original roleRef
+					APIGroup: "rbac.authorization.k8s.io",
+				},
+			}
+
+			// Add existing RoleBinding to fake client
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingRB).Build()
+
+			// Create FolderTree with changed roleRef
+			folderTree = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "test-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "admin",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "test-group",
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										Kind:     "ClusterRole",
+										Name:     "edit", // Changed from 'view' to 'edit'
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+							},
+							Namespaces: []string{"test-ns"},
+						},
+					},
+				},
+			}
+
+			builder = &RoleBindingBuilder{FolderTree: folderTree, Scheme: scheme}
+			diffAnalyzer = NewDiffAnalyzer(fakeClient, folderTree, builder)
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Should generate CREATE+DELETE operations (not UPDATE), creating the replacement first
+			Expect(operations).To(HaveLen(2))
+			createOp, deleteOp := operations[0], operations[1]
+
+			// Verify CREATE operation, which uses another name as the old RoleBinding still exists
+			Expect(createOp.Type).To(Equal(OperationCreate))
+			Expect(createOp.DesiredRoleBinding.Name).To(Equal(ReplacementRoleBindingName("foldertree-test-admin", createOp.DesiredRoleBinding.RoleRef)))
+			Expect(createOp.DesiredRoleBinding.Name).To(HavePrefix("foldertree-test-admin-"))
+			Expect(createOp.DesiredRoleBinding.RoleRef.Name).To(Equal("edit"))
+
+			// Verify DELETE operation
+			Expect(deleteOp.Type).To(Equal(OperationDelete))
+			Expect(deleteOp.ExistingRoleBinding.Name).To(Equal("foldertree-test-admin"))
+			Expect(deleteOp.ExistingRoleBinding.RoleRef.Name).To(Equal("view"))
+
+			By("keeping the replacement under its name once the old RoleBinding is gone")
+			Expect(fakeClient.Delete(ctx, existingRB)).To(Succeed())
+			Expect(fakeClient.Create(ctx, createOp.DesiredRoleBinding)).To(Succeed())
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+
+			By("replacing it under yet another name when the roleRef changes back")
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "view"
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2))
+			Expect(operations[0].Type).To(Equal(OperationCreate))
+			Expect(operations[0].DesiredRoleBinding.Name).To(Equal(ReplacementRoleBindingName("foldertree-test-admin", operations[0].DesiredRoleBinding.RoleRef)))
+			Expect(operations[1].Type).To(Equal(OperationDelete))
+			Expect(operations[1].ExistingRoleBinding.Name).To(Equal(createOp.DesiredRoleBinding.Name))
+
+			By("deleting first and keeping the name with the DeleteBeforeCreate replacement policy")
+			folderTree.Spec.ReplacementPolicy = rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(2))
+			Expect(operations[0].Type).To(Equal(OperationDelete))
+			Expect(operations[0].ExistingRoleBinding.Name).To(Equal(createOp.DesiredRoleBinding.Name))
+			Expect(operations[1].Type).To(Equal(OperationCreate))
+			Expect(operations[1].DesiredRoleBinding.Name).To(Equal(createOp.DesiredRoleBinding.Name))
+		})
+
+		It("should order operations by namespace and replacement policy", func() {
+			roleBinding := func(namespace, name string) *rbacv1.RoleBinding {
+				return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+			}
+			operations := []RoleBindingOperation{
+				{Type: OperationDelete, Namespace: "ns-b", ExistingRoleBinding: roleBinding("ns-b", "rb-1")},
+				{Type: OperationUpdate, Namespace: "ns-a", ExistingRoleBinding: roleBinding("ns-a", "rb-2"), DesiredRoleBinding: roleBinding("ns-a", "rb-2")},
+				{Type: OperationDelete, Namespace: "ns-a", ExistingRoleBinding: roleBinding("ns-a", "rb-1")},
+				{Type: OperationCreate, Namespace: "ns-b", DesiredRoleBinding: roleBinding("ns-b", "rb-2")},
+				{Type: OperationCreate, Namespace: "ns-a", DesiredRoleBinding: roleBinding("ns-a", "rb-3")},
+				{Type: OperationCreate, Namespace: "ns-a", DesiredRoleBinding: roleBinding("ns-a", "rb-1")},
+			}
+			order := func() []string {
+				var keys []string
+				for _, operation := range operations {
+					keys = append(keys, string(operation.Type)+" "+operation.targetKey())
+				}
+				return keys
+			}
+
+			SortOperations(folderTree, operations)
+			Expect(order()).To(Equal([]string{
+				"create ns-a/rb-1", "create ns-a/rb-3", "update ns-a/rb-2", "delete ns-a/rb-1",
+				"create ns-b/rb-2", "delete ns-b/rb-1",
+			}))
+
+			folderTree.Spec.ReplacementPolicy = rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate
+			SortOperations(folderTree, operations)
+			Expect(order()).To(Equal([]string{
+				"delete ns-a/rb-1", "create ns-a/rb-1", "create ns-a/rb-3", "update ns-a/rb-2",
+				"delete ns-b/rb-1", "create ns-b/rb-2",
+			}))
+		})
+
+		It("should generate UPDATE operation when only subjects change (roleRef unchanged)", func() {
+			// Create existing RoleBinding
+			existingRB := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foldertree-test-admin",
+					Namespace: "test-ns",
+					Labels: map[string]string{
+						"foldertree.rbac.kubevirt.io/tree": "test",
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "Group",
+						Name:     "old-group", // Original subject
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					Kind:     "ClusterRole",
+					Name:     "view", // Same roleRef
+					APIGroup: "rbac.authorization.k8s.io",
+				},
+			}
+
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingRB).Build()
+
+			// Create FolderTree with changed subjects but same roleRef
+			folderTree = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name: "test-folder",
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name: "admin",
+									Subjects: []rbacv1.Subject{
+										{
+											Kind:     "Group",
+											Name:     "new-group", // Changed subject
+											APIGroup: "rbac.authorization.k8s.io",
+										},
+									},
+									RoleRef: rbacv1.RoleRef{
+										Kind:     "ClusterRole",
+										Name:     "view", // Same roleRef
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+							},
+							Namespaces: []string{"test-ns"},
+						},
+					},
+				},
+			}
+
+			builder = &RoleBindingBuilder{FolderTree: folderTree, Scheme: scheme}
+			diffAnalyzer = NewDiffAnalyzer(fakeClient, folderTree, builder)
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Should generate single UPDATE operation (not DELETE+CREATE)
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(operations[0].ExistingRoleBinding.RoleRef.Name).To(Equal("view"))
+			Expect(operations[0].DesiredRoleBinding.RoleRef.Name).To(Equal("view"))
+			Expect(operations[0].DesiredRoleBinding.Subjects[0].Name).To(Equal("new-group"))
+		})
+	})
+
+	Context("with global role binding templates", func() {
+		It("should apply global templates to tree and standalone namespaces", func() {
+			globalTemplates := make([]rbacv1alpha1.RoleBindingTemplate, 1, 4) // spare capacity must not be shared between folders
+			globalTemplates[0] = rbacv1alpha1.RoleBindingTemplate{
+				Name: "auditors",
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "Group",
+						Name:     "auditors",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+			}
+			folderTemplate := func(name string) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name: name,
+					Subjects: []rbacv1.Subject{
+						{
+							Kind:     "Group",
+							Name:     name,
+							APIGroup: "rbac.authorization.k8s.io",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: "rbac.authorization.k8s.io",
+						Kind:     "ClusterRole",
+						Name:     "edit",
+					},
+				}
+			}
+
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				GlobalRoleBindingTemplates: globalTemplates,
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "root",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "left"}, {Name: "right"}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", Namespaces: []string{"root-ns"}},
+					{Name: "left", Namespaces: []string{"left-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{folderTemplate("left-editors")}},
+					{Name: "right", Namespaces: []string{"right-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{folderTemplate("right-editors")}},
+					{Name: "standalone", Namespaces: []string{"standalone-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{folderTemplate("standalone-editors")}},
+				},
+			}
+
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings).To(HaveLen(7))
+			for _, namespace := range []string{"root-ns", "left-ns", "right-ns", "standalone-ns"} {
+				Expect(desired.RoleBindings).To(HaveKey(namespace + "/foldertree-test-tree-auditors"))
+			}
+			Expect(desired.RoleBindings).To(HaveKey("left-ns/foldertree-test-tree-left-editors"))
+			Expect(desired.RoleBindings).To(HaveKey("right-ns/foldertree-test-tree-right-editors"))
+			Expect(desired.RoleBindings).To(HaveKey("standalone-ns/foldertree-test-tree-standalone-editors"))
+			Expect(desired.RoleBindings).NotTo(HaveKey("left-ns/foldertree-test-tree-right-editors"))
+
+			// The spec must not have been modified through the shared backing array
+			Expect(folderTree.Spec.GlobalRoleBindingTemplates).To(HaveLen(1))
+			Expect(globalTemplates[:2][1].Name).To(BeEmpty())
+		})
+	})
+
+	Context("with a drift policy", func() {
+		var existingRB *rbacv1.RoleBinding
+
+		BeforeEach(func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			// Apply the RoleBinding as the controller would, then edit it out-of-band
+			var err error
+			existingRB, err = builder.BuildRoleBinding("test-folder", "test-ns", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(existingRB.Annotations).To(HaveKeyWithValue(AppliedDigestAnnotation, BindingDigest(existingRB)))
+			builder.StampFolderPath(existingRB, []string{"test-folder"}, "test-folder")
+			existingRB.OwnerReferences = nil
+			existingRB.Subjects = append(existingRB.Subjects, rbacv1.Subject{
+				Kind:     "User",
+				Name:     "intruder",
+				APIGroup: "rbac.authorization.k8s.io",
+			})
+		})
+
+		It("should revert out-of-band edits by default", func() {
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(diffAnalyzer.Drift).To(BeEmpty())
+		})
+
+		It("should leave out-of-band edits alone with Warn and Ignore", func() {
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			for _, policy := range []rbacv1alpha1.DriftPolicy{rbacv1alpha1.DriftPolicyWarn, rbacv1alpha1.DriftPolicyIgnore} {
+				folderTree.Spec.DriftPolicy = policy
+
+				operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(operations).To(BeEmpty())
+				Expect(diffAnalyzer.Drift).To(HaveLen(1))
+				Expect(diffAnalyzer.Drift[0].ExistingRoleBinding.Name).To(Equal("foldertree-test-tree-admin-template"))
+			}
+		})
+
+		It("should still apply spec changes with Warn", func() {
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects[0].Name = "new-user"
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(diffAnalyzer.Drift).To(BeEmpty())
+		})
+
+		It("should still apply description and reference changes with Warn", func() {
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Description = "Administrators of the test namespace"
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Reference = "SEC-1234"
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].DesiredRoleBinding.Annotations).To(HaveKeyWithValue(DescriptionAnnotation, "Administrators of the test namespace"))
+			Expect(operations[0].DesiredRoleBinding.Annotations).To(HaveKeyWithValue(ReferenceAnnotation, "SEC-1234"))
+			Expect(diffAnalyzer.Drift).To(BeEmpty())
+		})
+
+		It("should revert RoleBindings without a recorded digest", func() {
+			existingRB.Annotations = nil
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyIgnore
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(diffAnalyzer.Drift).To(BeEmpty())
+		})
+	})
+
+	Context("with excluded namespaces", func() {
+		BeforeEach(func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "test-folder"},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "admin-template",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+						Namespaces: []string{"test-ns", "kube-system", "kube-public"},
+					},
+					{
+						Name:       "standalone",
+						Namespaces: []string{"kube-public"},
+					},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+					{
+						Name: "auditors",
+						Subjects: []rbacv1.Subject{
+							{
+								Kind:     "Group",
+								Name:     "auditors",
+								APIGroup: "rbac.authorization.k8s.io",
+							},
+						},
+						RoleRef: rbacv1.RoleRef{
+							APIGroup: "rbac.authorization.k8s.io",
+							Kind:     "ClusterRole",
+							Name:     "view",
+						},
+					},
+				},
+				ExcludedNamespaces: []string{"kube-system"},
+			}
+			builder.ExcludedNamespaces = []string{"kube-public"}
+		})
+
+		It("should never calculate RoleBindings for excluded namespaces", func() {
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings).To(HaveLen(2))
+			for _, desiredRB := range desired.RoleBindings {
+				Expect(desiredRB.Namespace).To(Equal("test-ns"))
+			}
+		})
+
+		It("should delete existing RoleBindings in excluded namespaces", func() {
+			existingRB, err := builder.BuildRoleBinding("test-folder", "kube-system", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+			Expect(err).NotTo(HaveOccurred())
+			existingRB.OwnerReferences = nil
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var deletes []string
+			for _, op := range operations {
+				if op.Type == OperationDelete {
+					deletes = append(deletes, op.Namespace)
+				} else {
+					Expect(op.Namespace).To(Equal("test-ns"))
+				}
+			}
+			Expect(deletes).To(Equal([]string{"kube-system"}))
+		})
+	})
+
+	Context("with folder path labels", func() {
+		BeforeEach(func() {
+			template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name:      name,
+					Propagate: boolPtr(propagate),
+					Subjects: []rbacv1.Subject{
+						{
+							Kind:     "Group",
+							Name:     name,
+							APIGroup: "rbac.authorization.k8s.io",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: "rbac.authorization.k8s.io",
+						Kind:     "ClusterRole",
+						Name:     "view",
+					},
+				}
+			}
+
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "root",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "prod", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "root",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("sre", true)},
+					},
+					{
+						Name:                 "web",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("web-team", false)},
+						Namespaces:           []string{"web-ns"},
+					},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("auditors", false)},
+			}
+		})
+
+		It("should stamp the folder path and where each template comes from", func() {
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings).To(HaveLen(3))
+
+			for _, desiredRB := range desired.RoleBindings {
+				rb := desiredRB.RoleBinding
+				Expect(rb.Labels).To(HaveKeyWithValue(FolderPathKey, "root.prod.web"))
+				Expect(rb.Annotations).To(HaveKeyWithValue(FolderPathKey, "root.prod.web"))
+
+				switch desiredRB.RoleBindingTemplate.Name {
+				case "sre":
+					Expect(rb.Labels).To(HaveKeyWithValue(InheritedLabel, "true"))
+					Expect(rb.Annotations).To(HaveKeyWithValue(SourceFolderAnnotation, "root"))
+				case "auditors":
+					Expect(rb.Labels).To(HaveKeyWithValue(InheritedLabel, "true"))
+					Expect(rb.Annotations).NotTo(HaveKey(SourceFolderAnnotation))
+				case "web-team":
+					Expect(rb.Labels).To(HaveKeyWithValue(InheritedLabel, "false"))
+					Expect(rb.Annotations).To(HaveKeyWithValue(SourceFolderAnnotation, "web"))
+				}
+			}
+		})
+
+		It("should only annotate paths that are too long for a label", func() {
+			longName := strings.Repeat("a", 60)
+			folderTree.Spec.Tree.Subfolders[0].Name = longName
+
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			for _, desiredRB := range desired.RoleBindings {
+				Expect(desiredRB.RoleBinding.Labels).NotTo(HaveKey(FolderPathKey))
+				Expect(desiredRB.RoleBinding.Annotations).To(HaveKeyWithValue(FolderPathKey, "root."+longName+".web"))
+			}
+		})
+
+		It("should update RoleBindings when their folder moves, even when drift is tolerated", func() {
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			for _, desiredRB := range desired.RoleBindings {
+				existingRB := desiredRB.RoleBinding.DeepCopy()
+				existingRB.OwnerReferences = nil
+				Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+			}
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+
+			By("moving web directly under root")
+			folderTree.Spec.Tree.Subfolders = []rbacv1alpha1.TreeNode{{Name: "prod"}, {Name: "web"}}
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(3))
+			for _, op := range operations {
+				Expect(op.Type).To(Equal(OperationUpdate))
+				Expect(op.DesiredRoleBinding.Labels).To(HaveKeyWithValue(FolderPathKey, "root.web"))
+			}
+			Expect(diffAnalyzer.Drift).To(BeEmpty())
+		})
+	})
+
+	Context("with multiple trees", func() {
+		It("should calculate each hierarchy independently and keep other folders standalone", func() {
+			template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+				return rbacv1alpha1.RoleBindingTemplate{
+					Name:      name,
+					Propagate: boolPtr(propagate),
+					Subjects: []rbacv1.Subject{
+						{
+							Kind:     "Group",
+							Name:     name,
+							APIGroup: "rbac.authorization.k8s.io",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: "rbac.authorization.k8s.io",
+						Kind:     "ClusterRole",
+						Name:     "view",
+					},
+				}
+			}
+
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "engineering",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}},
+				},
+				Trees: []rbacv1alpha1.TreeNode{
+					{
+						Name:       "finance",
+						Subfolders: []rbacv1alpha1.TreeNode{{Name: "billing"}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "engineering",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("engineers", true)},
+					},
+					{Name: "web", Namespaces: []string{"web-ns"}},
+					{
+						Name:                 "finance",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("accountants", true)},
+					},
+					{Name: "billing", Namespaces: []string{"billing-ns"}},
+					{
+						Name:                 "sandbox",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("testers", false)},
+						Namespaces:           []string{"sandbox-ns"},
+					},
+				},
+			}
+
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings).To(HaveLen(3))
+			Expect(desired.RoleBindings).To(HaveKey("web-ns/foldertree-test-tree-engineers"))
+			Expect(desired.RoleBindings).To(HaveKey("billing-ns/foldertree-test-tree-accountants"))
+			Expect(desired.RoleBindings).To(HaveKey("sandbox-ns/foldertree-test-tree-testers"))
+			Expect(desired.RoleBindings["billing-ns/foldertree-test-tree-accountants"].RoleBinding.Labels).
+				To(HaveKeyWithValue(FolderPathKey, "finance.billing"))
+		})
+	})
+
+	Context("with template overrides", func() {
+		group := func(name string) []rbacv1.Subject {
+			return []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}}
+		}
+
+		BeforeEach(func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "engineering", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "engineering",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:      "engineers",
+							Propagate: boolPtr(true),
+							Subjects:  group("engineers"),
+							RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+						}},
+					},
+					{
+						Name:       "web",
+						Namespaces: []string{"web-dev", "web-prod"},
+						NamespaceOverrides: []rbacv1alpha1.NamespaceOverride{{
+							Name: "web-prod",
+							TemplateOverrides: map[string]rbacv1alpha1.TemplateOverride{
+								"engineers": {Subjects: group("web-oncall")},
+							},
+						}},
+					},
+				},
+			}
+		})
+
+		It("should replace the subjects of an inherited template in the overriding namespace only", func() {
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings).To(HaveLen(2))
+
+			prod := desired.RoleBindings["web-prod/foldertree-test-tree-engineers"]
+			Expect(prod.RoleBinding.Subjects).To(Equal(group("web-oncall")))
+			Expect(prod.RoleBindingTemplate.Subjects).To(Equal(group("web-oncall")))
+			Expect(prod.RoleBinding.Annotations).To(HaveKeyWithValue(SourceFolderAnnotation, "engineering"))
+			Expect(desired.RoleBindings["web-dev/foldertree-test-tree-engineers"].RoleBinding.Subjects).To(Equal(group("engineers")))
+		})
+
+		It("should update the RoleBinding when an override is added", func() {
+			overrides := folderTree.Spec.Folders[1].NamespaceOverrides[0].TemplateOverrides
+			folderTree.Spec.Folders[1].NamespaceOverrides[0].TemplateOverrides = nil
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			for _, desiredRB := range desired.RoleBindings {
+				Expect(fakeClient.Create(ctx, desiredRB.RoleBinding.DeepCopy())).To(Succeed())
+			}
+
+			folderTree.Spec.Folders[1].NamespaceOverrides[0].TemplateOverrides = overrides
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(operations[0].Namespace).To(Equal("web-prod"))
+			Expect(operations[0].DesiredRoleBinding.Subjects).To(Equal(group("web-oncall")))
+		})
+	})
+
+	Context("with owner references", func() {
+		BeforeEach(func() {
+			folderTree.UID = "current-uid"
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "viewers",
+								Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+		})
+
+		// createExisting creates the desired RoleBinding with the given owner references
+		createExisting := func(ownerReferences ...metav1.OwnerReference) {
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			roleBinding := desired.RoleBindings["test-ns/foldertree-test-tree-viewers"].RoleBinding.DeepCopy()
+			roleBinding.OwnerReferences = ownerReferences
+			Expect(fakeClient.Create(ctx, roleBinding)).To(Succeed())
+		}
+
+		staleOwner := metav1.OwnerReference{
+			APIVersion: rbacv1alpha1.GroupVersion.String(), Kind: "FolderTree", Name: "test-tree", UID: "deleted-uid", Controller: boolPtr(true),
+		}
+		otherOwner := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}
+
+		It("should adopt RoleBindings owned by an earlier FolderTree of the same name", func() {
+			createExisting(staleOwner, otherOwner)
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(operations[0].DesiredRoleBinding.OwnerReferences).To(HaveLen(2))
+			Expect(operations[0].DesiredRoleBinding.OwnerReferences[0]).To(Equal(otherOwner))
+			Expect(operations[0].DesiredRoleBinding.OwnerReferences[1].UID).To(BeEquivalentTo("current-uid"))
+		})
+
+		It("should remove FolderTree owner references when they are disabled", func() {
+			createExisting(staleOwner, otherOwner)
+			builder.DisableOwnerReferences = true
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].DesiredRoleBinding.OwnerReferences).To(Equal([]metav1.OwnerReference{otherOwner}))
+		})
+
+		It("should leave RoleBindings without FolderTree owner references unowned", func() {
+			createExisting()
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+		})
+
+		It("should adopt without reverting drift the policy leaves alone", func() {
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+			createExisting(staleOwner)
+			existing := &rbacv1.RoleBinding{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: "foldertree-test-tree-viewers"}, existing)).To(Succeed())
+			existing.Subjects = append(existing.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder", APIGroup: "rbac.authorization.k8s.io"})
+			Expect(fakeClient.Update(ctx, existing)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diffAnalyzer.Drift).To(HaveLen(1))
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].DesiredRoleBinding.Subjects).To(HaveLen(2))
+			Expect(operations[0].DesiredRoleBinding.OwnerReferences[0].UID).To(BeEquivalentTo("current-uid"))
+		})
+	})
+
+	Context("with labels and annotations from other tools", func() {
+		var existingRB *rbacv1.RoleBinding
+
+		BeforeEach(func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "admin-template",
+								Subjects: []rbacv1.Subject{{Kind: "User", Name: "test-user", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			var err error
+			existingRB, err = builder.BuildRoleBinding("test-folder", "test-ns", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+			Expect(err).NotTo(HaveOccurred())
+			builder.StampFolderPath(existingRB, []string{"test-folder"}, "test-folder")
+			existingRB.Labels["app.kubernetes.io/managed-by"] = "argocd"
+			existingRB.Labels["team"] = "web"
+			existingRB.Annotations["example.com/owner"] = "web-team"
+		})
+
+		It("should not update RoleBindings for labels and annotations the controller does not manage", func() {
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+		})
+
+		It("should only apply the fields the controller manages", func() {
+			applied := ForServerSideApply(existingRB, ExtraMetadata{})
+
+			Expect(applied.APIVersion).To(Equal("rbac.authorization.k8s.io/v1"))
+			Expect(applied.Kind).To(Equal("RoleBinding"))
+			Expect(applied.ResourceVersion).To(BeEmpty())
+			Expect(applied.Subjects).To(Equal(existingRB.Subjects))
+			Expect(applied.RoleRef).To(Equal(existingRB.RoleRef))
+			Expect(applied.OwnerReferences).To(Equal(existingRB.OwnerReferences))
+			Expect(applied.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", "test-tree"))
+			Expect(applied.Labels).NotTo(HaveKey("app.kubernetes.io/managed-by"))
+			Expect(applied.Labels).NotTo(HaveKey("team"))
+			Expect(applied.Annotations).To(HaveKey(AppliedDigestAnnotation))
+			Expect(applied.Annotations).NotTo(HaveKey("example.com/owner"))
+		})
+
+		It("should manage the configured extra labels and annotations", func() {
+			builder.ExtraMetadata = ExtraMetadata{
+				Labels:      map[string]string{"team": "platform"},
+				Annotations: map[string]string{"example.com/cost-center": "cc-42"},
+			}
+			Expect(fakeClient.Create(ctx, existingRB)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+
+			applied := ForServerSideApply(operations[0].DesiredRoleBinding, builder.ExtraMetadata)
+			Expect(applied.Labels).To(HaveKeyWithValue("team", "platform"))
+			Expect(applied.Annotations).To(HaveKeyWithValue("example.com/cost-center", "cc-42"))
+			Expect(applied.Annotations).NotTo(HaveKey("example.com/owner"))
+
+			existingRB.Labels["team"] = "platform"
+			existingRB.Annotations["example.com/cost-center"] = "cc-42"
+			Expect(fakeClient.Update(ctx, existingRB)).To(Succeed())
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+		})
+
+		It("should reject extra labels and annotations the controller sets itself", func() {
+			Expect(ExtraMetadata{Labels: map[string]string{"example.com/cost-center": "cc-42"}}.Validate()).To(Succeed())
+			Expect(ExtraMetadata{Labels: map[string]string{"foldertree.rbac.kubevirt.io/tree": "x"}}.Validate()).NotTo(Succeed())
+			Expect(ExtraMetadata{Annotations: map[string]string{"app.kubernetes.io/managed-by": "x"}}.Validate()).NotTo(Succeed())
+			Expect(ExtraMetadata{Labels: map[string]string{"docs": "https://example.com"}}.Validate()).NotTo(Succeed())
+			Expect(ExtraMetadata{Annotations: map[string]string{"docs": "https://example.com"}}.Validate()).To(Succeed())
+		})
+	})
+
+	Context("with adoption", func() {
+		var handManaged *rbacv1.RoleBinding
+
+		BeforeEach(func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:     "admin-template",
+								Subjects: []rbacv1.Subject{{Kind: "User", Name: "test-user", APIGroup: "rbac.authorization.k8s.io"}},
+								RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+							},
+						},
+						Namespaces: []string{"test-ns"},
+					},
+				},
+			}
+
+			handManaged = &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-admins", Namespace: "test-ns", Labels: map[string]string{"team": "platform"}},
+				Subjects:   []rbacv1.Subject{{Kind: "User", Name: "test-user", APIGroup: "rbac.authorization.k8s.io"}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+			}
+		})
+
+		It("should adopt an unmanaged RoleBinding granting exactly the same access", func() {
+			Expect(fakeClient.Create(ctx, handManaged)).To(Succeed())
+			diffAnalyzer.Adopt = true
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(operations[0].Adopt).To(BeTrue())
+			Expect(operations[0].ExistingRoleBinding.Name).To(Equal("team-admins"))
+			Expect(operations[0].DesiredRoleBinding.Name).To(Equal("team-admins"))
+			Expect(operations[0].DesiredRoleBinding.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", "test-tree"))
+			Expect(operations[0].String()).To(HavePrefix("ADOPT RoleBinding 'team-admins'"))
+		})
+
+		It("should create a RoleBinding when adoption is off or nothing matches exactly", func() {
+			Expect(fakeClient.Create(ctx, handManaged)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationCreate))
+
+			diffAnalyzer.Adopt = true
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects = append(folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects,
+				rbacv1.Subject{Kind: "User", Name: "other-user", APIGroup: "rbac.authorization.k8s.io"})
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationCreate))
+		})
+
+		It("should keep managing an adopted RoleBinding under its own name", func() {
+			adopted, err := builder.BuildRoleBinding("test-folder", "test-ns", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+			Expect(err).NotTo(HaveOccurred())
+			builder.StampFolderPath(adopted, []string{"test-folder"}, "test-folder")
+			adopted.Name = "team-admins"
+			Expect(fakeClient.Create(ctx, adopted)).To(Succeed())
+
+			operations, err := diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(BeEmpty())
+
+			By("updating it in place when the template changes")
+			folderTree.Spec.Folders[0].RoleBindingTemplates[0].Subjects[0].Name = "new-user"
+			operations, err = diffAnalyzer.AnalyzeDiff(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationUpdate))
+			Expect(operations[0].DesiredRoleBinding.Name).To(Equal("team-admins"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// digestHashLength is the number of hex characters of the subject hash kept in a digest
+const digestHashLength = 16
+
+// AppliedDigestAnnotation records the BindingDigest a RoleBinding was last written with by the
+// controller. A RoleBinding whose content no longer matches while the desired digest is unchanged
+// has been edited out-of-band.
+const AppliedDigestAnnotation = "foldertree.rbac.kubevirt.io/applied-digest"
+
+// BindingDigest returns a compact digest of a RoleBinding in the form "<roleRef kind>/<roleRef name>/<subjects hash>".
+// The roleRef is kept readable so that roleRef changes (which require DELETE+CREATE) can be detected
+// from the digest alone. The subjects hash does not depend on subject order or repeated subjects.
+func BindingDigest(roleBinding *rbacv1.RoleBinding) string {
+	subjects := make([]string, 0, len(roleBinding.Subjects))
+	for _, subject := range roleBinding.Subjects {
+		subjects = append(subjects, fmt.Sprintf("%s:%s:%s:%s", subject.Kind, subject.Name, subject.Namespace, subject.APIGroup))
+	}
+	sort.Strings(subjects)
+	subjects = slices.Compact(subjects)
+
+	hash := sha256.Sum256([]byte(strings.Join(subjects, "\n")))
+	return fmt.Sprintf("%s/%s/%s", roleBinding.RoleRef.Kind, roleBinding.RoleRef.Name, hex.EncodeToString(hash[:])[:digestHashLength])
+}
+
+// AppliedBindingKey returns the key of a RoleBinding in status.appliedBindings ("<namespace>/<name>")
+func AppliedBindingKey(roleBinding *rbacv1.RoleBinding) string {
+	return fmt.Sprintf("%s/%s", roleBinding.Namespace, roleBinding.Name)
+}
+
+// AppliedRoleBinding reconstructs the identity of an applied RoleBinding from its status.appliedBindings entry.
+// The returned RoleBinding carries the name, namespace, managed labels and roleRef, but no subjects.
+func AppliedRoleBinding(treeName, key, digest string) (*rbacv1.RoleBinding, error) {
+	namespace, name, ok := strings.Cut(key, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid applied binding key '%s'", key)
+	}
+
+	parts := strings.SplitN(digest, "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid applied binding digest '%s' for '%s'", digest, key)
+	}
+
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":                      "foldertree-controller",
+				"foldertree.rbac.kubevirt.io/tree":                  treeName,
+				"foldertree.rbac.kubevirt.io/role-binding-template": strings.TrimPrefix(name, fmt.Sprintf("foldertree-%s-", treeName)),
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     parts[0],
+			Name:     parts[1],
+		},
+	}, nil
+}
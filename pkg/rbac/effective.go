@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// EffectiveAccessQuery selects the grants returned by EffectiveAccess. Empty fields match everything.
+type EffectiveAccessQuery struct {
+	Namespace string
+	Subject   *rbacv1.Subject
+}
+
+// EffectiveGrant describes a role binding template of a FolderTree in effect in a namespace
+type EffectiveGrant struct {
+	Tree      string `json:"tree"`
+	Folder    string `json:"folder"`
+	Namespace string `json:"namespace"`
+	Template  string `json:"template"`
+	// Path is the folder path of the namespace, from its root folder down to Folder
+	Path []string `json:"path,omitempty"`
+	// From is the folder defining the template; empty for global templates
+	From     string           `json:"from,omitempty"`
+	RoleRef  rbacv1.RoleRef   `json:"roleRef"`
+	Subjects []rbacv1.Subject `json:"subjects"`
+}
+
+// ParseSubject parses a subject in "<kind>:<name>" form, e.g. "group:admins", "user:alice" or
+// "serviceaccount:<namespace>/<name>". The kind is case-insensitive.
+func ParseSubject(value string) (rbacv1.Subject, error) {
+	kind, name, found := strings.Cut(value, ":")
+	if !found || name == "" {
+		return rbacv1.Subject{}, fmt.Errorf("subject '%s' must have the form <kind>:<name>", value)
+	}
+
+	switch strings.ToLower(kind) {
+	case "group":
+		return rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: name}, nil
+	case "user":
+		return rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: name}, nil
+	case "serviceaccount":
+		namespace, saName, found := strings.Cut(name, "/")
+		if !found || namespace == "" || saName == "" {
+			return rbacv1.Subject{}, fmt.Errorf("service account subject '%s' must have the form serviceaccount:<namespace>/<name>", value)
+		}
+		return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: namespace, Name: saName}, nil
+	default:
+		return rbacv1.Subject{}, fmt.Errorf("subject kind '%s' must be one of group, user or serviceaccount", kind)
+	}
+}
+
+// EffectiveAccess returns the role binding templates of all FolderTrees in effect after inheritance,
+// restricted to a namespace and/or a subject, sorted by namespace, tree and template. Like WhoCan,
+// the desired state is calculated from each FolderTree spec plus approved FolderMemberships, so the
+// answer does not depend on whether the controller has caught up yet.
+func EffectiveAccess(ctx context.Context, c client.Reader, query EffectiveAccessQuery) ([]EffectiveGrant, error) {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := c.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+
+	var membershipOpts []client.ListOption
+	if query.Namespace != "" {
+		membershipOpts = append(membershipOpts, client.InNamespace(query.Namespace))
+	}
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList, membershipOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+
+	patches, err := listApprovedPatches(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectMappings, err := ListSubjectMappings(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []EffectiveGrant
+	for i := range folderTreeList.Items {
+		folderTree := withApprovedPatches(withApprovedMemberships(&folderTreeList.Items[i], membershipList.Items), patches)
+		serviceAccounts, err := ListServiceAccounts(ctx, c, folderTree, IndexFolderTreeNamespaces(folderTree))
+		if err != nil {
+			return nil, err
+		}
+		desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{
+			FolderTree: folderTree, SubjectMappings: subjectMappings, ServiceAccounts: serviceAccounts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate RoleBindings for FolderTree '%s': %v", folderTree.Name, err)
+		}
+
+		for _, desiredRB := range desired.RoleBindings {
+			if query.Namespace != "" && desiredRB.Namespace != query.Namespace {
+				continue
+			}
+			if query.Subject != nil && !containsSubject(desiredRB.RoleBinding.Subjects, *query.Subject) {
+				continue
+			}
+			grants = append(grants, EffectiveGrant{
+				Tree:      folderTree.Name,
+				Folder:    desiredRB.Folder,
+				Namespace: desiredRB.Namespace,
+				Template:  desiredRB.RoleBindingTemplate.Name,
+				Path:      strings.Split(desiredRB.RoleBinding.Annotations[FolderPathKey], "."),
+				From:      desiredRB.RoleBinding.Annotations[SourceFolderAnnotation],
+				RoleRef:   desiredRB.RoleBinding.RoleRef,
+				Subjects:  desiredRB.RoleBinding.Subjects,
+			})
+		}
+	}
+
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Tree != b.Tree {
+			return a.Tree < b.Tree
+		}
+		return a.Template < b.Template
+	})
+
+	return grants, nil
+}
+
+// containsSubject reports whether subjects include the subject, comparing kind, name and,
+// for service accounts, namespace
+func containsSubject(subjects []rbacv1.Subject, subject rbacv1.Subject) bool {
+	for _, candidate := range subjects {
+		if candidate.Kind == subject.Kind && candidate.Name == subject.Name &&
+			(subject.Kind != rbacv1.ServiceAccountKind || candidate.Namespace == subject.Namespace) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("EffectiveAccess", func() {
+	var (
+		ctx         context.Context
+		scheme      *runtime.Scheme
+		folderTree  *rbacv1alpha1.FolderTree
+		memberships []rbacv1alpha1.FolderMembership
+	)
+
+	template := func(name string, subject rbacv1.Subject, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{
+			Name:      name,
+			Subjects:  []rbacv1.Subject{subject},
+			RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+			Propagate: boolPtr(propagate),
+		}
+	}
+	group := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}
+	}
+
+	effectiveAccess := func(query EffectiveAccessQuery) []EffectiveGrant {
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(folderTree)
+		for i := range memberships {
+			builder = builder.WithObjects(&memberships[i])
+		}
+		grants, err := EffectiveAccess(ctx, builder.Build(), query)
+		Expect(err).NotTo(HaveOccurred())
+		return grants
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		memberships = nil
+
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("sre", group("sre-team"), true)},
+						Namespaces:           []string{"platform-ns"},
+					},
+					{
+						Name:                 "web",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("web-viewers", group("web-team"), false)},
+						Namespaces:           []string{"web-ns"},
+						AcceptMemberships:    true,
+					},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+					template("bots", rbacv1.Subject{Kind: "ServiceAccount", Namespace: "ci", Name: "bot"}, false),
+				},
+			},
+		}
+	})
+
+	It("should list the templates in effect in a namespace", func() {
+		grants := effectiveAccess(EffectiveAccessQuery{Namespace: "web-ns"})
+
+		Expect(grants).To(HaveLen(3))
+		Expect(grants[0]).To(Equal(EffectiveGrant{
+			Tree: "org", Folder: "web", Namespace: "web-ns", Template: "bots", Path: []string{"platform", "web"},
+			RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+			Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "ci", Name: "bot"}},
+		}))
+		Expect(grants[1].Template).To(Equal("sre"))
+		Expect(grants[1].From).To(Equal("platform"))
+		Expect(grants[2].Template).To(Equal("web-viewers"))
+		Expect(grants[2].From).To(Equal("web"))
+	})
+
+	It("should list where a subject is granted access, including approved memberships", func() {
+		memberships = []rbacv1alpha1.FolderMembership{{
+			ObjectMeta: metav1.ObjectMeta{Name: "join", Namespace: "team-ns"},
+			Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "org", FolderName: "web"},
+			Status:     rbacv1alpha1.FolderMembershipStatus{Phase: rbacv1alpha1.MembershipPhaseApproved},
+		}}
+		subject, err := ParseSubject("group:sre-team")
+		Expect(err).NotTo(HaveOccurred())
+
+		grants := effectiveAccess(EffectiveAccessQuery{Subject: &subject})
+
+		var namespaces []string
+		for _, grant := range grants {
+			Expect(grant.Template).To(Equal("sre"))
+			namespaces = append(namespaces, grant.Namespace)
+		}
+		Expect(namespaces).To(Equal([]string{"platform-ns", "team-ns", "web-ns"}))
+	})
+
+	It("should match service accounts by namespace", func() {
+		subject, err := ParseSubject("serviceaccount:ci/bot")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(effectiveAccess(EffectiveAccessQuery{Namespace: "platform-ns", Subject: &subject})).To(HaveLen(1))
+
+		subject.Namespace = "other"
+		Expect(effectiveAccess(EffectiveAccessQuery{Namespace: "platform-ns", Subject: &subject})).To(BeEmpty())
+	})
+
+	It("should reject malformed subjects", func() {
+		for _, value := range []string{"admins", "group:", "robot:x", "serviceaccount:bot"} {
+			_, err := ParseSubject(value)
+			Expect(err).To(HaveOccurred(), value)
+		}
+		subject, err := ParseSubject("User:alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subject).To(Equal(rbacv1.Subject{Kind: "User", Name: "alice", APIGroup: "rbac.authorization.k8s.io"}))
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"slices"
+	"time"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Expired reports whether the expiresAt of a role binding template has passed at the given time
+func Expired(template rbacv1alpha1.RoleBindingTemplate, now time.Time) bool {
+	return template.ExpiresAt != nil && !now.Before(template.ExpiresAt.Time)
+}
+
+// WithoutExpired returns the FolderTree without the role binding templates that have expired at
+// the given time. The original is never modified; it is returned as is when nothing has expired.
+func WithoutExpired(folderTree *rbacv1alpha1.FolderTree, now time.Time) *rbacv1alpha1.FolderTree {
+	expired := func(template rbacv1alpha1.RoleBindingTemplate) bool {
+		return Expired(template, now)
+	}
+	if !slices.ContainsFunc(folderTree.Spec.GlobalRoleBindingTemplates, expired) &&
+		!slices.ContainsFunc(folderTree.Spec.Folders, func(folder rbacv1alpha1.Folder) bool {
+			return slices.ContainsFunc(folder.RoleBindingTemplates, expired)
+		}) {
+		return folderTree
+	}
+
+	active := folderTree.DeepCopy()
+	for i := range active.Spec.Folders {
+		active.Spec.Folders[i].RoleBindingTemplates = slices.DeleteFunc(active.Spec.Folders[i].RoleBindingTemplates, expired)
+	}
+	active.Spec.GlobalRoleBindingTemplates = slices.DeleteFunc(active.Spec.GlobalRoleBindingTemplates, expired)
+	return active
+}
+
+// UpcomingExpirations returns the role binding templates of a FolderTree that expire after the
+// given time, soonest first
+func UpcomingExpirations(folderTree *rbacv1alpha1.FolderTree, now time.Time) []rbacv1alpha1.TemplateExpiration {
+	var expirations []rbacv1alpha1.TemplateExpiration
+	add := func(template rbacv1alpha1.RoleBindingTemplate, folder string) {
+		if template.ExpiresAt != nil && !Expired(template, now) {
+			expirations = append(expirations, rbacv1alpha1.TemplateExpiration{
+				Template:  template.Name,
+				Folder:    folder,
+				ExpiresAt: *template.ExpiresAt,
+			})
+		}
+	}
+	for _, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		add(template, "")
+	}
+	for _, folder := range folderTree.Spec.Folders {
+		for _, template := range folder.RoleBindingTemplates {
+			add(template, folder.Name)
+		}
+	}
+
+	slices.SortStableFunc(expirations, func(a, b rbacv1alpha1.TemplateExpiration) int {
+		return a.ExpiresAt.Time.Compare(b.ExpiresAt.Time)
+	})
+	return expirations
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Template Expiration", func() {
+	var (
+		viewers    = []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}}
+		viewRole   = rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+		now        = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		folderTree *rbacv1alpha1.FolderTree
+	)
+
+	at := func(offset time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(offset)}
+	}
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{Name: "permanent", Subjects: viewers, RoleRef: viewRole},
+							{Name: "oncall", Subjects: viewers, RoleRef: viewRole, ExpiresAt: at(2 * time.Hour)},
+							{Name: "incident", Subjects: viewers, RoleRef: viewRole, ExpiresAt: at(-time.Minute)},
+						},
+						Namespaces: []string{"platform-ns"},
+					},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+					{Name: "audit", Subjects: viewers, RoleRef: viewRole, ExpiresAt: at(time.Hour)},
+				},
+			},
+		}
+	})
+
+	It("should treat a template as expired from its expiresAt on", func() {
+		template := rbacv1alpha1.RoleBindingTemplate{Name: "oncall", ExpiresAt: at(0)}
+		Expect(Expired(template, now.Add(-time.Second))).To(BeFalse())
+		Expect(Expired(template, now)).To(BeTrue())
+		Expect(Expired(rbacv1alpha1.RoleBindingTemplate{Name: "permanent"}, now)).To(BeFalse())
+	})
+
+	It("should leave out expired templates without modifying the FolderTree", func() {
+		active := WithoutExpired(folderTree, now)
+		Expect(active.Spec.Folders[0].RoleBindingTemplates).To(HaveLen(2))
+		Expect(active.Spec.Folders[0].RoleBindingTemplates).NotTo(ContainElement(HaveField("Name", "incident")))
+		Expect(active.Spec.GlobalRoleBindingTemplates).To(HaveLen(1))
+		Expect(folderTree.Spec.Folders[0].RoleBindingTemplates).To(HaveLen(3))
+
+		Expect(WithoutExpired(active, now)).To(BeIdenticalTo(active))
+
+		active = WithoutExpired(folderTree, now.Add(3*time.Hour))
+		Expect(active.Spec.Folders[0].RoleBindingTemplates).To(ConsistOf(HaveField("Name", "permanent")))
+		Expect(active.Spec.GlobalRoleBindingTemplates).To(BeEmpty())
+	})
+
+	It("should list upcoming expirations soonest first", func() {
+		Expect(UpcomingExpirations(folderTree, now)).To(Equal([]rbacv1alpha1.TemplateExpiration{
+			{Template: "audit", ExpiresAt: *at(time.Hour)},
+			{Template: "oncall", Folder: "platform", ExpiresAt: *at(2 * time.Hour)},
+		}))
+		Expect(UpcomingExpirations(folderTree, now.Add(3*time.Hour))).To(BeEmpty())
+	})
+
+	It("should not calculate RoleBindings for expired templates", func() {
+		folderTree.Spec.Folders[0].RoleBindingTemplates[1].ExpiresAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+		folderTree.Spec.Folders[0].RoleBindingTemplates[2].ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+		desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.RoleBindings).To(HaveKey("platform-ns/foldertree-org-oncall"))
+		Expect(desired.RoleBindings).NotTo(HaveKey("platform-ns/foldertree-org-incident"))
+	})
+})
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// RestoreResult describes what restoring a RoleBinding did
+type RestoreResult string
+
+const (
+	// RestoreCreated indicates the RoleBinding did not exist and was created
+	RestoreCreated RestoreResult = "created"
+	// RestoreUpdated indicates the subjects, labels or annotations of the RoleBinding were updated
+	RestoreUpdated RestoreResult = "updated"
+	// RestoreReplaced indicates the RoleBinding had a different roleRef and was re-created
+	RestoreReplaced RestoreResult = "replaced"
+	// RestoreUnchanged indicates the RoleBinding already matched the export
+	RestoreUnchanged RestoreResult = "unchanged"
+)
+
+// ExportRoleBindings returns the RoleBindings the given FolderTrees want, or all FolderTrees when
+// trees is empty, sorted by namespace and name. Desired state is calculated as the controller does:
+// with approved FolderMemberships and FolderTreePatches and the namespaces matching namespacePatterns.
+// Owner references and the labels and annotations configured on the controller are not included.
+func ExportRoleBindings(ctx context.Context, c client.Client, trees []string) ([]rbacv1.RoleBinding, error) {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := c.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	for _, tree := range trees {
+		if !slices.ContainsFunc(folderTreeList.Items, func(folderTree rbacv1alpha1.FolderTree) bool { return folderTree.Name == tree }) {
+			return nil, fmt.Errorf("FolderTree '%s' not found", tree)
+		}
+	}
+
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+	patches, err := listApprovedPatches(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	subjectMappings, err := ListSubjectMappings(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := listPatternNamespaces(ctx, c, folderTreeList.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var roleBindings []rbacv1.RoleBinding
+	for i := range folderTreeList.Items {
+		folderTree := &folderTreeList.Items[i]
+		if len(trees) > 0 && !slices.Contains(trees, folderTree.Name) {
+			continue
+		}
+		resolved := withApprovedPatches(withApprovedMemberships(folderTree, membershipList.Items), patches)
+		resolved = WithPatternNamespaces(resolved, namespaces, ClaimedByOtherTrees(folderTree, folderTreeList.Items))
+		serviceAccounts, err := ListServiceAccounts(ctx, c, resolved, IndexFolderTreeNamespaces(resolved))
+		if err != nil {
+			return nil, err
+		}
+		desired, err := CalculateDesiredRoleBindings(resolved, &RoleBindingBuilder{
+			FolderTree: resolved, SubjectMappings: subjectMappings, ServiceAccounts: serviceAccounts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate RoleBindings for FolderTree '%s': %v", folderTree.Name, err)
+		}
+		for _, desiredRB := range desired.RoleBindings {
+			roleBinding := *desiredRB.RoleBinding
+			roleBinding.TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"}
+			roleBindings = append(roleBindings, roleBinding)
+		}
+	}
+
+	slices.SortFunc(roleBindings, func(a, b rbacv1.RoleBinding) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return roleBindings, nil
+}
+
+// listPatternNamespaces lists the existing namespaces when any of the FolderTrees has a namespacePattern
+func listPatternNamespaces(ctx context.Context, c client.Reader, folderTrees []rbacv1alpha1.FolderTree) ([]string, error) {
+	if !slices.ContainsFunc(folderTrees, func(folderTree rbacv1alpha1.FolderTree) bool { return UsesNamespacePatterns(&folderTree) }) {
+		return nil, nil
+	}
+	var namespaceList corev1.NamespaceList
+	if err := c.List(ctx, &namespaceList); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	var namespaces []string
+	for _, namespace := range namespaceList.Items {
+		if namespace.DeletionTimestamp.IsZero() {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// RestoreRoleBinding creates an exported RoleBinding, or brings the existing RoleBinding of the same
+// name back to it: subjects are replaced and labels and annotations added, and a RoleBinding with a
+// different roleRef, which cannot be changed, is deleted and re-created. Only RoleBindings labeled
+// as managed by a FolderTree are restored, so that the export cannot be used to write arbitrary
+// RoleBindings. With dryRun, the requests are sent as server-side dry runs.
+func RestoreRoleBinding(ctx context.Context, c client.Client, roleBinding *rbacv1.RoleBinding, dryRun bool) (RestoreResult, error) {
+	if roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"] == "" {
+		return "", fmt.Errorf("RoleBinding %s/%s is not labeled as managed by a FolderTree", roleBinding.Namespace, roleBinding.Name)
+	}
+	var createOpts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	var deleteOpts []client.DeleteOption
+	if dryRun {
+		createOpts = append(createOpts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+		deleteOpts = append(deleteOpts, client.DryRunAll)
+	}
+
+	desired := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        roleBinding.Name,
+			Namespace:   roleBinding.Namespace,
+			Labels:      roleBinding.Labels,
+			Annotations: roleBinding.Annotations,
+		},
+		Subjects: roleBinding.Subjects,
+		RoleRef:  roleBinding.RoleRef,
+	}
+
+	existing := &rbacv1.RoleBinding{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired, createOpts...); err != nil {
+			return "", err
+		}
+		return RestoreCreated, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if existing.RoleRef != desired.RoleRef {
+		if err := c.Delete(ctx, existing, deleteOpts...); err != nil {
+			return "", err
+		}
+		if dryRun {
+			// The dry-run delete leaves the RoleBinding in place, so the create cannot be tried
+			return RestoreReplaced, nil
+		}
+		if err := c.Create(ctx, desired, createOpts...); err != nil {
+			return "", err
+		}
+		return RestoreReplaced, nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Subjects = desired.Subjects
+	if updated.Labels == nil {
+		updated.Labels = make(map[string]string)
+	}
+	maps.Copy(updated.Labels, desired.Labels)
+	if len(desired.Annotations) > 0 && updated.Annotations == nil {
+		updated.Annotations = make(map[string]string)
+	}
+	maps.Copy(updated.Annotations, desired.Annotations)
+	if equality.Semantic.DeepEqual(existing, updated) {
+		return RestoreUnchanged, nil
+	}
+	if err := c.Update(ctx, updated, updateOpts...); err != nil {
+		return "", err
+	}
+	return RestoreUpdated, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Export and Restore", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "export-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "export-root", Subfolders: []rbacv1alpha1.TreeNode{{Name: "export-child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "export-root",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+							Name:      "admins",
+							Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "admins", APIGroup: "rbac.authorization.k8s.io"}},
+							RoleRef:   rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+							Propagate: boolPtr(true),
+						}},
+						Namespaces: []string{"export-b"},
+					},
+					{Name: "export-child", NamespacePattern: "^export-a$"},
+				},
+			},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(folderTree,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "export-a"}}).Build()
+	})
+
+	It("should export the desired RoleBindings sorted by namespace", func() {
+		roleBindings, err := ExportRoleBindings(ctx, fakeClient, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindings).To(HaveLen(2))
+		Expect(roleBindings[0].Namespace).To(Equal("export-a"))
+		Expect(roleBindings[1].Namespace).To(Equal("export-b"))
+		Expect(roleBindings[0].Kind).To(Equal("RoleBinding"))
+		Expect(roleBindings[0].Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", "export-tree"))
+		Expect(roleBindings[0].OwnerReferences).To(BeEmpty())
+
+		_, err = ExportRoleBindings(ctx, fakeClient, []string{"missing-tree"})
+		Expect(err).To(MatchError(ContainSubstring("FolderTree 'missing-tree' not found")))
+	})
+
+	It("should restore exported RoleBindings", func() {
+		roleBindings, err := ExportRoleBindings(ctx, fakeClient, []string{"export-tree"})
+		Expect(err).NotTo(HaveOccurred())
+		roleBinding := &roleBindings[0]
+
+		result, err := RestoreRoleBinding(ctx, fakeClient, roleBinding, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(RestoreCreated))
+		result, err = RestoreRoleBinding(ctx, fakeClient, roleBinding, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(RestoreUnchanged))
+
+		By("reverting changed subjects")
+		existing := &rbacv1.RoleBinding{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(roleBinding), existing)).To(Succeed())
+		existing.Subjects = append(existing.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder", APIGroup: "rbac.authorization.k8s.io"})
+		Expect(fakeClient.Update(ctx, existing)).To(Succeed())
+		result, err = RestoreRoleBinding(ctx, fakeClient, roleBinding, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(RestoreUpdated))
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(roleBinding), existing)).To(Succeed())
+		Expect(existing.Subjects).To(Equal(roleBinding.Subjects))
+
+		By("re-creating a RoleBinding with another roleRef")
+		existing.RoleRef.Name = "view"
+		Expect(fakeClient.Update(ctx, existing)).To(Succeed())
+		result, err = RestoreRoleBinding(ctx, fakeClient, roleBinding, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(RestoreReplaced))
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(roleBinding), existing)).To(Succeed())
+		Expect(existing.RoleRef.Name).To(Equal("admin"))
+
+		By("refusing RoleBindings not managed by a FolderTree")
+		unmanaged := roleBinding.DeepCopy()
+		unmanaged.Labels = nil
+		_, err = RestoreRoleBinding(ctx, fakeClient, unmanaged, false)
+		Expect(err).To(MatchError(ContainSubstring("not labeled as managed by a FolderTree")))
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OpenShiftGroupListGVK is the list kind of OpenShift's user.openshift.io Groups. It is read as
+// unstructured so that the controller does not depend on the OpenShift API types.
+var OpenShiftGroupListGVK = schema.GroupVersionKind{Group: "user.openshift.io", Version: "v1", Kind: "GroupList"}
+
+// OpenShiftGroups is the set of the names of the Groups defined in user.openshift.io
+type OpenShiftGroups map[string]bool
+
+// ListOpenShiftGroups reads all Groups of an OpenShift cluster. It fails on clusters without the
+// user.openshift.io API.
+func ListOpenShiftGroups(ctx context.Context, c client.Reader) (OpenShiftGroups, error) {
+	groupList := &unstructured.UnstructuredList{}
+	groupList.SetGroupVersionKind(OpenShiftGroupListGVK)
+	if err := c.List(ctx, groupList); err != nil {
+		return nil, fmt.Errorf("failed to list OpenShift Groups: %v", err)
+	}
+
+	groups := OpenShiftGroups{}
+	for _, group := range groupList.Items {
+		groups[group.GetName()] = true
+	}
+	return groups, nil
+}
+
+// Dangling returns the sorted names of the Group subjects of the desired RoleBindings that are not
+// OpenShift Groups. Subjects are taken after expansion of subject templates and subjectRefs.
+// Virtual groups such as system:authenticated are assigned by the authenticator and never dangle.
+func (g OpenShiftGroups) Dangling(desired *DesiredRoleBindingSet) []string {
+	var dangling []string
+	for _, desiredRB := range desired.RoleBindings {
+		for _, subject := range desiredRB.RoleBinding.Subjects {
+			if subject.Kind != rbacv1.GroupKind || strings.HasPrefix(subject.Name, "system:") || g[subject.Name] {
+				continue
+			}
+			dangling = append(dangling, subject.Name)
+		}
+	}
+	slices.Sort(dangling)
+	return slices.Compact(dangling)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// openShiftGroup returns a user.openshift.io Group as read by ListOpenShiftGroups
+func openShiftGroup(name string) client.Object {
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(OpenShiftGroupListGVK.GroupVersion().WithKind("Group"))
+	group.SetName(name)
+	return group
+}
+
+var _ = Describe("OpenShift Groups", func() {
+	view := rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+	group := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}
+	}
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			Folders: []rbacv1alpha1.Folder{
+				{
+					Name:       "web",
+					Namespaces: []string{"web-prod", "web-dev"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:     "viewers",
+						Subjects: []rbacv1.Subject{group("{{ .folder.name }}-viewers"), group("system:authenticated"), group("auditors")},
+						RoleRef:  view,
+					}},
+				},
+				{
+					Name:       "db",
+					Namespaces: []string{"db-prod"},
+					RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+						Name:        "operators",
+						Subjects:    []rbacv1.Subject{{Kind: "User", Name: "oncall", APIGroup: "rbac.authorization.k8s.io"}},
+						SubjectRefs: []string{"dbas"},
+						RoleRef:     view,
+					}},
+				},
+			},
+		},
+	}
+
+	It("should list the Groups of the cluster", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(OpenShiftGroupListGVK.GroupVersion().WithKind("Group"), meta.RESTScopeRoot)
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithRESTMapper(mapper).
+			WithObjects(openShiftGroup("web-viewers"), openShiftGroup("auditors")).
+			Build()
+
+		groups, err := ListOpenShiftGroups(context.Background(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(Equal(OpenShiftGroups{"web-viewers": true, "auditors": true}))
+	})
+
+	It("should report expanded and mapped Group subjects that do not exist once", func() {
+		builder := &RoleBindingBuilder{
+			FolderTree: folderTree,
+			SubjectMappings: NewSubjectMappings([]rbacv1alpha1.SubjectMapping{{
+				ObjectMeta: metav1.ObjectMeta{Name: "dbas"},
+				Spec:       rbacv1alpha1.SubjectMappingSpec{Subjects: []rbacv1.Subject{group("idp:dbas")}},
+			}}),
+		}
+		desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(OpenShiftGroups{"auditors": true}.Dangling(desired)).To(Equal([]string{"idp:dbas", "web-viewers"}))
+		Expect(OpenShiftGroups{"auditors": true, "web-viewers": true, "idp:dbas": true}.Dangling(desired)).To(BeEmpty())
+	})
+})
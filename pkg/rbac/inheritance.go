@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// CalculateInheritance summarizes, for every node of the FolderTree's trees in depth-first order,
+// which templates it receives from its ancestors and which it contributes to its descendants.
+// It follows the same propagation rules as CalculateDesiredRoleBindings.
+func CalculateInheritance(folderTree *rbacv1alpha1.FolderTree) []rbacv1alpha1.FolderInheritanceStatus {
+	folderTree = WithoutExpired(WithDefaults(folderTree), time.Now())
+	roots := folderTree.Spec.Roots()
+	if len(roots) == 0 {
+		return nil
+	}
+
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range folderTree.Spec.Folders {
+		folderMap[folder.Name] = folder
+	}
+
+	var received []receivedTemplate
+	for _, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		received = append(received, receivedTemplate{Name: template.Name, Remaining: math.MaxInt})
+	}
+
+	var inheritance []rbacv1alpha1.FolderInheritanceStatus
+	for _, root := range roots {
+		calculateNodeInheritance(root, "", folderMap, received, &inheritance)
+	}
+	return inheritance
+}
+
+// receivedTemplate is the name of an inherited template and the folder defining it,
+// which is empty for global templates
+type receivedTemplate struct {
+	Name   string
+	Source string
+
+	// Remaining is the number of levels below the receiving folder that inherit the template too
+	Remaining int
+}
+
+// String formats the template as "<template> (from <folder>)" or "<template> (global)"
+func (t receivedTemplate) String() string {
+	if t.Source == "" {
+		return fmt.Sprintf("%s (global)", t.Name)
+	}
+	return fmt.Sprintf("%s (from %s)", t.Name, t.Source)
+}
+
+// calculateNodeInheritance records the inheritance of a tree node and recurses into its subfolders
+func calculateNodeInheritance(node rbacv1alpha1.TreeNode, parentPath string, folderMap map[string]rbacv1alpha1.Folder,
+	received []receivedTemplate, inheritance *[]rbacv1alpha1.FolderInheritanceStatus) {
+	path := node.Name
+	if parentPath != "" {
+		path = parentPath + "/" + node.Name
+	}
+
+	// Templates blocked by this folder or filtered by the edge from its parent are neither received
+	// nor passed on, like in CalculateDesiredRoleBindings
+	folder := folderMap[node.Name]
+	var kept []receivedTemplate
+	var blocked []string
+	for _, template := range received {
+		if template.Source != "" && (slices.Contains(folder.BlockInherited, template.Name) || !node.Inherits(template.Name)) {
+			blocked = append(blocked, template.String())
+			continue
+		}
+		kept = append(kept, template)
+	}
+
+	var contributed []string
+	depths := make(map[string]int)
+	for _, template := range folder.RoleBindingTemplates {
+		if depth := PropagationDepth(template); depth > 0 {
+			contributed = append(contributed, template.Name)
+			depths[template.Name] = depth
+		}
+	}
+
+	var receivedNames []string
+	for _, template := range kept {
+		receivedNames = append(receivedNames, template.String())
+	}
+	// A namespace group receives the templates of its descendants too, without passing them on
+	if folder.InheritNamespaces {
+		for _, template := range DescendantTemplates(node, folderMap) {
+			receivedNames = append(receivedNames, receivedTemplate{Name: template.Name, Source: template.Folder}.String())
+		}
+	}
+	*inheritance = append(*inheritance, rbacv1alpha1.FolderInheritanceStatus{
+		Path:        path,
+		Summary:     fmt.Sprintf("receives %d, contributes %d", len(receivedNames), len(contributed)),
+		Received:    receivedNames,
+		Contributed: contributed,
+		Blocked:     blocked,
+	})
+
+	// Descendants receive what this node received and may go further down, plus its contributions
+	toInherit := make([]receivedTemplate, 0, len(kept)+len(contributed))
+	for _, template := range kept {
+		if template.Remaining > 0 {
+			template.Remaining--
+			toInherit = append(toInherit, template)
+		}
+	}
+	for _, template := range contributed {
+		toInherit = append(toInherit, receivedTemplate{Name: template, Source: node.Name, Remaining: depths[template] - 1})
+	}
+	for _, subfolder := range node.Subfolders {
+		calculateNodeInheritance(subfolder, path, folderMap, toInherit, inheritance)
+	}
+}
+
+// CalculateEffectiveBindings groups a desired state by namespace, listing for each namespace the
+// templates in effect there after inheritance, sorted by template name
+func CalculateEffectiveBindings(desired *DesiredRoleBindingSet) map[string][]rbacv1alpha1.EffectiveBinding {
+	if len(desired.RoleBindings) == 0 {
+		return nil
+	}
+
+	effective := make(map[string][]rbacv1alpha1.EffectiveBinding)
+	for _, desiredRB := range desired.RoleBindings {
+		roleRef := desiredRB.RoleBinding.RoleRef
+		effective[desiredRB.Namespace] = append(effective[desiredRB.Namespace], rbacv1alpha1.EffectiveBinding{
+			Template: desiredRB.RoleBindingTemplate.Name,
+			From:     desiredRB.RoleBinding.Annotations[SourceFolderAnnotation],
+			RoleRef:  fmt.Sprintf("%s/%s", roleRef.Kind, roleRef.Name),
+		})
+	}
+	for _, bindings := range effective {
+		sort.Slice(bindings, func(i, j int) bool { return bindings[i].Template < bindings[j].Template })
+	}
+	return effective
+}
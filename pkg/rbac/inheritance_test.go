@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("CalculateInheritance", func() {
+	template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{Name: name, Propagate: boolPtr(propagate)}
+	}
+
+	It("should return nothing without a tree", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: "standalone"}},
+			},
+		}
+		Expect(CalculateInheritance(folderTree)).To(BeEmpty())
+	})
+
+	It("should summarize every root of spec.trees after spec.tree", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "engineering"},
+				Trees: []rbacv1alpha1.TreeNode{
+					{Name: "finance", Subfolders: []rbacv1alpha1.TreeNode{{Name: "billing"}}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "engineering", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("engineers", true)}},
+					{Name: "finance"},
+					{Name: "billing"},
+				},
+			},
+		}
+
+		inheritance := CalculateInheritance(folderTree)
+		Expect(inheritance).To(HaveLen(3))
+		Expect(inheritance[0].Path).To(Equal("engineering"))
+		Expect(inheritance[1].Path).To(Equal("finance"))
+		Expect(inheritance[2].Path).To(Equal("finance/billing"))
+		Expect(inheritance[2].Received).To(BeEmpty())
+	})
+
+	It("should list received and contributed templates per tree node", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "org",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{
+							Name:       "platform",
+							Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}},
+						},
+						{Name: "sandbox"},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "org",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("auditors", true), template("org-admins", false)},
+					},
+					{
+						Name:                 "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("sre", true)},
+					},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("security", false)},
+			},
+		}
+
+		inheritance := CalculateInheritance(folderTree)
+		Expect(inheritance).To(HaveLen(4))
+
+		Expect(inheritance[0].Path).To(Equal("org"))
+		Expect(inheritance[0].Received).To(Equal([]string{"security (global)"}))
+		Expect(inheritance[0].Contributed).To(Equal([]string{"auditors"}))
+		Expect(inheritance[0].Summary).To(Equal("receives 1, contributes 1"))
+
+		Expect(inheritance[1].Path).To(Equal("org/platform"))
+		Expect(inheritance[1].Received).To(Equal([]string{"security (global)", "auditors (from org)"}))
+		Expect(inheritance[1].Contributed).To(Equal([]string{"sre"}))
+
+		Expect(inheritance[2].Path).To(Equal("org/platform/web"))
+		Expect(inheritance[2].Received).To(Equal([]string{"security (global)", "auditors (from org)", "sre (from platform)"}))
+		Expect(inheritance[2].Contributed).To(BeEmpty())
+		Expect(inheritance[2].Summary).To(Equal("receives 3, contributes 0"))
+
+		Expect(inheritance[3].Path).To(Equal("org/sandbox"))
+		Expect(inheritance[3].Received).To(Equal([]string{"security (global)", "auditors (from org)"}))
+	})
+
+	It("should list blocked templates and stop them at the blocking folder", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "org",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "sandbox", Subfolders: []rbacv1alpha1.TreeNode{{Name: "experiments"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "org",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("auditors", true), template("viewers", true)},
+					},
+					{Name: "sandbox", BlockInherited: []string{"auditors", "security"}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("security", false)},
+			},
+		}
+
+		inheritance := CalculateInheritance(folderTree)
+		Expect(inheritance).To(HaveLen(3))
+
+		Expect(inheritance[1].Path).To(Equal("org/sandbox"))
+		Expect(inheritance[1].Received).To(Equal([]string{"security (global)", "viewers (from org)"}))
+		Expect(inheritance[1].Blocked).To(Equal([]string{"auditors (from org)"}))
+		Expect(inheritance[1].Summary).To(Equal("receives 2, contributes 0"))
+
+		Expect(inheritance[2].Path).To(Equal("org/sandbox/experiments"))
+		Expect(inheritance[2].Received).To(Equal([]string{"security (global)", "viewers (from org)"}))
+		Expect(inheritance[2].Blocked).To(BeEmpty())
+	})
+
+	It("should stop templates after their propagateDepth", func() {
+		limited := template("on-call", true)
+		limited.PropagateDepth = ptr.To[int32](1)
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name: "org",
+					Subfolders: []rbacv1alpha1.TreeNode{
+						{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+					},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "org", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{limited, template("auditors", true)}},
+				},
+			},
+		}
+
+		inheritance := CalculateInheritance(folderTree)
+		Expect(inheritance).To(HaveLen(3))
+		Expect(inheritance[0].Contributed).To(Equal([]string{"on-call", "auditors"}))
+		Expect(inheritance[1].Received).To(Equal([]string{"on-call (from org)", "auditors (from org)"}))
+		Expect(inheritance[2].Received).To(Equal([]string{"auditors (from org)"}))
+	})
+
+	It("should list the templates a namespace group receives from its descendants", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "platform",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "web", Subfolders: []rbacv1alpha1.TreeNode{{Name: "frontend"}}}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "platform", InheritNamespaces: true},
+					{Name: "web", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("web-devs", false)}},
+					{Name: "frontend", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("designers", false)}},
+				},
+			},
+		}
+
+		inheritance := CalculateInheritance(folderTree)
+		Expect(inheritance).To(HaveLen(3))
+		Expect(inheritance[0].Received).To(Equal([]string{"web-devs (from web)", "designers (from frontend)"}))
+		Expect(inheritance[0].Summary).To(Equal("receives 2, contributes 0"))
+		Expect(inheritance[1].Received).To(BeEmpty())
+	})
+})
+
+var _ = Describe("CalculateEffectiveBindings", func() {
+	It("should list the templates in effect per namespace after inheritance", func() {
+		viewer := rbacv1alpha1.RoleBindingTemplate{
+			Name:      "viewers",
+			Subjects:  []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: rbacv1.GroupName}},
+			RoleRef:   rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			Propagate: boolPtr(true),
+		}
+		admin := rbacv1alpha1.RoleBindingTemplate{
+			Name:     "admins",
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "admins", APIGroup: rbacv1.GroupName}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+		}
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "effective"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "parent", Subfolders: []rbacv1alpha1.TreeNode{{Name: "child"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "parent", Namespaces: []string{"parent-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewer}},
+					{Name: "child", Namespaces: []string{"child-ns"}, RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{admin}},
+				},
+				GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "auditors",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "auditors", APIGroup: rbacv1.GroupName}},
+					RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+				}},
+			},
+		}
+
+		desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(CalculateEffectiveBindings(desired)).To(Equal(map[string][]rbacv1alpha1.EffectiveBinding{
+			"parent-ns": {
+				{Template: "auditors", RoleRef: "ClusterRole/view"},
+				{Template: "viewers", From: "parent", RoleRef: "ClusterRole/view"},
+			},
+			"child-ns": {
+				{Template: "admins", From: "child", RoleRef: "ClusterRole/admin"},
+				{Template: "auditors", RoleRef: "ClusterRole/view"},
+				{Template: "viewers", From: "parent", RoleRef: "ClusterRole/view"},
+			},
+		}))
+	})
+})
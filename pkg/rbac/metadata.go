@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ExtraMetadata holds labels and annotations the controller sets on every RoleBinding it manages on
+// top of its own, e.g. a cost center for chargeback or a URL for policy engines. Its keys are managed
+// like the controller's own: changed values are restored on the next reconcile. Keys removed from
+// ExtraMetadata are no longer managed and stay on existing RoleBindings.
+type ExtraMetadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Validate rejects invalid keys and label values, and keys the controller sets itself
+func (m ExtraMetadata) Validate() error {
+	for key, value := range m.Labels {
+		if err := validateExtraKey(key); err != nil {
+			return fmt.Errorf("label %q: %w", key, err)
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("label %q: invalid value %q: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	for key := range m.Annotations {
+		if err := validateExtraKey(key); err != nil {
+			return fmt.Errorf("annotation %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateExtraKey rejects invalid keys and keys the controller sets itself
+func validateExtraKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid key: %s", strings.Join(errs, "; "))
+	}
+	if IsManagedKey(key) || key == "app.kubernetes.io/managed-by" {
+		return fmt.Errorf("key is set by the controller")
+	}
+	return nil
+}
+
+// manages reports whether a label or annotation key is set from ExtraMetadata
+func (m ExtraMetadata) manages(key string, annotation bool) bool {
+	if annotation {
+		_, ok := m.Annotations[key]
+		return ok
+	}
+	_, ok := m.Labels[key]
+	return ok
+}
+
+// stamp sets the extra labels and annotations on a RoleBinding
+func (m ExtraMetadata) stamp(roleBinding *rbacv1.RoleBinding) {
+	if len(m.Labels) > 0 {
+		if roleBinding.Labels == nil {
+			roleBinding.Labels = make(map[string]string, len(m.Labels))
+		}
+		maps.Copy(roleBinding.Labels, m.Labels)
+	}
+	if len(m.Annotations) > 0 {
+		if roleBinding.Annotations == nil {
+			roleBinding.Annotations = make(map[string]string, len(m.Annotations))
+		}
+		maps.Copy(roleBinding.Annotations, m.Annotations)
+	}
+}
+
+// differs reports whether an existing RoleBinding lacks an extra label or annotation or has another value
+func (m ExtraMetadata) differs(existing *rbacv1.RoleBinding) bool {
+	for key, value := range m.Labels {
+		if existingValue, ok := existing.Labels[key]; !ok || existingValue != value {
+			return true
+		}
+	}
+	for key, value := range m.Annotations {
+		if existingValue, ok := existing.Annotations[key]; !ok || existingValue != value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// NetworkPolicyTemplateLabel names the network policy template a NetworkPolicy was generated from
+const NetworkPolicyTemplateLabel = "foldertree.rbac.kubevirt.io/network-policy-template"
+
+// NetworkPolicyBuilder creates the NetworkPolicies of network policy templates
+type NetworkPolicyBuilder struct {
+	FolderTree *rbacv1alpha1.FolderTree
+	Scheme     *runtime.Scheme
+
+	// ExcludedNamespaces never receive NetworkPolicies, in addition to the FolderTree's spec.excludedNamespaces
+	ExcludedNamespaces []string
+
+	// DisableOwnerReferences leaves the owner reference to the FolderTree off the NetworkPolicies
+	DisableOwnerReferences bool
+}
+
+// NetworkPolicyName returns the name of the NetworkPolicies generated from a template
+func NetworkPolicyName(folderTreeName, templateName string) string {
+	return fmt.Sprintf("foldertree-%s-%s", folderTreeName, templateName)
+}
+
+// BuildNetworkPolicyFromTemplate creates the NetworkPolicy of a template in the given namespace
+func (b *NetworkPolicyBuilder) BuildNetworkPolicyFromTemplate(namespace string, template rbacv1alpha1.NetworkPolicyTemplate) (*networkingv1.NetworkPolicy, error) {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(b.FolderTree.Name, template.Name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":     "foldertree-controller",
+				"foldertree.rbac.kubevirt.io/tree": b.FolderTree.Name,
+				NetworkPolicyTemplateLabel:         template.Name,
+			},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	defaultNetworkPolicySpec(&networkPolicy.Spec)
+
+	if b.Scheme != nil && !b.DisableOwnerReferences {
+		if err := controllerutil.SetControllerReference(b.FolderTree, networkPolicy, b.Scheme); err != nil {
+			return nil, err
+		}
+	}
+	return networkPolicy, nil
+}
+
+// CalculateDesiredNetworkPolicies returns the NetworkPolicies that should exist for a FolderTree,
+// keyed by "<namespace>/<name>". Network policy templates are inherited like role binding templates.
+func CalculateDesiredNetworkPolicies(folderTree *rbacv1alpha1.FolderTree, builder *NetworkPolicyBuilder) (map[string]*networkingv1.NetworkPolicy, error) {
+	templates := folderTemplates[rbacv1alpha1.NetworkPolicyTemplate]{
+		of: func(folder rbacv1alpha1.Folder) []rbacv1alpha1.NetworkPolicyTemplate {
+			return folder.NetworkPolicyTemplates
+		},
+		name: func(template rbacv1alpha1.NetworkPolicyTemplate) string { return template.Name },
+		propagate: func(template rbacv1alpha1.NetworkPolicyTemplate) bool {
+			return defaultPropagate(folderTree, template.Propagate)
+		},
+	}
+
+	desired := make(map[string]*networkingv1.NetworkPolicy)
+	err := walkFolderTemplates(folderTree, templates, excludedNamespaceSet(folderTree, builder.ExcludedNamespaces),
+		func(folder, namespace string, template rbacv1alpha1.NetworkPolicyTemplate) error {
+			networkPolicy, err := builder.BuildNetworkPolicyFromTemplate(namespace, template)
+			if err != nil {
+				return fmt.Errorf("failed to build NetworkPolicy for folder '%s': %v", folder, err)
+			}
+			desired[fmt.Sprintf("%s/%s", namespace, networkPolicy.Name)] = networkPolicy
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+// NetworkPolicyOperation is an operation needed to synchronize a NetworkPolicy with its template
+type NetworkPolicyOperation struct {
+	Type     OperationType
+	Existing *networkingv1.NetworkPolicy // nil for create operations
+	Desired  *networkingv1.NetworkPolicy // nil for delete operations
+}
+
+// String returns a human-readable description of the operation
+func (op *NetworkPolicyOperation) String() string {
+	target := op.Desired
+	if target == nil {
+		target = op.Existing
+	}
+	return fmt.Sprintf("%s NetworkPolicy '%s' in namespace '%s'", op.Type, target.Name, target.Namespace)
+}
+
+// AnalyzeNetworkPolicyDiff compares the NetworkPolicies labeled with the FolderTree with the desired
+// ones and returns the operations needed to synchronize them
+func AnalyzeNetworkPolicyDiff(ctx context.Context, c client.Reader, folderTree *rbacv1alpha1.FolderTree, builder *NetworkPolicyBuilder) ([]NetworkPolicyOperation, error) {
+	desired, err := CalculateDesiredNetworkPolicies(folderTree, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	networkPolicyList := &networkingv1.NetworkPolicyList{}
+	if err := c.List(ctx, networkPolicyList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list NetworkPolicies: %v", err)
+	}
+
+	var operations []NetworkPolicyOperation
+	existing := make(map[string]bool)
+	for i := range networkPolicyList.Items {
+		networkPolicy := &networkPolicyList.Items[i]
+		key := fmt.Sprintf("%s/%s", networkPolicy.Namespace, networkPolicy.Name)
+		existing[key] = true
+		desiredPolicy, ok := desired[key]
+		switch {
+		case !ok:
+			operations = append(operations, NetworkPolicyOperation{Type: OperationDelete, Existing: networkPolicy})
+		case networkPolicyNeedsUpdate(networkPolicy, desiredPolicy, builder.Scheme != nil):
+			operations = append(operations, NetworkPolicyOperation{Type: OperationUpdate, Existing: networkPolicy, Desired: desiredPolicy})
+		}
+	}
+	for key, desiredPolicy := range desired {
+		if !existing[key] {
+			operations = append(operations, NetworkPolicyOperation{Type: OperationCreate, Desired: desiredPolicy})
+		}
+	}
+	return operations, nil
+}
+
+// networkPolicyNeedsUpdate reports whether an existing NetworkPolicy differs from the desired one in
+// its spec, its managed labels or, when compareOwnership is set, its FolderTree owner references
+func networkPolicyNeedsUpdate(existing, desired *networkingv1.NetworkPolicy, compareOwnership bool) bool {
+	if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return true
+	}
+	for key, value := range desired.Labels {
+		if IsManagedKey(key) && existing.Labels[key] != value {
+			return true
+		}
+	}
+	return compareOwnership && !ownerReferencesEqual(existing.OwnerReferences, desired.OwnerReferences)
+}
+
+// defaultNetworkPolicySpec sets the defaults the API server applies to NetworkPolicies, so desired
+// and existing specs compare equal: TCP for ports without a protocol, and policy types derived
+// from the rules when none are given
+func defaultNetworkPolicySpec(spec *networkingv1.NetworkPolicySpec) {
+	defaultPorts := func(ports []networkingv1.NetworkPolicyPort) {
+		for i := range ports {
+			if ports[i].Protocol == nil {
+				ports[i].Protocol = ptr.To(corev1.ProtocolTCP)
+			}
+		}
+	}
+	for i := range spec.Ingress {
+		defaultPorts(spec.Ingress[i].Ports)
+	}
+	for i := range spec.Egress {
+		defaultPorts(spec.Egress[i].Ports)
+	}
+
+	if len(spec.PolicyTypes) == 0 {
+		spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+		if len(spec.Egress) > 0 {
+			spec.PolicyTypes = append(spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		}
+	}
+}
+
+// MergeNetworkPolicyOwnerReferences returns the owner references of an existing NetworkPolicy with
+// its FolderTree owner references replaced by those of the desired one, see MergeOwnerReferences
+func MergeNetworkPolicyOwnerReferences(existing, desired *networkingv1.NetworkPolicy) []metav1.OwnerReference {
+	return mergeOwnerReferences(existing.OwnerReferences, desired.OwnerReferences)
+}
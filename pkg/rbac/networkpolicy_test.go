@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("NetworkPolicy Templates", func() {
+	var folderTree *rbacv1alpha1.FolderTree
+
+	denyAll := rbacv1alpha1.NetworkPolicyTemplate{
+		Name: "deny-all",
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+		Propagate: ptr.To(true),
+	}
+	allowWeb := rbacv1alpha1.NetworkPolicyTemplate{
+		Name: "allow-web",
+		Spec: networkingv1.NetworkPolicySpec{
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(8080))}},
+			}},
+		},
+	}
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "np-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "root",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}, {Name: "open"}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", Namespaces: []string{"root-ns"}, NetworkPolicyTemplates: []rbacv1alpha1.NetworkPolicyTemplate{denyAll}},
+					{Name: "web", Namespaces: []string{"web-ns"}, NetworkPolicyTemplates: []rbacv1alpha1.NetworkPolicyTemplate{allowWeb}},
+					{Name: "open", Namespaces: []string{"open-ns"}, NetworkPolicyTemplates: []rbacv1alpha1.NetworkPolicyTemplate{
+						{Name: "deny-all", Spec: networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}}},
+					}},
+				},
+			},
+		}
+	})
+
+	It("should inherit propagating templates and let subfolders override them by name", func() {
+		desired, err := CalculateDesiredNetworkPolicies(folderTree, &NetworkPolicyBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired).To(HaveLen(4))
+		Expect(desired).To(HaveKey("root-ns/foldertree-np-tree-deny-all"))
+		Expect(desired).To(HaveKey("web-ns/foldertree-np-tree-deny-all"))
+		Expect(desired).To(HaveKey("web-ns/foldertree-np-tree-allow-web"))
+
+		overridden := desired["open-ns/foldertree-np-tree-deny-all"]
+		Expect(overridden.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeEgress}))
+		Expect(overridden.Labels).To(HaveKeyWithValue(NetworkPolicyTemplateLabel, "deny-all"))
+		Expect(overridden.Labels).To(HaveKeyWithValue("foldertree.rbac.kubevirt.io/tree", "np-tree"))
+	})
+
+	It("should fill in the defaults of the API server", func() {
+		desired, err := CalculateDesiredNetworkPolicies(folderTree, &NetworkPolicyBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		allow := desired["web-ns/foldertree-np-tree-allow-web"]
+		Expect(allow.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+		Expect(allow.Spec.Ingress[0].Ports[0].Protocol).To(Equal(ptr.To(corev1.ProtocolTCP)))
+		Expect(folderTree.Spec.Folders[1].NetworkPolicyTemplates[0].Spec.Ingress[0].Ports[0].Protocol).To(BeNil())
+	})
+
+	It("should skip excluded namespaces", func() {
+		folderTree.Spec.ExcludedNamespaces = []string{"web-ns"}
+		desired, err := CalculateDesiredNetworkPolicies(folderTree, &NetworkPolicyBuilder{FolderTree: folderTree, ExcludedNamespaces: []string{"open-ns"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired).To(HaveLen(1))
+		Expect(desired).To(HaveKey("root-ns/foldertree-np-tree-deny-all"))
+	})
+
+	It("should create, update and delete NetworkPolicies to match the templates", func() {
+		builder := &NetworkPolicyBuilder{FolderTree: folderTree}
+		desired, err := CalculateDesiredNetworkPolicies(folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+
+		unchanged := desired["root-ns/foldertree-np-tree-deny-all"].DeepCopy()
+		changed := desired["web-ns/foldertree-np-tree-allow-web"].DeepCopy()
+		changed.Spec.Ingress = nil
+		stale := desired["root-ns/foldertree-np-tree-deny-all"].DeepCopy()
+		stale.Namespace = "old-ns"
+
+		scheme := runtime.NewScheme()
+		Expect(networkingv1.AddToScheme(scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(unchanged, changed, stale).Build()
+
+		operations, err := AnalyzeNetworkPolicyDiff(context.Background(), fakeClient, folderTree, builder)
+		Expect(err).NotTo(HaveOccurred())
+
+		counts := map[OperationType]int{}
+		for _, operation := range operations {
+			counts[operation.Type]++
+		}
+		Expect(counts).To(Equal(map[OperationType]int{OperationCreate: 2, OperationUpdate: 1, OperationDelete: 1}))
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// replacementHashLength is the number of hex characters of the roleRef hash in replacement names
+const replacementHashLength = 8
+
+// ReplacementRoleBindingName returns the name a RoleBinding named name is replaced under when its
+// roleRef changes to roleRef with the CreateBeforeDelete replacement policy. The name is suffixed
+// with a hash of the roleRef, so it never collides with the RoleBinding it replaces.
+func ReplacementRoleBindingName(name string, roleRef rbacv1.RoleRef) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", roleRef.APIGroup, roleRef.Kind, roleRef.Name)))
+	suffix := "-" + hex.EncodeToString(hash[:])[:replacementHashLength]
+	if len(name)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		name = name[:validation.DNS1123SubdomainMaxLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// createsBeforeDeletes reports whether RoleBindings are replaced by creating the replacement first
+func createsBeforeDeletes(folderTree *rbacv1alpha1.FolderTree) bool {
+	return folderTree.Spec.ReplacementPolicy != rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate
+}
+
+// SortOperations orders operations by namespace and, within a namespace, by the replacement policy
+// of the FolderTree: with CreateBeforeDelete, creates and updates come before deletes, so access
+// being replaced is granted again before it is revoked; with DeleteBeforeCreate, deletes come first,
+// so a RoleBinding recreated under the same name is gone before it is created again.
+// Operations of the same type are ordered by RoleBinding name.
+func SortOperations(folderTree *rbacv1alpha1.FolderTree, operations []RoleBindingOperation) {
+	phases := map[OperationType]int{OperationCreate: 0, OperationUpdate: 1, OperationDelete: 2}
+	if !createsBeforeDeletes(folderTree) {
+		phases[OperationDelete] = -1
+	}
+
+	sort.SliceStable(operations, func(i, j int) bool {
+		if operations[i].Namespace != operations[j].Namespace {
+			return operations[i].Namespace < operations[j].Namespace
+		}
+		if pi, pj := phases[operations[i].Type], phases[operations[j].Type]; pi != pj {
+			return pi < pj
+		}
+		return operations[i].targetKey() < operations[j].targetKey()
+	})
+}
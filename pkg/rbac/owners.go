@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// NamespaceIndexField is the cache field index mapping FolderTrees to the namespaces their folders list
+const NamespaceIndexField = "spec.folders.namespaces"
+
+// SetupNamespaceIndex registers NamespaceIndexField with a cache, e.g. the field indexer of a manager.
+// It must be called before the cache is started.
+func SetupNamespaceIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &rbacv1alpha1.FolderTree{}, NamespaceIndexField, IndexFolderTreeNamespaces)
+}
+
+// IndexFolderTreeNamespaces returns the namespaces listed by the folders of a FolderTree,
+// leaving out namespaces in spec.excludedNamespaces
+func IndexFolderTreeNamespaces(obj client.Object) []string {
+	folderTree, ok := obj.(*rbacv1alpha1.FolderTree)
+	if !ok {
+		return nil
+	}
+
+	var namespaces []string
+	for _, folder := range folderTree.Spec.Folders {
+		for _, namespace := range folder.Namespaces {
+			if !slices.Contains(folderTree.Spec.ExcludedNamespaces, namespace) && !slices.Contains(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	return namespaces
+}
+
+// WhichTreeOwns returns the sorted names of the FolderTrees managing a namespace, either because
+// one of their folders lists it or through an approved FolderMembership or FolderTreePatch. The
+// reader must be a cache with NamespaceIndexField registered (see SetupNamespaceIndex), so the
+// lookup does not need to scan the spec of every FolderTree.
+func WhichTreeOwns(ctx context.Context, c client.Reader, namespace string) ([]string, error) {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := c.List(ctx, &folderTreeList, client.MatchingFields{NamespaceIndexField: namespace}); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees for namespace '%s': %v", namespace, err)
+	}
+
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+
+	patches, err := listApprovedPatches(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var trees []string
+	for _, folderTree := range folderTreeList.Items {
+		trees = append(trees, folderTree.Name)
+	}
+	for _, membership := range membershipList.Items {
+		if membership.Status.Phase == rbacv1alpha1.MembershipPhaseApproved {
+			trees = append(trees, membership.Spec.TreeName)
+		}
+	}
+	for _, patch := range patches {
+		if slices.Contains(patch.Spec.Namespaces, namespace) {
+			trees = append(trees, patch.Spec.TreeName)
+		}
+	}
+
+	slices.Sort(trees)
+	return slices.Compact(trees), nil
+}
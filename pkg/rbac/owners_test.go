@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("WhichTreeOwns", func() {
+	var (
+		ctx     context.Context
+		scheme  *runtime.Scheme
+		objects []client.Object
+	)
+
+	folderTree := func(name string, excluded []string, namespaces ...string) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "first", Namespaces: namespaces},
+					{Name: "second", Namespaces: namespaces[:1]},
+				},
+				ExcludedNamespaces: excluded,
+			},
+		}
+	}
+
+	whichTreeOwns := func(namespace string) []string {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			WithIndex(&rbacv1alpha1.FolderTree{}, NamespaceIndexField, IndexFolderTreeNamespaces).
+			Build()
+		trees, err := WhichTreeOwns(ctx, fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		return trees
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		objects = []client.Object{
+			folderTree("platform", nil, "shared-ns", "platform-ns"),
+			folderTree("apps", []string{"platform-ns"}, "shared-ns", "platform-ns", "apps-ns"),
+		}
+	})
+
+	It("should index each namespace of a FolderTree once, leaving out excluded namespaces", func() {
+		Expect(IndexFolderTreeNamespaces(objects[0])).To(Equal([]string{"shared-ns", "platform-ns"}))
+		Expect(IndexFolderTreeNamespaces(objects[1])).To(Equal([]string{"shared-ns", "apps-ns"}))
+	})
+
+	It("should return the FolderTrees whose folders list the namespace", func() {
+		Expect(whichTreeOwns("shared-ns")).To(Equal([]string{"apps", "platform"}))
+		Expect(whichTreeOwns("platform-ns")).To(Equal([]string{"platform"}))
+		Expect(whichTreeOwns("unrelated-ns")).To(BeEmpty())
+	})
+
+	It("should include FolderTrees joined through approved FolderMemberships", func() {
+		objects = append(objects,
+			&rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join-apps", Namespace: "team-ns"},
+				Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "apps", FolderName: "first"},
+				Status:     rbacv1alpha1.FolderMembershipStatus{Phase: rbacv1alpha1.MembershipPhaseApproved},
+			},
+			&rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join-platform", Namespace: "team-ns"},
+				Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "platform", FolderName: "first"},
+				Status:     rbacv1alpha1.FolderMembershipStatus{Phase: rbacv1alpha1.MembershipPhasePending},
+			},
+		)
+
+		Expect(whichTreeOwns("team-ns")).To(Equal([]string{"apps"}))
+	})
+})
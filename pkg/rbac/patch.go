@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// ApplyFolderTreePatch returns a copy of the FolderTree with the role binding templates and
+// namespaces of a FolderTreePatch added to its folder. Whether the folder accepts the patch is
+// not checked. The original is never modified.
+func ApplyFolderTreePatch(folderTree *rbacv1alpha1.FolderTree, patch *rbacv1alpha1.FolderTreePatch) (*rbacv1alpha1.FolderTree, error) {
+	patched := folderTree.DeepCopy()
+	spec := patch.Spec.DeepCopy()
+	for i := range patched.Spec.Folders {
+		folder := &patched.Spec.Folders[i]
+		if folder.Name != spec.FolderName {
+			continue
+		}
+		folder.RoleBindingTemplates = append(folder.RoleBindingTemplates, spec.RoleBindingTemplates...)
+		for _, namespace := range spec.Namespaces {
+			if !slices.Contains(folder.Namespaces, namespace) {
+				folder.Namespaces = append(folder.Namespaces, namespace)
+			}
+		}
+		return patched, nil
+	}
+	return nil, fmt.Errorf("folder '%s' not found in FolderTree '%s'", spec.FolderName, folderTree.Name)
+}
+
+// NamespaceGroupAbove returns the nearest folder with inheritNamespaces above a folder of the tree.
+// The namespaces of such a namespace group receive the templates of all its descendants, which is
+// why FolderTreePatches may not add role binding templates below one: folders only accept patches
+// for themselves, not for the namespaces of the groups above them.
+func NamespaceGroupAbove(spec *rbacv1alpha1.FolderTreeSpec, folderName string) (string, bool) {
+	groups := make(map[string]bool)
+	for _, folder := range spec.Folders {
+		if folder.InheritNamespaces {
+			groups[folder.Name] = true
+		}
+	}
+	var find func(node rbacv1alpha1.TreeNode, group string) (string, bool)
+	find = func(node rbacv1alpha1.TreeNode, group string) (string, bool) {
+		if node.Name == folderName {
+			return group, group != ""
+		}
+		if groups[node.Name] {
+			group = node.Name
+		}
+		for _, subfolder := range node.Subfolders {
+			if found, ok := find(subfolder, group); ok {
+				return found, true
+			}
+		}
+		return "", false
+	}
+	for _, root := range spec.Roots() {
+		if group, ok := find(root, ""); ok {
+			return group, true
+		}
+	}
+	return "", false
+}
+
+// listApprovedPatches returns the approved FolderTreePatches of all FolderTrees
+func listApprovedPatches(ctx context.Context, c client.Reader) ([]rbacv1alpha1.FolderTreePatch, error) {
+	var patchList rbacv1alpha1.FolderTreePatchList
+	if err := c.List(ctx, &patchList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTreePatches: %v", err)
+	}
+	var approved []rbacv1alpha1.FolderTreePatch
+	for _, patch := range patchList.Items {
+		if patch.Status.Phase == rbacv1alpha1.PatchPhaseApproved {
+			approved = append(approved, patch)
+		}
+	}
+	return approved, nil
+}
+
+// withApprovedPatches returns the FolderTree with the approved FolderTreePatches targeting it
+// merged in, in the order the controller merges them. The original is never modified.
+func withApprovedPatches(folderTree *rbacv1alpha1.FolderTree, patches []rbacv1alpha1.FolderTreePatch) *rbacv1alpha1.FolderTree {
+	resolved := folderTree
+	for _, patch := range SortedPatches(patches) {
+		if patch.Spec.TreeName != folderTree.Name {
+			continue
+		}
+		if patched, err := ApplyFolderTreePatch(resolved, patch); err == nil {
+			resolved = patched
+		}
+	}
+	return resolved
+}
+
+// SortedPatches returns pointers to FolderTreePatches ordered by namespace and name, the order
+// the controller merges them in
+func SortedPatches(patches []rbacv1alpha1.FolderTreePatch) []*rbacv1alpha1.FolderTreePatch {
+	sorted := make([]*rbacv1alpha1.FolderTreePatch, 0, len(patches))
+	for i := range patches {
+		sorted = append(sorted, &patches[i])
+	}
+	slices.SortFunc(sorted, func(a, b *rbacv1alpha1.FolderTreePatch) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return sorted
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"regexp"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// folderPattern is the compiled namespacePattern of a folder
+type folderPattern struct {
+	Folder  string
+	Pattern *regexp.Regexp
+}
+
+// namespacePatterns compiles the namespacePatterns of the folders of a FolderTree in spec order.
+// Invalid patterns, which the webhook rejects, are left out.
+func namespacePatterns(folderTree *rbacv1alpha1.FolderTree) []folderPattern {
+	var patterns []folderPattern
+	for _, folder := range folderTree.Spec.Folders {
+		if folder.NamespacePattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(folder.NamespacePattern)
+		if err != nil {
+			continue
+		}
+		patterns = append(patterns, folderPattern{Folder: folder.Name, Pattern: pattern})
+	}
+	return patterns
+}
+
+// UsesNamespacePatterns reports whether any folder of a FolderTree has a namespacePattern
+func UsesNamespacePatterns(folderTree *rbacv1alpha1.FolderTree) bool {
+	return slices.ContainsFunc(folderTree.Spec.Folders, func(folder rbacv1alpha1.Folder) bool {
+		return folder.NamespacePattern != ""
+	})
+}
+
+// MatchesNamespacePattern reports whether a namespace matches the namespacePattern of any folder of a FolderTree
+func MatchesNamespacePattern(folderTree *rbacv1alpha1.FolderTree, namespace string) bool {
+	return slices.ContainsFunc(namespacePatterns(folderTree), func(pattern folderPattern) bool {
+		return pattern.Pattern.MatchString(namespace)
+	})
+}
+
+// WithPatternNamespaces returns a copy of a FolderTree with the existing namespaces matching the
+// namespacePatterns of its folders added to them. Namespaces that a folder already lists, that
+// spec.excludedNamespaces excludes or that claimed reports as assigned elsewhere are left out.
+// A namespace matching the patterns of several folders joins the first of them. The FolderTree is
+// returned as is when it has no patterns.
+func WithPatternNamespaces(folderTree *rbacv1alpha1.FolderTree, namespaces []string, claimed func(namespace string) bool) *rbacv1alpha1.FolderTree {
+	patterns := namespacePatterns(folderTree)
+	if len(patterns) == 0 {
+		return folderTree
+	}
+
+	listed := IndexFolderTreeNamespaces(folderTree)
+	desired := folderTree.DeepCopy()
+	for _, namespace := range slices.Sorted(slices.Values(namespaces)) {
+		if slices.Contains(listed, namespace) || slices.Contains(folderTree.Spec.ExcludedNamespaces, namespace) ||
+			(claimed != nil && claimed(namespace)) {
+			continue
+		}
+		i := slices.IndexFunc(patterns, func(pattern folderPattern) bool {
+			return pattern.Pattern.MatchString(namespace)
+		})
+		if i < 0 {
+			continue
+		}
+		for j := range desired.Spec.Folders {
+			if desired.Spec.Folders[j].Name == patterns[i].Folder {
+				desired.Spec.Folders[j].Namespaces = append(desired.Spec.Folders[j].Namespaces, namespace)
+				break
+			}
+		}
+	}
+	return desired
+}
+
+// ClaimedByOtherTrees returns a function reporting whether a namespace belongs to a FolderTree other
+// than the given one: because one of its folders lists it, or because it matches a namespacePattern of
+// a FolderTree whose name sorts first. Patterns of the given tree never match such namespaces.
+func ClaimedByOtherTrees(folderTree *rbacv1alpha1.FolderTree, folderTrees []rbacv1alpha1.FolderTree) func(namespace string) bool {
+	listed := make(map[string]bool)
+	var earlier []folderPattern
+	for i := range folderTrees {
+		tree := &folderTrees[i]
+		if tree.Name == folderTree.Name {
+			continue
+		}
+		for _, namespace := range IndexFolderTreeNamespaces(tree) {
+			listed[namespace] = true
+		}
+		if tree.Name < folderTree.Name {
+			earlier = append(earlier, namespacePatterns(tree)...)
+		}
+	}
+	return func(namespace string) bool {
+		return listed[namespace] || slices.ContainsFunc(earlier, func(pattern folderPattern) bool {
+			return pattern.Pattern.MatchString(namespace)
+		})
+	}
+}
+
+// PatternRoleBindings returns the RoleBindings a namespace receives when it starts matching the
+// namespacePattern of a folder, keyed by folder/RoleBinding name. They are calculated for a
+// placeholder namespace per folder, named so that it cannot collide with a real namespace, as the
+// namespaces created later are unknown.
+func PatternRoleBindings(folderTree *rbacv1alpha1.FolderTree, builder *RoleBindingBuilder) (map[string]*rbacv1.RoleBinding, error) {
+	patterns := namespacePatterns(folderTree)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make(map[string]string)
+	withPlaceholders := folderTree.DeepCopy()
+	for _, pattern := range patterns {
+		namespace := pattern.Folder + ".namespace-pattern"
+		placeholders[namespace] = pattern.Folder
+		for i := range withPlaceholders.Spec.Folders {
+			if withPlaceholders.Spec.Folders[i].Name == pattern.Folder {
+				withPlaceholders.Spec.Folders[i].Namespaces = append(withPlaceholders.Spec.Folders[i].Namespaces, namespace)
+			}
+		}
+	}
+
+	placeholderBuilder := *builder
+	placeholderBuilder.FolderTree = withPlaceholders
+	desired, err := CalculateDesiredRoleBindings(withPlaceholders, &placeholderBuilder)
+	if err != nil {
+		return nil, err
+	}
+	roleBindings := make(map[string]*rbacv1.RoleBinding)
+	for _, desiredRoleBinding := range desired.RoleBindings {
+		if folder, ok := placeholders[desiredRoleBinding.Namespace]; ok {
+			roleBindings[folder+"/"+desiredRoleBinding.RoleBinding.Name] = desiredRoleBinding.RoleBinding
+		}
+	}
+	return roleBindings, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Namespace Patterns", func() {
+	template := func(name string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{
+			Name:      name,
+			Propagate: boolPtr(propagate),
+			Subjects:  []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: rbacv1.GroupName}},
+			RoleRef:   rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		}
+	}
+
+	It("should return the RoleBindings namespaces matching a pattern later receive", func() {
+		folderTree := &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "pattern-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "teams"}}},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "platform", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("sre", true), template("leads", false)}},
+					{Name: "teams", NamespacePattern: "^team-.*$", Namespaces: []string{"team-a"},
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("developers", false)}},
+				},
+			},
+		}
+
+		roleBindings, err := PatternRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindings).To(HaveLen(2))
+		Expect(roleBindings).To(HaveKey("teams/foldertree-pattern-tree-sre"))
+		Expect(roleBindings).To(HaveKey("teams/foldertree-pattern-tree-developers"))
+
+		By("returning nothing for FolderTrees without patterns")
+		folderTree.Spec.Folders[1].NamespacePattern = ""
+		roleBindings, err = PatternRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBindings).To(BeEmpty())
+	})
+})
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"slices"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Outranks reports whether FolderTree a manages the namespaces it shares with FolderTree b.
+// The higher spec.priority wins, then the older FolderTree, then the lexically smaller name.
+// A FolderTree that has not been created yet is younger than any existing one.
+func Outranks(a, b *rbacv1alpha1.FolderTree) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority > b.Spec.Priority
+	}
+	aCreated, bCreated := a.CreationTimestamp, b.CreationTimestamp
+	if !aCreated.Equal(&bCreated) {
+		switch {
+		case aCreated.IsZero():
+			return false
+		case bCreated.IsZero():
+			return true
+		}
+		return aCreated.Before(&bCreated)
+	}
+	return a.Name < b.Name
+}
+
+// SupersededNamespaces returns the namespaces of a FolderTree that other FolderTrees outranking
+// it also list, mapped to the name of the highest ranked of them, which manages the namespace.
+func SupersededNamespaces(folderTree *rbacv1alpha1.FolderTree, others []rbacv1alpha1.FolderTree) map[string]string {
+	namespaces := IndexFolderTreeNamespaces(folderTree)
+	superseded := make(map[string]string)
+	winners := make(map[string]*rbacv1alpha1.FolderTree)
+	for i := range others {
+		other := &others[i]
+		if other.Name == folderTree.Name || !Outranks(other, folderTree) {
+			continue
+		}
+		for _, namespace := range IndexFolderTreeNamespaces(other) {
+			if !slices.Contains(namespaces, namespace) {
+				continue
+			}
+			if winner, ok := winners[namespace]; !ok || Outranks(other, winner) {
+				winners[namespace] = other
+				superseded[namespace] = other.Name
+			}
+		}
+	}
+	return superseded
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Priority", func() {
+	created := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	folderTree := func(name string, priority int32, creationTimestamp metav1.Time, namespaces ...string) rbacv1alpha1.FolderTree {
+		return rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: creationTimestamp},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Priority: priority,
+				Folders:  []rbacv1alpha1.Folder{{Name: name + "-folder", Namespaces: namespaces}},
+			},
+		}
+	}
+
+	It("should rank by priority, then age, then name", func() {
+		older := folderTree("b", 0, created)
+		newer := folderTree("a", 0, metav1.NewTime(created.Add(time.Hour)))
+		higher := folderTree("c", 10, metav1.NewTime(created.Add(2*time.Hour)))
+		sameAge := folderTree("a", 0, created)
+		notCreated := folderTree("0", 0, metav1.Time{})
+
+		Expect(Outranks(&higher, &older)).To(BeTrue())
+		Expect(Outranks(&older, &higher)).To(BeFalse())
+		Expect(Outranks(&older, &newer)).To(BeTrue())
+		Expect(Outranks(&sameAge, &older)).To(BeTrue())
+		Expect(Outranks(&newer, &notCreated)).To(BeTrue())
+		Expect(Outranks(&notCreated, &newer)).To(BeFalse())
+	})
+
+	It("should map superseded namespaces to the highest ranked FolderTree listing them", func() {
+		tree := folderTree("tree", 5, created, "shared", "contested", "own")
+		others := []rbacv1alpha1.FolderTree{
+			tree,
+			folderTree("low", 1, created, "shared", "own"),
+			folderTree("high", 10, created, "shared"),
+			folderTree("highest", 20, created, "contested"),
+			folderTree("medium", 8, created, "contested"),
+		}
+
+		Expect(SupersededNamespaces(&tree, others)).To(Equal(map[string]string{
+			"shared":    "high",
+			"contested": "highest",
+		}))
+	})
+})
@@ -0,0 +1,286 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// TreeResolver resolves the FolderTree the controller reconciles towards: the tree with the approved
+// FolderMemberships and FolderTreePatches targeting it applied. Whether a membership or patch is
+// approved is decided against the spec being resolved, not read from its status, so that the webhook
+// validates the RoleBindings a FolderTree update leads to, including those of memberships and patches
+// the update approves.
+type TreeResolver struct {
+	// FolderTrees are all FolderTrees. Namespaces listed by the others cannot join the resolved tree.
+	FolderTrees []rbacv1alpha1.FolderTree
+
+	// Memberships are all FolderMemberships, whatever their phase
+	Memberships []rbacv1alpha1.FolderMembership
+
+	// Patches are all FolderTreePatches, whatever their phase
+	Patches []rbacv1alpha1.FolderTreePatch
+
+	// ValidateSpec, when set, rejects the patches that make the spec of a valid FolderTree invalid
+	ValidateSpec func(spec *rbacv1alpha1.FolderTreeSpec) error
+
+	// RecordMembership, when set, is called with the outcome of every membership targeting the tree
+	RecordMembership func(membership *rbacv1alpha1.FolderMembership, phase rbacv1alpha1.MembershipPhase, message string)
+
+	// RecordPatch, when set, is called with the outcome of every patch targeting the tree
+	RecordPatch func(patch *rbacv1alpha1.FolderTreePatch, phase rbacv1alpha1.PatchPhase, message string)
+}
+
+// NewTreeResolver returns a TreeResolver for all FolderTrees, FolderMemberships and FolderTreePatches
+func NewTreeResolver(ctx context.Context, c client.Reader) (*TreeResolver, error) {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := c.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+	var patchList rbacv1alpha1.FolderTreePatchList
+	if err := c.List(ctx, &patchList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTreePatches: %v", err)
+	}
+	return &TreeResolver{
+		FolderTrees: folderTreeList.Items,
+		Memberships: membershipList.Items,
+		Patches:     patchList.Items,
+	}, nil
+}
+
+// Resolve returns a copy of the FolderTree with the namespaces of approved memberships added first,
+// and the approved patches merged on top of them. The original is never modified.
+func (r *TreeResolver) Resolve(folderTree *rbacv1alpha1.FolderTree) *rbacv1alpha1.FolderTree {
+	if folderTree == nil {
+		return nil
+	}
+	otherTrees := NamespacesOfOtherTrees(folderTree, r.FolderTrees)
+	return r.resolvePatches(folderTree, r.resolveMemberships(folderTree, otherTrees), otherTrees)
+}
+
+// resolveMemberships adds the namespaces of the approved memberships targeting a FolderTree to a copy of it.
+// Memberships are evaluated in namespace/name order against the tree itself.
+func (r *TreeResolver) resolveMemberships(folderTree *rbacv1alpha1.FolderTree, otherTrees map[string]string) *rbacv1alpha1.FolderTree {
+	var memberships []*rbacv1alpha1.FolderMembership
+	for i := range r.Memberships {
+		if r.Memberships[i].Spec.TreeName == folderTree.Name {
+			memberships = append(memberships, &r.Memberships[i])
+		}
+	}
+	if len(memberships) == 0 {
+		return folderTree
+	}
+	slices.SortFunc(memberships, func(a, b *rbacv1alpha1.FolderMembership) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	primary := PrimaryMemberships(r.Memberships)
+	desired := folderTree.DeepCopy()
+	for _, membership := range memberships {
+		phase, message := EvaluateMembership(membership, folderTree, otherTrees, primary)
+		if phase == rbacv1alpha1.MembershipPhaseApproved {
+			for i := range desired.Spec.Folders {
+				folder := &desired.Spec.Folders[i]
+				if folder.Name == membership.Spec.FolderName && !slices.Contains(folder.Namespaces, membership.Namespace) {
+					folder.Namespaces = append(folder.Namespaces, membership.Namespace)
+				}
+			}
+		}
+		if r.RecordMembership != nil {
+			r.RecordMembership(membership, phase, message)
+		}
+	}
+	return desired
+}
+
+// resolvePatches merges the approved patches targeting a FolderTree into desiredTree, the tree with
+// approved memberships resolved. Patches are merged in namespace/name order, each on top of the ones before.
+func (r *TreeResolver) resolvePatches(folderTree, desiredTree *rbacv1alpha1.FolderTree, otherTrees map[string]string) *rbacv1alpha1.FolderTree {
+	var patches []rbacv1alpha1.FolderTreePatch
+	for _, patch := range r.Patches {
+		if patch.Spec.TreeName == folderTree.Name {
+			patches = append(patches, patch)
+		}
+	}
+	if len(patches) == 0 {
+		return desiredTree
+	}
+
+	// Patches are validated like the FolderTree, but a tree that is invalid already, e.g. because
+	// the webhook allows deeper trees than the defaults, does not block them
+	validate := r.ValidateSpec != nil && r.ValidateSpec(&desiredTree.Spec) == nil
+
+	for _, patch := range SortedPatches(patches) {
+		phase, message, patched := EvaluatePatch(patch, desiredTree, otherTrees)
+		if patched != nil && validate {
+			if err := r.ValidateSpec(&patched.Spec); err != nil {
+				phase, message, patched = rbacv1alpha1.PatchPhaseRejected, err.Error(), nil
+			}
+		}
+		if patched != nil {
+			desiredTree = patched
+		}
+		if r.RecordPatch != nil {
+			r.RecordPatch(patch, phase, message)
+		}
+	}
+	return desiredTree
+}
+
+// NamespacesOfOtherTrees returns the FolderTree assigning each namespace listed by the spec of a
+// FolderTree other than the given one
+func NamespacesOfOtherTrees(folderTree *rbacv1alpha1.FolderTree, folderTrees []rbacv1alpha1.FolderTree) map[string]string {
+	otherTrees := make(map[string]string)
+	for _, tree := range folderTrees {
+		if tree.Name == folderTree.Name {
+			continue
+		}
+		for _, folder := range tree.Spec.Folders {
+			for _, namespace := range folder.Namespaces {
+				otherTrees[namespace] = tree.Name
+			}
+		}
+	}
+	return otherTrees
+}
+
+// PrimaryMemberships returns, per namespace, the name of the FolderMembership that is allowed
+// to take effect. A namespace can only join one folder, so when several memberships exist
+// in the same namespace the oldest one wins.
+func PrimaryMemberships(memberships []rbacv1alpha1.FolderMembership) map[string]string {
+	primary := make(map[string]string)
+	oldest := make(map[string]*rbacv1alpha1.FolderMembership)
+	for i := range memberships {
+		membership := &memberships[i]
+		current, ok := oldest[membership.Namespace]
+		if ok {
+			if membership.CreationTimestamp.After(current.CreationTimestamp.Time) {
+				continue
+			}
+			if membership.CreationTimestamp.Equal(&current.CreationTimestamp) && membership.Name > current.Name {
+				continue
+			}
+		}
+		oldest[membership.Namespace] = membership
+		primary[membership.Namespace] = membership.Name
+	}
+	return primary
+}
+
+// EvaluateMembership decides whether a FolderMembership can be applied to the FolderTree
+func EvaluateMembership(membership *rbacv1alpha1.FolderMembership, folderTree *rbacv1alpha1.FolderTree,
+	otherTrees map[string]string, primary map[string]string) (rbacv1alpha1.MembershipPhase, string) {
+
+	namespace := membership.Namespace
+
+	if name := primary[namespace]; name != membership.Name {
+		return rbacv1alpha1.MembershipPhaseRejected,
+			fmt.Sprintf("namespace '%s' already requests membership with FolderMembership '%s'", namespace, name)
+	}
+
+	if tree, ok := otherTrees[namespace]; ok {
+		return rbacv1alpha1.MembershipPhaseRejected,
+			fmt.Sprintf("namespace '%s' is already assigned in FolderTree '%s'", namespace, tree)
+	}
+
+	var target *rbacv1alpha1.Folder
+	for i, folder := range folderTree.Spec.Folders {
+		if folder.Name == membership.Spec.FolderName {
+			target = &folderTree.Spec.Folders[i]
+		}
+		if slices.Contains(folder.Namespaces, namespace) && folder.Name != membership.Spec.FolderName {
+			return rbacv1alpha1.MembershipPhaseRejected,
+				fmt.Sprintf("namespace '%s' is already assigned to folder '%s'", namespace, folder.Name)
+		}
+	}
+
+	if target == nil {
+		return rbacv1alpha1.MembershipPhaseRejected,
+			fmt.Sprintf("folder '%s' not found in FolderTree '%s'", membership.Spec.FolderName, folderTree.Name)
+	}
+
+	if !target.AcceptMemberships {
+		return rbacv1alpha1.MembershipPhasePending,
+			fmt.Sprintf("folder '%s' does not accept memberships", target.Name)
+	}
+
+	return rbacv1alpha1.MembershipPhaseApproved,
+		fmt.Sprintf("namespace '%s' is a member of folder '%s'", namespace, target.Name)
+}
+
+// EvaluatePatch decides whether a FolderTreePatch can be merged into the desired FolderTree and
+// returns the patched tree if so
+func EvaluatePatch(patch *rbacv1alpha1.FolderTreePatch, desiredTree *rbacv1alpha1.FolderTree,
+	otherTrees map[string]string) (rbacv1alpha1.PatchPhase, string, *rbacv1alpha1.FolderTree) {
+
+	var target *rbacv1alpha1.Folder
+	assigned := make(map[string]string)
+	for i, folder := range desiredTree.Spec.Folders {
+		if folder.Name == patch.Spec.FolderName {
+			target = &desiredTree.Spec.Folders[i]
+		}
+		for _, namespace := range folder.Namespaces {
+			assigned[namespace] = folder.Name
+		}
+	}
+
+	if target == nil {
+		return rbacv1alpha1.PatchPhaseRejected,
+			fmt.Sprintf("folder '%s' not found in FolderTree '%s'", patch.Spec.FolderName, desiredTree.Name), nil
+	}
+
+	if !target.AcceptsPatchesFrom(patch.Namespace) {
+		return rbacv1alpha1.PatchPhasePending,
+			fmt.Sprintf("folder '%s' does not accept patches from namespace '%s'", target.Name, patch.Namespace), nil
+	}
+
+	if group, ok := NamespaceGroupAbove(&desiredTree.Spec, target.Name); ok && len(patch.Spec.RoleBindingTemplates) > 0 {
+		return rbacv1alpha1.PatchPhaseRejected,
+			fmt.Sprintf("folder '%s' is below namespace group '%s', whose namespaces would receive the role binding templates of the patch",
+				target.Name, group), nil
+	}
+
+	for _, namespace := range patch.Spec.Namespaces {
+		if tree, ok := otherTrees[namespace]; ok {
+			return rbacv1alpha1.PatchPhaseRejected,
+				fmt.Sprintf("namespace '%s' is already assigned in FolderTree '%s'", namespace, tree), nil
+		}
+		if folder, ok := assigned[namespace]; ok {
+			return rbacv1alpha1.PatchPhaseRejected,
+				fmt.Sprintf("namespace '%s' is already assigned to folder '%s'", namespace, folder), nil
+		}
+	}
+
+	patched, err := ApplyFolderTreePatch(desiredTree, patch)
+	if err != nil {
+		return rbacv1alpha1.PatchPhaseRejected, err.Error(), nil
+	}
+	return rbacv1alpha1.PatchPhaseApproved,
+		fmt.Sprintf("%d role binding templates and %d namespaces are part of folder '%s'",
+			len(patch.Spec.RoleBindingTemplates), len(patch.Spec.Namespaces), target.Name), patched
+}
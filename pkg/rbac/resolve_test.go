@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("TreeResolver", func() {
+	var folderTree *rbacv1alpha1.FolderTree
+
+	newMembership := func(namespace, name, folder string) *rbacv1alpha1.FolderMembership {
+		return &rbacv1alpha1.FolderMembership{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "resolve-tree", FolderName: folder},
+		}
+	}
+
+	newPatch := func(folder string, namespaces ...string) *rbacv1alpha1.FolderTreePatch {
+		return &rbacv1alpha1.FolderTreePatch{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "team-ns"},
+			Spec: rbacv1alpha1.FolderTreePatchSpec{
+				TreeName:   "resolve-tree",
+				FolderName: folder,
+				Namespaces: namespaces,
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name:     "team-admin",
+					Subjects: []rbacv1.Subject{{Kind: "Group", Name: "team", APIGroup: "rbac.authorization.k8s.io"}},
+					RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin", APIGroup: "rbac.authorization.k8s.io"},
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "resolve-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:              "resolve-tree-folder",
+						Namespaces:        []string{"folder-ns"},
+						AcceptMemberships: true,
+						PatchNamespaces:   []string{"team-ns"},
+					},
+					{Name: "other-folder", Namespaces: []string{"assigned-ns"}},
+				},
+			},
+		}
+	})
+
+	Context("When evaluating memberships", func() {
+		It("should reject memberships that conflict with existing assignments", func() {
+			primary := map[string]string{"assigned-ns": "join", "foreign-ns": "join", "free-ns": "join"}
+			otherTrees := map[string]string{"foreign-ns": "other-tree"}
+
+			phase, message := EvaluateMembership(newMembership("assigned-ns", "join", "resolve-tree-folder"), folderTree, otherTrees, primary)
+			Expect(phase).To(Equal(rbacv1alpha1.MembershipPhaseRejected))
+			Expect(message).To(ContainSubstring("other-folder"))
+
+			phase, message = EvaluateMembership(newMembership("foreign-ns", "join", "resolve-tree-folder"), folderTree, otherTrees, primary)
+			Expect(phase).To(Equal(rbacv1alpha1.MembershipPhaseRejected))
+			Expect(message).To(ContainSubstring("other-tree"))
+
+			phase, _ = EvaluateMembership(newMembership("free-ns", "join", "missing-folder"), folderTree, otherTrees, primary)
+			Expect(phase).To(Equal(rbacv1alpha1.MembershipPhaseRejected))
+
+			phase, _ = EvaluateMembership(newMembership("free-ns", "join", "resolve-tree-folder"), folderTree, otherTrees, primary)
+			Expect(phase).To(Equal(rbacv1alpha1.MembershipPhaseApproved))
+		})
+
+		It("should only honor the oldest membership per namespace", func() {
+			older := newMembership("shared-ns", "b-join", "resolve-tree-folder")
+			older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			newer := newMembership("shared-ns", "a-join", "resolve-tree-folder")
+			newer.CreationTimestamp = metav1.Now()
+
+			primary := PrimaryMemberships([]rbacv1alpha1.FolderMembership{*newer, *older})
+			Expect(primary).To(HaveKeyWithValue("shared-ns", "b-join"))
+
+			phase, message := EvaluateMembership(newer, folderTree, nil, primary)
+			Expect(phase).To(Equal(rbacv1alpha1.MembershipPhaseRejected))
+			Expect(message).To(ContainSubstring("b-join"))
+		})
+	})
+
+	Context("When evaluating patches", func() {
+		It("should reject patches that conflict with existing assignments", func() {
+			otherTrees := map[string]string{"foreign-ns": "other-tree"}
+
+			phase, message, patched := EvaluatePatch(newPatch("resolve-tree-folder", "assigned-ns"), folderTree, otherTrees)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseRejected))
+			Expect(message).To(ContainSubstring("other-folder"))
+			Expect(patched).To(BeNil())
+
+			phase, message, _ = EvaluatePatch(newPatch("resolve-tree-folder", "foreign-ns"), folderTree, otherTrees)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseRejected))
+			Expect(message).To(ContainSubstring("other-tree"))
+
+			phase, _, _ = EvaluatePatch(newPatch("missing-folder"), folderTree, otherTrees)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseRejected))
+
+			phase, _, _ = EvaluatePatch(newPatch("other-folder"), folderTree, otherTrees)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhasePending))
+
+			phase, _, patched = EvaluatePatch(newPatch("resolve-tree-folder", "free-ns"), folderTree, otherTrees)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseApproved))
+			Expect(patched.Spec.Folders[0].Namespaces).To(ConsistOf("folder-ns", "free-ns"))
+			Expect(folderTree.Spec.Folders[0].Namespaces).To(ConsistOf("folder-ns"))
+		})
+
+		It("should reject templates below a namespace group but not namespaces", func() {
+			folderTree.Spec.Tree = &rbacv1alpha1.TreeNode{
+				Name:       "group",
+				Subfolders: []rbacv1alpha1.TreeNode{{Name: "resolve-tree-folder"}, {Name: "other-folder"}},
+			}
+			folderTree.Spec.Folders = append(folderTree.Spec.Folders, rbacv1alpha1.Folder{
+				Name: "group", InheritNamespaces: true, Namespaces: []string{"group-ns"},
+			})
+
+			phase, message, patched := EvaluatePatch(newPatch("resolve-tree-folder"), folderTree, nil)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseRejected))
+			Expect(message).To(ContainSubstring("below namespace group 'group'"))
+			Expect(patched).To(BeNil())
+
+			namespacesOnly := newPatch("resolve-tree-folder", "free-ns")
+			namespacesOnly.Spec.RoleBindingTemplates = nil
+			phase, _, _ = EvaluatePatch(namespacesOnly, folderTree, nil)
+			Expect(phase).To(Equal(rbacv1alpha1.PatchPhaseApproved))
+
+			group, ok := NamespaceGroupAbove(&folderTree.Spec, "group")
+			Expect(ok).To(BeFalse())
+			Expect(group).To(BeEmpty())
+		})
+	})
+
+	Context("When resolving a FolderTree", func() {
+		It("should decide the phases against the resolved spec rather than the recorded status", func() {
+			pending := newPatch("other-folder")
+			pending.Status.Phase = rbacv1alpha1.PatchPhasePending
+			resolver := &TreeResolver{
+				Memberships: []rbacv1alpha1.FolderMembership{*newMembership("member-ns", "join", "other-folder")},
+				Patches:     []rbacv1alpha1.FolderTreePatch{*pending},
+			}
+
+			resolved := resolver.Resolve(folderTree)
+			Expect(resolved.Spec.Folders[1].Namespaces).To(ConsistOf("assigned-ns"))
+			Expect(resolved.Spec.Folders[1].RoleBindingTemplates).To(BeEmpty())
+
+			// An update accepting the membership and the patch approves both
+			accepting := folderTree.DeepCopy()
+			accepting.Spec.Folders[1].AcceptMemberships = true
+			accepting.Spec.Folders[1].PatchNamespaces = []string{"team-ns"}
+			resolved = resolver.Resolve(accepting)
+			Expect(resolved.Spec.Folders[1].Namespaces).To(ConsistOf("assigned-ns", "member-ns"))
+			Expect(resolved.Spec.Folders[1].RoleBindingTemplates).To(HaveLen(1))
+			Expect(accepting.Spec.Folders[1].Namespaces).To(ConsistOf("assigned-ns"))
+		})
+
+		It("should leave namespaces listed by other FolderTrees to them and record every outcome", func() {
+			other := rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{Folders: []rbacv1alpha1.Folder{
+					{Name: "other-tree-folder", Namespaces: []string{"foreign-ns"}},
+				}},
+			}
+			membershipPhases := map[string]rbacv1alpha1.MembershipPhase{}
+			patchPhases := map[string]rbacv1alpha1.PatchPhase{}
+			resolver := &TreeResolver{
+				FolderTrees: []rbacv1alpha1.FolderTree{*folderTree, other},
+				Memberships: []rbacv1alpha1.FolderMembership{*newMembership("foreign-ns", "join", "resolve-tree-folder")},
+				Patches:     []rbacv1alpha1.FolderTreePatch{*newPatch("resolve-tree-folder", "patched-ns")},
+				RecordMembership: func(membership *rbacv1alpha1.FolderMembership, phase rbacv1alpha1.MembershipPhase, _ string) {
+					membershipPhases[membership.Namespace] = phase
+				},
+				RecordPatch: func(patch *rbacv1alpha1.FolderTreePatch, phase rbacv1alpha1.PatchPhase, _ string) {
+					patchPhases[patch.Namespace] = phase
+				},
+			}
+
+			resolved := resolver.Resolve(folderTree)
+			Expect(resolved.Spec.Folders[0].Namespaces).To(ConsistOf("folder-ns", "patched-ns"))
+			Expect(membershipPhases).To(HaveKeyWithValue("foreign-ns", rbacv1alpha1.MembershipPhaseRejected))
+			Expect(patchPhases).To(HaveKeyWithValue("team-ns", rbacv1alpha1.PatchPhaseApproved))
+		})
+
+		It("should reject patches that make a valid spec invalid", func() {
+			resolver := &TreeResolver{
+				Patches: []rbacv1alpha1.FolderTreePatch{*newPatch("resolve-tree-folder", "patched-ns")},
+				ValidateSpec: func(spec *rbacv1alpha1.FolderTreeSpec) error {
+					for _, folder := range spec.Folders {
+						if len(folder.RoleBindingTemplates) > 0 {
+							return errors.New("templates are not allowed")
+						}
+					}
+					return nil
+				},
+			}
+
+			resolved := resolver.Resolve(folderTree)
+			Expect(resolved.Spec.Folders[0].Namespaces).To(ConsistOf("folder-ns"))
+			Expect(resolved.Spec.Folders[0].RoleBindingTemplates).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// ResourceQuotaTemplateLabel names the resource quota template a ResourceQuota was generated from
+const ResourceQuotaTemplateLabel = "foldertree.rbac.kubevirt.io/resource-quota-template"
+
+// ResourceQuotaBuilder creates the ResourceQuotas of resource quota templates
+type ResourceQuotaBuilder struct {
+	FolderTree *rbacv1alpha1.FolderTree
+	Scheme     *runtime.Scheme
+
+	// ExcludedNamespaces never receive ResourceQuotas, in addition to the FolderTree's spec.excludedNamespaces
+	ExcludedNamespaces []string
+
+	// DisableOwnerReferences leaves the owner reference to the FolderTree off the ResourceQuotas
+	DisableOwnerReferences bool
+}
+
+// BuildResourceQuotaFromTemplate creates the ResourceQuota of a template in the given namespace.
+// The digest of its spec is recorded in the AppliedDigestAnnotation for drift detection.
+func (b *ResourceQuotaBuilder) BuildResourceQuotaFromTemplate(namespace string, template rbacv1alpha1.ResourceQuotaTemplate) (*corev1.ResourceQuota, error) {
+	digest, err := resourceQuotaDigest(template.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("template '%s': %v", template.Name, err)
+	}
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("foldertree-%s-%s", b.FolderTree.Name, template.Name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":     "foldertree-controller",
+				"foldertree.rbac.kubevirt.io/tree": b.FolderTree.Name,
+				ResourceQuotaTemplateLabel:         template.Name,
+			},
+			Annotations: map[string]string{
+				AppliedDigestAnnotation: digest,
+			},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+
+	if b.Scheme != nil && !b.DisableOwnerReferences {
+		if err := controllerutil.SetControllerReference(b.FolderTree, resourceQuota, b.Scheme); err != nil {
+			return nil, err
+		}
+	}
+	return resourceQuota, nil
+}
+
+// resourceQuotaDigest returns a short hash of a ResourceQuota spec
+func resourceQuotaDigest(spec corev1.ResourceQuotaSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:digestHashLength], nil
+}
+
+// CalculateDesiredResourceQuotas returns the ResourceQuotas that should exist for a FolderTree,
+// keyed by "<namespace>/<name>". Resource quota templates are inherited like role binding
+// templates, so a quota set on a folder is the default for its whole subtree unless overridden.
+func CalculateDesiredResourceQuotas(folderTree *rbacv1alpha1.FolderTree, builder *ResourceQuotaBuilder) (map[string]*corev1.ResourceQuota, error) {
+	templates := folderTemplates[rbacv1alpha1.ResourceQuotaTemplate]{
+		of: func(folder rbacv1alpha1.Folder) []rbacv1alpha1.ResourceQuotaTemplate {
+			return folder.ResourceQuotaTemplates
+		},
+		name: func(template rbacv1alpha1.ResourceQuotaTemplate) string { return template.Name },
+		propagate: func(template rbacv1alpha1.ResourceQuotaTemplate) bool {
+			return defaultPropagate(folderTree, template.Propagate)
+		},
+	}
+
+	desired := make(map[string]*corev1.ResourceQuota)
+	err := walkFolderTemplates(folderTree, templates, excludedNamespaceSet(folderTree, builder.ExcludedNamespaces),
+		func(folder, namespace string, template rbacv1alpha1.ResourceQuotaTemplate) error {
+			resourceQuota, err := builder.BuildResourceQuotaFromTemplate(namespace, template)
+			if err != nil {
+				return fmt.Errorf("failed to build ResourceQuota for folder '%s': %v", folder, err)
+			}
+			desired[fmt.Sprintf("%s/%s", namespace, resourceQuota.Name)] = resourceQuota
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+// ResourceQuotaOperation is an operation needed to synchronize a ResourceQuota with its template
+type ResourceQuotaOperation struct {
+	Type     OperationType
+	Existing *corev1.ResourceQuota // nil for create operations
+	Desired  *corev1.ResourceQuota // nil for delete operations
+}
+
+// String returns a human-readable description of the operation
+func (op *ResourceQuotaOperation) String() string {
+	target := op.Desired
+	if target == nil {
+		target = op.Existing
+	}
+	return fmt.Sprintf("%s ResourceQuota '%s' in namespace '%s'", op.Type, target.Name, target.Namespace)
+}
+
+// ResourceQuotaDiff holds the operations needed to synchronize the ResourceQuotas of a FolderTree
+type ResourceQuotaDiff struct {
+	Operations []ResourceQuotaOperation
+
+	// Drift holds the updates that would revert out-of-band edits but were left out because the
+	// FolderTree's drift policy is Warn or Ignore
+	Drift []ResourceQuotaOperation
+}
+
+// AnalyzeResourceQuotaDiff compares the ResourceQuotas labeled with the FolderTree with the desired
+// ones. Like for RoleBindings, a ResourceQuota whose spec differs while the digest it was written
+// with matches the desired one was edited out-of-band, and is only reverted under the Enforce drift policy.
+func AnalyzeResourceQuotaDiff(ctx context.Context, c client.Reader, folderTree *rbacv1alpha1.FolderTree, builder *ResourceQuotaBuilder) (*ResourceQuotaDiff, error) {
+	desired, err := CalculateDesiredResourceQuotas(folderTree, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceQuotaList := &corev1.ResourceQuotaList{}
+	if err := c.List(ctx, resourceQuotaList, client.MatchingLabels{
+		"foldertree.rbac.kubevirt.io/tree": folderTree.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list ResourceQuotas: %v", err)
+	}
+
+	enforce := folderTree.Spec.DriftPolicy == "" || folderTree.Spec.DriftPolicy == rbacv1alpha1.DriftPolicyEnforce
+	diff := &ResourceQuotaDiff{}
+	existing := make(map[string]bool)
+	for i := range resourceQuotaList.Items {
+		resourceQuota := &resourceQuotaList.Items[i]
+		key := fmt.Sprintf("%s/%s", resourceQuota.Namespace, resourceQuota.Name)
+		existing[key] = true
+		desiredQuota, ok := desired[key]
+		if !ok {
+			diff.Operations = append(diff.Operations, ResourceQuotaOperation{Type: OperationDelete, Existing: resourceQuota})
+			continue
+		}
+		if !resourceQuotaNeedsUpdate(resourceQuota, desiredQuota, builder.Scheme != nil) {
+			continue
+		}
+		operation := ResourceQuotaOperation{Type: OperationUpdate, Existing: resourceQuota, Desired: desiredQuota}
+		if !enforce && resourceQuota.Annotations[AppliedDigestAnnotation] == desiredQuota.Annotations[AppliedDigestAnnotation] {
+			diff.Drift = append(diff.Drift, operation)
+			continue
+		}
+		diff.Operations = append(diff.Operations, operation)
+	}
+	for key, desiredQuota := range desired {
+		if !existing[key] {
+			diff.Operations = append(diff.Operations, ResourceQuotaOperation{Type: OperationCreate, Desired: desiredQuota})
+		}
+	}
+	return diff, nil
+}
+
+// resourceQuotaNeedsUpdate reports whether an existing ResourceQuota differs from the desired one in
+// its spec, its managed labels and annotations or, when compareOwnership is set, its FolderTree owner references
+func resourceQuotaNeedsUpdate(existing, desired *corev1.ResourceQuota, compareOwnership bool) bool {
+	if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return true
+	}
+	for key, value := range desired.Labels {
+		if IsManagedKey(key) && existing.Labels[key] != value {
+			return true
+		}
+	}
+	if existing.Annotations[AppliedDigestAnnotation] != desired.Annotations[AppliedDigestAnnotation] {
+		return true
+	}
+	return compareOwnership && !ownerReferencesEqual(existing.OwnerReferences, desired.OwnerReferences)
+}
+
+// MergeResourceQuotaOwnerReferences returns the owner references of an existing ResourceQuota with
+// its FolderTree owner references replaced by those of the desired one, see MergeOwnerReferences
+func MergeResourceQuotaOwnerReferences(existing, desired *corev1.ResourceQuota) []metav1.OwnerReference {
+	return mergeOwnerReferences(existing.OwnerReferences, desired.OwnerReferences)
+}
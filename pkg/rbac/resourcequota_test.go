@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("ResourceQuota Templates", func() {
+	var folderTree *rbacv1alpha1.FolderTree
+
+	quota := func(name, cpu string) rbacv1alpha1.ResourceQuotaTemplate {
+		return rbacv1alpha1.ResourceQuotaTemplate{
+			Name: name,
+			Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse(cpu),
+			}},
+			Propagate: ptr.To(true),
+		}
+	}
+
+	BeforeEach(func() {
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota-tree"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "root",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "batch", Subfolders: []rbacv1alpha1.TreeNode{{Name: "nightly"}}}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{Name: "root", Namespaces: []string{"root-ns"}, ResourceQuotaTemplates: []rbacv1alpha1.ResourceQuotaTemplate{quota("compute", "4")}},
+					{Name: "batch", Namespaces: []string{"batch-ns"}, ResourceQuotaTemplates: []rbacv1alpha1.ResourceQuotaTemplate{quota("compute", "32")}},
+					{Name: "nightly", Namespaces: []string{"nightly-ns"}},
+				},
+			},
+		}
+	})
+
+	cpuOf := func(resourceQuota *corev1.ResourceQuota) string {
+		hard := resourceQuota.Spec.Hard[corev1.ResourceRequestsCPU]
+		return hard.String()
+	}
+
+	It("should let child folders override the quotas of their parents", func() {
+		desired, err := CalculateDesiredResourceQuotas(folderTree, &ResourceQuotaBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired).To(HaveLen(3))
+		Expect(cpuOf(desired["root-ns/foldertree-quota-tree-compute"])).To(Equal("4"))
+		Expect(cpuOf(desired["batch-ns/foldertree-quota-tree-compute"])).To(Equal("32"))
+		Expect(cpuOf(desired["nightly-ns/foldertree-quota-tree-compute"])).To(Equal("32"))
+		Expect(desired["nightly-ns/foldertree-quota-tree-compute"].Labels).To(HaveKeyWithValue(ResourceQuotaTemplateLabel, "compute"))
+	})
+
+	Context("with existing ResourceQuotas", func() {
+		var (
+			builder *ResourceQuotaBuilder
+			edited  *corev1.ResourceQuota
+			scheme  *runtime.Scheme
+		)
+
+		BeforeEach(func() {
+			builder = &ResourceQuotaBuilder{FolderTree: folderTree}
+			desired, err := CalculateDesiredResourceQuotas(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			edited = desired["root-ns/foldertree-quota-tree-compute"].DeepCopy()
+			edited.Spec.Hard[corev1.ResourceRequestsCPU] = resource.MustParse("100")
+			scheme = runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		})
+
+		analyze := func() *ResourceQuotaDiff {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(edited).Build()
+			diff, err := AnalyzeResourceQuotaDiff(context.Background(), fakeClient, folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			return diff
+		}
+
+		It("should revert out-of-band edits under the Enforce drift policy", func() {
+			diff := analyze()
+			Expect(diff.Drift).To(BeEmpty())
+			Expect(diff.Operations).To(ContainElement(HaveField("Type", OperationUpdate)))
+		})
+
+		It("should report out-of-band edits without reverting them under the Warn drift policy", func() {
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyWarn
+			diff := analyze()
+			Expect(diff.Drift).To(HaveLen(1))
+			Expect(diff.Operations).NotTo(ContainElement(HaveField("Type", OperationUpdate)))
+		})
+
+		It("should update ResourceQuotas when the template changes, whatever the drift policy", func() {
+			folderTree.Spec.DriftPolicy = rbacv1alpha1.DriftPolicyIgnore
+			folderTree.Spec.Folders[0].ResourceQuotaTemplates[0] = quota("compute", "8")
+			diff := analyze()
+			Expect(diff.Drift).To(BeEmpty())
+			Expect(diff.Operations).To(ContainElement(HaveField("Type", OperationUpdate)))
+		})
+	})
+})
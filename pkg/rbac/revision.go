@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// RevisionName returns the name of the FolderTreeRevision of a FolderTree ("<tree>-<revision>")
+func RevisionName(treeName string, revision int64) string {
+	return fmt.Sprintf("%s-%d", treeName, revision)
+}
+
+// RevisionSpec decodes the FolderTree spec held by a FolderTreeRevision
+func RevisionSpec(revision *rbacv1alpha1.FolderTreeRevision) (rbacv1alpha1.FolderTreeSpec, error) {
+	var spec rbacv1alpha1.FolderTreeSpec
+	if err := json.Unmarshal(revision.Data.Raw, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode the spec of FolderTreeRevision '%s': %v", revision.Name, err)
+	}
+	return spec, nil
+}
+
+// ListRevisions reads the FolderTreeRevisions of a FolderTree, oldest first
+func ListRevisions(ctx context.Context, c client.Reader, treeName string) ([]rbacv1alpha1.FolderTreeRevision, error) {
+	var revisionList rbacv1alpha1.FolderTreeRevisionList
+	if err := c.List(ctx, &revisionList, client.MatchingLabels{"foldertree.rbac.kubevirt.io/tree": treeName}); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTreeRevisions: %v", err)
+	}
+	revisions := revisionList.Items
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// GetRollbackTarget reads the FolderTreeRevision named by the value of the
+// rbacv1alpha1.RollbackToAnnotation of a FolderTree and returns the spec it holds
+func GetRollbackTarget(ctx context.Context, c client.Reader, treeName, target string) (rbacv1alpha1.FolderTreeSpec, error) {
+	number, err := strconv.ParseInt(target, 10, 64)
+	if err != nil || number < 1 {
+		return rbacv1alpha1.FolderTreeSpec{}, fmt.Errorf("rollback target '%s' is not a revision number", target)
+	}
+
+	revision := &rbacv1alpha1.FolderTreeRevision{}
+	err = c.Get(ctx, types.NamespacedName{Name: RevisionName(treeName, number)}, revision)
+	if apierrors.IsNotFound(err) || (err == nil && revision.Labels["foldertree.rbac.kubevirt.io/tree"] != treeName) {
+		return rbacv1alpha1.FolderTreeSpec{}, fmt.Errorf("revision %d of FolderTree '%s' does not exist", number, treeName)
+	}
+	if err != nil {
+		return rbacv1alpha1.FolderTreeSpec{}, fmt.Errorf("failed to get revision %d of FolderTree '%s': %v", number, treeName, err)
+	}
+	return RevisionSpec(revision)
+}
+
+// RevisionSpecHash returns the hash of a spec restored by a rollback, which the webhook records in the
+// rbacv1alpha1.RollbackSpecHashAnnotation and the controller compares before restoring the spec
+func RevisionSpecHash(spec rbacv1alpha1.FolderTreeSpec) (string, error) {
+	return SpecHash(&rbacv1alpha1.FolderTree{Spec: spec})
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+const (
+	// FolderPathKey is the label and annotation holding the "."-separated folder path of the namespace
+	// a RoleBinding was generated for, e.g. "root.prod.web". The label is omitted when the path is
+	// not a valid label value (longer than 63 characters); the annotation is always set.
+	FolderPathKey = "foldertree.rbac.kubevirt.io/path"
+
+	// InheritedLabel is "true" when the RoleBinding's template comes from an ancestor folder or is global
+	InheritedLabel = "foldertree.rbac.kubevirt.io/inherited"
+
+	// SourceFolderAnnotation names the folder that defines the RoleBinding's template (unset for global templates)
+	SourceFolderAnnotation = "foldertree.rbac.kubevirt.io/source-folder"
+
+	// DescriptionAnnotation carries the description of the RoleBinding's template (unset when it has none)
+	DescriptionAnnotation = "foldertree.rbac.kubevirt.io/description"
+
+	// ReferenceAnnotation carries the reference of the RoleBinding's template, e.g. a ticket (unset when it has none)
+	ReferenceAnnotation = "foldertree.rbac.kubevirt.io/reference"
+
+	// managedKeyPrefix prefixes the labels and annotations the controller owns on its RoleBindings
+	managedKeyPrefix = "foldertree.rbac.kubevirt.io/"
+)
+
+// IsManagedKey reports whether a label or annotation key is owned by the controller. Other labels
+// and annotations, including app.kubernetes.io/managed-by after creation, may be changed by other tools.
+func IsManagedKey(key string) bool {
+	return strings.HasPrefix(key, managedKeyPrefix)
+}
+
+// RoleBindingBuilder provides shared logic for creating RoleBindings
+// Used by both the controller (for actual creation) and webhook (for dry-run validation)
+type RoleBindingBuilder struct {
+	FolderTree *rbacv1alpha1.FolderTree
+	Scheme     *runtime.Scheme
+
+	// ExcludedNamespaces never receive RoleBindings, in addition to the FolderTree's
+	// spec.excludedNamespaces (e.g. set from a controller flag)
+	ExcludedNamespaces []string
+
+	// DisableOwnerReferences leaves the owner reference to the FolderTree off the RoleBindings, for
+	// GitOps tools that prune objects with cross-scope owner references. RoleBindings are then
+	// tracked by their labels only and must be cleaned up by the controller.
+	DisableOwnerReferences bool
+
+	// SubjectMappings resolves the subjectRefs of role binding templates. Without it, references
+	// bind no subjects.
+	SubjectMappings SubjectMappings
+
+	// ServiceAccounts resolves the serviceAccountSelectors of role binding templates. Without it,
+	// selectors bind no ServiceAccounts.
+	ServiceAccounts ServiceAccounts
+
+	// ExtraMetadata is set on every RoleBinding on top of the controller's own labels and annotations
+	ExtraMetadata ExtraMetadata
+
+	// Cache reuses RoleBindings calculated for the same FolderTree and inputs, e.g. by the webhook
+	// at admission. Without it, every calculation starts over. It is not used with an Override.
+	Cache *DesiredStateCache
+
+	// Override customizes the RoleBindings built for templates (see Builder). Nil builds the
+	// default RoleBindings.
+	Override Builder
+}
+
+// RoleBindingName returns the name of the RoleBindings a FolderTree creates for a role binding template
+func RoleBindingName(folderTreeName, templateName string) string {
+	return fmt.Sprintf("foldertree-%s-%s", folderTreeName, templateName)
+}
+
+// NameFor implements Builder, naming RoleBindings "foldertree-<tree>-<template>"
+func (rb *RoleBindingBuilder) NameFor(templateName string) string {
+	return RoleBindingName(rb.FolderTree.Name, templateName)
+}
+
+// Labels implements Builder, labeling RoleBindings with their FolderTree and template
+func (rb *RoleBindingBuilder) Labels(_, _ string, roleBindingTemplate rbacv1alpha1.RoleBindingTemplate) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by":                      "foldertree-controller",
+		"foldertree.rbac.kubevirt.io/tree":                  rb.FolderTree.Name,
+		"foldertree.rbac.kubevirt.io/role-binding-template": roleBindingTemplate.Name,
+	}
+}
+
+// BuildRoleBinding creates a RoleBinding for the given namespace and role binding template.
+// folderName is the folder the namespace belongs to and is used to expand subject template variables.
+// The name and labels come from the Override when set. This is the shared logic used by both
+// controller and webhook
+func (rb *RoleBindingBuilder) BuildRoleBinding(folderName, namespace string, roleBindingTemplate rbacv1alpha1.RoleBindingTemplate) (*rbacv1.RoleBinding, error) {
+	// Create RoleBinding name
+	roleBindingName := rb.builder().NameFor(roleBindingTemplate.Name)
+
+	// Expand subject template variables such as {{ .folder.name }}
+	subjects, err := ExpandSubjects(roleBindingTemplate.Subjects, rb.FolderTree.Name, folderName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("template '%s': %v", roleBindingTemplate.Name, err)
+	}
+
+	// Subjects of referenced SubjectMappings are bound as they are
+	subjects = rb.SubjectMappings.appendSubjects(subjects, roleBindingTemplate.SubjectRefs)
+
+	// Bind ServiceAccounts of the target namespace when requested
+	if roleBindingTemplate.SubjectNamespaceMode == rbacv1alpha1.SubjectNamespaceModeTarget {
+		for i := range subjects {
+			if subjects[i].Kind == rbacv1.ServiceAccountKind {
+				subjects[i].Namespace = namespace
+			}
+		}
+	}
+
+	// Selected ServiceAccounts are bound after the namespace of the other subjects is set, since
+	// they already are in the right namespace
+	if selector := roleBindingTemplate.ServiceAccountSelector; selector != nil {
+		selectedNamespace := ""
+		if roleBindingTemplate.SubjectNamespaceMode == rbacv1alpha1.SubjectNamespaceModeTarget {
+			selectedNamespace = namespace
+		}
+		subjects, err = rb.ServiceAccounts.appendSubjects(subjects, selector, selectedNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("template '%s': %v", roleBindingTemplate.Name, err)
+		}
+	}
+
+	// Subject mappings, selected ServiceAccounts and expanded templates may repeat a subject
+	subjects = uniqueSubjects(subjects)
+
+	// Define the RoleBinding
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      roleBindingName,
+			Namespace: namespace,
+			Labels:    rb.builder().Labels(folderName, namespace, roleBindingTemplate),
+		},
+		Subjects: subjects,
+		RoleRef:  roleBindingTemplate.RoleRef,
+	}
+	roleBinding.Annotations = map[string]string{
+		AppliedDigestAnnotation: BindingDigest(roleBinding),
+	}
+	if roleBindingTemplate.Description != "" {
+		roleBinding.Annotations[DescriptionAnnotation] = roleBindingTemplate.Description
+	}
+	if roleBindingTemplate.Reference != "" {
+		roleBinding.Annotations[ReferenceAnnotation] = roleBindingTemplate.Reference
+	}
+
+	rb.ExtraMetadata.stamp(roleBinding)
+	if err := rb.setOwnerReference(roleBinding); err != nil {
+		return nil, err
+	}
+
+	return roleBinding, nil
+}
+
+// setOwnerReference sets the FolderTree as controller of a RoleBinding (only for controller, webhook skips this)
+func (rb *RoleBindingBuilder) setOwnerReference(roleBinding *rbacv1.RoleBinding) error {
+	if rb.Scheme != nil && !rb.DisableOwnerReferences {
+		return controllerutil.SetControllerReference(rb.FolderTree, roleBinding, rb.Scheme)
+	}
+	return nil
+}
+
+// managesOwnership reports whether the builder decides the FolderTree owner references of
+// RoleBindings, which requires a scheme
+func (rb *RoleBindingBuilder) managesOwnership() bool {
+	return rb.Scheme != nil
+}
+
+// isFolderTreeOwnerReference reports whether an owner reference points to a FolderTree
+func isFolderTreeOwnerReference(ref metav1.OwnerReference) bool {
+	return ref.Kind == "FolderTree" && strings.HasPrefix(ref.APIVersion, rbacv1alpha1.GroupVersion.Group+"/")
+}
+
+// ownershipEqual reports whether two RoleBindings have the same FolderTree owner references
+func ownershipEqual(existing, desired *rbacv1.RoleBinding) bool {
+	return ownerReferencesEqual(existing.OwnerReferences, desired.OwnerReferences)
+}
+
+// ownerReferencesEqual reports whether two lists of owner references hold the same FolderTree owner references
+func ownerReferencesEqual(existing, desired []metav1.OwnerReference) bool {
+	existingRefs := slices.DeleteFunc(slices.Clone(existing), func(ref metav1.OwnerReference) bool {
+		return !isFolderTreeOwnerReference(ref)
+	})
+	desiredRefs := slices.DeleteFunc(slices.Clone(desired), func(ref metav1.OwnerReference) bool {
+		return !isFolderTreeOwnerReference(ref)
+	})
+	return slices.EqualFunc(existingRefs, desiredRefs, func(a, b metav1.OwnerReference) bool {
+		return a.UID == b.UID && ptr.Deref(a.Controller, false) == ptr.Deref(b.Controller, false)
+	})
+}
+
+// MergeOwnerReferences returns the owner references of an existing RoleBinding with its FolderTree
+// owner references replaced by those of the desired RoleBinding. References to FolderTrees are
+// replaced regardless of which FolderTree they point to, so RoleBindings left behind by a deleted
+// FolderTree of the same name are adopted. Owner references of other kinds are kept.
+func MergeOwnerReferences(existing, desired *rbacv1.RoleBinding) []metav1.OwnerReference {
+	return mergeOwnerReferences(existing.OwnerReferences, desired.OwnerReferences)
+}
+
+// mergeOwnerReferences replaces the FolderTree owner references of existing by those of desired
+func mergeOwnerReferences(existing, desired []metav1.OwnerReference) []metav1.OwnerReference {
+	var refs []metav1.OwnerReference
+	for _, ref := range existing {
+		if !isFolderTreeOwnerReference(ref) {
+			refs = append(refs, ref)
+		}
+	}
+	for _, ref := range desired {
+		if isFolderTreeOwnerReference(ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// ForServerSideApply returns the fields of a desired RoleBinding the controller applies with
+// server-side apply: subjects, roleRef, owner references and the managed labels and annotations,
+// including those of extra. Fields left out stay with whichever field manager set them.
+func ForServerSideApply(desired *rbacv1.RoleBinding, extra ExtraMetadata) *rbacv1.RoleBinding {
+	applied := &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            desired.Name,
+			Namespace:       desired.Namespace,
+			Labels:          make(map[string]string),
+			Annotations:     make(map[string]string),
+			OwnerReferences: desired.OwnerReferences,
+		},
+		Subjects: desired.Subjects,
+		RoleRef:  desired.RoleRef,
+	}
+	for key, value := range desired.Labels {
+		if IsManagedKey(key) || extra.manages(key, false) {
+			applied.Labels[key] = value
+		}
+	}
+	for key, value := range desired.Annotations {
+		if IsManagedKey(key) || extra.manages(key, true) {
+			applied.Annotations[key] = value
+		}
+	}
+	return applied
+}
+
+// StampFolderPath labels and annotates a RoleBinding with the folder path of its namespace and whether
+// its template was inherited. folderPath runs from the tree root to the namespace's folder and
+// sourceFolder is the folder defining the template (empty for global templates).
+func (rb *RoleBindingBuilder) StampFolderPath(roleBinding *rbacv1.RoleBinding, folderPath []string, sourceFolder string) {
+	path := strings.Join(folderPath, ".")
+	inherited := sourceFolder != folderPath[len(folderPath)-1]
+
+	if len(validation.IsValidLabelValue(path)) == 0 {
+		roleBinding.Labels[FolderPathKey] = path
+	}
+	roleBinding.Labels[InheritedLabel] = strconv.FormatBool(inherited)
+
+	if roleBinding.Annotations == nil {
+		roleBinding.Annotations = make(map[string]string)
+	}
+	roleBinding.Annotations[FolderPathKey] = path
+	if sourceFolder != "" {
+		roleBinding.Annotations[SourceFolderAnnotation] = sourceFolder
+	}
+}
+
+// GenerateRandomRoleBindingName creates a unique name for dry-run validation
+// This ensures webhook dry-run attempts don't conflict with real RoleBindings
+func GenerateRandomRoleBindingName(folderTreeName, permissionName string) string {
+	// Use a timestamp with nanoseconds to ensure uniqueness even for rapid calls
+	return fmt.Sprintf("dryrun-foldertree-%s-%s-%d", folderTreeName, permissionName, metav1.Now().UnixNano())
+}
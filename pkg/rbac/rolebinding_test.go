@@ -0,0 +1,345 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+func TestRBAC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RBAC Package Suite")
+}
+
+var _ = Describe("RoleBindingBuilder", func() {
+	var (
+		folderTree *rbacv1alpha1.FolderTree
+		builder    *RoleBindingBuilder
+		scheme     *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-tree",
+			},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "test-folder",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name: "test-permission",
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "User",
+										Name:     "test-user",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("BuildRoleBinding", func() {
+		var testRoleBindingTemplate rbacv1alpha1.RoleBindingTemplate
+
+		BeforeEach(func() {
+			testRoleBindingTemplate = rbacv1alpha1.RoleBindingTemplate{
+				Name: "test-permission",
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "User",
+						Name:     "test-user",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+		})
+
+		It("should create RoleBinding with owner reference when scheme provided", func() {
+			builder = &RoleBindingBuilder{
+				FolderTree: folderTree,
+				Scheme:     scheme,
+			}
+
+			roleBinding, err := builder.BuildRoleBinding("test-folder", "test-namespace", testRoleBindingTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding).NotTo(BeNil())
+
+			// Verify basic properties
+			Expect(roleBinding.Name).To(Equal("foldertree-test-tree-test-permission"))
+			Expect(roleBinding.Namespace).To(Equal("test-namespace"))
+			Expect(roleBinding.Subjects).To(HaveLen(1))
+			Expect(roleBinding.Subjects[0].Name).To(Equal("test-user"))
+			Expect(roleBinding.RoleRef.Name).To(Equal("admin"))
+
+			// Verify labels
+			Expect(roleBinding.Labels["app.kubernetes.io/managed-by"]).To(Equal("foldertree-controller"))
+			Expect(roleBinding.Labels["foldertree.rbac.kubevirt.io/tree"]).To(Equal("test-tree"))
+			Expect(roleBinding.Labels["foldertree.rbac.kubevirt.io/role-binding-template"]).To(Equal("test-permission"))
+
+			// Verify owner reference is set
+			Expect(roleBinding.OwnerReferences).To(HaveLen(1))
+			Expect(roleBinding.OwnerReferences[0].Name).To(Equal("test-tree"))
+		})
+
+		It("should create RoleBinding without owner reference when scheme not provided", func() {
+			builder = &RoleBindingBuilder{
+				FolderTree: folderTree,
+				Scheme:     nil, // No scheme - for webhook usage
+			}
+
+			roleBinding, err := builder.BuildRoleBinding("test-folder", "test-namespace", testRoleBindingTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding).NotTo(BeNil())
+
+			// Verify basic properties
+			Expect(roleBinding.Name).To(Equal("foldertree-test-tree-test-permission"))
+			Expect(roleBinding.Namespace).To(Equal("test-namespace"))
+
+			// Verify no owner reference is set (for webhook dry-run)
+			Expect(roleBinding.OwnerReferences).To(BeEmpty())
+		})
+
+		It("should annotate the description and reference of the template", func() {
+			builder = &RoleBindingBuilder{
+				FolderTree: folderTree,
+			}
+
+			roleBinding, err := builder.BuildRoleBinding("test-folder", "test-namespace", testRoleBindingTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Annotations).NotTo(HaveKey(DescriptionAnnotation))
+			Expect(roleBinding.Annotations).NotTo(HaveKey(ReferenceAnnotation))
+
+			testRoleBindingTemplate.Description = "On-call engineers need admin access to restart workloads"
+			testRoleBindingTemplate.Reference = "https://tickets.example.com/SEC-1234"
+			roleBinding, err = builder.BuildRoleBinding("test-folder", "test-namespace", testRoleBindingTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Annotations).To(HaveKeyWithValue(DescriptionAnnotation, "On-call engineers need admin access to restart workloads"))
+			Expect(roleBinding.Annotations).To(HaveKeyWithValue(ReferenceAnnotation, "https://tickets.example.com/SEC-1234"))
+		})
+	})
+
+	Context("Subject templates", func() {
+		BeforeEach(func() {
+			builder = &RoleBindingBuilder{
+				FolderTree: folderTree,
+			}
+		})
+
+		It("should expand folder, namespace and tree variables in subjects", func() {
+			template := rbacv1alpha1.RoleBindingTemplate{
+				Name: "team-admins",
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:     "Group",
+						Name:     "team-{{ .folder.name }}-admins",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+					{
+						Kind:      "ServiceAccount",
+						Name:      "{{ .tree.name }}-deployer",
+						Namespace: "{{ .namespace }}",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "admin",
+				},
+			}
+
+			roleBinding, err := builder.BuildRoleBinding("payments", "payments-prod", template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Subjects[0].Name).To(Equal("team-payments-admins"))
+			Expect(roleBinding.Subjects[1].Name).To(Equal("test-tree-deployer"))
+			Expect(roleBinding.Subjects[1].Namespace).To(Equal("payments-prod"))
+
+			// The template itself must not be modified
+			Expect(template.Subjects[0].Name).To(Equal("team-{{ .folder.name }}-admins"))
+		})
+
+		It("should bind a subject once when expanded subjects repeat it", func() {
+			template := rbacv1alpha1.RoleBindingTemplate{
+				Name: "team-admins",
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "team-{{ .folder.name }}-admins", APIGroup: "rbac.authorization.k8s.io"},
+					{Kind: "Group", Name: "team-payments-admins", APIGroup: "rbac.authorization.k8s.io"},
+				},
+				RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "admin"},
+			}
+
+			roleBinding, err := builder.BuildRoleBinding("payments", "payments-prod", template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{
+				{Kind: "Group", Name: "team-payments-admins", APIGroup: "rbac.authorization.k8s.io"},
+			}))
+
+			// A RoleBinding repeating the subject out-of-band has the same digest
+			repeated := roleBinding.DeepCopy()
+			repeated.Subjects = append(repeated.Subjects, repeated.Subjects[0])
+			Expect(BindingDigest(repeated)).To(Equal(roleBinding.Annotations[AppliedDigestAnnotation]))
+			Expect(subjectsEqual(repeated.Subjects, roleBinding.Subjects)).To(BeTrue())
+		})
+
+		It("should compare subjects as sets, ignoring repeats on either side", func() {
+			alice := rbacv1.Subject{Kind: "User", Name: "alice", APIGroup: "rbac.authorization.k8s.io"}
+			bob := rbacv1.Subject{Kind: "User", Name: "bob", APIGroup: "rbac.authorization.k8s.io"}
+			Expect(subjectsEqual([]rbacv1.Subject{alice, bob}, []rbacv1.Subject{bob, alice})).To(BeTrue())
+			Expect(subjectsEqual([]rbacv1.Subject{alice, alice, bob}, []rbacv1.Subject{bob, bob, alice})).To(BeTrue())
+			Expect(subjectsEqual([]rbacv1.Subject{alice, alice}, []rbacv1.Subject{alice, bob})).To(BeFalse())
+			Expect(subjectsEqual([]rbacv1.Subject{alice, bob}, []rbacv1.Subject{alice, alice})).To(BeFalse())
+			Expect(subjectsEqual(nil, []rbacv1.Subject{alice})).To(BeFalse())
+		})
+
+		It("should reject unknown variables and invalid templates", func() {
+			Expect(ValidateSubjectTemplate("team-{{ .folder.name }}")).To(Succeed())
+			Expect(ValidateSubjectTemplate("team-{{ .folder.owner }}")).NotTo(Succeed())
+			Expect(ValidateSubjectTemplate("team-{{ .cluster }}")).NotTo(Succeed())
+			Expect(ValidateSubjectTemplate("team-{{ .folder.name")).NotTo(Succeed())
+		})
+
+		It("should expand inherited templates with the folder of the namespace", func() {
+			folderTree.Spec = rbacv1alpha1.FolderTreeSpec{
+				Tree: &rbacv1alpha1.TreeNode{
+					Name:       "root",
+					Subfolders: []rbacv1alpha1.TreeNode{{Name: "frontend"}, {Name: "backend"}},
+				},
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "root",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{
+								Name:      "team-admins",
+								Propagate: boolPtr(true),
+								Subjects: []rbacv1.Subject{
+									{
+										Kind:     "Group",
+										Name:     "team-{{ .folder.name }}-admins",
+										APIGroup: "rbac.authorization.k8s.io",
+									},
+								},
+								RoleRef: rbacv1.RoleRef{
+									APIGroup: "rbac.authorization.k8s.io",
+									Kind:     "ClusterRole",
+									Name:     "admin",
+								},
+							},
+						},
+					},
+					{Name: "frontend", Namespaces: []string{"frontend-ns"}},
+					{Name: "backend", Namespaces: []string{"backend-ns"}},
+				},
+			}
+
+			desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desired.RoleBindings["frontend-ns/foldertree-test-tree-team-admins"].RoleBinding.Subjects[0].Name).To(Equal("team-frontend-admins"))
+			Expect(desired.RoleBindings["backend-ns/foldertree-test-tree-team-admins"].RoleBinding.Subjects[0].Name).To(Equal("team-backend-admins"))
+		})
+	})
+
+	Context("GenerateRandomRoleBindingName", func() {
+		It("should generate names with expected format", func() {
+			name := GenerateRandomRoleBindingName("tree1", "perm1")
+
+			// Should contain expected components
+			Expect(name).To(ContainSubstring("dryrun-foldertree-tree1-perm1"))
+			Expect(name).To(MatchRegexp(`dryrun-foldertree-tree1-perm1-\d+`))
+		})
+	})
+
+	Context("Subject namespace mode", func() {
+		var template rbacv1alpha1.RoleBindingTemplate
+
+		BeforeEach(func() {
+			builder = &RoleBindingBuilder{
+				FolderTree: folderTree,
+			}
+			template = rbacv1alpha1.RoleBindingTemplate{
+				Name: "deployers",
+				Subjects: []rbacv1.Subject{
+					{
+						Kind: "ServiceAccount",
+						Name: "deployer",
+					},
+					{
+						Kind:     "Group",
+						Name:     "deployers",
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     "edit",
+				},
+				SubjectNamespaceMode: rbacv1alpha1.SubjectNamespaceModeTarget,
+			}
+		})
+
+		It("should stamp the target namespace into ServiceAccount subjects", func() {
+			first, err := builder.BuildRoleBinding("apps", "apps-dev", template)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := builder.BuildRoleBinding("apps", "apps-prod", template)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Subjects[0].Namespace).To(Equal("apps-dev"))
+			Expect(second.Subjects[0].Namespace).To(Equal("apps-prod"))
+			Expect(first.Subjects[1].Namespace).To(BeEmpty())
+
+			// The template itself must not be modified
+			Expect(template.Subjects[0].Namespace).To(BeEmpty())
+		})
+
+		It("should keep the subject namespace in Fixed mode", func() {
+			template.SubjectNamespaceMode = rbacv1alpha1.SubjectNamespaceModeFixed
+			template.Subjects[0].Namespace = "ci"
+
+			roleBinding, err := builder.BuildRoleBinding("apps", "apps-dev", template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Subjects[0].Namespace).To(Equal("ci"))
+		})
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// ServiceAccounts holds the metadata of the ServiceAccounts that serviceAccountSelectors select
+// from, sorted by namespace and name
+type ServiceAccounts []metav1.PartialObjectMetadata
+
+// UsesServiceAccountSelectors reports whether any role binding template of a FolderTree has a
+// serviceAccountSelector
+func UsesServiceAccountSelectors(folderTree *rbacv1alpha1.FolderTree) bool {
+	hasSelector := func(template rbacv1alpha1.RoleBindingTemplate) bool {
+		return template.ServiceAccountSelector != nil
+	}
+	if slices.ContainsFunc(folderTree.Spec.GlobalRoleBindingTemplates, hasSelector) {
+		return true
+	}
+	return slices.ContainsFunc(folderTree.Spec.Folders, func(folder rbacv1alpha1.Folder) bool {
+		return slices.ContainsFunc(folder.RoleBindingTemplates, hasSelector)
+	})
+}
+
+// ListServiceAccounts reads the metadata of the ServiceAccounts in the given namespaces when the
+// FolderTree has serviceAccountSelectors, and returns nil otherwise
+func ListServiceAccounts(ctx context.Context, c client.Reader, folderTree *rbacv1alpha1.FolderTree, namespaces []string) (ServiceAccounts, error) {
+	if !UsesServiceAccountSelectors(folderTree) {
+		return nil, nil
+	}
+
+	var serviceAccounts ServiceAccounts
+	for _, namespace := range namespaces {
+		serviceAccountList := &metav1.PartialObjectMetadataList{}
+		serviceAccountList.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccountList"))
+		if err := c.List(ctx, serviceAccountList, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list ServiceAccounts in namespace '%s': %v", namespace, err)
+		}
+		serviceAccounts = append(serviceAccounts, serviceAccountList.Items...)
+	}
+	slices.SortFunc(serviceAccounts, func(a, b metav1.PartialObjectMetadata) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return serviceAccounts, nil
+}
+
+// appendSubjects appends a subject for every ServiceAccount matching the selector to the given
+// subjects, leaving out duplicates. A non-empty namespace limits the ServiceAccounts to that namespace.
+func (s ServiceAccounts) appendSubjects(subjects []rbacv1.Subject, selector *metav1.LabelSelector, namespace string) ([]rbacv1.Subject, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid serviceAccountSelector: %v", err)
+	}
+	for _, serviceAccount := range s {
+		if namespace != "" && serviceAccount.Namespace != namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(serviceAccount.Labels)) {
+			continue
+		}
+		subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount.Name, Namespace: serviceAccount.Namespace}
+		if !slices.Contains(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects, nil
+}
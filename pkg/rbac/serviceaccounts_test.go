@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("ServiceAccounts", func() {
+	oncall := rbacv1.Subject{Kind: "User", Name: "oncall", APIGroup: "rbac.authorization.k8s.io"}
+	view := rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+	ciSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"ci": "true"}}
+
+	serviceAccount := func(namespace, name string, labels map[string]string) metav1.PartialObjectMetadata {
+		return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+	}
+	serviceAccounts := ServiceAccounts{
+		serviceAccount("web-dev", "builder", map[string]string{"ci": "true"}),
+		serviceAccount("web-dev", "default", nil),
+		serviceAccount("web-prod", "builder", map[string]string{"ci": "true"}),
+	}
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			Folders: []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"web-dev", "web-prod"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+					{Name: "ci", Subjects: []rbacv1.Subject{oncall}, ServiceAccountSelector: ciSelector, RoleRef: view},
+					{Name: "ci-local", ServiceAccountSelector: ciSelector, SubjectNamespaceMode: rbacv1alpha1.SubjectNamespaceModeTarget, RoleRef: view},
+				},
+			}},
+		},
+	}
+
+	It("should bind the selected ServiceAccounts of all namespaces after the other subjects", func() {
+		Expect(UsesServiceAccountSelectors(folderTree)).To(BeTrue())
+
+		builder := &RoleBindingBuilder{FolderTree: folderTree, ServiceAccounts: serviceAccounts}
+		roleBinding, err := builder.BuildRoleBinding("web", "web-prod", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{
+			oncall,
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "web-dev"},
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "web-prod"},
+		}))
+	})
+
+	It("should only bind the selected ServiceAccounts of the RoleBinding's namespace in Target mode", func() {
+		builder := &RoleBindingBuilder{FolderTree: folderTree, ServiceAccounts: serviceAccounts}
+		roleBinding, err := builder.BuildRoleBinding("web", "web-dev", folderTree.Spec.Folders[0].RoleBindingTemplates[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "web-dev"},
+		}))
+	})
+
+	It("should not fill in default subjects for templates with a serviceAccountSelector", func() {
+		defaulted := folderTree.DeepCopy()
+		defaulted.Spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{Subjects: []rbacv1.Subject{oncall}}
+		Expect(WithDefaults(defaulted).Spec.Folders[0].RoleBindingTemplates[1].Subjects).To(BeEmpty())
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("SimulateAccess", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		folderTree *rbacv1alpha1.FolderTree
+		objects    []client.Object
+	)
+
+	simulate := func(subject, verb, resource, namespace string) []SimulatedGrant {
+		parsed, err := ParseSubject(subject)
+		Expect(err).NotTo(HaveOccurred())
+		query, err := ParseResourceQuery(verb, resource)
+		Expect(err).NotTo(HaveOccurred())
+		query.Namespace = namespace
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, folderTree)...).Build()
+		grants, err := SimulateAccess(ctx, fakeClient, parsed, query)
+		Expect(err).NotTo(HaveOccurred())
+		return grants
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+
+		objects = []client.Object{
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "view"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+				},
+			},
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "edit"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+					{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+				},
+			},
+		}
+
+		group := func(name string) []rbacv1.Subject {
+			return []rbacv1.Subject{{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}}
+		}
+		clusterRole := func(name string) rbacv1.RoleRef {
+			return rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: name}
+		}
+
+		rootTree := rbacv1alpha1.TreeNode{Name: "platform"}
+		rootTree.Subfolders = []rbacv1alpha1.TreeNode{{Name: "web"}}
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rootTree,
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name: "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{Name: "sre", Subjects: group("sre-team"), RoleRef: clusterRole("edit"), Propagate: boolPtr(true)},
+							{Name: "everyone", Subjects: group("system:authenticated"), RoleRef: clusterRole("view"), Propagate: boolPtr(true)},
+						},
+						Namespaces: []string{"platform-ns"},
+					},
+					{
+						Name: "web",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+							{Name: "web-viewers", Subjects: group("web-team"), RoleRef: clusterRole("view")},
+						},
+						Namespaces: []string{"web-ns"},
+					},
+				},
+			},
+		}
+	})
+
+	It("should report every namespace where a group is allowed, with its folder path", func() {
+		grants := simulate("group:sre-team", "delete", "deployments.apps", "")
+
+		Expect(grants).To(HaveLen(2))
+		Expect(grants[0].Namespace).To(Equal("platform-ns"))
+		Expect(grants[0].Path).To(Equal([]string{"platform"}))
+		Expect(grants[1].Namespace).To(Equal("web-ns"))
+		Expect(grants[1].Path).To(Equal([]string{"platform", "web"}))
+		Expect(grants[1].From).To(Equal("platform"))
+		Expect(grants[1].Template).To(Equal("sre"))
+		Expect(grants[1].Rule.Resources).To(Equal([]string{"*"}))
+	})
+
+	It("should only report templates whose role rules allow the action", func() {
+		Expect(simulate("group:web-team", "get", "pods", "")).To(HaveLen(1))
+		Expect(simulate("group:web-team", "delete", "pods", "")).To(BeEmpty())
+		Expect(simulate("group:web-team", "get", "pods", "platform-ns")).To(BeEmpty())
+	})
+
+	It("should match groups users and service accounts implicitly belong to", func() {
+		grants := simulate("user:alice", "list", "pods", "web-ns")
+
+		Expect(grants).To(HaveLen(1))
+		Expect(grants[0].Template).To(Equal("everyone"))
+		Expect(grants[0].Via.Name).To(Equal("system:authenticated"))
+
+		Expect(simulate("serviceaccount:web-ns/builder", "list", "pods", "")).To(HaveLen(2))
+		Expect(simulate("group:other-team", "list", "pods", "")).To(BeEmpty())
+	})
+
+	It("should ignore missing roles", func() {
+		folderTree.Spec.Folders[0].RoleBindingTemplates[0].RoleRef.Name = "missing-role"
+		Expect(simulate("group:sre-team", "get", "pods", "")).To(BeEmpty())
+	})
+})
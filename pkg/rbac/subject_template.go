@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Subject names and namespaces may contain Go template variables that are expanded per generated RoleBinding:
+//   - {{ .tree.name }}   the FolderTree name
+//   - {{ .folder.name }} the folder the namespace belongs to (not the folder defining an inherited template)
+//   - {{ .namespace }}   the namespace the RoleBinding is created in
+//
+// This lets a single propagating template produce per-folder or per-namespace group bindings.
+
+// subjectTemplateData returns the variables available to subject templates
+func subjectTemplateData(treeName, folderName, namespace string) map[string]any {
+	return map[string]any{
+		"tree":      map[string]string{"name": treeName},
+		"folder":    map[string]string{"name": folderName},
+		"namespace": namespace,
+	}
+}
+
+// ExpandSubjects returns a copy of the subjects with template variables in their name and namespace expanded
+func ExpandSubjects(subjects []rbacv1.Subject, treeName, folderName, namespace string) ([]rbacv1.Subject, error) {
+	if subjects == nil {
+		return nil, nil
+	}
+
+	data := subjectTemplateData(treeName, folderName, namespace)
+	expanded := make([]rbacv1.Subject, len(subjects))
+	for i, subject := range subjects {
+		name, err := expandSubjectField(subject.Name, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand name of subject '%s': %v", subject.Name, err)
+		}
+		subjectNamespace, err := expandSubjectField(subject.Namespace, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand namespace of subject '%s': %v", subject.Name, err)
+		}
+
+		expanded[i] = subject
+		expanded[i].Name = name
+		expanded[i].Namespace = subjectNamespace
+	}
+
+	return expanded, nil
+}
+
+// ValidateSubjectTemplate checks that a subject field is a valid template using only known variables
+func ValidateSubjectTemplate(value string) error {
+	_, err := expandSubjectField(value, subjectTemplateData("tree", "folder", "namespace"))
+	return err
+}
+
+// IsSubjectTemplate reports whether a subject field contains template variables
+func IsSubjectTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+// expandSubjectField expands a single subject field; values without template actions are returned unchanged
+func expandSubjectField(value string, data map[string]any) (string, error) {
+	if !IsSubjectTemplate(value) {
+		return value, nil
+	}
+
+	tmpl, err := template.New("subject").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
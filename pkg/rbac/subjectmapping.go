@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// SubjectMappings maps the names of SubjectMappings to their subjects
+type SubjectMappings map[string][]rbacv1.Subject
+
+// NewSubjectMappings indexes SubjectMappings by name
+func NewSubjectMappings(mappings []rbacv1alpha1.SubjectMapping) SubjectMappings {
+	subjectMappings := make(SubjectMappings, len(mappings))
+	for _, mapping := range mappings {
+		subjectMappings[mapping.Name] = mapping.Spec.Subjects
+	}
+	return subjectMappings
+}
+
+// ListSubjectMappings reads all SubjectMappings of the cluster
+func ListSubjectMappings(ctx context.Context, c client.Reader) (SubjectMappings, error) {
+	var mappingList rbacv1alpha1.SubjectMappingList
+	if err := c.List(ctx, &mappingList); err != nil {
+		return nil, fmt.Errorf("failed to list SubjectMappings: %v", err)
+	}
+	return NewSubjectMappings(mappingList.Items), nil
+}
+
+// SubjectRefs returns the sorted names of the SubjectMappings referenced by the role binding
+// templates of a FolderTree
+func SubjectRefs(folderTree *rbacv1alpha1.FolderTree) []string {
+	var refs []string
+	for _, template := range folderTree.Spec.GlobalRoleBindingTemplates {
+		refs = append(refs, template.SubjectRefs...)
+	}
+	for _, folder := range folderTree.Spec.Folders {
+		for _, template := range folder.RoleBindingTemplates {
+			refs = append(refs, template.SubjectRefs...)
+		}
+	}
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
+// Missing returns the sorted names of the SubjectMappings a FolderTree references that do not exist
+func (m SubjectMappings) Missing(folderTree *rbacv1alpha1.FolderTree) []string {
+	var missing []string
+	for _, ref := range SubjectRefs(folderTree) {
+		if _, ok := m[ref]; !ok {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+// appendSubjects appends the subjects of the referenced SubjectMappings to the given subjects,
+// leaving out duplicates. References to missing SubjectMappings add no subjects.
+func (m SubjectMappings) appendSubjects(subjects []rbacv1.Subject, refs []string) []rbacv1.Subject {
+	for _, ref := range refs {
+		for _, subject := range m[ref] {
+			if !slices.Contains(subjects, subject) {
+				subjects = append(subjects, subject)
+			}
+		}
+	}
+	return subjects
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("SubjectMappings", func() {
+	devs := rbacv1.Subject{Kind: "Group", Name: "idp:devs", APIGroup: "rbac.authorization.k8s.io"}
+	oncall := rbacv1.Subject{Kind: "User", Name: "oncall", APIGroup: "rbac.authorization.k8s.io"}
+	viewers := rbacv1.Subject{Kind: "Group", Name: "{{ .folder.name }}-viewers", APIGroup: "rbac.authorization.k8s.io"}
+	view := rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"}
+
+	mappings := NewSubjectMappings([]rbacv1alpha1.SubjectMapping{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}, Spec: rbacv1alpha1.SubjectMappingSpec{Subjects: []rbacv1.Subject{devs, oncall}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}, Spec: rbacv1alpha1.SubjectMappingSpec{Subjects: []rbacv1.Subject{oncall}}},
+	})
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			GlobalRoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+				{Name: "global", SubjectRefs: []string{"team-c"}, RoleRef: view},
+			},
+			Folders: []rbacv1alpha1.Folder{{
+				Name: "web",
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+					{Name: "mapped", Subjects: []rbacv1.Subject{viewers}, SubjectRefs: []string{"team-a", "team-b"}, RoleRef: view},
+				},
+			}},
+		},
+	}
+
+	It("should list the SubjectMappings referenced by templates and the missing ones", func() {
+		Expect(SubjectRefs(folderTree)).To(Equal([]string{"team-a", "team-b", "team-c"}))
+		Expect(mappings.Missing(folderTree)).To(Equal([]string{"team-c"}))
+	})
+
+	It("should append mapped subjects without expanding or duplicating them", func() {
+		builder := &RoleBindingBuilder{FolderTree: folderTree, SubjectMappings: mappings}
+		roleBinding, err := builder.BuildRoleBinding("web", "web-prod", folderTree.Spec.Folders[0].RoleBindingTemplates[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBinding.Subjects).To(Equal([]rbacv1.Subject{
+			{Kind: "Group", Name: "web-viewers", APIGroup: "rbac.authorization.k8s.io"}, devs, oncall,
+		}))
+
+		By("binding no subjects for missing SubjectMappings")
+		roleBinding, err = builder.BuildRoleBinding("web", "web-prod", folderTree.Spec.GlobalRoleBindingTemplates[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleBinding.Subjects).To(BeEmpty())
+	})
+
+	It("should not fill in default subjects for templates with subjectRefs", func() {
+		defaulted := folderTree.DeepCopy()
+		defaulted.Spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{Subjects: []rbacv1.Subject{oncall}}
+		Expect(WithDefaults(defaulted).Spec.GlobalRoleBindingTemplates[0].Subjects).To(BeEmpty())
+	})
+})
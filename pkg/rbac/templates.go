@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"math"
+	"slices"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// folderTemplates describes a kind of template carried by folders besides role binding templates,
+// e.g. network policy templates, for walkFolderTemplates
+type folderTemplates[T any] struct {
+	// of returns the templates a folder defines
+	of func(folder rbacv1alpha1.Folder) []T
+
+	// name returns the name of a template, which subfolders use to override inherited templates
+	name func(template T) string
+
+	// propagate reports whether a template is inherited by subfolders
+	propagate func(template T) bool
+}
+
+// walkFolderTemplates calls visit for every namespace of the FolderTree and every template in
+// effect for it: the templates of the namespace's folder and the propagating templates of its
+// ancestors, with templates of a folder overriding inherited templates of the same name.
+// Excluded namespaces are skipped.
+func walkFolderTemplates[T any](folderTree *rbacv1alpha1.FolderTree, templates folderTemplates[T], excluded map[string]bool,
+	visit func(folder, namespace string, template T) error) error {
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range folderTree.Spec.Folders {
+		folderMap[folder.Name] = folder
+	}
+
+	visitFolder := func(folder rbacv1alpha1.Folder, inherited []T) ([]T, error) {
+		own := templates.of(folder)
+		var inEffect []T
+		for _, template := range inherited {
+			if !slices.ContainsFunc(own, func(t T) bool { return templates.name(t) == templates.name(template) }) {
+				inEffect = append(inEffect, template)
+			}
+		}
+		toInherit := append([]T(nil), inEffect...)
+		for _, template := range own {
+			inEffect = append(inEffect, template)
+			if templates.propagate(template) {
+				toInherit = append(toInherit, template)
+			}
+		}
+
+		for _, namespace := range folder.Namespaces {
+			if excluded[namespace] {
+				continue
+			}
+			for _, template := range inEffect {
+				if err := visit(folder.Name, namespace, template); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return toInherit, nil
+	}
+
+	var walk func(node rbacv1alpha1.TreeNode, inherited []T) error
+	walk = func(node rbacv1alpha1.TreeNode, inherited []T) error {
+		if folder, exists := folderMap[node.Name]; exists {
+			var err error
+			if inherited, err = visitFolder(folder, inherited); err != nil {
+				return err
+			}
+		}
+		for _, subfolder := range node.Subfolders {
+			if err := walk(subfolder, inherited); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	roots := folderTree.Spec.Roots()
+	for _, root := range roots {
+		if err := walk(root, nil); err != nil {
+			return err
+		}
+	}
+	for _, folder := range folderTree.Spec.Folders {
+		if !isInTree(folder.Name, roots) {
+			if _, err := visitFolder(folder, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// excludedNamespaceSet returns the namespaces excluded by the FolderTree and by the given list
+func excludedNamespaceSet(folderTree *rbacv1alpha1.FolderTree, excludedNamespaces []string) map[string]bool {
+	excluded := make(map[string]bool)
+	for _, namespace := range folderTree.Spec.ExcludedNamespaces {
+		excluded[namespace] = true
+	}
+	for _, namespace := range excludedNamespaces {
+		excluded[namespace] = true
+	}
+	return excluded
+}
+
+// defaultPropagate resolves an unset propagate field of a template against spec.defaults.propagate
+func defaultPropagate(folderTree *rbacv1alpha1.FolderTree, propagate *bool) bool {
+	if propagate != nil {
+		return *propagate
+	}
+	defaults := folderTree.Spec.Defaults
+	return defaults != nil && defaults.Propagate != nil && *defaults.Propagate
+}
+
+// PropagationDepth returns how many levels of descendants inherit a role binding template resolved
+// against spec.defaults: 0 when it does not propagate, spec.propagateDepth when set, and math.MaxInt
+// when all descendants inherit it
+func PropagationDepth(template rbacv1alpha1.RoleBindingTemplate) int {
+	switch {
+	case template.Propagate != nil && !*template.Propagate:
+		return 0
+	case template.PropagateDepth != nil:
+		return int(*template.PropagateDepth)
+	case template.Propagate != nil:
+		return math.MaxInt
+	default:
+		return 0
+	}
+}
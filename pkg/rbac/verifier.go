@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SubjectVerifier confirms that the User and Group subjects of RoleBindings exist in the identity
+// provider of the cluster. Kubernetes accepts RoleBindings for any name, so a typo in a subject
+// silently grants nothing.
+type SubjectVerifier interface {
+	// Unresolved returns the subjects the identity provider does not know, out of the given User
+	// and Group subjects
+	Unresolved(ctx context.Context, subjects []rbacv1.Subject) ([]rbacv1.Subject, error)
+}
+
+// NoopSubjectVerifier resolves every subject. It is used when no identity provider is configured.
+type NoopSubjectVerifier struct{}
+
+// Unresolved implements SubjectVerifier
+func (NoopSubjectVerifier) Unresolved(context.Context, []rbacv1.Subject) ([]rbacv1.Subject, error) {
+	return nil, nil
+}
+
+// OpenShiftUserListGVK is the list kind of OpenShift's user.openshift.io Users, read as unstructured
+// like OpenShiftGroupListGVK
+var OpenShiftUserListGVK = schema.GroupVersionKind{Group: "user.openshift.io", Version: "v1", Kind: "UserList"}
+
+// OpenShiftSubjectVerifier resolves User and Group subjects against the user.openshift.io Users and
+// Groups of an OpenShift cluster. Users only exist once they have logged in.
+type OpenShiftSubjectVerifier struct {
+	Reader client.Reader
+}
+
+// Unresolved implements SubjectVerifier
+func (v *OpenShiftSubjectVerifier) Unresolved(ctx context.Context, subjects []rbacv1.Subject) ([]rbacv1.Subject, error) {
+	groups, err := ListOpenShiftGroups(ctx, v.Reader)
+	if err != nil {
+		return nil, err
+	}
+	userList := &unstructured.UnstructuredList{}
+	userList.SetGroupVersionKind(OpenShiftUserListGVK)
+	if err := v.Reader.List(ctx, userList); err != nil {
+		return nil, fmt.Errorf("failed to list OpenShift Users: %v", err)
+	}
+	users := make(map[string]bool, len(userList.Items))
+	for _, user := range userList.Items {
+		users[user.GetName()] = true
+	}
+
+	var unresolved []rbacv1.Subject
+	for _, subject := range subjects {
+		if (subject.Kind == rbacv1.GroupKind && !groups[subject.Name]) || (subject.Kind == rbacv1.UserKind && !users[subject.Name]) {
+			unresolved = append(unresolved, subject)
+		}
+	}
+	return unresolved, nil
+}
+
+// HTTPSubjectVerifierTimeout bounds a request of HTTPSubjectVerifier when its client has no timeout
+const HTTPSubjectVerifierTimeout = 5 * time.Second
+
+// HTTPSubjectVerifier resolves subjects with an external endpoint, e.g. a small service in front of
+// the identity provider. It POSTs {"subjects": [{"kind": "User", "name": "alice"}, ...]} to URL and
+// expects a 200 response listing the subjects it does not know: {"unresolved": [...]}.
+type HTTPSubjectVerifier struct {
+	URL string
+
+	// Client sends the requests; defaults to a client with HTTPSubjectVerifierTimeout
+	Client *http.Client
+}
+
+// subjectVerification is the request and response body of HTTPSubjectVerifier
+type subjectVerification struct {
+	Subjects   []rbacv1.Subject `json:"subjects,omitempty"`
+	Unresolved []rbacv1.Subject `json:"unresolved,omitempty"`
+}
+
+// Unresolved implements SubjectVerifier
+func (v *HTTPSubjectVerifier) Unresolved(ctx context.Context, subjects []rbacv1.Subject) ([]rbacv1.Subject, error) {
+	body, err := json.Marshal(subjectVerification{Subjects: subjects})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := v.Client
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: HTTPSubjectVerifierTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify subjects: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to verify subjects: %s returned %s", v.URL, resp.Status)
+	}
+
+	var result subjectVerification
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode the subject verification response: %v", err)
+	}
+	return result.Unresolved, nil
+}
+
+// UnresolvedSubjects returns the sorted User and Group subjects of the desired RoleBindings that the
+// verifier cannot resolve, formatted as "<kind> <name>". Subjects are taken after expansion of
+// subject templates and subjectRefs, and each is verified once. Subjects assigned by Kubernetes
+// itself, such as system:authenticated or system:admin, are never verified.
+func UnresolvedSubjects(ctx context.Context, verifier SubjectVerifier, desired *DesiredRoleBindingSet) ([]string, error) {
+	var subjects []rbacv1.Subject
+	for _, desiredRB := range desired.RoleBindings {
+		for _, subject := range desiredRB.RoleBinding.Subjects {
+			if (subject.Kind != rbacv1.UserKind && subject.Kind != rbacv1.GroupKind) || strings.HasPrefix(subject.Name, "system:") {
+				continue
+			}
+			subjects = append(subjects, rbacv1.Subject{Kind: subject.Kind, Name: subject.Name, APIGroup: rbacv1.GroupName})
+		}
+	}
+	if len(subjects) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(subjects, func(a, b rbacv1.Subject) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	subjects = slices.Compact(subjects)
+
+	unresolved, err := verifier.Unresolved(ctx, subjects)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(unresolved))
+	for _, subject := range unresolved {
+		names = append(names, fmt.Sprintf("%s %s", subject.Kind, subject.Name))
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
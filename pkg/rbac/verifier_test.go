@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("Subject Verifiers", func() {
+	user := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: "User", Name: name, APIGroup: "rbac.authorization.k8s.io"}
+	}
+	group := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: "Group", Name: name, APIGroup: "rbac.authorization.k8s.io"}
+	}
+
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "tree"},
+		Spec: rbacv1alpha1.FolderTreeSpec{
+			Folders: []rbacv1alpha1.Folder{{
+				Name:       "web",
+				Namespaces: []string{"web-prod", "web-dev"},
+				RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{{
+					Name: "viewers",
+					Subjects: []rbacv1.Subject{
+						group("{{ .folder.name }}-viewers"), group("system:authenticated"), user("alice"), user("bob"),
+						{Kind: "ServiceAccount", Name: "deployer", Namespace: "ci"},
+					},
+					RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+				}},
+			}},
+		},
+	}
+
+	var desired *DesiredRoleBindingSet
+	BeforeEach(func() {
+		var err error
+		desired, err = CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{FolderTree: folderTree})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should verify expanded User and Group subjects once and skip system subjects", func() {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			Expect(r.Method).To(Equal(http.MethodPost))
+			var verification subjectVerification
+			Expect(json.NewDecoder(r.Body).Decode(&verification)).To(Succeed())
+			Expect(verification.Subjects).To(Equal([]rbacv1.Subject{group("web-viewers"), user("alice"), user("bob")}))
+			Expect(json.NewEncoder(w).Encode(subjectVerification{Unresolved: []rbacv1.Subject{user("bob"), group("web-viewers")}})).To(Succeed())
+		}))
+		defer server.Close()
+
+		unresolved, err := UnresolvedSubjects(context.Background(), &HTTPSubjectVerifier{URL: server.URL}, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unresolved).To(Equal([]string{"Group web-viewers", "User bob"}))
+		Expect(requests).To(Equal(1))
+	})
+
+	It("should fail when the endpoint does not answer with 200", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := UnresolvedSubjects(context.Background(), &HTTPSubjectVerifier{URL: server.URL}, desired)
+		Expect(err).To(MatchError(ContainSubstring("503 Service Unavailable")))
+	})
+
+	It("should resolve every subject with the no-op verifier", func() {
+		unresolved, err := UnresolvedSubjects(context.Background(), NoopSubjectVerifier{}, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unresolved).To(BeEmpty())
+	})
+
+	It("should resolve subjects against OpenShift Users and Groups", func() {
+		userKind := OpenShiftUserListGVK.GroupVersion().WithKind("User")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(OpenShiftGroupListGVK.GroupVersion().WithKind("Group"), meta.RESTScopeRoot)
+		mapper.Add(userKind, meta.RESTScopeRoot)
+		alice := &unstructured.Unstructured{}
+		alice.SetGroupVersionKind(userKind)
+		alice.SetName("alice")
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithRESTMapper(mapper).
+			WithObjects(openShiftGroup("web-viewers"), alice).
+			Build()
+
+		unresolved, err := UnresolvedSubjects(context.Background(), &OpenShiftSubjectVerifier{Reader: c}, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unresolved).To(Equal([]string{"User bob"}))
+	})
+})
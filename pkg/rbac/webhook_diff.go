@@ -30,6 +30,12 @@ type WebhookDiffAnalyzer struct {
 	OldFolderTree *rbacv1alpha1.FolderTree // Previous state (can be nil for create)
 	NewFolderTree *rbacv1alpha1.FolderTree // Desired state
 	Builder       *RoleBindingBuilder
+
+	// AppliedBindings is the status.appliedBindings digest map of the old FolderTree.
+	// When set, the changes from what the controller actually applied (e.g. after a partial
+	// reconcile) are returned in addition to the changes from the old spec. It never removes an
+	// operation of the old spec, so a tampered status cannot hide changes.
+	AppliedBindings map[string]string
 }
 
 // NewWebhookDiffAnalyzer creates a new webhook diff analyzer for comparing FolderTree states
@@ -64,8 +70,30 @@ func (w *WebhookDiffAnalyzer) AnalyzeFolderTreeDiff() ([]RoleBindingOperation, e
 		return nil, fmt.Errorf("failed to calculate new desired state: %v", err)
 	}
 
-	// Compare the two sets to determine operations
-	return w.compareDesiredStates(oldDesired.RoleBindings, newDesired.RoleBindings), nil
+	// The old spec is always diffed, so every change it implies is checked
+	operations := w.compareDesiredStates(oldDesired.RoleBindings, newDesired.RoleBindings)
+	if w.AppliedBindings == nil {
+		return operations, nil
+	}
+
+	// The applied bindings may only add checks, e.g. for RoleBindings left behind by a partial
+	// reconcile. The status subresource is not covered by the webhook and digests can be computed
+	// by anyone, so a RoleBinding listed there is never exempt from the checks of the old spec.
+	applied, err := w.compareAppliedState(w.AppliedBindings, newDesired.RoleBindings)
+	if err != nil {
+		return nil, err
+	}
+	checked := make(map[string]bool, len(operations))
+	for _, operation := range operations {
+		checked[string(operation.Type)+" "+operation.targetKey()] = true
+	}
+	for _, operation := range applied {
+		if !checked[string(operation.Type)+" "+operation.targetKey()] {
+			operations = append(operations, operation)
+		}
+	}
+
+	return operations, nil
 }
 
 // compareDesiredStates compares old and new desired states to generate operations
@@ -132,10 +160,82 @@ func (w *WebhookDiffAnalyzer) compareDesiredStates(oldDesired, newDesired map[st
 	return operations
 }
 
+// compareAppliedState compares the applied bindings digest map with the new desired state to generate operations.
+// Applied bindings only carry their identity and roleRef, so changes are detected by comparing digests.
+func (w *WebhookDiffAnalyzer) compareAppliedState(applied map[string]string, newDesired map[string]*DesiredRoleBinding) ([]RoleBindingOperation, error) {
+	var operations []RoleBindingOperation
+
+	// Find creates and updates
+	for key, newRB := range newDesired {
+		digest, exists := applied[key]
+		if !exists {
+			operations = append(operations, RoleBindingOperation{
+				Type:                OperationCreate,
+				Namespace:           newRB.Namespace,
+				RoleBindingTemplate: newRB.RoleBindingTemplate,
+				DesiredRoleBinding:  newRB.RoleBinding,
+			})
+			continue
+		}
+
+		if digest == BindingDigest(newRB.RoleBinding) {
+			continue
+		}
+
+		existing, err := AppliedRoleBinding(w.NewFolderTree.Name, key, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing.RoleRef != newRB.RoleBinding.RoleRef {
+			// RoleRef changed - need to delete and recreate
+			operations = append(operations, RoleBindingOperation{
+				Type:                OperationDelete,
+				Namespace:           newRB.Namespace,
+				RoleBindingTemplate: newRB.RoleBindingTemplate,
+				ExistingRoleBinding: existing,
+			})
+			operations = append(operations, RoleBindingOperation{
+				Type:                OperationCreate,
+				Namespace:           newRB.Namespace,
+				RoleBindingTemplate: newRB.RoleBindingTemplate,
+				DesiredRoleBinding:  newRB.RoleBinding,
+			})
+		} else {
+			operations = append(operations, RoleBindingOperation{
+				Type:                OperationUpdate,
+				Namespace:           newRB.Namespace,
+				RoleBindingTemplate: newRB.RoleBindingTemplate,
+				ExistingRoleBinding: existing,
+				DesiredRoleBinding:  newRB.RoleBinding,
+			})
+		}
+	}
+
+	// Find deletes
+	for key, digest := range applied {
+		if _, exists := newDesired[key]; exists {
+			continue
+		}
+
+		existing, err := AppliedRoleBinding(w.NewFolderTree.Name, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, RoleBindingOperation{
+			Type:                OperationDelete,
+			Namespace:           existing.Namespace,
+			ExistingRoleBinding: existing,
+		})
+	}
+
+	return operations, nil
+}
+
 // needsUpdate checks if a RoleBinding needs to be updated (reused from diff.go logic)
 func (w *WebhookDiffAnalyzer) needsUpdate(existing, desired *rbacv1.RoleBinding) bool {
 	// Compare subjects
-	if !w.subjectsEqual(existing.Subjects, desired.Subjects) {
+	if !subjectsEqual(existing.Subjects, desired.Subjects) {
 		return true
 	}
 
@@ -144,8 +244,11 @@ func (w *WebhookDiffAnalyzer) needsUpdate(existing, desired *rbacv1.RoleBinding)
 		return true
 	}
 
-	// Compare labels (only the ones we manage)
+	// Compare labels (only the ones we manage), so labels set by other tools are never fought over
 	for key, desiredValue := range desired.Labels {
+		if !IsManagedKey(key) {
+			continue
+		}
 		if existingValue, exists := existing.Labels[key]; !exists || existingValue != desiredValue {
 			return true
 		}
@@ -153,33 +256,3 @@ func (w *WebhookDiffAnalyzer) needsUpdate(existing, desired *rbacv1.RoleBinding)
 
 	return false
 }
-
-// subjectsEqual compares two slices of RBAC subjects for equality (reused from diff.go logic)
-func (w *WebhookDiffAnalyzer) subjectsEqual(a, b []rbacv1.Subject) bool {
-	if len(a) != len(b) {
-		return false
-	}
-
-	// Create maps for comparison (order shouldn't matter)
-	aMap := make(map[string]rbacv1.Subject)
-	bMap := make(map[string]rbacv1.Subject)
-
-	for _, subject := range a {
-		key := fmt.Sprintf("%s:%s:%s:%s", subject.Kind, subject.Name, subject.Namespace, subject.APIGroup)
-		aMap[key] = subject
-	}
-
-	for _, subject := range b {
-		key := fmt.Sprintf("%s:%s:%s:%s", subject.Kind, subject.Name, subject.Namespace, subject.APIGroup)
-		bMap[key] = subject
-	}
-
-	// Compare maps
-	for key, subjectA := range aMap {
-		if subjectB, exists := bMap[key]; !exists || subjectA != subjectB {
-			return false
-		}
-	}
-
-	return true
-}
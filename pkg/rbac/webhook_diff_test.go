@@ -482,4 +482,161 @@ var _ = Describe("WebhookDiffAnalyzer", func() {
 			Expect(operations).To(BeEmpty()) // No changes = no operations
 		})
 	})
+
+	Context("Applied bindings as previous state", func() {
+		var newFolderTree *rbacv1alpha1.FolderTree
+
+		viewers := rbacv1.Subject{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}
+		admins := rbacv1.Subject{Kind: "Group", Name: "admins", APIGroup: "rbac.authorization.k8s.io"}
+
+		BeforeEach(func() {
+			newFolderTree = &rbacv1alpha1.FolderTree{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tree"},
+				Spec: rbacv1alpha1.FolderTreeSpec{
+					Folders: []rbacv1alpha1.Folder{
+						{
+							Name:       "test-folder",
+							Namespaces: []string{"ns-a", "ns-b"},
+							RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{
+								{
+									Name:     "readers",
+									Subjects: []rbacv1.Subject{viewers, admins},
+									RoleRef: rbacv1.RoleRef{
+										APIGroup: "rbac.authorization.k8s.io",
+										Kind:     "ClusterRole",
+										Name:     "view",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			builder.FolderTree = newFolderTree
+		})
+
+		It("should produce order-independent digests that include the roleRef", func() {
+			a := &rbacv1.RoleBinding{
+				Subjects: []rbacv1.Subject{viewers, admins},
+				RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			}
+			b := &rbacv1.RoleBinding{
+				Subjects: []rbacv1.Subject{admins, viewers},
+				RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			}
+			Expect(BindingDigest(a)).To(Equal(BindingDigest(b)))
+			Expect(BindingDigest(a)).To(HavePrefix("ClusterRole/view/"))
+
+			b.Subjects = []rbacv1.Subject{viewers}
+			Expect(BindingDigest(a)).NotTo(Equal(BindingDigest(b)))
+		})
+
+		It("should reconstruct applied RoleBindings from their digest", func() {
+			roleBinding, err := AppliedRoleBinding("test-tree", "ns-a/foldertree-test-tree-readers", "ClusterRole/edit/0123456789abcdef")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleBinding.Namespace).To(Equal("ns-a"))
+			Expect(roleBinding.Name).To(Equal("foldertree-test-tree-readers"))
+			Expect(roleBinding.RoleRef.Name).To(Equal("edit"))
+			Expect(roleBinding.Labels["foldertree.rbac.kubevirt.io/role-binding-template"]).To(Equal("readers"))
+
+			_, err = AppliedRoleBinding("test-tree", "no-separator", "ClusterRole/edit/0123456789abcdef")
+			Expect(err).To(HaveOccurred())
+			_, err = AppliedRoleBinding("test-tree", "ns-a/rb", "garbage")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should diff the new spec against what was actually applied", func() {
+			desired, err := CalculateDesiredRoleBindings(newFolderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+			upToDate := BindingDigest(desired.RoleBindings["ns-a/foldertree-test-tree-readers"].RoleBinding)
+
+			analyzer := NewWebhookDiffAnalyzer(newFolderTree.DeepCopy(), newFolderTree, builder)
+			analyzer.AppliedBindings = map[string]string{
+				// Up to date - no operation
+				"ns-a/foldertree-test-tree-readers": upToDate,
+				// Applied with a different roleRef - DELETE+CREATE
+				"ns-b/foldertree-test-tree-readers": "ClusterRole/edit/0123456789abcdef",
+				// Left behind by a partial reconcile although the spec no longer has it - DELETE
+				"ns-c/foldertree-test-tree-readers": upToDate,
+			}
+
+			operations, err := analyzer.AnalyzeFolderTreeDiff()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(3))
+
+			var deletes, creates []string
+			for _, op := range operations {
+				switch op.Type {
+				case OperationDelete:
+					deletes = append(deletes, op.Namespace)
+				case OperationCreate:
+					creates = append(creates, op.Namespace)
+				}
+			}
+			Expect(deletes).To(ConsistOf("ns-b", "ns-c"))
+			Expect(creates).To(ConsistOf("ns-b"))
+		})
+
+		It("should still detect removals hidden from a tampered applied bindings map", func() {
+			oldFolderTree := newFolderTree.DeepCopy()
+			oldFolderTree.Spec.Folders[0].Namespaces = append(oldFolderTree.Spec.Folders[0].Namespaces, "ns-removed")
+
+			desired, err := CalculateDesiredRoleBindings(newFolderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+
+			analyzer := NewWebhookDiffAnalyzer(oldFolderTree, newFolderTree, builder)
+			analyzer.AppliedBindings = map[string]string{
+				// "ns-removed" was deleted from the map by whoever tampered with the status
+				"ns-a/foldertree-test-tree-readers": BindingDigest(desired.RoleBindings["ns-a/foldertree-test-tree-readers"].RoleBinding),
+				"ns-b/foldertree-test-tree-readers": BindingDigest(desired.RoleBindings["ns-b/foldertree-test-tree-readers"].RoleBinding),
+			}
+
+			operations, err := analyzer.AnalyzeFolderTreeDiff()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationDelete))
+			Expect(operations[0].Namespace).To(Equal("ns-removed"))
+		})
+
+		It("should not skip changes of the spec whose digests are pre-seeded in the applied bindings map", func() {
+			oldFolderTree := newFolderTree.DeepCopy()
+			oldFolderTree.Spec.Folders[0].Namespaces = oldFolderTree.Spec.Folders[0].Namespaces[:1]
+
+			desired, err := CalculateDesiredRoleBindings(newFolderTree, builder)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Whoever can write the status pre-seeds the digest of the RoleBinding the update adds
+			analyzer := NewWebhookDiffAnalyzer(oldFolderTree, newFolderTree, builder)
+			analyzer.AppliedBindings = make(map[string]string)
+			for key, desiredRB := range desired.RoleBindings {
+				analyzer.AppliedBindings[key] = BindingDigest(desiredRB.RoleBinding)
+			}
+
+			operations, err := analyzer.AnalyzeFolderTreeDiff()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Type).To(Equal(OperationCreate))
+			Expect(operations[0].Namespace).To(Equal("ns-b"))
+		})
+
+		It("should update when only the subjects differ", func() {
+			analyzer := NewWebhookDiffAnalyzer(nil, newFolderTree, builder)
+			analyzer.AppliedBindings = map[string]string{
+				"ns-a/foldertree-test-tree-readers": "ClusterRole/view/0123456789abcdef",
+			}
+
+			operations, err := analyzer.AnalyzeFolderTreeDiff()
+			Expect(err).NotTo(HaveOccurred())
+
+			var updates []RoleBindingOperation
+			for _, op := range operations {
+				if op.Type == OperationUpdate {
+					updates = append(updates, op)
+				}
+			}
+			Expect(updates).To(HaveLen(1))
+			Expect(updates[0].Namespace).To(Equal("ns-a"))
+			Expect(updates[0].ExistingRoleBinding.RoleRef.Name).To(Equal("view"))
+		})
+	})
 })
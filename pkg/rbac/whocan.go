@@ -0,0 +1,299 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// AccessQuery describes the action a who-can query asks about
+type AccessQuery struct {
+	Verb        string
+	APIGroup    string
+	Resource    string
+	Subresource string
+	Namespace   string
+}
+
+// AccessGrant describes a subject that a FolderTree grants the queried action to
+type AccessGrant struct {
+	Subject  rbacv1.Subject
+	Tree     string
+	Folder   string
+	Template string
+	RoleRef  rbacv1.RoleRef
+}
+
+// ParseAccessQuery builds an AccessQuery from a verb and a kubectl-style resource
+// such as "pods", "pods/log" or "deployments.apps".
+func ParseAccessQuery(verb, resource, namespace string) (AccessQuery, error) {
+	if namespace == "" {
+		return AccessQuery{}, fmt.Errorf("namespace must not be empty")
+	}
+	query, err := ParseResourceQuery(verb, resource)
+	query.Namespace = namespace
+	return query, err
+}
+
+// ParseResourceQuery builds an AccessQuery for all namespaces from a verb and a kubectl-style
+// resource, see ParseAccessQuery
+func ParseResourceQuery(verb, resource string) (AccessQuery, error) {
+	if verb == "" {
+		return AccessQuery{}, fmt.Errorf("verb must not be empty")
+	}
+
+	query := AccessQuery{Verb: verb}
+	resource, query.Subresource, _ = strings.Cut(resource, "/")
+	query.Resource, query.APIGroup, _ = strings.Cut(resource, ".")
+	if query.Resource == "" {
+		return AccessQuery{}, fmt.Errorf("resource must not be empty")
+	}
+
+	return query, nil
+}
+
+// String returns the query in "verb resource.group/subresource" form
+func (q AccessQuery) String() string {
+	resource := q.Resource
+	if q.APIGroup != "" {
+		resource += "." + q.APIGroup
+	}
+	if q.Subresource != "" {
+		resource += "/" + q.Subresource
+	}
+	return fmt.Sprintf("%s %s", q.Verb, resource)
+}
+
+// WhoCan returns the subjects that FolderTrees grant the queried action in the query namespace,
+// together with the folder and template responsible. Desired state is calculated from each
+// FolderTree spec plus the approved FolderMemberships of the namespace, and the referenced
+// roles are read from the cluster to evaluate their rules.
+func WhoCan(ctx context.Context, c client.Client, query AccessQuery) ([]AccessGrant, error) {
+	var folderTreeList rbacv1alpha1.FolderTreeList
+	if err := c.List(ctx, &folderTreeList); err != nil {
+		return nil, fmt.Errorf("failed to list FolderTrees: %v", err)
+	}
+
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList, client.InNamespace(query.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+
+	patches, err := listApprovedPatches(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectMappings, err := ListSubjectMappings(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[rbacv1.RoleRef][]rbacv1.PolicyRule)
+	var grants []AccessGrant
+
+	for i := range folderTreeList.Items {
+		folderTree := withApprovedPatches(withApprovedMemberships(&folderTreeList.Items[i], membershipList.Items), patches)
+		serviceAccounts, err := ListServiceAccounts(ctx, c, folderTree, IndexFolderTreeNamespaces(folderTree))
+		if err != nil {
+			return nil, err
+		}
+		builder := &RoleBindingBuilder{FolderTree: folderTree, SubjectMappings: subjectMappings, ServiceAccounts: serviceAccounts}
+
+		desired, err := CalculateDesiredRoleBindings(folderTree, builder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate RoleBindings for FolderTree '%s': %v", folderTree.Name, err)
+		}
+
+		for _, desiredRB := range desired.RoleBindings {
+			if desiredRB.Namespace != query.Namespace {
+				continue
+			}
+
+			roleRef := desiredRB.RoleBinding.RoleRef
+			roleRules, cached := rules[roleRef]
+			if !cached {
+				roleRules, err = getRoleRules(ctx, c, query.Namespace, roleRef)
+				if err != nil {
+					return nil, err
+				}
+				rules[roleRef] = roleRules
+			}
+
+			if !slices.ContainsFunc(roleRules, query.allowedBy) {
+				continue
+			}
+
+			for _, subject := range desiredRB.RoleBinding.Subjects {
+				grants = append(grants, AccessGrant{
+					Subject:  subject,
+					Tree:     folderTree.Name,
+					Folder:   desiredRB.Folder,
+					Template: desiredRB.RoleBindingTemplate.Name,
+					RoleRef:  roleRef,
+				})
+			}
+		}
+	}
+
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.Subject.Kind != b.Subject.Kind {
+			return a.Subject.Kind < b.Subject.Kind
+		}
+		if a.Subject.Name != b.Subject.Name {
+			return a.Subject.Name < b.Subject.Name
+		}
+		if a.Tree != b.Tree {
+			return a.Tree < b.Tree
+		}
+		return a.Template < b.Template
+	})
+
+	return grants, nil
+}
+
+// EffectiveRoleBindings returns the RoleBindings a FolderTree wants in a namespace after inheritance,
+// including those for approved FolderMemberships of the namespace and approved FolderTreePatches,
+// sorted by template name
+func EffectiveRoleBindings(ctx context.Context, c client.Client, folderTree *rbacv1alpha1.FolderTree, namespace string) ([]*DesiredRoleBinding, error) {
+	var membershipList rbacv1alpha1.FolderMembershipList
+	if err := c.List(ctx, &membershipList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list FolderMemberships: %v", err)
+	}
+
+	patches, err := listApprovedPatches(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectMappings, err := ListSubjectMappings(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	folderTree = withApprovedPatches(withApprovedMemberships(folderTree, membershipList.Items), patches)
+	serviceAccounts, err := ListServiceAccounts(ctx, c, folderTree, IndexFolderTreeNamespaces(folderTree))
+	if err != nil {
+		return nil, err
+	}
+	desired, err := CalculateDesiredRoleBindings(folderTree, &RoleBindingBuilder{
+		FolderTree: folderTree, SubjectMappings: subjectMappings, ServiceAccounts: serviceAccounts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate RoleBindings for FolderTree '%s': %v", folderTree.Name, err)
+	}
+
+	var effective []*DesiredRoleBinding
+	for _, desiredRB := range desired.RoleBindings {
+		if desiredRB.Namespace == namespace {
+			effective = append(effective, desiredRB)
+		}
+	}
+	sort.Slice(effective, func(i, j int) bool {
+		return effective[i].RoleBindingTemplate.Name < effective[j].RoleBindingTemplate.Name
+	})
+
+	return effective, nil
+}
+
+// withApprovedMemberships returns the FolderTree with the namespaces of approved
+// FolderMemberships added to their folders. The original is never modified.
+func withApprovedMemberships(folderTree *rbacv1alpha1.FolderTree, memberships []rbacv1alpha1.FolderMembership) *rbacv1alpha1.FolderTree {
+	resolved := folderTree
+	for _, membership := range memberships {
+		if membership.Spec.TreeName != folderTree.Name || membership.Status.Phase != rbacv1alpha1.MembershipPhaseApproved {
+			continue
+		}
+		if resolved == folderTree {
+			resolved = folderTree.DeepCopy()
+		}
+		for i := range resolved.Spec.Folders {
+			folder := &resolved.Spec.Folders[i]
+			if folder.Name == membership.Spec.FolderName && !slices.Contains(folder.Namespaces, membership.Namespace) {
+				folder.Namespaces = append(folder.Namespaces, membership.Namespace)
+			}
+		}
+	}
+	return resolved
+}
+
+// getRoleRules returns the rules of the referenced Role or ClusterRole.
+// A missing role grants nothing and is not an error.
+func getRoleRules(ctx context.Context, c client.Reader, namespace string, roleRef rbacv1.RoleRef) ([]rbacv1.PolicyRule, error) {
+	switch roleRef.Kind {
+	case "ClusterRole":
+		clusterRole := &rbacv1.ClusterRole{}
+		if err := c.Get(ctx, types.NamespacedName{Name: roleRef.Name}, clusterRole); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get ClusterRole '%s': %v", roleRef.Name, err)
+		}
+		return clusterRole.Rules, nil
+	case "Role":
+		role := &rbacv1.Role{}
+		if err := c.Get(ctx, types.NamespacedName{Name: roleRef.Name, Namespace: namespace}, role); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get Role '%s/%s': %v", namespace, roleRef.Name, err)
+		}
+		return role.Rules, nil
+	}
+	return nil, nil
+}
+
+// allowedBy reports whether the policy rule allows the queried action, following the
+// matching semantics of the Kubernetes RBAC authorizer. Rules restricted to resource
+// names never match since the query is not for a named object.
+func (q AccessQuery) allowedBy(rule rbacv1.PolicyRule) bool {
+	if len(rule.ResourceNames) > 0 {
+		return false
+	}
+	if !slices.Contains(rule.Verbs, rbacv1.VerbAll) && !slices.Contains(rule.Verbs, q.Verb) {
+		return false
+	}
+	if !slices.Contains(rule.APIGroups, rbacv1.APIGroupAll) && !slices.Contains(rule.APIGroups, q.APIGroup) {
+		return false
+	}
+
+	resource := q.Resource
+	if q.Subresource != "" {
+		resource += "/" + q.Subresource
+	}
+	for _, ruleResource := range rule.Resources {
+		if ruleResource == rbacv1.ResourceAll || ruleResource == resource {
+			return true
+		}
+		if q.Subresource != "" && ruleResource == "*/"+q.Subresource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+var _ = Describe("WhoCan", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		folderTree *rbacv1alpha1.FolderTree
+		objects    []client.Object
+	)
+
+	template := func(name, group, clusterRole string, propagate bool) rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{
+			Name: name,
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:     "Group",
+					Name:     group,
+					APIGroup: "rbac.authorization.k8s.io",
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     clusterRole,
+			},
+			Propagate: boolPtr(propagate),
+		}
+	}
+
+	whoCan := func(verb, resource, namespace string) []AccessGrant {
+		query, err := ParseAccessQuery(verb, resource, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, folderTree)...).Build()
+		grants, err := WhoCan(ctx, fakeClient, query)
+		Expect(err).NotTo(HaveOccurred())
+		return grants
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(rbacv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+
+		objects = []client.Object{
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "view"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"pods", "pods/log"}, Verbs: []string{"get", "list", "watch"}},
+					{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list", "watch"}},
+				},
+			},
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "edit"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+				},
+			},
+		}
+
+		rootTree := rbacv1alpha1.TreeNode{Name: "platform"}
+		rootTree.Subfolders = []rbacv1alpha1.TreeNode{{Name: "web"}}
+		folderTree = &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Tree: &rootTree,
+				Folders: []rbacv1alpha1.Folder{
+					{
+						Name:                 "platform",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("sre", "sre-team", "edit", true)},
+						Namespaces:           []string{"platform-ns"},
+					},
+					{
+						Name:                 "web",
+						RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{template("web-viewers", "web-team", "view", false)},
+						Namespaces:           []string{"web-ns"},
+						AcceptMemberships:    true,
+					},
+				},
+			},
+		}
+	})
+
+	It("should report inherited and local templates that grant the action", func() {
+		grants := whoCan("get", "pods", "web-ns")
+
+		Expect(grants).To(HaveLen(2))
+		Expect(grants[0].Subject.Name).To(Equal("sre-team"))
+		Expect(grants[0].Folder).To(Equal("web"))
+		Expect(grants[0].Template).To(Equal("sre"))
+		Expect(grants[0].RoleRef.Name).To(Equal("edit"))
+		Expect(grants[1].Subject.Name).To(Equal("web-team"))
+		Expect(grants[1].Template).To(Equal("web-viewers"))
+	})
+
+	It("should only report templates whose role rules allow the action", func() {
+		grants := whoCan("delete", "deployments.apps", "web-ns")
+
+		Expect(grants).To(HaveLen(1))
+		Expect(grants[0].Subject.Name).To(Equal("sre-team"))
+
+		Expect(whoCan("get", "pods/log", "web-ns")).To(HaveLen(2))
+		Expect(whoCan("get", "pods/exec", "platform-ns")).To(HaveLen(1))
+	})
+
+	It("should ignore namespaces outside the tree and missing roles", func() {
+		Expect(whoCan("get", "pods", "unrelated-ns")).To(BeEmpty())
+
+		folderTree.Spec.Folders[1].RoleBindingTemplates[0].RoleRef.Name = "missing-role"
+		Expect(whoCan("get", "pods", "web-ns")).To(HaveLen(1))
+	})
+
+	It("should include namespaces joined through approved FolderMemberships", func() {
+		objects = append(objects,
+			&rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join-web", Namespace: "team-ns"},
+				Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "org", FolderName: "web"},
+				Status:     rbacv1alpha1.FolderMembershipStatus{Phase: rbacv1alpha1.MembershipPhaseApproved},
+			},
+			&rbacv1alpha1.FolderMembership{
+				ObjectMeta: metav1.ObjectMeta{Name: "join-platform", Namespace: "pending-ns"},
+				Spec:       rbacv1alpha1.FolderMembershipSpec{TreeName: "org", FolderName: "platform"},
+				Status:     rbacv1alpha1.FolderMembershipStatus{Phase: rbacv1alpha1.MembershipPhasePending},
+			},
+		)
+
+		grants := whoCan("list", "pods", "team-ns")
+		Expect(grants).To(HaveLen(2))
+		Expect(grants[1].Folder).To(Equal("web"))
+
+		Expect(whoCan("list", "pods", "pending-ns")).To(BeEmpty())
+		Expect(folderTree.Spec.Folders[1].Namespaces).To(Equal([]string{"web-ns"}))
+	})
+
+	It("should list the effective RoleBindings of a namespace with their source folders", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(folderTree).Build()
+
+		effective, err := EffectiveRoleBindings(ctx, fakeClient, folderTree, "web-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(effective).To(HaveLen(2))
+		Expect(effective[0].RoleBindingTemplate.Name).To(Equal("sre"))
+		Expect(effective[0].RoleBinding.Annotations).To(HaveKeyWithValue(SourceFolderAnnotation, "platform"))
+		Expect(effective[1].RoleBindingTemplate.Name).To(Equal("web-viewers"))
+		Expect(effective[1].RoleBinding.Labels).To(HaveKeyWithValue(InheritedLabel, "false"))
+
+		effective, err = EffectiveRoleBindings(ctx, fakeClient, folderTree, "unrelated-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(effective).To(BeEmpty())
+	})
+
+	Context("ParseAccessQuery", func() {
+		It("should split group and subresource from the resource", func() {
+			query, err := ParseAccessQuery("get", "deployments.apps/scale", "ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query.Resource).To(Equal("deployments"))
+			Expect(query.APIGroup).To(Equal("apps"))
+			Expect(query.Subresource).To(Equal("scale"))
+			Expect(query.String()).To(Equal("get deployments.apps/scale"))
+		})
+
+		It("should reject empty input", func() {
+			_, err := ParseAccessQuery("", "pods", "ns")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseAccessQuery("get", "", "ns")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseAccessQuery("get", "pods", "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
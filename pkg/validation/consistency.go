@@ -0,0 +1,544 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// ValidateBusinessLogic performs additional business logic validation on a structurally valid spec.
+// Errors have the code of the most specific problem found, ErrInvalidSpec by default.
+func ValidateBusinessLogic(spec *rbacv1alpha1.FolderTreeSpec, opts Options) error {
+	var allErrors field.ErrorList
+
+	// Validate that at least one namespace is assigned somewhere
+	hasNamespaces := false
+	for _, folder := range spec.Folders {
+		if len(folder.Namespaces) > 0 {
+			hasNamespaces = true
+			break
+		}
+	}
+
+	if !hasNamespaces {
+		allErrors = append(allErrors, field.Invalid(
+			field.NewPath("spec", "folders"),
+			spec.Folders,
+			"folder tree must contain at least one namespace assignment"))
+	}
+
+	// Validate unique folder names and role binding template names, unless the API server already has
+	duplicateFolders := false
+	if !opts.APIServerListValidation {
+		folderNames := make(map[string]*field.Path)
+		for i, folder := range spec.Folders {
+			folderPath := field.NewPath("spec", "folders").Index(i)
+			if existingPath, exists := folderNames[folder.Name]; exists {
+				duplicateFolders = true
+				allErrors = append(allErrors, field.Duplicate(
+					folderPath.Child("name"),
+					fmt.Sprintf("folder name '%s' already used at %s", folder.Name, existingPath)))
+			} else {
+				folderNames[folder.Name] = folderPath.Child("name")
+			}
+		}
+
+		// Validate unique role binding template names within each folder
+		for i, folder := range spec.Folders {
+			folderPath := field.NewPath("spec", "folders").Index(i)
+			roleBindingTemplateNames := make(map[string]*field.Path)
+			for j, roleBindingTemplate := range folder.RoleBindingTemplates {
+				roleBindingTemplatePath := folderPath.Child("roleBindingTemplates").Index(j)
+				if existingPath, exists := roleBindingTemplateNames[roleBindingTemplate.Name]; exists {
+					allErrors = append(allErrors, field.Duplicate(
+						roleBindingTemplatePath.Child("name"),
+						fmt.Sprintf("role binding template name '%s' already used in folder '%s' at %s", roleBindingTemplate.Name, folder.Name, existingPath)))
+				} else {
+					roleBindingTemplateNames[roleBindingTemplate.Name] = roleBindingTemplatePath.Child("name")
+				}
+			}
+		}
+
+		// Validate unique global role binding template names
+		globalTemplateNames := make(map[string]*field.Path)
+		for i, roleBindingTemplate := range spec.GlobalRoleBindingTemplates {
+			templatePath := field.NewPath("spec", "globalRoleBindingTemplates").Index(i)
+			if existingPath, exists := globalTemplateNames[roleBindingTemplate.Name]; exists {
+				allErrors = append(allErrors, field.Duplicate(
+					templatePath.Child("name"),
+					fmt.Sprintf("global role binding template name '%s' already used at %s", roleBindingTemplate.Name, existingPath)))
+			} else {
+				globalTemplateNames[roleBindingTemplate.Name] = templatePath.Child("name")
+			}
+		}
+	}
+
+	// Validate unique namespace assignments
+	namespaceAssignments := make(map[string]*field.Path)
+	for i, folder := range spec.Folders {
+		folderPath := field.NewPath("spec", "folders").Index(i)
+		for j, namespace := range folder.Namespaces {
+			namespacePath := folderPath.Child("namespaces").Index(j)
+			if existingPath, exists := namespaceAssignments[namespace]; exists {
+				allErrors = append(allErrors, field.Duplicate(
+					namespacePath,
+					fmt.Sprintf("namespace '%s' already assigned at %s", namespace, existingPath)))
+			} else {
+				namespaceAssignments[namespace] = namespacePath
+			}
+
+			// Excluded namespaces must never receive RoleBindings
+			if slices.Contains(opts.ExcludedNamespaces, namespace) {
+				allErrors = append(allErrors, field.Forbidden(namespacePath,
+					fmt.Sprintf("namespace '%s' is excluded from FolderTrees by the controller configuration", namespace)))
+			} else if slices.Contains(spec.ExcludedNamespaces, namespace) {
+				allErrors = append(allErrors, field.Forbidden(namespacePath,
+					fmt.Sprintf("namespace '%s' is listed in spec.excludedNamespaces", namespace)))
+			}
+		}
+	}
+
+	// Validate unique tree node names across all trees, and that trees stay within the maximum depth
+	treeNodeNames := make(map[string]*field.Path)
+	for _, root := range treeRoots(spec) {
+		validateUniqueTreeNodeNames(root.Node, root.Path, treeNodeNames, map[string]*field.Path{}, &allErrors)
+		validateTreeDepth(root.Node, root.Path, 1, opts.maxTreeDepth(), &allErrors)
+	}
+
+	// Validate role binding template names don't conflict in inheritance chains
+	errorsBefore := len(allErrors)
+	validateInheritanceConflicts(spec, &allErrors)
+	inheritConflicts := len(allErrors) > errorsBefore
+
+	// Validate that all tree nodes reference declared folders and all folders are used
+	validateFolderReferences(spec, &allErrors)
+
+	// Validate that template overrides refer to templates that reach their namespaces
+	validateTemplateOverrides(spec, &allErrors)
+
+	// Validate that namespace groups have descendants to inherit templates from
+	validateNamespaceGroups(spec, &allErrors)
+
+	// Validate the configured limits
+	size := MeasureSpec(spec)
+	limits := opts.Limits.Resolved()
+	if size.Folders > limits.MaxFolders {
+		allErrors = append(allErrors, field.TooMany(
+			field.NewPath("spec", "folders"),
+			size.Folders,
+			limits.MaxFolders))
+	}
+
+	if size.TreeNodes > limits.MaxTreeNodes {
+		allErrors = append(allErrors, field.TooMany(
+			field.NewPath("spec", "trees"),
+			size.TreeNodes,
+			limits.MaxTreeNodes))
+	}
+
+	if size.Namespaces > limits.MaxNamespaces {
+		allErrors = append(allErrors, field.TooMany(
+			field.NewPath("spec", "folders"),
+			size.Namespaces,
+			limits.MaxNamespaces))
+	}
+
+	if size.RoleBindingTemplates > limits.MaxRoleBindingTemplates {
+		allErrors = append(allErrors, field.TooMany(
+			field.NewPath("spec", "folders"),
+			size.RoleBindingTemplates,
+			limits.MaxRoleBindingTemplates))
+	}
+
+	if size.NamespaceGroupRoleBindings > limits.MaxNamespaceGroupRoleBindings {
+		allErrors = append(allErrors, field.TooMany(
+			field.NewPath("spec", "folders"),
+			size.NamespaceGroupRoleBindings,
+			limits.MaxNamespaceGroupRoleBindings))
+	}
+
+	if len(allErrors) > 0 {
+		// The most specific problem names the rejection; the message lists all of them
+		code := ErrInvalidSpec
+		switch {
+		case duplicateFolders:
+			code = ErrDuplicateFolder
+		case inheritConflicts:
+			code = ErrInheritConflict
+		}
+		return Reject(code, allErrors.ToAggregate())
+	}
+
+	return nil
+}
+
+// validateUniqueTreeNodeNames validates that tree node names are unique within the tree structure.
+// A node repeating the name of one of its ancestors is reported as a cycle, since both would
+// resolve to the same folder.
+func validateUniqueTreeNodeNames(treeNode rbacv1alpha1.TreeNode, fldPath *field.Path,
+	treeNodeNames, ancestors map[string]*field.Path, allErrors *field.ErrorList) {
+
+	// Check if this tree node name is already used
+	if ancestorPath, isAncestor := ancestors[treeNode.Name]; isAncestor {
+		*allErrors = append(*allErrors, field.Invalid(
+			fldPath.Child("name"), treeNode.Name,
+			fmt.Sprintf("tree node '%s' repeats its ancestor at %s, forming a cycle", treeNode.Name, ancestorPath)))
+	} else if existingPath, exists := treeNodeNames[treeNode.Name]; exists {
+		*allErrors = append(*allErrors, field.Duplicate(
+			fldPath.Child("name"),
+			fmt.Sprintf("tree node name '%s' already used at %s", treeNode.Name, existingPath)))
+	} else {
+		treeNodeNames[treeNode.Name] = fldPath.Child("name")
+	}
+
+	// Recursively check subfolders
+	if _, isAncestor := ancestors[treeNode.Name]; !isAncestor {
+		ancestors[treeNode.Name] = fldPath.Child("name")
+		defer delete(ancestors, treeNode.Name)
+	}
+	for i, subfolder := range treeNode.Subfolders {
+		subPath := fldPath.Child("subfolders").Index(i)
+		validateUniqueTreeNodeNames(subfolder, subPath, treeNodeNames, ancestors, allErrors)
+	}
+}
+
+// validateTreeDepth validates that no branch of a tree is deeper than the maximum tree depth.
+// Each branch is reported once, at the first node beyond the limit.
+func validateTreeDepth(treeNode rbacv1alpha1.TreeNode, fldPath *field.Path,
+	depth, maxDepth int, allErrors *field.ErrorList) {
+	if depth > maxDepth {
+		*allErrors = append(*allErrors, field.Invalid(fldPath, treeNode.Name,
+			fmt.Sprintf("tree node is at depth %d, which exceeds the maximum tree depth of %d", depth, maxDepth)))
+		return
+	}
+	for i, subfolder := range treeNode.Subfolders {
+		validateTreeDepth(subfolder, fldPath.Child("subfolders").Index(i), depth+1, maxDepth, allErrors)
+	}
+}
+
+// validateInheritanceConflicts validates that role binding template names don't conflict
+// in inheritance chains. This prevents the issue where a child folder's template
+// overwrites a parent folder's template with the same name.
+func validateInheritanceConflicts(spec *rbacv1alpha1.FolderTreeSpec, allErrors *field.ErrorList) {
+	// Create a map of folder name to folder data for quick lookup
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	folderIndexMap := make(map[string]int) // Track folder indices for error reporting
+	for i, folder := range spec.Folders {
+		folderMap[folder.Name] = folder
+		folderIndexMap[folder.Name] = i
+	}
+
+	// Check the trees for inheritance conflicts (if they exist)
+	for _, root := range treeRoots(spec) {
+		validateTreeInheritanceConflicts(root.Node, root.Path, folderMap, folderIndexMap, []string{}, allErrors)
+	}
+
+	// Global templates are inherited by every folder, in or outside the tree
+	globalTemplateNames := make(map[string]bool)
+	for _, roleBindingTemplate := range spec.GlobalRoleBindingTemplates {
+		globalTemplateNames[roleBindingTemplate.Name] = true
+	}
+	for i, folder := range spec.Folders {
+		blockedNames := make(map[string]bool)
+		for j, name := range folder.BlockInherited {
+			blockPath := field.NewPath("spec", "folders").Index(i).Child("blockInherited").Index(j)
+			if blockedNames[name] {
+				*allErrors = append(*allErrors, field.Duplicate(blockPath, name))
+			}
+			blockedNames[name] = true
+			if globalTemplateNames[name] {
+				*allErrors = append(*allErrors, field.Invalid(blockPath, name,
+					fmt.Sprintf("global role binding template '%s' cannot be blocked", name)))
+			}
+		}
+	}
+	for _, root := range treeRoots(spec) {
+		validateEdgeFilters(root.Node, root.Path, true, globalTemplateNames, allErrors)
+	}
+	for i, folder := range spec.Folders {
+		for j, roleBindingTemplate := range folder.RoleBindingTemplates {
+			if globalTemplateNames[roleBindingTemplate.Name] {
+				*allErrors = append(*allErrors, field.Invalid(
+					field.NewPath("spec", "folders").Index(i).Child("roleBindingTemplates").Index(j).Child("name"),
+					roleBindingTemplate.Name,
+					fmt.Sprintf("role binding template name '%s' conflicts with global role binding template", roleBindingTemplate.Name)))
+			}
+		}
+	}
+}
+
+// validateEdgeFilters validates the inheritOnly and exclude lists of a tree node and its subfolders.
+// Tree roots have no parent to inherit from, and global templates are always inherited.
+func validateEdgeFilters(treeNode rbacv1alpha1.TreeNode, treePath *field.Path, root bool,
+	globalTemplateNames map[string]bool, allErrors *field.ErrorList) {
+	for _, list := range []struct {
+		name  string
+		names []string
+	}{{"inheritOnly", treeNode.InheritOnly}, {"exclude", treeNode.Exclude}} {
+		if root && len(list.names) > 0 {
+			*allErrors = append(*allErrors, field.Forbidden(treePath.Child(list.name),
+				"tree roots do not inherit templates from a parent folder"))
+			continue
+		}
+		seen := make(map[string]bool)
+		for j, name := range list.names {
+			namePath := treePath.Child(list.name).Index(j)
+			if seen[name] {
+				*allErrors = append(*allErrors, field.Duplicate(namePath, name))
+			}
+			seen[name] = true
+			if globalTemplateNames[name] {
+				*allErrors = append(*allErrors, field.Invalid(namePath, name,
+					fmt.Sprintf("global role binding template '%s' is always inherited", name)))
+			}
+		}
+	}
+	for i, subfolder := range treeNode.Subfolders {
+		validateEdgeFilters(subfolder, treePath.Child("subfolders").Index(i), false, globalTemplateNames, allErrors)
+	}
+}
+
+// validateTreeInheritanceConflicts recursively validates inheritance conflicts in a tree structure
+//
+//nolint:unparam
+func validateTreeInheritanceConflicts(
+	treeNode rbacv1alpha1.TreeNode,
+	treePath *field.Path,
+	folderMap map[string]rbacv1alpha1.Folder,
+	folderIndexMap map[string]int,
+	inheritedTemplateNames []string,
+	allErrors *field.ErrorList) {
+
+	// Templates the edge from the parent does not pass on are not inherited either
+	inheritedTemplateNames = slices.DeleteFunc(slices.Clone(inheritedTemplateNames), func(name string) bool {
+		return !treeNode.Inherits(name)
+	})
+
+	// Get folder data for this tree node
+	folder, exists := folderMap[treeNode.Name]
+	var currentTemplateNames []string
+
+	if exists {
+		// Check for conflicts between inherited templates and this folder's templates
+		folderIndex := folderIndexMap[treeNode.Name]
+		folderPath := field.NewPath("spec", "folders").Index(folderIndex)
+
+		// Blocked templates are not inherited, so this folder and its descendants may reuse their names
+		if len(folder.BlockInherited) > 0 {
+			inheritedTemplateNames = slices.DeleteFunc(slices.Clone(inheritedTemplateNames), func(name string) bool {
+				return slices.Contains(folder.BlockInherited, name)
+			})
+		}
+
+		for j, roleBindingTemplate := range folder.RoleBindingTemplates {
+			templatePath := folderPath.Child("roleBindingTemplates").Index(j)
+
+			// Check if this template name conflicts with any inherited template
+			for _, inheritedName := range inheritedTemplateNames {
+				if roleBindingTemplate.Name == inheritedName {
+					*allErrors = append(*allErrors, field.Invalid(
+						templatePath.Child("name"),
+						roleBindingTemplate.Name,
+						fmt.Sprintf("role binding template name '%s' conflicts with inherited template from parent folder in tree hierarchy", roleBindingTemplate.Name)))
+				}
+			}
+
+			currentTemplateNames = append(currentTemplateNames, roleBindingTemplate.Name)
+		}
+
+		// The namespaces of a namespace group receive the templates of all its descendants too, so
+		// their names may neither repeat each other nor the templates the group already receives
+		if folder.InheritNamespaces {
+			received := make(map[string]string)
+			for _, name := range inheritedTemplateNames {
+				received[name] = "inherited by"
+			}
+			for _, name := range currentTemplateNames {
+				received[name] = "defined by"
+			}
+			for _, descendant := range rbac.DescendantTemplates(treeNode, folderMap) {
+				templatePath := field.NewPath("spec", "folders").Index(folderIndexMap[descendant.Folder]).
+					Child("roleBindingTemplates").Index(descendant.Index).Child("name")
+				if how, exists := received[descendant.Name]; exists {
+					*allErrors = append(*allErrors, field.Invalid(templatePath, descendant.Name, fmt.Sprintf(
+						"role binding template name '%s' conflicts with a template %s folder '%s', which inherits the templates of its subfolders",
+						descendant.Name, how, folder.Name)))
+					continue
+				}
+				received[descendant.Name] = fmt.Sprintf("of subfolder '%s' received by", descendant.Folder)
+			}
+		}
+
+		// Combine inherited and current template names for child validation
+		allTemplateNames := append(inheritedTemplateNames, currentTemplateNames...)
+
+		// Recursively validate subfolders with accumulated template names
+		for _, subfolder := range treeNode.Subfolders {
+			validateTreeInheritanceConflicts(subfolder, treePath, folderMap, folderIndexMap, allTemplateNames, allErrors)
+		}
+	} else {
+		// Tree node exists but no folder data - pass inherited templates to children
+		for _, subfolder := range treeNode.Subfolders {
+			validateTreeInheritanceConflicts(subfolder, treePath, folderMap, folderIndexMap, inheritedTemplateNames, allErrors)
+		}
+	}
+}
+
+// validateNamespaceGroups validates that folders with inheritNamespaces have subfolders whose
+// templates their namespaces could receive
+func validateNamespaceGroups(spec *rbacv1alpha1.FolderTreeSpec, allErrors *field.ErrorList) {
+	hasSubfolders := make(map[string]bool)
+	var walk func(rbacv1alpha1.TreeNode)
+	walk = func(treeNode rbacv1alpha1.TreeNode) {
+		hasSubfolders[treeNode.Name] = len(treeNode.Subfolders) > 0
+		for _, subfolder := range treeNode.Subfolders {
+			walk(subfolder)
+		}
+	}
+	for _, root := range spec.Roots() {
+		walk(root)
+	}
+
+	for i, folder := range spec.Folders {
+		if folder.InheritNamespaces && !hasSubfolders[folder.Name] {
+			*allErrors = append(*allErrors, field.Invalid(
+				field.NewPath("spec", "folders").Index(i).Child("inheritNamespaces"), true,
+				fmt.Sprintf("folder '%s' has no subfolders whose templates its namespaces could inherit", folder.Name)))
+		}
+	}
+}
+
+// validateFolderReferences validates that all tree nodes reference declared folders
+func validateFolderReferences(spec *rbacv1alpha1.FolderTreeSpec, allErrors *field.ErrorList) {
+	// Collect all declared folders
+	declaredFolders := make(map[string]bool)
+	for _, folder := range spec.Folders {
+		declaredFolders[folder.Name] = true
+	}
+
+	// Recursively check all folder names referenced in trees
+	var collectReferencedFolders func(rbacv1alpha1.TreeNode, *field.Path)
+	collectReferencedFolders = func(treeNode rbacv1alpha1.TreeNode, treePath *field.Path) {
+		// Check if this tree node references a declared folder
+		if !declaredFolders[treeNode.Name] {
+			*allErrors = append(*allErrors, field.Invalid(
+				treePath.Child("name"),
+				treeNode.Name,
+				fmt.Sprintf("tree node '%s' references undeclared folder (must be declared in spec.folders)", treeNode.Name)))
+		}
+
+		// Recursively check subfolders
+		for i, subfolder := range treeNode.Subfolders {
+			subPath := treePath.Child("subfolders").Index(i)
+			collectReferencedFolders(subfolder, subPath)
+		}
+	}
+
+	// Check the trees (if they exist)
+	for _, root := range treeRoots(spec) {
+		collectReferencedFolders(root.Node, root.Path)
+	}
+
+	// Declared folders that are not referenced by any tree are standalone folders, which are
+	// valid; the webhook warns about empty ones
+}
+
+// validateTemplateOverrides validates that namespace overrides name a namespace of their folder and
+// that their template overrides name a role binding template of the folder, one of its ancestors, one
+// of its descendants when the folder inherits namespaces, or the global templates, and validates the
+// override subjects against the subject namespace mode of that template
+func validateTemplateOverrides(spec *rbacv1alpha1.FolderTreeSpec, allErrors *field.ErrorList) {
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range spec.Folders {
+		folderMap[folder.Name] = folder
+	}
+
+	// Record the ancestors of every folder in the trees, nearest first
+	ancestors := make(map[string][]string)
+	var collectAncestors func(node rbacv1alpha1.TreeNode, parents []string)
+	collectAncestors = func(node rbacv1alpha1.TreeNode, parents []string) {
+		ancestors[node.Name] = parents
+		for _, subfolder := range node.Subfolders {
+			collectAncestors(subfolder, append([]string{node.Name}, parents...))
+		}
+	}
+	for _, root := range spec.Roots() {
+		collectAncestors(root, nil)
+	}
+
+	// Namespace groups also receive the templates of their descendants
+	descendants := make(map[string][]string)
+	var collectDescendants func(node rbacv1alpha1.TreeNode)
+	collectDescendants = func(node rbacv1alpha1.TreeNode) {
+		for _, subfolder := range node.Subfolders {
+			for _, ancestor := range append([]string{node.Name}, ancestors[node.Name]...) {
+				if folderMap[ancestor].InheritNamespaces {
+					descendants[ancestor] = append(descendants[ancestor], subfolder.Name)
+				}
+			}
+			collectDescendants(subfolder)
+		}
+	}
+	for _, root := range spec.Roots() {
+		collectDescendants(root)
+	}
+
+	findTemplate := func(folderName, name string) (rbacv1alpha1.RoleBindingTemplate, bool) {
+		sources := append([]string{folderName}, ancestors[folderName]...)
+		for _, source := range append(sources, descendants[folderName]...) {
+			for _, template := range folderMap[source].RoleBindingTemplates {
+				if template.Name == name {
+					return template, true
+				}
+			}
+		}
+		for _, template := range spec.GlobalRoleBindingTemplates {
+			if template.Name == name {
+				return template, true
+			}
+		}
+		return rbacv1alpha1.RoleBindingTemplate{}, false
+	}
+
+	for i, folder := range spec.Folders {
+		for j, override := range folder.NamespaceOverrides {
+			overridePath := field.NewPath("spec", "folders").Index(i).Child("namespaces").Index(folder.NamespaceEntryIndex(j))
+			if !slices.Contains(folder.Namespaces, override.Name) {
+				*allErrors = append(*allErrors, field.Invalid(overridePath.Child("name"), override.Name,
+					fmt.Sprintf("namespace '%s' is not listed in the namespaces of folder '%s'", override.Name, folder.Name)))
+			}
+			overridesPath := overridePath.Child("templateOverrides")
+			for _, name := range slices.Sorted(maps.Keys(override.TemplateOverrides)) {
+				template, found := findTemplate(folder.Name, name)
+				if !found {
+					*allErrors = append(*allErrors, field.Invalid(overridesPath.Key(name), name,
+						fmt.Sprintf("role binding template '%s' is not defined by folder '%s', its ancestors or the global templates", name, folder.Name)))
+					continue
+				}
+				*allErrors = append(*allErrors, validateSubjects(override.TemplateOverrides[name].Subjects,
+					template.SubjectNamespaceMode, overridesPath.Key(name).Child("subjects"))...)
+			}
+		}
+	}
+}
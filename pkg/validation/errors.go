@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// RejectionCode classifies why a FolderTree was rejected, so that CI pipelines and UIs can act on
+// rejections without parsing their messages. The webhook returns it as the reason of the admission
+// response status, and it prefixes the message in brackets, e.g. "[DuplicateFolder] ...", since
+// kubectl only prints the message. Codes of checks that need a cluster are only set by the webhook.
+type RejectionCode string
+
+const (
+	// ErrInvalidStructure rejects malformed trees and folders, e.g. invalid names or templates
+	ErrInvalidStructure RejectionCode = "InvalidStructure"
+
+	// ErrInvalidSpec rejects specs that are well-formed but inconsistent, e.g. exceeding limits
+	ErrInvalidSpec RejectionCode = "InvalidSpec"
+
+	// ErrDuplicateFolder rejects folder or tree node names already used in this or another FolderTree
+	ErrDuplicateFolder RejectionCode = "DuplicateFolder"
+
+	// ErrDuplicateNamespace rejects namespaces already assigned in another FolderTree
+	ErrDuplicateNamespace RejectionCode = "DuplicateNamespace"
+
+	// ErrInheritConflict rejects role binding template names that collide with inherited or global templates
+	ErrInheritConflict RejectionCode = "InheritConflict"
+
+	// ErrFanOutExceeded rejects FolderTrees producing more RoleBindings than allowed
+	ErrFanOutExceeded RejectionCode = "FanOutExceeded"
+
+	// ErrDestructiveChange rejects unconfirmed updates removing most of the RoleBindings of a FolderTree
+	ErrDestructiveChange RejectionCode = "DestructiveChange"
+
+	// ErrPolicyViolation rejects templates violating a policy rule without a FolderPolicyException
+	ErrPolicyViolation RejectionCode = "PolicyViolation"
+
+	// ErrNamespaceMissing rejects namespaces newly added to folders that do not exist
+	ErrNamespaceMissing RejectionCode = "NamespaceMissing"
+
+	// ErrNamespaceOwned rejects namespaces newly added to folders that another tenancy system owns
+	ErrNamespaceOwned RejectionCode = "NamespaceOwned"
+
+	// ErrPrivilegeEscalation rejects users granting or removing permissions they do not hold themselves
+	ErrPrivilegeEscalation RejectionCode = "PrivilegeEscalation"
+)
+
+// RejectionError is a validation error together with its code
+type RejectionError struct {
+	Code RejectionCode
+	Err  error
+}
+
+// Reject attaches a code to an error, keeping the code of errors that already have one
+func Reject(code RejectionCode, err error) error {
+	var rejectionErr *RejectionError
+	if err == nil || errors.As(err, &rejectionErr) {
+		return err
+	}
+	return &RejectionError{Code: code, Err: err}
+}
+
+// RejectionCodeOf returns the code of a rejection, or an empty code for other errors
+func RejectionCodeOf(err error) RejectionCode {
+	var rejectionErr *RejectionError
+	if errors.As(err, &rejectionErr) {
+		return rejectionErr.Code
+	}
+	return ""
+}
+
+// Error returns the message prefixed by the code
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("[%s] %v", e.Code, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RejectionError) Unwrap() error {
+	return e.Err
+}
+
+// Status implements apierrors.APIStatus, so that controller-runtime returns the code as the reason
+// of the admission response. Field errors are listed as causes.
+func (e *RejectionError) Status() metav1.Status {
+	status := metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusForbidden,
+		Reason:  metav1.StatusReason(e.Code),
+		Message: e.Error(),
+	}
+
+	errs := []error{e.Err}
+	var aggregate utilerrors.Aggregate
+	if errors.As(e.Err, &aggregate) {
+		errs = aggregate.Errors()
+	}
+	var causes []metav1.StatusCause
+	for _, err := range errs {
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseType(fieldErr.Type),
+				Message: fieldErr.ErrorBody(),
+				Field:   fieldErr.Field,
+			})
+		}
+	}
+	if len(causes) > 0 {
+		status.Details = &metav1.StatusDetails{Causes: causes}
+	}
+	return status
+}
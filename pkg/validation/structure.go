@@ -0,0 +1,512 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// treeRoot is the root node of one hierarchy of a FolderTree together with its field path
+type treeRoot struct {
+	Node rbacv1alpha1.TreeNode
+	Path *field.Path
+}
+
+// treeRoots returns spec.tree (if set) followed by every entry of spec.trees
+func treeRoots(spec *rbacv1alpha1.FolderTreeSpec) []treeRoot {
+	var roots []treeRoot
+	if spec.Tree != nil {
+		roots = append(roots, treeRoot{Node: *spec.Tree, Path: field.NewPath("spec", "tree")})
+	}
+	for i, root := range spec.Trees {
+		roots = append(roots, treeRoot{Node: root, Path: field.NewPath("spec", "trees").Index(i)})
+	}
+	return roots
+}
+
+// ValidateStructure validates the split structure design by:
+// 1. Validating the TreeNode structure (hierarchy validation)
+// 2. Validating each Folder in the folders array (data validation with inline role binding templates)
+// 3. Ensuring proper structure and field constraints for all types
+// Errors have the code ErrInvalidStructure.
+func ValidateStructure(spec *rbacv1alpha1.FolderTreeSpec) error {
+	var allErrors field.ErrorList
+
+	// Templates are validated with the defaults filled in, so invalid default subjects
+	// would otherwise be reported once for every template using them
+	if spec.Defaults != nil {
+		defaultsPath := field.NewPath("spec", "defaults", "subjects")
+		if errs := validateSubjects(spec.Defaults.Subjects, "", defaultsPath); len(errs) > 0 {
+			return Reject(ErrInvalidStructure, errs.ToAggregate())
+		}
+	}
+
+	// Validate the tree structures (if they exist)
+	for _, root := range treeRoots(spec) {
+		if err := validateTreeNode(root.Node, root.Path); err != nil {
+			allErrors = append(allErrors, field.InternalError(root.Path, err))
+		}
+	}
+
+	// Validate each folder
+	for i, folder := range spec.Folders {
+		folderPath := field.NewPath("spec", "folders").Index(i)
+		if err := validateFolder(folder, folderPath); err != nil {
+			allErrors = append(allErrors, field.InternalError(folderPath, err))
+		}
+	}
+
+	// Validate each global role binding template
+	for i, roleBindingTemplate := range spec.GlobalRoleBindingTemplates {
+		templatePath := field.NewPath("spec", "globalRoleBindingTemplates").Index(i)
+		if err := ValidateRoleBindingTemplate(roleBindingTemplate, templatePath); err != nil {
+			allErrors = append(allErrors, field.InternalError(templatePath, err))
+		}
+	}
+
+	// Validate the rollout strategy (if it exists)
+	if spec.RolloutStrategy != nil {
+		allErrors = append(allErrors, validateRolloutStrategy(spec.RolloutStrategy, field.NewPath("spec", "rolloutStrategy"))...)
+	}
+
+	// Validate the cluster selector (if it exists)
+	if spec.Clusters != nil {
+		allErrors = append(allErrors, metav1validation.ValidateLabelSelector(spec.Clusters,
+			metav1validation.LabelSelectorValidationOptions{}, field.NewPath("spec", "clusters"))...)
+	}
+
+	// Validate the drift policy
+	switch spec.DriftPolicy {
+	case "", rbacv1alpha1.DriftPolicyEnforce, rbacv1alpha1.DriftPolicyWarn, rbacv1alpha1.DriftPolicyIgnore:
+	default:
+		allErrors = append(allErrors, field.NotSupported(field.NewPath("spec", "driftPolicy"), spec.DriftPolicy,
+			[]rbacv1alpha1.DriftPolicy{rbacv1alpha1.DriftPolicyEnforce, rbacv1alpha1.DriftPolicyWarn, rbacv1alpha1.DriftPolicyIgnore}))
+	}
+
+	// Validate the replacement policy
+	switch spec.ReplacementPolicy {
+	case "", rbacv1alpha1.ReplacementPolicyCreateBeforeDelete, rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate:
+	default:
+		allErrors = append(allErrors, field.NotSupported(field.NewPath("spec", "replacementPolicy"), spec.ReplacementPolicy,
+			[]rbacv1alpha1.ReplacementPolicy{rbacv1alpha1.ReplacementPolicyCreateBeforeDelete, rbacv1alpha1.ReplacementPolicyDeleteBeforeCreate}))
+	}
+
+	if len(allErrors) > 0 {
+		return Reject(ErrInvalidStructure, allErrors.ToAggregate())
+	}
+
+	return nil
+}
+
+// ValidateRoleBindingNames rejects role binding templates whose RoleBinding name, made of the
+// FolderTree name and the template name, is longer than Kubernetes object names may be.
+// Errors have the code ErrInvalidStructure.
+func ValidateRoleBindingNames(folderTree *rbacv1alpha1.FolderTree) error {
+	var allErrors field.ErrorList
+	// Template names may take what the FolderTree name leaves of the maximum name length
+	maxLength := k8svalidation.DNS1123SubdomainMaxLength - len(rbac.RoleBindingName(folderTree.Name, ""))
+	validateName := func(templateName string, fldPath *field.Path) {
+		if len(templateName) > maxLength {
+			allErrors = append(allErrors, field.TooLong(fldPath.Child("name"), templateName, maxLength))
+		}
+	}
+
+	for i, roleBindingTemplate := range folderTree.Spec.GlobalRoleBindingTemplates {
+		validateName(roleBindingTemplate.Name, field.NewPath("spec", "globalRoleBindingTemplates").Index(i))
+	}
+	for i, folder := range folderTree.Spec.Folders {
+		for j, roleBindingTemplate := range folder.RoleBindingTemplates {
+			validateName(roleBindingTemplate.Name, field.NewPath("spec", "folders").Index(i).Child("roleBindingTemplates").Index(j))
+		}
+	}
+
+	if len(allErrors) > 0 {
+		return Reject(ErrInvalidStructure, allErrors.ToAggregate())
+	}
+	return nil
+}
+
+// validateTreeNode validates a single tree node structure
+//
+//nolint:unparam
+func validateTreeNode(treeNode rbacv1alpha1.TreeNode, fldPath *field.Path) error {
+	var allErrors field.ErrorList
+
+	// Validate name
+	if len(treeNode.Name) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
+	} else if !isValidKubernetesName(treeNode.Name) {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), treeNode.Name, "name must be a valid DNS-1123 label"))
+	}
+
+	// Recursively validate subfolders
+	for i, subfolder := range treeNode.Subfolders {
+		subPath := fldPath.Child("subfolders").Index(i)
+		if err := validateTreeNode(subfolder, subPath); err != nil {
+			allErrors = append(allErrors, field.InternalError(subPath, err))
+		}
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors.ToAggregate()
+	}
+
+	return nil
+}
+
+// validateFolder validates a single folder data structure
+func validateFolder(folder rbacv1alpha1.Folder, fldPath *field.Path) error {
+	var allErrors field.ErrorList
+
+	// Validate name
+	if len(folder.Name) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
+	} else if !isValidKubernetesName(folder.Name) {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), folder.Name, "name must be a valid DNS-1123 label"))
+	}
+
+	// Validate role binding templates
+	for i, roleBindingTemplate := range folder.RoleBindingTemplates {
+		roleBindingTemplatePath := fldPath.Child("roleBindingTemplates").Index(i)
+		if err := ValidateRoleBindingTemplate(roleBindingTemplate, roleBindingTemplatePath); err != nil {
+			allErrors = append(allErrors, field.InternalError(roleBindingTemplatePath, err))
+		}
+	}
+
+	// Validate namespaces
+	for i, namespace := range folder.Namespaces {
+		if len(namespace) == 0 {
+			allErrors = append(allErrors, field.Invalid(
+				fldPath.Child("namespaces").Index(i), namespace,
+				"namespace name cannot be empty string"))
+		} else if !isValidKubernetesName(namespace) {
+			allErrors = append(allErrors, field.Invalid(
+				fldPath.Child("namespaces").Index(i), namespace,
+				"namespace must be a valid DNS-1123 label"))
+		}
+	}
+
+	// Validate the namespace pattern
+	if folder.NamespacePattern != "" {
+		if _, err := regexp.Compile(folder.NamespacePattern); err != nil {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("namespacePattern"), folder.NamespacePattern,
+				fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+	}
+
+	// Validate template overrides; their subjects are validated against the overridden template
+	// by ValidateBusinessLogic
+	for i, override := range folder.NamespaceOverrides {
+		overridePath := fldPath.Child("namespaces").Index(folder.NamespaceEntryIndex(i))
+		overridesPath := overridePath.Child("templateOverrides")
+		if len(override.TemplateOverrides) == 0 {
+			allErrors = append(allErrors, field.Required(overridesPath, "a namespace override must override at least one template"))
+		}
+		for _, name := range slices.Sorted(maps.Keys(override.TemplateOverrides)) {
+			if len(name) == 0 {
+				allErrors = append(allErrors, field.Invalid(overridesPath, name, "template name cannot be empty"))
+			} else if len(override.TemplateOverrides[name].Subjects) == 0 {
+				allErrors = append(allErrors, field.Required(overridesPath.Key(name).Child("subjects"), "an override must set subjects"))
+			}
+		}
+	}
+
+	// Validate network policy and resource quota templates
+	var networkPolicyTemplateNames, resourceQuotaTemplateNames []string
+	for _, template := range folder.NetworkPolicyTemplates {
+		networkPolicyTemplateNames = append(networkPolicyTemplateNames, template.Name)
+	}
+	for _, template := range folder.ResourceQuotaTemplates {
+		resourceQuotaTemplateNames = append(resourceQuotaTemplateNames, template.Name)
+	}
+	allErrors = append(allErrors, validateTemplateNames(networkPolicyTemplateNames, fldPath.Child("networkPolicyTemplates"))...)
+	allErrors = append(allErrors, validateTemplateNames(resourceQuotaTemplateNames, fldPath.Child("resourceQuotaTemplates"))...)
+
+	// Validate namespace metadata
+	allErrors = append(allErrors, metav1validation.ValidateLabels(folder.LabelsToApply, fldPath.Child("labelsToApply"))...)
+	allErrors = append(allErrors, apivalidation.ValidateAnnotations(folder.AnnotationsToApply, fldPath.Child("annotationsToApply"))...)
+	allErrors = append(allErrors, validateNamespaceMetadataKeys(folder.LabelsToApply, fldPath.Child("labelsToApply"))...)
+	allErrors = append(allErrors, validateNamespaceMetadataKeys(folder.AnnotationsToApply, fldPath.Child("annotationsToApply"))...)
+
+	// Validate the folder metadata annotated onto namespaces
+	for _, metadata := range []struct {
+		name   string
+		value  string
+		length int
+	}{{"description", folder.Description, 1024}, {"owner", folder.Owner, 256}, {"contact", folder.Contact, 256}} {
+		if len(metadata.value) > metadata.length {
+			allErrors = append(allErrors, field.TooLong(fldPath.Child(metadata.name), "", metadata.length))
+		}
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors.ToAggregate()
+	}
+
+	return nil
+}
+
+// validateNamespaceMetadataKeys rejects keys in the kubernetes.io and k8s.io domains. They drive
+// cluster behavior such as Pod Security admission, which folder metadata must not be able to change.
+func validateNamespaceMetadataKeys(metadata map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		prefix, _, found := strings.Cut(key, "/")
+		if !found {
+			continue
+		}
+		for _, domain := range []string{"kubernetes.io", "k8s.io"} {
+			if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+				allErrors = append(allErrors, field.Forbidden(fldPath.Key(key),
+					fmt.Sprintf("keys in the %s domain are reserved and cannot be applied by folders", domain)))
+			}
+		}
+	}
+	return allErrors
+}
+
+// ValidateRoleBindingTemplate validates a single role binding template structure
+func ValidateRoleBindingTemplate(roleBindingTemplate rbacv1alpha1.RoleBindingTemplate, fldPath *field.Path) error {
+	var allErrors field.ErrorList
+
+	// Validate name
+	if len(roleBindingTemplate.Name) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath.Child("name"), "name cannot be empty"))
+	} else if !isValidKubernetesName(roleBindingTemplate.Name) {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), roleBindingTemplate.Name, "name must be a valid DNS-1123 label"))
+	}
+
+	// Validate subjects (required and must have at least one, unless subjectRefs, a serviceAccountSelector
+	// or spec.defaults.subjects fill them in)
+	if len(roleBindingTemplate.Subjects) == 0 && len(roleBindingTemplate.SubjectRefs) == 0 && roleBindingTemplate.ServiceAccountSelector == nil {
+		allErrors = append(allErrors, field.Required(fldPath.Child("subjects"),
+			"subjects cannot be empty unless subjectRefs, serviceAccountSelector or spec.defaults.subjects is set"))
+	} else {
+		allErrors = append(allErrors, validateSubjects(roleBindingTemplate.Subjects, roleBindingTemplate.SubjectNamespaceMode, fldPath.Child("subjects"))...)
+	}
+
+	// A propagation depth only makes sense for a propagating template
+	if depth := roleBindingTemplate.PropagateDepth; depth != nil {
+		if *depth < 1 {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("propagateDepth"), *depth, "propagateDepth must be at least 1"))
+		}
+		if roleBindingTemplate.Propagate != nil && !*roleBindingTemplate.Propagate {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("propagateDepth"), *depth,
+				"propagateDepth cannot be set when propagate is false"))
+		}
+	}
+
+	// Validate subject references, which name cluster-scoped SubjectMappings
+	seenRefs := make(map[string]bool)
+	for i, ref := range roleBindingTemplate.SubjectRefs {
+		refPath := fldPath.Child("subjectRefs").Index(i)
+		for _, msg := range apivalidation.NameIsDNSSubdomain(ref, false) {
+			allErrors = append(allErrors, field.Invalid(refPath, ref, msg))
+		}
+		if seenRefs[ref] {
+			allErrors = append(allErrors, field.Duplicate(refPath, ref))
+		}
+		seenRefs[ref] = true
+	}
+
+	// Validate the ServiceAccount selector (if it exists)
+	if roleBindingTemplate.ServiceAccountSelector != nil {
+		allErrors = append(allErrors, metav1validation.ValidateLabelSelector(roleBindingTemplate.ServiceAccountSelector,
+			metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("serviceAccountSelector"))...)
+	}
+
+	// Validate subject namespace mode
+	switch roleBindingTemplate.SubjectNamespaceMode {
+	case "", rbacv1alpha1.SubjectNamespaceModeFixed:
+	case rbacv1alpha1.SubjectNamespaceModeTarget:
+		hasServiceAccount := slices.ContainsFunc(roleBindingTemplate.Subjects, func(subject rbacv1.Subject) bool {
+			return subject.Kind == rbacv1.ServiceAccountKind
+		})
+		// ServiceAccounts of SubjectMappings cannot be checked without the cluster
+		if !hasServiceAccount && len(roleBindingTemplate.SubjectRefs) == 0 && roleBindingTemplate.ServiceAccountSelector == nil {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("subjectNamespaceMode"), roleBindingTemplate.SubjectNamespaceMode,
+				"subjectNamespaceMode Target requires at least one ServiceAccount subject, a subjectRef or a serviceAccountSelector"))
+		}
+	default:
+		allErrors = append(allErrors, field.NotSupported(fldPath.Child("subjectNamespaceMode"), roleBindingTemplate.SubjectNamespaceMode,
+			[]rbacv1alpha1.SubjectNamespaceMode{rbacv1alpha1.SubjectNamespaceModeFixed, rbacv1alpha1.SubjectNamespaceModeTarget}))
+	}
+
+	// Validate the template metadata annotated onto RoleBindings
+	for _, metadata := range []struct {
+		name   string
+		value  string
+		length int
+	}{{"description", roleBindingTemplate.Description, 1024}, {"reference", roleBindingTemplate.Reference, 256}} {
+		if len(metadata.value) > metadata.length {
+			allErrors = append(allErrors, field.TooLong(fldPath.Child(metadata.name), "", metadata.length))
+		}
+	}
+
+	// Validate roleRef (required)
+	if len(roleBindingTemplate.RoleRef.Kind) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath.Child("roleRef").Child("kind"), "roleRef.kind cannot be empty"))
+	}
+	if len(roleBindingTemplate.RoleRef.Name) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath.Child("roleRef").Child("name"), "roleRef.name cannot be empty"))
+	}
+	if roleBindingTemplate.RoleRef.APIGroup != "rbac.authorization.k8s.io" {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("roleRef").Child("apiGroup"), roleBindingTemplate.RoleRef.APIGroup, "roleRef.apiGroup must be 'rbac.authorization.k8s.io'"))
+	}
+
+	if len(allErrors) > 0 {
+		return allErrors.ToAggregate()
+	}
+
+	return nil
+}
+
+// validateSubjects validates the subjects of a role binding template or of spec.defaults,
+// resolving the namespace of ServiceAccount subjects with the given subject namespace mode
+func validateSubjects(subjects []rbacv1.Subject, mode rbacv1alpha1.SubjectNamespaceMode, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	// The same subject listed twice only adds a redundant entry to every RoleBinding
+	subjectPaths := make(map[rbacv1.Subject]*field.Path)
+	for i, subject := range subjects {
+		subjectPath := fldPath.Index(i)
+
+		if existingPath, exists := subjectPaths[subject]; exists {
+			allErrors = append(allErrors, field.Duplicate(subjectPath,
+				fmt.Sprintf("subject %s '%s' already listed at %s", subject.Kind, subject.Name, existingPath)))
+		} else {
+			subjectPaths[subject] = subjectPath
+		}
+
+		// Validate subject kind
+		if len(subject.Kind) == 0 {
+			allErrors = append(allErrors, field.Required(subjectPath.Child("kind"), "kind cannot be empty"))
+		}
+
+		// Validate subject name
+		if len(subject.Name) == 0 {
+			allErrors = append(allErrors, field.Required(subjectPath.Child("name"), "name cannot be empty"))
+		}
+
+		// Validate template variables in subject name and namespace
+		if rbac.IsSubjectTemplate(subject.Name) {
+			if err := rbac.ValidateSubjectTemplate(subject.Name); err != nil {
+				allErrors = append(allErrors, field.Invalid(subjectPath.Child("name"), subject.Name,
+					fmt.Sprintf("invalid subject template (supported variables: .tree.name, .folder.name, .namespace): %v", err)))
+			}
+		}
+		if rbac.IsSubjectTemplate(subject.Namespace) {
+			if err := rbac.ValidateSubjectTemplate(subject.Namespace); err != nil {
+				allErrors = append(allErrors, field.Invalid(subjectPath.Child("namespace"), subject.Namespace,
+					fmt.Sprintf("invalid subject template (supported variables: .tree.name, .folder.name, .namespace): %v", err)))
+			}
+		}
+
+		// Validate apiGroup for Group and User kinds
+		if (subject.Kind == "Group" || subject.Kind == "User") && subject.APIGroup != "rbac.authorization.k8s.io" {
+			allErrors = append(allErrors, field.Invalid(subjectPath.Child("apiGroup"), subject.APIGroup, "apiGroup must be 'rbac.authorization.k8s.io' for Group and User kinds"))
+		}
+
+		// Validate the namespace of ServiceAccount subjects against the subject namespace mode
+		if subject.Kind == rbacv1.ServiceAccountKind {
+			targetMode := mode == rbacv1alpha1.SubjectNamespaceModeTarget
+			if targetMode && len(subject.Namespace) > 0 {
+				allErrors = append(allErrors, field.Invalid(subjectPath.Child("namespace"), subject.Namespace,
+					"namespace must be empty when subjectNamespaceMode is Target"))
+			} else if !targetMode && len(subject.Namespace) == 0 {
+				allErrors = append(allErrors, field.Required(subjectPath.Child("namespace"),
+					"namespace is required for ServiceAccount subjects unless subjectNamespaceMode is Target"))
+			}
+		}
+	}
+
+	return allErrors
+}
+
+// validateRolloutStrategy validates that a rollout strategy limits the wave size
+// and uses sensible values
+func validateRolloutStrategy(strategy *rbacv1alpha1.RolloutStrategy, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if strategy.MaxNamespacesPerWave == nil && strategy.MaxPercentPerWave == nil {
+		allErrors = append(allErrors, field.Required(fldPath,
+			"at least one of maxNamespacesPerWave or maxPercentPerWave must be set"))
+	}
+
+	if strategy.MaxNamespacesPerWave != nil && *strategy.MaxNamespacesPerWave < 1 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxNamespacesPerWave"),
+			*strategy.MaxNamespacesPerWave, "must be at least 1"))
+	}
+
+	if strategy.MaxPercentPerWave != nil && (*strategy.MaxPercentPerWave < 1 || *strategy.MaxPercentPerWave > 100) {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("maxPercentPerWave"),
+			*strategy.MaxPercentPerWave, "must be between 1 and 100"))
+	}
+
+	if strategy.MinWaveInterval != nil && strategy.MinWaveInterval.Duration < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("minWaveInterval"),
+			strategy.MinWaveInterval.Duration.String(), "must not be negative"))
+	}
+
+	return allErrors
+}
+
+// isValidKubernetesName validates that a name follows DNS-1123 label format
+func isValidKubernetesName(name string) bool {
+	// DNS-1123 label: lowercase alphanumeric characters or '-',
+	// must start and end with alphanumeric character
+	if len(name) == 0 || len(name) > 63 {
+		return false
+	}
+
+	// Regex for DNS-1123 label
+	dnsLabelRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	return dnsLabelRegex.MatchString(name)
+}
+
+// validateTemplateNames validates the names of the network policy or resource quota templates of a
+// folder. The specs themselves are validated by the API server when the controller creates the objects.
+func validateTemplateNames(names []string, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+	seen := make(map[string]bool)
+	for i, name := range names {
+		namePath := fldPath.Index(i).Child("name")
+		switch {
+		case name == "":
+			allErrors = append(allErrors, field.Required(namePath, "name cannot be empty"))
+		case !isValidKubernetesName(name):
+			allErrors = append(allErrors, field.Invalid(namePath, name, "name must be a valid DNS-1123 label"))
+		case seen[name]:
+			allErrors = append(allErrors, field.Duplicate(namePath, name))
+		}
+		seen[name] = true
+	}
+	return allErrors
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// ValidateUniqueness checks that the folder names, tree node names and namespaces of a FolderTree
+// are not used by other FolderTrees. Others of the same name are skipped, since they are the
+// FolderTree itself. With Options.AllowNamespaceOverlap, shared namespaces are returned as warnings
+// naming the FolderTree managing them by priority. The returned error is a *RejectionError.
+func ValidateUniqueness(newTree *rbacv1alpha1.FolderTree, others []rbacv1alpha1.FolderTree, opts Options) ([]string, error) {
+	// Collect folder names and namespaces from the new tree
+	newFolderNames := make(map[string]bool)
+	newNamespaces := make(map[string]bool)
+	newTreeNodeNames := make(map[string]bool)
+
+	// Collect from folders
+	for _, folder := range newTree.Spec.Folders {
+		newFolderNames[folder.Name] = true
+		for _, ns := range folder.Namespaces {
+			newNamespaces[ns] = true
+		}
+	}
+
+	// Collect from tree nodes
+	var collectFromTreeNode func(rbacv1alpha1.TreeNode)
+	collectFromTreeNode = func(treeNode rbacv1alpha1.TreeNode) {
+		newTreeNodeNames[treeNode.Name] = true
+		for _, subfolder := range treeNode.Subfolders {
+			collectFromTreeNode(subfolder)
+		}
+	}
+
+	for _, root := range newTree.Spec.Roots() {
+		collectFromTreeNode(root)
+	}
+
+	// Check against the other trees
+	var allErrors field.ErrorList
+	var warnings []string
+	duplicateNames := false
+	for _, existingTree := range others {
+		// Skip self when updating
+		if existingTree.Name == newTree.Name {
+			continue
+		}
+
+		// Check existing folders for conflicts
+		for _, folder := range existingTree.Spec.Folders {
+			// Check for folder name conflicts
+			if newFolderNames[folder.Name] {
+				duplicateNames = true
+				allErrors = append(allErrors, field.Duplicate(
+					field.NewPath("spec", "folders"),
+					fmt.Sprintf("folder name '%s' already exists in FolderTree '%s'", folder.Name, existingTree.Name)))
+			}
+
+			// Check for namespace conflicts
+			for _, ns := range folder.Namespaces {
+				if !newNamespaces[ns] {
+					continue
+				}
+				if opts.AllowNamespaceOverlap {
+					winner := newTree.Name
+					if rbac.Outranks(&existingTree, newTree) {
+						winner = existingTree.Name
+					}
+					warnings = append(warnings, fmt.Sprintf("namespace '%s' is also assigned in FolderTree '%s'; FolderTree '%s' manages it by priority",
+						ns, existingTree.Name, winner))
+				} else {
+					allErrors = append(allErrors, field.Duplicate(
+						field.NewPath("spec", "folders"),
+						fmt.Sprintf("namespace '%s' is already assigned in FolderTree '%s'", ns, existingTree.Name)))
+				}
+			}
+		}
+
+		// Check existing tree nodes for conflicts
+		var checkExistingTreeNode func(rbacv1alpha1.TreeNode)
+		checkExistingTreeNode = func(treeNode rbacv1alpha1.TreeNode) {
+			if newTreeNodeNames[treeNode.Name] {
+				duplicateNames = true
+				allErrors = append(allErrors, field.Duplicate(
+					field.NewPath("spec", "trees"),
+					fmt.Sprintf("tree node name '%s' already exists in FolderTree '%s'", treeNode.Name, existingTree.Name)))
+			}
+			for _, subfolder := range treeNode.Subfolders {
+				checkExistingTreeNode(subfolder)
+			}
+		}
+
+		for _, root := range existingTree.Spec.Roots() {
+			checkExistingTreeNode(root)
+		}
+	}
+
+	if len(allErrors) > 0 {
+		if duplicateNames {
+			return nil, Reject(ErrDuplicateFolder, allErrors.ToAggregate())
+		}
+		return nil, Reject(ErrDuplicateNamespace, allErrors.ToAggregate())
+	}
+
+	return warnings, nil
+}
+
+// Result is the outcome of validating one FolderTree of a set
+type Result struct {
+	// Name is the name of the FolderTree
+	Name string
+
+	// Warnings are the namespaces shared with other FolderTrees under Options.AllowNamespaceOverlap
+	Warnings []string
+
+	// Errors holds every *RejectionError of the FolderTree; it is empty for valid FolderTrees
+	Errors []error
+}
+
+// ValidateFolderTrees validates FolderTrees meant to be applied together, e.g. all FolderTrees of a
+// GitOps repository. Besides the spec of each, the uniqueness of folder names, tree node names and
+// namespaces is checked across the set and the existing FolderTrees, e.g. those in the cluster.
+// Submitted FolderTrees replace existing ones of the same name. Unlike admission, which stops at the
+// first failing check, all errors of every FolderTree are returned, in the order of folderTrees.
+func ValidateFolderTrees(folderTrees []*rbacv1alpha1.FolderTree, existing []rbacv1alpha1.FolderTree, opts Options) []Result {
+	submitted := make(map[string]int)
+	var others []rbacv1alpha1.FolderTree
+	for _, folderTree := range folderTrees {
+		submitted[folderTree.Name]++
+		others = append(others, *folderTree)
+	}
+	for _, folderTree := range existing {
+		if submitted[folderTree.Name] == 0 {
+			others = append(others, folderTree)
+		}
+	}
+
+	results := make([]Result, 0, len(folderTrees))
+	for _, folderTree := range folderTrees {
+		result := Result{Name: folderTree.Name}
+		if submitted[folderTree.Name] > 1 {
+			result.Errors = append(result.Errors, Reject(ErrInvalidSpec, field.Duplicate(field.NewPath("metadata", "name"),
+				fmt.Sprintf("FolderTree '%s' is defined %d times", folderTree.Name, submitted[folderTree.Name]))))
+		}
+		if err := ValidateFolderTreeSpec(&folderTree.Spec, opts); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		warnings, err := ValidateUniqueness(folderTree, others, opts)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		result.Warnings = warnings
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates FolderTree specs without access to a cluster, so that CI pipelines
+// and the CLI can check FolderTree YAML before applying it. The admission webhook runs the same
+// checks, followed by those that need the cluster: uniqueness across FolderTrees, namespace
+// existence, policy exceptions and privilege escalation. Uniqueness can also be checked offline
+// across a set of FolderTrees with ValidateFolderTrees.
+package validation
+
+import (
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// DefaultMaxTreeDepth is the maximum tree depth when Options.MaxTreeDepth is not set
+const DefaultMaxTreeDepth = 10
+
+// Default size limits of a FolderTree spec, used for the fields of Limits that are not set
+const (
+	DefaultMaxFolders              = 100
+	DefaultMaxTreeNodes            = 100
+	DefaultMaxNamespaces           = 500
+	DefaultMaxRoleBindingTemplates = 200
+
+	// DefaultMaxNamespaceGroupRoleBindings bounds the RoleBindings namespace groups receive from
+	// their descendants, which multiply with every namespace of the group
+	DefaultMaxNamespaceGroupRoleBindings = 1000
+)
+
+// Limits caps the size of a FolderTree spec. Fields that are zero or negative use the defaults.
+type Limits struct {
+	// MaxFolders is the maximum number of folders
+	MaxFolders int `json:"maxFolders,omitempty"`
+
+	// MaxTreeNodes is the maximum number of nodes across all trees
+	MaxTreeNodes int `json:"maxTreeNodes,omitempty"`
+
+	// MaxNamespaces is the maximum number of namespaces listed across all folders
+	MaxNamespaces int `json:"maxNamespaces,omitempty"`
+
+	// MaxRoleBindingTemplates is the maximum number of role binding templates across all folders,
+	// global templates included
+	MaxRoleBindingTemplates int `json:"maxRoleBindingTemplates,omitempty"`
+
+	// MaxNamespaceGroupRoleBindings is the maximum number of RoleBindings that folders with
+	// inheritNamespaces receive from the templates of their descendants, summed over those folders
+	MaxNamespaceGroupRoleBindings int `json:"maxNamespaceGroupRoleBindings,omitempty"`
+}
+
+// Resolved returns the limits with the defaults filled in for the fields that are not set
+func (l Limits) Resolved() Limits {
+	return Limits{
+		MaxFolders:                    orDefault(l.MaxFolders, DefaultMaxFolders),
+		MaxTreeNodes:                  orDefault(l.MaxTreeNodes, DefaultMaxTreeNodes),
+		MaxNamespaces:                 orDefault(l.MaxNamespaces, DefaultMaxNamespaces),
+		MaxRoleBindingTemplates:       orDefault(l.MaxRoleBindingTemplates, DefaultMaxRoleBindingTemplates),
+		MaxNamespaceGroupRoleBindings: orDefault(l.MaxNamespaceGroupRoleBindings, DefaultMaxNamespaceGroupRoleBindings),
+	}
+}
+
+// orDefault returns limit, or fallback when limit is not set
+func orDefault(limit, fallback int) int {
+	if limit <= 0 {
+		return fallback
+	}
+	return limit
+}
+
+// SpecSize is the size of a FolderTree spec in the terms of Limits
+type SpecSize struct {
+	Folders              int
+	TreeNodes            int
+	Namespaces           int
+	RoleBindingTemplates int
+
+	// NamespaceGroupRoleBindings counts, for every folder with inheritNamespaces, its namespaces
+	// times the role binding templates of its descendants
+	NamespaceGroupRoleBindings int
+}
+
+// MeasureSpec returns the size of a FolderTree spec
+func MeasureSpec(spec *rbacv1alpha1.FolderTreeSpec) SpecSize {
+	size := SpecSize{Folders: len(spec.Folders), RoleBindingTemplates: len(spec.GlobalRoleBindingTemplates)}
+
+	var countTreeNodes func(rbacv1alpha1.TreeNode)
+	countTreeNodes = func(treeNode rbacv1alpha1.TreeNode) {
+		size.TreeNodes++
+		for _, subfolder := range treeNode.Subfolders {
+			countTreeNodes(subfolder)
+		}
+	}
+	for _, root := range spec.Roots() {
+		countTreeNodes(root)
+	}
+
+	folderMap := make(map[string]rbacv1alpha1.Folder)
+	for _, folder := range spec.Folders {
+		folderMap[folder.Name] = folder
+		size.Namespaces += len(folder.Namespaces)
+		size.RoleBindingTemplates += len(folder.RoleBindingTemplates)
+	}
+
+	// Nested namespace groups each receive the templates of their own descendants, so the number
+	// of RoleBindings they add grows with both the depth and the breadth of the tree
+	var countGroupRoleBindings func(rbacv1alpha1.TreeNode)
+	countGroupRoleBindings = func(treeNode rbacv1alpha1.TreeNode) {
+		if folder := folderMap[treeNode.Name]; folder.InheritNamespaces {
+			size.NamespaceGroupRoleBindings += len(folder.Namespaces) * len(rbac.DescendantTemplates(treeNode, folderMap))
+		}
+		for _, subfolder := range treeNode.Subfolders {
+			countGroupRoleBindings(subfolder)
+		}
+	}
+	for _, root := range spec.Roots() {
+		countGroupRoleBindings(root)
+	}
+	return size
+}
+
+// Options holds the settings of the controller that validation depends on
+type Options struct {
+	// ExcludedNamespaces may not be assigned to folders of any FolderTree
+	ExcludedNamespaces []string
+
+	// MaxTreeDepth is the maximum number of levels of a tree, counting the root as level 1.
+	// Defaults to DefaultMaxTreeDepth.
+	MaxTreeDepth int
+
+	// APIServerListValidation skips the uniqueness checks of folder and role binding template
+	// names, which the API server already enforces through the list-map markers of the CRD.
+	// The webhook sets it; the CLI validates files that have not been through the API server.
+	APIServerListValidation bool
+
+	// Limits caps the size of the spec; the zero value applies the default limits
+	Limits Limits
+
+	// AllowNamespaceOverlap accepts namespaces assigned in more than one FolderTree, reporting
+	// them as warnings instead (see ValidateUniqueness)
+	AllowNamespaceOverlap bool
+}
+
+// maxTreeDepth returns the maximum number of levels of a tree
+func (o Options) maxTreeDepth() int {
+	if o.MaxTreeDepth <= 0 {
+		return DefaultMaxTreeDepth
+	}
+	return o.MaxTreeDepth
+}
+
+// ValidateFolderTreeSpec validates a FolderTree spec as the webhook does before its cluster checks,
+// with spec.defaults filled into the role binding templates first. Business logic is only validated
+// once the structure is valid. The returned error is a *RejectionError.
+func ValidateFolderTreeSpec(spec *rbacv1alpha1.FolderTreeSpec, opts Options) error {
+	resolved := rbac.WithDefaults(&rbacv1alpha1.FolderTree{Spec: *spec})
+	if err := ValidateStructure(&resolved.Spec); err != nil {
+		return err
+	}
+	return ValidateBusinessLogic(&resolved.Spec, opts)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+func TestValidation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Validation Package Suite")
+}
+
+var _ = Describe("ValidateFolderTreeSpec", func() {
+	viewers := func() rbacv1alpha1.RoleBindingTemplate {
+		return rbacv1alpha1.RoleBindingTemplate{
+			Name:     "viewers",
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}},
+			RoleRef:  rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "view"},
+		}
+	}
+
+	var spec *rbacv1alpha1.FolderTreeSpec
+
+	BeforeEach(func() {
+		spec = &rbacv1alpha1.FolderTreeSpec{
+			Tree: &rbacv1alpha1.TreeNode{Name: "platform", Subfolders: []rbacv1alpha1.TreeNode{{Name: "web"}}},
+			Folders: []rbacv1alpha1.Folder{
+				{Name: "platform", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{viewers()}},
+				{Name: "web", Namespaces: []string{"web-prod"}},
+			},
+		}
+	})
+
+	It("should accept a valid spec", func() {
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+	})
+
+	It("should fill in spec.defaults before validating templates", func() {
+		spec.Folders[0].RoleBindingTemplates[0].Subjects = nil
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring("subjects cannot be empty unless subjectRefs, serviceAccountSelector or spec.defaults.subjects is set"))
+
+		spec.Defaults = &rbacv1alpha1.FolderTreeDefaults{
+			Subjects: []rbacv1.Subject{{Kind: "Group", Name: "auditors", APIGroup: "rbac.authorization.k8s.io"}},
+		}
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+		Expect(spec.Folders[0].RoleBindingTemplates[0].Subjects).To(BeEmpty())
+	})
+
+	It("should accept a serviceAccountSelector in place of subjects and validate it", func() {
+		spec.Folders[0].RoleBindingTemplates[0].Subjects = nil
+		spec.Folders[0].RoleBindingTemplates[0].ServiceAccountSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"ci": "true"}}
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		spec.Folders[0].RoleBindingTemplates[0].ServiceAccountSelector.MatchLabels = map[string]string{"ci": "not valid"}
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring("serviceAccountSelector.matchLabels"))
+	})
+
+	It("should classify business logic errors by their most specific problem", func() {
+		spec.Folders = append(spec.Folders, rbacv1alpha1.Folder{Name: "web", Namespaces: []string{"web-dev"}})
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrDuplicateFolder))
+		Expect(err.Error()).To(HavePrefix("[DuplicateFolder] "))
+
+		spec.Folders = spec.Folders[:2]
+		spec.Folders[1].RoleBindingTemplates = []rbacv1alpha1.RoleBindingTemplate{viewers()}
+		spec.Folders[0].RoleBindingTemplates[0].Propagate = ptr.To(true)
+		Expect(RejectionCodeOf(ValidateFolderTreeSpec(spec, Options{}))).To(Equal(ErrInheritConflict))
+	})
+
+	It("should leave duplicate list keys to the API server when asked to", func() {
+		spec.Folders = append(spec.Folders, rbacv1alpha1.Folder{Name: "web", Namespaces: []string{"web-dev"}})
+		Expect(ValidateFolderTreeSpec(spec, Options{APIServerListValidation: true})).To(Succeed())
+
+		// Namespaces assigned to two folders are not a duplicate list key
+		spec.Folders[2].Namespaces = spec.Folders[1].Namespaces
+		err := ValidateFolderTreeSpec(spec, Options{APIServerListValidation: true})
+		Expect(err).To(MatchError(ContainSubstring("already assigned")))
+	})
+
+	It("should reject subjects listed more than once", func() {
+		template := &spec.Folders[0].RoleBindingTemplates[0]
+		template.Subjects = append(template.Subjects, template.Subjects[0])
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring(
+			"spec.folders[0].roleBindingTemplates[0].subjects[1]: Duplicate value: \"subject Group 'viewers' already listed at spec.folders[0].roleBindingTemplates[0].subjects[0]\""))
+
+		// The same name in another namespace or of another kind is a different subject
+		template.Subjects[1] = rbacv1.Subject{Kind: "User", Name: "viewers", APIGroup: "rbac.authorization.k8s.io"}
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+	})
+
+	It("should reject a propagateDepth on a template that does not propagate", func() {
+		spec.Folders[0].RoleBindingTemplates[0].PropagateDepth = ptr.To[int32](1)
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		spec.Folders[0].RoleBindingTemplates[0].Propagate = ptr.To(false)
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring("propagateDepth cannot be set when propagate is false"))
+
+		spec.Folders[0].RoleBindingTemplates[0].Propagate = nil
+		spec.Folders[0].RoleBindingTemplates[0].PropagateDepth = ptr.To[int32](0)
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(MatchError(ContainSubstring("propagateDepth must be at least 1")))
+	})
+
+	It("should validate template overrides against the overridden template", func() {
+		override := func(template string, subjects ...rbacv1.Subject) {
+			spec.Folders[1].NamespaceOverrides = []rbacv1alpha1.NamespaceOverride{{
+				Name:              spec.Folders[1].Namespaces[0],
+				TemplateOverrides: map[string]rbacv1alpha1.TemplateOverride{template: {Subjects: subjects}},
+			}}
+		}
+		oncall := rbacv1.Subject{Kind: "Group", Name: "web-oncall", APIGroup: "rbac.authorization.k8s.io"}
+
+		override("viewers", oncall)
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		override("viewers")
+		err := ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidStructure))
+		Expect(err.Error()).To(ContainSubstring("spec.folders[1].namespaces[0].templateOverrides[viewers].subjects: Required value"))
+
+		override("editors", oncall)
+		err = ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidSpec))
+		Expect(err.Error()).To(ContainSubstring("role binding template 'editors' is not defined by folder 'web', its ancestors or the global templates"))
+
+		// Overrides only apply to namespaces listed in the folder, once each
+		override("viewers", oncall)
+		spec.Folders[1].NamespaceOverrides[0].Name = "web-staging"
+		err = ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidSpec))
+		Expect(err.Error()).To(ContainSubstring("namespace 'web-staging' is not listed in the namespaces of folder 'web'"))
+
+		// The API server no longer keeps namespace entries unique, since they may be objects
+		override("viewers", oncall)
+		spec.Folders[1].Namespaces = append(spec.Folders[1].Namespaces, spec.Folders[1].Namespaces[0])
+		err = ValidateFolderTreeSpec(spec, Options{APIServerListValidation: true})
+		Expect(err).To(MatchError(ContainSubstring("spec.folders[1].namespaces[1]: Duplicate value")))
+		spec.Folders[1].Namespaces = spec.Folders[1].Namespaces[:1]
+
+		// ServiceAccount subjects follow the subject namespace mode of the template
+		spec.Folders[0].RoleBindingTemplates[0].SubjectNamespaceMode = rbacv1alpha1.SubjectNamespaceModeTarget
+		spec.Folders[0].RoleBindingTemplates[0].Subjects = []rbacv1.Subject{{Kind: "ServiceAccount", Name: "deployer"}}
+		override("viewers", rbacv1.Subject{Kind: "ServiceAccount", Name: "deployer", Namespace: "ci"})
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(MatchError(ContainSubstring("namespace must be empty when subjectNamespaceMode is Target")))
+	})
+
+	It("should validate namespace groups against the templates of their descendants", func() {
+		webDevs := viewers()
+		webDevs.Name = "web-devs"
+		spec.Folders[0].InheritNamespaces = true
+		spec.Folders[0].Namespaces = []string{"platform-shared", "platform-tools"}
+		spec.Folders[1].RoleBindingTemplates = []rbacv1alpha1.RoleBindingTemplate{webDevs}
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		// The namespaces of the group may override the templates of its descendants
+		spec.Folders[0].NamespaceOverrides = []rbacv1alpha1.NamespaceOverride{{
+			Name: "platform-shared",
+			TemplateOverrides: map[string]rbacv1alpha1.TemplateOverride{
+				"web-devs": {Subjects: []rbacv1.Subject{{Kind: "Group", Name: "web-oncall", APIGroup: "rbac.authorization.k8s.io"}}},
+			},
+		}}
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		err := ValidateFolderTreeSpec(spec, Options{Limits: Limits{MaxNamespaceGroupRoleBindings: 1}})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidSpec))
+		Expect(err.Error()).To(ContainSubstring("spec.folders: Too many: 2: must have at most 1 items"))
+
+		// Sibling subtrees may reuse template names, unless a common ancestor receives both
+		spec.Tree.Subfolders = append(spec.Tree.Subfolders, rbacv1alpha1.TreeNode{Name: "api"})
+		spec.Folders = append(spec.Folders, rbacv1alpha1.Folder{Name: "api", RoleBindingTemplates: []rbacv1alpha1.RoleBindingTemplate{webDevs}})
+		err = ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInheritConflict))
+		Expect(err.Error()).To(ContainSubstring(
+			"spec.folders[2].roleBindingTemplates[0].name: Invalid value: \"web-devs\": role binding template name 'web-devs' conflicts with a template of subfolder 'web' received by folder 'platform'"))
+
+		spec.Folders[0].InheritNamespaces = false
+		spec.Folders[0].NamespaceOverrides = nil
+		Expect(ValidateFolderTreeSpec(spec, Options{})).To(Succeed())
+
+		spec.Folders[1].InheritNamespaces = true
+		err = ValidateFolderTreeSpec(spec, Options{})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidSpec))
+		Expect(err.Error()).To(ContainSubstring("folder 'web' has no subfolders whose templates its namespaces could inherit"))
+	})
+
+	It("should apply the controller options", func() {
+		err := ValidateFolderTreeSpec(spec, Options{ExcludedNamespaces: []string{"web-prod"}})
+		Expect(RejectionCodeOf(err)).To(Equal(ErrInvalidSpec))
+		Expect(err.Error()).To(ContainSubstring("excluded from FolderTrees by the controller configuration"))
+
+		err = ValidateFolderTreeSpec(spec, Options{MaxTreeDepth: 1})
+		Expect(err).To(MatchError(ContainSubstring("exceeds the maximum tree depth of 1")))
+	})
+})
+
+var _ = Describe("ValidateFolderTrees", func() {
+	folderTree := func(name, folder string, namespaces ...string) *rbacv1alpha1.FolderTree {
+		return &rbacv1alpha1.FolderTree{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbacv1alpha1.FolderTreeSpec{
+				Folders: []rbacv1alpha1.Folder{{Name: folder, Namespaces: namespaces}},
+			},
+		}
+	}
+
+	It("should check uniqueness across the set and report every error", func() {
+		web := folderTree("web", "web", "web-prod")
+		billing := folderTree("billing", "web", "web-prod")
+		billing.Spec.Folders = append(billing.Spec.Folders, rbacv1alpha1.Folder{Name: "Invalid"})
+		data := folderTree("data", "data", "data-prod")
+
+		results := ValidateFolderTrees([]*rbacv1alpha1.FolderTree{web, billing, data}, nil, Options{})
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].Name).To(Equal("web"))
+		Expect(results[0].Errors).To(HaveLen(1))
+		Expect(RejectionCodeOf(results[0].Errors[0])).To(Equal(ErrDuplicateFolder))
+		Expect(results[0].Errors[0].Error()).To(ContainSubstring("folder name 'web' already exists in FolderTree 'billing'"))
+		Expect(results[0].Errors[0].Error()).To(ContainSubstring("namespace 'web-prod' is already assigned in FolderTree 'billing'"))
+
+		// The structure error and the conflicts are both reported
+		Expect(results[1].Errors).To(HaveLen(2))
+		Expect(RejectionCodeOf(results[1].Errors[0])).To(Equal(ErrInvalidStructure))
+		Expect(RejectionCodeOf(results[1].Errors[1])).To(Equal(ErrDuplicateFolder))
+
+		Expect(results[2].Errors).To(BeEmpty())
+	})
+
+	It("should replace existing FolderTrees with submitted ones of the same name", func() {
+		existing := []rbacv1alpha1.FolderTree{*folderTree("web", "web", "web-prod"), *folderTree("data", "data", "data-prod")}
+
+		// The submitted web FolderTree moves web-prod to another folder
+		results := ValidateFolderTrees([]*rbacv1alpha1.FolderTree{folderTree("web", "frontend", "web-prod")}, existing, Options{})
+		Expect(results[0].Errors).To(BeEmpty())
+
+		results = ValidateFolderTrees([]*rbacv1alpha1.FolderTree{folderTree("analytics", "analytics", "data-prod")}, existing, Options{})
+		Expect(results[0].Errors).To(HaveLen(1))
+		Expect(RejectionCodeOf(results[0].Errors[0])).To(Equal(ErrDuplicateNamespace))
+
+		results = ValidateFolderTrees([]*rbacv1alpha1.FolderTree{folderTree("analytics", "analytics", "data-prod")}, existing,
+			Options{AllowNamespaceOverlap: true})
+		Expect(results[0].Errors).To(BeEmpty())
+		Expect(results[0].Warnings).To(ConsistOf(ContainSubstring("namespace 'data-prod' is also assigned in FolderTree 'data'")))
+	})
+
+	It("should reject FolderTrees defined more than once", func() {
+		results := ValidateFolderTrees([]*rbacv1alpha1.FolderTree{folderTree("web", "web", "web-prod"), folderTree("web", "web", "web-prod")}, nil, Options{})
+		for _, result := range results {
+			Expect(result.Errors).To(HaveLen(1))
+			Expect(result.Errors[0].Error()).To(ContainSubstring("FolderTree 'web' is defined 2 times"))
+		}
+	})
+})
@@ -381,6 +381,20 @@ var _ = Describe("Manager", Ordered, func() {
 			Eventually(verifyCertManager).Should(Succeed())
 		})
 
+		It("should have CA injection for mutating webhooks", func() {
+			By("checking CA injection for mutating webhooks")
+			verifyCAInjection := func(g Gomega) {
+				cmd := exec.Command("kubectl", "get",
+					"mutatingwebhookconfigurations.admissionregistration.k8s.io",
+					"foldertree-mutating-webhook-configuration",
+					"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
+				mwhOutput, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(len(mwhOutput)).To(BeNumerically(">", 10))
+			}
+			Eventually(verifyCAInjection).Should(Succeed())
+		})
+
 		It("should have CA injection for validating webhooks", func() {
 			By("checking CA injection for validating webhooks")
 			verifyCAInjection := func(g Gomega) {
@@ -735,7 +749,7 @@ spec:
 					cmd.Stdin = strings.NewReader(invalidYAML)
 					_, err := utils.Run(cmd)
 					Expect(err).To(HaveOccurred(), "Should have rejected duplicate folder names")
-					Expect(err.Error()).To(ContainSubstring("already used"))
+					Expect(err.Error()).To(ContainSubstring("Duplicate value"))
 				})
 
 				It("should reject FolderTree with namespace conflicts", func() {
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/folders/test/utils"
+)
+
+// helmNamespace is the namespace the Helm chart is installed into, separate from the namespace
+// of the kustomize deployment so that its deletion does not race the chart install
+const helmNamespace = "foldertree-helm-system"
+
+// helmTreeSelector restricts the chart installation to FolderTrees labeled with it
+const helmTreeSelector = "e2e.foldertree.kubevirt.io/shard=helm"
+
+var _ = Describe("Helm chart", Ordered, func() {
+	helmNamespaces := []string{"ft-helm-selected", "ft-helm-ignored"}
+
+	BeforeAll(func() {
+		if _, err := exec.LookPath("helm"); err != nil {
+			Skip("helm is not installed")
+		}
+
+		By("creating the chart namespace with the restricted security policy")
+		_, err := utils.Run(exec.Command("kubectl", "create", "ns", helmNamespace))
+		Expect(err).NotTo(HaveOccurred(), "Failed to create namespace")
+		_, err = utils.Run(exec.Command("kubectl", "label", "--overwrite", "ns", helmNamespace,
+			"pod-security.kubernetes.io/enforce=restricted"))
+		Expect(err).NotTo(HaveOccurred(), "Failed to label namespace with restricted policy")
+
+		By("installing the chart")
+		helmArgs := strings.Join([]string{
+			"--set controllerManager.replicas=2",
+			"--set crd.keep=false",
+			"--set webhook.failurePolicy=Fail",
+			"--set-string controller.folderTreeSelector=" + helmTreeSelector,
+		}, " ")
+		cmd := exec.Command("make", "helm-deploy", fmt.Sprintf("IMG=%s", projectImage),
+			"HELM_NAMESPACE="+helmNamespace, "HELM_ARGS="+helmArgs)
+		_, err = utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred(), "Failed to install the Helm chart")
+
+		for _, ns := range helmNamespaces {
+			_, err = utils.Run(exec.Command("kubectl", "create", "ns", ns))
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	AfterAll(func() {
+		By("deleting the FolderTrees and their namespaces")
+		_, _ = utils.Run(exec.Command("kubectl", "delete", "foldertree", "helm-selected", "helm-ignored", "--ignore-not-found"))
+		for _, ns := range helmNamespaces {
+			_, _ = utils.Run(exec.Command("kubectl", "delete", "ns", ns, "--ignore-not-found"))
+		}
+
+		By("uninstalling the chart")
+		_, _ = utils.Run(exec.Command("make", "helm-undeploy", "HELM_NAMESPACE="+helmNamespace))
+		_, _ = utils.Run(exec.Command("kubectl", "delete", "ns", helmNamespace, "--ignore-not-found"))
+	})
+
+	AfterEach(func() {
+		if CurrentSpecReport().Failed() {
+			cmd := exec.Command("kubectl", "logs", "-l", "control-plane=controller-manager", "-n", helmNamespace, "--tail=-1")
+			if logs, err := utils.Run(cmd); err == nil {
+				_, _ = fmt.Fprintf(GinkgoWriter, "Controller logs:\n %s", logs)
+			}
+		}
+	})
+
+	SetDefaultEventuallyTimeout(2 * time.Minute)
+	SetDefaultEventuallyPollingInterval(time.Second)
+
+	It("should run the configured number of controller-manager replicas", func() {
+		Eventually(func(g Gomega) {
+			cmd := exec.Command("kubectl", "get", "deployment", "foldertree-controller-manager",
+				"-n", helmNamespace, "-o", "jsonpath={.status.readyReplicas}")
+			output, err := utils.Run(cmd)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(output).To(Equal("2"))
+		}).Should(Succeed())
+	})
+
+	It("should provision the webhook certificate and inject its CA", func() {
+		Eventually(func(g Gomega) {
+			_, err := utils.Run(exec.Command("kubectl", "get", "secret", "webhook-server-cert", "-n", helmNamespace))
+			g.Expect(err).NotTo(HaveOccurred())
+		}).Should(Succeed())
+
+		for _, resource := range []string{
+			"mutatingwebhookconfigurations.admissionregistration.k8s.io/foldertree-mutating-webhook-configuration",
+			"validatingwebhookconfigurations.admissionregistration.k8s.io/foldertree-validating-webhook-configuration",
+		} {
+			Eventually(func(g Gomega) {
+				cmd := exec.Command("kubectl", "get", resource,
+					"-o", "go-template={{ range .webhooks }}{{ .failurePolicy }} {{ .clientConfig.caBundle }}{{ end }}")
+				output, err := utils.Run(cmd)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(output).To(HavePrefix("Fail "))
+				g.Expect(len(output)).To(BeNumerically(">", 15))
+			}).Should(Succeed())
+		}
+
+		Eventually(func(g Gomega) {
+			cmd := exec.Command("kubectl", "get", "crd", "foldertrees.rbac.kubevirt.io",
+				"-o", "jsonpath={.spec.conversion.webhook.clientConfig.caBundle}")
+			output, err := utils.Run(cmd)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(len(output)).To(BeNumerically(">", 10))
+		}).Should(Succeed())
+	})
+
+	It("should only reconcile FolderTrees matching the chart's tree selector", func() {
+		selectorLabel := strings.SplitN(helmTreeSelector, "=", 2)
+		folderTree := func(name, namespace, labels string) string {
+			return fmt.Sprintf(`
+apiVersion: rbac.kubevirt.io/v1alpha1
+kind: FolderTree
+metadata:
+  name: %s
+  labels: {%s}
+spec:
+  folders:
+  - name: apps
+    namespaces: ["%s"]
+    roleBindingTemplates:
+    - name: viewers
+      subjects:
+      - kind: Group
+        name: helm-viewers
+        apiGroup: rbac.authorization.k8s.io
+      roleRef:
+        kind: ClusterRole
+        name: view
+        apiGroup: rbac.authorization.k8s.io
+`, name, labels, namespace)
+		}
+
+		By("creating a selected and an unselected FolderTree through the chart's webhook")
+		cmd := exec.Command("kubectl", "apply", "-f", "-")
+		cmd.Stdin = strings.NewReader(folderTree("helm-selected", "ft-helm-selected",
+			fmt.Sprintf("%q: %q", selectorLabel[0], selectorLabel[1])) + "---" +
+			folderTree("helm-ignored", "ft-helm-ignored", ""))
+		output, err := utils.Run(cmd)
+		Expect(err).NotTo(HaveOccurred(), "Failed to create FolderTrees: %s", output)
+
+		By("verifying the selected FolderTree is reconciled")
+		Eventually(func(g Gomega) {
+			cmd := exec.Command("kubectl", "get", "rolebindings", "-n", "ft-helm-selected",
+				"-l", "foldertree.rbac.kubevirt.io/tree=helm-selected", "-o", "jsonpath={.items[*].metadata.name}")
+			output, err := utils.Run(cmd)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(output).To(ContainSubstring("viewers"))
+		}).Should(Succeed())
+
+		By("verifying the unselected FolderTree is left alone")
+		Consistently(func(g Gomega) {
+			cmd := exec.Command("kubectl", "get", "rolebindings", "-n", "ft-helm-ignored",
+				"-l", "foldertree.rbac.kubevirt.io/tree=helm-ignored", "-o", "jsonpath={.items[*].metadata.name}")
+			output, err := utils.Run(cmd)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(output).To(BeEmpty())
+		}, 15*time.Second).Should(Succeed())
+	})
+})
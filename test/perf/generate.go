@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package perf generates FolderTrees of configurable size for scale tests and benchmarks.
+package perf
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+)
+
+// Shape describes the size of a generated FolderTree
+type Shape struct {
+	// Depth is the number of levels of the tree, counting the root as level 1
+	Depth int
+
+	// Fanout is the number of subfolders of every folder above the last level
+	Fanout int
+
+	// Templates is the number of role binding templates of every folder
+	Templates int
+
+	// PropagatingTemplates is how many of the templates of every folder propagate to its descendants
+	PropagatingTemplates int
+
+	// NamespacesPerFolder is the number of namespaces listed by every folder
+	NamespacesPerFolder int
+}
+
+// String names the shape, e.g. for sub-benchmarks
+func (s Shape) String() string {
+	return fmt.Sprintf("depth%d-fanout%d-templates%d-propagating%d-namespaces%d",
+		s.Depth, s.Fanout, s.Templates, s.PropagatingTemplates, s.NamespacesPerFolder)
+}
+
+// Folders returns the number of folders of a FolderTree of this shape
+func (s Shape) Folders() int {
+	folders, level := 0, 1
+	for range s.Depth {
+		folders += level
+		level *= s.Fanout
+	}
+	return folders
+}
+
+// GenerateFolderTree returns a FolderTree of the given shape. Folders are named after their
+// position in the tree, e.g. root-0-1, and list namespaces named <tree>-<folder>-<index>, which
+// the caller creates when the FolderTree is used against an API server. Every template binds its
+// own Group to the view ClusterRole.
+func GenerateFolderTree(name string, shape Shape) *rbacv1alpha1.FolderTree {
+	folderTree := &rbacv1alpha1.FolderTree{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	root := generateNode(folderTree, "root", shape, 1)
+	folderTree.Spec.Tree = &root
+	return folderTree
+}
+
+// generateNode adds the folder of a tree node at the given level, and those of its subfolders,
+// to the FolderTree and returns the node
+func generateNode(folderTree *rbacv1alpha1.FolderTree, name string, shape Shape, level int) rbacv1alpha1.TreeNode {
+	folder := rbacv1alpha1.Folder{Name: name}
+	for i := range shape.Templates {
+		propagate := i < shape.PropagatingTemplates
+		folder.RoleBindingTemplates = append(folder.RoleBindingTemplates, rbacv1alpha1.RoleBindingTemplate{
+			Name:      fmt.Sprintf("%s-t%d", name, i),
+			Subjects:  []rbacv1.Subject{{Kind: "Group", Name: fmt.Sprintf("%s-%s-t%d", folderTree.Name, name, i), APIGroup: rbacv1.GroupName}},
+			RoleRef:   rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			Propagate: &propagate,
+		})
+	}
+	for i := range shape.NamespacesPerFolder {
+		folder.Namespaces = append(folder.Namespaces, fmt.Sprintf("%s-%s-%d", folderTree.Name, name, i))
+	}
+	folderTree.Spec.Folders = append(folderTree.Spec.Folders, folder)
+
+	node := rbacv1alpha1.TreeNode{Name: name}
+	if level < shape.Depth {
+		for i := range shape.Fanout {
+			node.Subfolders = append(node.Subfolders, generateNode(folderTree, fmt.Sprintf("%s-%d", name, i), shape, level+1))
+		}
+	}
+	return node
+}
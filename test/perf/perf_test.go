@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perf
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1alpha1 "kubevirt.io/folders/api/v1alpha1"
+	"kubevirt.io/folders/internal/controller"
+	"kubevirt.io/folders/pkg/rbac"
+)
+
+// apiShapes are benchmarked against envtest; they stay within the CRD limit of 100 folders
+var apiShapes = []Shape{
+	{Depth: 2, Fanout: 4, Templates: 2, PropagatingTemplates: 1, NamespacesPerFolder: 2},
+	{Depth: 3, Fanout: 4, Templates: 3, PropagatingTemplates: 1, NamespacesPerFolder: 1},
+	{Depth: 4, Fanout: 3, Templates: 2, PropagatingTemplates: 2, NamespacesPerFolder: 1},
+}
+
+// memoryShapes are only benchmarked in memory and may be larger
+var memoryShapes = append(apiShapes,
+	Shape{Depth: 5, Fanout: 4, Templates: 3, PropagatingTemplates: 2, NamespacesPerFolder: 2},
+)
+
+// k8sClient is connected to envtest, or nil when the benchmarks run without it
+var k8sClient client.Client
+
+// TestMain starts envtest for benchmarks that need an API server. It is only started when
+// benchmarks are run, so that `go test ./...` stays fast, and the benchmarks needing it are
+// skipped when the envtest binaries cannot be found (see `make setup-envtest`).
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if flag.Lookup("test.bench").Value.String() == "" {
+		os.Exit(m.Run())
+	}
+
+	if err := rbacv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to register the API: %v\n", err)
+		os.Exit(1)
+	}
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		BinaryAssetsDirectory: firstFoundEnvTestBinaryDir(),
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "envtest is not available, skipping the benchmarks that need it: %v\n", err)
+		os.Exit(m.Run())
+	}
+	if k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme}); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create a client: %v\n", err)
+		_ = testEnv.Stop()
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	_ = testEnv.Stop()
+	os.Exit(code)
+}
+
+// firstFoundEnvTestBinaryDir returns the first envtest binary directory installed by
+// `make setup-envtest`, or "" to rely on KUBEBUILDER_ASSETS
+func firstFoundEnvTestBinaryDir() string {
+	basePath := filepath.Join("..", "..", "bin", "k8s")
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			return filepath.Join(basePath, entry.Name())
+		}
+	}
+	return ""
+}
+
+// setupFolderTree creates a FolderTree of the given shape, its namespaces and its RoleBindings in
+// envtest, and removes the FolderTree and RoleBindings when the benchmark ends. envtest runs no
+// garbage collector and never finishes deleting namespaces, so namespaces are kept and reused.
+func setupFolderTree(b *testing.B, shape Shape) *rbacv1alpha1.FolderTree {
+	b.Helper()
+	if k8sClient == nil {
+		b.Skip("envtest is not available")
+	}
+	ctx := context.Background()
+
+	folderTree := GenerateFolderTree(fmt.Sprintf("perf-%d", shape.Folders()), shape)
+	namespaces := rbac.IndexFolderTreeNamespaces(folderTree)
+	for _, namespace := range namespaces {
+		if err := client.IgnoreAlreadyExists(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})); err != nil {
+			b.Fatalf("failed to create namespace %s: %v", namespace, err)
+		}
+	}
+	if err := k8sClient.Create(ctx, folderTree); err != nil {
+		b.Fatalf("failed to create FolderTree: %v", err)
+	}
+	b.Cleanup(func() {
+		_ = k8sClient.Delete(ctx, folderTree)
+		for _, namespace := range namespaces {
+			_ = k8sClient.DeleteAllOf(ctx, &rbacv1.RoleBinding{}, client.InNamespace(namespace),
+				client.MatchingLabels{"foldertree.rbac.kubevirt.io/tree": folderTree.Name})
+		}
+	})
+
+	if _, err := newReconciler().Reconcile(ctx, treeRequest(folderTree)); err != nil {
+		b.Fatalf("failed to reconcile FolderTree: %v", err)
+	}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(folderTree), folderTree); err != nil {
+		b.Fatalf("failed to get FolderTree: %v", err)
+	}
+	return folderTree
+}
+
+// newReconciler returns a reconciler without state from earlier reconciles
+func newReconciler() *controller.FolderTreeReconciler {
+	return &controller.FolderTreeReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+}
+
+// treeRequest returns the reconcile request of a FolderTree
+func treeRequest(folderTree *rbacv1alpha1.FolderTree) reconcile.Request {
+	return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(folderTree)}
+}
+
+func TestGenerateFolderTree(t *testing.T) {
+	shape := Shape{Depth: 3, Fanout: 2, Templates: 2, PropagatingTemplates: 1, NamespacesPerFolder: 2}
+	folderTree := GenerateFolderTree("perf", shape)
+
+	if got := len(folderTree.Spec.Folders); got != shape.Folders() || got != 7 {
+		t.Fatalf("expected 7 folders, got %d (shape reports %d)", got, shape.Folders())
+	}
+
+	desired, err := rbac.CalculateDesiredRoleBindings(folderTree, &rbac.RoleBindingBuilder{FolderTree: folderTree})
+	if err != nil {
+		t.Fatalf("failed to calculate RoleBindings: %v", err)
+	}
+	// Every namespace gets the 2 templates of its folder and the propagating template of each ancestor
+	want := 0
+	for level, folders := 1, 1; level <= shape.Depth; level, folders = level+1, folders*shape.Fanout {
+		want += folders * shape.NamespacesPerFolder * (shape.Templates + level - 1)
+	}
+	if got := len(desired.RoleBindings); got != want {
+		t.Fatalf("expected %d RoleBindings, got %d", want, got)
+	}
+}
+
+func BenchmarkCalculateDesiredRoleBindings(b *testing.B) {
+	for _, shape := range memoryShapes {
+		b.Run(shape.String(), func(b *testing.B) {
+			folderTree := GenerateFolderTree("perf", shape)
+			builder := &rbac.RoleBindingBuilder{FolderTree: folderTree}
+
+			var desired *rbac.DesiredRoleBindingSet
+			for b.Loop() {
+				var err error
+				if desired, err = rbac.CalculateDesiredRoleBindings(folderTree, builder); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(desired.RoleBindings)), "rolebindings")
+		})
+	}
+}
+
+// BenchmarkAnalyzeDiff measures the diff of a FolderTree whose RoleBindings are up to date, which
+// is what every full reconcile of an unchanged FolderTree pays
+func BenchmarkAnalyzeDiff(b *testing.B) {
+	for _, shape := range apiShapes {
+		b.Run(shape.String(), func(b *testing.B) {
+			folderTree := setupFolderTree(b, shape)
+			builder := &rbac.RoleBindingBuilder{FolderTree: folderTree, Scheme: k8sClient.Scheme()}
+			ctx := context.Background()
+
+			var operations []rbac.RoleBindingOperation
+			for b.Loop() {
+				var err error
+				if operations, err = rbac.NewDiffAnalyzer(k8sClient, folderTree, builder).AnalyzeDiff(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if len(operations) > 0 {
+				b.Fatalf("expected no operations for an up-to-date FolderTree, got %d", len(operations))
+			}
+		})
+	}
+}
+
+// BenchmarkReconcile measures reconciles of an unchanged FolderTree, both full ones and those the
+// fast path skips
+func BenchmarkReconcile(b *testing.B) {
+	for _, shape := range apiShapes {
+		b.Run(shape.String(), func(b *testing.B) {
+			folderTree := setupFolderTree(b, shape)
+			ctx := context.Background()
+
+			b.Run("full", func(b *testing.B) {
+				for b.Loop() {
+					// A new reconciler has no observed state to skip the diff with
+					if _, err := newReconciler().Reconcile(ctx, treeRequest(folderTree)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			b.Run("fast-path", func(b *testing.B) {
+				reconciler := newReconciler()
+				if _, err := reconciler.Reconcile(ctx, treeRequest(folderTree)); err != nil {
+					b.Fatal(err)
+				}
+				for b.Loop() {
+					if _, err := reconciler.Reconcile(ctx, treeRequest(folderTree)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}